@@ -7,10 +7,14 @@ import (
 	"microgame-bot/internal/core"
 	"microgame-bot/internal/core/bot"
 	"microgame-bot/internal/core/database"
+	"microgame-bot/internal/core/kv"
 	"microgame-bot/internal/core/logger"
+	"microgame-bot/internal/deadletter"
 	"microgame-bot/internal/domain"
+	"microgame-bot/internal/domain/trivia"
 	"microgame-bot/internal/domain/user"
 	"microgame-bot/internal/health"
+	"microgame-bot/internal/jobs"
 	"microgame-bot/internal/locker"
 	"microgame-bot/internal/mdw"
 	"microgame-bot/internal/queue"
@@ -25,12 +29,25 @@ import (
 	"microgame-bot/internal/handlers"
 	gormLocker "microgame-bot/internal/locker/gorm"
 	memoryLocker "microgame-bot/internal/locker/memory"
+	memoryMetastore "microgame-bot/internal/metastore/memory"
 	qHandlers "microgame-bot/internal/queue/handlers"
 	gormBetRepository "microgame-bot/internal/repo/bet"
 	gormClaimRepository "microgame-bot/internal/repo/claim"
+	gormBattleshipRepository "microgame-bot/internal/repo/game/battleship"
+	gormBlackjackRepository "microgame-bot/internal/repo/game/blackjack"
+	gormBullsCowsRepository "microgame-bot/internal/repo/game/bullscows"
+	gormCheckersRepository "microgame-bot/internal/repo/game/checkers"
+	gormDiceRepository "microgame-bot/internal/repo/game/dice"
+	gormHangmanRepository "microgame-bot/internal/repo/game/hangman"
+	gormReversiRepository "microgame-bot/internal/repo/game/reversi"
 	gormRPSRepository "microgame-bot/internal/repo/game/rps"
+	gormTriviaRepository "microgame-bot/internal/repo/game/trivia"
 	gormTTTRepository "microgame-bot/internal/repo/game/ttt"
+	gormHouseRepository "microgame-bot/internal/repo/house"
+	gormLeagueRepository "microgame-bot/internal/repo/league"
+	gormMatchmakingRepository "microgame-bot/internal/repo/matchmaking"
 	gormSessionRepository "microgame-bot/internal/repo/session"
+	gormTournamentRepository "microgame-bot/internal/repo/tournament"
 	gormUserRepository "microgame-bot/internal/repo/user"
 	uowGorm "microgame-bot/internal/uow"
 )
@@ -72,15 +89,66 @@ func startup() error {
 	}
 	slog.Info("Locker initialized successfully", "driver", cfg.App.LockerDriver)
 
+	// Short-lived per-user state of the handlers, e.g. the checkers piece selected by the first tap
+	metaStore := memoryMetastore.New("microgame")
+
+	// Trivia question packs are embedded, a broken pack must stop the bot rather than a game
+	triviaLibrary, err := trivia.LoadLibrary()
+	if err != nil {
+		return fmt.Errorf("failed to load trivia question packs: %w", err)
+	}
+
+	// Finished tasks are archived after a week, failed ones stay in the dead letters for a month
+	defaultRetention := queue.WithDefaultRetention(queue.RetentionPolicy{
+		Completed: 7 * 24 * time.Hour,
+		Failed:    30 * 24 * time.Hour,
+		Archive:   true,
+	})
+	var q queue.IQueue
+	if cfg.App.QueueDriver == "jetstream" {
+		natsConn, err := kv.MustInit(ctx, cfg.Nats)
+		if err != nil {
+			return fmt.Errorf("failed to connect to nats: %w", err)
+		}
+		defer natsConn.Close()
+		// Tasks published inside the units go through the outbox table, the relay moves them to the stream
+		q, err = queue.NewJetStream(ctx, natsConn, 10,
+			queue.WithReplicas(cfg.Nats.QueueReplicas),
+			queue.WithOutbox(db),
+			defaultRetention,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create task queue: %w", err)
+		}
+	} else {
+		// Published tasks wake the poller up through LISTEN/NOTIFY, the slow poll only catches delayed ones
+		q = queue.New(db, 10, queue.WithNotifications(cfg.Postgres.URL), defaultRetention)
+	}
+	slog.Info("Task queue initialized successfully", "driver", cfg.App.QueueDriver)
+	// Cron ticks and profile loads are worth nothing once done, they are dropped after a day
+	shortRetention := queue.WithRetention(queue.RetentionPolicy{
+		Completed: 24 * time.Hour,
+		Failed:    7 * 24 * time.Hour,
+	})
+
+	// Jobs are managed at runtime through the admin command and HTTP, the scheduler starts with the handlers
+	sc := scheduler.New(db, 10, q, 1*time.Second)
+
 	healthHandler := health.NewHandler(5 * time.Second)
 	healthHandler.RegisterChecker("database", health.NewDatabaseChecker(db))
-	healthHandler.RegisterChecker("queue", health.NewQueueChecker(db))
+	healthHandler.RegisterChecker("queue", health.NewQueueChecker(q))
 	healthHandler.RegisterChecker("scheduler", health.NewSchedulerChecker(db))
 	slog.Info("Health check initialized successfully")
 
-	bot, bh, webhookSrv, err := bot.MustInit(ctx, cfg, &bot.InitOptions{
+	initOptions := &bot.InitOptions{
 		HealthHandler: healthHandler,
-	})
+	}
+	if cfg.App.AdminToken != "" {
+		initOptions.DeadLetterHandler = deadletter.NewHandler(q, cfg.App.AdminToken)
+		initOptions.JobsHandler = jobs.NewHandler(sc, cfg.App.AdminToken)
+	}
+
+	bot, bh, webhookSrv, err := bot.MustInit(ctx, cfg, initOptions)
 	if err != nil {
 		return err
 	}
@@ -131,14 +199,33 @@ func startup() error {
 	userRepo := gormUserRepository.New(db)
 	tttRepo := gormTTTRepository.New(db)
 	rpsRepo := gormRPSRepository.New(db)
+	diceRepo := gormDiceRepository.New(db)
+	hangmanRepo := gormHangmanRepository.New(db)
+	triviaRepo := gormTriviaRepository.New(db)
+	reversiRepo := gormReversiRepository.New(db)
+	checkersRepo := gormCheckersRepository.New(db)
+	battleshipRepo := gormBattleshipRepository.New(db)
+	bullsCowsRepo := gormBullsCowsRepository.New(db)
+	blackjackRepo := gormBlackjackRepository.New(db)
+	houseRepo := gormHouseRepository.New(db)
 	sessionRepo := gormSessionRepository.New(db)
 	claimRepo := gormClaimRepository.New(db)
 	betRepo := gormBetRepository.New(db)
+	tournamentRepo := gormTournamentRepository.New(db)
+	leagueRepo := gormLeagueRepository.New(db)
+	ticketRepo := gormMatchmakingRepository.New(db)
+
+	// Quick play games live in private chats, game edits of the queue handlers go through the editor
+	quickPlayEditor := qHandlers.NewQuickPlayEditor(ticketRepo, bot)
 
-	q := queue.New(db, 10)
 	q.Register("queue.cleanup", func(ctx context.Context, _ []byte) error {
 		return q.CleanupStuckTasks(ctx)
-	})
+	}, shortRetention)
+	q.Register(scheduler.RunOutcomeSubject, sc.RunOutcomeHandler(), shortRetention)
+	q.Register(queue.RetentionSubject, func(ctx context.Context, _ []byte) error {
+		_, err := q.ApplyRetention(ctx)
+		return err
+	}, shortRetention)
 
 	// Register profile load handler
 	profileLoadUnit := uowGorm.New(db,
@@ -147,7 +234,9 @@ func startup() error {
 		uowGorm.WithRPSRepo(rpsRepo),
 		uowGorm.WithTTTRepo(tttRepo),
 	)
-	q.Register("profile.load", qHandlers.ProfileLoadHandler(profileLoadUnit, bot))
+	// Profile loads come in bursts from inline queries, the cap keeps them from taking every worker
+	q.Register("profile.load", qHandlers.ProfileLoadHandler(profileLoadUnit, bot),
+		queue.WithConcurrency(3), shortRetention)
 
 	// Register bet payout handler
 	betPayoutUnit := uowGorm.New(db,
@@ -156,8 +245,26 @@ func startup() error {
 		uowGorm.WithUserRepo(userRepo),
 		uowGorm.WithTTTRepo(tttRepo),
 		uowGorm.WithRPSRepo(rpsRepo),
+		uowGorm.WithTournamentRepo(tournamentRepo),
+		uowGorm.WithDiceRepo(diceRepo),
+		uowGorm.WithHangmanRepo(hangmanRepo),
+		uowGorm.WithTriviaRepo(triviaRepo),
+		uowGorm.WithReversiRepo(reversiRepo),
+		uowGorm.WithCheckersRepo(checkersRepo),
+		uowGorm.WithBattleshipRepo(battleshipRepo),
+		uowGorm.WithBullsCowsRepo(bullsCowsRepo),
+		uowGorm.WithBlackjackRepo(blackjackRepo),
+		uowGorm.WithHouseRepo(houseRepo),
+		uowGorm.WithOutbox(q),
+	)
+	// Payouts go first and one at a time, every run settles all the waiting bets
+	q.Register(
+		"bets.payout",
+		qHandlers.BetPayoutHandler(betPayoutUnit),
+		queue.WithWeight(10),
+		queue.WithConcurrency(1),
+		shortRetention,
 	)
-	q.Register("bets.payout", qHandlers.BetPayoutHandler(betPayoutUnit))
 
 	// Register game timeout handler
 	gameTimeoutUnit := uowGorm.New(db,
@@ -166,9 +273,63 @@ func startup() error {
 		uowGorm.WithUserRepo(userRepo),
 		uowGorm.WithTTTRepo(tttRepo),
 		uowGorm.WithRPSRepo(rpsRepo),
+		uowGorm.WithDiceRepo(diceRepo),
+		uowGorm.WithHangmanRepo(hangmanRepo),
+		uowGorm.WithTriviaRepo(triviaRepo),
+		uowGorm.WithReversiRepo(reversiRepo),
+		uowGorm.WithCheckersRepo(checkersRepo),
+		uowGorm.WithBattleshipRepo(battleshipRepo),
+		uowGorm.WithBullsCowsRepo(bullsCowsRepo),
+		uowGorm.WithBlackjackRepo(blackjackRepo),
+		uowGorm.WithOutbox(q),
+	)
+	q.Register("games.timeout", qHandlers.GameTimeoutHandler(gameTimeoutUnit), shortRetention)
+	q.Register(
+		queue.GameAFKSubject,
+		qHandlers.GameAFKHandler(gameTimeoutUnit, quickPlayEditor),
+		queue.WithWeight(5),
+	)
+	q.Register(queue.GameClockSubject, qHandlers.GameClockHandler(gameTimeoutUnit, q, quickPlayEditor))
+	q.Register(
+		queue.TriviaQuestionSubject,
+		qHandlers.TriviaQuestionHandler(gameTimeoutUnit, triviaLibrary, quickPlayEditor),
+	)
+	q.Register("locks.cleanup", qHandlers.LockCleanupHandler(userLocker, cfg.App.LockerTTL), shortRetention)
+
+	// Register tournament advance handler
+	tournamentAdvanceUnit := uowGorm.New(db,
+		uowGorm.WithBetRepo(betRepo),
+		uowGorm.WithSessionRepo(sessionRepo),
+		uowGorm.WithUserRepo(userRepo),
+		uowGorm.WithTTTRepo(tttRepo),
+		uowGorm.WithRPSRepo(rpsRepo),
+		uowGorm.WithTournamentRepo(tournamentRepo),
+		uowGorm.WithOutbox(q),
+	)
+	q.Register(queue.TournamentAdvanceSubject, qHandlers.TournamentAdvanceHandler(tournamentAdvanceUnit, bot))
+
+	// Register league handlers
+	leagueAdvanceUnit := uowGorm.New(db,
+		uowGorm.WithSessionRepo(sessionRepo),
+		uowGorm.WithUserRepo(userRepo),
+		uowGorm.WithTTTRepo(tttRepo),
+		uowGorm.WithRPSRepo(rpsRepo),
+		uowGorm.WithLeagueRepo(leagueRepo),
+	)
+	q.Register(queue.LeagueAdvanceSubject, qHandlers.LeagueAdvanceHandler(leagueAdvanceUnit, bot))
+	q.Register(queue.LeagueRemindSubject, qHandlers.LeagueRemindHandler(leagueAdvanceUnit, bot))
+
+	// Register quick play matcher
+	quickPlayMatchUnit := uowGorm.New(db,
+		uowGorm.WithBetRepo(betRepo),
+		uowGorm.WithSessionRepo(sessionRepo),
+		uowGorm.WithUserRepo(userRepo),
+		uowGorm.WithTTTRepo(tttRepo),
+		uowGorm.WithRPSRepo(rpsRepo),
+		uowGorm.WithMatchmakingRepo(ticketRepo),
+		uowGorm.WithOutbox(q),
 	)
-	q.Register("games.timeout", qHandlers.GameTimeoutHandler(gameTimeoutUnit, q))
-	q.Register("locks.cleanup", qHandlers.LockCleanupHandler(userLocker, cfg.App.LockerTTL))
+	q.Register(queue.QuickPlayMatchSubject, qHandlers.QuickPlayMatchHandler(quickPlayMatchUnit, bot))
 
 	defer func() { _ = q.Stop(ctx) }()
 	q.Start(ctx)
@@ -181,6 +342,13 @@ func startup() error {
 			Subject:    "queue.cleanup",
 			Payload:    queue.EmptyPayload,
 		},
+		{
+			Name:       "queue-retention",
+			Expression: "0 30 4 * * *",
+			Status:     scheduler.CronJobStatusActive,
+			Subject:    queue.RetentionSubject,
+			Payload:    queue.EmptyPayload,
+		},
 		{
 			Name:       "bets-payout",
 			Expression: "0 */5 * * * *",
@@ -202,8 +370,38 @@ func startup() error {
 			Subject:    "locks.cleanup",
 			Payload:    queue.EmptyPayload,
 		},
+		{
+			Name:       "tournaments-advance",
+			Expression: "*/30 * * * * *",
+			Status:     scheduler.CronJobStatusActive,
+			Subject:    queue.TournamentAdvanceSubject,
+			Payload:    queue.EmptyPayload,
+		},
+		{
+			Name:       "leagues-advance",
+			Expression: "*/30 * * * * *",
+			Status:     scheduler.CronJobStatusActive,
+			Subject:    queue.LeagueAdvanceSubject,
+			Payload:    queue.EmptyPayload,
+		},
+		{
+			Name:       "leagues-remind",
+			Expression: "0 0 12 * * *",
+			Status:     scheduler.CronJobStatusActive,
+			Subject:    queue.LeagueRemindSubject,
+			Payload:    queue.EmptyPayload,
+			// A reminder sent hours late would be confusing
+			Misfire: scheduler.MisfireSkip,
+		},
+		{
+			Name:       "quickplay-match",
+			Expression: "*/5 * * * * *",
+			Status:     scheduler.CronJobStatusActive,
+			Subject:    queue.QuickPlayMatchSubject,
+			Payload:    queue.EmptyPayload,
+			Misfire:    scheduler.MisfireSkip,
+		},
 	}
-	sc := scheduler.New(db, 10, q, 1*time.Second)
 	err = sc.CreateOrUpdateCronJobs(ctx, cronJobs)
 	if err != nil {
 		return fmt.Errorf("failed to create or update cron jobs: %w", err)
@@ -222,13 +420,14 @@ func startup() error {
 
 	bh.Use(
 		mdw.CorrelationIDProvider(),
+		mdw.QuickPlayProvider(ticketRepo),
 		mdw.InlineMsgProvider(inlineMsgLocker),
 		mdw.UserProvider(userLocker, userRepo),
 		mdw.DailyBonusMiddleware(dbmUow),
 	)
 
 	// Selector
-	bh.HandleInlineQuery(wrap.WrapInlineQuery(handlers.GameSelector(cfg.App)), th.AnyInlineQuery())
+	bh.HandleInlineQuery(wrap.WrapInlineQuery(handlers.GameSelector(cfg.App, triviaLibrary)), th.AnyInlineQuery())
 
 	// Profile handler
 	bh.HandleChosenInlineResult(
@@ -240,6 +439,7 @@ func startup() error {
 	rpsCreateUnit := uowGorm.New(db,
 		uowGorm.WithSessionRepo(sessionRepo),
 		uowGorm.WithRPSRepo(rpsRepo),
+		uowGorm.WithOutbox(q),
 	)
 	bh.HandleCallbackQuery(
 		wrap.WrapCallbackQuery(handlers.RPSCreate(rpsCreateUnit, cfg.App)),
@@ -253,25 +453,418 @@ func startup() error {
 		uowGorm.WithRPSRepo(rpsRepo),
 		uowGorm.WithUserRepo(userRepo),
 		uowGorm.WithBetRepo(betRepo),
+		uowGorm.WithOutbox(q),
 	)
 	rpsG.HandleCallbackQuery(
 		wrap.WrapCallbackQuery(handlers.RPSJoin(userRepo, rpsJoinUnit)),
 		th.CallbackDataPrefix("g::rps::join::"),
 	)
+	rpsCancelUnit := uowGorm.New(db,
+		uowGorm.WithSessionRepo(sessionRepo),
+		uowGorm.WithRPSRepo(rpsRepo),
+		uowGorm.WithBetRepo(betRepo),
+		uowGorm.WithOutbox(q),
+	)
+	rpsG.HandleCallbackQuery(
+		wrap.WrapCallbackQuery(handlers.RPSCancel(rpsCancelUnit)),
+		th.CallbackDataPrefix("g::rps::cancel::"),
+	)
 	rpsChoiceUnit := uowGorm.New(db,
 		uowGorm.WithSessionRepo(sessionRepo),
 		uowGorm.WithRPSRepo(rpsRepo),
 		uowGorm.WithBetRepo(betRepo),
+		uowGorm.WithOutbox(q),
 	)
 	rpsG.HandleCallbackQuery(
-		wrap.WrapCallbackQuery(handlers.RPSChoice(userRepo, rpsChoiceUnit, q)),
+		wrap.WrapCallbackQuery(handlers.RPSChoice(userRepo, rpsChoiceUnit)),
 		th.CallbackDataPrefix("g::rps::choice::"),
 	)
 
+	// DICE GAME HANDLERS
+	diceCreateUnit := uowGorm.New(db,
+		uowGorm.WithSessionRepo(sessionRepo),
+		uowGorm.WithDiceRepo(diceRepo),
+		uowGorm.WithOutbox(q),
+	)
+	bh.HandleCallbackQuery(
+		wrap.WrapCallbackQuery(handlers.DiceCreate(diceCreateUnit, cfg.App)),
+		th.CallbackDataPrefix("create::dice"),
+	)
+
+	diceG := bh.Group(th.CallbackDataPrefix("g::dice::"))
+
+	diceJoinUnit := uowGorm.New(db,
+		uowGorm.WithSessionRepo(sessionRepo),
+		uowGorm.WithDiceRepo(diceRepo),
+		uowGorm.WithUserRepo(userRepo),
+		uowGorm.WithBetRepo(betRepo),
+		uowGorm.WithOutbox(q),
+	)
+	diceG.HandleCallbackQuery(
+		wrap.WrapCallbackQuery(handlers.DiceJoin(userRepo, diceJoinUnit)),
+		th.CallbackDataPrefix("g::dice::join::"),
+	)
+	diceCancelUnit := uowGorm.New(db,
+		uowGorm.WithSessionRepo(sessionRepo),
+		uowGorm.WithDiceRepo(diceRepo),
+		uowGorm.WithBetRepo(betRepo),
+		uowGorm.WithOutbox(q),
+	)
+	diceG.HandleCallbackQuery(
+		wrap.WrapCallbackQuery(handlers.DiceCancel(diceCancelUnit)),
+		th.CallbackDataPrefix("g::dice::cancel::"),
+	)
+	diceThrowUnit := uowGorm.New(db,
+		uowGorm.WithSessionRepo(sessionRepo),
+		uowGorm.WithDiceRepo(diceRepo),
+		uowGorm.WithBetRepo(betRepo),
+		uowGorm.WithOutbox(q),
+	)
+	diceG.HandleCallbackQuery(
+		wrap.WrapCallbackQuery(handlers.DiceThrow(userRepo, diceThrowUnit)),
+		th.CallbackDataPrefix("g::dice::throw::"),
+	)
+
+	// HANGMAN GAME HANDLERS
+	hangmanCreateUnit := uowGorm.New(db,
+		uowGorm.WithSessionRepo(sessionRepo),
+		uowGorm.WithHangmanRepo(hangmanRepo),
+		uowGorm.WithOutbox(q),
+	)
+	bh.HandleCallbackQuery(
+		wrap.WrapCallbackQuery(handlers.HangmanCreate(hangmanCreateUnit, cfg.App)),
+		th.CallbackDataPrefix("create::hm"),
+	)
+
+	hangmanG := bh.Group(th.CallbackDataPrefix("g::hm::"))
+
+	hangmanJoinUnit := uowGorm.New(db,
+		uowGorm.WithSessionRepo(sessionRepo),
+		uowGorm.WithHangmanRepo(hangmanRepo),
+		uowGorm.WithUserRepo(userRepo),
+		uowGorm.WithBetRepo(betRepo),
+		uowGorm.WithOutbox(q),
+	)
+	hangmanG.HandleCallbackQuery(
+		wrap.WrapCallbackQuery(handlers.HangmanJoin(userRepo, hangmanJoinUnit)),
+		th.CallbackDataPrefix("g::hm::join::"),
+	)
+	hangmanCancelUnit := uowGorm.New(db,
+		uowGorm.WithSessionRepo(sessionRepo),
+		uowGorm.WithHangmanRepo(hangmanRepo),
+		uowGorm.WithBetRepo(betRepo),
+		uowGorm.WithOutbox(q),
+	)
+	hangmanG.HandleCallbackQuery(
+		wrap.WrapCallbackQuery(handlers.HangmanCancel(hangmanCancelUnit)),
+		th.CallbackDataPrefix("g::hm::cancel::"),
+	)
+	hangmanGuessUnit := uowGorm.New(db,
+		uowGorm.WithSessionRepo(sessionRepo),
+		uowGorm.WithHangmanRepo(hangmanRepo),
+		uowGorm.WithBetRepo(betRepo),
+		uowGorm.WithOutbox(q),
+	)
+	hangmanG.HandleCallbackQuery(
+		wrap.WrapCallbackQuery(handlers.HangmanGuess(userRepo, hangmanGuessUnit)),
+		th.CallbackDataPrefix("g::hm::guess::"),
+	)
+	hangmanG.HandleCallbackQuery(
+		wrap.WrapCallbackQuery(handlers.HangmanPage(hangmanGuessUnit)),
+		th.CallbackDataPrefix("g::hm::page::"),
+	)
+
+	// REVERSI GAME HANDLERS
+	reversiCreateUnit := uowGorm.New(db,
+		uowGorm.WithSessionRepo(sessionRepo),
+		uowGorm.WithReversiRepo(reversiRepo),
+		uowGorm.WithOutbox(q),
+	)
+	bh.HandleCallbackQuery(
+		wrap.WrapCallbackQuery(handlers.ReversiCreate(reversiCreateUnit, cfg.App)),
+		th.CallbackDataPrefix("create::rv"),
+	)
+
+	reversiG := bh.Group(th.CallbackDataPrefix("g::rv::"))
+
+	reversiJoinUnit := uowGorm.New(db,
+		uowGorm.WithSessionRepo(sessionRepo),
+		uowGorm.WithReversiRepo(reversiRepo),
+		uowGorm.WithUserRepo(userRepo),
+		uowGorm.WithBetRepo(betRepo),
+		uowGorm.WithOutbox(q),
+	)
+	reversiG.HandleCallbackQuery(
+		wrap.WrapCallbackQuery(handlers.ReversiJoin(userRepo, reversiJoinUnit)),
+		th.CallbackDataPrefix("g::rv::join::"),
+	)
+	reversiCancelUnit := uowGorm.New(db,
+		uowGorm.WithSessionRepo(sessionRepo),
+		uowGorm.WithReversiRepo(reversiRepo),
+		uowGorm.WithBetRepo(betRepo),
+		uowGorm.WithOutbox(q),
+	)
+	reversiG.HandleCallbackQuery(
+		wrap.WrapCallbackQuery(handlers.ReversiCancel(reversiCancelUnit)),
+		th.CallbackDataPrefix("g::rv::cancel::"),
+	)
+	reversiMoveUnit := uowGorm.New(db,
+		uowGorm.WithSessionRepo(sessionRepo),
+		uowGorm.WithReversiRepo(reversiRepo),
+		uowGorm.WithBetRepo(betRepo),
+		uowGorm.WithOutbox(q),
+	)
+	reversiG.HandleCallbackQuery(
+		wrap.WrapCallbackQuery(handlers.ReversiMove(userRepo, reversiMoveUnit)),
+		th.CallbackDataPrefix("g::rv::move::"),
+	)
+
+	// CHECKERS GAME HANDLERS
+	checkersCreateUnit := uowGorm.New(db,
+		uowGorm.WithSessionRepo(sessionRepo),
+		uowGorm.WithCheckersRepo(checkersRepo),
+		uowGorm.WithOutbox(q),
+	)
+	bh.HandleCallbackQuery(
+		wrap.WrapCallbackQuery(handlers.CheckersCreate(checkersCreateUnit, cfg.App)),
+		th.CallbackDataPrefix("create::ck"),
+	)
+
+	checkersG := bh.Group(th.CallbackDataPrefix("g::ck::"))
+
+	checkersJoinUnit := uowGorm.New(db,
+		uowGorm.WithSessionRepo(sessionRepo),
+		uowGorm.WithCheckersRepo(checkersRepo),
+		uowGorm.WithUserRepo(userRepo),
+		uowGorm.WithBetRepo(betRepo),
+		uowGorm.WithOutbox(q),
+	)
+	checkersG.HandleCallbackQuery(
+		wrap.WrapCallbackQuery(handlers.CheckersJoin(userRepo, checkersJoinUnit)),
+		th.CallbackDataPrefix("g::ck::join::"),
+	)
+	checkersCancelUnit := uowGorm.New(db,
+		uowGorm.WithSessionRepo(sessionRepo),
+		uowGorm.WithCheckersRepo(checkersRepo),
+		uowGorm.WithBetRepo(betRepo),
+		uowGorm.WithOutbox(q),
+	)
+	checkersG.HandleCallbackQuery(
+		wrap.WrapCallbackQuery(handlers.CheckersCancel(checkersCancelUnit)),
+		th.CallbackDataPrefix("g::ck::cancel::"),
+	)
+	checkersCellUnit := uowGorm.New(db,
+		uowGorm.WithSessionRepo(sessionRepo),
+		uowGorm.WithCheckersRepo(checkersRepo),
+		uowGorm.WithBetRepo(betRepo),
+		uowGorm.WithOutbox(q),
+	)
+	checkersG.HandleCallbackQuery(
+		wrap.WrapCallbackQuery(handlers.CheckersCell(userRepo, checkersCellUnit, metaStore)),
+		th.CallbackDataPrefix("g::ck::cell::"),
+	)
+
+	// TRIVIA GAME HANDLERS
+	triviaCreateUnit := uowGorm.New(db,
+		uowGorm.WithSessionRepo(sessionRepo),
+		uowGorm.WithTriviaRepo(triviaRepo),
+		uowGorm.WithOutbox(q),
+	)
+	bh.HandleCallbackQuery(
+		wrap.WrapCallbackQuery(handlers.TriviaCreate(triviaCreateUnit, cfg.App, triviaLibrary)),
+		th.CallbackDataPrefix("create::tv"),
+	)
+
+	triviaG := bh.Group(th.CallbackDataPrefix("g::tv::"))
+
+	triviaJoinUnit := uowGorm.New(db,
+		uowGorm.WithSessionRepo(sessionRepo),
+		uowGorm.WithTriviaRepo(triviaRepo),
+		uowGorm.WithUserRepo(userRepo),
+		uowGorm.WithBetRepo(betRepo),
+		uowGorm.WithOutbox(q),
+	)
+	triviaG.HandleCallbackQuery(
+		wrap.WrapCallbackQuery(handlers.TriviaJoin(userRepo, triviaJoinUnit, triviaLibrary)),
+		th.CallbackDataPrefix("g::tv::join::"),
+	)
+	triviaCancelUnit := uowGorm.New(db,
+		uowGorm.WithSessionRepo(sessionRepo),
+		uowGorm.WithTriviaRepo(triviaRepo),
+		uowGorm.WithBetRepo(betRepo),
+		uowGorm.WithOutbox(q),
+	)
+	triviaG.HandleCallbackQuery(
+		wrap.WrapCallbackQuery(handlers.TriviaCancel(triviaCancelUnit)),
+		th.CallbackDataPrefix("g::tv::cancel::"),
+	)
+	triviaAnswerUnit := uowGorm.New(db,
+		uowGorm.WithSessionRepo(sessionRepo),
+		uowGorm.WithTriviaRepo(triviaRepo),
+		uowGorm.WithUserRepo(userRepo),
+		uowGorm.WithBetRepo(betRepo),
+		uowGorm.WithOutbox(q),
+	)
+	triviaG.HandleCallbackQuery(
+		wrap.WrapCallbackQuery(handlers.TriviaAnswer(triviaAnswerUnit, triviaLibrary)),
+		th.CallbackDataPrefix("g::tv::answer::"),
+	)
+
+	// BATTLESHIP GAME HANDLERS
+	battleshipCreateUnit := uowGorm.New(db,
+		uowGorm.WithSessionRepo(sessionRepo),
+		uowGorm.WithBattleshipRepo(battleshipRepo),
+		uowGorm.WithOutbox(q),
+	)
+	bh.HandleCallbackQuery(
+		wrap.WrapCallbackQuery(handlers.BattleshipCreate(battleshipCreateUnit, cfg.App)),
+		th.CallbackDataPrefix("create::bs"),
+	)
+
+	battleshipG := bh.Group(th.CallbackDataPrefix("g::bs::"))
+
+	battleshipJoinUnit := uowGorm.New(db,
+		uowGorm.WithSessionRepo(sessionRepo),
+		uowGorm.WithBattleshipRepo(battleshipRepo),
+		uowGorm.WithUserRepo(userRepo),
+		uowGorm.WithBetRepo(betRepo),
+		uowGorm.WithOutbox(q),
+	)
+	battleshipG.HandleCallbackQuery(
+		wrap.WrapCallbackQuery(handlers.BattleshipJoin(userRepo, battleshipJoinUnit)),
+		th.CallbackDataPrefix("g::bs::join::"),
+	)
+	battleshipCancelUnit := uowGorm.New(db,
+		uowGorm.WithSessionRepo(sessionRepo),
+		uowGorm.WithBattleshipRepo(battleshipRepo),
+		uowGorm.WithBetRepo(betRepo),
+		uowGorm.WithOutbox(q),
+	)
+	battleshipG.HandleCallbackQuery(
+		wrap.WrapCallbackQuery(handlers.BattleshipCancel(battleshipCancelUnit)),
+		th.CallbackDataPrefix("g::bs::cancel::"),
+	)
+	battleshipPlayUnit := uowGorm.New(db,
+		uowGorm.WithSessionRepo(sessionRepo),
+		uowGorm.WithBattleshipRepo(battleshipRepo),
+		uowGorm.WithBetRepo(betRepo),
+		uowGorm.WithOutbox(q),
+	)
+	battleshipG.HandleCallbackQuery(
+		wrap.WrapCallbackQuery(handlers.BattleshipSetup(battleshipPlayUnit)),
+		th.CallbackDataPrefix("g::bs::setup::"),
+	)
+	// Placement keyboard lives in the private chat of the player
+	battleshipG.HandleCallbackQuery(
+		wrap.WrapCallbackQuery(handlers.BattleshipPlace(userRepo, battleshipPlayUnit)),
+		th.Or(
+			th.CallbackDataPrefix("g::bs::place::"),
+			th.CallbackDataPrefix("g::bs::orient::"),
+			th.CallbackDataPrefix("g::bs::random::"),
+			th.CallbackDataPrefix("g::bs::reset::"),
+			th.CallbackDataPrefix("g::bs::ready::"),
+		),
+	)
+	battleshipG.HandleCallbackQuery(
+		wrap.WrapCallbackQuery(handlers.BattleshipShot(userRepo, battleshipPlayUnit)),
+		th.CallbackDataPrefix("g::bs::shot::"),
+	)
+
+	// BULLS AND COWS GAME HANDLERS
+	bullsCowsCreateUnit := uowGorm.New(db,
+		uowGorm.WithSessionRepo(sessionRepo),
+		uowGorm.WithBullsCowsRepo(bullsCowsRepo),
+		uowGorm.WithOutbox(q),
+	)
+	bh.HandleCallbackQuery(
+		wrap.WrapCallbackQuery(handlers.BullsCowsCreate(bullsCowsCreateUnit, cfg.App)),
+		th.CallbackDataPrefix("create::bc"),
+	)
+
+	bullsCowsG := bh.Group(th.CallbackDataPrefix("g::bc::"))
+
+	bullsCowsJoinUnit := uowGorm.New(db,
+		uowGorm.WithSessionRepo(sessionRepo),
+		uowGorm.WithBullsCowsRepo(bullsCowsRepo),
+		uowGorm.WithUserRepo(userRepo),
+		uowGorm.WithBetRepo(betRepo),
+		uowGorm.WithOutbox(q),
+	)
+	bullsCowsG.HandleCallbackQuery(
+		wrap.WrapCallbackQuery(handlers.BullsCowsJoin(userRepo, bullsCowsJoinUnit)),
+		th.CallbackDataPrefix("g::bc::join::"),
+	)
+	bullsCowsCancelUnit := uowGorm.New(db,
+		uowGorm.WithSessionRepo(sessionRepo),
+		uowGorm.WithBullsCowsRepo(bullsCowsRepo),
+		uowGorm.WithBetRepo(betRepo),
+		uowGorm.WithOutbox(q),
+	)
+	bullsCowsG.HandleCallbackQuery(
+		wrap.WrapCallbackQuery(handlers.BullsCowsCancel(bullsCowsCancelUnit)),
+		th.CallbackDataPrefix("g::bc::cancel::"),
+	)
+	bullsCowsPlayUnit := uowGorm.New(db,
+		uowGorm.WithSessionRepo(sessionRepo),
+		uowGorm.WithBullsCowsRepo(bullsCowsRepo),
+		uowGorm.WithBetRepo(betRepo),
+		uowGorm.WithOutbox(q),
+	)
+	bullsCowsG.HandleCallbackQuery(
+		wrap.WrapCallbackQuery(handlers.BullsCowsSetup(bullsCowsPlayUnit)),
+		th.CallbackDataPrefix("g::bc::setup::"),
+	)
+	// Secret keypad lives in the private chat of the player
+	bullsCowsG.HandleCallbackQuery(
+		wrap.WrapCallbackQuery(handlers.BullsCowsSecret(userRepo, bullsCowsPlayUnit)),
+		th.Or(
+			th.CallbackDataPrefix("g::bc::sdigit::"),
+			th.CallbackDataPrefix("g::bc::serase::"),
+			th.CallbackDataPrefix("g::bc::srandom::"),
+			th.CallbackDataPrefix("g::bc::ssubmit::"),
+		),
+	)
+	bullsCowsG.HandleCallbackQuery(
+		wrap.WrapCallbackQuery(handlers.BullsCowsGuess(userRepo, bullsCowsPlayUnit)),
+		th.Or(
+			th.CallbackDataPrefix("g::bc::digit::"),
+			th.CallbackDataPrefix("g::bc::erase::"),
+			th.CallbackDataPrefix("g::bc::submit::"),
+		),
+	)
+
+	// BLACKJACK GAME HANDLERS
+	blackjackCreateUnit := uowGorm.New(db,
+		uowGorm.WithSessionRepo(sessionRepo),
+		uowGorm.WithBlackjackRepo(blackjackRepo),
+		uowGorm.WithUserRepo(userRepo),
+		uowGorm.WithBetRepo(betRepo),
+		uowGorm.WithOutbox(q),
+	)
+	bh.HandleCallbackQuery(
+		wrap.WrapCallbackQuery(handlers.BlackjackCreate(blackjackCreateUnit)),
+		th.CallbackDataPrefix("create::bj"),
+	)
+
+	blackjackG := bh.Group(th.CallbackDataPrefix("g::bj::"))
+
+	blackjackPlayUnit := uowGorm.New(db,
+		uowGorm.WithSessionRepo(sessionRepo),
+		uowGorm.WithBlackjackRepo(blackjackRepo),
+		uowGorm.WithUserRepo(userRepo),
+		uowGorm.WithBetRepo(betRepo),
+		uowGorm.WithOutbox(q),
+	)
+	blackjackG.HandleCallbackQuery(
+		wrap.WrapCallbackQuery(handlers.BlackjackPlay(blackjackPlayUnit)),
+		th.CallbackDataPrefix("g::bj::"),
+	)
+
 	// TTT GAME HANDLERS
 	tttCreateUnit := uowGorm.New(db,
 		uowGorm.WithSessionRepo(sessionRepo),
 		uowGorm.WithTTTRepo(tttRepo),
+		uowGorm.WithOutbox(q),
 	)
 	bh.HandleCallbackQuery(
 		wrap.WrapCallbackQuery(handlers.TTTCreate(tttCreateUnit, cfg.App)),
@@ -285,27 +878,162 @@ func startup() error {
 		uowGorm.WithTTTRepo(tttRepo),
 		uowGorm.WithUserRepo(userRepo),
 		uowGorm.WithBetRepo(betRepo),
+		uowGorm.WithOutbox(q),
 	)
 	tttG.HandleCallbackQuery(
 		wrap.WrapCallbackQuery(handlers.TTTJoin(userRepo, tttJoinUnit)),
 		th.CallbackDataPrefix("g::ttt::join::"),
 	)
 
+	tttCancelUnit := uowGorm.New(db,
+		uowGorm.WithSessionRepo(sessionRepo),
+		uowGorm.WithTTTRepo(tttRepo),
+		uowGorm.WithBetRepo(betRepo),
+		uowGorm.WithOutbox(q),
+	)
+	tttG.HandleCallbackQuery(
+		wrap.WrapCallbackQuery(handlers.TTTCancel(tttCancelUnit)),
+		th.CallbackDataPrefix("g::ttt::cancel::"),
+	)
+
 	tttMoveUnit := uowGorm.New(db,
 		uowGorm.WithSessionRepo(sessionRepo),
 		uowGorm.WithTTTRepo(tttRepo),
 		uowGorm.WithBetRepo(betRepo),
+		uowGorm.WithOutbox(q),
 	)
 	tttG.HandleCallbackQuery(
-		wrap.WrapCallbackQuery(handlers.TTTMove(userRepo, tttMoveUnit, q)),
+		wrap.WrapCallbackQuery(handlers.TTTMove(userRepo, tttMoveUnit)),
 		th.CallbackDataPrefix("g::ttt::move::"),
 	)
 
 	tttG.HandleCallbackQuery(
-		wrap.WrapCallbackQuery(handlers.TTTRebuild(userRepo, tttRepo)),
+		wrap.WrapCallbackQuery(handlers.TTTRebuild(userRepo, tttRepo, sessionRepo)),
 		th.CallbackDataPrefix("g::ttt::rebuild::"),
 	)
 
+	tttG.HandleCallbackQuery(
+		wrap.WrapCallbackQuery(handlers.TTTReplay(tttRepo)),
+		th.CallbackDataPrefix("g::ttt::replay::"),
+	)
+
+	tttG.HandleCallbackQuery(
+		wrap.WrapCallbackQuery(handlers.TTTNotation(userRepo, tttRepo)),
+		th.CallbackDataPrefix("g::ttt::notation::"),
+	)
+
+	// TOURNAMENT HANDLERS
+	tournamentCreateUnit := uowGorm.New(db,
+		uowGorm.WithSessionRepo(sessionRepo),
+		uowGorm.WithTournamentRepo(tournamentRepo),
+	)
+	bh.HandleCallbackQuery(
+		wrap.WrapCallbackQuery(handlers.TournamentCreate(userRepo, tournamentCreateUnit, cfg.App)),
+		th.CallbackDataPrefix("create::tour"),
+	)
+
+	tourG := bh.Group(th.CallbackDataPrefix("g::tour::"))
+
+	tournamentJoinUnit := uowGorm.New(db,
+		uowGorm.WithTournamentRepo(tournamentRepo),
+		uowGorm.WithSessionRepo(sessionRepo),
+		uowGorm.WithUserRepo(userRepo),
+		uowGorm.WithBetRepo(betRepo),
+		uowGorm.WithOutbox(q),
+	)
+	tourG.HandleCallbackQuery(
+		wrap.WrapCallbackQuery(handlers.TournamentJoin(userRepo, tournamentJoinUnit)),
+		th.CallbackDataPrefix("g::tour::join::"),
+	)
+
+	tournamentCancelUnit := uowGorm.New(db,
+		uowGorm.WithTournamentRepo(tournamentRepo),
+		uowGorm.WithSessionRepo(sessionRepo),
+		uowGorm.WithBetRepo(betRepo),
+		uowGorm.WithOutbox(q),
+	)
+	tourG.HandleCallbackQuery(
+		wrap.WrapCallbackQuery(handlers.TournamentCancel(userRepo, tournamentCancelUnit)),
+		th.CallbackDataPrefix("g::tour::cancel::"),
+	)
+
+	tournamentPlayUnit := uowGorm.New(db,
+		uowGorm.WithTournamentRepo(tournamentRepo),
+		uowGorm.WithSessionRepo(sessionRepo),
+		uowGorm.WithTTTRepo(tttRepo),
+		uowGorm.WithRPSRepo(rpsRepo),
+		uowGorm.WithOutbox(q),
+	)
+	tourG.HandleCallbackQuery(
+		wrap.WrapCallbackQuery(handlers.TournamentMatchStart(userRepo, tournamentPlayUnit)),
+		th.CallbackDataPrefix("g::tour::play::"),
+	)
+
+	// LEAGUE HANDLERS
+	leagueCreateUnit := uowGorm.New(db,
+		uowGorm.WithLeagueRepo(leagueRepo),
+	)
+	bh.HandleCallbackQuery(
+		wrap.WrapCallbackQuery(handlers.LeagueCreate(userRepo, leagueCreateUnit, cfg.App)),
+		th.CallbackDataPrefix("create::league"),
+	)
+
+	leagueG := bh.Group(th.CallbackDataPrefix("g::league::"))
+
+	leagueUnit := uowGorm.New(db,
+		uowGorm.WithLeagueRepo(leagueRepo),
+	)
+	leagueG.HandleCallbackQuery(
+		wrap.WrapCallbackQuery(handlers.LeagueJoin(userRepo, leagueUnit)),
+		th.CallbackDataPrefix("g::league::join::"),
+	)
+	leagueG.HandleCallbackQuery(
+		wrap.WrapCallbackQuery(handlers.LeagueStart(userRepo, leagueUnit)),
+		th.CallbackDataPrefix("g::league::start::"),
+	)
+	leagueG.HandleCallbackQuery(
+		wrap.WrapCallbackQuery(handlers.LeagueCancel(userRepo, leagueUnit)),
+		th.CallbackDataPrefix("g::league::cancel::"),
+	)
+
+	leaguePlayUnit := uowGorm.New(db,
+		uowGorm.WithLeagueRepo(leagueRepo),
+		uowGorm.WithSessionRepo(sessionRepo),
+		uowGorm.WithTTTRepo(tttRepo),
+		uowGorm.WithRPSRepo(rpsRepo),
+		uowGorm.WithOutbox(q),
+	)
+	leagueG.HandleCallbackQuery(
+		wrap.WrapCallbackQuery(handlers.LeagueFixtureStart(userRepo, leaguePlayUnit)),
+		th.CallbackDataPrefix("g::league::play::"),
+	)
+
+	// QUICK PLAY HANDLERS
+	bh.HandleMessage(wrap.WrapMessage(handlers.QuickPlayMenu()), th.CommandEqual(handlers.QuickPlayCommand))
+
+	quickPlayUnit := uowGorm.New(db,
+		uowGorm.WithMatchmakingRepo(ticketRepo),
+		uowGorm.WithUserRepo(userRepo),
+	)
+	bh.HandleCallbackQuery(
+		wrap.WrapCallbackQuery(handlers.QuickPlayJoin(quickPlayUnit)),
+		th.CallbackDataPrefix("create::quick"),
+	)
+	bh.HandleCallbackQuery(
+		wrap.WrapCallbackQuery(handlers.QuickPlayLeave(quickPlayUnit)),
+		th.CallbackDataPrefix("g::quick::leave::"),
+	)
+
+	// ADMIN HANDLERS
+	bh.HandleMessage(
+		wrap.WrapMessage(handlers.DeadLetter(q, cfg.Telegram.AdminIDs)),
+		th.CommandEqual(handlers.DeadLetterCommand),
+	)
+	bh.HandleMessage(
+		wrap.WrapMessage(handlers.CronJobs(sc, cfg.Telegram.AdminIDs)),
+		th.CommandEqual(handlers.CronJobsCommand),
+	)
+
 	// Empty callback handler
 	bh.HandleCallbackQuery(wrap.WrapCallbackQuery(handlers.Empty()), th.CallbackDataEqual("empty"))
 
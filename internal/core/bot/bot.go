@@ -6,6 +6,8 @@ import (
 	"log/slog"
 	"microgame-bot/internal/core"
 	"microgame-bot/internal/core/logger"
+	"microgame-bot/internal/deadletter"
+	"microgame-bot/internal/jobs"
 	"net/http"
 
 	th "github.com/mymmrac/telego/telegohandler"
@@ -15,6 +17,10 @@ import (
 
 type InitOptions struct {
 	HealthHandler http.Handler
+	// DeadLetterHandler serves the dead-letter API next to the health check, nil disables it.
+	DeadLetterHandler http.Handler
+	// JobsHandler serves the scheduler API next to the health check, nil disables it.
+	JobsHandler http.Handler
 }
 
 func MustInit(ctx context.Context, cfg core.Config, opts *InitOptions) (*telego.Bot, *th.BotHandler, *http.Server, error) {
@@ -33,7 +39,7 @@ func MustInit(ctx context.Context, cfg core.Config, opts *InitOptions) (*telego.
 		}
 	} else {
 		if opts != nil && opts.HealthHandler != nil {
-			srv = setupHealthServer(cfg, opts.HealthHandler)
+			srv = setupHealthServer(cfg, opts)
 			slog.Info("Health check endpoint registered", "path", "/health")
 		}
 
@@ -97,6 +103,10 @@ func setupWebhook(ctx context.Context, bot *telego.Bot, cfg core.Config, opts *I
 		mux.Handle("/health", opts.HealthHandler)
 		slog.InfoContext(ctx, "Health check endpoint registered", "path", "/health")
 	}
+	if opts != nil {
+		registerAdmin(mux, deadletter.Path, opts.DeadLetterHandler)
+		registerAdmin(mux, jobs.Path, opts.JobsHandler)
+	}
 
 	srv := &http.Server{
 		Addr:    cfg.Telegram.WebhookAddr,
@@ -116,12 +126,24 @@ func setupWebhook(ctx context.Context, bot *telego.Bot, cfg core.Config, opts *I
 	return updates, srv, nil
 }
 
-func setupHealthServer(cfg core.Config, healthHandler http.Handler) *http.Server {
+func setupHealthServer(cfg core.Config, opts *InitOptions) *http.Server {
 	mux := http.NewServeMux()
-	mux.Handle("/health", healthHandler)
+	mux.Handle("/health", opts.HealthHandler)
+	registerAdmin(mux, deadletter.Path, opts.DeadLetterHandler)
+	registerAdmin(mux, jobs.Path, opts.JobsHandler)
 
 	return &http.Server{
 		Addr:    cfg.Telegram.WebhookAddr,
 		Handler: mux,
 	}
 }
+
+// registerAdmin serves the admin API under the path, nil handler is skipped.
+func registerAdmin(mux *http.ServeMux, path string, handler http.Handler) {
+	if handler == nil {
+		return
+	}
+	mux.Handle(path, handler)
+	mux.Handle(path+"/", handler)
+	slog.Info("Admin endpoint registered", "path", path)
+}
@@ -13,7 +13,7 @@ type TelegramToken string
 
 type Config struct {
 	Postgres PostgresConfig `env-prefix:"POSTGRES__"`
-	// Nats     NatsConfig     `env-prefix:"NATS__"`
+	Nats     NatsConfig     `env-prefix:"NATS__"`
 	App      AppConfig      `env-prefix:"APP__"`
 	Logs     LogsConfig     `env-prefix:"LOGS__"`
 	Telegram TelegramConfig `env-prefix:"TELEGRAM__"`
@@ -41,15 +41,21 @@ type TelegramConfig struct {
 
 type AppConfig struct {
 	LockerDriver string        `env:"LOCKER_DRIVER"  env-default:"memory" validate:"oneof=gorm memory"`
+	QueueDriver  string        `env:"QUEUE_DRIVER"   env-default:"gorm"   validate:"oneof=gorm jetstream"`
 	MaxGameCount int           `env:"MAX_GAME_COUNT" env-default:"10"     validate:"min=1"`
 	LockerTTL    time.Duration `env:"LOCKER_TLL"     env-default:"720h"   validate:"required"`
+	JoinTimeout  time.Duration `env:"JOIN_TIMEOUT"   env-default:"5m"     validate:"min=0"`
+	// AdminToken guards the HTTP admin endpoints, they are off while it is empty.
+	AdminToken string `env:"ADMIN_TOKEN"`
 }
 
+// NatsConfig is only used by the jetstream queue driver, the nkeys are required by it (see validateNats).
 type NatsConfig struct {
 	URL               string `env:"URL"                env-default:"nats://nats:4222" validate:"required"`
-	NKeySeed          string `env:"NKEY_SEED"                                         validate:"required"`
-	NKeyPublic        string `env:"NKEY_PUBLIC"                                       validate:"required"`
+	NKeySeed          string `env:"NKEY_SEED"`
+	NKeyPublic        string `env:"NKEY_PUBLIC"`
 	MetastoreReplicas int    `env:"METASTORE_REPLICAS" env-default:"1"                validate:"min=1,max=5"`
+	QueueReplicas     int    `env:"QUEUE_REPLICAS"     env-default:"1"                validate:"min=1,max=5"`
 }
 
 func LoadConfig() (Config, error) {
@@ -60,9 +66,24 @@ func LoadConfig() (Config, error) {
 	}
 
 	validate := validator.New()
+	validate.RegisterStructValidation(validateNats, Config{})
 	if err := validate.Struct(cfg); err != nil {
 		return Config{}, fmt.Errorf("invalid config: %w", err)
 	}
 
 	return cfg, nil
 }
+
+// validateNats requires the nkeys only with the jetstream queue driver, the gorm one doesn't connect to NATS.
+func validateNats(sl validator.StructLevel) {
+	cfg, _ := sl.Current().Interface().(Config)
+	if cfg.App.QueueDriver != "jetstream" {
+		return
+	}
+	if cfg.Nats.NKeySeed == "" {
+		sl.ReportError(cfg.Nats.NKeySeed, "Nats.NKeySeed", "NKeySeed", "required", "")
+	}
+	if cfg.Nats.NKeyPublic == "" {
+		sl.ReportError(cfg.Nats.NKeyPublic, "Nats.NKeyPublic", "NKeyPublic", "required", "")
+	}
+}
@@ -9,7 +9,12 @@ import (
 	gormBetRepository "microgame-bot/internal/repo/bet"
 	gormClaimRepository "microgame-bot/internal/repo/claim"
 	gormGameRepository "microgame-bot/internal/repo/game"
+	gormRPSRepository "microgame-bot/internal/repo/game/rps"
+	gormHouseRepository "microgame-bot/internal/repo/house"
+	gormLeagueRepository "microgame-bot/internal/repo/league"
+	gormMatchmakingRepository "microgame-bot/internal/repo/matchmaking"
 	gormSessionRepository "microgame-bot/internal/repo/session"
+	gormTournamentRepository "microgame-bot/internal/repo/tournament"
 	gormUserRepository "microgame-bot/internal/repo/user"
 
 	gormLogger "gorm.io/gorm/logger"
@@ -40,6 +45,10 @@ func MustInit(cfg core.Config) (*gorm.DB, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to migrate game table in %s: %w", operationName, err)
 	}
+	err = db.AutoMigrate(&gormRPSRepository.Salt{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to migrate RPS salt table in %s: %w", operationName, err)
+	}
 	err = db.AutoMigrate(&gormClaimRepository.Claim{})
 	if err != nil {
 		return nil, fmt.Errorf("failed to migrate claim table in %s: %w", operationName, err)
@@ -48,13 +57,49 @@ func MustInit(cfg core.Config) (*gorm.DB, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to migrate cron job table in %s: %w", operationName, err)
 	}
+	err = db.AutoMigrate(&scheduler.CronJobRun{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to migrate cron job run table in %s: %w", operationName, err)
+	}
 	err = db.AutoMigrate(&queue.Task{})
 	if err != nil {
 		return nil, fmt.Errorf("failed to migrate task table in %s: %w", operationName, err)
 	}
+	err = queue.DropStaleIndexes(db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to drop stale task indexes in %s: %w", operationName, err)
+	}
+	err = db.AutoMigrate(&queue.OutboxTask{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to migrate task outbox table in %s: %w", operationName, err)
+	}
+	err = db.AutoMigrate(&queue.TaskGroup{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to migrate task group table in %s: %w", operationName, err)
+	}
+	err = queue.MigrateArchive(db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to migrate task archive table in %s: %w", operationName, err)
+	}
 	err = db.AutoMigrate(&gormBetRepository.Bet{})
 	if err != nil {
 		return nil, fmt.Errorf("failed to migrate bet table in %s: %w", operationName, err)
 	}
+	err = db.AutoMigrate(&gormTournamentRepository.Tournament{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to migrate tournament table in %s: %w", operationName, err)
+	}
+	err = db.AutoMigrate(&gormLeagueRepository.League{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to migrate league table in %s: %w", operationName, err)
+	}
+	err = db.AutoMigrate(&gormMatchmakingRepository.Ticket{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to migrate matchmaking ticket table in %s: %w", operationName, err)
+	}
+	err = db.AutoMigrate(&gormHouseRepository.Account{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to migrate house account table in %s: %w", operationName, err)
+	}
 	return db, nil
 }
@@ -8,4 +8,6 @@ const (
 	ContextKeyGame            = ContextKey("game")
 	ContextKeyGameSession     = ContextKey("game_session")
 	ContextKeyInlineMessageID = ContextKey("inline_message_id")
+	// ContextKeyQuickPlayMessages holds the private chat messages mirroring a quick play game.
+	ContextKeyQuickPlayMessages = ContextKey("quick_play_messages")
 )
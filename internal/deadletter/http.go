@@ -0,0 +1,191 @@
+package deadletter
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"microgame-bot/internal/core/logger"
+	"microgame-bot/internal/queue"
+	"microgame-bot/internal/utils"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Path is the prefix of the dead-letter endpoints, they are served next to /health.
+const Path = "/deadletter"
+
+// TaskView is a failed task as the endpoints return it.
+type TaskView struct {
+	CreatedAt   time.Time           `json:"created_at"`
+	UpdatedAt   time.Time           `json:"updated_at"`
+	ID          string              `json:"id"`
+	Subject     string              `json:"subject"`
+	LastError   string              `json:"last_error"`
+	Payload     json.RawMessage     `json:"payload,omitempty"`
+	Errors      []queue.TaskAttempt `json:"errors,omitempty"`
+	Attempts    int                 `json:"attempts"`
+	MaxAttempts int                 `json:"max_attempts"`
+}
+
+// NewTaskView converts the task, the payload and the error history are only shown in details.
+func NewTaskView(task queue.Task, details bool) TaskView {
+	view := TaskView{
+		ID:          task.ID.String(),
+		Subject:     task.Subject,
+		LastError:   task.LastError,
+		Attempts:    task.Attempts,
+		MaxAttempts: task.MaxAttempts,
+		CreatedAt:   task.CreatedAt,
+		UpdatedAt:   task.UpdatedAt,
+	}
+	if details {
+		view.Payload = task.Payload
+		view.Errors = task.Errors
+	}
+	return view
+}
+
+type Handler struct {
+	dl    queue.IDeadLetter
+	mux   *http.ServeMux
+	token string
+}
+
+// NewHandler serves the dead-letter API, every request must carry the token as a bearer:
+//
+//	GET  /deadletter?subject=&since=&until=&limit=  lists the failed tasks
+//	GET  /deadletter/{id}                           shows the payload and the error history
+//	POST /deadletter/requeue?id=&subject=&since=    requeues the tasks with reset attempts
+//	POST /deadletter/discard?id=&subject=&since=    deletes the tasks
+//
+// Times are RFC 3339, id may be repeated.
+func NewHandler(dl queue.IDeadLetter, token string) *Handler {
+	h := &Handler{dl: dl, token: token, mux: http.NewServeMux()}
+	h.mux.HandleFunc("GET "+Path, h.list)
+	h.mux.HandleFunc("GET "+Path+"/{id}", h.show)
+	h.mux.HandleFunc("POST "+Path+"/requeue", h.requeue)
+	h.mux.HandleFunc("POST "+Path+"/discard", h.discard)
+	return h
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	auth := []byte(r.Header.Get("Authorization"))
+	if h.token == "" || subtle.ConstantTimeCompare(auth, []byte("Bearer "+h.token)) != 1 {
+		writeError(w, http.StatusUnauthorized, errors.New("unauthorized"))
+		return
+	}
+	h.mux.ServeHTTP(w, r)
+}
+
+func (h *Handler) list(w http.ResponseWriter, r *http.Request) {
+	filter, err := filterFromQuery(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	tasks, err := h.dl.FailedTasks(r.Context(), filter)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	views := make([]TaskView, 0, len(tasks))
+	for _, task := range tasks {
+		views = append(views, NewTaskView(task, false))
+	}
+	writeJSON(w, http.StatusOK, views)
+}
+
+func (h *Handler) show(w http.ResponseWriter, r *http.Request) {
+	id, err := utils.UUIDFromString[utils.UniqueID](r.PathValue("id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	task, err := h.dl.FailedTask(r.Context(), id)
+	if errors.Is(err, queue.ErrTaskNotFound) {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, NewTaskView(task, true))
+}
+
+func (h *Handler) requeue(w http.ResponseWriter, r *http.Request) {
+	filter, err := filterFromQuery(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	requeued, err := h.dl.RequeueFailed(r.Context(), filter)
+	writeAffected(w, "requeued", requeued, err)
+}
+
+func (h *Handler) discard(w http.ResponseWriter, r *http.Request) {
+	filter, err := filterFromQuery(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	discarded, err := h.dl.DiscardFailed(r.Context(), filter)
+	writeAffected(w, "discarded", discarded, err)
+}
+
+func writeAffected(w http.ResponseWriter, key string, affected int64, err error) {
+	if errors.Is(err, queue.ErrEmptyFilter) {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]int64{key: affected})
+}
+
+func filterFromQuery(r *http.Request) (queue.DeadLetterFilter, error) {
+	query := r.URL.Query()
+	filter := queue.DeadLetterFilter{Subject: query.Get("subject")}
+
+	for _, raw := range query["id"] {
+		id, err := utils.UUIDFromString[utils.UniqueID](raw)
+		if err != nil {
+			return queue.DeadLetterFilter{}, err
+		}
+		filter.IDs = append(filter.IDs, id)
+	}
+
+	var err error
+	if raw := query.Get("since"); raw != "" {
+		if filter.Since, err = time.Parse(time.RFC3339, raw); err != nil {
+			return queue.DeadLetterFilter{}, err
+		}
+	}
+	if raw := query.Get("until"); raw != "" {
+		if filter.Until, err = time.Parse(time.RFC3339, raw); err != nil {
+			return queue.DeadLetterFilter{}, err
+		}
+	}
+	if raw := query.Get("limit"); raw != "" {
+		if filter.Limit, err = strconv.Atoi(raw); err != nil {
+			return queue.DeadLetterFilter{}, err
+		}
+	}
+	return filter, nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		slog.Error("Failed to encode dead letter response", logger.ErrorField, err.Error())
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
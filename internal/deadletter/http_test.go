@@ -0,0 +1,91 @@
+package deadletter
+
+import (
+	"context"
+	"encoding/json"
+	"microgame-bot/internal/queue"
+	"microgame-bot/internal/utils"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type deadLetterStub struct {
+	filter queue.DeadLetterFilter
+	tasks  []queue.Task
+}
+
+func (s *deadLetterStub) FailedTasks(_ context.Context, filter queue.DeadLetterFilter) ([]queue.Task, error) {
+	s.filter = filter
+	return s.tasks, nil
+}
+
+func (s *deadLetterStub) FailedTask(_ context.Context, id utils.UniqueID) (queue.Task, error) {
+	for _, task := range s.tasks {
+		if task.ID == id {
+			return task, nil
+		}
+	}
+	return queue.Task{}, queue.ErrTaskNotFound
+}
+
+func (s *deadLetterStub) RequeueFailed(_ context.Context, filter queue.DeadLetterFilter) (int64, error) {
+	s.filter = filter
+	return int64(len(filter.IDs)), nil
+}
+
+func (s *deadLetterStub) DiscardFailed(_ context.Context, filter queue.DeadLetterFilter) (int64, error) {
+	s.filter = filter
+	return 0, queue.ErrEmptyFilter
+}
+
+func serve(h http.Handler, method, target, token string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(method, target, nil)
+	if token != "" {
+		r.Header.Set("Authorization", "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	return w
+}
+
+func TestHandler(t *testing.T) {
+	task := queue.NewTask("bets.payout", []byte(`{"id":1}`), time.Now(), 1, queue.DefaultTimeout)
+	task.Errors = []queue.TaskAttempt{{Attempt: 1, Error: "boom"}}
+	dl := &deadLetterStub{tasks: []queue.Task{task}}
+	h := NewHandler(dl, "secret")
+
+	assert.Equal(t, http.StatusUnauthorized, serve(h, http.MethodGet, Path, "").Code)
+	assert.Equal(t, http.StatusUnauthorized, serve(h, http.MethodGet, Path, "wrong").Code)
+
+	w := serve(h, http.MethodGet, Path+"?subject=bets.payout&since=2026-01-05T10:30:00Z&limit=5", "secret")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "bets.payout", dl.filter.Subject)
+	assert.Equal(t, 5, dl.filter.Limit)
+	assert.False(t, dl.filter.Since.IsZero())
+	var list []TaskView
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	require.Len(t, list, 1)
+	assert.Empty(t, list[0].Payload, "the list has no payloads")
+
+	w = serve(h, http.MethodGet, Path+"/"+task.ID.String(), "secret")
+	require.Equal(t, http.StatusOK, w.Code)
+	var view TaskView
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &view))
+	assert.JSONEq(t, `{"id":1}`, string(view.Payload))
+	assert.Len(t, view.Errors, 1)
+
+	other := utils.NewUniqueID()
+	assert.Equal(t, http.StatusNotFound, serve(h, http.MethodGet, Path+"/"+other.String(), "secret").Code)
+	assert.Equal(t, http.StatusBadRequest, serve(h, http.MethodGet, Path+"?since=yesterday", "secret").Code)
+
+	w = serve(h, http.MethodPost, Path+"/requeue?id="+task.ID.String()+"&id="+other.String(), "secret")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"requeued":2}`, w.Body.String())
+
+	assert.Equal(t, http.StatusBadRequest, serve(h, http.MethodPost, Path+"/discard", "secret").Code)
+}
@@ -0,0 +1,350 @@
+package battleship
+
+import (
+	"microgame-bot/internal/domain"
+	se "microgame-bot/internal/domain/session"
+	"microgame-bot/internal/domain/user"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Battleship is a game of two hidden fleets. Players place their ships in private,
+// then take turns shooting at the opponent board, a hit gives another shot.
+type Battleship struct {
+	createdAt time.Time
+	updatedAt time.Time
+	status    domain.GameStatus
+	board1    Board
+	board2    Board
+	ready1    bool
+	ready2    bool
+	turn      user.ID
+	winnerID  user.ID
+	sessionID se.ID
+	id        ID
+	player1ID user.ID
+	player2ID user.ID
+	creatorID user.ID
+}
+
+func New(opts ...Opt) (Battleship, error) {
+	b := &Battleship{
+		status: domain.GameStatusCreated,
+	}
+
+	for _, opt := range opts {
+		if err := opt(b); err != nil {
+			return Battleship{}, err
+		}
+	}
+
+	// Validate required fields
+	if b.id.IsZero() {
+		return Battleship{}, domain.ErrIDRequired
+	}
+	if b.sessionID.IsZero() {
+		return Battleship{}, domain.ErrSessionIDRequired
+	}
+	if b.creatorID.IsZero() {
+		return Battleship{}, domain.ErrCreatorIDRequired
+	}
+	if (b.player1ID.IsZero() || b.player2ID.IsZero()) &&
+		b.status != domain.GameStatusCreated &&
+		b.status != domain.GameStatusWaitingForPlayers &&
+		b.status != domain.GameStatusCancelled {
+		return Battleship{}, domain.ErrCantPlayWithoutPlayers
+	}
+
+	return *b, nil
+}
+
+func (b Battleship) ID() ID                    { return b.id }
+func (b Battleship) CreatorID() user.ID        { return b.creatorID }
+func (b Battleship) Player1ID() user.ID        { return b.player1ID }
+func (b Battleship) Player2ID() user.ID        { return b.player2ID }
+func (b Battleship) Board1() Board             { return b.board1 }
+func (b Battleship) Board2() Board             { return b.board2 }
+func (b Battleship) Ready1() bool              { return b.ready1 }
+func (b Battleship) Ready2() bool              { return b.ready2 }
+func (b Battleship) Turn() user.ID             { return b.turn }
+func (b Battleship) Status() domain.GameStatus { return b.status }
+func (b Battleship) CreatedAt() time.Time      { return b.createdAt }
+func (b Battleship) UpdatedAt() time.Time      { return b.updatedAt }
+func (b Battleship) SessionID() se.ID          { return b.sessionID }
+func (b Battleship) IDtoUUID() uuid.UUID       { return uuid.UUID(b.id) }
+func (b Battleship) Type() domain.GameType     { return domain.GameTypeBattleship }
+
+func (b Battleship) Participants() []user.ID {
+	participants := []user.ID{}
+	if !b.player1ID.IsZero() {
+		participants = append(participants, b.player1ID)
+	}
+	if !b.player2ID.IsZero() {
+		participants = append(participants, b.player2ID)
+	}
+	return participants
+}
+
+// BoardOf returns the board with the ships of the player.
+func (b Battleship) BoardOf(playerID user.ID) Board {
+	if playerID == b.player2ID {
+		return b.board2
+	}
+	return b.board1
+}
+
+// TargetBoardOf returns the board the player shoots at.
+func (b Battleship) TargetBoardOf(playerID user.ID) Board {
+	return b.BoardOf(b.OpponentOf(playerID))
+}
+
+func (b Battleship) OpponentOf(playerID user.ID) user.ID {
+	if playerID == b.player1ID {
+		return b.player2ID
+	}
+	return b.player1ID
+}
+
+func (b Battleship) IsReady(playerID user.ID) bool {
+	switch playerID {
+	case b.player1ID:
+		return b.ready1
+	case b.player2ID:
+		return b.ready2
+	default:
+		return false
+	}
+}
+
+// IsPlacing returns true while the players are still placing their fleets.
+func (b Battleship) IsPlacing() bool {
+	return b.status == domain.GameStatusInProgress && (!b.ready1 || !b.ready2)
+}
+
+func (b Battleship) JoinGame(playerID user.ID) (Battleship, error) {
+	if b.IsFinished() {
+		return Battleship{}, domain.ErrGameOver
+	}
+
+	if !b.player1ID.IsZero() && !b.player2ID.IsZero() {
+		return Battleship{}, domain.ErrGameFull
+	}
+
+	if b.player1ID == playerID || b.player2ID == playerID {
+		return Battleship{}, domain.ErrPlayerAlreadyInGame
+	}
+
+	if b.player1ID.IsZero() {
+		b.player1ID = playerID
+		// Status remains WaitingForPlayers
+		return b, nil
+	}
+
+	b.player2ID = playerID
+	b.status = domain.GameStatusInProgress
+
+	return b, nil
+}
+
+// Cancel withdraws the game on behalf of its creator.
+// Only a game that is still waiting for players can be cancelled.
+func (b Battleship) Cancel(userID user.ID) (Battleship, error) {
+	if b.creatorID != userID {
+		return Battleship{}, domain.ErrNotGameCreator
+	}
+	if b.status != domain.GameStatusWaitingForPlayers {
+		return Battleship{}, domain.ErrGameAlreadyStarted
+	}
+
+	b.status = domain.GameStatusCancelled
+	return b, nil
+}
+
+// CanPlace checks that the player may still change the fleet.
+func (b Battleship) CanPlace(playerID user.ID) error {
+	if b.IsFinished() {
+		return domain.ErrGameOver
+	}
+	if playerID != b.player1ID && playerID != b.player2ID {
+		return domain.ErrPlayerNotInGame
+	}
+	if b.status != domain.GameStatusInProgress {
+		return domain.ErrGameNotStarted
+	}
+	if !b.IsPlacing() {
+		return ErrPlacementOver
+	}
+	if b.IsReady(playerID) {
+		return ErrAlreadyReady
+	}
+	return nil
+}
+
+// PlaceShip puts the next ship of the player fleet on the board.
+func (b Battleship) PlaceShip(playerID user.ID, bow Cell, orientation Orientation) (Battleship, error) {
+	if err := b.CanPlace(playerID); err != nil {
+		return Battleship{}, err
+	}
+	board, err := b.BoardOf(playerID).Place(bow, orientation)
+	if err != nil {
+		return Battleship{}, err
+	}
+	return b.withBoard(playerID, board), nil
+}
+
+// PlaceRandomly replaces the fleet of the player with a random one. randInt returns a number in [0, n).
+func (b Battleship) PlaceRandomly(playerID user.ID, randInt func(n int) int) (Battleship, error) {
+	if err := b.CanPlace(playerID); err != nil {
+		return Battleship{}, err
+	}
+	board, err := RandomBoard(randInt)
+	if err != nil {
+		return Battleship{}, err
+	}
+	return b.withBoard(playerID, board), nil
+}
+
+// ResetFleet removes all ships of the player.
+func (b Battleship) ResetFleet(playerID user.ID) (Battleship, error) {
+	if err := b.CanPlace(playerID); err != nil {
+		return Battleship{}, err
+	}
+	return b.withBoard(playerID, b.BoardOf(playerID).Reset()), nil
+}
+
+// Ready confirms the fleet of the player. Once both fleets are confirmed the first player shoots.
+func (b Battleship) Ready(playerID user.ID) (Battleship, error) {
+	if err := b.CanPlace(playerID); err != nil {
+		return Battleship{}, err
+	}
+	if !b.BoardOf(playerID).IsComplete() {
+		return Battleship{}, ErrFleetIncomplete
+	}
+
+	if playerID == b.player1ID {
+		b.ready1 = true
+	} else {
+		b.ready2 = true
+	}
+	if b.ready1 && b.ready2 {
+		b.turn = b.player1ID
+	}
+	return b, nil
+}
+
+// CanShoot checks that the player may shoot now.
+func (b Battleship) CanShoot(playerID user.ID) error {
+	if b.IsFinished() {
+		return domain.ErrGameOver
+	}
+	if playerID != b.player1ID && playerID != b.player2ID {
+		return domain.ErrPlayerNotInGame
+	}
+	if b.status != domain.GameStatusInProgress || b.IsPlacing() {
+		return domain.ErrGameNotStarted
+	}
+	if b.turn != playerID {
+		return domain.ErrNotPlayersTurn
+	}
+	return nil
+}
+
+// Shoot fires at the cell of the opponent board. A miss passes the turn,
+// sinking the last ship of the opponent wins the game.
+func (b Battleship) Shoot(playerID user.ID, cell Cell) (Battleship, ShotResult, error) {
+	if err := b.CanShoot(playerID); err != nil {
+		return Battleship{}, "", err
+	}
+
+	opponentID := b.OpponentOf(playerID)
+	board, result, err := b.BoardOf(opponentID).Shoot(cell)
+	if err != nil {
+		return Battleship{}, "", err
+	}
+	b = b.withBoard(opponentID, board)
+
+	if result == ShotMiss {
+		b.turn = opponentID
+		return b, result, nil
+	}
+	if board.AllSunk() {
+		b.winnerID = playerID
+		b.status = domain.GameStatusFinished
+	}
+	return b, result, nil
+}
+
+func (b Battleship) withBoard(playerID user.ID, board Board) Battleship {
+	if playerID == b.player1ID {
+		b.board1 = board
+	} else {
+		b.board2 = board
+	}
+	return b
+}
+
+func (b Battleship) IsFinished() bool {
+	return !b.winnerID.IsZero() ||
+		b.status == domain.GameStatusCancelled ||
+		b.status == domain.GameStatusFinished ||
+		b.status == domain.GameStatusAbandoned
+}
+
+// IsDraw is always false, one of the fleets always goes down first.
+func (b Battleship) IsDraw() bool {
+	return false
+}
+
+func (b Battleship) Winners() []user.ID {
+	if b.winnerID.IsZero() {
+		return []user.ID{}
+	}
+	return []user.ID{b.winnerID}
+}
+
+func (b Battleship) WinnerID() user.ID {
+	return b.winnerID
+}
+
+// IsStarted returns true once a fleet is confirmed.
+func (b Battleship) IsStarted() bool {
+	return b.ready1 || b.ready2
+}
+
+func (b Battleship) SetWinner(winnerID user.ID) (Battleship, error) {
+	if winnerID != b.player1ID && winnerID != b.player2ID {
+		return Battleship{}, domain.ErrPlayerNotInGame
+	}
+	b.winnerID = winnerID
+	return b, nil
+}
+
+// AFKPlayerID returns the player holding the game up: the one placing the fleet or the one to shoot.
+func (b Battleship) AFKPlayerID() (user.ID, error) {
+	if b.IsPlacing() {
+		switch {
+		case !b.ready1 && !b.ready2:
+			return user.ID{}, domain.ErrAllPlayersAFK
+		case !b.ready1:
+			return b.player1ID, nil
+		default:
+			return b.player2ID, nil
+		}
+	}
+	if b.turn.IsZero() {
+		return user.ID{}, domain.ErrAFKPlayerNotFound
+	}
+	return b.turn, nil
+}
+
+func (b Battleship) SetStatus(status domain.GameStatus) (Battleship, error) {
+	if status.IsZero() {
+		return Battleship{}, domain.ErrGameStatusRequired
+	}
+	if !status.IsValid() {
+		return Battleship{}, domain.ErrInvalidGameStatus
+	}
+	b.status = status
+	return b, nil
+}
@@ -0,0 +1,227 @@
+package battleship
+
+import (
+	"math/rand/v2"
+	"microgame-bot/internal/domain"
+	se "microgame-bot/internal/domain/session"
+	"microgame-bot/internal/domain/user"
+	"microgame-bot/internal/utils"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// lineFleet places the fleet on the even rows from the left edge, so no ships touch.
+func lineFleet(t *testing.T) Board {
+	t.Helper()
+	board := Board{}
+	positions := []Cell{{0, 0}, {2, 0}, {2, 4}, {4, 0}, {4, 3}, {6, 0}, {6, 2}}
+	for _, bow := range positions {
+		var err error
+		board, err = board.Place(bow, OrientationHorizontal)
+		require.NoError(t, err)
+	}
+	require.True(t, board.IsComplete())
+	return board
+}
+
+func newPlacingGame(t *testing.T) (Battleship, user.ID, user.ID) {
+	t.Helper()
+	player1 := user.ID(utils.NewUniqueID())
+	player2 := user.ID(utils.NewUniqueID())
+
+	game, err := New(
+		WithNewID(),
+		WithCreatorID(player1),
+		WithSessionID(se.ID(utils.NewUniqueID())),
+		WithStatus(domain.GameStatusWaitingForPlayers),
+	)
+	require.NoError(t, err)
+	game, err = game.JoinGame(player1)
+	require.NoError(t, err)
+	game, err = game.JoinGame(player2)
+	require.NoError(t, err)
+	require.True(t, game.IsPlacing())
+
+	return game, player1, player2
+}
+
+func TestCellFromString(t *testing.T) {
+	cell, err := CellFromString("C4")
+	require.NoError(t, err)
+	assert.Equal(t, Cell{Row: 3, Col: 2}, cell)
+	assert.Equal(t, "C4", cell.String())
+
+	for _, invalid := range []string{"", "C", "I1", "A0", "A9", "4C"} {
+		_, err := CellFromString(invalid)
+		assert.ErrorIs(t, err, ErrInvalidCell, invalid)
+	}
+}
+
+func TestBoard_Place(t *testing.T) {
+	board, err := Board{}.Place(Cell{0, 5}, OrientationHorizontal)
+	require.ErrorIs(t, err, ErrOutOfBoard, "four-deck ship does not fit from F1")
+	assert.Empty(t, board.Ships())
+
+	board, err = Board{}.Place(Cell{0, 0}, OrientationVertical)
+	require.NoError(t, err)
+	size, ok := board.NextShipSize()
+	require.True(t, ok)
+	assert.Equal(t, 3, size)
+
+	_, err = board.Place(Cell{0, 1}, OrientationVertical)
+	assert.ErrorIs(t, err, ErrShipsTouch, "side by side")
+	_, err = board.Place(Cell{4, 1}, OrientationHorizontal)
+	assert.ErrorIs(t, err, ErrShipsTouch, "diagonal corner")
+	_, err = board.Place(Cell{5, 0}, OrientationHorizontal)
+	assert.NoError(t, err, "one row gap is enough")
+
+	_, err = lineFleet(t).Place(Cell{7, 7}, OrientationHorizontal)
+	assert.ErrorIs(t, err, ErrFleetComplete)
+}
+
+func TestRandomBoard(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2)) //nolint:gosec // Deterministic source for the test.
+	for range 20 {
+		board, err := RandomBoard(r.IntN)
+		require.NoError(t, err)
+		require.True(t, board.IsComplete())
+
+		cells := 0
+		ships := board.Ships()
+		for i, ship := range ships {
+			cells += ship.Size
+			assert.Equal(t, Fleet()[i], ship.Size)
+			for _, other := range ships[i+1:] {
+				assert.False(t, ship.touches(other))
+			}
+		}
+		assert.Equal(t, FleetCells(), cells)
+	}
+}
+
+func TestBoard_ShootAndMarks(t *testing.T) {
+	board := lineFleet(t)
+
+	// The two-deck ship at A5:B5
+	board, result, err := board.Shoot(Cell{4, 0})
+	require.NoError(t, err)
+	assert.Equal(t, ShotHit, result)
+	assert.Equal(t, MarkHit, board.Mark(Cell{4, 0}))
+	assert.Equal(t, MarkUnknown, board.Mark(Cell{4, 1}), "intact ship cells stay hidden")
+	assert.Equal(t, MarkShip, board.OwnerMark(Cell{4, 1}))
+
+	_, _, err = board.Shoot(Cell{4, 0})
+	require.ErrorIs(t, err, ErrAlreadyShot)
+
+	board, result, err = board.Shoot(Cell{4, 1})
+	require.NoError(t, err)
+	assert.Equal(t, ShotSunk, result)
+	assert.Equal(t, MarkSunk, board.Mark(Cell{4, 0}))
+	assert.Equal(t, MarkWater, board.Mark(Cell{5, 2}), "cells around a sunk ship are known")
+	_, _, err = board.Shoot(Cell{5, 2})
+	require.ErrorIs(t, err, ErrAlreadyShot)
+
+	board, result, err = board.Shoot(Cell{7, 7})
+	require.NoError(t, err)
+	assert.Equal(t, ShotMiss, result)
+	assert.Equal(t, MarkMiss, board.Mark(Cell{7, 7}))
+
+	assert.Equal(t, len(Fleet())-1, board.ShipsLeft())
+	assert.False(t, board.AllSunk())
+}
+
+func TestBattleship_Placement(t *testing.T) {
+	game, player1, player2 := newPlacingGame(t)
+
+	afkID, err := game.AFKPlayerID()
+	require.ErrorIs(t, err, domain.ErrAllPlayersAFK)
+	assert.True(t, afkID.IsZero())
+	assert.False(t, game.IsStarted())
+
+	_, err = game.Ready(player1)
+	require.ErrorIs(t, err, ErrFleetIncomplete)
+
+	game, err = game.PlaceShip(player1, Cell{0, 0}, OrientationHorizontal)
+	require.NoError(t, err)
+	assert.Len(t, game.BoardOf(player1).Ships(), 1)
+	assert.Empty(t, game.BoardOf(player2).Ships())
+
+	game, err = game.ResetFleet(player1)
+	require.NoError(t, err)
+	assert.Empty(t, game.BoardOf(player1).Ships())
+
+	r := rand.New(rand.NewPCG(3, 4)) //nolint:gosec // Deterministic source for the test.
+	game, err = game.PlaceRandomly(player1, r.IntN)
+	require.NoError(t, err)
+	game, err = game.Ready(player1)
+	require.NoError(t, err)
+	assert.True(t, game.IsStarted())
+
+	_, err = game.PlaceShip(player1, Cell{7, 7}, OrientationHorizontal)
+	require.ErrorIs(t, err, ErrAlreadyReady)
+	_, _, err = game.Shoot(player1, Cell{0, 0})
+	require.ErrorIs(t, err, domain.ErrGameNotStarted)
+
+	afkID, err = game.AFKPlayerID()
+	require.NoError(t, err)
+	assert.Equal(t, player2, afkID)
+
+	game, err = game.PlaceRandomly(player2, r.IntN)
+	require.NoError(t, err)
+	game, err = game.Ready(player2)
+	require.NoError(t, err)
+	assert.False(t, game.IsPlacing())
+	assert.Equal(t, player1, game.Turn())
+
+	_, err = game.ResetFleet(player2)
+	assert.ErrorIs(t, err, ErrPlacementOver)
+}
+
+func TestBattleship_ShootUntilSunk(t *testing.T) {
+	game, player1, player2 := newPlacingGame(t)
+	game, err := New(
+		WithID(game.ID()),
+		WithCreatorID(player1),
+		WithSessionID(game.SessionID()),
+		WithStatus(domain.GameStatusInProgress),
+		WithPlayer1ID(player1),
+		WithPlayer2ID(player2),
+		WithBoard1(lineFleet(t)),
+		WithBoard2(lineFleet(t)),
+		WithReady1(true),
+		WithReady2(true),
+		WithTurn(player1),
+	)
+	require.NoError(t, err)
+
+	_, _, err = game.Shoot(player2, Cell{0, 0})
+	require.ErrorIs(t, err, domain.ErrNotPlayersTurn)
+
+	game, result, err := game.Shoot(player1, Cell{7, 7})
+	require.NoError(t, err)
+	assert.Equal(t, ShotMiss, result)
+	assert.Equal(t, player2, game.Turn(), "a miss passes the turn")
+
+	game, result, err = game.Shoot(player2, Cell{6, 0})
+	require.NoError(t, err)
+	assert.Equal(t, ShotSunk, result)
+	assert.Equal(t, player2, game.Turn(), "a hit gives another shot")
+
+	for _, ship := range game.BoardOf(player1).Ships() {
+		for _, cell := range ship.Cells() {
+			if game.BoardOf(player1).Mark(cell) != MarkUnknown {
+				continue
+			}
+			game, _, err = game.Shoot(player2, cell)
+			require.NoError(t, err)
+		}
+	}
+
+	assert.True(t, game.IsFinished())
+	assert.Equal(t, player2, game.WinnerID())
+	assert.Equal(t, []user.ID{player2}, game.Winners())
+	assert.Equal(t, domain.GameStatusFinished, game.Status())
+	assert.True(t, game.BoardOf(player1).AllSunk())
+}
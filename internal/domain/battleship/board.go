@@ -0,0 +1,211 @@
+package battleship
+
+import "slices"
+
+// maxRandomAttempts limits restarts of the random placement, a dead end is very unlikely on a board this size.
+const maxRandomAttempts = 100
+
+// Board is the sea of one player: the ships placed on it and the shots the opponent has fired at it.
+type Board struct {
+	ships []Ship
+	shots []Cell
+}
+
+// NewBoard restores a board from stored ships and shots.
+func NewBoard(ships []Ship, shots []Cell) Board {
+	return Board{
+		ships: slices.Clone(ships),
+		shots: slices.Clone(shots),
+	}
+}
+
+func (b Board) Ships() []Ship { return slices.Clone(b.ships) }
+func (b Board) Shots() []Cell { return slices.Clone(b.shots) }
+
+// NextShipSize returns the size of the ship to place next.
+func (b Board) NextShipSize() (int, bool) {
+	if len(b.ships) >= len(fleet) {
+		return 0, false
+	}
+	return fleet[len(b.ships)], true
+}
+
+func (b Board) IsComplete() bool {
+	return len(b.ships) == len(fleet)
+}
+
+// CanPlace checks the next ship of the fleet at the given bow: it must fit on the board
+// and must not overlap or touch the ships placed before.
+func (b Board) CanPlace(bow Cell, orientation Orientation) error {
+	size, ok := b.NextShipSize()
+	if !ok {
+		return ErrFleetComplete
+	}
+	if !orientation.IsValid() {
+		return ErrInvalidOrientation
+	}
+	ship := Ship{Bow: bow, Size: size, Orientation: orientation}
+	if !ship.fits() {
+		return ErrOutOfBoard
+	}
+	for _, placed := range b.ships {
+		if ship.touches(placed) {
+			return ErrShipsTouch
+		}
+	}
+	return nil
+}
+
+// Place puts the next ship of the fleet on the board.
+func (b Board) Place(bow Cell, orientation Orientation) (Board, error) {
+	if err := b.CanPlace(bow, orientation); err != nil {
+		return Board{}, err
+	}
+	size, _ := b.NextShipSize()
+	b.ships = append(slices.Clone(b.ships), Ship{Bow: bow, Size: size, Orientation: orientation})
+	return b, nil
+}
+
+// Reset removes all ships from the board.
+func (b Board) Reset() Board {
+	return Board{}
+}
+
+// RandomBoard places the whole fleet at random. randInt returns a number in [0, n).
+func RandomBoard(randInt func(n int) int) (Board, error) {
+	for range maxRandomAttempts {
+		board := Board{}
+		for !board.IsComplete() {
+			options := board.placements()
+			if len(options) == 0 {
+				break
+			}
+			option := options[randInt(len(options))]
+			board, _ = board.Place(option.Bow, option.Orientation)
+		}
+		if board.IsComplete() {
+			return board, nil
+		}
+	}
+	return Board{}, ErrNoRoom
+}
+
+// placements lists every valid position of the next ship.
+func (b Board) placements() []Ship {
+	options := make([]Ship, 0, BoardSize*BoardSize*2) //nolint:mnd // Two orientations per cell.
+	for row := range BoardSize {
+		for col := range BoardSize {
+			for _, orientation := range []Orientation{OrientationHorizontal, OrientationVertical} {
+				bow := Cell{Row: row, Col: col}
+				if b.CanPlace(bow, orientation) == nil {
+					options = append(options, Ship{Bow: bow, Orientation: orientation})
+				}
+			}
+		}
+	}
+	return options
+}
+
+func (b Board) isShot(cell Cell) bool {
+	return slices.Contains(b.shots, cell)
+}
+
+func (b Board) shipAt(cell Cell) (Ship, bool) {
+	for _, ship := range b.ships {
+		if ship.Contains(cell) {
+			return ship, true
+		}
+	}
+	return Ship{}, false
+}
+
+func (b Board) isSunk(ship Ship) bool {
+	for _, cell := range ship.Cells() {
+		if !b.isShot(cell) {
+			return false
+		}
+	}
+	return true
+}
+
+// Shoot fires at the cell and reports a miss, a hit or a sunk ship.
+func (b Board) Shoot(cell Cell) (Board, ShotResult, error) {
+	if !cell.IsValid() {
+		return Board{}, "", ErrInvalidCell
+	}
+	if b.isShot(cell) {
+		return Board{}, "", ErrAlreadyShot
+	}
+	if b.Mark(cell) == MarkWater {
+		// Cells around a sunk ship are known to be empty, shooting there would waste the turn.
+		return Board{}, "", ErrAlreadyShot
+	}
+	b.shots = append(slices.Clone(b.shots), cell)
+
+	ship, ok := b.shipAt(cell)
+	if !ok {
+		return b, ShotMiss, nil
+	}
+	if b.isSunk(ship) {
+		return b, ShotSunk, nil
+	}
+	return b, ShotHit, nil
+}
+
+// AllSunk returns true when every ship of a complete fleet is sunk.
+func (b Board) AllSunk() bool {
+	if !b.IsComplete() {
+		return false
+	}
+	return b.ShipsLeft() == 0
+}
+
+// ShipsLeft returns the number of ships that are still afloat.
+func (b Board) ShipsLeft() int {
+	left := 0
+	for _, ship := range b.ships {
+		if !b.isSunk(ship) {
+			left++
+		}
+	}
+	return left
+}
+
+// Mark returns the cell as the opponent sees it: only shots and what they revealed.
+// Intact ship cells are never exposed, so it is safe to render for everyone.
+func (b Board) Mark(cell Cell) Mark {
+	ship, hasShip := b.shipAt(cell)
+	if b.isShot(cell) {
+		switch {
+		case !hasShip:
+			return MarkMiss
+		case b.isSunk(ship):
+			return MarkSunk
+		default:
+			return MarkHit
+		}
+	}
+	for _, placed := range b.ships {
+		if !b.isSunk(placed) {
+			continue
+		}
+		for _, c := range placed.Cells() {
+			if c.isNeighbour(cell) {
+				return MarkWater
+			}
+		}
+	}
+	return MarkUnknown
+}
+
+// OwnerMark returns the cell as the owner of the board sees it, intact ships included.
+func (b Board) OwnerMark(cell Cell) Mark {
+	mark := b.Mark(cell)
+	if mark != MarkUnknown && mark != MarkWater {
+		return mark
+	}
+	if _, ok := b.shipAt(cell); ok {
+		return MarkShip
+	}
+	return mark
+}
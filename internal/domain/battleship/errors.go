@@ -0,0 +1,17 @@
+package battleship
+
+import "errors"
+
+var (
+	ErrInvalidCell        = errors.New("invalid cell")
+	ErrInvalidOrientation = errors.New("invalid ship orientation")
+	ErrOutOfBoard         = errors.New("ship does not fit on the board")
+	ErrShipsTouch         = errors.New("ships must not overlap or touch")
+	ErrFleetComplete      = errors.New("all ships are already placed")
+	ErrFleetIncomplete    = errors.New("not all ships are placed")
+	ErrNoRoom             = errors.New("no room left for the fleet")
+	ErrAlreadyShot        = errors.New("cell is already shot")
+	ErrAlreadyReady       = errors.New("fleet is already confirmed")
+	ErrPlacementOver      = errors.New("placement is over")
+	ErrChatRequired       = errors.New("private chat with the bot required")
+)
@@ -0,0 +1,166 @@
+package battleship
+
+import (
+	"fmt"
+	"microgame-bot/internal/core"
+	"microgame-bot/internal/domain"
+	se "microgame-bot/internal/domain/session"
+	"microgame-bot/internal/domain/user"
+	"microgame-bot/internal/utils"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Opt func(*Battleship) error
+
+func WithID(id ID) Opt {
+	return func(b *Battleship) error {
+		if id.IsZero() {
+			return domain.ErrIDRequired
+		}
+		b.id = id
+		return nil
+	}
+}
+
+func WithNewID() Opt {
+	return WithID(ID(utils.NewUniqueID()))
+}
+
+func WithIDFromString(id string) Opt {
+	return func(b *Battleship) error {
+		idUUID, err := utils.UUIDFromString[ID](id)
+		if err != nil {
+			return fmt.Errorf("%w: %w", core.ErrFailedToParseID, err)
+		}
+		b.id = idUUID
+		return nil
+	}
+}
+
+func WithIDFromUUID(id uuid.UUID) Opt {
+	return WithID(ID(id))
+}
+
+func WithCreatorID(creatorID user.ID) Opt {
+	return func(b *Battleship) error {
+		if creatorID.IsZero() {
+			return domain.ErrCreatorIDRequired
+		}
+		b.creatorID = creatorID
+		return nil
+	}
+}
+
+func WithPlayer1ID(player1ID user.ID) Opt {
+	return func(b *Battleship) error {
+		b.player1ID = player1ID
+		return nil
+	}
+}
+
+func WithPlayer1IDFromUUID(player1ID uuid.UUID) Opt {
+	return WithPlayer1ID(user.ID(player1ID))
+}
+
+func WithPlayer2ID(player2ID user.ID) Opt {
+	return func(b *Battleship) error {
+		b.player2ID = player2ID
+		return nil
+	}
+}
+
+func WithPlayer2IDFromUUID(player2ID uuid.UUID) Opt {
+	return WithPlayer2ID(user.ID(player2ID))
+}
+
+func WithBoard1(board Board) Opt {
+	return func(b *Battleship) error {
+		b.board1 = board
+		return nil
+	}
+}
+
+func WithBoard2(board Board) Opt {
+	return func(b *Battleship) error {
+		b.board2 = board
+		return nil
+	}
+}
+
+func WithReady1(ready bool) Opt {
+	return func(b *Battleship) error {
+		b.ready1 = ready
+		return nil
+	}
+}
+
+func WithReady2(ready bool) Opt {
+	return func(b *Battleship) error {
+		b.ready2 = ready
+		return nil
+	}
+}
+
+func WithTurn(turn user.ID) Opt {
+	return func(b *Battleship) error {
+		b.turn = turn
+		return nil
+	}
+}
+
+func WithTurnFromUUID(turn uuid.UUID) Opt {
+	return WithTurn(user.ID(turn))
+}
+
+func WithStatus(status domain.GameStatus) Opt {
+	return func(b *Battleship) error {
+		if status.IsZero() {
+			return domain.ErrGameStatusRequired
+		}
+		if !status.IsValid() {
+			return domain.ErrInvalidGameStatus
+		}
+		b.status = status
+		return nil
+	}
+}
+
+func WithWinnerID(winnerID user.ID) Opt {
+	return func(b *Battleship) error {
+		b.winnerID = winnerID
+		return nil
+	}
+}
+
+func WithWinnerIDFromUUID(winnerID uuid.UUID) Opt {
+	return WithWinnerID(user.ID(winnerID))
+}
+
+func WithCreatedAt(createdAt time.Time) Opt {
+	return func(b *Battleship) error {
+		if createdAt.IsZero() {
+			return domain.ErrCreatedAtRequired
+		}
+		b.createdAt = createdAt
+		return nil
+	}
+}
+
+func WithUpdatedAt(updatedAt time.Time) Opt {
+	return func(b *Battleship) error {
+		if updatedAt.IsZero() {
+			return domain.ErrUpdatedAtRequired
+		}
+		b.updatedAt = updatedAt
+		return nil
+	}
+}
+
+func WithSessionID(sessionID se.ID) Opt {
+	return func(b *Battleship) error {
+		b.sessionID = sessionID
+		return nil
+	}
+}
@@ -0,0 +1,174 @@
+package battleship
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// BoardSize is the side of the square board. 8x8 keeps the whole board within the inline keyboard limits.
+const BoardSize = 8
+
+//nolint:gochecknoglobals // Fleet composition is constant.
+var fleet = []int{4, 3, 3, 2, 2, 1, 1}
+
+// Fleet returns the ship sizes in the order they are placed.
+func Fleet() []int {
+	return append([]int(nil), fleet...)
+}
+
+// FleetCells returns the number of cells taken by the whole fleet.
+func FleetCells() int {
+	total := 0
+	for _, size := range fleet {
+		total += size
+	}
+	return total
+}
+
+type Cell struct {
+	Row int
+	Col int
+}
+
+func (c Cell) IsValid() bool {
+	return c.Row >= 0 && c.Row < BoardSize && c.Col >= 0 && c.Col < BoardSize
+}
+
+// String returns the cell in the board notation: column letter and row number, e.g. "C4".
+func (c Cell) String() string {
+	return string(rune('A'+c.Col)) + strconv.Itoa(c.Row+1)
+}
+
+func CellFromString(s string) (Cell, error) {
+	//nolint:mnd // Column letter and at least one row digit.
+	if len(s) < 2 {
+		return Cell{}, ErrInvalidCell
+	}
+	row, err := strconv.Atoi(s[1:])
+	if err != nil {
+		return Cell{}, fmt.Errorf("%w: %w", ErrInvalidCell, err)
+	}
+	cell := Cell{Row: row - 1, Col: int(s[0]) - 'A'}
+	if !cell.IsValid() {
+		return Cell{}, ErrInvalidCell
+	}
+	return cell, nil
+}
+
+// isNeighbour returns true for the same cell and the eight cells around it.
+func (c Cell) isNeighbour(other Cell) bool {
+	return abs(c.Row-other.Row) <= 1 && abs(c.Col-other.Col) <= 1
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+type Orientation string
+
+const (
+	OrientationHorizontal Orientation = "h"
+	OrientationVertical   Orientation = "v"
+)
+
+func (o Orientation) IsValid() bool {
+	return o == OrientationHorizontal || o == OrientationVertical
+}
+
+func (o Orientation) String() string {
+	return string(o)
+}
+
+// Toggle returns the other orientation.
+func (o Orientation) Toggle() Orientation {
+	if o == OrientationVertical {
+		return OrientationHorizontal
+	}
+	return OrientationVertical
+}
+
+func OrientationFromString(s string) (Orientation, error) {
+	o := Orientation(s)
+	if !o.IsValid() {
+		return "", ErrInvalidOrientation
+	}
+	return o, nil
+}
+
+// Ship is placed from its bow to the right or downwards.
+type Ship struct {
+	Bow         Cell
+	Size        int
+	Orientation Orientation
+}
+
+func (s Ship) Cells() []Cell {
+	cells := make([]Cell, 0, s.Size)
+	for i := range s.Size {
+		if s.Orientation == OrientationVertical {
+			cells = append(cells, Cell{Row: s.Bow.Row + i, Col: s.Bow.Col})
+		} else {
+			cells = append(cells, Cell{Row: s.Bow.Row, Col: s.Bow.Col + i})
+		}
+	}
+	return cells
+}
+
+func (s Ship) Contains(cell Cell) bool {
+	for _, c := range s.Cells() {
+		if c == cell {
+			return true
+		}
+	}
+	return false
+}
+
+func (s Ship) fits() bool {
+	if s.Size <= 0 || !s.Orientation.IsValid() {
+		return false
+	}
+	for _, c := range s.Cells() {
+		if !c.IsValid() {
+			return false
+		}
+	}
+	return true
+}
+
+// touches returns true if the ships overlap or have adjacent cells, diagonals included.
+func (s Ship) touches(other Ship) bool {
+	for _, a := range s.Cells() {
+		for _, b := range other.Cells() {
+			if a.isNeighbour(b) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ShotResult is what the shooter learns about the cell.
+type ShotResult string
+
+const (
+	ShotMiss ShotResult = "miss"
+	ShotHit  ShotResult = "hit"
+	ShotSunk ShotResult = "sunk"
+)
+
+// Mark is the state of a cell as it may be shown to a player.
+type Mark int
+
+const (
+	MarkUnknown Mark = iota
+	// MarkWater is a cell next to a sunk ship, it can't hold a ship by the rules.
+	MarkWater
+	MarkMiss
+	MarkHit
+	MarkSunk
+	// MarkShip is an intact ship cell, it is shown to the owner only.
+	MarkShip
+)
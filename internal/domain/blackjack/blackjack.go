@@ -0,0 +1,282 @@
+package blackjack
+
+import (
+	"microgame-bot/internal/domain"
+	se "microgame-bot/internal/domain/session"
+	"microgame-bot/internal/domain/user"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Blackjack is a single hand played by one player against the house.
+// Cards are drawn from the shoe derived from the session seed, only the position in the shoe is stored.
+type Blackjack struct {
+	createdAt time.Time
+	updatedAt time.Time
+	status    domain.GameStatus
+	outcome   Outcome
+	seed      string
+	player    Hand
+	dealer    Hand
+	cursor    int
+	doubled   bool
+	sessionID se.ID
+	id        ID
+	playerID  user.ID
+	creatorID user.ID
+}
+
+func New(opts ...Opt) (Blackjack, error) {
+	b := &Blackjack{
+		status: domain.GameStatusCreated,
+	}
+
+	for _, opt := range opts {
+		if err := opt(b); err != nil {
+			return Blackjack{}, err
+		}
+	}
+
+	// Validate required fields
+	if b.id.IsZero() {
+		return Blackjack{}, domain.ErrIDRequired
+	}
+	if b.sessionID.IsZero() {
+		return Blackjack{}, domain.ErrSessionIDRequired
+	}
+	if b.creatorID.IsZero() {
+		return Blackjack{}, domain.ErrCreatorIDRequired
+	}
+	if b.seed == "" {
+		return Blackjack{}, ErrSeedRequired
+	}
+	if b.playerID.IsZero() {
+		b.playerID = b.creatorID
+	}
+
+	return *b, nil
+}
+
+func (b Blackjack) ID() ID                    { return b.id }
+func (b Blackjack) CreatorID() user.ID        { return b.creatorID }
+func (b Blackjack) PlayerID() user.ID         { return b.playerID }
+func (b Blackjack) Seed() string              { return b.seed }
+func (b Blackjack) Cursor() int               { return b.cursor }
+func (b Blackjack) PlayerHand() Hand          { return b.player }
+func (b Blackjack) DealerHand() Hand          { return b.dealer }
+func (b Blackjack) Doubled() bool             { return b.doubled }
+func (b Blackjack) Outcome() Outcome          { return b.outcome }
+func (b Blackjack) Status() domain.GameStatus { return b.status }
+func (b Blackjack) CreatedAt() time.Time      { return b.createdAt }
+func (b Blackjack) UpdatedAt() time.Time      { return b.updatedAt }
+func (b Blackjack) SessionID() se.ID          { return b.sessionID }
+func (b Blackjack) IDtoUUID() uuid.UUID       { return uuid.UUID(b.id) }
+func (b Blackjack) Type() domain.GameType     { return domain.GameTypeBlackjack }
+
+// DealerUpCard returns the face-up card of the dealer, the hole card stays hidden until the hand is settled.
+func (b Blackjack) DealerUpCard() (Card, bool) {
+	if len(b.dealer) == 0 {
+		return 0, false
+	}
+	return b.dealer[0], true
+}
+
+func (b Blackjack) Participants() []user.ID {
+	return []user.ID{b.playerID}
+}
+
+// Winners returns the player if the hand is won, the house wins are left empty.
+func (b Blackjack) Winners() []user.ID {
+	if b.outcome == OutcomeBlackjack || b.outcome == OutcomeWin {
+		return []user.ID{b.playerID}
+	}
+	return []user.ID{}
+}
+
+// Payout returns the amount the house pays back for the tokens staked on the hand.
+func (b Blackjack) Payout(staked domain.Token) domain.Token {
+	return b.outcome.Payout(staked)
+}
+
+// Deal deals two cards to the player and two to the dealer.
+// A natural on either side settles the hand right away.
+func (b Blackjack) Deal() (Blackjack, error) {
+	if b.IsFinished() {
+		return Blackjack{}, domain.ErrGameOver
+	}
+	if len(b.player) > 0 || len(b.dealer) > 0 {
+		return Blackjack{}, ErrAlreadyDealt
+	}
+
+	shoe := Shoe(b.seed, b.id)
+	for range initialCards {
+		var err error
+		if b.player, err = b.draw(shoe, b.player); err != nil {
+			return Blackjack{}, err
+		}
+		if b.dealer, err = b.draw(shoe, b.dealer); err != nil {
+			return Blackjack{}, err
+		}
+	}
+	b.status = domain.GameStatusInProgress
+
+	switch {
+	case b.player.IsBlackjack() && b.dealer.IsBlackjack():
+		return b.settle(OutcomePush), nil
+	case b.player.IsBlackjack():
+		return b.settle(OutcomeBlackjack), nil
+	case b.dealer.IsBlackjack():
+		return b.settle(OutcomeLose), nil
+	}
+
+	return b, nil
+}
+
+// CanAct checks that the player may make a decision on the hand.
+func (b Blackjack) CanAct(playerID user.ID) error {
+	if b.IsFinished() {
+		return domain.ErrGameOver
+	}
+	if playerID != b.playerID {
+		return domain.ErrPlayerNotInGame
+	}
+	if b.status != domain.GameStatusInProgress {
+		return domain.ErrGameNotStarted
+	}
+	return nil
+}
+
+// CanDouble returns true while the player holds the first two cards.
+func (b Blackjack) CanDouble() bool {
+	return !b.IsFinished() && len(b.player) == initialCards
+}
+
+// Hit draws a card for the player. A bust loses the hand, 21 stands automatically.
+func (b Blackjack) Hit(playerID user.ID) (Blackjack, error) {
+	if err := b.CanAct(playerID); err != nil {
+		return Blackjack{}, err
+	}
+
+	var err error
+	if b.player, err = b.draw(Shoe(b.seed, b.id), b.player); err != nil {
+		return Blackjack{}, err
+	}
+
+	if b.player.IsBust() {
+		return b.settle(OutcomeLose), nil
+	}
+	if b.player.Value() == blackjack {
+		return b.playDealer()
+	}
+
+	return b, nil
+}
+
+// Stand ends the turn of the player and plays out the dealer hand.
+func (b Blackjack) Stand(playerID user.ID) (Blackjack, error) {
+	if err := b.CanAct(playerID); err != nil {
+		return Blackjack{}, err
+	}
+	return b.playDealer()
+}
+
+// Double doubles the stake, draws exactly one card and stands.
+// The extra stake is taken by the caller before the hand is doubled.
+func (b Blackjack) Double(playerID user.ID) (Blackjack, error) {
+	if err := b.CanAct(playerID); err != nil {
+		return Blackjack{}, err
+	}
+	if !b.CanDouble() {
+		return Blackjack{}, ErrCantDouble
+	}
+
+	var err error
+	if b.player, err = b.draw(Shoe(b.seed, b.id), b.player); err != nil {
+		return Blackjack{}, err
+	}
+	b.doubled = true
+
+	if b.player.IsBust() {
+		return b.settle(OutcomeLose), nil
+	}
+	return b.playDealer()
+}
+
+// Forfeit settles the hand in favour of the house, used when the player runs out of time.
+func (b Blackjack) Forfeit() Blackjack {
+	b.outcome = OutcomeLose
+	b.status = domain.GameStatusAbandoned
+	return b
+}
+
+// playDealer draws for the dealer until 17, soft 17 included, and compares the hands.
+func (b Blackjack) playDealer() (Blackjack, error) {
+	shoe := Shoe(b.seed, b.id)
+	for b.dealer.Value() < dealerStandsOn {
+		var err error
+		if b.dealer, err = b.draw(shoe, b.dealer); err != nil {
+			return Blackjack{}, err
+		}
+	}
+
+	player, dealer := b.player.Value(), b.dealer.Value()
+	switch {
+	case b.dealer.IsBust(), player > dealer:
+		return b.settle(OutcomeWin), nil
+	case player < dealer:
+		return b.settle(OutcomeLose), nil
+	default:
+		return b.settle(OutcomePush), nil
+	}
+}
+
+func (b *Blackjack) draw(shoe []Card, hand Hand) (Hand, error) {
+	if b.cursor >= len(shoe) {
+		return nil, ErrShoeExhausted
+	}
+	card := shoe[b.cursor]
+	b.cursor++
+	return append(hand, card), nil
+}
+
+func (b Blackjack) settle(outcome Outcome) Blackjack {
+	b.outcome = outcome
+	b.status = domain.GameStatusFinished
+	return b
+}
+
+func (b Blackjack) IsFinished() bool {
+	return b.outcome != OutcomeNone ||
+		b.status == domain.GameStatusCancelled ||
+		b.status == domain.GameStatusFinished ||
+		b.status == domain.GameStatusAbandoned
+}
+
+func (b Blackjack) IsDraw() bool {
+	return b.outcome == OutcomePush
+}
+
+// IsStarted returns true once the cards are dealt. The stake is committed from then on,
+// a player who sees a bad hand and walks away forfeits it instead of getting a refund.
+func (b Blackjack) IsStarted() bool {
+	return len(b.player) > 0 || b.IsFinished()
+}
+
+func (b Blackjack) AFKPlayerID() (user.ID, error) {
+	if b.IsFinished() {
+		return user.ID{}, domain.ErrAFKPlayerNotFound
+	}
+	return b.playerID, nil
+}
+
+func (b Blackjack) SetStatus(status domain.GameStatus) (Blackjack, error) {
+	if status.IsZero() {
+		return Blackjack{}, domain.ErrGameStatusRequired
+	}
+	if !status.IsValid() {
+		return Blackjack{}, domain.ErrInvalidGameStatus
+	}
+	b.status = status
+	return b, nil
+}
@@ -0,0 +1,216 @@
+package blackjack
+
+import (
+	"microgame-bot/internal/domain"
+	se "microgame-bot/internal/domain/session"
+	"microgame-bot/internal/domain/user"
+	"microgame-bot/internal/utils"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Cards of spades: rank 0 is the ace, rank 9 is the ten.
+const (
+	ace   Card = 0
+	two   Card = 1
+	five  Card = 4
+	six   Card = 5
+	seven Card = 6
+	nine  Card = 8
+	ten   Card = 9
+	king  Card = 12
+)
+
+func newGame(t *testing.T, seed string, opts ...Opt) (Blackjack, user.ID) {
+	t.Helper()
+	playerID := user.ID(utils.NewUniqueID())
+
+	game, err := New(append([]Opt{
+		WithNewID(),
+		WithCreatorID(playerID),
+		WithSessionID(se.ID(utils.NewUniqueID())),
+		WithSeed(seed),
+	}, opts...)...)
+	require.NoError(t, err)
+
+	return game, playerID
+}
+
+func TestHand_Value(t *testing.T) {
+	tests := []struct {
+		name      string
+		hand      Hand
+		value     int
+		soft      bool
+		blackjack bool
+		bust      bool
+	}{
+		{name: "natural", hand: Hand{ace, king}, value: 21, soft: true, blackjack: true},
+		{name: "soft seventeen", hand: Hand{ace, six}, value: 17, soft: true},
+		{name: "ace falls back to one", hand: Hand{ace, six, ten}, value: 17},
+		{name: "two aces", hand: Hand{ace, ace + suitSize, nine}, value: 21, soft: true},
+		{name: "three card twenty one", hand: Hand{seven, seven, seven}, value: 21},
+		{name: "bust", hand: Hand{ten, king, two}, value: 22, bust: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, soft := tt.hand.Total()
+			assert.Equal(t, tt.value, value)
+			assert.Equal(t, tt.soft, soft)
+			assert.Equal(t, tt.blackjack, tt.hand.IsBlackjack())
+			assert.Equal(t, tt.bust, tt.hand.IsBust())
+		})
+	}
+}
+
+func TestOutcome_Payout(t *testing.T) {
+	assert.Equal(t, domain.Token(250), OutcomeBlackjack.Payout(100))
+	assert.Equal(t, domain.Token(200), OutcomeWin.Payout(100))
+	assert.Equal(t, domain.Token(100), OutcomePush.Payout(100))
+	assert.Equal(t, domain.Token(0), OutcomeLose.Payout(100))
+	assert.Equal(t, domain.Token(0), OutcomeNone.Payout(100))
+}
+
+func TestShoe_DeterministicPermutation(t *testing.T) {
+	id := ID(utils.NewUniqueID())
+	shoe := Shoe("seed", id)
+	require.Len(t, shoe, ShoeDecks*deckSize)
+	assert.Equal(t, shoe, Shoe("seed", id))
+	assert.NotEqual(t, shoe, Shoe("other seed", id))
+
+	counts := make(map[Card]int)
+	for _, card := range shoe {
+		counts[card]++
+	}
+	require.Len(t, counts, deckSize)
+	for _, count := range counts {
+		assert.Equal(t, ShoeDecks, count)
+	}
+}
+
+func TestDeal(t *testing.T) {
+	for i := range 50 {
+		game, _ := newGame(t, "seed-"+strconv.Itoa(i))
+		require.False(t, game.IsStarted())
+
+		game, err := game.Deal()
+		require.NoError(t, err)
+		require.Len(t, game.PlayerHand(), 2)
+		require.Len(t, game.DealerHand(), 2)
+		assert.Equal(t, 4, game.Cursor())
+
+		shoe := Shoe(game.Seed(), game.ID())
+		assert.Equal(t, Hand{shoe[0], shoe[2]}, game.PlayerHand())
+		assert.Equal(t, Hand{shoe[1], shoe[3]}, game.DealerHand())
+
+		naturals := game.PlayerHand().IsBlackjack() || game.DealerHand().IsBlackjack()
+		assert.Equal(t, naturals, game.IsFinished())
+		assert.True(t, game.IsStarted())
+
+		_, err = game.Deal()
+		assert.Error(t, err)
+	}
+}
+
+func TestStand_DealerDrawsToSeventeen(t *testing.T) {
+	for i := range 50 {
+		game, playerID := newGame(t, "stand-"+strconv.Itoa(i),
+			WithStatus(domain.GameStatusInProgress),
+			WithPlayerHand(Hand{ten, seven + suitSize}),
+			WithDealerHand(Hand{ten + suitSize, two}),
+			WithCursor(4),
+		)
+
+		game, err := game.Stand(playerID)
+		require.NoError(t, err)
+		require.True(t, game.IsFinished())
+		assert.Equal(t, domain.GameStatusFinished, game.Status())
+		assert.GreaterOrEqual(t, game.DealerHand().Value(), 17)
+		assert.Equal(t, 4+len(game.DealerHand())-2, game.Cursor())
+
+		dealer := game.DealerHand().Value()
+		switch {
+		case dealer > 21:
+			assert.Equal(t, OutcomeWin, game.Outcome())
+			assert.Equal(t, []user.ID{playerID}, game.Winners())
+		case dealer == 17:
+			assert.Equal(t, OutcomePush, game.Outcome())
+			assert.True(t, game.IsDraw())
+		default:
+			assert.Equal(t, OutcomeLose, game.Outcome())
+			assert.Empty(t, game.Winners())
+		}
+	}
+}
+
+func TestHit_BustLoses(t *testing.T) {
+	game, playerID := newGame(t, "hit",
+		WithStatus(domain.GameStatusInProgress),
+		WithPlayerHand(Hand{ten, king, two}),
+		WithDealerHand(Hand{five, six}),
+		WithCursor(5),
+	)
+
+	for !game.IsFinished() {
+		var err error
+		game, err = game.Hit(playerID)
+		require.NoError(t, err)
+	}
+
+	if game.PlayerHand().IsBust() {
+		assert.Equal(t, OutcomeLose, game.Outcome())
+		assert.Len(t, game.DealerHand(), 2, "dealer does not draw when the player is bust")
+	} else {
+		assert.Equal(t, 21, game.PlayerHand().Value())
+	}
+}
+
+func TestDouble(t *testing.T) {
+	game, playerID := newGame(t, "double",
+		WithStatus(domain.GameStatusInProgress),
+		WithPlayerHand(Hand{five, six}),
+		WithDealerHand(Hand{ten, six}),
+		WithCursor(4),
+	)
+	require.True(t, game.CanDouble())
+
+	doubled, err := game.Double(playerID)
+	require.NoError(t, err)
+	assert.True(t, doubled.Doubled())
+	assert.Len(t, doubled.PlayerHand(), 3)
+	assert.True(t, doubled.IsFinished())
+
+	hit, err := game.Hit(playerID)
+	require.NoError(t, err)
+	if !hit.IsFinished() {
+		_, err = hit.Double(playerID)
+		assert.ErrorIs(t, err, ErrCantDouble)
+	}
+}
+
+func TestCanAct(t *testing.T) {
+	game, playerID := newGame(t, "act",
+		WithStatus(domain.GameStatusInProgress),
+		WithPlayerHand(Hand{five, six}),
+		WithDealerHand(Hand{ten, six}),
+		WithCursor(4),
+	)
+
+	_, err := game.Stand(user.ID(utils.NewUniqueID()))
+	require.ErrorIs(t, err, domain.ErrPlayerNotInGame)
+
+	afkID, err := game.AFKPlayerID()
+	require.NoError(t, err)
+	assert.Equal(t, playerID, afkID)
+
+	forfeited := game.Forfeit()
+	assert.True(t, forfeited.IsFinished())
+	assert.Equal(t, OutcomeLose, forfeited.Outcome())
+	assert.Equal(t, domain.GameStatusAbandoned, forfeited.Status())
+	_, err = forfeited.Hit(playerID)
+	assert.ErrorIs(t, err, domain.ErrGameOver)
+}
@@ -0,0 +1,11 @@
+package blackjack
+
+import "errors"
+
+var (
+	ErrSeedRequired  = errors.New("shoe seed required")
+	ErrAlreadyDealt  = errors.New("cards already dealt")
+	ErrCantDouble    = errors.New("double is allowed only on the first two cards")
+	ErrShoeExhausted = errors.New("shoe exhausted")
+	ErrInvalidAction = errors.New("invalid blackjack action")
+)
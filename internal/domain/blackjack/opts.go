@@ -0,0 +1,150 @@
+package blackjack
+
+import (
+	"fmt"
+	"microgame-bot/internal/core"
+	"microgame-bot/internal/domain"
+	se "microgame-bot/internal/domain/session"
+	"microgame-bot/internal/domain/user"
+	"microgame-bot/internal/utils"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Opt func(*Blackjack) error
+
+func WithID(id ID) Opt {
+	return func(b *Blackjack) error {
+		if id.IsZero() {
+			return domain.ErrIDRequired
+		}
+		b.id = id
+		return nil
+	}
+}
+
+func WithNewID() Opt {
+	return WithID(ID(utils.NewUniqueID()))
+}
+
+func WithIDFromString(id string) Opt {
+	return func(b *Blackjack) error {
+		idUUID, err := utils.UUIDFromString[ID](id)
+		if err != nil {
+			return fmt.Errorf("%w: %w", core.ErrFailedToParseID, err)
+		}
+		b.id = idUUID
+		return nil
+	}
+}
+
+func WithIDFromUUID(id uuid.UUID) Opt {
+	return WithID(ID(id))
+}
+
+func WithCreatorID(creatorID user.ID) Opt {
+	return func(b *Blackjack) error {
+		if creatorID.IsZero() {
+			return domain.ErrCreatorIDRequired
+		}
+		b.creatorID = creatorID
+		return nil
+	}
+}
+
+func WithPlayerID(playerID user.ID) Opt {
+	return func(b *Blackjack) error {
+		b.playerID = playerID
+		return nil
+	}
+}
+
+func WithPlayerIDFromUUID(playerID uuid.UUID) Opt {
+	return WithPlayerID(user.ID(playerID))
+}
+
+func WithSeed(seed string) Opt {
+	return func(b *Blackjack) error {
+		if seed == "" {
+			return ErrSeedRequired
+		}
+		b.seed = seed
+		return nil
+	}
+}
+
+func WithCursor(cursor int) Opt {
+	return func(b *Blackjack) error {
+		b.cursor = cursor
+		return nil
+	}
+}
+
+func WithPlayerHand(hand Hand) Opt {
+	return func(b *Blackjack) error {
+		b.player = hand
+		return nil
+	}
+}
+
+func WithDealerHand(hand Hand) Opt {
+	return func(b *Blackjack) error {
+		b.dealer = hand
+		return nil
+	}
+}
+
+func WithDoubled(doubled bool) Opt {
+	return func(b *Blackjack) error {
+		b.doubled = doubled
+		return nil
+	}
+}
+
+func WithOutcome(outcome Outcome) Opt {
+	return func(b *Blackjack) error {
+		b.outcome = outcome
+		return nil
+	}
+}
+
+func WithStatus(status domain.GameStatus) Opt {
+	return func(b *Blackjack) error {
+		if status.IsZero() {
+			return domain.ErrGameStatusRequired
+		}
+		if !status.IsValid() {
+			return domain.ErrInvalidGameStatus
+		}
+		b.status = status
+		return nil
+	}
+}
+
+func WithCreatedAt(createdAt time.Time) Opt {
+	return func(b *Blackjack) error {
+		if createdAt.IsZero() {
+			return domain.ErrCreatedAtRequired
+		}
+		b.createdAt = createdAt
+		return nil
+	}
+}
+
+func WithUpdatedAt(updatedAt time.Time) Opt {
+	return func(b *Blackjack) error {
+		if updatedAt.IsZero() {
+			return domain.ErrUpdatedAtRequired
+		}
+		b.updatedAt = updatedAt
+		return nil
+	}
+}
+
+func WithSessionID(sessionID se.ID) Opt {
+	return func(b *Blackjack) error {
+		b.sessionID = sessionID
+		return nil
+	}
+}
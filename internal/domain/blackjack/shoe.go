@@ -0,0 +1,29 @@
+package blackjack
+
+import (
+	"microgame-bot/internal/utils"
+	"strconv"
+)
+
+// ShoeDecks is the number of decks shuffled together into a shoe.
+const ShoeDecks = 6
+
+// Shoe returns the shuffled shoe of the game. The order is derived from the session seed,
+// so once the seed is revealed anyone can rebuild the shoe and check every card dealt:
+// it is a Fisher-Yates shuffle of ShoeDecks ordered decks,
+// where position i is swapped with SeededRandInt(seed, "<gameID>:<i>", i+1).
+func Shoe(seed string, gameID ID) []Card {
+	shoe := make([]Card, 0, ShoeDecks*deckSize)
+	for range ShoeDecks {
+		for card := range deckSize {
+			shoe = append(shoe, Card(card))
+		}
+	}
+
+	key := gameID.String() + ":"
+	for i := len(shoe) - 1; i > 0; i-- {
+		j := utils.SeededRandInt(seed, key+strconv.Itoa(i), i+1)
+		shoe[i], shoe[j] = shoe[j], shoe[i]
+	}
+	return shoe
+}
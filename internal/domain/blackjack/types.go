@@ -0,0 +1,139 @@
+package blackjack
+
+import "microgame-bot/internal/domain"
+
+// Card is an index of a card in a single deck: suit * 13 + rank, where rank 0 is the ace.
+type Card uint8
+
+const (
+	deckSize   = 52
+	suitSize   = 13
+	aceRank    = 0
+	tenRank    = 9
+	blackjack  = 21
+	softAceAdd = 10
+	// dealerStandsOn is the total the dealer stops drawing at, soft 17 included.
+	dealerStandsOn = 17
+	initialCards   = 2
+)
+
+//nolint:gochecknoglobals // Card faces are constant.
+var (
+	rankLabels = [suitSize]string{"A", "2", "3", "4", "5", "6", "7", "8", "9", "10", "J", "Q", "K"}
+	suitLabels = [4]string{"♠", "♥", "♦", "♣"}
+)
+
+func (c Card) rank() int { return int(c) % suitSize }
+func (c Card) suit() int { return int(c) / suitSize % len(suitLabels) }
+
+// Points returns the value of the card with the ace counted as one.
+func (c Card) Points() int {
+	if c.rank() >= tenRank {
+		return softAceAdd
+	}
+	return c.rank() + 1
+}
+
+func (c Card) IsAce() bool {
+	return c.rank() == aceRank
+}
+
+func (c Card) String() string {
+	return rankLabels[c.rank()] + suitLabels[c.suit()]
+}
+
+type Hand []Card
+
+// Total returns the best total of the hand and whether an ace is counted as eleven.
+func (h Hand) Total() (int, bool) {
+	total := 0
+	hasAce := false
+	for _, card := range h {
+		total += card.Points()
+		if card.IsAce() {
+			hasAce = true
+		}
+	}
+	if hasAce && total+softAceAdd <= blackjack {
+		return total + softAceAdd, true
+	}
+	return total, false
+}
+
+// Value returns the best total of the hand.
+func (h Hand) Value() int {
+	total, _ := h.Total()
+	return total
+}
+
+// IsBlackjack returns true for a natural: 21 with the first two cards.
+func (h Hand) IsBlackjack() bool {
+	return len(h) == initialCards && h.Value() == blackjack
+}
+
+func (h Hand) IsBust() bool {
+	return h.Value() > blackjack
+}
+
+func (h Hand) String() string {
+	s := ""
+	for i, card := range h {
+		if i > 0 {
+			s += " "
+		}
+		s += card.String()
+	}
+	return s
+}
+
+type Outcome string
+
+const (
+	OutcomeNone      Outcome = ""
+	OutcomeBlackjack Outcome = "blackjack"
+	OutcomeWin       Outcome = "win"
+	OutcomePush      Outcome = "push"
+	OutcomeLose      Outcome = "lose"
+)
+
+func (o Outcome) String() string {
+	return string(o)
+}
+
+// Payout returns the amount returned to the player for the staked tokens:
+// a natural pays 3:2, a win pays 1:1, a push returns the stake.
+func (o Outcome) Payout(staked domain.Token) domain.Token {
+	switch o {
+	case OutcomeBlackjack:
+		//nolint:mnd // Blackjack pays 3:2.
+		return staked + staked*3/2
+	case OutcomeWin:
+		//nolint:mnd // Even money.
+		return staked * 2
+	case OutcomePush:
+		return staked
+	default:
+		return 0
+	}
+}
+
+type Action string
+
+const (
+	ActionHit    Action = "hit"
+	ActionStand  Action = "stand"
+	ActionDouble Action = "double"
+)
+
+func (a Action) String() string {
+	return string(a)
+}
+
+func ActionFromString(action string) (Action, error) {
+	switch Action(action) {
+	case ActionHit, ActionStand, ActionDouble:
+		return Action(action), nil
+	default:
+		return "", ErrInvalidAction
+	}
+}
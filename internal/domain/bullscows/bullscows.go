@@ -0,0 +1,415 @@
+package bullscows
+
+import (
+	"microgame-bot/internal/domain"
+	se "microgame-bot/internal/domain/session"
+	"microgame-bot/internal/domain/user"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BullsCows is a number guessing duel. Players set their secret numbers in private,
+// then take turns guessing the number of the opponent and learn the bulls and cows of every guess.
+// The first player guesses first, so a hit by the first player gives the second one the last chance
+// to hit back and draw. The game is a draw as well when both players run out of guesses.
+// Numbers are typed digit by digit on the keypad, the digits typed so far are kept as the draft of the player.
+type BullsCows struct {
+	createdAt time.Time
+	updatedAt time.Time
+	status    domain.GameStatus
+	secret1   Number
+	secret2   Number
+	draft1    Number
+	draft2    Number
+	guesses   []Guess
+	turn      user.ID
+	winnerID  user.ID
+	sessionID se.ID
+	id        ID
+	player1ID user.ID
+	player2ID user.ID
+	creatorID user.ID
+}
+
+func New(opts ...Opt) (BullsCows, error) {
+	g := &BullsCows{
+		status: domain.GameStatusCreated,
+	}
+
+	for _, opt := range opts {
+		if err := opt(g); err != nil {
+			return BullsCows{}, err
+		}
+	}
+
+	// Validate required fields
+	if g.id.IsZero() {
+		return BullsCows{}, domain.ErrIDRequired
+	}
+	if g.sessionID.IsZero() {
+		return BullsCows{}, domain.ErrSessionIDRequired
+	}
+	if g.creatorID.IsZero() {
+		return BullsCows{}, domain.ErrCreatorIDRequired
+	}
+	if (g.player1ID.IsZero() || g.player2ID.IsZero()) &&
+		g.status != domain.GameStatusCreated &&
+		g.status != domain.GameStatusWaitingForPlayers &&
+		g.status != domain.GameStatusCancelled {
+		return BullsCows{}, domain.ErrCantPlayWithoutPlayers
+	}
+
+	return *g, nil
+}
+
+func (g BullsCows) ID() ID                    { return g.id }
+func (g BullsCows) CreatorID() user.ID        { return g.creatorID }
+func (g BullsCows) Player1ID() user.ID        { return g.player1ID }
+func (g BullsCows) Player2ID() user.ID        { return g.player2ID }
+func (g BullsCows) Guesses() []Guess          { return slices.Clone(g.guesses) }
+func (g BullsCows) Turn() user.ID             { return g.turn }
+func (g BullsCows) Status() domain.GameStatus { return g.status }
+func (g BullsCows) CreatedAt() time.Time      { return g.createdAt }
+func (g BullsCows) UpdatedAt() time.Time      { return g.updatedAt }
+func (g BullsCows) SessionID() se.ID          { return g.sessionID }
+func (g BullsCows) IDtoUUID() uuid.UUID       { return uuid.UUID(g.id) }
+func (g BullsCows) Type() domain.GameType     { return domain.GameTypeBullsCows }
+
+// Secret1 and Secret2 are meant for the storage and the reveal of a finished game only,
+// a secret of a game in progress may only be shown to its owner, see SecretOf.
+func (g BullsCows) Secret1() Number { return g.secret1 }
+func (g BullsCows) Secret2() Number { return g.secret2 }
+
+// Draft1 and Draft2 follow the same rule: a draft of a secret may only be shown to its owner.
+func (g BullsCows) Draft1() Number { return g.draft1 }
+func (g BullsCows) Draft2() Number { return g.draft2 }
+
+func (g BullsCows) Participants() []user.ID {
+	participants := []user.ID{}
+	if !g.player1ID.IsZero() {
+		participants = append(participants, g.player1ID)
+	}
+	if !g.player2ID.IsZero() {
+		participants = append(participants, g.player2ID)
+	}
+	return participants
+}
+
+// SecretOf returns the secret number the player has set.
+func (g BullsCows) SecretOf(playerID user.ID) Number {
+	if playerID == g.player2ID {
+		return g.secret2
+	}
+	return g.secret1
+}
+
+// DraftOf returns the digits the player has typed so far.
+func (g BullsCows) DraftOf(playerID user.ID) Number {
+	if playerID == g.player2ID {
+		return g.draft2
+	}
+	return g.draft1
+}
+
+func (g BullsCows) OpponentOf(playerID user.ID) user.ID {
+	if playerID == g.player1ID {
+		return g.player2ID
+	}
+	return g.player1ID
+}
+
+// GuessesOf returns the guesses of the player in the order they were made.
+func (g BullsCows) GuessesOf(playerID user.ID) []Guess {
+	guesses := []Guess{}
+	for _, guess := range g.guesses {
+		if guess.PlayerID == playerID {
+			guesses = append(guesses, guess)
+		}
+	}
+	return guesses
+}
+
+// GuessesLeft returns the number of guesses the player may still make.
+func (g BullsCows) GuessesLeft(playerID user.ID) int {
+	return max(MaxGuesses-len(g.GuessesOf(playerID)), 0)
+}
+
+func (g BullsCows) IsReady(playerID user.ID) bool {
+	switch playerID {
+	case g.player1ID:
+		return g.secret1 != ""
+	case g.player2ID:
+		return g.secret2 != ""
+	default:
+		return false
+	}
+}
+
+// IsSetting returns true while the players are still setting their secrets.
+func (g BullsCows) IsSetting() bool {
+	return g.status == domain.GameStatusInProgress && (g.secret1 == "" || g.secret2 == "")
+}
+
+func (g BullsCows) JoinGame(playerID user.ID) (BullsCows, error) {
+	if g.IsFinished() {
+		return BullsCows{}, domain.ErrGameOver
+	}
+
+	if !g.player1ID.IsZero() && !g.player2ID.IsZero() {
+		return BullsCows{}, domain.ErrGameFull
+	}
+
+	if g.player1ID == playerID || g.player2ID == playerID {
+		return BullsCows{}, domain.ErrPlayerAlreadyInGame
+	}
+
+	if g.player1ID.IsZero() {
+		g.player1ID = playerID
+		// Status remains WaitingForPlayers
+		return g, nil
+	}
+
+	g.player2ID = playerID
+	g.status = domain.GameStatusInProgress
+
+	return g, nil
+}
+
+// Cancel withdraws the game on behalf of its creator.
+// Only a game that is still waiting for players can be cancelled.
+func (g BullsCows) Cancel(userID user.ID) (BullsCows, error) {
+	if g.creatorID != userID {
+		return BullsCows{}, domain.ErrNotGameCreator
+	}
+	if g.status != domain.GameStatusWaitingForPlayers {
+		return BullsCows{}, domain.ErrGameAlreadyStarted
+	}
+
+	g.status = domain.GameStatusCancelled
+	return g, nil
+}
+
+// CanSetSecret checks that the player may still set the secret.
+func (g BullsCows) CanSetSecret(playerID user.ID) error {
+	if g.IsFinished() {
+		return domain.ErrGameOver
+	}
+	if playerID != g.player1ID && playerID != g.player2ID {
+		return domain.ErrPlayerNotInGame
+	}
+	if g.status != domain.GameStatusInProgress {
+		return domain.ErrGameNotStarted
+	}
+	if !g.IsSetting() {
+		return ErrSettingOver
+	}
+	if g.IsReady(playerID) {
+		return ErrSecretAlreadySet
+	}
+	return nil
+}
+
+// SetSecret sets the secret of the player, it can't be changed afterwards.
+// Once both secrets are set the first player guesses.
+func (g BullsCows) SetSecret(playerID user.ID, secret Number) (BullsCows, error) {
+	if err := g.CanSetSecret(playerID); err != nil {
+		return BullsCows{}, err
+	}
+	if err := secret.Validate(); err != nil {
+		return BullsCows{}, err
+	}
+
+	if playerID == g.player1ID {
+		g.secret1 = secret
+	} else {
+		g.secret2 = secret
+	}
+	g = g.withDraft(playerID, "")
+	if g.secret1 != "" && g.secret2 != "" {
+		g.turn = g.player1ID
+	}
+	return g, nil
+}
+
+// CanGuess checks that the player may guess now.
+func (g BullsCows) CanGuess(playerID user.ID) error {
+	if g.IsFinished() {
+		return domain.ErrGameOver
+	}
+	if playerID != g.player1ID && playerID != g.player2ID {
+		return domain.ErrPlayerNotInGame
+	}
+	if g.status != domain.GameStatusInProgress || g.IsSetting() {
+		return domain.ErrGameNotStarted
+	}
+	if g.turn != playerID {
+		return domain.ErrNotPlayersTurn
+	}
+	return nil
+}
+
+// Guess scores the number against the secret of the opponent and passes the turn.
+func (g BullsCows) Guess(playerID user.ID, number Number) (BullsCows, Guess, error) {
+	if err := g.CanGuess(playerID); err != nil {
+		return BullsCows{}, Guess{}, err
+	}
+	if err := number.Validate(); err != nil {
+		return BullsCows{}, Guess{}, err
+	}
+	for _, guess := range g.GuessesOf(playerID) {
+		if guess.Number == number {
+			return BullsCows{}, Guess{}, ErrAlreadyGuessed
+		}
+	}
+
+	opponentID := g.OpponentOf(playerID)
+	bulls, cows := Score(g.SecretOf(opponentID), number)
+	guess := Guess{
+		PlayerID: playerID,
+		Number:   number,
+		Bulls:    bulls,
+		Cows:     cows,
+	}
+	g.guesses = append(slices.Clone(g.guesses), guess)
+	g = g.withDraft(playerID, "")
+
+	// The first player has made one guess more, the second one may still catch up
+	if playerID == g.player1ID {
+		g.turn = opponentID
+		return g, guess, nil
+	}
+
+	hit1, hit2 := g.hasHit(g.player1ID), guess.IsHit()
+	switch {
+	case hit1 && hit2:
+		g.status = domain.GameStatusFinished
+	case hit1:
+		g.winnerID = g.player1ID
+		g.status = domain.GameStatusFinished
+	case hit2:
+		g.winnerID = g.player2ID
+		g.status = domain.GameStatusFinished
+	case g.GuessesLeft(g.player2ID) == 0:
+		g.status = domain.GameStatusFinished
+	default:
+		g.turn = opponentID
+	}
+	return g, guess, nil
+}
+
+// canType checks that the player may type on the keypad: the secret while setting it, a guess on the own turn.
+func (g BullsCows) canType(playerID user.ID) error {
+	if g.IsSetting() {
+		return g.CanSetSecret(playerID)
+	}
+	return g.CanGuess(playerID)
+}
+
+// TypeDigit adds the digit to the draft of the player.
+func (g BullsCows) TypeDigit(playerID user.ID, digit int) (BullsCows, error) {
+	if err := g.canType(playerID); err != nil {
+		return BullsCows{}, err
+	}
+	draft, err := g.DraftOf(playerID).Append(digit)
+	if err != nil {
+		return BullsCows{}, err
+	}
+	return g.withDraft(playerID, draft), nil
+}
+
+// EraseDigit removes the last digit of the draft of the player.
+func (g BullsCows) EraseDigit(playerID user.ID) (BullsCows, error) {
+	if err := g.canType(playerID); err != nil {
+		return BullsCows{}, err
+	}
+	return g.withDraft(playerID, g.DraftOf(playerID).Erase()), nil
+}
+
+// DraftRandomly replaces the draft of the secret with a random number. randInt returns a number in [0, n).
+func (g BullsCows) DraftRandomly(playerID user.ID, randInt func(n int) int) (BullsCows, error) {
+	if err := g.CanSetSecret(playerID); err != nil {
+		return BullsCows{}, err
+	}
+	return g.withDraft(playerID, RandomNumber(randInt)), nil
+}
+
+func (g BullsCows) withDraft(playerID user.ID, draft Number) BullsCows {
+	if playerID == g.player1ID {
+		g.draft1 = draft
+	} else {
+		g.draft2 = draft
+	}
+	return g
+}
+
+// hasHit returns true if the player has guessed the secret of the opponent.
+func (g BullsCows) hasHit(playerID user.ID) bool {
+	return slices.ContainsFunc(g.GuessesOf(playerID), Guess.IsHit)
+}
+
+func (g BullsCows) IsFinished() bool {
+	return !g.winnerID.IsZero() ||
+		g.status == domain.GameStatusCancelled ||
+		g.status == domain.GameStatusFinished ||
+		g.status == domain.GameStatusAbandoned
+}
+
+// IsDraw returns true if both players have hit in the same number of guesses or nobody has hit at all.
+func (g BullsCows) IsDraw() bool {
+	return g.status == domain.GameStatusFinished && g.winnerID.IsZero()
+}
+
+func (g BullsCows) Winners() []user.ID {
+	if g.winnerID.IsZero() {
+		return []user.ID{}
+	}
+	return []user.ID{g.winnerID}
+}
+
+func (g BullsCows) WinnerID() user.ID {
+	return g.winnerID
+}
+
+// IsStarted returns true once a secret is set.
+func (g BullsCows) IsStarted() bool {
+	return g.secret1 != "" || g.secret2 != ""
+}
+
+func (g BullsCows) SetWinner(winnerID user.ID) (BullsCows, error) {
+	if winnerID != g.player1ID && winnerID != g.player2ID {
+		return BullsCows{}, domain.ErrPlayerNotInGame
+	}
+	g.winnerID = winnerID
+	return g, nil
+}
+
+// AFKPlayerID returns the player holding the game up: the one setting the secret or the one to guess.
+func (g BullsCows) AFKPlayerID() (user.ID, error) {
+	if g.IsSetting() {
+		switch {
+		case g.secret1 == "" && g.secret2 == "":
+			return user.ID{}, domain.ErrAllPlayersAFK
+		case g.secret1 == "":
+			return g.player1ID, nil
+		default:
+			return g.player2ID, nil
+		}
+	}
+	if g.turn.IsZero() {
+		return user.ID{}, domain.ErrAFKPlayerNotFound
+	}
+	return g.turn, nil
+}
+
+func (g BullsCows) SetStatus(status domain.GameStatus) (BullsCows, error) {
+	if status.IsZero() {
+		return BullsCows{}, domain.ErrGameStatusRequired
+	}
+	if !status.IsValid() {
+		return BullsCows{}, domain.ErrInvalidGameStatus
+	}
+	g.status = status
+	return g, nil
+}
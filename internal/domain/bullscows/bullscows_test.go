@@ -0,0 +1,234 @@
+package bullscows
+
+import (
+	"math/rand/v2"
+	"microgame-bot/internal/domain"
+	se "microgame-bot/internal/domain/session"
+	"microgame-bot/internal/domain/user"
+	"microgame-bot/internal/utils"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSettingGame(t *testing.T) (BullsCows, user.ID, user.ID) {
+	t.Helper()
+	player1 := user.ID(utils.NewUniqueID())
+	player2 := user.ID(utils.NewUniqueID())
+
+	game, err := New(
+		WithNewID(),
+		WithCreatorID(player1),
+		WithSessionID(se.ID(utils.NewUniqueID())),
+		WithStatus(domain.GameStatusWaitingForPlayers),
+	)
+	require.NoError(t, err)
+	game, err = game.JoinGame(player1)
+	require.NoError(t, err)
+	game, err = game.JoinGame(player2)
+	require.NoError(t, err)
+	require.True(t, game.IsSetting())
+
+	return game, player1, player2
+}
+
+func newGuessingGame(t *testing.T, secret1 Number, secret2 Number) (BullsCows, user.ID, user.ID) {
+	t.Helper()
+	game, player1, player2 := newSettingGame(t)
+	game, err := game.SetSecret(player1, secret1)
+	require.NoError(t, err)
+	game, err = game.SetSecret(player2, secret2)
+	require.NoError(t, err)
+	require.False(t, game.IsSetting())
+	return game, player1, player2
+}
+
+func TestNumber(t *testing.T) {
+	number := Number("")
+	for _, digit := range []int{0, 4, 7} {
+		var err error
+		number, err = number.Append(digit)
+		require.NoError(t, err)
+	}
+	_, err := number.Append(4)
+	require.ErrorIs(t, err, ErrRepeatedDigit)
+	_, err = number.Append(DigitsCount)
+	require.ErrorIs(t, err, ErrInvalidDigit)
+
+	number, err = number.Append(1)
+	require.NoError(t, err)
+	assert.Equal(t, Number("0471"), number)
+	_, err = number.Append(2)
+	require.ErrorIs(t, err, ErrInvalidNumber, "number is complete")
+	assert.Equal(t, Number("047"), number.Erase())
+
+	for _, invalid := range []string{"", "123", "12345", "12a4", "1231"} {
+		_, err := NumberFromString(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestScore(t *testing.T) {
+	tests := []struct {
+		secret, guess Number
+		bulls, cows   int
+	}{
+		{"1234", "1234", 4, 0},
+		{"1234", "4321", 0, 4},
+		{"1234", "1243", 2, 2},
+		{"1234", "5678", 0, 0},
+		{"0918", "8019", 1, 3},
+	}
+	for _, tt := range tests {
+		bulls, cows := Score(tt.secret, tt.guess)
+		assert.Equal(t, tt.bulls, bulls, "%s vs %s", tt.secret, tt.guess)
+		assert.Equal(t, tt.cows, cows, "%s vs %s", tt.secret, tt.guess)
+	}
+}
+
+func TestRandomNumber(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	for range 100 {
+		assert.NoError(t, RandomNumber(rng.IntN).Validate())
+	}
+}
+
+func TestBullsCows_SetSecret(t *testing.T) {
+	game, player1, player2 := newSettingGame(t)
+
+	_, err := game.SetSecret(player1, "1123")
+	require.ErrorIs(t, err, ErrRepeatedDigit)
+	_, _, err = game.Guess(player1, "1234")
+	require.ErrorIs(t, err, domain.ErrGameNotStarted, "can't guess before both secrets are set")
+
+	game, err = game.SetSecret(player1, "1234")
+	require.NoError(t, err)
+	_, err = game.SetSecret(player1, "5678")
+	require.ErrorIs(t, err, ErrSecretAlreadySet)
+	assert.True(t, game.Turn().IsZero())
+
+	afk, err := game.AFKPlayerID()
+	require.NoError(t, err)
+	assert.Equal(t, player2, afk, "the player without a secret holds the game up")
+
+	game, err = game.SetSecret(player2, "5678")
+	require.NoError(t, err)
+	assert.Equal(t, player1, game.Turn())
+	assert.True(t, game.IsStarted())
+
+	_, err = game.SetSecret(player2, "0123")
+	assert.ErrorIs(t, err, ErrSettingOver)
+}
+
+func TestBullsCows_Guess(t *testing.T) {
+	game, player1, player2 := newGuessingGame(t, "1234", "5678")
+
+	_, _, err := game.Guess(player2, "1234")
+	require.ErrorIs(t, err, domain.ErrNotPlayersTurn)
+
+	game, guess, err := game.Guess(player1, "5687")
+	require.NoError(t, err)
+	assert.Equal(t, 2, guess.Bulls)
+	assert.Equal(t, 2, guess.Cows)
+	assert.Equal(t, player2, game.Turn())
+
+	afk, err := game.AFKPlayerID()
+	require.NoError(t, err)
+	assert.Equal(t, player2, afk)
+
+	game, _, err = game.Guess(player2, "1230")
+	require.NoError(t, err)
+	_, _, err = game.Guess(player1, "5687")
+	require.ErrorIs(t, err, ErrAlreadyGuessed)
+
+	// A hit of the first player gives the second one the last chance
+	game, guess, err = game.Guess(player1, "5678")
+	require.NoError(t, err)
+	assert.True(t, guess.IsHit())
+	assert.False(t, game.IsFinished())
+	assert.Equal(t, player2, game.Turn())
+
+	game, _, err = game.Guess(player2, "1243")
+	require.NoError(t, err)
+	assert.True(t, game.IsFinished())
+	assert.Equal(t, player1, game.WinnerID())
+}
+
+func TestBullsCows_Guess_LastChanceDraw(t *testing.T) {
+	game, player1, player2 := newGuessingGame(t, "1234", "5678")
+
+	game, _, err := game.Guess(player1, "5678")
+	require.NoError(t, err)
+	game, _, err = game.Guess(player2, "1234")
+	require.NoError(t, err)
+
+	assert.True(t, game.IsFinished())
+	assert.True(t, game.IsDraw())
+	assert.Empty(t, game.Winners())
+}
+
+func TestBullsCows_Guess_SecondPlayerWins(t *testing.T) {
+	game, player1, player2 := newGuessingGame(t, "1234", "5678")
+
+	game, _, err := game.Guess(player1, "9012")
+	require.NoError(t, err)
+	game, _, err = game.Guess(player2, "1234")
+	require.NoError(t, err)
+
+	assert.True(t, game.IsFinished())
+	assert.Equal(t, player2, game.WinnerID())
+}
+
+func TestBullsCows_Guess_OutOfGuesses(t *testing.T) {
+	game, player1, player2 := newGuessingGame(t, "1234", "5678")
+
+	misses := []Number{"0129", "0139", "0149", "0159", "0169", "0179", "0189", "0198", "0197", "0196"}
+	require.Len(t, misses, MaxGuesses)
+	for _, miss := range misses {
+		var err error
+		game, _, err = game.Guess(player1, miss)
+		require.NoError(t, err)
+		game, _, err = game.Guess(player2, miss)
+		require.NoError(t, err)
+	}
+
+	assert.True(t, game.IsFinished())
+	assert.True(t, game.IsDraw())
+	assert.Zero(t, game.GuessesLeft(player1))
+	assert.Zero(t, game.GuessesLeft(player2))
+}
+
+func TestBullsCows_TypeDigit(t *testing.T) {
+	game, player1, player2 := newSettingGame(t)
+
+	for _, digit := range []int{1, 2, 3, 4} {
+		var err error
+		game, err = game.TypeDigit(player1, digit)
+		require.NoError(t, err)
+	}
+	game, err := game.EraseDigit(player1)
+	require.NoError(t, err)
+	game, err = game.TypeDigit(player1, 5)
+	require.NoError(t, err)
+	assert.Equal(t, Number("1235"), game.DraftOf(player1))
+	assert.Empty(t, game.DraftOf(player2), "drafts are kept per player")
+
+	game, err = game.SetSecret(player1, game.DraftOf(player1))
+	require.NoError(t, err)
+	assert.Empty(t, game.DraftOf(player1), "the draft is cleared once the secret is set")
+	_, err = game.TypeDigit(player1, 6)
+	require.ErrorIs(t, err, ErrSecretAlreadySet)
+
+	game, err = game.DraftRandomly(player2, rand.New(rand.NewPCG(1, 2)).IntN)
+	require.NoError(t, err)
+	game, err = game.SetSecret(player2, game.DraftOf(player2))
+	require.NoError(t, err)
+
+	// Once both secrets are set only the player to guess may type
+	_, err = game.TypeDigit(player2, 1)
+	require.ErrorIs(t, err, domain.ErrNotPlayersTurn)
+	game, err = game.TypeDigit(player1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, Number("1"), game.DraftOf(player1))
+}
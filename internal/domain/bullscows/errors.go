@@ -0,0 +1,13 @@
+package bullscows
+
+import "errors"
+
+var (
+	ErrInvalidNumber    = errors.New("number must have 4 digits")
+	ErrRepeatedDigit    = errors.New("digits of the number must not repeat")
+	ErrInvalidDigit     = errors.New("invalid digit")
+	ErrAlreadyGuessed   = errors.New("number is already guessed")
+	ErrSecretAlreadySet = errors.New("secret number is already set")
+	ErrSettingOver      = errors.New("secret numbers are already set")
+	ErrChatRequired     = errors.New("private chat with the bot required")
+)
@@ -0,0 +1,186 @@
+package bullscows
+
+import (
+	"fmt"
+	"microgame-bot/internal/core"
+	"microgame-bot/internal/domain"
+	se "microgame-bot/internal/domain/session"
+	"microgame-bot/internal/domain/user"
+	"microgame-bot/internal/utils"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Opt func(*BullsCows) error
+
+func WithID(id ID) Opt {
+	return func(g *BullsCows) error {
+		if id.IsZero() {
+			return domain.ErrIDRequired
+		}
+		g.id = id
+		return nil
+	}
+}
+
+func WithNewID() Opt {
+	return WithID(ID(utils.NewUniqueID()))
+}
+
+func WithIDFromString(id string) Opt {
+	return func(g *BullsCows) error {
+		idUUID, err := utils.UUIDFromString[ID](id)
+		if err != nil {
+			return fmt.Errorf("%w: %w", core.ErrFailedToParseID, err)
+		}
+		g.id = idUUID
+		return nil
+	}
+}
+
+func WithIDFromUUID(id uuid.UUID) Opt {
+	return WithID(ID(id))
+}
+
+func WithCreatorID(creatorID user.ID) Opt {
+	return func(g *BullsCows) error {
+		if creatorID.IsZero() {
+			return domain.ErrCreatorIDRequired
+		}
+		g.creatorID = creatorID
+		return nil
+	}
+}
+
+func WithPlayer1ID(player1ID user.ID) Opt {
+	return func(g *BullsCows) error {
+		g.player1ID = player1ID
+		return nil
+	}
+}
+
+func WithPlayer1IDFromUUID(player1ID uuid.UUID) Opt {
+	return WithPlayer1ID(user.ID(player1ID))
+}
+
+func WithPlayer2ID(player2ID user.ID) Opt {
+	return func(g *BullsCows) error {
+		g.player2ID = player2ID
+		return nil
+	}
+}
+
+func WithPlayer2IDFromUUID(player2ID uuid.UUID) Opt {
+	return WithPlayer2ID(user.ID(player2ID))
+}
+
+// WithSecret1 restores the secret of the first player, an empty one means it is not set yet.
+func WithSecret1(secret Number) Opt {
+	return func(g *BullsCows) error {
+		if secret != "" {
+			if err := secret.Validate(); err != nil {
+				return err
+			}
+		}
+		g.secret1 = secret
+		return nil
+	}
+}
+
+// WithSecret2 restores the secret of the second player, an empty one means it is not set yet.
+func WithSecret2(secret Number) Opt {
+	return func(g *BullsCows) error {
+		if secret != "" {
+			if err := secret.Validate(); err != nil {
+				return err
+			}
+		}
+		g.secret2 = secret
+		return nil
+	}
+}
+
+func WithDraft1(draft Number) Opt {
+	return func(g *BullsCows) error {
+		g.draft1 = draft
+		return nil
+	}
+}
+
+func WithDraft2(draft Number) Opt {
+	return func(g *BullsCows) error {
+		g.draft2 = draft
+		return nil
+	}
+}
+
+func WithGuesses(guesses []Guess) Opt {
+	return func(g *BullsCows) error {
+		g.guesses = slices.Clone(guesses)
+		return nil
+	}
+}
+
+func WithTurn(turn user.ID) Opt {
+	return func(g *BullsCows) error {
+		g.turn = turn
+		return nil
+	}
+}
+
+func WithTurnFromUUID(turn uuid.UUID) Opt {
+	return WithTurn(user.ID(turn))
+}
+
+func WithStatus(status domain.GameStatus) Opt {
+	return func(g *BullsCows) error {
+		if status.IsZero() {
+			return domain.ErrGameStatusRequired
+		}
+		if !status.IsValid() {
+			return domain.ErrInvalidGameStatus
+		}
+		g.status = status
+		return nil
+	}
+}
+
+func WithWinnerID(winnerID user.ID) Opt {
+	return func(g *BullsCows) error {
+		g.winnerID = winnerID
+		return nil
+	}
+}
+
+func WithWinnerIDFromUUID(winnerID uuid.UUID) Opt {
+	return WithWinnerID(user.ID(winnerID))
+}
+
+func WithCreatedAt(createdAt time.Time) Opt {
+	return func(g *BullsCows) error {
+		if createdAt.IsZero() {
+			return domain.ErrCreatedAtRequired
+		}
+		g.createdAt = createdAt
+		return nil
+	}
+}
+
+func WithUpdatedAt(updatedAt time.Time) Opt {
+	return func(g *BullsCows) error {
+		if updatedAt.IsZero() {
+			return domain.ErrUpdatedAtRequired
+		}
+		g.updatedAt = updatedAt
+		return nil
+	}
+}
+
+func WithSessionID(sessionID se.ID) Opt {
+	return func(g *BullsCows) error {
+		g.sessionID = sessionID
+		return nil
+	}
+}
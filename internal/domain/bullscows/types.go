@@ -0,0 +1,119 @@
+package bullscows
+
+import (
+	"microgame-bot/internal/domain/user"
+	"strconv"
+	"strings"
+)
+
+const (
+	// SecretLength is the number of digits in the secret and in every guess.
+	SecretLength = 4
+	// MaxGuesses is the number of guesses each player has in a game, after that the game is a draw.
+	MaxGuesses = 10
+	// DigitsCount is the number of different digits a number is made of.
+	DigitsCount = 10
+)
+
+// Number is a secret or a guess: digits that don't repeat, a leading zero is allowed.
+// A number being typed on the keypad is shorter than SecretLength until it is complete.
+type Number string
+
+func (n Number) String() string {
+	return string(n)
+}
+
+func (n Number) IsComplete() bool {
+	return len(n) == SecretLength
+}
+
+// Contains returns true if the digit is already in the number.
+func (n Number) Contains(digit int) bool {
+	return strings.ContainsRune(string(n), rune('0'+digit))
+}
+
+// Append adds the digit to the end of the number being typed.
+func (n Number) Append(digit int) (Number, error) {
+	if digit < 0 || digit >= DigitsCount {
+		return n, ErrInvalidDigit
+	}
+	if n.IsComplete() {
+		return n, ErrInvalidNumber
+	}
+	if n.Contains(digit) {
+		return n, ErrRepeatedDigit
+	}
+	return n + Number(strconv.Itoa(digit)), nil
+}
+
+// Erase removes the last digit of the number being typed.
+func (n Number) Erase() Number {
+	if n == "" {
+		return n
+	}
+	return n[:len(n)-1]
+}
+
+// Validate checks that the number is complete and its digits don't repeat.
+func (n Number) Validate() error {
+	if !n.IsComplete() {
+		return ErrInvalidNumber
+	}
+	seen := [DigitsCount]bool{}
+	for _, r := range n {
+		if r < '0' || r > '9' {
+			return ErrInvalidNumber
+		}
+		if seen[r-'0'] {
+			return ErrRepeatedDigit
+		}
+		seen[r-'0'] = true
+	}
+	return nil
+}
+
+func NumberFromString(s string) (Number, error) {
+	n := Number(s)
+	if err := n.Validate(); err != nil {
+		return "", err
+	}
+	return n, nil
+}
+
+// RandomNumber draws a valid secret. randInt returns a number in [0, n).
+func RandomNumber(randInt func(n int) int) Number {
+	digits := []byte("0123456789")
+	for i := range SecretLength {
+		j := i + randInt(len(digits)-i)
+		digits[i], digits[j] = digits[j], digits[i]
+	}
+	return Number(digits[:SecretLength])
+}
+
+// Score compares the guess with the secret: bulls are right digits in the right place,
+// cows are right digits in a wrong place.
+func Score(secret Number, guess Number) (int, int) {
+	bulls, cows := 0, 0
+	for i := range min(len(secret), len(guess)) {
+		switch {
+		case secret[i] == guess[i]:
+			bulls++
+		case strings.IndexByte(string(secret), guess[i]) >= 0:
+			cows++
+		}
+	}
+	return bulls, cows
+}
+
+// Guess is a scored guess, the only thing a player learns about the secret of the opponent.
+type Guess struct {
+	PlayerID user.ID
+	Number   Number
+	Bulls    int
+	Cows     int
+}
+
+// IsHit returns true if the guess is the secret itself.
+func (g Guess) IsHit() bool {
+	return g.Bulls == SecretLength
+}
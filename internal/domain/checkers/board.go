@@ -0,0 +1,198 @@
+package checkers
+
+import "slices"
+
+// Board is the 8x8 grid, rows go top to bottom from Black's side, columns go left to right.
+type Board [BoardSize][BoardSize]Piece
+
+// diagonals are the four directions pieces move along.
+//
+//nolint:gochecknoglobals // Directions are constant.
+var diagonals = [4][2]int{{-1, -1}, {-1, 1}, {1, -1}, {1, 1}}
+
+// startRows is the number of rows each side fills at the start.
+const startRows = 3
+
+// NewBoard returns the starting position: twelve men of each color on the dark squares of the three nearest rows.
+func NewBoard() Board {
+	var b Board
+	for row := range BoardSize {
+		for col := range BoardSize {
+			cell := Cell{Row: row, Col: col}
+			switch {
+			case !cell.IsDark():
+			case row < startRows:
+				b[row][col] = PieceBlackMan
+			case row >= BoardSize-startRows:
+				b[row][col] = PieceWhiteMan
+			}
+		}
+	}
+	return b
+}
+
+// Get returns the piece on the cell, cells off the board are empty.
+func (b Board) Get(cell Cell) Piece {
+	if !cell.IsValid() {
+		return PieceEmpty
+	}
+	return b[cell.Row][cell.Col]
+}
+
+// Count returns the number of pieces of the color on the board.
+func (b Board) Count(color Color) int {
+	count := 0
+	for row := range BoardSize {
+		for col := range BoardSize {
+			if b[row][col].Color() == color {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// Captures returns the jumps the piece on the cell can make.
+// Pieces already captured in the chain stay on the board until the move is over,
+// they can't be jumped twice and block the way (the Turkish strike rule).
+// A king has to land on a square the chain goes on from whenever there is one.
+func (b Board) Captures(from Cell, taken []Cell) []Step {
+	piece := b.Get(from)
+	if piece == PieceEmpty {
+		return nil
+	}
+	if !piece.IsKing() {
+		return b.manCaptures(from, piece, taken)
+	}
+
+	var steps []Step
+	for _, dir := range diagonals {
+		over := Cell{Row: from.Row + dir[0], Col: from.Col + dir[1]}
+		for over.IsValid() && b.Get(over) == PieceEmpty {
+			over = Cell{Row: over.Row + dir[0], Col: over.Col + dir[1]}
+		}
+		if !over.IsValid() || b.Get(over).Color() != piece.Color().Opponent() || slices.Contains(taken, over) {
+			continue
+		}
+
+		var landings, continued []Step
+		land := Cell{Row: over.Row + dir[0], Col: over.Col + dir[1]}
+		for land.IsValid() && b.Get(land) == PieceEmpty {
+			captured := over
+			step := Step{From: from, To: land, Captured: &captured}
+			landings = append(landings, step)
+			if len(b.move(from, land).Captures(land, append(slices.Clone(taken), over))) > 0 {
+				continued = append(continued, step)
+			}
+			land = Cell{Row: land.Row + dir[0], Col: land.Col + dir[1]}
+		}
+		// The landing square is free to choose only if none of them goes on with the chain
+		if len(continued) > 0 {
+			landings = continued
+		}
+		steps = append(steps, landings...)
+	}
+	return steps
+}
+
+func (b Board) manCaptures(from Cell, piece Piece, taken []Cell) []Step {
+	var steps []Step
+	for _, dir := range diagonals {
+		over := Cell{Row: from.Row + dir[0], Col: from.Col + dir[1]}
+		land := Cell{Row: over.Row + dir[0], Col: over.Col + dir[1]}
+		if !land.IsValid() || b.Get(land) != PieceEmpty {
+			continue
+		}
+		if b.Get(over).Color() != piece.Color().Opponent() || slices.Contains(taken, over) {
+			continue
+		}
+		captured := over
+		steps = append(steps, Step{From: from, To: land, Captured: &captured})
+	}
+	return steps
+}
+
+// QuietMoves returns the moves without a capture of the piece on the cell:
+// a man steps forward, a king slides any distance along a free diagonal.
+func (b Board) QuietMoves(from Cell) []Step {
+	piece := b.Get(from)
+	if piece == PieceEmpty {
+		return nil
+	}
+
+	var steps []Step
+	for _, dir := range diagonals {
+		if !piece.IsKing() && dir[0] != piece.Color().Forward() {
+			continue
+		}
+		to := Cell{Row: from.Row + dir[0], Col: from.Col + dir[1]}
+		for to.IsValid() && b.Get(to) == PieceEmpty {
+			steps = append(steps, Step{From: from, To: to})
+			if !piece.IsKing() {
+				break
+			}
+			to = Cell{Row: to.Row + dir[0], Col: to.Col + dir[1]}
+		}
+	}
+	return steps
+}
+
+// HasCaptures checks that any piece of the color can capture.
+func (b Board) HasCaptures(color Color) bool {
+	for _, cell := range b.cells(color) {
+		if len(b.Captures(cell, nil)) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// HasMoves checks that the color has at least one legal move.
+func (b Board) HasMoves(color Color) bool {
+	for _, cell := range b.cells(color) {
+		if len(b.Captures(cell, nil)) > 0 || len(b.QuietMoves(cell)) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// cells returns the squares occupied by the pieces of the color.
+func (b Board) cells(color Color) []Cell {
+	var cells []Cell
+	for row := range BoardSize {
+		for col := range BoardSize {
+			if b[row][col].Color() == color {
+				cells = append(cells, Cell{Row: row, Col: col})
+			}
+		}
+	}
+	return cells
+}
+
+// move puts the piece from one square to another and promotes a man reaching the kings row.
+func (b Board) move(from, to Cell) Board {
+	piece := b.Get(from)
+	if to.Row == piece.Color().KingsRow() {
+		piece = piece.Promote()
+	}
+	b[from.Row][from.Col] = PieceEmpty
+	b[to.Row][to.Col] = piece
+	return b
+}
+
+// validate checks that every square holds a known piece and only dark squares are occupied.
+func (b Board) validate() error {
+	for row := range BoardSize {
+		for col := range BoardSize {
+			piece := b[row][col]
+			if !piece.IsValid() {
+				return ErrInvalidPiece
+			}
+			if piece != PieceEmpty && !(Cell{Row: row, Col: col}).IsDark() {
+				return ErrInvalidBoardLayout
+			}
+		}
+	}
+	return nil
+}
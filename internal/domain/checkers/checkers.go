@@ -0,0 +1,372 @@
+package checkers
+
+import (
+	"microgame-bot/internal/domain"
+	"microgame-bot/internal/domain/session"
+	"microgame-bot/internal/domain/user"
+	"microgame-bot/internal/utils"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Checkers is the two-player game of Russian draughts.
+// Captures are mandatory and go on while the capturing piece can jump again, men capture backwards too
+// and kings fly along the diagonals. Every jump of a chain is a separate step of the same player.
+type Checkers struct {
+	createdAt     time.Time
+	updatedAt     time.Time
+	board         Board
+	status        domain.GameStatus
+	id            ID
+	creatorID     user.ID
+	playerWhiteID user.ID
+	playerBlackID user.ID
+	winnerID      user.ID
+	sessionID     session.ID
+	turn          user.ID
+	seed          string
+	moves         []Move
+	chain         *Cell
+	taken         []Cell
+	quietMoves    int
+}
+
+// New creates a new Checkers instance with the given options, the board starts in the initial position.
+func New(opts ...Opt) (Checkers, error) {
+	c := &Checkers{
+		board:  NewBoard(),
+		status: domain.GameStatusCreated,
+	}
+
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			return Checkers{}, err
+		}
+	}
+
+	// Validate required fields
+	if c.id.IsZero() {
+		return Checkers{}, domain.ErrIDRequired
+	}
+	if c.sessionID.IsZero() {
+		return Checkers{}, domain.ErrSessionIDRequired
+	}
+	if c.creatorID.IsZero() {
+		return Checkers{}, domain.ErrCreatorIDRequired
+	}
+	if (c.playerWhiteID.IsZero() || c.playerBlackID.IsZero()) &&
+		c.status != domain.GameStatusCreated &&
+		c.status != domain.GameStatusWaitingForPlayers &&
+		c.status != domain.GameStatusCancelled {
+		return Checkers{}, domain.ErrCantPlayWithoutPlayers
+	}
+
+	// White moves first
+	if c.turn.IsZero() && !c.playerWhiteID.IsZero() && !c.playerBlackID.IsZero() && !c.IsFinished() {
+		c.turn = c.playerWhiteID
+	}
+
+	if err := c.board.validate(); err != nil {
+		return Checkers{}, err
+	}
+
+	return *c, nil
+}
+
+func (c Checkers) ID() ID                    { return c.id }
+func (c Checkers) CreatorID() user.ID        { return c.creatorID }
+func (c Checkers) PlayerWhiteID() user.ID    { return c.playerWhiteID }
+func (c Checkers) PlayerBlackID() user.ID    { return c.playerBlackID }
+func (c Checkers) Turn() user.ID             { return c.turn }
+func (c Checkers) Board() Board              { return c.board }
+func (c Checkers) Moves() []Move             { return slices.Clone(c.moves) }
+func (c Checkers) Taken() []Cell             { return slices.Clone(c.taken) }
+func (c Checkers) QuietMoves() int           { return c.quietMoves }
+func (c Checkers) Status() domain.GameStatus { return c.status }
+func (c Checkers) CreatedAt() time.Time      { return c.createdAt }
+func (c Checkers) UpdatedAt() time.Time      { return c.updatedAt }
+func (c Checkers) SessionID() session.ID     { return c.sessionID }
+func (c Checkers) IDtoUUID() uuid.UUID       { return uuid.UUID(c.id) }
+func (c Checkers) Type() domain.GameType     { return domain.GameTypeCheckers }
+func (c Checkers) Seed() string              { return c.seed }
+
+// Chain returns the piece in the middle of a capture chain, the same player has to jump on with it.
+func (c Checkers) Chain() (Cell, bool) {
+	if c.chain == nil {
+		return Cell{}, false
+	}
+	return *c.chain, true
+}
+
+// Participants returns all participants in the game.
+func (c Checkers) Participants() []user.ID {
+	participants := make([]user.ID, 0, 2) //nolint:mnd // Two players.
+	if !c.playerWhiteID.IsZero() {
+		participants = append(participants, c.playerWhiteID)
+	}
+	if !c.playerBlackID.IsZero() {
+		participants = append(participants, c.playerBlackID)
+	}
+	return participants
+}
+
+// PlayerColor returns the color of the player.
+func (c Checkers) PlayerColor(userID user.ID) Color {
+	switch userID {
+	case c.playerWhiteID:
+		return ColorWhite
+	case c.playerBlackID:
+		return ColorBlack
+	default:
+		return ColorNone
+	}
+}
+
+// PlayerByColor returns the player of the color.
+func (c Checkers) PlayerByColor(color Color) user.ID {
+	switch color {
+	case ColorWhite:
+		return c.playerWhiteID
+	case ColorBlack:
+		return c.playerBlackID
+	default:
+		return user.ID{}
+	}
+}
+
+// Pieces returns the number of pieces of the player left on the board.
+func (c Checkers) Pieces(userID user.ID) int {
+	color := c.PlayerColor(userID)
+	if color == ColorNone {
+		return 0
+	}
+	return c.board.Count(color)
+}
+
+// JoinGame adds a player to the game.
+// First player joins: temporarily stored as white (colors not assigned yet).
+// Second player joins: colors are randomly assigned between first and second players.
+func (c Checkers) JoinGame(playerID user.ID) (Checkers, error) {
+	if c.IsFinished() {
+		return Checkers{}, domain.ErrGameOver
+	}
+
+	if !c.playerWhiteID.IsZero() && !c.playerBlackID.IsZero() {
+		return Checkers{}, domain.ErrGameFull
+	}
+
+	if c.playerWhiteID == playerID || c.playerBlackID == playerID {
+		return Checkers{}, domain.ErrPlayerAlreadyInGame
+	}
+
+	if c.playerWhiteID.IsZero() {
+		c.playerWhiteID = playerID
+		// Status remains WaitingForPlayers
+		return c, nil
+	}
+
+	c.playerBlackID = playerID
+	c = c.AssignColorsRandomly()
+	c.status = domain.GameStatusInProgress
+
+	return c, nil
+}
+
+// AssignColorsRandomly randomly assigns the colors to the two players, white moves first.
+// If the game has a session seed, the choice is derived from it and can be verified
+// once the seed is revealed: players are swapped when SHA256(seed:gameID) is odd.
+func (c Checkers) AssignColorsRandomly() Checkers {
+	var roll int
+	if c.seed != "" {
+		//nolint:mnd // 50% chance.
+		roll = utils.SeededRandInt(c.seed, c.id.String(), 2)
+	} else {
+		//nolint:mnd // Random 50% chance.
+		roll = utils.RandInt(2)
+	}
+	if roll == 1 {
+		c.playerWhiteID, c.playerBlackID = c.playerBlackID, c.playerWhiteID
+	}
+	c.turn = c.playerWhiteID
+	return c
+}
+
+// Cancel withdraws the game on behalf of its creator.
+// Only a game that is still waiting for players can be cancelled.
+func (c Checkers) Cancel(userID user.ID) (Checkers, error) {
+	if c.creatorID != userID {
+		return Checkers{}, domain.ErrNotGameCreator
+	}
+	if c.status != domain.GameStatusWaitingForPlayers {
+		return Checkers{}, domain.ErrGameAlreadyStarted
+	}
+
+	c.status = domain.GameStatusCancelled
+	return c, nil
+}
+
+// StepsFrom returns the legal steps of the piece on the cell for the player to move.
+// Captures are mandatory: while any piece can capture, quiet moves are not allowed,
+// in the middle of a chain only the capturing piece may go on.
+func (c Checkers) StepsFrom(userID user.ID, from Cell) ([]Step, error) {
+	if c.IsFinished() {
+		return nil, domain.ErrGameOver
+	}
+
+	// Check if both players are in game
+	if c.playerWhiteID.IsZero() || c.playerBlackID.IsZero() {
+		return nil, domain.ErrWaitingForOpponent
+	}
+
+	if c.turn != userID {
+		return nil, domain.ErrNotPlayersTurn
+	}
+
+	if !from.IsValid() {
+		return nil, ErrOutOfBounds
+	}
+
+	color := c.PlayerColor(userID)
+	if c.board.Get(from).Color() != color {
+		return nil, ErrNotOwnPiece
+	}
+
+	if c.chain != nil {
+		if *c.chain != from {
+			return nil, ErrMustContinueChain
+		}
+		return c.board.Captures(from, c.taken), nil
+	}
+
+	if captures := c.board.Captures(from, nil); len(captures) > 0 {
+		return captures, nil
+	}
+	if c.board.HasCaptures(color) {
+		return nil, ErrCaptureRequired
+	}
+
+	steps := c.board.QuietMoves(from)
+	if len(steps) == 0 {
+		return nil, ErrPieceCantMove
+	}
+	return steps, nil
+}
+
+// MakeMove moves the piece of the player one step.
+// After a jump the turn stays with the player while the piece can capture again,
+// captured pieces leave the board once the chain is over. The player who leaves
+// the opponent without a move wins.
+func (c Checkers) MakeMove(from, to Cell, userID user.ID) (Checkers, error) {
+	steps, err := c.StepsFrom(userID, from)
+	if err != nil {
+		return Checkers{}, err
+	}
+	idx := slices.IndexFunc(steps, func(s Step) bool { return s.To == to })
+	if idx < 0 {
+		return Checkers{}, ErrIllegalMove
+	}
+	step := steps[idx]
+
+	piece := c.board.Get(from)
+	c.board = c.board.move(from, to)
+	c.moves = append(slices.Clone(c.moves), Move{
+		PlayerID: userID,
+		From:     from,
+		To:       to,
+		Captured: step.Captured,
+		Promoted: c.board.Get(to) != piece,
+	})
+
+	if step.IsCapture() {
+		c.taken = append(slices.Clone(c.taken), *step.Captured)
+		if len(c.board.Captures(to, c.taken)) > 0 {
+			c.chain = &to
+			return c, nil
+		}
+		for _, cell := range c.taken {
+			c.board[cell.Row][cell.Col] = PieceEmpty
+		}
+		c.quietMoves = 0
+	} else if piece.IsKing() {
+		c.quietMoves++
+	} else {
+		c.quietMoves = 0
+	}
+	c.chain = nil
+	c.taken = nil
+
+	opponent := c.PlayerColor(userID).Opponent()
+	switch {
+	case !c.board.HasMoves(opponent):
+		c.winnerID = userID
+		c.turn = user.ID{}
+		c.status = domain.GameStatusFinished
+	case c.quietMoves >= DrawQuietMoves:
+		c.turn = user.ID{}
+		c.status = domain.GameStatusFinished
+	default:
+		c.turn = c.PlayerByColor(opponent)
+	}
+
+	return c, nil
+}
+
+// IsFinished returns true if the game has ended.
+func (c Checkers) IsFinished() bool {
+	return !c.winnerID.IsZero() ||
+		c.status == domain.GameStatusCancelled ||
+		c.status == domain.GameStatusFinished ||
+		c.status == domain.GameStatusAbandoned
+}
+
+// IsDraw returns true if the game ended without a winner.
+func (c Checkers) IsDraw() bool {
+	return c.status == domain.GameStatusFinished && c.winnerID.IsZero()
+}
+
+func (c Checkers) Winners() []user.ID {
+	if c.winnerID.IsZero() {
+		return []user.ID{}
+	}
+	return []user.ID{c.winnerID}
+}
+
+func (c Checkers) WinnerID() user.ID {
+	return c.winnerID
+}
+
+// IsStarted returns true if at least one piece has moved.
+func (c Checkers) IsStarted() bool {
+	return len(c.moves) > 0
+}
+
+func (c Checkers) SetWinner(winnerID user.ID) (Checkers, error) {
+	if winnerID != c.playerWhiteID && winnerID != c.playerBlackID {
+		return Checkers{}, domain.ErrPlayerNotInGame
+	}
+	c.winnerID = winnerID
+	return c, nil
+}
+
+func (c Checkers) AFKPlayerID() (user.ID, error) {
+	if !c.IsStarted() {
+		return user.ID{}, domain.ErrAllPlayersAFK
+	}
+	if !c.turn.IsZero() {
+		return c.turn, nil
+	}
+	return user.ID{}, domain.ErrAFKPlayerNotFound
+}
+
+func (c Checkers) SetStatus(status domain.GameStatus) (Checkers, error) {
+	if status.IsZero() {
+		return Checkers{}, domain.ErrGameStatusRequired
+	}
+	if !status.IsValid() {
+		return Checkers{}, domain.ErrInvalidGameStatus
+	}
+	c.status = status
+	return c, nil
+}
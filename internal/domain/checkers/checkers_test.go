@@ -0,0 +1,173 @@
+package checkers
+
+import (
+	"microgame-bot/internal/domain"
+	se "microgame-bot/internal/domain/session"
+	"microgame-bot/internal/domain/user"
+	"microgame-bot/internal/utils"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newGameWithBoard(t *testing.T, board Board, opts ...Opt) (Checkers, user.ID, user.ID) {
+	t.Helper()
+	white := user.ID(utils.NewUniqueID())
+	black := user.ID(utils.NewUniqueID())
+
+	game, err := New(append([]Opt{
+		WithNewID(),
+		WithCreatorID(white),
+		WithSessionID(se.ID(utils.NewUniqueID())),
+		WithPlayerWhiteID(white),
+		WithPlayerBlackID(black),
+		WithBoard(board),
+		WithStatus(domain.GameStatusInProgress),
+	}, opts...)...)
+	require.NoError(t, err)
+	require.Equal(t, white, game.Turn())
+
+	return game, white, black
+}
+
+func targets(steps []Step) []Cell {
+	cells := make([]Cell, len(steps))
+	for i, step := range steps {
+		cells[i] = step.To
+	}
+	return cells
+}
+
+func TestNewBoard_StartingPosition(t *testing.T) {
+	game, white, black := newGameWithBoard(t, NewBoard())
+
+	assert.Equal(t, 12, game.Pieces(white))
+	assert.Equal(t, 12, game.Pieces(black))
+	assert.Equal(t, "c3", Cell{Row: 5, Col: 2}.String())
+
+	steps, err := game.StepsFrom(white, Cell{Row: 5, Col: 2})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []Cell{{Row: 4, Col: 1}, {Row: 4, Col: 3}}, targets(steps))
+
+	_, err = game.StepsFrom(white, Cell{Row: 2, Col: 1})
+	require.ErrorIs(t, err, ErrNotOwnPiece)
+	_, err = game.StepsFrom(white, Cell{Row: 6, Col: 1})
+	require.ErrorIs(t, err, ErrPieceCantMove)
+	_, err = game.StepsFrom(black, Cell{Row: 2, Col: 1})
+	require.ErrorIs(t, err, domain.ErrNotPlayersTurn)
+
+	game, err = game.MakeMove(Cell{Row: 5, Col: 2}, Cell{Row: 4, Col: 3}, white)
+	require.NoError(t, err)
+	assert.Equal(t, black, game.Turn())
+	assert.True(t, game.IsStarted())
+}
+
+func TestMakeMove_CaptureIsMandatory(t *testing.T) {
+	var board Board
+	board[5][2] = PieceWhiteMan
+	board[5][6] = PieceWhiteMan
+	board[4][3] = PieceBlackMan
+	game, white, _ := newGameWithBoard(t, board)
+
+	_, err := game.StepsFrom(white, Cell{Row: 5, Col: 6})
+	require.ErrorIs(t, err, ErrCaptureRequired)
+	_, err = game.MakeMove(Cell{Row: 5, Col: 2}, Cell{Row: 4, Col: 1}, white)
+	require.ErrorIs(t, err, ErrIllegalMove)
+
+	game, err = game.MakeMove(Cell{Row: 5, Col: 2}, Cell{Row: 3, Col: 4}, white)
+	require.NoError(t, err)
+	assert.Equal(t, PieceEmpty, game.Board().Get(Cell{Row: 4, Col: 3}))
+	assert.True(t, game.IsFinished())
+	assert.Equal(t, white, game.WinnerID())
+}
+
+func TestMakeMove_ManCapturesBackwards(t *testing.T) {
+	var board Board
+	board[4][3] = PieceWhiteMan
+	board[5][4] = PieceBlackMan
+	board[0][1] = PieceBlackMan
+	game, white, _ := newGameWithBoard(t, board)
+
+	steps, err := game.StepsFrom(white, Cell{Row: 4, Col: 3})
+	require.NoError(t, err)
+	assert.Equal(t, []Cell{{Row: 6, Col: 5}}, targets(steps))
+}
+
+func TestMakeMove_CaptureChainKeepsTurn(t *testing.T) {
+	var board Board
+	board[5][0] = PieceWhiteMan
+	board[4][1] = PieceBlackMan
+	board[2][3] = PieceBlackMan
+	board[0][7] = PieceBlackMan
+	game, white, black := newGameWithBoard(t, board)
+
+	game, err := game.MakeMove(Cell{Row: 5, Col: 0}, Cell{Row: 3, Col: 2}, white)
+	require.NoError(t, err)
+	chain, ok := game.Chain()
+	require.True(t, ok)
+	assert.Equal(t, Cell{Row: 3, Col: 2}, chain)
+	assert.Equal(t, white, game.Turn())
+	// The captured piece stays on the board until the chain is over
+	assert.Equal(t, PieceBlackMan, game.Board().Get(Cell{Row: 4, Col: 1}))
+
+	game, err = game.MakeMove(Cell{Row: 3, Col: 2}, Cell{Row: 1, Col: 4}, white)
+	require.NoError(t, err)
+	_, ok = game.Chain()
+	assert.False(t, ok)
+	assert.Empty(t, game.Taken())
+	assert.Equal(t, 1, game.Pieces(black))
+	assert.Equal(t, black, game.Turn())
+}
+
+func TestMakeMove_PromotedManGoesOnCapturingAsKing(t *testing.T) {
+	var board Board
+	board[2][1] = PieceWhiteMan
+	board[1][2] = PieceBlackMan
+	board[2][5] = PieceBlackMan
+	board[7][6] = PieceBlackMan
+	game, white, _ := newGameWithBoard(t, board)
+
+	game, err := game.MakeMove(Cell{Row: 2, Col: 1}, Cell{Row: 0, Col: 3}, white)
+	require.NoError(t, err)
+	assert.Equal(t, PieceWhiteKing, game.Board().Get(Cell{Row: 0, Col: 3}))
+	assert.True(t, game.Moves()[0].Promoted)
+	chain, ok := game.Chain()
+	require.True(t, ok)
+	assert.Equal(t, Cell{Row: 0, Col: 3}, chain)
+
+	steps, err := game.StepsFrom(white, chain)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []Cell{{Row: 3, Col: 6}, {Row: 4, Col: 7}}, targets(steps))
+}
+
+func TestCaptures_FlyingKingMustLandWhereChainGoesOn(t *testing.T) {
+	var board Board
+	board[7][0] = PieceWhiteKing
+	board[4][3] = PieceBlackMan
+	board[1][4] = PieceBlackMan
+	game, white, _ := newGameWithBoard(t, board)
+
+	steps, err := game.StepsFrom(white, Cell{Row: 7, Col: 0})
+	require.NoError(t, err)
+	assert.Equal(t, []Cell{{Row: 2, Col: 5}}, targets(steps))
+
+	board[1][4] = PieceEmpty
+	game, white, _ = newGameWithBoard(t, board)
+	steps, err = game.StepsFrom(white, Cell{Row: 7, Col: 0})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []Cell{{Row: 3, Col: 4}, {Row: 2, Col: 5}, {Row: 1, Col: 6}, {Row: 0, Col: 7}}, targets(steps))
+}
+
+func TestMakeMove_QuietKingMovesEndInDraw(t *testing.T) {
+	var board Board
+	board[7][0] = PieceWhiteKing
+	board[0][1] = PieceBlackKing
+	game, white, _ := newGameWithBoard(t, board, WithQuietMoves(DrawQuietMoves-1))
+
+	game, err := game.MakeMove(Cell{Row: 7, Col: 0}, Cell{Row: 6, Col: 1}, white)
+	require.NoError(t, err)
+	assert.True(t, game.IsFinished())
+	assert.True(t, game.IsDraw())
+	assert.Empty(t, game.Winners())
+}
@@ -0,0 +1,15 @@
+package checkers
+
+import "errors"
+
+var (
+	ErrOutOfBounds        = errors.New("coordinates out of bounds")
+	ErrInvalidPiece       = errors.New("invalid piece")
+	ErrNotOwnPiece        = errors.New("cell holds no piece of the player")
+	ErrPieceCantMove      = errors.New("piece has no moves")
+	ErrCaptureRequired    = errors.New("capture is mandatory")
+	ErrMustContinueChain  = errors.New("capture chain must be continued with the same piece")
+	ErrIllegalMove        = errors.New("illegal move")
+	ErrNothingSelected    = errors.New("no piece selected")
+	ErrInvalidBoardLayout = errors.New("pieces must stand on dark squares")
+)
@@ -0,0 +1,180 @@
+package checkers
+
+import (
+	"fmt"
+	"microgame-bot/internal/core"
+	"microgame-bot/internal/domain"
+	se "microgame-bot/internal/domain/session"
+	"microgame-bot/internal/domain/user"
+	"microgame-bot/internal/utils"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Opt func(*Checkers) error
+
+func WithID(id ID) Opt {
+	return func(c *Checkers) error {
+		if id.IsZero() {
+			return domain.ErrIDRequired
+		}
+		c.id = id
+		return nil
+	}
+}
+
+func WithNewID() Opt {
+	return WithID(ID(utils.NewUniqueID()))
+}
+
+func WithIDFromString(id string) Opt {
+	return func(c *Checkers) error {
+		idUUID, err := utils.UUIDFromString[ID](id)
+		if err != nil {
+			return fmt.Errorf("%w: %w", core.ErrFailedToParseID, err)
+		}
+		c.id = idUUID
+		return nil
+	}
+}
+
+func WithIDFromUUID(id uuid.UUID) Opt {
+	return WithID(ID(id))
+}
+
+func WithCreatorID(creatorID user.ID) Opt {
+	return func(c *Checkers) error {
+		if creatorID.IsZero() {
+			return domain.ErrCreatorIDRequired
+		}
+		c.creatorID = creatorID
+		return nil
+	}
+}
+
+func WithPlayerWhiteID(playerID user.ID) Opt {
+	return func(c *Checkers) error {
+		c.playerWhiteID = playerID
+		return nil
+	}
+}
+
+func WithPlayerWhiteIDFromUUID(playerID uuid.UUID) Opt {
+	return WithPlayerWhiteID(user.ID(playerID))
+}
+
+func WithPlayerBlackID(playerID user.ID) Opt {
+	return func(c *Checkers) error {
+		c.playerBlackID = playerID
+		return nil
+	}
+}
+
+func WithPlayerBlackIDFromUUID(playerID uuid.UUID) Opt {
+	return WithPlayerBlackID(user.ID(playerID))
+}
+
+func WithBoard(board Board) Opt {
+	return func(c *Checkers) error {
+		c.board = board
+		return nil
+	}
+}
+
+// WithChain sets the piece in the middle of a capture chain with the pieces it has captured so far.
+func WithChain(chain *Cell, taken []Cell) Opt {
+	return func(c *Checkers) error {
+		if chain != nil && !chain.IsValid() {
+			return ErrOutOfBounds
+		}
+		c.chain = chain
+		c.taken = slices.Clone(taken)
+		return nil
+	}
+}
+
+func WithQuietMoves(quietMoves int) Opt {
+	return func(c *Checkers) error {
+		c.quietMoves = quietMoves
+		return nil
+	}
+}
+
+func WithTurn(turn user.ID) Opt {
+	return func(c *Checkers) error {
+		c.turn = turn
+		return nil
+	}
+}
+
+func WithTurnFromUUID(turn uuid.UUID) Opt {
+	return WithTurn(user.ID(turn))
+}
+
+// WithSeed sets the session seed the colors are assigned from.
+func WithSeed(seed string) Opt {
+	return func(c *Checkers) error {
+		c.seed = seed
+		return nil
+	}
+}
+
+func WithMoves(moves []Move) Opt {
+	return func(c *Checkers) error {
+		c.moves = slices.Clone(moves)
+		return nil
+	}
+}
+
+func WithStatus(status domain.GameStatus) Opt {
+	return func(c *Checkers) error {
+		if status.IsZero() {
+			return domain.ErrGameStatusRequired
+		}
+		if !status.IsValid() {
+			return domain.ErrInvalidGameStatus
+		}
+		c.status = status
+		return nil
+	}
+}
+
+func WithWinnerID(winnerID user.ID) Opt {
+	return func(c *Checkers) error {
+		c.winnerID = winnerID
+		return nil
+	}
+}
+
+func WithWinnerIDFromUUID(winnerID uuid.UUID) Opt {
+	return WithWinnerID(user.ID(winnerID))
+}
+
+func WithCreatedAt(createdAt time.Time) Opt {
+	return func(c *Checkers) error {
+		if createdAt.IsZero() {
+			return domain.ErrCreatedAtRequired
+		}
+		c.createdAt = createdAt
+		return nil
+	}
+}
+
+func WithUpdatedAt(updatedAt time.Time) Opt {
+	return func(c *Checkers) error {
+		if updatedAt.IsZero() {
+			return domain.ErrUpdatedAtRequired
+		}
+		c.updatedAt = updatedAt
+		return nil
+	}
+}
+
+func WithSessionID(sessionID se.ID) Opt {
+	return func(c *Checkers) error {
+		c.sessionID = sessionID
+		return nil
+	}
+}
@@ -0,0 +1,170 @@
+package checkers
+
+import "microgame-bot/internal/domain/user"
+
+const (
+	// BoardSize is the side of the draughts board.
+	BoardSize = 8
+	// DrawQuietMoves ends the game in a draw after 15 moves of each player made only by kings without captures.
+	DrawQuietMoves = 30
+)
+
+// Color is the side of the player, White always moves first.
+type Color string
+
+const (
+	ColorNone  Color = ""
+	ColorWhite Color = "white"
+	ColorBlack Color = "black"
+)
+
+// Opponent returns the color of the other player.
+func (c Color) Opponent() Color {
+	switch c {
+	case ColorWhite:
+		return ColorBlack
+	case ColorBlack:
+		return ColorWhite
+	default:
+		return ColorNone
+	}
+}
+
+// Forward returns the row step of a man of the color: White starts at the bottom and moves up.
+func (c Color) Forward() int {
+	if c == ColorWhite {
+		return -1
+	}
+	return 1
+}
+
+// KingsRow returns the row a man of the color is promoted on.
+func (c Color) KingsRow() int {
+	if c == ColorWhite {
+		return 0
+	}
+	return BoardSize - 1
+}
+
+// Piece is the content of a board square, lower case letters are men and upper case are kings.
+type Piece string
+
+const (
+	PieceEmpty     Piece = ""
+	PieceWhiteMan  Piece = "w"
+	PieceWhiteKing Piece = "W"
+	PieceBlackMan  Piece = "b"
+	PieceBlackKing Piece = "B"
+)
+
+const (
+	PieceWhiteManIcon  = "⚪"
+	PieceWhiteKingIcon = "🤍"
+	PieceBlackManIcon  = "⚫"
+	PieceBlackKingIcon = "🖤"
+	DarkSquareIcon     = "⬛"
+	LightSquareIcon    = "⬜"
+	// SelectedIcon marks the piece chosen by the first tap.
+	SelectedIcon = "🟡"
+	// TargetIcon marks the squares the selected piece may go to.
+	TargetIcon = "🟩"
+)
+
+func (p Piece) IsValid() bool {
+	switch p {
+	case PieceEmpty, PieceWhiteMan, PieceWhiteKing, PieceBlackMan, PieceBlackKing:
+		return true
+	default:
+		return false
+	}
+}
+
+// Color returns the side the piece belongs to.
+func (p Piece) Color() Color {
+	switch p {
+	case PieceWhiteMan, PieceWhiteKing:
+		return ColorWhite
+	case PieceBlackMan, PieceBlackKing:
+		return ColorBlack
+	default:
+		return ColorNone
+	}
+}
+
+func (p Piece) IsKing() bool {
+	return p == PieceWhiteKing || p == PieceBlackKing
+}
+
+// Promote turns a man into the king of the same color.
+func (p Piece) Promote() Piece {
+	switch p {
+	case PieceWhiteMan:
+		return PieceWhiteKing
+	case PieceBlackMan:
+		return PieceBlackKing
+	default:
+		return p
+	}
+}
+
+func (p Piece) Icon() string {
+	switch p {
+	case PieceWhiteMan:
+		return PieceWhiteManIcon
+	case PieceWhiteKing:
+		return PieceWhiteKingIcon
+	case PieceBlackMan:
+		return PieceBlackManIcon
+	case PieceBlackKing:
+		return PieceBlackKingIcon
+	default:
+		return DarkSquareIcon
+	}
+}
+
+// Cell is a square of the board, row 0 is the top row on Black's side.
+type Cell struct {
+	Row int
+	Col int
+}
+
+// IsValid checks that the cell is on the board.
+func (c Cell) IsValid() bool {
+	return c.Row >= 0 && c.Row < BoardSize && c.Col >= 0 && c.Col < BoardSize
+}
+
+// IsDark checks that the cell is a playing square, pieces stand only on dark squares.
+func (c Cell) IsDark() bool {
+	return (c.Row+c.Col)%2 == 1
+}
+
+// String returns the cell in the draughts notation: column letter and row number from White's side, e.g. "c3".
+func (c Cell) String() string {
+	return string(rune('a'+c.Col)) + string(rune('0'+BoardSize-c.Row))
+}
+
+// Step is a single move of a piece: a quiet move or one jump of a capture chain.
+type Step struct {
+	From     Cell
+	To       Cell
+	Captured *Cell
+}
+
+// IsCapture returns true if the step jumps over an opponent piece.
+func (s Step) IsCapture() bool {
+	return s.Captured != nil
+}
+
+// Move is a recorded step of the game.
+type Move struct {
+	PlayerID user.ID
+	From     Cell
+	To       Cell
+	Captured *Cell
+	Promoted bool
+}
+
+// IsCapture returns true if the step jumped over an opponent piece.
+func (m Move) IsCapture() bool {
+	return m.Captured != nil
+}
@@ -0,0 +1,248 @@
+package dice
+
+import (
+	"microgame-bot/internal/domain"
+	se "microgame-bot/internal/domain/session"
+	"microgame-bot/internal/domain/user"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Dice is a duel where both players throw the same Telegram dice and the higher value wins.
+// Values come from the Bot API, the bot never rolls them itself.
+type Dice struct {
+	createdAt time.Time
+	updatedAt time.Time
+	status    domain.GameStatus
+	kind      Kind
+	throw1    Throw
+	throw2    Throw
+	winnerID  user.ID
+	sessionID se.ID
+	id        ID
+	player1ID user.ID
+	player2ID user.ID
+	creatorID user.ID
+}
+
+func New(opts ...Opt) (Dice, error) {
+	d := &Dice{
+		status: domain.GameStatusCreated,
+		kind:   KindDice,
+	}
+
+	for _, opt := range opts {
+		if err := opt(d); err != nil {
+			return Dice{}, err
+		}
+	}
+
+	// Validate required fields
+	if d.id.IsZero() {
+		return Dice{}, domain.ErrIDRequired
+	}
+	if d.sessionID.IsZero() {
+		return Dice{}, domain.ErrSessionIDRequired
+	}
+	if d.creatorID.IsZero() {
+		return Dice{}, domain.ErrCreatorIDRequired
+	}
+	if (d.player1ID.IsZero() || d.player2ID.IsZero()) &&
+		d.status != domain.GameStatusCreated &&
+		d.status != domain.GameStatusWaitingForPlayers &&
+		d.status != domain.GameStatusCancelled {
+		return Dice{}, domain.ErrCantPlayWithoutPlayers
+	}
+
+	return *d, nil
+}
+
+func (d Dice) ID() ID                    { return d.id }
+func (d Dice) CreatorID() user.ID        { return d.creatorID }
+func (d Dice) Player1ID() user.ID        { return d.player1ID }
+func (d Dice) Player2ID() user.ID        { return d.player2ID }
+func (d Dice) Kind() Kind                { return d.kind }
+func (d Dice) Throw1() Throw             { return d.throw1 }
+func (d Dice) Throw2() Throw             { return d.throw2 }
+func (d Dice) Winner() user.ID           { return d.winnerID }
+func (d Dice) Winners() []user.ID        { return []user.ID{d.winnerID} }
+func (d Dice) Status() domain.GameStatus { return d.status }
+func (d Dice) CreatedAt() time.Time      { return d.createdAt }
+func (d Dice) UpdatedAt() time.Time      { return d.updatedAt }
+func (d Dice) SessionID() se.ID          { return d.sessionID }
+func (d Dice) IDtoUUID() uuid.UUID       { return uuid.UUID(d.id) }
+func (d Dice) Type() domain.GameType     { return domain.GameTypeDice }
+
+// ThrowOf returns the throw of the given player.
+func (d Dice) ThrowOf(playerID user.ID) Throw {
+	switch playerID {
+	case d.player1ID:
+		return d.throw1
+	case d.player2ID:
+		return d.throw2
+	default:
+		return Throw{}
+	}
+}
+
+func (d Dice) Participants() []user.ID {
+	participants := []user.ID{}
+	if !d.player1ID.IsZero() {
+		participants = append(participants, d.player1ID)
+	}
+	if !d.player2ID.IsZero() {
+		participants = append(participants, d.player2ID)
+	}
+	return participants
+}
+
+func (d Dice) JoinGame(playerID user.ID) (Dice, error) {
+	if d.IsFinished() {
+		return Dice{}, domain.ErrGameOver
+	}
+
+	if !d.player1ID.IsZero() && !d.player2ID.IsZero() {
+		return Dice{}, domain.ErrGameFull
+	}
+
+	if d.player1ID == playerID || d.player2ID == playerID {
+		return Dice{}, domain.ErrPlayerAlreadyInGame
+	}
+
+	if d.player1ID.IsZero() {
+		d.player1ID = playerID
+		// Status remains WaitingForPlayers
+		return d, nil
+	}
+
+	d.player2ID = playerID
+	d.status = domain.GameStatusInProgress
+
+	return d, nil
+}
+
+// Cancel withdraws the game on behalf of its creator.
+// Only a game that is still waiting for players can be cancelled.
+func (d Dice) Cancel(userID user.ID) (Dice, error) {
+	if d.creatorID != userID {
+		return Dice{}, domain.ErrNotGameCreator
+	}
+	if d.status != domain.GameStatusWaitingForPlayers {
+		return Dice{}, domain.ErrGameAlreadyStarted
+	}
+
+	d.status = domain.GameStatusCancelled
+	return d, nil
+}
+
+// CanThrow checks that the player may throw now.
+// The dice is sent to Telegram before the throw is recorded, so it is checked up front
+// to not waste a roll on a throw that would be rejected.
+func (d Dice) CanThrow(playerID user.ID) error {
+	if d.IsFinished() {
+		return domain.ErrGameOver
+	}
+	if playerID != d.player1ID && playerID != d.player2ID {
+		return domain.ErrPlayerNotInGame
+	}
+	if d.status != domain.GameStatusInProgress {
+		return domain.ErrGameNotStarted
+	}
+	if !d.ThrowOf(playerID).IsZero() {
+		return ErrAlreadyThrown
+	}
+	return nil
+}
+
+// Throw records the value rolled by Telegram for the player.
+// The game is finished once both players have thrown.
+func (d Dice) Throw(playerID user.ID, throw Throw) (Dice, error) {
+	if err := d.CanThrow(playerID); err != nil {
+		return Dice{}, err
+	}
+	if throw.Value < 1 || throw.Value > d.kind.MaxValue() {
+		return Dice{}, ErrInvalidValue
+	}
+
+	if playerID == d.player1ID {
+		d.throw1 = throw
+	} else {
+		d.throw2 = throw
+	}
+
+	if d.throw1.IsZero() || d.throw2.IsZero() {
+		return d, nil
+	}
+
+	switch {
+	case d.throw1.Value > d.throw2.Value:
+		d.winnerID = d.player1ID
+	case d.throw2.Value > d.throw1.Value:
+		d.winnerID = d.player2ID
+	}
+	d.status = domain.GameStatusFinished
+
+	return d, nil
+}
+
+func (d Dice) IsFinished() bool {
+	return !d.winnerID.IsZero() || d.IsDraw() ||
+		d.status == domain.GameStatusCancelled ||
+		d.status == domain.GameStatusFinished ||
+		d.status == domain.GameStatusAbandoned
+}
+
+func (d Dice) IsDraw() bool {
+	return !d.throw1.IsZero() && !d.throw2.IsZero() && d.throw1.Value == d.throw2.Value
+}
+
+func (d Dice) WinnerID() user.ID {
+	if d.winnerID == d.player1ID {
+		return d.player1ID
+	}
+	if d.winnerID == d.player2ID {
+		return d.player2ID
+	}
+	return user.ID{}
+}
+
+// IsStarted returns true if at least one player has thrown.
+func (d Dice) IsStarted() bool {
+	return !d.throw1.IsZero() || !d.throw2.IsZero()
+}
+
+func (d Dice) SetWinner(winnerID user.ID) (Dice, error) {
+	if winnerID != d.player1ID && winnerID != d.player2ID {
+		return Dice{}, domain.ErrPlayerNotInGame
+	}
+	d.winnerID = winnerID
+	return d, nil
+}
+
+func (d Dice) AFKPlayerID() (user.ID, error) {
+	throw1Empty := d.throw1.IsZero()
+	throw2Empty := d.throw2.IsZero()
+
+	if throw1Empty && throw2Empty {
+		return user.ID{}, domain.ErrAllPlayersAFK
+	}
+	if throw1Empty {
+		return d.player1ID, nil
+	}
+	if throw2Empty {
+		return d.player2ID, nil
+	}
+	return user.ID{}, domain.ErrAFKPlayerNotFound
+}
+
+func (d Dice) SetStatus(status domain.GameStatus) (Dice, error) {
+	if status.IsZero() {
+		return Dice{}, domain.ErrGameStatusRequired
+	}
+	if !status.IsValid() {
+		return Dice{}, domain.ErrInvalidGameStatus
+	}
+	d.status = status
+	return d, nil
+}
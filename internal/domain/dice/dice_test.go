@@ -0,0 +1,108 @@
+package dice
+
+import (
+	"microgame-bot/internal/domain"
+	se "microgame-bot/internal/domain/session"
+	"microgame-bot/internal/domain/user"
+	"microgame-bot/internal/utils"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newStartedGame(t *testing.T, kind Kind) (Dice, user.ID, user.ID) {
+	t.Helper()
+	player1 := user.ID(utils.NewUniqueID())
+	player2 := user.ID(utils.NewUniqueID())
+
+	game, err := New(
+		WithNewID(),
+		WithCreatorID(player1),
+		WithSessionID(se.ID(utils.NewUniqueID())),
+		WithKind(kind),
+		WithStatus(domain.GameStatusWaitingForPlayers),
+	)
+	require.NoError(t, err)
+	game, err = game.JoinGame(player1)
+	require.NoError(t, err)
+	game, err = game.JoinGame(player2)
+	require.NoError(t, err)
+	require.Equal(t, domain.GameStatusInProgress, game.Status())
+
+	return game, player1, player2
+}
+
+func TestThrow_HigherValueWins(t *testing.T) {
+	game, player1, player2 := newStartedGame(t, KindDice)
+
+	game, err := game.Throw(player1, Throw{Value: 2})
+	require.NoError(t, err)
+	assert.False(t, game.IsFinished())
+	assert.True(t, game.IsStarted())
+
+	afkID, err := game.AFKPlayerID()
+	require.NoError(t, err)
+	assert.Equal(t, player2, afkID)
+
+	game, err = game.Throw(player2, Throw{Value: 5})
+	require.NoError(t, err)
+	assert.True(t, game.IsFinished())
+	assert.False(t, game.IsDraw())
+	assert.Equal(t, player2, game.WinnerID())
+	assert.Equal(t, domain.GameStatusFinished, game.Status())
+}
+
+func TestThrow_EqualValuesDraw(t *testing.T) {
+	game, player1, player2 := newStartedGame(t, KindBowling)
+
+	game, err := game.Throw(player1, Throw{Value: 6})
+	require.NoError(t, err)
+	game, err = game.Throw(player2, Throw{Value: 6})
+	require.NoError(t, err)
+
+	assert.True(t, game.IsFinished())
+	assert.True(t, game.IsDraw())
+	assert.True(t, game.WinnerID().IsZero())
+}
+
+func TestThrow_Rejected(t *testing.T) {
+	game, player1, _ := newStartedGame(t, KindFootball)
+
+	_, err := game.Throw(user.ID(utils.NewUniqueID()), Throw{Value: 1})
+	require.ErrorIs(t, err, domain.ErrPlayerNotInGame)
+
+	_, err = game.Throw(player1, Throw{Value: 6})
+	require.ErrorIs(t, err, ErrInvalidValue)
+
+	game, err = game.Throw(player1, Throw{Value: 5})
+	require.NoError(t, err)
+	require.ErrorIs(t, game.CanThrow(player1), ErrAlreadyThrown)
+}
+
+func TestCanThrow_WaitingForPlayers(t *testing.T) {
+	creatorID := user.ID(utils.NewUniqueID())
+	game, err := New(
+		WithNewID(),
+		WithCreatorID(creatorID),
+		WithSessionID(se.ID(utils.NewUniqueID())),
+		WithStatus(domain.GameStatusWaitingForPlayers),
+	)
+	require.NoError(t, err)
+	game, err = game.JoinGame(creatorID)
+	require.NoError(t, err)
+
+	require.ErrorIs(t, game.CanThrow(creatorID), domain.ErrGameNotStarted)
+}
+
+func TestKindFromString(t *testing.T) {
+	for _, kind := range Kinds() {
+		parsed, err := KindFromString(kind.String())
+		require.NoError(t, err)
+		assert.Equal(t, kind, parsed)
+	}
+
+	_, err := KindFromString("roulette")
+	require.ErrorIs(t, err, ErrInvalidKind)
+	assert.Equal(t, 64, KindSlots.MaxValue())
+}
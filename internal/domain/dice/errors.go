@@ -0,0 +1,11 @@
+package dice
+
+import "errors"
+
+var (
+	ErrInvalidKind     = errors.New("invalid dice kind")
+	ErrInvalidValue    = errors.New("dice value is out of range")
+	ErrAlreadyThrown   = errors.New("dice already thrown")
+	ErrChatRequired    = errors.New("player has no chat to throw the dice in")
+	ErrUnexpectedThrow = errors.New("telegram returned no dice")
+)
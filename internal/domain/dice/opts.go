@@ -0,0 +1,151 @@
+package dice
+
+import (
+	"fmt"
+	"microgame-bot/internal/core"
+	"microgame-bot/internal/domain"
+	se "microgame-bot/internal/domain/session"
+	"microgame-bot/internal/domain/user"
+	"microgame-bot/internal/utils"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Opt func(*Dice) error
+
+func WithID(id ID) Opt {
+	return func(d *Dice) error {
+		if id.IsZero() {
+			return domain.ErrIDRequired
+		}
+		d.id = id
+		return nil
+	}
+}
+
+func WithNewID() Opt {
+	return WithID(ID(utils.NewUniqueID()))
+}
+
+func WithIDFromString(id string) Opt {
+	return func(d *Dice) error {
+		idUUID, err := utils.UUIDFromString[ID](id)
+		if err != nil {
+			return fmt.Errorf("%w: %w", core.ErrFailedToParseID, err)
+		}
+		d.id = idUUID
+		return nil
+	}
+}
+
+func WithIDFromUUID(id uuid.UUID) Opt {
+	return WithID(ID(id))
+}
+
+func WithCreatorID(creatorID user.ID) Opt {
+	return func(d *Dice) error {
+		if creatorID.IsZero() {
+			return domain.ErrCreatorIDRequired
+		}
+		d.creatorID = creatorID
+		return nil
+	}
+}
+
+func WithPlayer1ID(player1ID user.ID) Opt {
+	return func(d *Dice) error {
+		d.player1ID = player1ID
+		return nil
+	}
+}
+
+func WithPlayer1IDFromUUID(player1ID uuid.UUID) Opt {
+	return WithPlayer1ID(user.ID(player1ID))
+}
+
+func WithPlayer2ID(player2ID user.ID) Opt {
+	return func(d *Dice) error {
+		d.player2ID = player2ID
+		return nil
+	}
+}
+
+func WithPlayer2IDFromUUID(player2ID uuid.UUID) Opt {
+	return WithPlayer2ID(user.ID(player2ID))
+}
+
+func WithKind(kind Kind) Opt {
+	return func(d *Dice) error {
+		if !kind.IsValid() {
+			return ErrInvalidKind
+		}
+		d.kind = kind
+		return nil
+	}
+}
+
+func WithThrow1(throw Throw) Opt {
+	return func(d *Dice) error {
+		d.throw1 = throw
+		return nil
+	}
+}
+
+func WithThrow2(throw Throw) Opt {
+	return func(d *Dice) error {
+		d.throw2 = throw
+		return nil
+	}
+}
+
+func WithStatus(status domain.GameStatus) Opt {
+	return func(d *Dice) error {
+		if status.IsZero() {
+			return domain.ErrGameStatusRequired
+		}
+		if !status.IsValid() {
+			return domain.ErrInvalidGameStatus
+		}
+		d.status = status
+		return nil
+	}
+}
+
+func WithWinnerID(winnerID user.ID) Opt {
+	return func(d *Dice) error {
+		d.winnerID = winnerID
+		return nil
+	}
+}
+
+func WithWinnerIDFromUUID(winnerID uuid.UUID) Opt {
+	return WithWinnerID(user.ID(winnerID))
+}
+
+func WithCreatedAt(createdAt time.Time) Opt {
+	return func(d *Dice) error {
+		if createdAt.IsZero() {
+			return domain.ErrCreatedAtRequired
+		}
+		d.createdAt = createdAt
+		return nil
+	}
+}
+
+func WithUpdatedAt(updatedAt time.Time) Opt {
+	return func(d *Dice) error {
+		if updatedAt.IsZero() {
+			return domain.ErrUpdatedAtRequired
+		}
+		d.updatedAt = updatedAt
+		return nil
+	}
+}
+
+func WithSessionID(sessionID se.ID) Opt {
+	return func(d *Dice) error {
+		d.sessionID = sessionID
+		return nil
+	}
+}
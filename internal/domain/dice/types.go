@@ -0,0 +1,100 @@
+package dice
+
+type Kind string
+
+// Kinds map to the animated emoji of the Bot API sendDice method.
+const (
+	KindDice       Kind = "dice"
+	KindDarts      Kind = "darts"
+	KindBasketball Kind = "basketball"
+	KindFootball   Kind = "football"
+	KindBowling    Kind = "bowling"
+	KindSlots      Kind = "slots"
+)
+
+// Kinds returns every kind in the order they are offered to players.
+func Kinds() []Kind {
+	return []Kind{KindDice, KindDarts, KindBasketball, KindFootball, KindBowling, KindSlots}
+}
+
+func (k Kind) String() string {
+	return string(k)
+}
+
+func (k Kind) IsValid() bool {
+	switch k {
+	case KindDice, KindDarts, KindBasketball, KindFootball, KindBowling, KindSlots:
+		return true
+	default:
+		return false
+	}
+}
+
+// Emoji returns the emoji the dice is sent with.
+func (k Kind) Emoji() string {
+	switch k {
+	case KindDarts:
+		return "🎯"
+	case KindBasketball:
+		return "🏀"
+	case KindFootball:
+		return "⚽"
+	case KindBowling:
+		return "🎳"
+	case KindSlots:
+		return "🎰"
+	default:
+		return "🎲"
+	}
+}
+
+// Title returns the name of the kind shown to players.
+func (k Kind) Title() string {
+	switch k {
+	case KindDarts:
+		return "Дартс"
+	case KindBasketball:
+		return "Баскетбол"
+	case KindFootball:
+		return "Футбол"
+	case KindBowling:
+		return "Боулинг"
+	case KindSlots:
+		return "Слоты"
+	default:
+		return "Кости"
+	}
+}
+
+// MaxValue returns the highest value Telegram can roll for the kind.
+func (k Kind) MaxValue() int {
+	//nolint:mnd // Value ranges are defined by the Bot API.
+	switch k {
+	case KindBasketball, KindFootball:
+		return 5
+	case KindSlots:
+		return 64
+	default:
+		return 6
+	}
+}
+
+func KindFromString(kind string) (Kind, error) {
+	k := Kind(kind)
+	if !k.IsValid() {
+		return "", ErrInvalidKind
+	}
+	return k, nil
+}
+
+// Throw is a dice rolled by Telegram. The message it was sent in is kept,
+// so the value can be checked against the chat history.
+type Throw struct {
+	Value     int
+	ChatID    int64
+	MessageID int
+}
+
+func (t Throw) IsZero() bool {
+	return t.Value == 0
+}
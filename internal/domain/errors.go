@@ -29,6 +29,8 @@ var (
 	ErrInvalidGameStatus               = errors.New("invalid game status")
 	ErrAFKPlayerNotFound               = errors.New("AFK player not found")
 	ErrAllPlayersAFK                   = errors.New("all players are AFK")
+	ErrNotGameCreator                  = errors.New("user is not the game creator")
+	ErrGameAlreadyStarted              = errors.New("game already started")
 	// Session errors.
 
 	ErrInvalidGameType         = errors.New("invalid game type")
@@ -37,6 +39,7 @@ var (
 	ErrGameNotStarted          = errors.New("game not started")
 	ErrSessionNotFound         = errors.New("session not found")
 	ErrSessionNotInProgress    = errors.New("session is not in progress")
+	ErrInvalidTimeout          = errors.New("invalid timeout")
 	// Bet errors.
 
 	ErrInsufficientTokens = errors.New("insufficient tokens")
@@ -44,4 +47,13 @@ var (
 	ErrInvalidStatus      = errors.New("invalid bet status")
 	ErrBetNotFound        = errors.New("bet not found")
 	ErrBetAlreadyPaid     = errors.New("bet already paid")
+	// Tournament errors.
+
+	ErrTournamentNotFound = errors.New("tournament not found")
+	// League errors.
+
+	ErrLeagueNotFound = errors.New("league not found")
+	// Matchmaking errors.
+
+	ErrTicketNotFound = errors.New("matchmaking ticket not found")
 )
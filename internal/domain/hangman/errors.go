@@ -0,0 +1,12 @@
+package hangman
+
+import "errors"
+
+var (
+	ErrInvalidLanguage = errors.New("invalid word list language")
+	ErrInvalidWord     = errors.New("invalid word")
+	ErrWordRequired    = errors.New("word required")
+	ErrEmptyWordList   = errors.New("word list is empty")
+	ErrInvalidLetter   = errors.New("letter is not in the alphabet")
+	ErrAlreadyGuessed  = errors.New("letter already guessed")
+)
@@ -0,0 +1,337 @@
+package hangman
+
+import (
+	"microgame-bot/internal/domain"
+	se "microgame-bot/internal/domain/session"
+	"microgame-bot/internal/domain/user"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// HiddenLetter stands for a letter of the word nobody has guessed yet.
+const HiddenLetter = '_'
+
+// Hangman is a competitive "Поле чудес": players take turns guessing letters of the same word.
+// A right letter scores a point for every occurrence and keeps the turn, a wrong one passes it
+// and spends one of the shared attempts. Once the word is revealed the higher score wins,
+// the player who spends the last attempt is hanged and loses.
+type Hangman struct {
+	createdAt time.Time
+	updatedAt time.Time
+	status    domain.GameStatus
+	language  Language
+	word      string
+	guessed   []rune
+	score1    int
+	score2    int
+	turn      user.ID
+	winnerID  user.ID
+	sessionID se.ID
+	id        ID
+	player1ID user.ID
+	player2ID user.ID
+	creatorID user.ID
+}
+
+func New(opts ...Opt) (Hangman, error) {
+	h := &Hangman{
+		status:   domain.GameStatusCreated,
+		language: LanguageRU,
+	}
+
+	for _, opt := range opts {
+		if err := opt(h); err != nil {
+			return Hangman{}, err
+		}
+	}
+
+	// Validate required fields
+	if h.id.IsZero() {
+		return Hangman{}, domain.ErrIDRequired
+	}
+	if h.sessionID.IsZero() {
+		return Hangman{}, domain.ErrSessionIDRequired
+	}
+	if h.creatorID.IsZero() {
+		return Hangman{}, domain.ErrCreatorIDRequired
+	}
+	if h.word == "" {
+		return Hangman{}, ErrWordRequired
+	}
+	if err := h.language.ValidateWord(h.word); err != nil {
+		return Hangman{}, err
+	}
+	if (h.player1ID.IsZero() || h.player2ID.IsZero()) &&
+		h.status != domain.GameStatusCreated &&
+		h.status != domain.GameStatusWaitingForPlayers &&
+		h.status != domain.GameStatusCancelled {
+		return Hangman{}, domain.ErrCantPlayWithoutPlayers
+	}
+
+	return *h, nil
+}
+
+func (h Hangman) ID() ID                    { return h.id }
+func (h Hangman) CreatorID() user.ID        { return h.creatorID }
+func (h Hangman) Player1ID() user.ID        { return h.player1ID }
+func (h Hangman) Player2ID() user.ID        { return h.player2ID }
+func (h Hangman) Language() Language        { return h.language }
+func (h Hangman) Word() string              { return h.word }
+func (h Hangman) Guessed() []rune           { return slices.Clone(h.guessed) }
+func (h Hangman) Score1() int               { return h.score1 }
+func (h Hangman) Score2() int               { return h.score2 }
+func (h Hangman) Turn() user.ID             { return h.turn }
+func (h Hangman) Status() domain.GameStatus { return h.status }
+func (h Hangman) CreatedAt() time.Time      { return h.createdAt }
+func (h Hangman) UpdatedAt() time.Time      { return h.updatedAt }
+func (h Hangman) SessionID() se.ID          { return h.sessionID }
+func (h Hangman) IDtoUUID() uuid.UUID       { return uuid.UUID(h.id) }
+func (h Hangman) Type() domain.GameType     { return domain.GameTypeHangman }
+
+func (h Hangman) Participants() []user.ID {
+	participants := []user.ID{}
+	if !h.player1ID.IsZero() {
+		participants = append(participants, h.player1ID)
+	}
+	if !h.player2ID.IsZero() {
+		participants = append(participants, h.player2ID)
+	}
+	return participants
+}
+
+// ScoreOf returns the score of the given player.
+func (h Hangman) ScoreOf(playerID user.ID) int {
+	switch playerID {
+	case h.player1ID:
+		return h.score1
+	case h.player2ID:
+		return h.score2
+	default:
+		return 0
+	}
+}
+
+// IsGuessed returns true if the letter has already been named.
+func (h Hangman) IsGuessed(letter rune) bool {
+	return slices.Contains(h.guessed, letter)
+}
+
+// Pattern returns the word with the letters nobody has guessed yet replaced by HiddenLetter.
+func (h Hangman) Pattern() []rune {
+	pattern := []rune(h.word)
+	for i, r := range pattern {
+		if !h.IsGuessed(r) {
+			pattern[i] = HiddenLetter
+		}
+	}
+	return pattern
+}
+
+// IsRevealed returns true once every letter of the word is guessed.
+func (h Hangman) IsRevealed() bool {
+	return !slices.Contains(h.Pattern(), HiddenLetter)
+}
+
+// WrongLetters returns the named letters missing from the word in the order they were named.
+func (h Hangman) WrongLetters() []rune {
+	wrong := []rune{}
+	for _, letter := range h.guessed {
+		if !h.inWord(letter) {
+			wrong = append(wrong, letter)
+		}
+	}
+	return wrong
+}
+
+// Misses returns the number of attempts spent on wrong letters.
+func (h Hangman) Misses() int {
+	return len(h.WrongLetters())
+}
+
+// AttemptsLeft returns the number of wrong letters the players may still name.
+func (h Hangman) AttemptsLeft() int {
+	return max(MaxMisses-h.Misses(), 0)
+}
+
+func (h Hangman) inWord(letter rune) bool {
+	return slices.Contains([]rune(h.word), letter)
+}
+
+func (h Hangman) opponentOf(playerID user.ID) user.ID {
+	if playerID == h.player1ID {
+		return h.player2ID
+	}
+	return h.player1ID
+}
+
+func (h Hangman) JoinGame(playerID user.ID) (Hangman, error) {
+	if h.IsFinished() {
+		return Hangman{}, domain.ErrGameOver
+	}
+
+	if !h.player1ID.IsZero() && !h.player2ID.IsZero() {
+		return Hangman{}, domain.ErrGameFull
+	}
+
+	if h.player1ID == playerID || h.player2ID == playerID {
+		return Hangman{}, domain.ErrPlayerAlreadyInGame
+	}
+
+	if h.player1ID.IsZero() {
+		h.player1ID = playerID
+		// Status remains WaitingForPlayers
+		return h, nil
+	}
+
+	h.player2ID = playerID
+	h.status = domain.GameStatusInProgress
+	h.turn = h.player1ID
+
+	return h, nil
+}
+
+// Cancel withdraws the game on behalf of its creator.
+// Only a game that is still waiting for players can be cancelled.
+func (h Hangman) Cancel(userID user.ID) (Hangman, error) {
+	if h.creatorID != userID {
+		return Hangman{}, domain.ErrNotGameCreator
+	}
+	if h.status != domain.GameStatusWaitingForPlayers {
+		return Hangman{}, domain.ErrGameAlreadyStarted
+	}
+
+	h.status = domain.GameStatusCancelled
+	return h, nil
+}
+
+// CanGuess checks that the player may name a letter now.
+func (h Hangman) CanGuess(playerID user.ID) error {
+	if h.IsFinished() {
+		return domain.ErrGameOver
+	}
+	if playerID != h.player1ID && playerID != h.player2ID {
+		return domain.ErrPlayerNotInGame
+	}
+	if h.status != domain.GameStatusInProgress {
+		return domain.ErrGameNotStarted
+	}
+	if h.turn != playerID {
+		return domain.ErrNotPlayersTurn
+	}
+	return nil
+}
+
+// Guess names the letter on behalf of the player and returns how many times it occurs in the word.
+func (h Hangman) Guess(playerID user.ID, letter rune) (Hangman, int, error) {
+	if err := h.CanGuess(playerID); err != nil {
+		return Hangman{}, 0, err
+	}
+	letter = NormalizeLetter(letter)
+	if !h.language.HasLetter(letter) {
+		return Hangman{}, 0, ErrInvalidLetter
+	}
+	if h.IsGuessed(letter) {
+		return Hangman{}, 0, ErrAlreadyGuessed
+	}
+
+	h.guessed = append(slices.Clone(h.guessed), letter)
+
+	occurrences := 0
+	for _, r := range h.word {
+		if r == letter {
+			occurrences++
+		}
+	}
+
+	if occurrences == 0 {
+		if h.Misses() >= MaxMisses {
+			// The last attempt is spent, the player who spent it is hanged
+			h.winnerID = h.opponentOf(playerID)
+			h.status = domain.GameStatusFinished
+			return h, 0, nil
+		}
+		h.turn = h.opponentOf(playerID)
+		return h, 0, nil
+	}
+
+	if playerID == h.player1ID {
+		h.score1 += occurrences
+	} else {
+		h.score2 += occurrences
+	}
+
+	if h.IsRevealed() {
+		switch {
+		case h.score1 > h.score2:
+			h.winnerID = h.player1ID
+		case h.score2 > h.score1:
+			h.winnerID = h.player2ID
+		}
+		h.status = domain.GameStatusFinished
+	}
+
+	return h, occurrences, nil
+}
+
+func (h Hangman) IsFinished() bool {
+	return !h.winnerID.IsZero() ||
+		h.status == domain.GameStatusCancelled ||
+		h.status == domain.GameStatusFinished ||
+		h.status == domain.GameStatusAbandoned
+}
+
+// IsDraw returns true if the word is revealed with equal scores.
+func (h Hangman) IsDraw() bool {
+	return h.status == domain.GameStatusFinished && h.winnerID.IsZero()
+}
+
+func (h Hangman) Winners() []user.ID {
+	if h.winnerID.IsZero() {
+		return []user.ID{}
+	}
+	return []user.ID{h.winnerID}
+}
+
+func (h Hangman) WinnerID() user.ID {
+	if h.winnerID == h.player1ID {
+		return h.player1ID
+	}
+	if h.winnerID == h.player2ID {
+		return h.player2ID
+	}
+	return user.ID{}
+}
+
+// IsStarted returns true if at least one letter has been named.
+func (h Hangman) IsStarted() bool {
+	return len(h.guessed) > 0
+}
+
+func (h Hangman) SetWinner(winnerID user.ID) (Hangman, error) {
+	if winnerID != h.player1ID && winnerID != h.player2ID {
+		return Hangman{}, domain.ErrPlayerNotInGame
+	}
+	h.winnerID = winnerID
+	return h, nil
+}
+
+// AFKPlayerID returns the player whose turn it is.
+func (h Hangman) AFKPlayerID() (user.ID, error) {
+	if h.turn.IsZero() {
+		return user.ID{}, domain.ErrAFKPlayerNotFound
+	}
+	return h.turn, nil
+}
+
+func (h Hangman) SetStatus(status domain.GameStatus) (Hangman, error) {
+	if status.IsZero() {
+		return Hangman{}, domain.ErrGameStatusRequired
+	}
+	if !status.IsValid() {
+		return Hangman{}, domain.ErrInvalidGameStatus
+	}
+	h.status = status
+	return h, nil
+}
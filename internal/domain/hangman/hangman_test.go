@@ -0,0 +1,175 @@
+package hangman
+
+import (
+	"microgame-bot/internal/domain"
+	se "microgame-bot/internal/domain/session"
+	"microgame-bot/internal/domain/user"
+	"microgame-bot/internal/utils"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newStartedGame(t *testing.T, language Language, word string) (Hangman, user.ID, user.ID) {
+	t.Helper()
+	player1 := user.ID(utils.NewUniqueID())
+	player2 := user.ID(utils.NewUniqueID())
+
+	game, err := New(
+		WithNewID(),
+		WithCreatorID(player1),
+		WithSessionID(se.ID(utils.NewUniqueID())),
+		WithLanguage(language),
+		WithWord(word),
+		WithStatus(domain.GameStatusWaitingForPlayers),
+	)
+	require.NoError(t, err)
+	game, err = game.JoinGame(player1)
+	require.NoError(t, err)
+	game, err = game.JoinGame(player2)
+	require.NoError(t, err)
+	require.Equal(t, domain.GameStatusInProgress, game.Status())
+	require.Equal(t, player1, game.Turn())
+
+	return game, player1, player2
+}
+
+func TestGuess_RightLetterScoresOccurrencesAndKeepsTurn(t *testing.T) {
+	game, player1, player2 := newStartedGame(t, LanguageRU, "арбуз")
+
+	game, occurrences, err := game.Guess(player1, 'а')
+	require.NoError(t, err)
+	assert.Equal(t, 1, occurrences)
+	assert.Equal(t, 1, game.Score1())
+	assert.Equal(t, player1, game.Turn())
+	assert.Equal(t, "А____", string(game.Pattern()))
+	assert.True(t, game.IsStarted())
+
+	_, _, err = game.Guess(player2, 'Б')
+	require.ErrorIs(t, err, domain.ErrNotPlayersTurn)
+
+	_, _, err = game.Guess(player1, 'А')
+	require.ErrorIs(t, err, ErrAlreadyGuessed)
+
+	_, _, err = game.Guess(player1, 'Q')
+	require.ErrorIs(t, err, ErrInvalidLetter)
+}
+
+func TestGuess_WrongLetterPassesTurn(t *testing.T) {
+	game, player1, player2 := newStartedGame(t, LanguageEN, "river")
+
+	game, occurrences, err := game.Guess(player1, 'Z')
+	require.NoError(t, err)
+	assert.Zero(t, occurrences)
+	assert.Equal(t, player2, game.Turn())
+	assert.Equal(t, MaxMisses-1, game.AttemptsLeft())
+	assert.Equal(t, []rune{'Z'}, game.WrongLetters())
+
+	afkID, err := game.AFKPlayerID()
+	require.NoError(t, err)
+	assert.Equal(t, player2, afkID)
+
+	game, occurrences, err = game.Guess(player2, 'R')
+	require.NoError(t, err)
+	assert.Equal(t, 2, occurrences)
+	assert.Equal(t, 2, game.Score2())
+	assert.Equal(t, player2, game.Turn())
+}
+
+func TestGuess_RevealedWordHigherScoreWins(t *testing.T) {
+	game, player1, player2 := newStartedGame(t, LanguageEN, "lemon")
+
+	var err error
+	game, _, err = game.Guess(player1, 'X')
+	require.NoError(t, err)
+	for _, letter := range "LEMO" {
+		game, _, err = game.Guess(player2, letter)
+		require.NoError(t, err)
+	}
+	assert.False(t, game.IsFinished())
+
+	game, _, err = game.Guess(player2, 'N')
+	require.NoError(t, err)
+	assert.True(t, game.IsRevealed())
+	assert.True(t, game.IsFinished())
+	assert.False(t, game.IsDraw())
+	assert.Equal(t, player2, game.WinnerID())
+	assert.Equal(t, []user.ID{player2}, game.Winners())
+}
+
+func TestGuess_RevealedWordEqualScoresDraw(t *testing.T) {
+	game, player1, player2 := newStartedGame(t, LanguageEN, "drum")
+
+	var err error
+	game, _, err = game.Guess(player1, 'D')
+	require.NoError(t, err)
+	game, _, err = game.Guess(player1, 'R')
+	require.NoError(t, err)
+	game, _, err = game.Guess(player1, 'X')
+	require.NoError(t, err)
+	game, _, err = game.Guess(player2, 'U')
+	require.NoError(t, err)
+	game, _, err = game.Guess(player2, 'M')
+	require.NoError(t, err)
+
+	assert.True(t, game.IsFinished())
+	assert.True(t, game.IsDraw())
+	assert.Empty(t, game.Winners())
+}
+
+func TestGuess_LastAttemptHangsThePlayer(t *testing.T) {
+	game, player1, player2 := newStartedGame(t, LanguageEN, "tiger")
+
+	players := []user.ID{player1, player2}
+	var err error
+	for i, letter := range "ABCDFH" {
+		game, _, err = game.Guess(players[i%2], letter)
+		require.NoError(t, err)
+	}
+
+	assert.True(t, game.IsFinished())
+	assert.Zero(t, game.AttemptsLeft())
+	// The sixth wrong letter was named by the second player
+	assert.Equal(t, player1, game.WinnerID())
+}
+
+func TestNew_ValidatesWord(t *testing.T) {
+	_, err := New(
+		WithNewID(),
+		WithCreatorID(user.ID(utils.NewUniqueID())),
+		WithSessionID(se.ID(utils.NewUniqueID())),
+		WithLanguage(LanguageEN),
+		WithWord("кошка"),
+	)
+	require.ErrorIs(t, err, ErrInvalidWord)
+
+	game, err := New(
+		WithNewID(),
+		WithCreatorID(user.ID(utils.NewUniqueID())),
+		WithSessionID(se.ID(utils.NewUniqueID())),
+		WithWord("ёлочка"),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "ЕЛОЧКА", game.Word())
+}
+
+func TestWords_EmbeddedListsAreValid(t *testing.T) {
+	for _, language := range Languages() {
+		words, err := Words(language)
+		require.NoError(t, err, language)
+		assert.NotEmpty(t, words, language)
+	}
+
+	_, err := Words(Language("de"))
+	require.ErrorIs(t, err, ErrInvalidLanguage)
+}
+
+func TestRandomWord_IsDeterministic(t *testing.T) {
+	first, err := RandomWord(LanguageRU, "seed", "game")
+	require.NoError(t, err)
+	second, err := RandomWord(LanguageRU, "seed", "game")
+	require.NoError(t, err)
+	assert.Equal(t, first, second)
+	require.NoError(t, LanguageRU.ValidateWord(first))
+}
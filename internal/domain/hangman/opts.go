@@ -0,0 +1,179 @@
+package hangman
+
+import (
+	"fmt"
+	"microgame-bot/internal/core"
+	"microgame-bot/internal/domain"
+	se "microgame-bot/internal/domain/session"
+	"microgame-bot/internal/domain/user"
+	"microgame-bot/internal/utils"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Opt func(*Hangman) error
+
+func WithID(id ID) Opt {
+	return func(h *Hangman) error {
+		if id.IsZero() {
+			return domain.ErrIDRequired
+		}
+		h.id = id
+		return nil
+	}
+}
+
+func WithNewID() Opt {
+	return WithID(ID(utils.NewUniqueID()))
+}
+
+func WithIDFromString(id string) Opt {
+	return func(h *Hangman) error {
+		idUUID, err := utils.UUIDFromString[ID](id)
+		if err != nil {
+			return fmt.Errorf("%w: %w", core.ErrFailedToParseID, err)
+		}
+		h.id = idUUID
+		return nil
+	}
+}
+
+func WithIDFromUUID(id uuid.UUID) Opt {
+	return WithID(ID(id))
+}
+
+func WithCreatorID(creatorID user.ID) Opt {
+	return func(h *Hangman) error {
+		if creatorID.IsZero() {
+			return domain.ErrCreatorIDRequired
+		}
+		h.creatorID = creatorID
+		return nil
+	}
+}
+
+func WithPlayer1ID(player1ID user.ID) Opt {
+	return func(h *Hangman) error {
+		h.player1ID = player1ID
+		return nil
+	}
+}
+
+func WithPlayer1IDFromUUID(player1ID uuid.UUID) Opt {
+	return WithPlayer1ID(user.ID(player1ID))
+}
+
+func WithPlayer2ID(player2ID user.ID) Opt {
+	return func(h *Hangman) error {
+		h.player2ID = player2ID
+		return nil
+	}
+}
+
+func WithPlayer2IDFromUUID(player2ID uuid.UUID) Opt {
+	return WithPlayer2ID(user.ID(player2ID))
+}
+
+func WithLanguage(language Language) Opt {
+	return func(h *Hangman) error {
+		if !language.IsValid() {
+			return ErrInvalidLanguage
+		}
+		h.language = language
+		return nil
+	}
+}
+
+// WithWord sets the word to guess, the word is normalized.
+func WithWord(word string) Opt {
+	return func(h *Hangman) error {
+		h.word = NormalizeWord(word)
+		return nil
+	}
+}
+
+// WithGuessed sets the letters named so far in the order they were named.
+func WithGuessed(guessed []rune) Opt {
+	return func(h *Hangman) error {
+		h.guessed = slices.Clone(guessed)
+		return nil
+	}
+}
+
+func WithScore1(score int) Opt {
+	return func(h *Hangman) error {
+		h.score1 = score
+		return nil
+	}
+}
+
+func WithScore2(score int) Opt {
+	return func(h *Hangman) error {
+		h.score2 = score
+		return nil
+	}
+}
+
+func WithTurn(turn user.ID) Opt {
+	return func(h *Hangman) error {
+		h.turn = turn
+		return nil
+	}
+}
+
+func WithTurnFromUUID(turn uuid.UUID) Opt {
+	return WithTurn(user.ID(turn))
+}
+
+func WithStatus(status domain.GameStatus) Opt {
+	return func(h *Hangman) error {
+		if status.IsZero() {
+			return domain.ErrGameStatusRequired
+		}
+		if !status.IsValid() {
+			return domain.ErrInvalidGameStatus
+		}
+		h.status = status
+		return nil
+	}
+}
+
+func WithWinnerID(winnerID user.ID) Opt {
+	return func(h *Hangman) error {
+		h.winnerID = winnerID
+		return nil
+	}
+}
+
+func WithWinnerIDFromUUID(winnerID uuid.UUID) Opt {
+	return WithWinnerID(user.ID(winnerID))
+}
+
+func WithCreatedAt(createdAt time.Time) Opt {
+	return func(h *Hangman) error {
+		if createdAt.IsZero() {
+			return domain.ErrCreatedAtRequired
+		}
+		h.createdAt = createdAt
+		return nil
+	}
+}
+
+func WithUpdatedAt(updatedAt time.Time) Opt {
+	return func(h *Hangman) error {
+		if updatedAt.IsZero() {
+			return domain.ErrUpdatedAtRequired
+		}
+		h.updatedAt = updatedAt
+		return nil
+	}
+}
+
+func WithSessionID(sessionID se.ID) Opt {
+	return func(h *Hangman) error {
+		h.sessionID = sessionID
+		return nil
+	}
+}
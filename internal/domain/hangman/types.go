@@ -0,0 +1,108 @@
+package hangman
+
+import (
+	"slices"
+	"strings"
+)
+
+// MaxMisses is the number of wrong letters both players share before the man is hanged.
+const MaxMisses = 6
+
+// MinWordLength is the shortest word accepted from the word lists.
+const MinWordLength = 4
+
+type Language string
+
+const (
+	LanguageRU Language = "ru"
+	LanguageEN Language = "en"
+)
+
+// Languages returns every language in the order they are offered to players.
+func Languages() []Language {
+	return []Language{LanguageRU, LanguageEN}
+}
+
+func (l Language) String() string {
+	return string(l)
+}
+
+func (l Language) IsValid() bool {
+	switch l {
+	case LanguageRU, LanguageEN:
+		return true
+	default:
+		return false
+	}
+}
+
+// LanguageFromString parses the language of the word list.
+func LanguageFromString(s string) (Language, error) {
+	l := Language(strings.ToLower(s))
+	if !l.IsValid() {
+		return "", ErrInvalidLanguage
+	}
+	return l, nil
+}
+
+// Title returns the name of the game in the language shown to players.
+func (l Language) Title() string {
+	if l == LanguageEN {
+		return "Hangman"
+	}
+	return "Поле чудес"
+}
+
+// Flag returns the flag of the language.
+func (l Language) Flag() string {
+	if l == LanguageEN {
+		return "🇬🇧"
+	}
+	return "🇷🇺"
+}
+
+// Alphabet returns the letters of the language in the keyboard order.
+// Ё is folded into Е, so the Russian alphabet has 32 letters.
+func (l Language) Alphabet() []rune {
+	if l == LanguageEN {
+		return []rune("ABCDEFGHIJKLMNOPQRSTUVWXYZ")
+	}
+	return []rune("АБВГДЕЖЗИЙКЛМНОПРСТУФХЦЧШЩЪЫЬЭЮЯ")
+}
+
+// HasLetter checks that the letter belongs to the alphabet of the language.
+func (l Language) HasLetter(letter rune) bool {
+	return slices.Contains(l.Alphabet(), letter)
+}
+
+// NormalizeLetter brings the letter to the form used in words and guesses.
+func NormalizeLetter(letter rune) rune {
+	letter = []rune(strings.ToUpper(string(letter)))[0]
+	if letter == 'Ё' {
+		return 'Е'
+	}
+	return letter
+}
+
+// NormalizeWord brings the word to the form used in the game.
+func NormalizeWord(word string) string {
+	runes := []rune(strings.TrimSpace(word))
+	for i, r := range runes {
+		runes[i] = NormalizeLetter(r)
+	}
+	return string(runes)
+}
+
+// ValidateWord checks that the normalized word is long enough and is spelled with the alphabet of the language.
+func (l Language) ValidateWord(word string) error {
+	runes := []rune(word)
+	if len(runes) < MinWordLength {
+		return ErrInvalidWord
+	}
+	for _, r := range runes {
+		if !l.HasLetter(r) {
+			return ErrInvalidWord
+		}
+	}
+	return nil
+}
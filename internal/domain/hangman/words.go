@@ -0,0 +1,59 @@
+package hangman
+
+import (
+	"bufio"
+	"bytes"
+	"embed"
+	"fmt"
+	"microgame-bot/internal/utils"
+	"strings"
+)
+
+// wordFiles keeps one list per language: words/<language>.txt, a word per line, # starts a comment.
+//
+//go:embed words/*.txt
+var wordFiles embed.FS
+
+// Words loads the embedded word list of the language.
+// Words are normalized, so the lists may use any case and Ё.
+func Words(language Language) ([]string, error) {
+	if !language.IsValid() {
+		return nil, ErrInvalidLanguage
+	}
+	data, err := wordFiles.ReadFile("words/" + language.String() + ".txt")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s word list: %w", language, err)
+	}
+
+	var words []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		word := NormalizeWord(line)
+		if err := language.ValidateWord(word); err != nil {
+			return nil, fmt.Errorf("%w in %s word list: %q", err, language, line)
+		}
+		words = append(words, word)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to scan %s word list: %w", language, err)
+	}
+	if len(words) == 0 {
+		return nil, ErrEmptyWordList
+	}
+
+	return words, nil
+}
+
+// RandomWord picks the word of a game from the list of the language.
+// The pick depends on the session seed and the key only, so a revealed seed proves the word wasn't swapped.
+func RandomWord(language Language, seed string, key string) (string, error) {
+	words, err := Words(language)
+	if err != nil {
+		return "", err
+	}
+	return words[utils.SeededRandInt(seed, key, len(words))], nil
+}
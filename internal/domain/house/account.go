@@ -0,0 +1,62 @@
+package house
+
+import (
+	"errors"
+	"microgame-bot/internal/domain"
+	"time"
+)
+
+var ErrAccountIDRequired = errors.New("house account ID required")
+
+type AccountID string
+
+// MainAccountID is the account backing every solo game.
+const MainAccountID AccountID = "main"
+
+func (id AccountID) String() string {
+	return string(id)
+}
+
+// Account is the bank of solo games: it collects the stakes players lose and pays their wins.
+// The balance may go below zero, the house never refuses a payout.
+type Account struct {
+	createdAt time.Time
+	updatedAt time.Time
+	id        AccountID
+	balance   int64
+}
+
+func New(opts ...Opt) (Account, error) {
+	a := &Account{}
+
+	for _, opt := range opts {
+		if err := opt(a); err != nil {
+			return Account{}, err
+		}
+	}
+
+	if a.id == "" {
+		return Account{}, ErrAccountIDRequired
+	}
+
+	return *a, nil
+}
+
+func (a Account) ID() AccountID        { return a.id }
+func (a Account) Balance() int64       { return a.balance }
+func (a Account) CreatedAt() time.Time { return a.createdAt }
+func (a Account) UpdatedAt() time.Time { return a.updatedAt }
+
+// Collect takes a lost stake into the house.
+func (a Account) Collect(amount domain.Token) Account {
+	//nolint:gosec // Token amounts are far below the int64 limit.
+	a.balance += int64(amount)
+	return a
+}
+
+// Pay takes the winnings of a player out of the house.
+func (a Account) Pay(amount domain.Token) Account {
+	//nolint:gosec // Token amounts are far below the int64 limit.
+	a.balance -= int64(amount)
+	return a
+}
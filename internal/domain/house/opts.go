@@ -0,0 +1,45 @@
+package house
+
+import (
+	"microgame-bot/internal/domain"
+	"time"
+)
+
+type Opt func(*Account) error
+
+func WithID(id AccountID) Opt {
+	return func(a *Account) error {
+		if id == "" {
+			return ErrAccountIDRequired
+		}
+		a.id = id
+		return nil
+	}
+}
+
+func WithBalance(balance int64) Opt {
+	return func(a *Account) error {
+		a.balance = balance
+		return nil
+	}
+}
+
+func WithCreatedAt(createdAt time.Time) Opt {
+	return func(a *Account) error {
+		if createdAt.IsZero() {
+			return domain.ErrCreatedAtRequired
+		}
+		a.createdAt = createdAt
+		return nil
+	}
+}
+
+func WithUpdatedAt(updatedAt time.Time) Opt {
+	return func(a *Account) error {
+		if updatedAt.IsZero() {
+			return domain.ErrUpdatedAtRequired
+		}
+		a.updatedAt = updatedAt
+		return nil
+	}
+}
@@ -0,0 +1,308 @@
+package league
+
+import (
+	"microgame-bot/internal/domain"
+	"microgame-bot/internal/domain/session"
+	"microgame-bot/internal/domain/user"
+	"slices"
+	"time"
+)
+
+// League is a round robin where every registered player meets everyone else once.
+// Fixtures are played as regular game sessions tagged with the league ID until the deadline,
+// unplayed ones are forfeited when the league is finalised.
+type League struct {
+	createdAt       time.Time
+	updatedAt       time.Time
+	deadline        time.Time
+	gameType        domain.GameType
+	inlineMessageID domain.InlineMessageID
+	status          domain.GameStatus
+	players         []user.ID
+	fixtures        []Fixture
+	gameCount       int
+	duration        time.Duration
+	id              ID
+	organizerID     user.ID
+}
+
+func New(opts ...Opt) (League, error) {
+	l := &League{
+		status:    domain.GameStatusWaitingForPlayers,
+		gameCount: 1,
+		duration:  DefaultDuration,
+	}
+
+	for _, opt := range opts {
+		if err := opt(l); err != nil {
+			return League{}, err
+		}
+	}
+
+	// Validate required fields
+	if l.id.IsZero() {
+		return League{}, domain.ErrIDRequired
+	}
+	if l.organizerID.IsZero() {
+		return League{}, domain.ErrCreatorIDRequired
+	}
+	if l.gameType != domain.GameTypeTTT && l.gameType != domain.GameTypeRPS {
+		return League{}, domain.ErrInvalidGameType
+	}
+	if l.gameCount <= 0 {
+		return League{}, domain.ErrGameCountRequired
+	}
+	if l.duration <= 0 || l.duration > MaxDuration {
+		return League{}, ErrInvalidDuration
+	}
+	if len(l.players) > MaxPlayers {
+		return League{}, ErrLeagueFull
+	}
+
+	return *l, nil
+}
+
+func (l League) ID() ID                                  { return l.id }
+func (l League) OrganizerID() user.ID                    { return l.organizerID }
+func (l League) GameType() domain.GameType               { return l.gameType }
+func (l League) InlineMessageID() domain.InlineMessageID { return l.inlineMessageID }
+func (l League) Status() domain.GameStatus               { return l.status }
+func (l League) GameCount() int                          { return l.gameCount }
+func (l League) Duration() time.Duration                 { return l.duration }
+func (l League) Deadline() time.Time                     { return l.deadline }
+func (l League) CreatedAt() time.Time                    { return l.createdAt }
+func (l League) UpdatedAt() time.Time                    { return l.updatedAt }
+func (l League) Players() []user.ID                      { return slices.Clone(l.players) }
+func (l League) Fixtures() []Fixture                     { return slices.Clone(l.fixtures) }
+
+// IsRegistered returns true if the user plays in the league.
+func (l League) IsRegistered(userID user.ID) bool {
+	return slices.Contains(l.players, userID)
+}
+
+// IsOver returns true if the league is finished or cancelled.
+func (l League) IsOver() bool {
+	return l.status == domain.GameStatusFinished || l.status == domain.GameStatusCancelled
+}
+
+// IsDeadlinePassed returns true if fixtures can no longer be played.
+func (l League) IsDeadlinePassed(now time.Time) bool {
+	return !l.deadline.IsZero() && !now.Before(l.deadline)
+}
+
+// Register adds the player to the league.
+func (l League) Register(userID user.ID) (League, error) {
+	if userID.IsZero() {
+		return League{}, domain.ErrUserIDRequired
+	}
+	if l.status != domain.GameStatusWaitingForPlayers {
+		return League{}, ErrRegistrationClosed
+	}
+	if l.IsRegistered(userID) {
+		return League{}, ErrAlreadyRegistered
+	}
+	if len(l.players) >= MaxPlayers {
+		return League{}, ErrLeagueFull
+	}
+
+	l.players = append(slices.Clone(l.players), userID)
+	l.updatedAt = time.Now()
+	return l, nil
+}
+
+// Cancel withdraws the league on behalf of its organizer.
+// Only a league that has not started yet can be cancelled.
+func (l League) Cancel(userID user.ID) (League, error) {
+	if l.organizerID != userID {
+		return League{}, domain.ErrNotGameCreator
+	}
+	return l.Expire()
+}
+
+// Expire cancels the league that was never started.
+func (l League) Expire() (League, error) {
+	if l.status != domain.GameStatusWaitingForPlayers {
+		return League{}, domain.ErrGameAlreadyStarted
+	}
+	l.status = domain.GameStatusCancelled
+	l.updatedAt = time.Now()
+	return l, nil
+}
+
+// Start closes registration on behalf of the organizer and schedules the fixtures.
+// The schedule is built with the circle method, so every player meets everyone else exactly once
+// and nobody plays twice in the same round.
+func (l League) Start(userID user.ID, now time.Time) (League, error) {
+	if l.organizerID != userID {
+		return League{}, domain.ErrNotGameCreator
+	}
+	if l.status != domain.GameStatusWaitingForPlayers {
+		return League{}, domain.ErrGameAlreadyStarted
+	}
+	if len(l.players) < MinPlayers {
+		return League{}, ErrNotEnoughPlayers
+	}
+
+	// An odd number of players gets a bye, whoever meets it rests in that round.
+	circle := slices.Clone(l.players)
+	//nolint:mnd // Players are paired two by two.
+	if len(circle)%2 != 0 {
+		circle = append(circle, user.ID{})
+	}
+
+	n := len(circle)
+	fixtures := make([]Fixture, 0, len(l.players)*(len(l.players)-1)/2) //nolint:mnd // Pairs count.
+	for round := range n - 1 {
+		for i := range n / 2 { //nolint:mnd // Pairs per round.
+			home, away := circle[i], circle[n-1-i]
+			if home.IsZero() || away.IsZero() {
+				continue
+			}
+			// Alternate sides so the first player does not always start.
+			//nolint:mnd // Every other round.
+			if round%2 == 1 {
+				home, away = away, home
+			}
+			fixtures = append(fixtures, Fixture{Home: home, Away: away, Round: round})
+		}
+		// Keep the first player in place and rotate the rest clockwise.
+		last := circle[n-1]
+		copy(circle[2:], circle[1:n-1])
+		circle[1] = last
+	}
+
+	l.fixtures = fixtures
+	l.status = domain.GameStatusInProgress
+	l.deadline = now.Add(l.duration)
+	l.updatedAt = now
+	return l, nil
+}
+
+// Fixture returns the fixture by its number in the schedule.
+func (l League) Fixture(number int) (Fixture, error) {
+	if number < 0 || number >= len(l.fixtures) {
+		return Fixture{}, ErrFixtureNotFound
+	}
+	return l.fixtures[number], nil
+}
+
+// PendingFixtures returns numbers of fixtures that can be started right now.
+func (l League) PendingFixtures() []int {
+	var numbers []int
+	for i, f := range l.fixtures {
+		if !f.IsStarted() && !f.IsPlayed() {
+			numbers = append(numbers, i)
+		}
+	}
+	return numbers
+}
+
+// RunningFixtures returns numbers of fixtures that are being played.
+func (l League) RunningFixtures() []int {
+	var numbers []int
+	for i, f := range l.fixtures {
+		if f.IsStarted() && !f.IsPlayed() {
+			numbers = append(numbers, i)
+		}
+	}
+	return numbers
+}
+
+// UnplayedFixtures returns numbers of the player's fixtures that have no result yet.
+func (l League) UnplayedFixtures(userID user.ID) []int {
+	var numbers []int
+	for i, f := range l.fixtures {
+		if f.HasPlayer(userID) && !f.IsPlayed() {
+			numbers = append(numbers, i)
+		}
+	}
+	return numbers
+}
+
+// StartFixture binds the game session to the fixture.
+func (l League) StartFixture(number int, sessionID session.ID, now time.Time) (League, error) {
+	if sessionID.IsZero() {
+		return League{}, domain.ErrSessionIDRequired
+	}
+	if l.status != domain.GameStatusInProgress {
+		return League{}, ErrLeagueNotStarted
+	}
+	if l.IsDeadlinePassed(now) {
+		return League{}, ErrDeadlinePassed
+	}
+	f, err := l.Fixture(number)
+	if err != nil {
+		return League{}, err
+	}
+	if f.IsPlayed() {
+		return League{}, ErrFixtureAlreadyPlayed
+	}
+	if f.IsStarted() {
+		return League{}, ErrFixtureAlreadyStarted
+	}
+
+	l.fixtures = slices.Clone(l.fixtures)
+	l.fixtures[number].SessionID = sessionID
+	l.updatedAt = now
+	return l, nil
+}
+
+// ReportResult records the result of a played fixture. Zero winner ID means a draw.
+func (l League) ReportResult(number int, winnerID user.ID) (League, error) {
+	result := FixtureResultWin
+	if winnerID.IsZero() {
+		result = FixtureResultDraw
+	}
+	return l.record(number, winnerID, result)
+}
+
+// Forfeit awards the fixture without playing it. Zero winner ID means both players forfeit.
+func (l League) Forfeit(number int, winnerID user.ID) (League, error) {
+	return l.record(number, winnerID, FixtureResultForfeit)
+}
+
+func (l League) record(number int, winnerID user.ID, result FixtureResult) (League, error) {
+	if l.status != domain.GameStatusInProgress {
+		return League{}, ErrLeagueNotStarted
+	}
+	f, err := l.Fixture(number)
+	if err != nil {
+		return League{}, err
+	}
+	if f.IsPlayed() {
+		return League{}, ErrFixtureAlreadyPlayed
+	}
+	if !winnerID.IsZero() && !f.HasPlayer(winnerID) {
+		return League{}, ErrInvalidFixtureWinner
+	}
+
+	l.fixtures = slices.Clone(l.fixtures)
+	l.fixtures[number].Winner = winnerID
+	l.fixtures[number].Result = result
+	l.updatedAt = time.Now()
+
+	if !slices.ContainsFunc(l.fixtures, func(f Fixture) bool { return !f.IsPlayed() }) {
+		l.status = domain.GameStatusFinished
+	}
+	return l, nil
+}
+
+// Finalise closes the league at the deadline.
+// Fixtures that were never started are forfeited by both players.
+func (l League) Finalise() (League, error) {
+	if l.status != domain.GameStatusInProgress {
+		return League{}, ErrLeagueNotStarted
+	}
+
+	l.fixtures = slices.Clone(l.fixtures)
+	for i, f := range l.fixtures {
+		if f.IsPlayed() {
+			continue
+		}
+		l.fixtures[i].Winner = user.ID{}
+		l.fixtures[i].Result = FixtureResultForfeit
+	}
+	l.status = domain.GameStatusFinished
+	l.updatedAt = time.Now()
+	return l, nil
+}
@@ -0,0 +1,129 @@
+package league
+
+import (
+	"microgame-bot/internal/domain"
+	"microgame-bot/internal/domain/session"
+	"microgame-bot/internal/domain/user"
+	"microgame-bot/internal/utils"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLeague(t *testing.T, players int) (League, user.ID) {
+	t.Helper()
+	organizerID := user.ID(utils.NewUniqueID())
+	l, err := New(
+		WithNewID(),
+		WithOrganizerID(organizerID),
+		WithGameType(domain.GameTypeRPS),
+	)
+	require.NoError(t, err)
+	for range players {
+		l, err = l.Register(user.ID(utils.NewUniqueID()))
+		require.NoError(t, err)
+	}
+	return l, organizerID
+}
+
+func TestLeague_Start_NotEnoughPlayers(t *testing.T) {
+	l, organizerID := newTestLeague(t, MinPlayers-1)
+
+	_, err := l.Start(organizerID, time.Now())
+	assert.ErrorIs(t, err, ErrNotEnoughPlayers)
+}
+
+func TestLeague_Start_NotOrganizer(t *testing.T) {
+	l, _ := newTestLeague(t, MinPlayers)
+
+	_, err := l.Start(user.ID(utils.NewUniqueID()), time.Now())
+	assert.ErrorIs(t, err, domain.ErrNotGameCreator)
+}
+
+func TestLeague_Start_EveryoneMeetsEveryoneOnce(t *testing.T) {
+	for _, players := range []int{3, 4, 5, 8} {
+		l, organizerID := newTestLeague(t, players)
+		now := time.Now()
+
+		l, err := l.Start(organizerID, now)
+		require.NoError(t, err)
+		assert.Equal(t, domain.GameStatusInProgress, l.Status())
+		assert.Equal(t, now.Add(DefaultDuration), l.Deadline())
+		require.Len(t, l.Fixtures(), players*(players-1)/2)
+
+		pairs := make(map[[2]user.ID]bool)
+		perRound := make(map[int]map[user.ID]bool)
+		for _, f := range l.Fixtures() {
+			assert.NotEqual(t, f.Home, f.Away)
+			key := [2]user.ID{f.Home, f.Away}
+			if f.Away.String() < f.Home.String() {
+				key = [2]user.ID{f.Away, f.Home}
+			}
+			assert.False(t, pairs[key], "pair scheduled twice")
+			pairs[key] = true
+
+			if perRound[f.Round] == nil {
+				perRound[f.Round] = make(map[user.ID]bool)
+			}
+			assert.False(t, perRound[f.Round][f.Home], "player plays twice in a round")
+			assert.False(t, perRound[f.Round][f.Away], "player plays twice in a round")
+			perRound[f.Round][f.Home] = true
+			perRound[f.Round][f.Away] = true
+		}
+	}
+}
+
+func TestLeague_ResultsAndTable(t *testing.T) {
+	l, organizerID := newTestLeague(t, 3)
+	l, err := l.Start(organizerID, time.Now())
+	require.NoError(t, err)
+
+	fixtures := l.Fixtures()
+	require.Len(t, fixtures, 3)
+
+	l, err = l.StartFixture(0, session.ID(utils.NewUniqueID()), time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, []int{0}, l.RunningFixtures())
+
+	_, err = l.ReportResult(0, user.ID(utils.NewUniqueID()))
+	require.ErrorIs(t, err, ErrInvalidFixtureWinner)
+
+	winnerID := fixtures[0].Home
+	l, err = l.ReportResult(0, winnerID)
+	require.NoError(t, err)
+	l, err = l.ReportResult(1, user.ID{})
+	require.NoError(t, err)
+
+	_, err = l.ReportResult(0, winnerID)
+	require.ErrorIs(t, err, ErrFixtureAlreadyPlayed)
+
+	l, err = l.Finalise()
+	require.NoError(t, err)
+	assert.Equal(t, domain.GameStatusFinished, l.Status())
+	assert.Equal(t, FixtureResultForfeit, l.Fixtures()[2].Result)
+
+	table := l.Table()
+	require.Len(t, table, 3)
+	assert.Equal(t, winnerID, table[0].UserID)
+	assert.Equal(t, winnerID, l.Leader())
+
+	points := 0
+	for _, row := range table {
+		assert.Equal(t, 2, row.Played)
+		points += row.Points
+	}
+	// One win and one draw, the double forfeit gives no points.
+	assert.Equal(t, PointsWin+2*PointsDraw, points)
+}
+
+func TestLeague_StartFixture_DeadlinePassed(t *testing.T) {
+	l, organizerID := newTestLeague(t, 3)
+	now := time.Now()
+	l, err := l.Start(organizerID, now)
+	require.NoError(t, err)
+
+	_, err = l.StartFixture(0, session.ID(utils.NewUniqueID()), now.Add(DefaultDuration))
+	assert.ErrorIs(t, err, ErrDeadlinePassed)
+}
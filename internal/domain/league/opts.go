@@ -0,0 +1,146 @@
+package league
+
+import (
+	"fmt"
+	"microgame-bot/internal/core"
+	"microgame-bot/internal/domain"
+	"microgame-bot/internal/domain/user"
+	"microgame-bot/internal/utils"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Opt func(*League) error
+
+func WithID(id ID) Opt {
+	return func(l *League) error {
+		if id.IsZero() {
+			return domain.ErrIDRequired
+		}
+		l.id = id
+		return nil
+	}
+}
+
+func WithNewID() Opt {
+	return WithID(ID(utils.NewUniqueID()))
+}
+
+func WithIDFromString(id string) Opt {
+	return func(l *League) error {
+		idUUID, err := utils.UUIDFromString[ID](id)
+		if err != nil {
+			return fmt.Errorf("%w: %w", core.ErrFailedToParseID, err)
+		}
+		l.id = idUUID
+		return nil
+	}
+}
+
+func WithIDFromUUID(id uuid.UUID) Opt {
+	return WithID(ID(id))
+}
+
+func WithOrganizerID(organizerID user.ID) Opt {
+	return func(l *League) error {
+		if organizerID.IsZero() {
+			return domain.ErrCreatorIDRequired
+		}
+		l.organizerID = organizerID
+		return nil
+	}
+}
+
+func WithGameType(gameType domain.GameType) Opt {
+	return func(l *League) error {
+		l.gameType = gameType
+		return nil
+	}
+}
+
+func WithInlineMessageID(inlineMessageID domain.InlineMessageID) Opt {
+	return func(l *League) error {
+		if inlineMessageID.IsZero() {
+			return domain.ErrInlineMessageIDRequired
+		}
+		l.inlineMessageID = inlineMessageID
+		return nil
+	}
+}
+
+func WithInlineMessageIDFromString(inlineMessageID string) Opt {
+	return WithInlineMessageID(domain.InlineMessageID(inlineMessageID))
+}
+
+func WithStatus(status domain.GameStatus) Opt {
+	return func(l *League) error {
+		if status.IsZero() {
+			return domain.ErrGameStatusRequired
+		}
+		if !status.IsValid() {
+			return domain.ErrInvalidGameStatus
+		}
+		l.status = status
+		return nil
+	}
+}
+
+func WithGameCount(gameCount int) Opt {
+	return func(l *League) error {
+		l.gameCount = gameCount
+		return nil
+	}
+}
+
+func WithDuration(duration time.Duration) Opt {
+	return func(l *League) error {
+		if duration <= 0 || duration > MaxDuration {
+			return ErrInvalidDuration
+		}
+		l.duration = duration
+		return nil
+	}
+}
+
+func WithDeadline(deadline time.Time) Opt {
+	return func(l *League) error {
+		l.deadline = deadline
+		return nil
+	}
+}
+
+func WithPlayers(players []user.ID) Opt {
+	return func(l *League) error {
+		l.players = slices.Clone(players)
+		return nil
+	}
+}
+
+func WithFixtures(fixtures []Fixture) Opt {
+	return func(l *League) error {
+		l.fixtures = slices.Clone(fixtures)
+		return nil
+	}
+}
+
+func WithCreatedAt(createdAt time.Time) Opt {
+	return func(l *League) error {
+		if createdAt.IsZero() {
+			return domain.ErrCreatedAtRequired
+		}
+		l.createdAt = createdAt
+		return nil
+	}
+}
+
+func WithUpdatedAt(updatedAt time.Time) Opt {
+	return func(l *League) error {
+		if updatedAt.IsZero() {
+			return domain.ErrUpdatedAtRequired
+		}
+		l.updatedAt = updatedAt
+		return nil
+	}
+}
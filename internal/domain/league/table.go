@@ -0,0 +1,58 @@
+package league
+
+import (
+	"microgame-bot/internal/domain/user"
+	"slices"
+)
+
+// Table returns the league standings: points first, then wins.
+// Players level on both keep the registration order.
+func (l League) Table() []Standing {
+	rows := make([]Standing, len(l.players))
+	index := make(map[user.ID]int, len(l.players))
+	for i, playerID := range l.players {
+		rows[i] = Standing{UserID: playerID}
+		index[playerID] = i
+	}
+
+	for _, f := range l.fixtures {
+		if !f.IsPlayed() {
+			continue
+		}
+		for _, playerID := range []user.ID{f.Home, f.Away} {
+			i, ok := index[playerID]
+			if !ok {
+				continue
+			}
+			rows[i].Played++
+			switch {
+			case f.Result == FixtureResultDraw:
+				rows[i].Drawn++
+				rows[i].Points += PointsDraw
+			case f.Winner == playerID:
+				rows[i].Won++
+				rows[i].Points += PointsWin
+			default:
+				rows[i].Lost++
+				rows[i].Points += PointsLoss
+			}
+		}
+	}
+
+	slices.SortStableFunc(rows, func(a, b Standing) int {
+		if a.Points != b.Points {
+			return b.Points - a.Points
+		}
+		return b.Won - a.Won
+	})
+	return rows
+}
+
+// Leader returns the player on top of the table or zero ID if nobody has points yet.
+func (l League) Leader() user.ID {
+	table := l.Table()
+	if len(table) == 0 || table[0].Points == 0 {
+		return user.ID{}
+	}
+	return table[0].UserID
+}
@@ -0,0 +1,106 @@
+package league
+
+import (
+	"errors"
+	"microgame-bot/internal/domain/session"
+	"microgame-bot/internal/domain/user"
+	"time"
+)
+
+var (
+	ErrAlreadyRegistered     = errors.New("player already registered")
+	ErrLeagueFull            = errors.New("league is full")
+	ErrRegistrationClosed    = errors.New("league registration is closed")
+	ErrNotEnoughPlayers      = errors.New("not enough players to start the league")
+	ErrLeagueNotStarted      = errors.New("league not started")
+	ErrInvalidDuration       = errors.New("invalid league duration")
+	ErrDeadlinePassed        = errors.New("league deadline has passed")
+	ErrFixtureNotFound       = errors.New("league fixture not found")
+	ErrFixtureAlreadyStarted = errors.New("league fixture already started")
+	ErrFixtureAlreadyPlayed  = errors.New("league fixture already played")
+	ErrInvalidFixtureWinner  = errors.New("winner is not a fixture player")
+)
+
+const (
+	MinPlayers = 3
+	MaxPlayers = 8
+
+	// DefaultDuration is the time players have to play all fixtures once the league starts.
+	DefaultDuration = 7 * 24 * time.Hour
+	// MaxDuration limits the league length set by the organizer.
+	MaxDuration = 14 * 24 * time.Hour
+	// FixtureMoveTimeout is the move clock of every fixture,
+	// so a table never waits on a player who walked away in the middle of a game.
+	FixtureMoveTimeout = 5 * time.Minute
+	// RegistrationTimeout is how long a league may wait for the organizer to start it.
+	RegistrationTimeout = 48 * time.Hour
+
+	PointsWin  = 3
+	PointsDraw = 1
+	PointsLoss = 0
+)
+
+type FixtureResult string
+
+const (
+	FixtureResultNone    FixtureResult = ""
+	FixtureResultWin     FixtureResult = "win"
+	FixtureResultDraw    FixtureResult = "draw"
+	FixtureResultForfeit FixtureResult = "forfeit"
+)
+
+// Fixture is a single pairing of the league schedule.
+// A forfeit without a winner means neither player showed up, both take a loss.
+type Fixture struct {
+	Home      user.ID
+	Away      user.ID
+	Winner    user.ID
+	SessionID session.ID
+	Result    FixtureResult
+	Round     int
+}
+
+// IsStarted returns true if the fixture has a game session.
+func (f Fixture) IsStarted() bool {
+	return !f.SessionID.IsZero()
+}
+
+// IsPlayed returns true if the fixture has a result.
+func (f Fixture) IsPlayed() bool {
+	return f.Result != FixtureResultNone
+}
+
+// HasPlayer returns true if the user plays in the fixture.
+func (f Fixture) HasPlayer(userID user.ID) bool {
+	return !userID.IsZero() && (f.Home == userID || f.Away == userID)
+}
+
+// Opponent returns the other player of the fixture.
+func (f Fixture) Opponent(userID user.ID) user.ID {
+	switch userID {
+	case f.Home:
+		return f.Away
+	case f.Away:
+		return f.Home
+	default:
+		return user.ID{}
+	}
+}
+
+// Standing is a row of the league table.
+type Standing struct {
+	UserID user.ID
+	Played int
+	Won    int
+	Drawn  int
+	Lost   int
+	Points int
+}
+
+// ClampDuration keeps the league duration within the allowed range.
+func ClampDuration(d time.Duration) time.Duration {
+	if d <= 0 {
+		return DefaultDuration
+	}
+	return min(d, MaxDuration)
+}
@@ -0,0 +1,138 @@
+package matchmaking
+
+import (
+	"microgame-bot/internal/domain"
+	"microgame-bot/internal/domain/session"
+	"microgame-bot/internal/domain/user"
+	"microgame-bot/internal/utils"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestTicket(t *testing.T, gameType domain.GameType, stake domain.Token, createdAt time.Time) Ticket {
+	t.Helper()
+	ticket, err := New(
+		WithNewID(),
+		WithUserID(user.ID(utils.NewUniqueID())),
+		WithGameType(gameType),
+		WithStake(stake),
+		WithMessage(Message{ChatID: 1, MessageID: 1}),
+		WithCreatedAt(createdAt),
+	)
+	require.NoError(t, err)
+	return ticket
+}
+
+func TestNew_InvalidStake(t *testing.T) {
+	_, err := New(
+		WithNewID(),
+		WithUserID(user.ID(utils.NewUniqueID())),
+		WithGameType(domain.GameTypeRPS),
+		WithStake(7),
+		WithMessage(Message{ChatID: 1, MessageID: 1}),
+	)
+	assert.ErrorIs(t, err, ErrInvalidStake)
+}
+
+func TestPairTickets_SameGameAndStakeOnly(t *testing.T) {
+	now := time.Now()
+	rpsTen := newTestTicket(t, domain.GameTypeRPS, 10, now)
+	rpsFifty := newTestTicket(t, domain.GameTypeRPS, 50, now)
+	tttTen := newTestTicket(t, domain.GameTypeTTT, 10, now)
+	rpsTenAgain := newTestTicket(t, domain.GameTypeRPS, 10, now.Add(time.Second))
+
+	ratings := map[user.ID]int{
+		rpsTen.UserID():      BaseRating,
+		rpsFifty.UserID():    BaseRating,
+		tttTen.UserID():      BaseRating,
+		rpsTenAgain.UserID(): BaseRating,
+	}
+
+	pairs := PairTickets([]Ticket{rpsTenAgain, tttTen, rpsFifty, rpsTen}, ratings, now.Add(time.Second))
+	require.Len(t, pairs, 1)
+	assert.Equal(t, rpsTen.ID(), pairs[0].First.ID())
+	assert.Equal(t, rpsTenAgain.ID(), pairs[0].Second.ID())
+}
+
+func TestPairTickets_ClosestRating(t *testing.T) {
+	now := time.Now()
+	first := newTestTicket(t, domain.GameTypeTTT, 0, now)
+	far := newTestTicket(t, domain.GameTypeTTT, 0, now)
+	near := newTestTicket(t, domain.GameTypeTTT, 0, now)
+
+	ratings := map[user.ID]int{
+		first.UserID(): BaseRating,
+		far.UserID():   BaseRating + RatingWindow,
+		near.UserID():  BaseRating - RatingStep,
+	}
+
+	pairs := PairTickets([]Ticket{first, far, near}, ratings, now)
+	require.Len(t, pairs, 1)
+	assert.Equal(t, first.ID(), pairs[0].First.ID())
+	assert.Equal(t, near.ID(), pairs[0].Second.ID())
+}
+
+func TestPairTickets_WindowWidensWithWaiting(t *testing.T) {
+	now := time.Now()
+	strong := newTestTicket(t, domain.GameTypeRPS, 0, now)
+	weak := newTestTicket(t, domain.GameTypeRPS, 0, now)
+
+	ratings := map[user.ID]int{
+		strong.UserID(): BaseRating + RatingWindow + RatingWindowStep,
+		weak.UserID():   BaseRating,
+	}
+
+	assert.Empty(t, PairTickets([]Ticket{strong, weak}, ratings, now))
+	assert.Len(t, PairTickets([]Ticket{strong, weak}, ratings, now.Add(RatingWindowInterval)), 1)
+}
+
+func TestPairTickets_SkipsExpired(t *testing.T) {
+	now := time.Now()
+	stale := newTestTicket(t, domain.GameTypeRPS, 0, now.Add(-TicketTimeout))
+	fresh := newTestTicket(t, domain.GameTypeRPS, 0, now)
+
+	ratings := map[user.ID]int{stale.UserID(): BaseRating, fresh.UserID(): BaseRating}
+
+	assert.True(t, stale.IsExpired(now))
+	assert.Empty(t, PairTickets([]Ticket{stale, fresh}, ratings, now))
+}
+
+func TestTicket_Lifecycle(t *testing.T) {
+	now := time.Now()
+	ticket := newTestTicket(t, domain.GameTypeRPS, 10, now)
+
+	_, err := ticket.Cancel(user.ID(utils.NewUniqueID()))
+	require.ErrorIs(t, err, ErrNotTicketOwner)
+
+	_, err = ticket.Match(ticket.UserID(), session.ID(utils.NewUniqueID()), now)
+	require.ErrorIs(t, err, ErrSamePlayer)
+
+	sessionID := session.ID(utils.NewUniqueID())
+	matched, err := ticket.Match(user.ID(utils.NewUniqueID()), sessionID, now)
+	require.NoError(t, err)
+	assert.Equal(t, TicketStatusMatched, matched.Status())
+	assert.Equal(t, sessionID, matched.SessionID())
+
+	_, err = matched.Expire(now)
+	require.ErrorIs(t, err, ErrTicketNotWaiting)
+	_, err = matched.Cancel(matched.UserID())
+	require.ErrorIs(t, err, ErrTicketNotWaiting)
+
+	matched, err = matched.AttachMessage(Message{ChatID: 2, MessageID: 3})
+	require.NoError(t, err)
+	assert.Equal(t, Message{ChatID: 2, MessageID: 3}, matched.Message())
+}
+
+func TestInlineMessageID_RoundTrip(t *testing.T) {
+	sessionID := session.ID(utils.NewUniqueID())
+
+	id, ok := SessionIDFromInlineMessageID(InlineMessageID(sessionID))
+	require.True(t, ok)
+	assert.Equal(t, sessionID, id)
+
+	_, ok = SessionIDFromInlineMessageID(domain.InlineMessageID("AgAAAB2mAQBQ"))
+	assert.False(t, ok)
+}
@@ -0,0 +1,122 @@
+package matchmaking
+
+import (
+	"fmt"
+	"microgame-bot/internal/core"
+	"microgame-bot/internal/domain"
+	"microgame-bot/internal/domain/session"
+	"microgame-bot/internal/domain/user"
+	"microgame-bot/internal/utils"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Opt func(*Ticket) error
+
+func WithID(id ID) Opt {
+	return func(t *Ticket) error {
+		if id.IsZero() {
+			return domain.ErrIDRequired
+		}
+		t.id = id
+		return nil
+	}
+}
+
+func WithNewID() Opt {
+	return WithID(ID(utils.NewUniqueID()))
+}
+
+func WithIDFromString(id string) Opt {
+	return func(t *Ticket) error {
+		idUUID, err := utils.UUIDFromString[ID](id)
+		if err != nil {
+			return fmt.Errorf("%w: %w", core.ErrFailedToParseID, err)
+		}
+		t.id = idUUID
+		return nil
+	}
+}
+
+func WithIDFromUUID(id uuid.UUID) Opt {
+	return WithID(ID(id))
+}
+
+func WithUserID(userID user.ID) Opt {
+	return func(t *Ticket) error {
+		if userID.IsZero() {
+			return domain.ErrUserIDRequired
+		}
+		t.userID = userID
+		return nil
+	}
+}
+
+func WithOpponentID(opponentID user.ID) Opt {
+	return func(t *Ticket) error {
+		t.opponentID = opponentID
+		return nil
+	}
+}
+
+func WithSessionID(sessionID session.ID) Opt {
+	return func(t *Ticket) error {
+		t.sessionID = sessionID
+		return nil
+	}
+}
+
+func WithGameType(gameType domain.GameType) Opt {
+	return func(t *Ticket) error {
+		t.gameType = gameType
+		return nil
+	}
+}
+
+func WithStatus(status TicketStatus) Opt {
+	return func(t *Ticket) error {
+		if !status.IsValid() {
+			return domain.ErrInvalidGameStatus
+		}
+		t.status = status
+		return nil
+	}
+}
+
+func WithStake(stake domain.Token) Opt {
+	return func(t *Ticket) error {
+		t.stake = stake
+		return nil
+	}
+}
+
+func WithMessage(message Message) Opt {
+	return func(t *Ticket) error {
+		if message.IsZero() {
+			return ErrChatRequired
+		}
+		t.message = message
+		return nil
+	}
+}
+
+func WithCreatedAt(createdAt time.Time) Opt {
+	return func(t *Ticket) error {
+		if createdAt.IsZero() {
+			return domain.ErrCreatedAtRequired
+		}
+		t.createdAt = createdAt
+		return nil
+	}
+}
+
+func WithUpdatedAt(updatedAt time.Time) Opt {
+	return func(t *Ticket) error {
+		if updatedAt.IsZero() {
+			return domain.ErrUpdatedAtRequired
+		}
+		t.updatedAt = updatedAt
+		return nil
+	}
+}
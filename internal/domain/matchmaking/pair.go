@@ -0,0 +1,58 @@
+package matchmaking
+
+import (
+	"microgame-bot/internal/domain/user"
+	"slices"
+	"time"
+)
+
+// Pair is two tickets matched against each other, the older ticket goes first.
+type Pair struct {
+	First  Ticket
+	Second Ticket
+}
+
+// PairTickets matches the waiting tickets of the pool.
+// Tickets are served oldest first, each takes the closest rated suitable opponent.
+// Expired tickets and players without a rating are left out.
+func PairTickets(tickets []Ticket, ratings map[user.ID]int, now time.Time) []Pair {
+	pool := slices.Clone(tickets)
+	slices.SortStableFunc(pool, func(a, b Ticket) int {
+		return a.createdAt.Compare(b.createdAt)
+	})
+
+	taken := make([]bool, len(pool))
+	var pairs []Pair
+	for i, t := range pool {
+		rating, ok := ratings[t.userID]
+		if taken[i] || !ok || t.IsExpired(now) {
+			continue
+		}
+
+		best, bestGap := -1, 0
+		for j := i + 1; j < len(pool); j++ {
+			other := pool[j]
+			otherRating, ok := ratings[other.userID]
+			if taken[j] || !ok || other.IsExpired(now) {
+				continue
+			}
+			if !t.Accepts(other, rating, otherRating, now) {
+				continue
+			}
+			gap := rating - otherRating
+			if gap < 0 {
+				gap = -gap
+			}
+			if best < 0 || gap < bestGap {
+				best, bestGap = j, gap
+			}
+		}
+		if best < 0 {
+			continue
+		}
+
+		taken[i], taken[best] = true, true
+		pairs = append(pairs, Pair{First: t, Second: pool[best]})
+	}
+	return pairs
+}
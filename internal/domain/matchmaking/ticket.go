@@ -0,0 +1,156 @@
+package matchmaking
+
+import (
+	"microgame-bot/internal/domain"
+	"microgame-bot/internal/domain/session"
+	"microgame-bot/internal/domain/user"
+	"time"
+)
+
+// Ticket is a player waiting in the quick play pool for an opponent with the same game and stake.
+// The stake is held from the player's balance while the ticket waits and becomes the bet once it is matched.
+// Every ticket is bound to a message in the player's private chat with the bot:
+// the pool message while waiting and the game message once matched.
+type Ticket struct {
+	createdAt  time.Time
+	updatedAt  time.Time
+	gameType   domain.GameType
+	status     TicketStatus
+	stake      domain.Token
+	message    Message
+	id         ID
+	userID     user.ID
+	opponentID user.ID
+	sessionID  session.ID
+}
+
+func New(opts ...Opt) (Ticket, error) {
+	t := &Ticket{
+		status: TicketStatusWaiting,
+	}
+
+	for _, opt := range opts {
+		if err := opt(t); err != nil {
+			return Ticket{}, err
+		}
+	}
+
+	// Validate required fields
+	if t.id.IsZero() {
+		return Ticket{}, domain.ErrIDRequired
+	}
+	if t.userID.IsZero() {
+		return Ticket{}, domain.ErrUserIDRequired
+	}
+	if t.gameType != domain.GameTypeTTT && t.gameType != domain.GameTypeRPS {
+		return Ticket{}, domain.ErrInvalidGameType
+	}
+	if !IsValidStake(t.stake) {
+		return Ticket{}, ErrInvalidStake
+	}
+	if t.message.IsZero() {
+		return Ticket{}, ErrChatRequired
+	}
+
+	return *t, nil
+}
+
+func (t Ticket) ID() ID                    { return t.id }
+func (t Ticket) UserID() user.ID           { return t.userID }
+func (t Ticket) OpponentID() user.ID       { return t.opponentID }
+func (t Ticket) SessionID() session.ID     { return t.sessionID }
+func (t Ticket) GameType() domain.GameType { return t.gameType }
+func (t Ticket) Status() TicketStatus      { return t.status }
+func (t Ticket) Stake() domain.Token       { return t.stake }
+func (t Ticket) Message() Message          { return t.message }
+func (t Ticket) CreatedAt() time.Time      { return t.createdAt }
+func (t Ticket) UpdatedAt() time.Time      { return t.updatedAt }
+
+// IsWaiting returns true if the ticket is still in the pool.
+func (t Ticket) IsWaiting() bool {
+	return t.status == TicketStatusWaiting
+}
+
+// ExpiresAt returns the time the ticket leaves the pool unmatched.
+func (t Ticket) ExpiresAt() time.Time {
+	return t.createdAt.Add(TicketTimeout)
+}
+
+// IsExpired returns true if the waiting ticket outlived its timeout.
+func (t Ticket) IsExpired(now time.Time) bool {
+	return t.IsWaiting() && !now.Before(t.ExpiresAt())
+}
+
+// Accepts returns true if the other ticket is a suitable opponent: another player,
+// the same game and stake, and a rating gap within the window of this ticket.
+func (t Ticket) Accepts(other Ticket, rating, otherRating int, now time.Time) bool {
+	if !t.IsWaiting() || !other.IsWaiting() || t.userID == other.userID {
+		return false
+	}
+	if t.gameType != other.gameType || t.stake != other.stake {
+		return false
+	}
+	gap := rating - otherRating
+	if gap < 0 {
+		gap = -gap
+	}
+	return gap <= RatingWindowFor(now.Sub(t.createdAt))
+}
+
+// Match takes the ticket out of the pool into the game session with the opponent.
+func (t Ticket) Match(opponentID user.ID, sessionID session.ID, now time.Time) (Ticket, error) {
+	if !t.IsWaiting() {
+		return Ticket{}, ErrTicketNotWaiting
+	}
+	if opponentID.IsZero() {
+		return Ticket{}, domain.ErrUserIDRequired
+	}
+	if opponentID == t.userID {
+		return Ticket{}, ErrSamePlayer
+	}
+	if sessionID.IsZero() {
+		return Ticket{}, domain.ErrSessionIDRequired
+	}
+
+	t.opponentID = opponentID
+	t.sessionID = sessionID
+	t.status = TicketStatusMatched
+	t.updatedAt = now
+	return t, nil
+}
+
+// AttachMessage binds the matched ticket to the game message sent to the player.
+func (t Ticket) AttachMessage(message Message) (Ticket, error) {
+	if t.status != TicketStatusMatched {
+		return Ticket{}, ErrTicketNotWaiting
+	}
+	if message.IsZero() {
+		return Ticket{}, ErrChatRequired
+	}
+	t.message = message
+	t.updatedAt = time.Now()
+	return t, nil
+}
+
+// Cancel takes the ticket out of the pool on behalf of its owner.
+func (t Ticket) Cancel(userID user.ID) (Ticket, error) {
+	if t.userID != userID {
+		return Ticket{}, ErrNotTicketOwner
+	}
+	if !t.IsWaiting() {
+		return Ticket{}, ErrTicketNotWaiting
+	}
+	t.status = TicketStatusCancelled
+	t.updatedAt = time.Now()
+	return t, nil
+}
+
+// Expire takes the ticket that found no opponent out of the pool.
+func (t Ticket) Expire(now time.Time) (Ticket, error) {
+	if !t.IsWaiting() {
+		return Ticket{}, ErrTicketNotWaiting
+	}
+	t.status = TicketStatusExpired
+	t.updatedAt = now
+	return t, nil
+}
@@ -0,0 +1,119 @@
+package matchmaking
+
+import (
+	"errors"
+	"microgame-bot/internal/domain"
+	"microgame-bot/internal/domain/session"
+	"microgame-bot/internal/utils"
+	"strings"
+	"time"
+)
+
+var (
+	ErrAlreadyQueued    = errors.New("player already in matchmaking pool")
+	ErrTicketNotWaiting = errors.New("matchmaking ticket is not waiting")
+	ErrNotTicketOwner   = errors.New("matchmaking ticket belongs to another player")
+	ErrChatRequired     = errors.New("player has no private chat with the bot")
+	ErrInvalidStake     = errors.New("invalid matchmaking stake")
+	ErrSamePlayer       = errors.New("player can not be matched against themselves")
+)
+
+const (
+	// TicketTimeout is how long a ticket waits in the pool before it expires.
+	TicketTimeout = 5 * time.Minute
+	// MoveTimeout is the move clock of quick play games,
+	// strangers should not keep each other waiting.
+	MoveTimeout = time.Minute
+
+	// BaseRating is the rating of a player without decided games.
+	BaseRating = 1000
+	// RatingStep is added for every win and taken for every loss.
+	RatingStep = 25
+
+	// RatingWindow is the largest rating gap accepted by a fresh ticket.
+	RatingWindow = 100
+	// RatingWindowStep widens the window every RatingWindowInterval of waiting,
+	// so nobody waits for a perfect opponent until the ticket expires.
+	RatingWindowStep     = 50
+	RatingWindowInterval = 30 * time.Second
+
+	// inlineMessageIDPrefix marks sessions played in private chats instead of an inline message.
+	inlineMessageIDPrefix = "quick:"
+)
+
+type TicketStatus string
+
+const (
+	TicketStatusWaiting   TicketStatus = "waiting"
+	TicketStatusMatched   TicketStatus = "matched"
+	TicketStatusExpired   TicketStatus = "expired"
+	TicketStatusCancelled TicketStatus = "cancelled"
+)
+
+func (s TicketStatus) IsValid() bool {
+	switch s {
+	case TicketStatusWaiting, TicketStatusMatched, TicketStatusExpired, TicketStatusCancelled:
+		return true
+	default:
+		return false
+	}
+}
+
+// Stakes returns the stakes offered in the pool. Players are matched only with the same stake.
+func Stakes() []domain.Token {
+	//nolint:mnd // Offered stakes.
+	return []domain.Token{0, 10, 50, 100}
+}
+
+// IsValidStake returns true if the stake is offered in the pool.
+func IsValidStake(stake domain.Token) bool {
+	for _, s := range Stakes() {
+		if s == stake {
+			return true
+		}
+	}
+	return false
+}
+
+// Rating turns the decided games of a player into a matchmaking rating.
+func Rating(wins, losses int) int {
+	return BaseRating + RatingStep*(wins-losses)
+}
+
+// RatingWindowFor returns the largest rating gap a ticket accepts after waiting for the given time.
+func RatingWindowFor(waited time.Duration) int {
+	if waited <= 0 {
+		return RatingWindow
+	}
+	return RatingWindow + RatingWindowStep*int(waited/RatingWindowInterval)
+}
+
+// InlineMessageID returns the inline message ID of a quick play session.
+// Quick play games live in private chats, the ID only keys the session
+// and is resolved to the players' messages when the game is redrawn.
+func InlineMessageID(sessionID session.ID) domain.InlineMessageID {
+	return domain.InlineMessageID(inlineMessageIDPrefix + sessionID.String())
+}
+
+// SessionIDFromInlineMessageID returns the session ID of a quick play inline message ID.
+func SessionIDFromInlineMessageID(inlineMessageID domain.InlineMessageID) (session.ID, bool) {
+	raw, ok := strings.CutPrefix(inlineMessageID.String(), inlineMessageIDPrefix)
+	if !ok {
+		return session.ID{}, false
+	}
+	id, err := utils.UUIDFromString[session.ID](raw)
+	if err != nil || id.IsZero() {
+		return session.ID{}, false
+	}
+	return id, true
+}
+
+// Message is a private chat message showing the game of a matched ticket.
+type Message struct {
+	ChatID    int64
+	MessageID int
+}
+
+func (m Message) IsZero() bool {
+	return m.ChatID == 0 || m.MessageID == 0
+}
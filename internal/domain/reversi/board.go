@@ -0,0 +1,123 @@
+package reversi
+
+// Board is the 8x8 grid, rows go top to bottom, columns go left to right.
+type Board [BoardSize][BoardSize]Disc
+
+// directions are the eight lines a disc may flip along.
+//
+//nolint:gochecknoglobals // Directions are constant.
+var directions = [8][2]int{{-1, -1}, {-1, 0}, {-1, 1}, {0, -1}, {0, 1}, {1, -1}, {1, 0}, {1, 1}}
+
+// NewBoard returns the starting position: two discs of each color crossed in the center.
+func NewBoard() Board {
+	var b Board
+	//nolint:mnd // Center of the 8x8 board.
+	b[3][3], b[3][4], b[4][3], b[4][4] = DiscWhite, DiscBlack, DiscBlack, DiscWhite
+	return b
+}
+
+// Get returns the disc at the cell, cells off the board are empty.
+func (b Board) Get(cell Cell) Disc {
+	if !cell.IsValid() {
+		return DiscEmpty
+	}
+	return b[cell.Row][cell.Col]
+}
+
+// Flips returns the opponent discs the move would turn over.
+// A move is legal only if it flips at least one disc.
+func (b Board) Flips(cell Cell, disc Disc) []Cell {
+	if !cell.IsValid() || b.Get(cell) != DiscEmpty {
+		return nil
+	}
+
+	var flips []Cell
+	for _, dir := range directions {
+		var line []Cell
+		next := Cell{Row: cell.Row + dir[0], Col: cell.Col + dir[1]}
+		for next.IsValid() && b.Get(next) == disc.Opponent() {
+			line = append(line, next)
+			next = Cell{Row: next.Row + dir[0], Col: next.Col + dir[1]}
+		}
+		if len(line) > 0 && next.IsValid() && b.Get(next) == disc {
+			flips = append(flips, line...)
+		}
+	}
+	return flips
+}
+
+// IsLegal checks that the disc may be placed on the cell.
+func (b Board) IsLegal(cell Cell, disc Disc) bool {
+	return len(b.Flips(cell, disc)) > 0
+}
+
+// LegalMoves returns every cell the disc may be placed on.
+func (b Board) LegalMoves(disc Disc) []Cell {
+	var moves []Cell
+	for row := range BoardSize {
+		for col := range BoardSize {
+			cell := Cell{Row: row, Col: col}
+			if b.IsLegal(cell, disc) {
+				moves = append(moves, cell)
+			}
+		}
+	}
+	return moves
+}
+
+// HasMoves checks that the disc has at least one legal move.
+func (b Board) HasMoves(disc Disc) bool {
+	for row := range BoardSize {
+		for col := range BoardSize {
+			if b.IsLegal(Cell{Row: row, Col: col}, disc) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Place puts the disc on the cell and turns over the flanked discs.
+func (b Board) Place(cell Cell, disc Disc) (Board, int, error) {
+	if !cell.IsValid() {
+		return b, 0, ErrOutOfBounds
+	}
+	if b.Get(cell) != DiscEmpty {
+		return b, 0, ErrCellOccupied
+	}
+	flips := b.Flips(cell, disc)
+	if len(flips) == 0 {
+		return b, 0, ErrIllegalMove
+	}
+
+	b[cell.Row][cell.Col] = disc
+	for _, flip := range flips {
+		b[flip.Row][flip.Col] = disc
+	}
+	return b, len(flips), nil
+}
+
+// Count returns the number of discs of the color on the board.
+func (b Board) Count(disc Disc) int {
+	count := 0
+	for row := range BoardSize {
+		for col := range BoardSize {
+			if b[row][col] == disc {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// validate checks that every cell holds a known disc.
+func (b Board) validate() error {
+	for row := range BoardSize {
+		for col := range BoardSize {
+			if !b[row][col].IsValid() {
+				return ErrInvalidDisc
+			}
+		}
+	}
+	return nil
+}
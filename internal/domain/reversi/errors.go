@@ -0,0 +1,10 @@
+package reversi
+
+import "errors"
+
+var (
+	ErrOutOfBounds  = errors.New("coordinates out of bounds")
+	ErrCellOccupied = errors.New("cell is already occupied")
+	ErrIllegalMove  = errors.New("move flips no discs")
+	ErrInvalidDisc  = errors.New("invalid disc")
+)
@@ -0,0 +1,161 @@
+package reversi
+
+import (
+	"fmt"
+	"microgame-bot/internal/core"
+	"microgame-bot/internal/domain"
+	se "microgame-bot/internal/domain/session"
+	"microgame-bot/internal/domain/user"
+	"microgame-bot/internal/utils"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Opt func(*Reversi) error
+
+func WithID(id ID) Opt {
+	return func(r *Reversi) error {
+		if id.IsZero() {
+			return domain.ErrIDRequired
+		}
+		r.id = id
+		return nil
+	}
+}
+
+func WithNewID() Opt {
+	return WithID(ID(utils.NewUniqueID()))
+}
+
+func WithIDFromString(id string) Opt {
+	return func(r *Reversi) error {
+		idUUID, err := utils.UUIDFromString[ID](id)
+		if err != nil {
+			return fmt.Errorf("%w: %w", core.ErrFailedToParseID, err)
+		}
+		r.id = idUUID
+		return nil
+	}
+}
+
+func WithIDFromUUID(id uuid.UUID) Opt {
+	return WithID(ID(id))
+}
+
+func WithCreatorID(creatorID user.ID) Opt {
+	return func(r *Reversi) error {
+		if creatorID.IsZero() {
+			return domain.ErrCreatorIDRequired
+		}
+		r.creatorID = creatorID
+		return nil
+	}
+}
+
+func WithPlayerBlackID(playerID user.ID) Opt {
+	return func(r *Reversi) error {
+		r.playerBlackID = playerID
+		return nil
+	}
+}
+
+func WithPlayerBlackIDFromUUID(playerID uuid.UUID) Opt {
+	return WithPlayerBlackID(user.ID(playerID))
+}
+
+func WithPlayerWhiteID(playerID user.ID) Opt {
+	return func(r *Reversi) error {
+		r.playerWhiteID = playerID
+		return nil
+	}
+}
+
+func WithPlayerWhiteIDFromUUID(playerID uuid.UUID) Opt {
+	return WithPlayerWhiteID(user.ID(playerID))
+}
+
+func WithBoard(board Board) Opt {
+	return func(r *Reversi) error {
+		r.board = board
+		return nil
+	}
+}
+
+func WithTurn(turn user.ID) Opt {
+	return func(r *Reversi) error {
+		r.turn = turn
+		return nil
+	}
+}
+
+func WithTurnFromUUID(turn uuid.UUID) Opt {
+	return WithTurn(user.ID(turn))
+}
+
+// WithSeed sets the session seed the colors are assigned from.
+func WithSeed(seed string) Opt {
+	return func(r *Reversi) error {
+		r.seed = seed
+		return nil
+	}
+}
+
+func WithMoves(moves []Move) Opt {
+	return func(r *Reversi) error {
+		r.moves = slices.Clone(moves)
+		return nil
+	}
+}
+
+func WithStatus(status domain.GameStatus) Opt {
+	return func(r *Reversi) error {
+		if status.IsZero() {
+			return domain.ErrGameStatusRequired
+		}
+		if !status.IsValid() {
+			return domain.ErrInvalidGameStatus
+		}
+		r.status = status
+		return nil
+	}
+}
+
+func WithWinnerID(winnerID user.ID) Opt {
+	return func(r *Reversi) error {
+		r.winnerID = winnerID
+		return nil
+	}
+}
+
+func WithWinnerIDFromUUID(winnerID uuid.UUID) Opt {
+	return WithWinnerID(user.ID(winnerID))
+}
+
+func WithCreatedAt(createdAt time.Time) Opt {
+	return func(r *Reversi) error {
+		if createdAt.IsZero() {
+			return domain.ErrCreatedAtRequired
+		}
+		r.createdAt = createdAt
+		return nil
+	}
+}
+
+func WithUpdatedAt(updatedAt time.Time) Opt {
+	return func(r *Reversi) error {
+		if updatedAt.IsZero() {
+			return domain.ErrUpdatedAtRequired
+		}
+		r.updatedAt = updatedAt
+		return nil
+	}
+}
+
+func WithSessionID(sessionID se.ID) Opt {
+	return func(r *Reversi) error {
+		r.sessionID = sessionID
+		return nil
+	}
+}
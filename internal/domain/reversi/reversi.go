@@ -0,0 +1,327 @@
+package reversi
+
+import (
+	"microgame-bot/internal/domain"
+	"microgame-bot/internal/domain/session"
+	"microgame-bot/internal/domain/user"
+	"microgame-bot/internal/utils"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Reversi is the two-player game of flipping discs on the 8x8 board.
+// A player without a legal move passes automatically, the game ends once neither player can move
+// and the player with more discs wins.
+type Reversi struct {
+	createdAt     time.Time
+	updatedAt     time.Time
+	board         Board
+	status        domain.GameStatus
+	id            ID
+	creatorID     user.ID
+	playerBlackID user.ID
+	playerWhiteID user.ID
+	winnerID      user.ID
+	sessionID     session.ID
+	turn          user.ID
+	seed          string
+	moves         []Move
+}
+
+// New creates a new Reversi instance with the given options, the board starts in the initial position.
+func New(opts ...Opt) (Reversi, error) {
+	r := &Reversi{
+		board:  NewBoard(),
+		status: domain.GameStatusCreated,
+	}
+
+	for _, opt := range opts {
+		if err := opt(r); err != nil {
+			return Reversi{}, err
+		}
+	}
+
+	// Validate required fields
+	if r.id.IsZero() {
+		return Reversi{}, domain.ErrIDRequired
+	}
+	if r.sessionID.IsZero() {
+		return Reversi{}, domain.ErrSessionIDRequired
+	}
+	if r.creatorID.IsZero() {
+		return Reversi{}, domain.ErrCreatorIDRequired
+	}
+	if (r.playerBlackID.IsZero() || r.playerWhiteID.IsZero()) &&
+		r.status != domain.GameStatusCreated &&
+		r.status != domain.GameStatusWaitingForPlayers &&
+		r.status != domain.GameStatusCancelled {
+		return Reversi{}, domain.ErrCantPlayWithoutPlayers
+	}
+
+	// Black moves first
+	if r.turn.IsZero() && !r.playerBlackID.IsZero() && !r.playerWhiteID.IsZero() && !r.IsFinished() {
+		r.turn = r.playerBlackID
+	}
+
+	if err := r.board.validate(); err != nil {
+		return Reversi{}, err
+	}
+
+	return *r, nil
+}
+
+func (r Reversi) ID() ID                    { return r.id }
+func (r Reversi) CreatorID() user.ID        { return r.creatorID }
+func (r Reversi) PlayerBlackID() user.ID    { return r.playerBlackID }
+func (r Reversi) PlayerWhiteID() user.ID    { return r.playerWhiteID }
+func (r Reversi) Turn() user.ID             { return r.turn }
+func (r Reversi) Board() Board              { return r.board }
+func (r Reversi) Moves() []Move             { return slices.Clone(r.moves) }
+func (r Reversi) Status() domain.GameStatus { return r.status }
+func (r Reversi) CreatedAt() time.Time      { return r.createdAt }
+func (r Reversi) UpdatedAt() time.Time      { return r.updatedAt }
+func (r Reversi) SessionID() session.ID     { return r.sessionID }
+func (r Reversi) IDtoUUID() uuid.UUID       { return uuid.UUID(r.id) }
+func (r Reversi) Type() domain.GameType     { return domain.GameTypeReversi }
+func (r Reversi) Seed() string              { return r.seed }
+
+// Participants returns all participants in the game.
+func (r Reversi) Participants() []user.ID {
+	participants := make([]user.ID, 0, 2) //nolint:mnd // Two players.
+	if !r.playerBlackID.IsZero() {
+		participants = append(participants, r.playerBlackID)
+	}
+	if !r.playerWhiteID.IsZero() {
+		participants = append(participants, r.playerWhiteID)
+	}
+	return participants
+}
+
+// PlayerDisc returns the disc color of the player.
+func (r Reversi) PlayerDisc(userID user.ID) Disc {
+	switch userID {
+	case r.playerBlackID:
+		return DiscBlack
+	case r.playerWhiteID:
+		return DiscWhite
+	default:
+		return DiscEmpty
+	}
+}
+
+// PlayerByDisc returns the player of the disc color.
+func (r Reversi) PlayerByDisc(disc Disc) user.ID {
+	switch disc {
+	case DiscBlack:
+		return r.playerBlackID
+	case DiscWhite:
+		return r.playerWhiteID
+	default:
+		return user.ID{}
+	}
+}
+
+// Score returns the number of discs of the player on the board.
+func (r Reversi) Score(userID user.ID) int {
+	disc := r.PlayerDisc(userID)
+	if disc == DiscEmpty {
+		return 0
+	}
+	return r.board.Count(disc)
+}
+
+// LegalMoves returns the cells the player to move may take.
+func (r Reversi) LegalMoves() []Cell {
+	if r.IsFinished() || r.turn.IsZero() {
+		return nil
+	}
+	return r.board.LegalMoves(r.PlayerDisc(r.turn))
+}
+
+// LastPass returns the player who had to pass on the last turn, if any.
+func (r Reversi) LastPass() user.ID {
+	if len(r.moves) == 0 || !r.moves[len(r.moves)-1].Pass {
+		return user.ID{}
+	}
+	return r.moves[len(r.moves)-1].PlayerID
+}
+
+// JoinGame adds a player to the game.
+// First player joins: temporarily stored as black (colors not assigned yet).
+// Second player joins: colors are randomly assigned between first and second players.
+func (r Reversi) JoinGame(playerID user.ID) (Reversi, error) {
+	if r.IsFinished() {
+		return Reversi{}, domain.ErrGameOver
+	}
+
+	if !r.playerBlackID.IsZero() && !r.playerWhiteID.IsZero() {
+		return Reversi{}, domain.ErrGameFull
+	}
+
+	if r.playerBlackID == playerID || r.playerWhiteID == playerID {
+		return Reversi{}, domain.ErrPlayerAlreadyInGame
+	}
+
+	if r.playerBlackID.IsZero() {
+		r.playerBlackID = playerID
+		// Status remains WaitingForPlayers
+		return r, nil
+	}
+
+	r.playerWhiteID = playerID
+	r = r.AssignColorsRandomly()
+	r.status = domain.GameStatusInProgress
+
+	return r, nil
+}
+
+// AssignColorsRandomly randomly assigns the colors to the two players, black moves first.
+// If the game has a session seed, the choice is derived from it and can be verified
+// once the seed is revealed: players are swapped when SHA256(seed:gameID) is odd.
+func (r Reversi) AssignColorsRandomly() Reversi {
+	var roll int
+	if r.seed != "" {
+		//nolint:mnd // 50% chance.
+		roll = utils.SeededRandInt(r.seed, r.id.String(), 2)
+	} else {
+		//nolint:mnd // Random 50% chance.
+		roll = utils.RandInt(2)
+	}
+	if roll == 1 {
+		r.playerBlackID, r.playerWhiteID = r.playerWhiteID, r.playerBlackID
+	}
+	r.turn = r.playerBlackID
+	return r
+}
+
+// Cancel withdraws the game on behalf of its creator.
+// Only a game that is still waiting for players can be cancelled.
+func (r Reversi) Cancel(userID user.ID) (Reversi, error) {
+	if r.creatorID != userID {
+		return Reversi{}, domain.ErrNotGameCreator
+	}
+	if r.status != domain.GameStatusWaitingForPlayers {
+		return Reversi{}, domain.ErrGameAlreadyStarted
+	}
+
+	r.status = domain.GameStatusCancelled
+	return r, nil
+}
+
+// MakeMove places the disc of the player on the cell and flips the flanked discs.
+// If the opponent has no legal move, the opponent passes and the player moves again,
+// if neither player can move, the game is over and the discs are counted.
+func (r Reversi) MakeMove(row, col int, userID user.ID) (Reversi, error) {
+	if r.IsFinished() {
+		return Reversi{}, domain.ErrGameOver
+	}
+
+	// Check if both players are in game
+	if r.playerBlackID.IsZero() || r.playerWhiteID.IsZero() {
+		return Reversi{}, domain.ErrWaitingForOpponent
+	}
+
+	if r.turn != userID {
+		return Reversi{}, domain.ErrNotPlayersTurn
+	}
+
+	disc := r.PlayerDisc(userID)
+	board, flipped, err := r.board.Place(Cell{Row: row, Col: col}, disc)
+	if err != nil {
+		return Reversi{}, err
+	}
+	r.board = board
+	r.moves = append(slices.Clone(r.moves), Move{
+		PlayerID: userID,
+		Disc:     disc,
+		Row:      row,
+		Col:      col,
+		Flipped:  flipped,
+	})
+
+	opponent := r.PlayerByDisc(disc.Opponent())
+	switch {
+	case r.board.HasMoves(disc.Opponent()):
+		r.turn = opponent
+	case r.board.HasMoves(disc):
+		r.moves = append(r.moves, Move{PlayerID: opponent, Disc: disc.Opponent(), Pass: true})
+	default:
+		r = r.finish()
+	}
+
+	return r, nil
+}
+
+// finish counts the discs and ends the game, equal counts are a draw.
+func (r Reversi) finish() Reversi {
+	black, white := r.board.Count(DiscBlack), r.board.Count(DiscWhite)
+	switch {
+	case black > white:
+		r.winnerID = r.playerBlackID
+	case white > black:
+		r.winnerID = r.playerWhiteID
+	}
+	r.turn = user.ID{}
+	r.status = domain.GameStatusFinished
+	return r
+}
+
+// IsFinished returns true if the game has ended.
+func (r Reversi) IsFinished() bool {
+	return !r.winnerID.IsZero() ||
+		r.status == domain.GameStatusCancelled ||
+		r.status == domain.GameStatusFinished ||
+		r.status == domain.GameStatusAbandoned
+}
+
+// IsDraw returns true if the game ended with equal disc counts.
+func (r Reversi) IsDraw() bool {
+	return r.status == domain.GameStatusFinished && r.winnerID.IsZero()
+}
+
+func (r Reversi) Winners() []user.ID {
+	if r.winnerID.IsZero() {
+		return []user.ID{}
+	}
+	return []user.ID{r.winnerID}
+}
+
+func (r Reversi) WinnerID() user.ID {
+	return r.winnerID
+}
+
+// IsStarted returns true if at least one disc has been placed.
+func (r Reversi) IsStarted() bool {
+	return len(r.moves) > 0
+}
+
+func (r Reversi) SetWinner(winnerID user.ID) (Reversi, error) {
+	if winnerID != r.playerBlackID && winnerID != r.playerWhiteID {
+		return Reversi{}, domain.ErrPlayerNotInGame
+	}
+	r.winnerID = winnerID
+	return r, nil
+}
+
+func (r Reversi) AFKPlayerID() (user.ID, error) {
+	if !r.IsStarted() {
+		return user.ID{}, domain.ErrAllPlayersAFK
+	}
+	if !r.turn.IsZero() {
+		return r.turn, nil
+	}
+	return user.ID{}, domain.ErrAFKPlayerNotFound
+}
+
+func (r Reversi) SetStatus(status domain.GameStatus) (Reversi, error) {
+	if status.IsZero() {
+		return Reversi{}, domain.ErrGameStatusRequired
+	}
+	if !status.IsValid() {
+		return Reversi{}, domain.ErrInvalidGameStatus
+	}
+	r.status = status
+	return r, nil
+}
@@ -0,0 +1,124 @@
+package reversi
+
+import (
+	"microgame-bot/internal/domain"
+	se "microgame-bot/internal/domain/session"
+	"microgame-bot/internal/domain/user"
+	"microgame-bot/internal/utils"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newGameWithBoard(t *testing.T, board Board) (Reversi, user.ID, user.ID) {
+	t.Helper()
+	black := user.ID(utils.NewUniqueID())
+	white := user.ID(utils.NewUniqueID())
+
+	game, err := New(
+		WithNewID(),
+		WithCreatorID(black),
+		WithSessionID(se.ID(utils.NewUniqueID())),
+		WithPlayerBlackID(black),
+		WithPlayerWhiteID(white),
+		WithBoard(board),
+		WithStatus(domain.GameStatusInProgress),
+	)
+	require.NoError(t, err)
+	require.Equal(t, black, game.Turn())
+
+	return game, black, white
+}
+
+func TestJoinGame_AssignsColorsAndBlackMovesFirst(t *testing.T) {
+	player1 := user.ID(utils.NewUniqueID())
+	player2 := user.ID(utils.NewUniqueID())
+
+	game, err := New(
+		WithNewID(),
+		WithCreatorID(player1),
+		WithSessionID(se.ID(utils.NewUniqueID())),
+		WithSeed("seed"),
+		WithStatus(domain.GameStatusWaitingForPlayers),
+	)
+	require.NoError(t, err)
+	game, err = game.JoinGame(player1)
+	require.NoError(t, err)
+	_, err = game.JoinGame(player1)
+	require.ErrorIs(t, err, domain.ErrPlayerAlreadyInGame)
+	game, err = game.JoinGame(player2)
+	require.NoError(t, err)
+
+	assert.Equal(t, domain.GameStatusInProgress, game.Status())
+	assert.ElementsMatch(t, []user.ID{player1, player2}, game.Participants())
+	assert.Equal(t, game.PlayerBlackID(), game.Turn())
+	assert.Equal(t, 2, game.Score(player1))
+	assert.Equal(t, 2, game.Score(player2))
+	assert.Len(t, game.LegalMoves(), 4)
+}
+
+func TestMakeMove_FlipsDiscsAndPassesTurn(t *testing.T) {
+	game, black, white := newGameWithBoard(t, NewBoard())
+
+	_, err := game.MakeMove(2, 3, white)
+	require.ErrorIs(t, err, domain.ErrNotPlayersTurn)
+	_, err = game.MakeMove(0, 0, black)
+	require.ErrorIs(t, err, ErrIllegalMove)
+	_, err = game.MakeMove(3, 3, black)
+	require.ErrorIs(t, err, ErrCellOccupied)
+	_, err = game.MakeMove(8, 0, black)
+	require.ErrorIs(t, err, ErrOutOfBounds)
+
+	game, err = game.MakeMove(2, 3, black)
+	require.NoError(t, err)
+	assert.Equal(t, DiscBlack, game.Board().Get(Cell{Row: 3, Col: 3}))
+	assert.Equal(t, 4, game.Score(black))
+	assert.Equal(t, 1, game.Score(white))
+	assert.Equal(t, white, game.Turn())
+	assert.Equal(t, []Move{{PlayerID: black, Disc: DiscBlack, Row: 2, Col: 3, Flipped: 1}}, game.Moves())
+	assert.True(t, game.IsStarted())
+
+	afkID, err := game.AFKPlayerID()
+	require.NoError(t, err)
+	assert.Equal(t, white, afkID)
+}
+
+func TestMakeMove_OpponentWithoutMovesPasses(t *testing.T) {
+	var board Board
+	board[0][0], board[0][1] = DiscBlack, DiscWhite
+	board[7][0], board[7][1] = DiscBlack, DiscWhite
+	game, black, white := newGameWithBoard(t, board)
+
+	game, err := game.MakeMove(0, 2, black)
+	require.NoError(t, err)
+	assert.False(t, game.IsFinished())
+	assert.Equal(t, black, game.Turn())
+	assert.Equal(t, white, game.LastPass())
+	assert.Equal(t, []Cell{{Row: 7, Col: 2}}, game.LegalMoves())
+
+	game, err = game.MakeMove(7, 2, black)
+	require.NoError(t, err)
+	assert.True(t, game.IsFinished())
+	assert.False(t, game.IsDraw())
+	assert.Equal(t, black, game.WinnerID())
+	assert.Equal(t, 6, game.Score(black))
+	assert.Zero(t, game.Score(white))
+	assert.True(t, game.Turn().IsZero())
+
+	_, err = game.MakeMove(1, 1, black)
+	require.ErrorIs(t, err, domain.ErrGameOver)
+}
+
+func TestMakeMove_EqualDiscsIsDraw(t *testing.T) {
+	var board Board
+	board[0][0], board[0][1] = DiscBlack, DiscWhite
+	board[7][5], board[7][6], board[7][7] = DiscWhite, DiscWhite, DiscWhite
+	game, black, _ := newGameWithBoard(t, board)
+
+	game, err := game.MakeMove(0, 2, black)
+	require.NoError(t, err)
+	assert.True(t, game.IsFinished())
+	assert.True(t, game.IsDraw())
+	assert.Empty(t, game.Winners())
+}
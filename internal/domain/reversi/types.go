@@ -0,0 +1,81 @@
+package reversi
+
+import "microgame-bot/internal/domain/user"
+
+// BoardSize is the side of the Reversi board.
+const BoardSize = 8
+
+// Disc is the content of a board cell, Black always moves first.
+type Disc string
+
+const (
+	DiscEmpty Disc = ""
+	DiscBlack Disc = "B"
+	DiscWhite Disc = "W"
+)
+
+const (
+	DiscBlackIcon = "⚫"
+	DiscWhiteIcon = "⚪"
+	DiscEmptyIcon = "🟩"
+	// LegalMoveIcon marks the cells the player to move may take.
+	LegalMoveIcon = "▫️"
+)
+
+func (d Disc) IsValid() bool {
+	switch d {
+	case DiscEmpty, DiscBlack, DiscWhite:
+		return true
+	default:
+		return false
+	}
+}
+
+// Opponent returns the disc of the other player.
+func (d Disc) Opponent() Disc {
+	switch d {
+	case DiscBlack:
+		return DiscWhite
+	case DiscWhite:
+		return DiscBlack
+	default:
+		return DiscEmpty
+	}
+}
+
+func (d Disc) Icon() string {
+	switch d {
+	case DiscBlack:
+		return DiscBlackIcon
+	case DiscWhite:
+		return DiscWhiteIcon
+	default:
+		return DiscEmptyIcon
+	}
+}
+
+// Cell is a position on the board.
+type Cell struct {
+	Row int
+	Col int
+}
+
+// IsValid checks that the cell is on the board.
+func (c Cell) IsValid() bool {
+	return c.Row >= 0 && c.Row < BoardSize && c.Col >= 0 && c.Col < BoardSize
+}
+
+// String returns the cell in the board notation: column letter and row number, e.g. "D3".
+func (c Cell) String() string {
+	return string(rune('A'+c.Col)) + string(rune('1'+c.Row))
+}
+
+// Move is a turn of the game: a placed disc or a forced pass when the player had no legal move.
+type Move struct {
+	PlayerID user.ID
+	Disc     Disc
+	Row      int
+	Col      int
+	Flipped  int
+	Pass     bool
+}
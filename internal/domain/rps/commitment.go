@@ -0,0 +1,52 @@
+package rps
+
+import (
+	"microgame-bot/internal/utils"
+)
+
+const saltBytes = 16
+
+// Commitment is a salted hash of a player's choice: SHA256(gameID:choice:salt).
+// It is fixed at the moment of the choice and published with the salt after both players have chosen,
+// so anyone can verify that the choice was not changed afterwards.
+type Commitment struct {
+	hash string
+	salt string
+}
+
+// NewCommitment commits to the choice with a fresh random salt.
+func NewCommitment(gameID ID, choice Choice) Commitment {
+	salt := utils.RandHex(saltBytes)
+	return Commitment{
+		hash: commitmentHash(gameID, choice, salt),
+		salt: salt,
+	}
+}
+
+// CommitmentFrom restores a commitment from its stored hash and salt.
+func CommitmentFrom(hash string, salt string) Commitment {
+	return Commitment{hash: hash, salt: salt}
+}
+
+func (c Commitment) Hash() string { return c.hash }
+func (c Commitment) Salt() string { return c.salt }
+func (c Commitment) IsZero() bool { return c.hash == "" }
+
+// Verify reports whether the commitment matches the choice.
+func (c Commitment) Verify(gameID ID, choice Choice) bool {
+	return !c.IsZero() && c.hash == commitmentHash(gameID, choice, c.salt)
+}
+
+// Reveal finds the choice the commitment was made for.
+func (c Commitment) Reveal(gameID ID) (Choice, error) {
+	for _, choice := range []Choice{ChoiceRock, ChoicePaper, ChoiceScissors} {
+		if c.Verify(gameID, choice) {
+			return choice, nil
+		}
+	}
+	return ChoiceEmpty, ErrInvalidCommitment
+}
+
+func commitmentHash(gameID ID, choice Choice, salt string) string {
+	return utils.SHA256Hex(gameID.String(), choice.String(), salt)
+}
@@ -0,0 +1,38 @@
+package rps
+
+import (
+	"microgame-bot/internal/utils"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommitment_VerifyAndReveal(t *testing.T) {
+	gameID := ID(utils.NewUniqueID())
+
+	commitment := NewCommitment(gameID, ChoicePaper)
+	require.False(t, commitment.IsZero())
+	assert.NotEmpty(t, commitment.Salt())
+
+	assert.True(t, commitment.Verify(gameID, ChoicePaper))
+	assert.False(t, commitment.Verify(gameID, ChoiceRock))
+	assert.False(t, commitment.Verify(ID(utils.NewUniqueID()), ChoicePaper))
+
+	restored := CommitmentFrom(commitment.Hash(), commitment.Salt())
+	choice, err := restored.Reveal(gameID)
+	require.NoError(t, err)
+	assert.Equal(t, ChoicePaper, choice)
+
+	_, err = CommitmentFrom("deadbeef", commitment.Salt()).Reveal(gameID)
+	assert.ErrorIs(t, err, ErrInvalidCommitment)
+}
+
+func TestCommitment_IsSalted(t *testing.T) {
+	gameID := ID(utils.NewUniqueID())
+
+	first := NewCommitment(gameID, ChoiceRock)
+	second := NewCommitment(gameID, ChoiceRock)
+
+	assert.NotEqual(t, first.Hash(), second.Hash())
+}
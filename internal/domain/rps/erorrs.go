@@ -3,5 +3,7 @@ package rps
 import "errors"
 
 var (
-	ErrInvalidChoice = errors.New("invalid choice")
+	ErrInvalidChoice     = errors.New("invalid choice")
+	ErrChoiceAlreadyMade = errors.New("choice already made")
+	ErrInvalidCommitment = errors.New("invalid choice commitment")
 )
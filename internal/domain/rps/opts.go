@@ -89,6 +89,20 @@ func WithChoice2(choice Choice) Opt {
 	}
 }
 
+func WithCommitment1(commitment Commitment) Opt {
+	return func(r *RPS) error {
+		r.commit1 = commitment
+		return nil
+	}
+}
+
+func WithCommitment2(commitment Commitment) Opt {
+	return func(r *RPS) error {
+		r.commit2 = commitment
+		return nil
+	}
+}
+
 func WithStatus(status domain.GameStatus) Opt {
 	return func(r *RPS) error {
 		if status.IsZero() {
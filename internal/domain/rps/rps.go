@@ -15,6 +15,8 @@ type RPS struct {
 	status    domain.GameStatus
 	choice1   Choice
 	choice2   Choice
+	commit1   Commitment
+	commit2   Commitment
 	winnerID  user.ID
 	sessionID se.ID
 	id        ID
@@ -70,6 +72,25 @@ func (r RPS) UpdatedAt() time.Time      { return r.updatedAt }
 func (r RPS) SessionID() se.ID          { return r.sessionID }
 func (r RPS) IDtoUUID() uuid.UUID       { return uuid.UUID(r.id) }
 func (r RPS) Type() domain.GameType     { return domain.GameTypeRPS }
+func (r RPS) Commitment1() Commitment   { return r.commit1 }
+func (r RPS) Commitment2() Commitment   { return r.commit2 }
+
+// CommitmentOf returns the commitment of the given player's choice.
+func (r RPS) CommitmentOf(playerID user.ID) Commitment {
+	switch playerID {
+	case r.player1ID:
+		return r.commit1
+	case r.player2ID:
+		return r.commit2
+	default:
+		return Commitment{}
+	}
+}
+
+// IsRevealed returns true when both players have chosen, so choices may be published.
+func (r RPS) IsRevealed() bool {
+	return r.choice1 != ChoiceEmpty && r.choice2 != ChoiceEmpty
+}
 
 func (r RPS) Participants() []user.ID {
 	participants := []user.ID{}
@@ -107,15 +128,41 @@ func (r RPS) JoinGame(playerID user.ID) (RPS, error) {
 	return r, nil
 }
 
+// Cancel withdraws the game on behalf of its creator.
+// Only a game that is still waiting for players can be cancelled.
+func (r RPS) Cancel(userID user.ID) (RPS, error) {
+	if r.creatorID != userID {
+		return RPS{}, domain.ErrNotGameCreator
+	}
+	if r.status != domain.GameStatusWaitingForPlayers {
+		return RPS{}, domain.ErrGameAlreadyStarted
+	}
+
+	r.status = domain.GameStatusCancelled
+	return r, nil
+}
+
 func (r RPS) MakeChoice(playerID user.ID, choice Choice) (RPS, error) {
 	if playerID != r.player1ID && playerID != r.player2ID {
 		return RPS{}, domain.ErrPlayerNotInGame
 	}
 
+	if _, err := ChoiceFromString(choice.String()); err != nil {
+		return RPS{}, err
+	}
+
 	if playerID == r.player1ID {
+		if r.choice1 != ChoiceEmpty {
+			return RPS{}, ErrChoiceAlreadyMade
+		}
 		r.choice1 = choice
+		r.commit1 = NewCommitment(r.id, choice)
 	} else {
+		if r.choice2 != ChoiceEmpty {
+			return RPS{}, ErrChoiceAlreadyMade
+		}
 		r.choice2 = choice
+		r.commit2 = NewCommitment(r.id, choice)
 	}
 
 	if winnerID := r.tryWinnerID(); !winnerID.IsZero() {
@@ -12,7 +12,11 @@ type Result struct {
 	SeriesWinners []user.ID
 	Session       Session
 	Draws         int
-	IsCompleted   bool
+	// HouseScore counts the games won by the house in a solo session.
+	HouseScore  int
+	IsCompleted bool
+	// HouseWon is set when a completed solo session is won by the house.
+	HouseWon      bool
 	IsDraw        bool
 	NeedsNewRound bool
 }
@@ -73,6 +77,10 @@ func (sm *Manager) CalculateResult() Result {
 		}
 	}
 
+	if sm.session.GameType().IsSolo() {
+		return sm.soloResult(result)
+	}
+
 	finishedCount := sm.countFinishedGames()
 
 	if sm.session.WinCondition() == WinConditionFirstTo {
@@ -153,6 +161,49 @@ func (sm *Manager) CalculateResult() Result {
 	return result
 }
 
+// soloResult settles a session with a single human participant playing against the house.
+// A finished game that is neither a draw nor won by the participant goes to the house,
+// and the series is decided the same way as the first-to condition.
+func (sm *Manager) soloResult(result Result) Result {
+	for _, game := range sm.games {
+		if game.IsFinished() && !game.IsDraw() && len(game.Winners()) == 0 {
+			result.HouseScore++
+		}
+	}
+
+	playerScore := sm.maxScore(result.Scores)
+	winsNeeded := (sm.session.gameCount + 1) / 2
+	switch {
+	case playerScore >= winsNeeded:
+		result.IsCompleted = true
+		result.SeriesWinners = sm.determineWinners(result.Scores)
+		return result
+	case result.HouseScore >= winsNeeded:
+		result.IsCompleted = true
+		result.HouseWon = true
+		result.SeriesWinners = []user.ID{}
+		return result
+	}
+
+	if sm.countFinishedGames() >= sm.session.gameCount {
+		result.IsCompleted = true
+		switch {
+		case playerScore > result.HouseScore:
+			result.SeriesWinners = sm.determineWinners(result.Scores)
+		case result.HouseScore > playerScore:
+			result.HouseWon = true
+			result.SeriesWinners = []user.ID{}
+		default:
+			result.IsDraw = true
+			result.SeriesWinners = []user.ID{}
+		}
+		return result
+	}
+
+	result.NeedsNewRound = !sm.hasActiveGame()
+	return result
+}
+
 func (sm *Manager) determineWinners(scores map[user.ID]int) []user.ID {
 	maxWins := sm.maxScore(scores)
 	if maxWins == 0 {
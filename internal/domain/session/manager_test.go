@@ -0,0 +1,104 @@
+package session
+
+import (
+	"microgame-bot/internal/domain"
+	"microgame-bot/internal/domain/user"
+	"microgame-bot/internal/utils"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// soloGame is a finished or running hand of a solo session.
+type soloGame struct {
+	playerID user.ID
+	finished bool
+	won      bool
+	draw     bool
+}
+
+func (g soloGame) IsFinished() bool              { return g.finished }
+func (g soloGame) Participants() []user.ID       { return []user.ID{g.playerID} }
+func (g soloGame) IsDraw() bool                  { return g.draw }
+func (g soloGame) IsStarted() bool               { return g.finished }
+func (g soloGame) AFKPlayerID() (user.ID, error) { return g.playerID, nil }
+func (g soloGame) Winners() []user.ID {
+	if g.won {
+		return []user.ID{g.playerID}
+	}
+	return []user.ID{}
+}
+
+func newSoloSession(t *testing.T, gameCount int) Session {
+	t.Helper()
+	session, err := New(
+		WithNewID(),
+		WithGameType(domain.GameTypeBlackjack),
+		WithGameCount(gameCount),
+		WithWinCondition(WinConditionFirstTo),
+	)
+	require.NoError(t, err)
+	return session
+}
+
+func TestManager_SoloResult(t *testing.T) {
+	playerID := user.ID(utils.NewUniqueID())
+	session := newSoloSession(t, 1)
+
+	tests := []struct {
+		name     string
+		game     soloGame
+		houseWon bool
+		isDraw   bool
+		winners  []user.ID
+	}{
+		{
+			name:    "player wins",
+			game:    soloGame{playerID: playerID, finished: true, won: true},
+			winners: []user.ID{playerID},
+		},
+		{
+			name:     "house wins",
+			game:     soloGame{playerID: playerID, finished: true},
+			houseWon: true,
+			winners:  []user.ID{},
+		},
+		{
+			name:    "push",
+			game:    soloGame{playerID: playerID, finished: true, draw: true},
+			isDraw:  true,
+			winners: []user.ID{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := NewManager(session, []IGame{tt.game}).CalculateResult()
+			assert.True(t, result.IsCompleted)
+			assert.Equal(t, tt.houseWon, result.HouseWon)
+			assert.Equal(t, tt.isDraw, result.IsDraw)
+			assert.Equal(t, tt.winners, result.SeriesWinners)
+			assert.Equal(t, []user.ID{playerID}, result.Participants)
+		})
+	}
+}
+
+func TestManager_SoloSeriesGoesOn(t *testing.T) {
+	playerID := user.ID(utils.NewUniqueID())
+	session := newSoloSession(t, 3)
+
+	result := NewManager(session, []IGame{
+		soloGame{playerID: playerID, finished: true},
+	}).CalculateResult()
+	assert.False(t, result.IsCompleted)
+	assert.True(t, result.NeedsNewRound)
+	assert.Equal(t, 1, result.HouseScore)
+
+	result = NewManager(session, []IGame{
+		soloGame{playerID: playerID, finished: true},
+		soloGame{playerID: playerID, finished: true},
+	}).CalculateResult()
+	assert.True(t, result.IsCompleted)
+	assert.True(t, result.HouseWon)
+}
@@ -2,7 +2,10 @@ package session
 
 import (
 	"microgame-bot/internal/domain"
+	"microgame-bot/internal/utils"
 	"time"
+
+	"github.com/google/uuid"
 )
 
 type Session struct {
@@ -14,6 +17,10 @@ type Session struct {
 	winCondition    WinCondition
 	gameCount       int
 	bet             domain.Token
+	moveTimeout     time.Duration
+	joinTimeout     time.Duration
+	seed            string
+	leagueID        uuid.UUID
 	id              ID
 }
 
@@ -39,6 +46,9 @@ func New(opts ...Opt) (Session, error) {
 	if gs.gameCount <= 0 {
 		return Session{}, domain.ErrGameCountRequired
 	}
+	if gs.moveTimeout < 0 || gs.joinTimeout < 0 {
+		return Session{}, domain.ErrInvalidTimeout
+	}
 
 	return *gs, nil
 }
@@ -52,6 +62,52 @@ func (g Session) CreatedAt() time.Time                    { return g.createdAt }
 func (g Session) UpdatedAt() time.Time                    { return g.updatedAt }
 func (g Session) InlineMessageID() domain.InlineMessageID { return g.inlineMessageID }
 func (g Session) WinCondition() WinCondition              { return g.winCondition }
+func (g Session) MoveTimeout() time.Duration              { return g.moveTimeout }
+func (g Session) JoinTimeout() time.Duration              { return g.joinTimeout }
+func (g Session) Seed() string                            { return g.seed }
+func (g Session) LeagueID() uuid.UUID                     { return g.leagueID }
+
+// IsLeagueFixture returns true if the session is a fixture of a league.
+func (g Session) IsLeagueFixture() bool {
+	return g.leagueID != uuid.Nil
+}
+
+// SeedHash returns the public commitment to the session seed.
+// It is shown before the game starts, the seed itself is revealed when the session is over.
+func (g Session) SeedHash() string {
+	if g.seed == "" {
+		return ""
+	}
+	return utils.SHA256Hex(g.seed)
+}
+
+// HasClock returns true if the session was created with a per-move time control.
+func (g Session) HasClock() bool {
+	return g.moveTimeout > 0
+}
+
+// HasLiveClock returns true if the countdown should be redrawn on every tick.
+func (g Session) HasLiveClock() bool {
+	return g.HasClock() && g.moveTimeout <= MaxLiveClockTimeout
+}
+
+// MoveDeadline returns the moment the current player runs out of time,
+// counting from the last game update. Zero time means there is no clock.
+func (g Session) MoveDeadline(lastUpdate time.Time) time.Time {
+	if !g.HasClock() {
+		return time.Time{}
+	}
+	return lastUpdate.Add(g.moveTimeout)
+}
+
+// JoinDeadline returns the moment a waiting game is cancelled if nobody joins.
+// Zero time means there is no join timeout.
+func (g Session) JoinDeadline(createdAt time.Time) time.Time {
+	if g.joinTimeout <= 0 {
+		return time.Time{}
+	}
+	return createdAt.Add(g.joinTimeout)
+}
 
 func (g Session) ChangeStatus(status domain.GameStatus) (Session, error) {
 	if status.IsZero() {
@@ -119,3 +119,44 @@ func WithWinCondition(winCondition WinCondition) Opt {
 		return nil
 	}
 }
+
+func WithMoveTimeout(timeout time.Duration) Opt {
+	return func(gs *Session) error {
+		if timeout < 0 {
+			return domain.ErrInvalidTimeout
+		}
+		gs.moveTimeout = timeout
+		return nil
+	}
+}
+
+func WithJoinTimeout(timeout time.Duration) Opt {
+	return func(gs *Session) error {
+		if timeout < 0 {
+			return domain.ErrInvalidTimeout
+		}
+		gs.joinTimeout = timeout
+		return nil
+	}
+}
+
+func WithSeed(seed string) Opt {
+	return func(gs *Session) error {
+		gs.seed = seed
+		return nil
+	}
+}
+
+// WithLeagueID tags the session as a fixture of the league.
+func WithLeagueID(leagueID uuid.UUID) Opt {
+	return func(gs *Session) error {
+		gs.leagueID = leagueID
+		return nil
+	}
+}
+
+// WithNewSeed generates a fresh random seed for the session.
+func WithNewSeed() Opt {
+	//nolint:mnd // 256 bits of entropy.
+	return WithSeed(utils.RandHex(32))
+}
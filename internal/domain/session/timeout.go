@@ -0,0 +1,39 @@
+package session
+
+import (
+	"microgame-bot/internal/domain"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TimeoutKind string
+
+const (
+	// TimeoutKindJoin cancels the game if the opponent has not joined in time.
+	TimeoutKindJoin TimeoutKind = "join"
+	// TimeoutKindMove forfeits the game of the player who has not moved in time.
+	TimeoutKindMove TimeoutKind = "move"
+)
+
+// TimeoutTask is a payload of delayed tasks bound to one game of the session.
+// UpdatedAt is the game version the task was scheduled for: if the game was
+// updated after that, the task is stale and must be skipped.
+type TimeoutTask struct {
+	UpdatedAt       time.Time              `json:"updated_at"`
+	Deadline        time.Time              `json:"deadline"`
+	Kind            TimeoutKind            `json:"kind"`
+	GameType        domain.GameType        `json:"game_type"`
+	InlineMessageID domain.InlineMessageID `json:"inline_message_id"`
+	SessionID       ID                     `json:"session_id"`
+	GameID          uuid.UUID              `json:"game_id"`
+}
+
+// staleTolerance absorbs the precision lost when timestamps are stored in the database.
+const staleTolerance = time.Millisecond
+
+// IsStale reports whether the game has changed since the task was scheduled.
+func (t TimeoutTask) IsStale(gameUpdatedAt time.Time) bool {
+	diff := t.UpdatedAt.Sub(gameUpdatedAt)
+	return diff > staleTolerance || diff < -staleTolerance
+}
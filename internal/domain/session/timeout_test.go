@@ -0,0 +1,42 @@
+package session
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClampMoveTimeout(t *testing.T) {
+	assert.Equal(t, time.Duration(0), ClampMoveTimeout(0))
+	assert.Equal(t, time.Duration(0), ClampMoveTimeout(-time.Second))
+	assert.Equal(t, MinMoveTimeout, ClampMoveTimeout(time.Second))
+	assert.Equal(t, 30*time.Second, ClampMoveTimeout(30*time.Second))
+	assert.Equal(t, MaxMoveTimeout, ClampMoveTimeout(48*time.Hour))
+}
+
+func TestTimeoutTask_IsStale(t *testing.T) {
+	updatedAt := time.Date(2025, 1, 1, 12, 0, 0, 123456789, time.UTC)
+	task := TimeoutTask{UpdatedAt: updatedAt}
+
+	assert.False(t, task.IsStale(updatedAt))
+	assert.False(t, task.IsStale(updatedAt.Round(time.Microsecond)), "database precision loss is not a change")
+	assert.True(t, task.IsStale(updatedAt.Add(time.Second)))
+}
+
+func TestSession_MoveDeadline(t *testing.T) {
+	now := time.Now()
+
+	withoutClock, err := New(WithNewID(), WithGameCount(1))
+	assert.NoError(t, err)
+	assert.False(t, withoutClock.HasClock())
+	assert.True(t, withoutClock.MoveDeadline(now).IsZero())
+
+	withClock, err := New(WithNewID(), WithGameCount(1), WithMoveTimeout(30*time.Second))
+	assert.NoError(t, err)
+	assert.True(t, withClock.HasLiveClock())
+	assert.Equal(t, now.Add(30*time.Second), withClock.MoveDeadline(now))
+
+	_, err = New(WithNewID(), WithGameCount(1), WithMoveTimeout(-time.Second))
+	assert.Error(t, err)
+}
@@ -1,5 +1,7 @@
 package session
 
+import "time"
+
 type WinCondition string
 
 const (
@@ -7,3 +9,24 @@ const (
 	WinConditionBestOf  WinCondition = "best_of"
 	WinConditionAllTo   WinCondition = "all_to"
 )
+
+const (
+	MinMoveTimeout     = 10 * time.Second
+	MaxMoveTimeout     = 24 * time.Hour
+	DefaultJoinTimeout = 5 * time.Minute
+
+	// ClockTickInterval is how often the countdown on the game board is redrawn.
+	ClockTickInterval = 10 * time.Second
+	// MaxLiveClockTimeout is the longest move timeout that still gets a live countdown.
+	// Longer clocks only show the deadline once per move.
+	MaxLiveClockTimeout = 10 * time.Minute
+)
+
+// ClampMoveTimeout keeps user provided move timeout inside the allowed range.
+// Zero disables the clock.
+func ClampMoveTimeout(timeout time.Duration) time.Duration {
+	if timeout <= 0 {
+		return 0
+	}
+	return min(max(timeout, MinMoveTimeout), MaxMoveTimeout)
+}
@@ -0,0 +1,66 @@
+package tournament
+
+import (
+	"fmt"
+	"microgame-bot/internal/domain/user"
+	"strings"
+)
+
+const (
+	unknownPlayerIcon = "❔"
+	winnerIcon        = "✅"
+	runningIcon       = "⚔️"
+)
+
+// RoundName returns the conventional name of the round: final, semifinal, quarterfinal and so on.
+func (t Tournament) RoundName(round int) string {
+	matchesInRound := t.size >> (round + 1)
+	switch matchesInRound {
+	case 1:
+		return "Финал"
+	//nolint:mnd // Two matches make a semifinal.
+	case 2:
+		return "Полуфинал"
+	default:
+		return fmt.Sprintf("1/%d финала", matchesInRound)
+	}
+}
+
+// Bracket renders the bracket as HTML, one line per match grouped by rounds.
+// Matches are numbered from one in the order they are stored.
+func (t Tournament) Bracket(name func(user.ID) string) string {
+	var sb strings.Builder
+	round := -1
+	for i, m := range t.matches {
+		if m.Round != round {
+			round = m.Round
+			if i > 0 {
+				sb.WriteString("\n")
+			}
+			sb.WriteString(fmt.Sprintf("<b>%s</b>\n", t.RoundName(round)))
+		}
+		sb.WriteString(fmt.Sprintf("%d. %s\n", i+1, renderMatch(m, name)))
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+func renderMatch(m Match, name func(user.ID) string) string {
+	player := func(id user.ID) string {
+		if id.IsZero() {
+			return unknownPlayerIcon
+		}
+		if !m.IsFinished() {
+			return name(id)
+		}
+		if id == m.Winner {
+			return winnerIcon + " " + name(id)
+		}
+		return "<s>" + name(id) + "</s>"
+	}
+
+	separator := " — "
+	if m.IsStarted() && !m.IsFinished() {
+		separator = " " + runningIcon + " "
+	}
+	return player(m.Player1) + separator + player(m.Player2)
+}
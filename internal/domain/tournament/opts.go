@@ -0,0 +1,168 @@
+package tournament
+
+import (
+	"fmt"
+	"microgame-bot/internal/core"
+	"microgame-bot/internal/domain"
+	"microgame-bot/internal/domain/session"
+	"microgame-bot/internal/domain/user"
+	"microgame-bot/internal/utils"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Opt func(*Tournament) error
+
+func WithID(id ID) Opt {
+	return func(t *Tournament) error {
+		if id.IsZero() {
+			return domain.ErrIDRequired
+		}
+		t.id = id
+		return nil
+	}
+}
+
+func WithNewID() Opt {
+	return WithID(ID(utils.NewUniqueID()))
+}
+
+func WithIDFromString(id string) Opt {
+	return func(t *Tournament) error {
+		idUUID, err := utils.UUIDFromString[ID](id)
+		if err != nil {
+			return fmt.Errorf("%w: %w", core.ErrFailedToParseID, err)
+		}
+		t.id = idUUID
+		return nil
+	}
+}
+
+func WithIDFromUUID(id uuid.UUID) Opt {
+	return WithID(ID(id))
+}
+
+func WithOrganizerID(organizerID user.ID) Opt {
+	return func(t *Tournament) error {
+		if organizerID.IsZero() {
+			return domain.ErrCreatorIDRequired
+		}
+		t.organizerID = organizerID
+		return nil
+	}
+}
+
+func WithSessionID(sessionID session.ID) Opt {
+	return func(t *Tournament) error {
+		if sessionID.IsZero() {
+			return domain.ErrSessionIDRequired
+		}
+		t.sessionID = sessionID
+		return nil
+	}
+}
+
+func WithGameType(gameType domain.GameType) Opt {
+	return func(t *Tournament) error {
+		t.gameType = gameType
+		return nil
+	}
+}
+
+func WithInlineMessageID(inlineMessageID domain.InlineMessageID) Opt {
+	return func(t *Tournament) error {
+		if inlineMessageID.IsZero() {
+			return domain.ErrInlineMessageIDRequired
+		}
+		t.inlineMessageID = inlineMessageID
+		return nil
+	}
+}
+
+func WithInlineMessageIDFromString(inlineMessageID string) Opt {
+	return WithInlineMessageID(domain.InlineMessageID(inlineMessageID))
+}
+
+func WithStatus(status domain.GameStatus) Opt {
+	return func(t *Tournament) error {
+		if status.IsZero() {
+			return domain.ErrGameStatusRequired
+		}
+		if !status.IsValid() {
+			return domain.ErrInvalidGameStatus
+		}
+		t.status = status
+		return nil
+	}
+}
+
+func WithSize(size int) Opt {
+	return func(t *Tournament) error {
+		if !IsValidSize(size) {
+			return ErrInvalidSize
+		}
+		t.size = size
+		return nil
+	}
+}
+
+func WithGameCount(gameCount int) Opt {
+	return func(t *Tournament) error {
+		t.gameCount = gameCount
+		return nil
+	}
+}
+
+func WithEntryFee(entryFee domain.Token) Opt {
+	return func(t *Tournament) error {
+		t.entryFee = entryFee
+		return nil
+	}
+}
+
+func WithEntryFeeFromUint64(entryFee uint64) Opt {
+	return WithEntryFee(domain.Token(entryFee))
+}
+
+func WithSeed(seed string) Opt {
+	return func(t *Tournament) error {
+		t.seed = seed
+		return nil
+	}
+}
+
+func WithPlayers(players []user.ID) Opt {
+	return func(t *Tournament) error {
+		t.players = slices.Clone(players)
+		return nil
+	}
+}
+
+func WithMatches(matches []Match) Opt {
+	return func(t *Tournament) error {
+		t.matches = slices.Clone(matches)
+		return nil
+	}
+}
+
+func WithCreatedAt(createdAt time.Time) Opt {
+	return func(t *Tournament) error {
+		if createdAt.IsZero() {
+			return domain.ErrCreatedAtRequired
+		}
+		t.createdAt = createdAt
+		return nil
+	}
+}
+
+func WithUpdatedAt(updatedAt time.Time) Opt {
+	return func(t *Tournament) error {
+		if updatedAt.IsZero() {
+			return domain.ErrUpdatedAtRequired
+		}
+		t.updatedAt = updatedAt
+		return nil
+	}
+}
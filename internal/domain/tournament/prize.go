@@ -0,0 +1,85 @@
+package tournament
+
+import (
+	"microgame-bot/internal/domain"
+	"microgame-bot/internal/domain/bet"
+	"microgame-bot/internal/domain/user"
+)
+
+const (
+	PlaceChampion     = 1
+	PlaceFinalist     = 2
+	PlaceSemifinalist = 3
+)
+
+// Prize is the payout of a single top finisher.
+type Prize struct {
+	UserID user.ID
+	Place  int
+	Amount domain.Token
+}
+
+// PrizeFund returns the part of collected entry fees paid to the top finishers.
+// The house keeps the same share as in regular games.
+func (t Tournament) PrizeFund() domain.Token {
+	return bet.CalculateWinPayout(t.entryFee * domain.Token(len(t.players)))
+}
+
+// Standings returns the top finishers by place: the champion, the finalist and both semifinal losers.
+// Semifinal losers share the third place.
+func (t Tournament) Standings() map[int][]user.ID {
+	standings := make(map[int][]user.ID)
+	if t.status != domain.GameStatusFinished || len(t.matches) == 0 {
+		return standings
+	}
+
+	final := t.matches[len(t.matches)-1]
+	standings[PlaceChampion] = []user.ID{final.Winner}
+	standings[PlaceFinalist] = []user.ID{final.Loser()}
+
+	semifinal := t.RoundCount() - PlaceFinalist
+	for _, m := range t.matches {
+		if m.Round == semifinal {
+			standings[PlaceSemifinalist] = append(standings[PlaceSemifinalist], m.Loser())
+		}
+	}
+	return standings
+}
+
+// prizeShares returns the percent of the prize fund paid to each player of the place.
+// Small brackets pay only the final, bigger ones pay semifinalists as well.
+func (t Tournament) prizeShares() map[int]domain.Token {
+	//nolint:mnd // Prize table in percents.
+	if t.size <= 4 {
+		return map[int]domain.Token{PlaceChampion: 70, PlaceFinalist: 30}
+	}
+	//nolint:mnd // Prize table in percents.
+	return map[int]domain.Token{PlaceChampion: 50, PlaceFinalist: 30, PlaceSemifinalist: 10}
+}
+
+// Prizes splits the prize fund between the top finishers.
+// Rounding leftovers go to the champion, so the whole fund is always paid.
+func (t Tournament) Prizes(fund domain.Token) []Prize {
+	standings := t.Standings()
+	if len(standings) == 0 || fund == 0 {
+		return nil
+	}
+
+	shares := t.prizeShares()
+	var prizes []Prize
+	var paid domain.Token
+	for place := PlaceChampion; place <= PlaceSemifinalist; place++ {
+		if shares[place] == 0 {
+			continue
+		}
+		for _, userID := range standings[place] {
+			//nolint:mnd // Shares are percents.
+			amount := fund * shares[place] / 100
+			prizes = append(prizes, Prize{UserID: userID, Place: place, Amount: amount})
+			paid += amount
+		}
+	}
+	prizes[0].Amount += fund - paid
+
+	return prizes
+}
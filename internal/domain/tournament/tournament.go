@@ -0,0 +1,322 @@
+package tournament
+
+import (
+	"microgame-bot/internal/domain"
+	"microgame-bot/internal/domain/session"
+	"microgame-bot/internal/domain/user"
+	"microgame-bot/internal/utils"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// Tournament is a single elimination bracket played as a series of game sessions.
+// Entry fees are held as bets on the prize pool session, which is paid out when the final is over.
+type Tournament struct {
+	createdAt       time.Time
+	updatedAt       time.Time
+	gameType        domain.GameType
+	inlineMessageID domain.InlineMessageID
+	status          domain.GameStatus
+	seed            string
+	players         []user.ID
+	matches         []Match
+	size            int
+	gameCount       int
+	entryFee        domain.Token
+	id              ID
+	organizerID     user.ID
+	sessionID       session.ID
+}
+
+func New(opts ...Opt) (Tournament, error) {
+	t := &Tournament{
+		status:    domain.GameStatusWaitingForPlayers,
+		size:      DefaultSize,
+		gameCount: 1,
+	}
+
+	for _, opt := range opts {
+		if err := opt(t); err != nil {
+			return Tournament{}, err
+		}
+	}
+
+	// Validate required fields
+	if t.id.IsZero() {
+		return Tournament{}, domain.ErrIDRequired
+	}
+	if t.organizerID.IsZero() {
+		return Tournament{}, domain.ErrCreatorIDRequired
+	}
+	if t.sessionID.IsZero() {
+		return Tournament{}, domain.ErrSessionIDRequired
+	}
+	if t.gameType != domain.GameTypeTTT && t.gameType != domain.GameTypeRPS {
+		return Tournament{}, domain.ErrInvalidGameType
+	}
+	if !IsValidSize(t.size) {
+		return Tournament{}, ErrInvalidSize
+	}
+	if t.gameCount <= 0 {
+		return Tournament{}, domain.ErrGameCountRequired
+	}
+	if len(t.players) > t.size {
+		return Tournament{}, ErrTournamentFull
+	}
+
+	return *t, nil
+}
+
+func (t Tournament) ID() ID                                  { return t.id }
+func (t Tournament) OrganizerID() user.ID                    { return t.organizerID }
+func (t Tournament) SessionID() session.ID                   { return t.sessionID }
+func (t Tournament) GameType() domain.GameType               { return t.gameType }
+func (t Tournament) InlineMessageID() domain.InlineMessageID { return t.inlineMessageID }
+func (t Tournament) Status() domain.GameStatus               { return t.status }
+func (t Tournament) Size() int                               { return t.size }
+func (t Tournament) GameCount() int                          { return t.gameCount }
+func (t Tournament) EntryFee() domain.Token                  { return t.entryFee }
+func (t Tournament) Seed() string                            { return t.seed }
+func (t Tournament) CreatedAt() time.Time                    { return t.createdAt }
+func (t Tournament) UpdatedAt() time.Time                    { return t.updatedAt }
+func (t Tournament) Players() []user.ID                      { return slices.Clone(t.players) }
+func (t Tournament) Matches() []Match                        { return slices.Clone(t.matches) }
+
+// SeedHash returns the public commitment to the seed the bracket is drawn with.
+func (t Tournament) SeedHash() string {
+	if t.seed == "" {
+		return ""
+	}
+	return utils.SHA256Hex(t.seed)
+}
+
+// IsFull returns true if every seat of the bracket is taken.
+func (t Tournament) IsFull() bool {
+	return len(t.players) >= t.size
+}
+
+// IsRegistered returns true if the user has a seat in the tournament.
+func (t Tournament) IsRegistered(userID user.ID) bool {
+	return slices.Contains(t.players, userID)
+}
+
+// IsOver returns true if the tournament is finished or cancelled.
+func (t Tournament) IsOver() bool {
+	return t.status == domain.GameStatusFinished || t.status == domain.GameStatusCancelled
+}
+
+// RoundCount returns the number of rounds needed to get a champion.
+func (t Tournament) RoundCount() int {
+	rounds := 0
+	for n := t.size; n > 1; n /= 2 {
+		rounds++
+	}
+	return rounds
+}
+
+// Register takes a seat in the tournament.
+func (t Tournament) Register(userID user.ID) (Tournament, error) {
+	if userID.IsZero() {
+		return Tournament{}, domain.ErrUserIDRequired
+	}
+	if t.status != domain.GameStatusWaitingForPlayers {
+		return Tournament{}, ErrRegistrationClosed
+	}
+	if t.IsRegistered(userID) {
+		return Tournament{}, ErrAlreadyRegistered
+	}
+	if t.IsFull() {
+		return Tournament{}, ErrTournamentFull
+	}
+
+	t.players = append(slices.Clone(t.players), userID)
+	t.updatedAt = time.Now()
+	return t, nil
+}
+
+// Cancel withdraws the tournament on behalf of its organizer.
+// Only a tournament that is still waiting for players can be cancelled.
+func (t Tournament) Cancel(userID user.ID) (Tournament, error) {
+	if t.organizerID != userID {
+		return Tournament{}, domain.ErrNotGameCreator
+	}
+	return t.Expire()
+}
+
+// Expire cancels the tournament whose registration was never filled.
+func (t Tournament) Expire() (Tournament, error) {
+	if t.status != domain.GameStatusWaitingForPlayers {
+		return Tournament{}, domain.ErrGameAlreadyStarted
+	}
+	t.status = domain.GameStatusCancelled
+	t.updatedAt = time.Now()
+	return t, nil
+}
+
+// Start seeds the bracket once every seat is taken.
+// The order of players is shuffled with the tournament seed, so the draw can be verified after the seed is revealed.
+func (t Tournament) Start() (Tournament, error) {
+	if t.status != domain.GameStatusWaitingForPlayers {
+		return Tournament{}, domain.ErrGameAlreadyStarted
+	}
+	if !t.IsFull() {
+		return Tournament{}, domain.ErrWaitingForOpponent
+	}
+
+	seeded := slices.Clone(t.players)
+	for i := len(seeded) - 1; i > 0; i-- {
+		var j int
+		if t.seed != "" {
+			j = utils.SeededRandInt(t.seed, "draw:"+strconv.Itoa(i), i+1)
+		} else {
+			j = utils.RandInt(i + 1)
+		}
+		seeded[i], seeded[j] = seeded[j], seeded[i]
+	}
+
+	matches := make([]Match, 0, t.size-1)
+	for round, count := 0, t.size/2; count > 0; round, count = round+1, count/2 {
+		for slot := range count {
+			m := Match{Round: round, Slot: slot}
+			if round == 0 {
+				m.Player1 = seeded[slot*2]
+				m.Player2 = seeded[slot*2+1]
+			}
+			matches = append(matches, m)
+		}
+	}
+
+	t.matches = matches
+	t.status = domain.GameStatusInProgress
+	t.updatedAt = time.Now()
+	return t, nil
+}
+
+// Match returns the match by its number in the bracket.
+func (t Tournament) Match(number int) (Match, error) {
+	if number < 0 || number >= len(t.matches) {
+		return Match{}, ErrMatchNotFound
+	}
+	return t.matches[number], nil
+}
+
+// MatchNumber returns the number of the match in the bracket.
+func (t Tournament) MatchNumber(round int, slot int) int {
+	return t.size - t.size>>round + slot
+}
+
+// PendingMatches returns numbers of matches that can be started right now.
+func (t Tournament) PendingMatches() []int {
+	var numbers []int
+	for i, m := range t.matches {
+		if m.IsReady() && !m.IsStarted() {
+			numbers = append(numbers, i)
+		}
+	}
+	return numbers
+}
+
+// RunningMatches returns numbers of matches that are being played.
+func (t Tournament) RunningMatches() []int {
+	var numbers []int
+	for i, m := range t.matches {
+		if m.IsReady() && m.IsStarted() {
+			numbers = append(numbers, i)
+		}
+	}
+	return numbers
+}
+
+// StartMatch binds the game session to the match.
+func (t Tournament) StartMatch(number int, sessionID session.ID) (Tournament, error) {
+	if sessionID.IsZero() {
+		return Tournament{}, domain.ErrSessionIDRequired
+	}
+	if t.status != domain.GameStatusInProgress {
+		return Tournament{}, ErrTournamentNotStarted
+	}
+	m, err := t.Match(number)
+	if err != nil {
+		return Tournament{}, err
+	}
+	if !m.IsReady() {
+		return Tournament{}, ErrMatchNotReady
+	}
+	if m.IsStarted() {
+		return Tournament{}, ErrMatchAlreadyStarted
+	}
+
+	t.matches = slices.Clone(t.matches)
+	t.matches[number].SessionID = sessionID
+	t.updatedAt = time.Now()
+	return t, nil
+}
+
+// ReportWinner records the match result and advances the winner to the next round.
+// The tournament is finished when the final has a winner.
+func (t Tournament) ReportWinner(number int, winnerID user.ID) (Tournament, error) {
+	if t.status != domain.GameStatusInProgress {
+		return Tournament{}, ErrTournamentNotStarted
+	}
+	m, err := t.Match(number)
+	if err != nil {
+		return Tournament{}, err
+	}
+	if !m.IsReady() {
+		return Tournament{}, ErrMatchNotReady
+	}
+	if !m.HasPlayer(winnerID) {
+		return Tournament{}, ErrInvalidMatchWinner
+	}
+
+	t.matches = slices.Clone(t.matches)
+	t.matches[number].Winner = winnerID
+	t.updatedAt = time.Now()
+
+	if m.Round == t.RoundCount()-1 {
+		t.status = domain.GameStatusFinished
+		return t, nil
+	}
+
+	next := t.MatchNumber(m.Round+1, m.Slot/2)
+	//nolint:mnd // Two matches feed one match of the next round.
+	if m.Slot%2 == 0 {
+		t.matches[next].Player1 = winnerID
+	} else {
+		t.matches[next].Player2 = winnerID
+	}
+	return t, nil
+}
+
+// TieBreak picks the winner of a match that ended without one, e.g. a drawn or abandoned series.
+// The pick is derived from the tournament seed, so it can be verified later.
+func (t Tournament) TieBreak(number int) (user.ID, error) {
+	m, err := t.Match(number)
+	if err != nil {
+		return user.ID{}, err
+	}
+	//nolint:mnd // Coin flip between two players.
+	if utils.SeededRandInt(t.seed, "tiebreak:"+strconv.Itoa(number), 2) == 0 {
+		return m.Player1, nil
+	}
+	return m.Player2, nil
+}
+
+// MatchBySessionID returns the number of the match played in the session.
+func (t Tournament) MatchBySessionID(sessionID session.ID) (int, error) {
+	for i, m := range t.matches {
+		if m.SessionID == sessionID {
+			return i, nil
+		}
+	}
+	return 0, ErrMatchNotFound
+}
+
+// Champion returns the winner of the final or zero ID if the tournament is not finished.
+func (t Tournament) Champion() user.ID {
+	if len(t.matches) == 0 {
+		return user.ID{}
+	}
+	return t.matches[len(t.matches)-1].Winner
+}
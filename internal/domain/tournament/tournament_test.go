@@ -0,0 +1,164 @@
+package tournament
+
+import (
+	"microgame-bot/internal/domain"
+	"microgame-bot/internal/domain/session"
+	"microgame-bot/internal/domain/user"
+	"microgame-bot/internal/utils"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestTournament(t *testing.T, size int) Tournament {
+	t.Helper()
+	tour, err := New(
+		WithNewID(),
+		WithOrganizerID(user.ID(utils.NewUniqueID())),
+		WithSessionID(session.ID(utils.NewUniqueID())),
+		WithGameType(domain.GameTypeTTT),
+		WithSize(size),
+		WithEntryFee(100),
+		WithSeed("seed"),
+	)
+	require.NoError(t, err)
+	return tour
+}
+
+func registerPlayers(t *testing.T, tour Tournament) Tournament {
+	t.Helper()
+	for range tour.Size() {
+		var err error
+		tour, err = tour.Register(user.ID(utils.NewUniqueID()))
+		require.NoError(t, err)
+	}
+	return tour
+}
+
+func TestNew_InvalidSize(t *testing.T) {
+	_, err := New(
+		WithNewID(),
+		WithOrganizerID(user.ID(utils.NewUniqueID())),
+		WithSessionID(session.ID(utils.NewUniqueID())),
+		WithGameType(domain.GameTypeTTT),
+		WithSize(6),
+	)
+	assert.ErrorIs(t, err, ErrInvalidSize)
+}
+
+func TestTournament_Register(t *testing.T) {
+	tour := newTestTournament(t, 4)
+	playerID := user.ID(utils.NewUniqueID())
+
+	tour, err := tour.Register(playerID)
+	require.NoError(t, err)
+	assert.True(t, tour.IsRegistered(playerID))
+
+	_, err = tour.Register(playerID)
+	require.ErrorIs(t, err, ErrAlreadyRegistered)
+
+	tour = registerPlayers(t, newTestTournament(t, 4))
+	_, err = tour.Register(user.ID(utils.NewUniqueID()))
+	assert.ErrorIs(t, err, ErrTournamentFull)
+}
+
+func TestTournament_Start(t *testing.T) {
+	tour := newTestTournament(t, 8)
+	_, err := tour.Start()
+	require.ErrorIs(t, err, domain.ErrWaitingForOpponent)
+
+	tour = registerPlayers(t, tour)
+	started, err := tour.Start()
+	require.NoError(t, err)
+	assert.Equal(t, domain.GameStatusInProgress, started.Status())
+	assert.Len(t, started.Matches(), 7)
+	assert.Len(t, started.PendingMatches(), 4)
+
+	seen := make(map[user.ID]bool)
+	for _, n := range started.PendingMatches() {
+		m, err := started.Match(n)
+		require.NoError(t, err)
+		seen[m.Player1] = true
+		seen[m.Player2] = true
+	}
+	assert.Len(t, seen, 8, "every player is drawn exactly once")
+
+	again, err := tour.Start()
+	require.NoError(t, err)
+	assert.Equal(t, started.Matches(), again.Matches(), "the draw is derived from the seed")
+
+	_, err = tour.Cancel(tour.OrganizerID())
+	require.NoError(t, err)
+	_, err = started.Cancel(started.OrganizerID())
+	assert.ErrorIs(t, err, domain.ErrGameAlreadyStarted)
+}
+
+func TestTournament_ReportWinner(t *testing.T) {
+	tour, err := registerPlayers(t, newTestTournament(t, 4)).Start()
+	require.NoError(t, err)
+
+	semi1, _ := tour.Match(0)
+	semi2, _ := tour.Match(1)
+
+	_, err = tour.ReportWinner(0, user.ID(utils.NewUniqueID()))
+	require.ErrorIs(t, err, ErrInvalidMatchWinner)
+
+	tour, err = tour.StartMatch(0, session.ID(utils.NewUniqueID()))
+	require.NoError(t, err)
+	_, err = tour.StartMatch(0, session.ID(utils.NewUniqueID()))
+	require.ErrorIs(t, err, ErrMatchAlreadyStarted)
+
+	tour, err = tour.ReportWinner(0, semi1.Player2)
+	require.NoError(t, err)
+	tour, err = tour.ReportWinner(1, semi2.Player1)
+	require.NoError(t, err)
+
+	final, err := tour.Match(tour.MatchNumber(1, 0))
+	require.NoError(t, err)
+	assert.Equal(t, semi1.Player2, final.Player1)
+	assert.Equal(t, semi2.Player1, final.Player2)
+	assert.Equal(t, domain.GameStatusInProgress, tour.Status())
+
+	tour, err = tour.ReportWinner(2, final.Player2)
+	require.NoError(t, err)
+	assert.Equal(t, domain.GameStatusFinished, tour.Status())
+	assert.Equal(t, final.Player2, tour.Champion())
+}
+
+func TestTournament_Prizes(t *testing.T) {
+	tour, err := registerPlayers(t, newTestTournament(t, 8)).Start()
+	require.NoError(t, err)
+	for n := range tour.Matches() {
+		m, err := tour.Match(n)
+		require.NoError(t, err)
+		tour, err = tour.ReportWinner(n, m.Player1)
+		require.NoError(t, err)
+	}
+
+	prizes := tour.Prizes(1001)
+	require.Len(t, prizes, 4)
+	assert.Equal(t, tour.Champion(), prizes[0].UserID)
+
+	var total domain.Token
+	for _, p := range prizes {
+		total += p.Amount
+	}
+	assert.Equal(t, domain.Token(1001), total, "the whole fund is paid")
+	assert.Equal(t, domain.Token(501), prizes[0].Amount, "rounding leftovers go to the champion")
+	assert.Equal(t, domain.Token(300), prizes[1].Amount)
+}
+
+func TestTournament_TieBreak(t *testing.T) {
+	tour, err := registerPlayers(t, newTestTournament(t, 4)).Start()
+	require.NoError(t, err)
+
+	winner, err := tour.TieBreak(0)
+	require.NoError(t, err)
+	again, err := tour.TieBreak(0)
+	require.NoError(t, err)
+	m, _ := tour.Match(0)
+
+	assert.True(t, m.HasPlayer(winner))
+	assert.Equal(t, winner, again)
+}
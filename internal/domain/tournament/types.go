@@ -0,0 +1,84 @@
+package tournament
+
+import (
+	"errors"
+	"microgame-bot/internal/domain/session"
+	"microgame-bot/internal/domain/user"
+	"time"
+)
+
+var (
+	ErrInvalidSize          = errors.New("invalid tournament size")
+	ErrAlreadyRegistered    = errors.New("player already registered")
+	ErrTournamentFull       = errors.New("tournament is full")
+	ErrRegistrationClosed   = errors.New("tournament registration is closed")
+	ErrTournamentNotStarted = errors.New("tournament not started")
+	ErrMatchNotFound        = errors.New("tournament match not found")
+	ErrMatchNotReady        = errors.New("tournament match is not ready")
+	ErrMatchAlreadyStarted  = errors.New("tournament match already started")
+	ErrInvalidMatchWinner   = errors.New("winner is not a match player")
+)
+
+const (
+	DefaultSize = 8
+
+	// MatchMoveTimeout is the move clock of every tournament match,
+	// so a bracket never gets stuck on a player who walked away.
+	MatchMoveTimeout = 2 * time.Minute
+	// RegistrationTimeout is how long a tournament may wait for players before it is cancelled.
+	RegistrationTimeout = 24 * time.Hour
+)
+
+// Match is a single pairing of the bracket.
+// Matches of the first round are filled on start, later ones as winners advance.
+type Match struct {
+	Player1   user.ID
+	Player2   user.ID
+	Winner    user.ID
+	SessionID session.ID
+	Round     int
+	Slot      int
+}
+
+// IsReady returns true if both players are known and the match can be played.
+func (m Match) IsReady() bool {
+	return !m.Player1.IsZero() && !m.Player2.IsZero() && m.Winner.IsZero()
+}
+
+// IsStarted returns true if the match has a game session.
+func (m Match) IsStarted() bool {
+	return !m.SessionID.IsZero()
+}
+
+// IsFinished returns true if the match has a winner.
+func (m Match) IsFinished() bool {
+	return !m.Winner.IsZero()
+}
+
+// HasPlayer returns true if the user plays in the match.
+func (m Match) HasPlayer(userID user.ID) bool {
+	return !userID.IsZero() && (m.Player1 == userID || m.Player2 == userID)
+}
+
+// Loser returns the player who was knocked out by the match.
+func (m Match) Loser() user.ID {
+	switch m.Winner {
+	case m.Player1:
+		return m.Player2
+	case m.Player2:
+		return m.Player1
+	default:
+		return user.ID{}
+	}
+}
+
+// IsValidSize returns true if the bracket can be built for the given number of players.
+func IsValidSize(size int) bool {
+	switch size {
+	//nolint:mnd // Single elimination needs a power of two.
+	case 4, 8, 16:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,20 @@
+package trivia
+
+import "errors"
+
+var (
+	ErrInvalidCategory    = errors.New("invalid question category")
+	ErrUnknownCategory    = errors.New("unknown question category")
+	ErrInvalidDifficulty  = errors.New("invalid question difficulty")
+	ErrInvalidQuestion    = errors.New("invalid question")
+	ErrInvalidPack        = errors.New("invalid question pack")
+	ErrNoQuestions        = errors.New("no questions match the filter")
+	ErrQuestionsRequired  = errors.New("questions required")
+	ErrInvalidOption      = errors.New("invalid answer option")
+	ErrAlreadyAnswered    = errors.New("question already answered")
+	ErrQuestionClosed     = errors.New("question is closed")
+	ErrInvalidTimeout     = errors.New("invalid question timeout")
+	ErrUnknownPackFormat  = errors.New("unknown question pack format")
+	ErrDuplicateCategory  = errors.New("duplicate question category")
+	ErrCategoryTitleEmpty = errors.New("question category title required")
+)
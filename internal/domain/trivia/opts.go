@@ -0,0 +1,184 @@
+package trivia
+
+import (
+	"fmt"
+	"microgame-bot/internal/core"
+	"microgame-bot/internal/domain"
+	se "microgame-bot/internal/domain/session"
+	"microgame-bot/internal/domain/user"
+	"microgame-bot/internal/utils"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Opt func(*Trivia) error
+
+func WithID(id ID) Opt {
+	return func(t *Trivia) error {
+		if id.IsZero() {
+			return domain.ErrIDRequired
+		}
+		t.id = id
+		return nil
+	}
+}
+
+func WithNewID() Opt {
+	return WithID(ID(utils.NewUniqueID()))
+}
+
+func WithIDFromString(id string) Opt {
+	return func(t *Trivia) error {
+		idUUID, err := utils.UUIDFromString[ID](id)
+		if err != nil {
+			return fmt.Errorf("%w: %w", core.ErrFailedToParseID, err)
+		}
+		t.id = idUUID
+		return nil
+	}
+}
+
+func WithIDFromUUID(id uuid.UUID) Opt {
+	return WithID(ID(id))
+}
+
+func WithCreatorID(creatorID user.ID) Opt {
+	return func(t *Trivia) error {
+		if creatorID.IsZero() {
+			return domain.ErrCreatorIDRequired
+		}
+		t.creatorID = creatorID
+		return nil
+	}
+}
+
+func WithPlayer1ID(player1ID user.ID) Opt {
+	return func(t *Trivia) error {
+		t.player1ID = player1ID
+		return nil
+	}
+}
+
+func WithPlayer1IDFromUUID(player1ID uuid.UUID) Opt {
+	return WithPlayer1ID(user.ID(player1ID))
+}
+
+func WithPlayer2ID(player2ID user.ID) Opt {
+	return func(t *Trivia) error {
+		t.player2ID = player2ID
+		return nil
+	}
+}
+
+func WithPlayer2IDFromUUID(player2ID uuid.UUID) Opt {
+	return WithPlayer2ID(user.ID(player2ID))
+}
+
+// WithFilter sets the category and difficulty the questions of the game were drawn with, next rounds reuse it.
+func WithFilter(filter Filter) Opt {
+	return func(t *Trivia) error {
+		if !filter.Difficulty.IsValid() {
+			return ErrInvalidDifficulty
+		}
+		t.filter = filter
+		return nil
+	}
+}
+
+func WithQuestions(questions []Question) Opt {
+	return func(t *Trivia) error {
+		for _, question := range questions {
+			if err := question.Validate(); err != nil {
+				return err
+			}
+		}
+		t.questions = slices.Clone(questions)
+		return nil
+	}
+}
+
+// WithAnswers sets the answers given so far in the order they were given.
+func WithAnswers(answers []Answer) Opt {
+	return func(t *Trivia) error {
+		t.answers = slices.Clone(answers)
+		return nil
+	}
+}
+
+// WithCurrent sets the index of the open question, the number of questions once every one is closed.
+func WithCurrent(current int) Opt {
+	return func(t *Trivia) error {
+		t.current = current
+		return nil
+	}
+}
+
+func WithAskedAt(askedAt time.Time) Opt {
+	return func(t *Trivia) error {
+		t.askedAt = askedAt
+		return nil
+	}
+}
+
+func WithQuestionTimeout(timeout time.Duration) Opt {
+	return func(t *Trivia) error {
+		if timeout <= 0 {
+			return ErrInvalidTimeout
+		}
+		t.questionTimeout = timeout
+		return nil
+	}
+}
+
+func WithStatus(status domain.GameStatus) Opt {
+	return func(t *Trivia) error {
+		if status.IsZero() {
+			return domain.ErrGameStatusRequired
+		}
+		if !status.IsValid() {
+			return domain.ErrInvalidGameStatus
+		}
+		t.status = status
+		return nil
+	}
+}
+
+func WithWinnerID(winnerID user.ID) Opt {
+	return func(t *Trivia) error {
+		t.winnerID = winnerID
+		return nil
+	}
+}
+
+func WithWinnerIDFromUUID(winnerID uuid.UUID) Opt {
+	return WithWinnerID(user.ID(winnerID))
+}
+
+func WithCreatedAt(createdAt time.Time) Opt {
+	return func(t *Trivia) error {
+		if createdAt.IsZero() {
+			return domain.ErrCreatedAtRequired
+		}
+		t.createdAt = createdAt
+		return nil
+	}
+}
+
+func WithUpdatedAt(updatedAt time.Time) Opt {
+	return func(t *Trivia) error {
+		if updatedAt.IsZero() {
+			return domain.ErrUpdatedAtRequired
+		}
+		t.updatedAt = updatedAt
+		return nil
+	}
+}
+
+func WithSessionID(sessionID se.ID) Opt {
+	return func(t *Trivia) error {
+		t.sessionID = sessionID
+		return nil
+	}
+}
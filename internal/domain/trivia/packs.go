@@ -0,0 +1,242 @@
+package trivia
+
+import (
+	"bytes"
+	"embed"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"microgame-bot/internal/utils"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// packFiles keeps the question packs: packs/*.json declare categories and may hold questions,
+// packs/*.csv hold questions of the categories declared in the JSON packs.
+//
+//go:embed packs/*.json packs/*.csv
+var packFiles embed.FS
+
+// csvColumns is the header every CSV pack starts with, answer is the number of the right option from 1.
+//
+//nolint:gochecknoglobals // Header is constant.
+var csvColumns = []string{"category", "difficulty", "question", "option1", "option2", "option3", "option4", "answer"}
+
+// Pack is the content of one question pack file.
+type Pack struct {
+	Categories []Category
+	Questions  []Question
+}
+
+// jsonPack is the layout of a JSON pack, answer is the number of the right option from 1 like in CSV packs.
+type jsonPack struct {
+	Categories []Category `json:"categories"`
+	Questions  []struct {
+		Category   string     `json:"category"`
+		Difficulty Difficulty `json:"difficulty"`
+		Question   string     `json:"question"`
+		Options    []string   `json:"options"`
+		Answer     int        `json:"answer"`
+	} `json:"questions"`
+}
+
+// ParsePack parses the pack, the format is picked by the extension of the file name.
+func ParsePack(name string, r io.Reader) (Pack, error) {
+	switch strings.ToLower(path.Ext(name)) {
+	case ".json":
+		return ParseJSONPack(r)
+	case ".csv":
+		return ParseCSVPack(r)
+	default:
+		return Pack{}, fmt.Errorf("%w: %s", ErrUnknownPackFormat, name)
+	}
+}
+
+// ParseJSONPack parses a pack of categories and questions in JSON.
+func ParseJSONPack(r io.Reader) (Pack, error) {
+	var raw jsonPack
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&raw); err != nil {
+		return Pack{}, fmt.Errorf("%w: %w", ErrInvalidPack, err)
+	}
+
+	pack := Pack{
+		Categories: raw.Categories,
+		Questions:  make([]Question, 0, len(raw.Questions)),
+	}
+	for i, q := range raw.Questions {
+		question := Question{
+			Category:   q.Category,
+			Difficulty: q.Difficulty,
+			Text:       strings.TrimSpace(q.Question),
+			Options:    q.Options,
+			Answer:     q.Answer - 1,
+		}
+		if err := question.Validate(); err != nil {
+			return Pack{}, fmt.Errorf("%w in question %d", err, i+1)
+		}
+		pack.Questions = append(pack.Questions, question)
+	}
+
+	return pack, nil
+}
+
+// ParseCSVPack parses a pack of questions in CSV, the first record must be the csvColumns header.
+// Lines starting with # are comments.
+func ParseCSVPack(r io.Reader) (Pack, error) {
+	reader := csv.NewReader(r)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = len(csvColumns)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return Pack{}, fmt.Errorf("%w: %w", ErrInvalidPack, err)
+	}
+	if !slices.Equal(header, csvColumns) {
+		return Pack{}, fmt.Errorf("%w: header must be %s", ErrInvalidPack, strings.Join(csvColumns, ","))
+	}
+
+	var pack Pack
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return Pack{}, fmt.Errorf("%w: %w", ErrInvalidPack, err)
+		}
+
+		line, _ := reader.FieldPos(0)
+		//nolint:mnd // Answer is the last column.
+		answer, err := strconv.Atoi(record[7])
+		if err != nil {
+			return Pack{}, fmt.Errorf("%w on line %d", ErrInvalidOption, line)
+		}
+		//nolint:mnd // Options are the columns between the question and the answer.
+		question := Question{
+			Category:   record[0],
+			Difficulty: Difficulty(record[1]),
+			Text:       strings.TrimSpace(record[2]),
+			Options:    slices.Clone(record[3:7]),
+			Answer:     answer - 1,
+		}
+		if err := question.Validate(); err != nil {
+			return Pack{}, fmt.Errorf("%w on line %d", err, line)
+		}
+		pack.Questions = append(pack.Questions, question)
+	}
+
+	return pack, nil
+}
+
+// Library is the set of questions the games are drawn from.
+type Library struct {
+	categories []Category
+	questions  []Question
+}
+
+// NewLibrary merges the packs, every question must belong to a category declared in one of them.
+func NewLibrary(packs ...Pack) (Library, error) {
+	var l Library
+	for _, pack := range packs {
+		for _, category := range pack.Categories {
+			if err := category.Validate(); err != nil {
+				return Library{}, fmt.Errorf("%w: %q", err, category.ID)
+			}
+			if _, ok := l.Category(category.ID); ok {
+				return Library{}, fmt.Errorf("%w: %q", ErrDuplicateCategory, category.ID)
+			}
+			l.categories = append(l.categories, category)
+		}
+	}
+	for _, pack := range packs {
+		for _, question := range pack.Questions {
+			if _, ok := l.Category(question.Category); !ok {
+				return Library{}, fmt.Errorf("%w: %q", ErrUnknownCategory, question.Category)
+			}
+			l.questions = append(l.questions, question)
+		}
+	}
+	return l, nil
+}
+
+// LoadLibrary loads the embedded packs in the order of their file names.
+func LoadLibrary() (Library, error) {
+	names, err := fs.Glob(packFiles, "packs/*")
+	if err != nil {
+		return Library{}, fmt.Errorf("failed to list question packs: %w", err)
+	}
+
+	packs := make([]Pack, 0, len(names))
+	for _, name := range names {
+		data, err := packFiles.ReadFile(name)
+		if err != nil {
+			return Library{}, fmt.Errorf("failed to read question pack %s: %w", name, err)
+		}
+		pack, err := ParsePack(name, bytes.NewReader(data))
+		if err != nil {
+			return Library{}, fmt.Errorf("failed to parse question pack %s: %w", name, err)
+		}
+		packs = append(packs, pack)
+	}
+
+	return NewLibrary(packs...)
+}
+
+// Categories returns the categories in the order they are declared in the packs.
+func (l Library) Categories() []Category {
+	return slices.Clone(l.categories)
+}
+
+func (l Library) Category(id string) (Category, bool) {
+	for _, category := range l.categories {
+		if category.ID == id {
+			return category, true
+		}
+	}
+	return Category{}, false
+}
+
+// Questions returns the questions matching the filter.
+func (l Library) Questions(filter Filter) []Question {
+	questions := []Question{}
+	for _, question := range l.questions {
+		if filter.Matches(question) {
+			questions = append(questions, question)
+		}
+	}
+	return questions
+}
+
+// Pick draws up to count distinct questions matching the filter.
+// The draw depends on the session seed and the key only, so a revealed seed proves the questions weren't swapped.
+func (l Library) Pick(filter Filter, count int, seed string, key string) ([]Question, error) {
+	if filter.Category != "" {
+		if _, ok := l.Category(filter.Category); !ok {
+			return nil, ErrUnknownCategory
+		}
+	}
+	if !filter.Difficulty.IsValid() {
+		return nil, ErrInvalidDifficulty
+	}
+
+	questions := l.Questions(filter)
+	if len(questions) == 0 {
+		return nil, ErrNoQuestions
+	}
+
+	count = min(count, len(questions))
+	for i := range count {
+		j := i + utils.SeededRandInt(seed, key+":"+strconv.Itoa(i), len(questions)-i)
+		questions[i], questions[j] = questions[j], questions[i]
+	}
+
+	return questions[:count], nil
+}
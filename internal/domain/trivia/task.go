@@ -0,0 +1,17 @@
+package trivia
+
+import (
+	"microgame-bot/internal/domain"
+	se "microgame-bot/internal/domain/session"
+
+	"github.com/google/uuid"
+)
+
+// QuestionTask is a payload of the delayed task closing the question with the index once its time runs out.
+// The task is skipped if the question has been closed by the answers before that.
+type QuestionTask struct {
+	InlineMessageID domain.InlineMessageID `json:"inline_message_id"`
+	SessionID       se.ID                  `json:"session_id"`
+	GameID          uuid.UUID              `json:"game_id"`
+	Question        int                    `json:"question"`
+}
@@ -0,0 +1,337 @@
+package trivia
+
+import (
+	"microgame-bot/internal/domain"
+	se "microgame-bot/internal/domain/session"
+	"microgame-bot/internal/domain/user"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Trivia is a quiz duel: both players see the same question with OptionsCount options.
+// The first right answer scores a point and closes the question, a wrong one locks the player out of it.
+// The question also closes when both players are wrong or its time runs out.
+// Once every question is closed the higher score wins, equal scores are a draw.
+type Trivia struct {
+	createdAt       time.Time
+	updatedAt       time.Time
+	askedAt         time.Time
+	status          domain.GameStatus
+	filter          Filter
+	questions       []Question
+	answers         []Answer
+	current         int
+	questionTimeout time.Duration
+	winnerID        user.ID
+	sessionID       se.ID
+	id              ID
+	player1ID       user.ID
+	player2ID       user.ID
+	creatorID       user.ID
+}
+
+func New(opts ...Opt) (Trivia, error) {
+	t := &Trivia{
+		status:          domain.GameStatusCreated,
+		questionTimeout: DefaultQuestionTimeout,
+	}
+
+	for _, opt := range opts {
+		if err := opt(t); err != nil {
+			return Trivia{}, err
+		}
+	}
+
+	// Validate required fields
+	if t.id.IsZero() {
+		return Trivia{}, domain.ErrIDRequired
+	}
+	if t.sessionID.IsZero() {
+		return Trivia{}, domain.ErrSessionIDRequired
+	}
+	if t.creatorID.IsZero() {
+		return Trivia{}, domain.ErrCreatorIDRequired
+	}
+	if len(t.questions) == 0 {
+		return Trivia{}, ErrQuestionsRequired
+	}
+	if t.current < 0 || t.current > len(t.questions) {
+		return Trivia{}, ErrInvalidQuestion
+	}
+	if (t.player1ID.IsZero() || t.player2ID.IsZero()) &&
+		t.status != domain.GameStatusCreated &&
+		t.status != domain.GameStatusWaitingForPlayers &&
+		t.status != domain.GameStatusCancelled {
+		return Trivia{}, domain.ErrCantPlayWithoutPlayers
+	}
+
+	return *t, nil
+}
+
+func (t Trivia) ID() ID                         { return t.id }
+func (t Trivia) CreatorID() user.ID             { return t.creatorID }
+func (t Trivia) Player1ID() user.ID             { return t.player1ID }
+func (t Trivia) Player2ID() user.ID             { return t.player2ID }
+func (t Trivia) Filter() Filter                 { return t.filter }
+func (t Trivia) Questions() []Question          { return slices.Clone(t.questions) }
+func (t Trivia) Answers() []Answer              { return slices.Clone(t.answers) }
+func (t Trivia) Current() int                   { return t.current }
+func (t Trivia) AskedAt() time.Time             { return t.askedAt }
+func (t Trivia) QuestionTimeout() time.Duration { return t.questionTimeout }
+func (t Trivia) Status() domain.GameStatus      { return t.status }
+func (t Trivia) CreatedAt() time.Time           { return t.createdAt }
+func (t Trivia) UpdatedAt() time.Time           { return t.updatedAt }
+func (t Trivia) SessionID() se.ID               { return t.sessionID }
+func (t Trivia) IDtoUUID() uuid.UUID            { return uuid.UUID(t.id) }
+func (t Trivia) Type() domain.GameType          { return domain.GameTypeTrivia }
+
+func (t Trivia) Participants() []user.ID {
+	participants := []user.ID{}
+	if !t.player1ID.IsZero() {
+		participants = append(participants, t.player1ID)
+	}
+	if !t.player2ID.IsZero() {
+		participants = append(participants, t.player2ID)
+	}
+	return participants
+}
+
+// Question returns the open question, false once every question is closed.
+func (t Trivia) Question() (Question, bool) {
+	if t.current >= len(t.questions) {
+		return Question{}, false
+	}
+	return t.questions[t.current], true
+}
+
+// QuestionDeadline returns the moment the open question closes, zero if no question is open.
+func (t Trivia) QuestionDeadline() time.Time {
+	if t.askedAt.IsZero() || t.IsFinished() || t.status != domain.GameStatusInProgress {
+		return time.Time{}
+	}
+	return t.askedAt.Add(t.questionTimeout)
+}
+
+// AnswersTo returns the answers given to the question with the index in the order they were given.
+func (t Trivia) AnswersTo(question int) []Answer {
+	answers := []Answer{}
+	for _, answer := range t.answers {
+		if answer.Question == question {
+			answers = append(answers, answer)
+		}
+	}
+	return answers
+}
+
+// AnswerOf returns the answer of the player to the question with the index.
+func (t Trivia) AnswerOf(playerID user.ID, question int) (Answer, bool) {
+	for _, answer := range t.answers {
+		if answer.Question == question && answer.PlayerID == playerID {
+			return answer, true
+		}
+	}
+	return Answer{}, false
+}
+
+// ScoreOf returns the number of questions the player has answered first and right.
+func (t Trivia) ScoreOf(playerID user.ID) int {
+	score := 0
+	for _, answer := range t.answers {
+		if answer.PlayerID == playerID && answer.Correct {
+			score++
+		}
+	}
+	return score
+}
+
+func (t Trivia) JoinGame(playerID user.ID) (Trivia, error) {
+	if t.IsFinished() {
+		return Trivia{}, domain.ErrGameOver
+	}
+
+	if !t.player1ID.IsZero() && !t.player2ID.IsZero() {
+		return Trivia{}, domain.ErrGameFull
+	}
+
+	if t.player1ID == playerID || t.player2ID == playerID {
+		return Trivia{}, domain.ErrPlayerAlreadyInGame
+	}
+
+	if t.player1ID.IsZero() {
+		t.player1ID = playerID
+		// Status remains WaitingForPlayers
+		return t, nil
+	}
+
+	t.player2ID = playerID
+	t.status = domain.GameStatusInProgress
+	t.askedAt = time.Now()
+
+	return t, nil
+}
+
+// Cancel withdraws the game on behalf of its creator.
+// Only a game that is still waiting for players can be cancelled.
+func (t Trivia) Cancel(userID user.ID) (Trivia, error) {
+	if t.creatorID != userID {
+		return Trivia{}, domain.ErrNotGameCreator
+	}
+	if t.status != domain.GameStatusWaitingForPlayers {
+		return Trivia{}, domain.ErrGameAlreadyStarted
+	}
+
+	t.status = domain.GameStatusCancelled
+	return t, nil
+}
+
+// Answer picks the option of the question with the index on behalf of the player and reports whether it is right.
+// The index guards against a late tap on the keyboard of a question that has already closed.
+func (t Trivia) Answer(playerID user.ID, question int, option int) (Trivia, bool, error) {
+	if t.IsFinished() {
+		return Trivia{}, false, domain.ErrGameOver
+	}
+	if playerID != t.player1ID && playerID != t.player2ID {
+		return Trivia{}, false, domain.ErrPlayerNotInGame
+	}
+	if t.status != domain.GameStatusInProgress {
+		return Trivia{}, false, domain.ErrGameNotStarted
+	}
+	if question != t.current {
+		return Trivia{}, false, ErrQuestionClosed
+	}
+	if option < 0 || option >= OptionsCount {
+		return Trivia{}, false, ErrInvalidOption
+	}
+	if _, ok := t.AnswerOf(playerID, question); ok {
+		return Trivia{}, false, ErrAlreadyAnswered
+	}
+
+	correct := t.questions[question].Answer == option
+	t.answers = append(slices.Clone(t.answers), Answer{
+		PlayerID: playerID,
+		Question: question,
+		Option:   option,
+		Correct:  correct,
+	})
+
+	if correct || len(t.AnswersTo(question)) == len(t.Participants()) {
+		t.closeQuestion()
+	}
+
+	return t, correct, nil
+}
+
+// ExpireQuestion closes the question with the index once its time has run out.
+func (t Trivia) ExpireQuestion(question int) (Trivia, error) {
+	if t.IsFinished() {
+		return Trivia{}, domain.ErrGameOver
+	}
+	if t.status != domain.GameStatusInProgress {
+		return Trivia{}, domain.ErrGameNotStarted
+	}
+	if question != t.current {
+		return Trivia{}, ErrQuestionClosed
+	}
+
+	t.closeQuestion()
+	return t, nil
+}
+
+// closeQuestion asks the next question or finishes the game after the last one.
+func (t *Trivia) closeQuestion() {
+	t.current++
+	if t.current < len(t.questions) {
+		t.askedAt = time.Now()
+		return
+	}
+
+	score1, score2 := t.ScoreOf(t.player1ID), t.ScoreOf(t.player2ID)
+	switch {
+	case score1 > score2:
+		t.winnerID = t.player1ID
+	case score2 > score1:
+		t.winnerID = t.player2ID
+	}
+	t.status = domain.GameStatusFinished
+}
+
+func (t Trivia) IsFinished() bool {
+	return !t.winnerID.IsZero() ||
+		t.status == domain.GameStatusCancelled ||
+		t.status == domain.GameStatusFinished ||
+		t.status == domain.GameStatusAbandoned
+}
+
+// IsDraw returns true if every question is closed with equal scores.
+func (t Trivia) IsDraw() bool {
+	return t.status == domain.GameStatusFinished && t.winnerID.IsZero()
+}
+
+func (t Trivia) Winners() []user.ID {
+	if t.winnerID.IsZero() {
+		return []user.ID{}
+	}
+	return []user.ID{t.winnerID}
+}
+
+func (t Trivia) WinnerID() user.ID {
+	if t.winnerID == t.player1ID {
+		return t.player1ID
+	}
+	if t.winnerID == t.player2ID {
+		return t.player2ID
+	}
+	return user.ID{}
+}
+
+// IsStarted returns true if somebody has answered or a question has closed.
+func (t Trivia) IsStarted() bool {
+	return t.current > 0 || len(t.answers) > 0
+}
+
+func (t Trivia) SetWinner(winnerID user.ID) (Trivia, error) {
+	if winnerID != t.player1ID && winnerID != t.player2ID {
+		return Trivia{}, domain.ErrPlayerNotInGame
+	}
+	t.winnerID = winnerID
+	return t, nil
+}
+
+// AFKPlayerID returns the player who has answered fewer questions.
+// Nobody is AFK if both answered equally, both are if nobody has answered at all.
+func (t Trivia) AFKPlayerID() (user.ID, error) {
+	if len(t.answers) == 0 {
+		return user.ID{}, domain.ErrAllPlayersAFK
+	}
+
+	answered1, answered2 := 0, 0
+	for _, answer := range t.answers {
+		if answer.PlayerID == t.player1ID {
+			answered1++
+		} else {
+			answered2++
+		}
+	}
+
+	switch {
+	case answered1 < answered2:
+		return t.player1ID, nil
+	case answered2 < answered1:
+		return t.player2ID, nil
+	default:
+		return user.ID{}, domain.ErrAFKPlayerNotFound
+	}
+}
+
+func (t Trivia) SetStatus(status domain.GameStatus) (Trivia, error) {
+	if status.IsZero() {
+		return Trivia{}, domain.ErrGameStatusRequired
+	}
+	if !status.IsValid() {
+		return Trivia{}, domain.ErrInvalidGameStatus
+	}
+	t.status = status
+	return t, nil
+}
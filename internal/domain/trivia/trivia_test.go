@@ -0,0 +1,203 @@
+package trivia
+
+import (
+	"microgame-bot/internal/domain"
+	se "microgame-bot/internal/domain/session"
+	"microgame-bot/internal/domain/user"
+	"microgame-bot/internal/utils"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testQuestions(count int) []Question {
+	questions := make([]Question, 0, count)
+	for i := range count {
+		questions = append(questions, Question{
+			Category:   "geo",
+			Difficulty: DifficultyEasy,
+			Text:       "Question",
+			Options:    []string{"A", "B", "C", "D"},
+			Answer:     i % OptionsCount,
+		})
+	}
+	return questions
+}
+
+func newStartedGame(t *testing.T, questions int) (Trivia, user.ID, user.ID) {
+	t.Helper()
+	player1 := user.ID(utils.NewUniqueID())
+	player2 := user.ID(utils.NewUniqueID())
+
+	game, err := New(
+		WithNewID(),
+		WithCreatorID(player1),
+		WithSessionID(se.ID(utils.NewUniqueID())),
+		WithQuestions(testQuestions(questions)),
+		WithStatus(domain.GameStatusWaitingForPlayers),
+	)
+	require.NoError(t, err)
+	game, err = game.JoinGame(player1)
+	require.NoError(t, err)
+	game, err = game.JoinGame(player2)
+	require.NoError(t, err)
+	require.Equal(t, domain.GameStatusInProgress, game.Status())
+	require.False(t, game.QuestionDeadline().IsZero())
+
+	return game, player1, player2
+}
+
+func TestAnswer_FirstRightAnswerScoresAndClosesQuestion(t *testing.T) {
+	game, player1, player2 := newStartedGame(t, 2)
+
+	game, correct, err := game.Answer(player2, 0, 0)
+	require.NoError(t, err)
+	assert.True(t, correct)
+	assert.Equal(t, 1, game.ScoreOf(player2))
+	assert.Equal(t, 1, game.Current())
+	assert.True(t, game.IsStarted())
+
+	_, _, err = game.Answer(player1, 0, 0)
+	require.ErrorIs(t, err, ErrQuestionClosed)
+
+	_, _, err = game.Answer(player1, 1, OptionsCount)
+	require.ErrorIs(t, err, ErrInvalidOption)
+}
+
+func TestAnswer_WrongAnswerLocksPlayerOut(t *testing.T) {
+	game, player1, player2 := newStartedGame(t, 2)
+
+	game, correct, err := game.Answer(player1, 0, 3)
+	require.NoError(t, err)
+	assert.False(t, correct)
+	assert.Equal(t, 0, game.Current(), "the opponent may still answer")
+
+	_, _, err = game.Answer(player1, 0, 0)
+	require.ErrorIs(t, err, ErrAlreadyAnswered)
+
+	game, correct, err = game.Answer(player2, 0, 2)
+	require.NoError(t, err)
+	assert.False(t, correct)
+	assert.Equal(t, 1, game.Current(), "both players are wrong")
+	assert.Equal(t, 0, game.ScoreOf(player1))
+	assert.Equal(t, 0, game.ScoreOf(player2))
+}
+
+func TestAnswer_MostRightAnswersWin(t *testing.T) {
+	game, player1, player2 := newStartedGame(t, 3)
+
+	game, _, err := game.Answer(player1, 0, 0)
+	require.NoError(t, err)
+	game, _, err = game.Answer(player2, 1, 1)
+	require.NoError(t, err)
+	game, _, err = game.Answer(player1, 2, 2)
+	require.NoError(t, err)
+
+	assert.True(t, game.IsFinished())
+	assert.False(t, game.IsDraw())
+	assert.Equal(t, []user.ID{player1}, game.Winners())
+	assert.True(t, game.QuestionDeadline().IsZero())
+
+	_, _, err = game.Answer(player2, 2, 2)
+	require.ErrorIs(t, err, domain.ErrGameOver)
+}
+
+func TestExpireQuestion(t *testing.T) {
+	game, player1, _ := newStartedGame(t, 2)
+
+	game, err := game.ExpireQuestion(0)
+	require.NoError(t, err)
+	assert.Equal(t, 1, game.Current())
+
+	_, err = game.ExpireQuestion(0)
+	require.ErrorIs(t, err, ErrQuestionClosed, "a late timer of a closed question is ignored")
+
+	game, err = game.ExpireQuestion(1)
+	require.NoError(t, err)
+	assert.True(t, game.IsDraw())
+
+	_, _, err = game.Answer(player1, 1, 1)
+	require.ErrorIs(t, err, domain.ErrGameOver)
+}
+
+func TestAFKPlayerID(t *testing.T) {
+	game, player1, player2 := newStartedGame(t, 3)
+
+	_, err := game.AFKPlayerID()
+	require.ErrorIs(t, err, domain.ErrAllPlayersAFK)
+
+	game, _, err = game.Answer(player1, 0, 3)
+	require.NoError(t, err)
+	afk, err := game.AFKPlayerID()
+	require.NoError(t, err)
+	assert.Equal(t, player2, afk)
+
+	game, _, err = game.Answer(player2, 0, 3)
+	require.NoError(t, err)
+	_, err = game.AFKPlayerID()
+	require.ErrorIs(t, err, domain.ErrAFKPlayerNotFound)
+}
+
+func TestParseCSVPack(t *testing.T) {
+	pack, err := ParseCSVPack(strings.NewReader(
+		"category,difficulty,question,option1,option2,option3,option4,answer\n" +
+			"# comment\n" +
+			"geo,hard,\"Capital, of Burkina Faso?\",Niamey,Bamako,Ouagadougou,Lome,3\n",
+	))
+	require.NoError(t, err)
+	require.Len(t, pack.Questions, 1)
+	assert.Equal(t, "Capital, of Burkina Faso?", pack.Questions[0].Text)
+	assert.Equal(t, "Ouagadougou", pack.Questions[0].RightOption())
+
+	_, err = ParseCSVPack(strings.NewReader(
+		"category,difficulty,question,option1,option2,option3,option4,answer\n" +
+			"geo,hard,Question,A,B,C,D,5\n",
+	))
+	require.ErrorIs(t, err, ErrInvalidOption)
+
+	_, err = ParseCSVPack(strings.NewReader("question,answer\n"))
+	require.ErrorIs(t, err, ErrInvalidPack)
+}
+
+func TestNewLibrary_RejectsUnknownCategory(t *testing.T) {
+	pack, err := ParseJSONPack(strings.NewReader(`{
+		"categories": [{"id": "geo", "title": "Geography"}],
+		"questions": [{"category": "sport", "difficulty": "easy", "question": "Q", "options": ["A","B","C","D"], "answer": 1}]
+	}`))
+	require.NoError(t, err)
+
+	_, err = NewLibrary(pack)
+	require.ErrorIs(t, err, ErrUnknownCategory)
+}
+
+func TestLoadLibrary(t *testing.T) {
+	library, err := LoadLibrary()
+	require.NoError(t, err)
+	require.NotEmpty(t, library.Categories())
+
+	for _, category := range library.Categories() {
+		questions := library.Questions(Filter{Category: category.ID})
+		assert.GreaterOrEqual(t, len(questions), QuestionsPerGame, "category %s", category.ID)
+	}
+	for _, difficulty := range Difficulties() {
+		questions := library.Questions(Filter{Difficulty: difficulty})
+		assert.GreaterOrEqual(t, len(questions), QuestionsPerGame, "difficulty %s", difficulty)
+	}
+
+	picked, err := library.Pick(Filter{}, QuestionsPerGame, "seed", "game")
+	require.NoError(t, err)
+	require.Len(t, picked, QuestionsPerGame)
+	again, err := library.Pick(Filter{}, QuestionsPerGame, "seed", "game")
+	require.NoError(t, err)
+	assert.Equal(t, picked, again, "the draw depends on the seed and the key only")
+	for i, question := range picked {
+		for _, other := range picked[i+1:] {
+			assert.NotEqual(t, question.Text, other.Text)
+		}
+	}
+
+	_, err = library.Pick(Filter{Category: "unknown"}, QuestionsPerGame, "seed", "game")
+	require.ErrorIs(t, err, ErrUnknownCategory)
+}
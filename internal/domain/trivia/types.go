@@ -0,0 +1,158 @@
+package trivia
+
+import (
+	"microgame-bot/internal/domain/user"
+	"strings"
+	"time"
+)
+
+const (
+	// OptionsCount is the number of answer buttons under every question.
+	OptionsCount = 4
+	// QuestionsPerGame is the length of one game, the series counts games, not questions.
+	QuestionsPerGame = 5
+	// DefaultQuestionTimeout is the time to answer when the session has no move clock.
+	DefaultQuestionTimeout = 30 * time.Second
+	// MinQuestionTimeout and MaxQuestionTimeout bound the time to answer taken from the move clock.
+	MinQuestionTimeout = 10 * time.Second
+	MaxQuestionTimeout = 5 * time.Minute
+)
+
+// ClampQuestionTimeout turns the move clock of the session into the time to answer a question.
+// Zero falls back to DefaultQuestionTimeout, a trivia question is always timed.
+func ClampQuestionTimeout(timeout time.Duration) time.Duration {
+	if timeout <= 0 {
+		return DefaultQuestionTimeout
+	}
+	return min(max(timeout, MinQuestionTimeout), MaxQuestionTimeout)
+}
+
+type Difficulty string
+
+const (
+	DifficultyAny    Difficulty = ""
+	DifficultyEasy   Difficulty = "easy"
+	DifficultyMedium Difficulty = "medium"
+	DifficultyHard   Difficulty = "hard"
+)
+
+// Difficulties returns every difficulty in the order they are offered to players.
+func Difficulties() []Difficulty {
+	return []Difficulty{DifficultyEasy, DifficultyMedium, DifficultyHard}
+}
+
+func (d Difficulty) String() string {
+	return string(d)
+}
+
+// IsValid accepts DifficultyAny, it is only rejected in the questions themselves.
+func (d Difficulty) IsValid() bool {
+	switch d {
+	case DifficultyAny, DifficultyEasy, DifficultyMedium, DifficultyHard:
+		return true
+	default:
+		return false
+	}
+}
+
+// DifficultyFromString parses the difficulty, "any" and the empty string stand for any difficulty.
+func DifficultyFromString(s string) (Difficulty, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "any" {
+		return DifficultyAny, nil
+	}
+	d := Difficulty(s)
+	if !d.IsValid() {
+		return "", ErrInvalidDifficulty
+	}
+	return d, nil
+}
+
+// Title returns the name of the difficulty shown to players.
+func (d Difficulty) Title() string {
+	switch d {
+	case DifficultyEasy:
+		return "🟢 Лёгкие"
+	case DifficultyMedium:
+		return "🟡 Средние"
+	case DifficultyHard:
+		return "🔴 Сложные"
+	default:
+		return "🎲 Любые"
+	}
+}
+
+// MaxCategoryIDLength keeps category IDs short, they travel in the callback data.
+const MaxCategoryIDLength = 16
+
+// Category groups the questions of the library, the ID is used in callback data and the title is shown to players.
+type Category struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+}
+
+func (c Category) Validate() error {
+	if c.ID == "" || len(c.ID) > MaxCategoryIDLength {
+		return ErrInvalidCategory
+	}
+	for _, r := range c.ID {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '_' {
+			return ErrInvalidCategory
+		}
+	}
+	if strings.TrimSpace(c.Title) == "" {
+		return ErrCategoryTitleEmpty
+	}
+	return nil
+}
+
+// Question is a question with OptionsCount options, Answer is the index of the right option.
+type Question struct {
+	Category   string     `json:"category"`
+	Difficulty Difficulty `json:"difficulty"`
+	Text       string     `json:"text"`
+	Options    []string   `json:"options"`
+	Answer     int        `json:"answer"`
+}
+
+func (q Question) Validate() error {
+	if q.Difficulty == DifficultyAny || !q.Difficulty.IsValid() {
+		return ErrInvalidDifficulty
+	}
+	if strings.TrimSpace(q.Text) == "" || len(q.Options) != OptionsCount {
+		return ErrInvalidQuestion
+	}
+	for _, option := range q.Options {
+		if strings.TrimSpace(option) == "" {
+			return ErrInvalidQuestion
+		}
+	}
+	if q.Answer < 0 || q.Answer >= OptionsCount {
+		return ErrInvalidOption
+	}
+	return nil
+}
+
+// RightOption returns the text of the right option.
+func (q Question) RightOption() string {
+	return q.Options[q.Answer]
+}
+
+// Filter narrows the questions of a game, zero values match everything.
+type Filter struct {
+	Category   string     `json:"category"`
+	Difficulty Difficulty `json:"difficulty"`
+}
+
+func (f Filter) Matches(q Question) bool {
+	return (f.Category == "" || f.Category == q.Category) &&
+		(f.Difficulty == DifficultyAny || f.Difficulty == q.Difficulty)
+}
+
+// Answer is the option a player chose for the question with the given index.
+type Answer struct {
+	PlayerID user.ID `json:"player_id"`
+	Question int     `json:"question"`
+	Option   int     `json:"option"`
+	Correct  bool    `json:"correct"`
+}
@@ -6,4 +6,6 @@ var (
 	ErrInvalidMove  = errors.New("invalid move")
 	ErrCellOccupied = errors.New("cell is already occupied")
 	ErrOutOfBounds  = errors.New("coordinates out of bounds")
+	// ErrReplayUnfinished is returned for the replay of a game that is still going on.
+	ErrReplayUnfinished = errors.New("replay of unfinished game")
 )
@@ -0,0 +1,20 @@
+package ttt
+
+import (
+	"microgame-bot/internal/domain"
+	"microgame-bot/internal/domain/user"
+)
+
+// Cancel withdraws the game on behalf of its creator.
+// Only a game that is still waiting for players can be cancelled.
+func (t TTT) Cancel(userID user.ID) (TTT, error) {
+	if t.creatorID != userID {
+		return TTT{}, domain.ErrNotGameCreator
+	}
+	if t.status != domain.GameStatusWaitingForPlayers {
+		return TTT{}, domain.ErrGameAlreadyStarted
+	}
+
+	t.status = domain.GameStatusCancelled
+	return t, nil
+}
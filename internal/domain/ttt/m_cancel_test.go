@@ -0,0 +1,35 @@
+package ttt
+
+import (
+	"microgame-bot/internal/domain"
+	"microgame-bot/internal/domain/user"
+	"microgame-bot/internal/utils"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCancel(t *testing.T) {
+	creatorID := user.ID(utils.NewUniqueID())
+	otherID := user.ID(utils.NewUniqueID())
+
+	game, err := New(
+		WithNewID(),
+		WithCreatorID(creatorID),
+		WithStatus(domain.GameStatusWaitingForPlayers),
+	)
+	require.NoError(t, err)
+
+	_, err = game.Cancel(otherID)
+	require.ErrorIs(t, err, domain.ErrNotGameCreator)
+
+	cancelled, err := game.Cancel(creatorID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.GameStatusCancelled, cancelled.Status())
+
+	started, err := game.SetStatus(domain.GameStatusInProgress)
+	require.NoError(t, err)
+	_, err = started.Cancel(creatorID)
+	require.ErrorIs(t, err, domain.ErrGameAlreadyStarted)
+}
@@ -3,6 +3,8 @@ package ttt
 import (
 	"microgame-bot/internal/domain"
 	"microgame-bot/internal/domain/user"
+	"slices"
+	"time"
 )
 
 // MakeMove attempts to make a move at the specified coordinates for the given user.
@@ -29,6 +31,13 @@ func (t TTT) MakeMove(row, col int, userID user.ID) (TTT, error) {
 	}
 
 	t.board[row][col] = t.PlayerCell(userID)
+	t.moves = append(slices.Clone(t.moves), Move{
+		At:       time.Now(),
+		PlayerID: userID,
+		Cell:     t.board[row][col],
+		Row:      row,
+		Col:      col,
+	})
 
 	if winnerID := t.checkWinner(); !winnerID.IsZero() {
 		t.winnerID = winnerID
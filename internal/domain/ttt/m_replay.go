@@ -0,0 +1,48 @@
+package ttt
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Moves returns the move history of the game in the order moves were made.
+func (t TTT) Moves() []Move {
+	return slices.Clone(t.moves)
+}
+
+// BoardAt returns the board after the given number of moves.
+// Step is clamped to the range [0, len(moves)].
+func (t TTT) BoardAt(step int) [3][3]Cell {
+	var board [3][3]Cell
+	step = min(max(step, 0), len(t.moves))
+	for _, move := range t.moves[:step] {
+		board[move.Row][move.Col] = move.Cell
+	}
+	return board
+}
+
+// Notation exports the move list in compact text notation, e.g. "1. b2 a1 2. c3 c1 3. a3 1-0".
+// X always moves first, so every numbered pair is an X move followed by an O move.
+// The result is appended for finished games: 1-0 (X won), 0-1 (O won) or ½-½ (draw).
+func (t TTT) Notation() string {
+	parts := make([]string, 0, len(t.moves)+len(t.moves)/2+2)
+	for i, move := range t.moves {
+		//nolint:mnd // Two moves per turn number.
+		if i%2 == 0 {
+			parts = append(parts, strconv.Itoa(i/2+1)+".")
+		}
+		parts = append(parts, move.Square())
+	}
+
+	switch {
+	case !t.winnerID.IsZero() && t.winnerID == t.playerXID:
+		parts = append(parts, "1-0")
+	case !t.winnerID.IsZero() && t.winnerID == t.playerOID:
+		parts = append(parts, "0-1")
+	case t.IsDraw():
+		parts = append(parts, "½-½")
+	}
+
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,49 @@
+package ttt
+
+import (
+	"microgame-bot/internal/domain"
+	"microgame-bot/internal/domain/user"
+	"microgame-bot/internal/utils"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMoves_NotationAndReplay(t *testing.T) {
+	playerX := user.ID(utils.NewUniqueID())
+	playerO := user.ID(utils.NewUniqueID())
+
+	game, err := New(
+		WithNewID(),
+		WithCreatorID(playerX),
+		WithPlayerXID(playerX),
+		WithPlayerOID(playerO),
+		WithStatus(domain.GameStatusInProgress),
+	)
+	require.NoError(t, err)
+
+	moves := []struct {
+		player   user.ID
+		row, col int
+	}{
+		{playerX, 1, 1},
+		{playerO, 0, 0},
+		{playerX, 0, 2},
+		{playerO, 2, 0},
+		{playerX, 2, 2},
+		{playerO, 1, 0},
+	}
+	for _, m := range moves {
+		game, err = game.MakeMove(m.row, m.col, m.player)
+		require.NoError(t, err)
+	}
+
+	require.Len(t, game.Moves(), len(moves))
+	assert.Equal(t, playerO, game.WinnerID())
+	assert.Equal(t, "1. b2 a1 2. c1 a3 3. c3 a2 0-1", game.Notation())
+
+	assert.Equal(t, [3][3]Cell{}, game.BoardAt(0))
+	assert.Equal(t, [3][3]Cell{{CellO, CellEmpty, CellEmpty}, {CellEmpty, CellX, CellEmpty}, {}}, game.BoardAt(2))
+	assert.Equal(t, game.Board(), game.BoardAt(len(moves)+5))
+}
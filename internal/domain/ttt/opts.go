@@ -104,6 +104,22 @@ func WithBoard(board [3][3]Cell) Opt {
 	}
 }
 
+// WithMoves restores the move history of the game.
+func WithMoves(moves []Move) Opt {
+	return func(t *TTT) error {
+		t.moves = moves
+		return nil
+	}
+}
+
+// WithSeed sets the session seed used for random decisions in the game.
+func WithSeed(seed string) Opt {
+	return func(t *TTT) error {
+		t.seed = seed
+		return nil
+	}
+}
+
 // WithRandomFirstPlayer randomly assigns creator to X or O.
 func WithRandomFirstPlayer() Opt {
 	return func(t *TTT) error {
@@ -23,6 +23,8 @@ type TTT struct {
 	winnerID  user.ID
 	sessionID session.ID
 	turn      user.ID
+	seed      string
+	moves     []Move
 }
 
 // New creates a new TTT instance with the given options.
@@ -71,6 +73,7 @@ func (t TTT) UpdatedAt() time.Time      { return t.updatedAt }
 func (t TTT) SessionID() session.ID     { return t.sessionID }
 func (t TTT) IDtoUUID() uuid.UUID       { return uuid.UUID(t.id) }
 func (t TTT) Type() domain.GameType     { return domain.GameTypeTTT }
+func (t TTT) Seed() string              { return t.seed }
 
 // Participants returns all participants in the game.
 func (t TTT) Participants() []user.ID {
@@ -176,9 +179,18 @@ func (t TTT) GetCell(row, col int) (Cell, error) {
 }
 
 // AssignPlayersRandomly randomly assigns two players to X and O roles.
+// If the game has a session seed, the choice is derived from it and can be verified
+// once the seed is revealed: players are swapped when SHA256(seed:gameID) is odd.
 func (t TTT) AssignPlayersRandomly() TTT {
-	//nolint:mnd // Random 50% chance.
-	if utils.RandInt(2) == 0 {
+	var roll int
+	if t.seed != "" {
+		//nolint:mnd // 50% chance.
+		roll = utils.SeededRandInt(t.seed, t.id.String(), 2)
+	} else {
+		//nolint:mnd // Random 50% chance.
+		roll = utils.RandInt(2)
+	}
+	if roll == 0 {
 		t.turn = t.playerXID
 		return t
 	}
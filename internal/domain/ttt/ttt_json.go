@@ -20,6 +20,7 @@ func (t TTT) MarshalJSON() ([]byte, error) {
 		PlayerXID user.ID    `json:"player_x_id"`
 		PlayerOID user.ID    `json:"player_o_id"`
 		CreatorID user.ID    `json:"creator_id"`
+		Moves     []Move     `json:"moves"`
 	}{
 		ID:        t.id,
 		SessionID: t.sessionID,
@@ -31,6 +32,7 @@ func (t TTT) MarshalJSON() ([]byte, error) {
 		WinnerID:  t.winnerID,
 		CreatedAt: t.createdAt,
 		UpdatedAt: t.updatedAt,
+		Moves:     t.moves,
 	})
 }
 
@@ -47,6 +49,7 @@ func (t *TTT) UnmarshalJSON(data []byte) error {
 		PlayerXID user.ID    `json:"player_x_id"`
 		PlayerOID user.ID    `json:"player_o_id"`
 		CreatorID user.ID    `json:"creator_id"`
+		Moves     []Move     `json:"moves"`
 	}
 	if err := json.Unmarshal(data, &aux); err != nil {
 		return err
@@ -63,6 +66,7 @@ func (t *TTT) UnmarshalJSON(data []byte) error {
 		WithWinnerID(aux.WinnerID),
 		WithCreatedAt(aux.CreatedAt),
 		WithUpdatedAt(aux.UpdatedAt),
+		WithMoves(aux.Moves),
 	)
 	if err != nil {
 		return err
@@ -0,0 +1,21 @@
+package ttt
+
+import (
+	"microgame-bot/internal/domain/user"
+	"strconv"
+	"time"
+)
+
+// Move is a single move of the game history.
+type Move struct {
+	At       time.Time `json:"at"`
+	PlayerID user.ID   `json:"player_id"`
+	Cell     Cell      `json:"cell"`
+	Row      int       `json:"row"`
+	Col      int       `json:"col"`
+}
+
+// Square returns the cell in compact notation: column letter a-c and row number 1-3, a1 is top left.
+func (m Move) Square() string {
+	return string(rune('a'+m.Col)) + strconv.Itoa(m.Row+1)
+}
@@ -25,6 +25,24 @@ const (
 const (
 	GameTypeRPS GameType = "rps"
 	GameTypeTTT GameType = "ttt"
+	// GameTypeDice is a duel of Telegram dice throws, the higher value wins.
+	GameTypeDice GameType = "dice"
+	// GameTypeBullsCows is a number guessing duel, the secrets are set in private chats.
+	GameTypeBullsCows GameType = "bullscows"
+	// GameTypeTrivia is a quiz duel, the first right answer to every question scores.
+	GameTypeTrivia GameType = "trivia"
+	// GameTypeCheckers is Russian draughts with mandatory captures and flying kings.
+	GameTypeCheckers GameType = "checkers"
+	// GameTypeReversi is the disc flipping game on the 8x8 board.
+	GameTypeReversi GameType = "reversi"
+	// GameTypeHangman is a word guessing game, players take turns naming letters.
+	GameTypeHangman GameType = "hangman"
+	// GameTypeBattleship is a game of two hidden fleets placed in private chats.
+	GameTypeBattleship GameType = "battleship"
+	// GameTypeBlackjack is a solo game against the house, the bot deals for the dealer.
+	GameTypeBlackjack GameType = "blackjack"
+	// GameTypeTournament marks the session holding a tournament prize pool, it has no games of its own.
+	GameTypeTournament GameType = "tournament"
 )
 
 const (
@@ -79,6 +97,11 @@ func (g GameType) String() string {
 	return string(g)
 }
 
+// IsSolo returns true for games played by a single human participant against the house.
+func (g GameType) IsSolo() bool {
+	return g == GameTypeBlackjack
+}
+
 // Scan implements gorm.Serializer interface for reading from database.
 func (id *InlineMessageID) Scan(_ context.Context, _ *schema.Field, _ reflect.Value, dbValue any) error {
 	switch value := dbValue.(type) {
@@ -0,0 +1,181 @@
+package handlers
+
+import (
+	"fmt"
+	"microgame-bot/internal/domain/battleship"
+	domainUser "microgame-bot/internal/domain/user"
+	"microgame-bot/internal/msgs"
+	"strings"
+	"time"
+
+	"github.com/mymmrac/telego"
+	tu "github.com/mymmrac/telego/telegoutil"
+)
+
+// BuildBattleshipGameBoardKeyboard creates the keyboard of the shared message.
+// During placement it only has the button sending the placement keyboard to the private chat.
+// During the battle it is the board of the player under fire: shot cells show their marks,
+// every other cell has the same shot callback, so the keyboard tells nothing about the hidden ships.
+// Non-zero deadline is shown as a countdown below the board.
+func BuildBattleshipGameBoardKeyboard(game *battleship.Battleship, deadline time.Time) *telego.InlineKeyboardMarkup {
+	//nolint:mnd // Board rows and the clock row.
+	rows := make([][]telego.InlineKeyboardButton, 0, battleship.BoardSize+1)
+	if game.IsFinished() {
+		return &telego.InlineKeyboardMarkup{
+			InlineKeyboard: rows,
+		}
+	}
+
+	if game.IsPlacing() {
+		rows = append(rows, []telego.InlineKeyboardButton{
+			{
+				Text:         "⚓ Расставить корабли",
+				CallbackData: "g::bs::setup::" + game.ID().String(),
+			},
+		})
+	} else {
+		target := game.TargetBoardOf(game.Turn())
+		for row := range battleship.BoardSize {
+			buttons := make([]telego.InlineKeyboardButton, 0, battleship.BoardSize)
+			for col := range battleship.BoardSize {
+				cell := battleship.Cell{Row: row, Col: col}
+				mark := target.Mark(cell)
+				callbackData := "empty"
+				if mark == battleship.MarkUnknown {
+					callbackData = "g::bs::shot::" + game.ID().String() + "::" + cell.String()
+				}
+				buttons = append(buttons, telego.InlineKeyboardButton{
+					Text:         msgs.BattleshipCellIcon(mark),
+					CallbackData: callbackData,
+				})
+			}
+			rows = append(rows, buttons)
+		}
+	}
+
+	if !deadline.IsZero() {
+		rows = append(rows, []telego.InlineKeyboardButton{
+			{
+				Text:         msgs.ClockLabel(deadline),
+				CallbackData: "empty",
+			},
+		})
+	}
+
+	return &telego.InlineKeyboardMarkup{
+		InlineKeyboard: rows,
+	}
+}
+
+// buildBattleshipPlacementKeyboard creates the private keyboard with the own fleet of the player.
+// The orientation is kept in the callback data, switching it doesn't touch the game.
+func buildBattleshipPlacementKeyboard(
+	game *battleship.Battleship,
+	playerID domainUser.ID,
+	orientation battleship.Orientation,
+) *telego.InlineKeyboardMarkup {
+	//nolint:mnd // Board rows and three control rows.
+	rows := make([][]telego.InlineKeyboardButton, 0, battleship.BoardSize+3)
+	board := game.BoardOf(playerID)
+	prefix := "::" + game.ID().String() + "::" + orientation.String()
+
+	for row := range battleship.BoardSize {
+		buttons := make([]telego.InlineKeyboardButton, 0, battleship.BoardSize)
+		for col := range battleship.BoardSize {
+			cell := battleship.Cell{Row: row, Col: col}
+			buttons = append(buttons, telego.InlineKeyboardButton{
+				Text:         msgs.BattleshipCellIcon(board.OwnerMark(cell)),
+				CallbackData: "g::bs::place" + prefix + "::" + cell.String(),
+			})
+		}
+		rows = append(rows, buttons)
+	}
+
+	orientationLabel := "➡️ Горизонтально"
+	if orientation == battleship.OrientationVertical {
+		orientationLabel = "⬇️ Вертикально"
+	}
+	rows = append(rows,
+		tu.InlineKeyboardRow(
+			tu.InlineKeyboardButton(orientationLabel).
+				WithCallbackData("g::bs::orient::"+game.ID().String()+"::"+orientation.Toggle().String()),
+		),
+		tu.InlineKeyboardRow(
+			tu.InlineKeyboardButton("🎲 Случайно").WithCallbackData("g::bs::random"+prefix),
+			tu.InlineKeyboardButton("🔄 Сбросить").WithCallbackData("g::bs::reset"+prefix),
+		),
+	)
+	if board.IsComplete() {
+		rows = append(rows, tu.InlineKeyboardRow(
+			tu.InlineKeyboardButton("✅ Готово").WithCallbackData("g::bs::ready"+prefix),
+		))
+	}
+
+	return &telego.InlineKeyboardMarkup{
+		InlineKeyboard: rows,
+	}
+}
+
+// buildBattleshipWaitingKeyboard creates inline keyboard for a game waiting for players.
+func buildBattleshipWaitingKeyboard(game *battleship.Battleship) *telego.InlineKeyboardMarkup {
+	return tu.InlineKeyboard(
+		tu.InlineKeyboardRow(
+			tu.InlineKeyboardButton("Присоединиться").
+				WithCallbackData("g::bs::join::"+game.ID().String()),
+		),
+		tu.InlineKeyboardRow(
+			tu.InlineKeyboardButton("❌ Отменить").
+				WithCallbackData("g::bs::cancel::"+game.ID().String()),
+		),
+	)
+}
+
+// battleshipArticle offers the battleship game with the series settings of the query.
+func battleshipArticle(args gameArgs) telego.InlineQueryResult {
+	msg := fmt.Sprintf(
+		"🎮 <b>🚢 Морской бой</b>\n<i>%s</i>\n\nКорабли расставляются втайне, стрельба идёт здесь. "+
+			"Нажми кнопку, чтобы начать игру!",
+		args.label(),
+	)
+	return tu.ResultArticle(
+		"game::bs",
+		"🚢 Морской бой "+args.label(),
+		tu.TextMessage(msg).WithParseMode("HTML"),
+	).WithReplyMarkup(tu.InlineKeyboard(
+		tu.InlineKeyboardRow(
+			tu.InlineKeyboardButton("🎯 Начать игру").
+				WithCallbackData("create::bs::" + args.callbackData()),
+		),
+	))
+}
+
+// Extracts the orientation from the placement callback data: g::bs::<action>::<game id>::<orientation>.
+// If the orientation is missing, returns the horizontal one.
+func extractBattleshipOrientation(callbackData string) (battleship.Orientation, error) {
+	parts := strings.Split(callbackData, "::")
+	//nolint:mnd // Callback data params is constant.
+	if len(parts) < 5 {
+		return battleship.OrientationHorizontal, nil
+	}
+	return battleship.OrientationFromString(parts[4])
+}
+
+// Extracts the cell from the callback data, it is always the last part.
+func extractBattleshipCell(callbackData string) (battleship.Cell, error) {
+	parts := strings.Split(callbackData, "::")
+	//nolint:mnd // Callback data params is constant.
+	if len(parts) < 5 {
+		return battleship.Cell{}, ErrInvalidCallbackData
+	}
+	return battleship.CellFromString(parts[len(parts)-1])
+}
+
+// Extracts the placement action from the callback data: g::bs::<action>::<game id>.
+func extractBattleshipAction(callbackData string) string {
+	parts := strings.Split(callbackData, "::")
+	//nolint:mnd // Callback data params is constant.
+	if len(parts) < 3 {
+		return ""
+	}
+	return parts[2]
+}
@@ -0,0 +1,201 @@
+package handlers
+
+import (
+	"fmt"
+	"log/slog"
+	"microgame-bot/internal/core/logger"
+	"microgame-bot/internal/domain/battleship"
+	domainSession "microgame-bot/internal/domain/session"
+	domainUser "microgame-bot/internal/domain/user"
+	"microgame-bot/internal/msgs"
+	userRepository "microgame-bot/internal/repo/user"
+	"microgame-bot/internal/uow"
+	"microgame-bot/internal/utils"
+
+	"github.com/mymmrac/telego"
+	th "github.com/mymmrac/telego/telegohandler"
+)
+
+// BattleshipPlace handles the placement keyboard in the private chat of the player:
+// placing the next ship, switching the orientation, random placement, reset and the fleet confirmation.
+// Once the fleet is confirmed the shared message is updated, it only learns that the player is ready.
+func BattleshipPlace(
+	userGetter userRepository.IUserGetter,
+	unit uow.IUnitOfWork,
+) CallbackQueryHandlerFunc {
+	const operationName = "handler::battleship_place"
+	return func(ctx *th.Context, query telego.CallbackQuery) (IResponse, error) {
+		slog.DebugContext(ctx, "Battleship place callback received", logger.OperationField, operationName)
+
+		if query.Message == nil || !query.Message.IsAccessible() {
+			return nil, ErrInvalidCallbackData
+		}
+		chatID := query.Message.GetChat().ID
+		messageID := query.Message.GetMessageID()
+
+		player, err := userFromContext(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user from context in %s: %w", operationName, err)
+		}
+
+		gameID, err := extractGameID[battleship.ID](query.Data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract game ID from callback data in %s: %w", operationName, err)
+		}
+		orientation, err := extractBattleshipOrientation(query.Data)
+		if err != nil {
+			return nil, err
+		}
+		action := extractBattleshipAction(query.Data)
+
+		rawCtx := ctx.Context()
+		rawCtx = logger.WithLogValue(rawCtx, logger.GameIDField, utils.UUIDString(gameID))
+		ctx = ctx.WithContext(rawCtx)
+
+		var game battleship.Battleship
+		if action == "orient" {
+			// The orientation lives in the keyboard only, switching it doesn't touch the game
+			gameGetter, err := unit.BattleshipRepo()
+			if err != nil {
+				return nil, fmt.Errorf("failed to get game repository in %s: %w", operationName, err)
+			}
+			game, err = gameGetter.GameByID(ctx, gameID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get game by ID in %s: %w", operationName, err)
+			}
+			if err := game.CanPlace(player.ID()); err != nil {
+				return nil, err
+			}
+		} else {
+			var cell battleship.Cell
+			if action == "place" {
+				cell, err = extractBattleshipCell(query.Data)
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			err = unit.Do(ctx, func(uow uow.IUnitOfWork) error {
+				gameRepo, err := uow.BattleshipRepo()
+				if err != nil {
+					return fmt.Errorf("failed to get game repository in %s: %w", operationName, err)
+				}
+				game, err = gameRepo.GameByIDLocked(ctx, gameID)
+				if err != nil {
+					return fmt.Errorf("failed to get game by ID with lock in %s: %w", operationName, err)
+				}
+
+				switch action {
+				case "place":
+					game, err = game.PlaceShip(player.ID(), cell, orientation)
+				case "random":
+					game, err = game.PlaceRandomly(player.ID(), utils.RandInt)
+				case "reset":
+					game, err = game.ResetFleet(player.ID())
+				case "ready":
+					game, err = game.Ready(player.ID())
+				default:
+					err = ErrInvalidCallbackData
+				}
+				if err != nil {
+					return err
+				}
+
+				game, err = gameRepo.UpdateGame(ctx, game)
+				if err != nil {
+					return fmt.Errorf("failed to update game in %s: %w", operationName, err)
+				}
+
+				// Every update of the game invalidates the pending move timeout, so it is scheduled again
+				return scheduleGameMoveTimeout(ctx, uow, game.SessionID(), game.IDtoUUID(), game.UpdatedAt())
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		answer := &CallbackQueryResponse{CallbackQueryID: query.ID}
+		if action != "ready" {
+			return ResponseChain{
+				&EditMessageTextResponse{
+					ChatID:      chatID,
+					MessageID:   messageID,
+					Text:        msgs.BattleshipPlacement(game.BoardOf(player.ID()), orientation),
+					ParseMode:   "HTML",
+					ReplyMarkup: buildBattleshipPlacementKeyboard(&game, player.ID(), orientation),
+				},
+				answer,
+			}, nil
+		}
+
+		session, err := battleshipSession(ctx, unit, game)
+		if err != nil {
+			return nil, err
+		}
+
+		gameGetter, err := unit.BattleshipRepo()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get game repository in %s: %w", operationName, err)
+		}
+		allGames, err := gameGetter.GamesBySessionID(ctx, session.ID())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get games by session ID in %s: %w", operationName, err)
+		}
+		games := make([]domainSession.IGame, len(allGames))
+		for i, g := range allGames {
+			games[i] = g
+		}
+		result := domainSession.NewManager(session, games).CalculateResult()
+
+		var player1, player2 domainUser.User
+		player1, err = userGetter.UserByID(ctx, game.Player1ID())
+		if err != nil {
+			return nil, err
+		}
+		player2, err = userGetter.UserByID(ctx, game.Player2ID())
+		if err != nil {
+			return nil, err
+		}
+
+		return ResponseChain{
+			&EditMessageTextResponse{
+				ChatID:    chatID,
+				MessageID: messageID,
+				Text:      msgs.BattleshipFleetConfirmed(game.BoardOf(player.ID())),
+				ParseMode: "HTML",
+			},
+			&EditMessageTextResponse{
+				InlineMessageID: session.InlineMessageID().String(),
+				Text: msgs.BattleshipRound(
+					allGames,
+					game,
+					player1,
+					player2,
+					result.Scores[player1.ID()],
+					result.Scores[player2.ID()],
+					session.Bet(),
+				),
+				ParseMode:   "HTML",
+				ReplyMarkup: BuildBattleshipGameBoardKeyboard(&game, session.MoveDeadline(game.UpdatedAt())),
+			},
+			answer,
+		}, nil
+	}
+}
+
+// battleshipSession returns the session of the game.
+func battleshipSession(
+	ctx *th.Context,
+	unit uow.IUnitOfWork,
+	game battleship.Battleship,
+) (domainSession.Session, error) {
+	sessionGetter, err := unit.SessionRepo()
+	if err != nil {
+		return domainSession.Session{}, fmt.Errorf("failed to get game session repository: %w", err)
+	}
+	session, err := sessionGetter.SessionByID(ctx, game.SessionID())
+	if err != nil {
+		return domainSession.Session{}, fmt.Errorf("failed to get game session by ID: %w", err)
+	}
+	return session, nil
+}
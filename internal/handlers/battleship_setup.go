@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"fmt"
+	"log/slog"
+	"microgame-bot/internal/core/logger"
+	"microgame-bot/internal/domain/battleship"
+	"microgame-bot/internal/msgs"
+	"microgame-bot/internal/uow"
+	"microgame-bot/internal/utils"
+
+	"github.com/mymmrac/telego"
+	th "github.com/mymmrac/telego/telegohandler"
+)
+
+// BattleshipSetup sends the placement keyboard to the private chat of the player.
+// The fleet is never placed through the shared message, so the opponent can't see it.
+func BattleshipSetup(unit uow.IUnitOfWork) CallbackQueryHandlerFunc {
+	const operationName = "handler::battleship_setup"
+	return func(ctx *th.Context, query telego.CallbackQuery) (IResponse, error) {
+		slog.DebugContext(ctx, "Battleship setup callback received", logger.OperationField, operationName)
+
+		player, err := userFromContext(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user from context in %s: %w", operationName, err)
+		}
+
+		gameID, err := extractGameID[battleship.ID](query.Data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract game ID from callback data in %s: %w", operationName, err)
+		}
+
+		rawCtx := ctx.Context()
+		rawCtx = logger.WithLogValue(rawCtx, logger.GameIDField, utils.UUIDString(gameID))
+		ctx = ctx.WithContext(rawCtx)
+
+		gameRepo, err := unit.BattleshipRepo()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get game repository in %s: %w", operationName, err)
+		}
+
+		game, err := gameRepo.GameByID(ctx, gameID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get game by ID in %s: %w", operationName, err)
+		}
+		if err := game.CanPlace(player.ID()); err != nil {
+			return nil, err
+		}
+		if player.ChatID().IsZero() {
+			return nil, battleship.ErrChatRequired
+		}
+
+		return ResponseChain{
+			&SendMessageResponse{
+				ChatID:      int64(*player.ChatID()),
+				Text:        msgs.BattleshipPlacement(game.BoardOf(player.ID()), battleship.OrientationHorizontal),
+				ParseMode:   "HTML",
+				ReplyMarkup: buildBattleshipPlacementKeyboard(&game, player.ID(), battleship.OrientationHorizontal),
+			},
+			&CallbackQueryResponse{
+				CallbackQueryID: query.ID,
+				Text:            msgs.BattleshipSetupSent(),
+			},
+		}, nil
+	}
+}
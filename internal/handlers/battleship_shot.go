@@ -0,0 +1,256 @@
+package handlers
+
+import (
+	"fmt"
+	"log/slog"
+	"microgame-bot/internal/core/logger"
+	"microgame-bot/internal/domain"
+	"microgame-bot/internal/domain/battleship"
+	domainBet "microgame-bot/internal/domain/bet"
+	domainSession "microgame-bot/internal/domain/session"
+	domainUser "microgame-bot/internal/domain/user"
+	"microgame-bot/internal/msgs"
+	"microgame-bot/internal/queue"
+	userRepository "microgame-bot/internal/repo/user"
+	"microgame-bot/internal/uow"
+	"microgame-bot/internal/utils"
+
+	"github.com/mymmrac/telego"
+	th "github.com/mymmrac/telego/telegohandler"
+)
+
+// BattleshipShot fires at the cell of the opponent board from the shared message.
+// The answer only tells the result of the shot, the rest of the fleet stays hidden.
+func BattleshipShot(
+	userGetter userRepository.IUserGetter,
+	unit uow.IUnitOfWork,
+) CallbackQueryHandlerFunc {
+	const operationName = "handler::battleship_shot"
+	return func(ctx *th.Context, query telego.CallbackQuery) (IResponse, error) {
+		slog.DebugContext(ctx, "Battleship shot callback received", logger.OperationField, operationName)
+
+		player, err := userFromContext(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user from context in %s: %w", operationName, err)
+		}
+
+		gameID, err := extractGameID[battleship.ID](query.Data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract game ID from callback data in %s: %w", operationName, err)
+		}
+		cell, err := extractBattleshipCell(query.Data)
+		if err != nil {
+			return nil, err
+		}
+
+		rawCtx := ctx.Context()
+		rawCtx = logger.WithLogValue(rawCtx, logger.GameIDField, utils.UUIDString(gameID))
+		ctx = ctx.WithContext(rawCtx)
+
+		var game battleship.Battleship
+		var shot battleship.ShotResult
+		err = unit.Do(ctx, func(uow uow.IUnitOfWork) error {
+			gameRepo, err := uow.BattleshipRepo()
+			if err != nil {
+				return fmt.Errorf("failed to get game repository in %s: %w", operationName, err)
+			}
+			game, err = gameRepo.GameByIDLocked(ctx, gameID)
+			if err != nil {
+				return fmt.Errorf("failed to get game by ID with lock in %s: %w", operationName, err)
+			}
+
+			game, shot, err = game.Shoot(player.ID(), cell)
+			if err != nil {
+				return fmt.Errorf("failed to shoot in %s: %w", operationName, err)
+			}
+
+			game, err = gameRepo.UpdateGame(ctx, game)
+			if err != nil {
+				return fmt.Errorf("failed to update game in %s: %w", operationName, err)
+			}
+
+			if game.IsFinished() {
+				return nil
+			}
+			// The shot restarts the clock of the shooter, or of the opponent after a miss.
+			return scheduleGameMoveTimeout(ctx, uow, game.SessionID(), game.IDtoUUID(), game.UpdatedAt())
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed do transaction in %s: %w", operationName, err)
+		}
+
+		session, err := battleshipSession(ctx, unit, game)
+		if err != nil {
+			return nil, err
+		}
+
+		gameGetter, err := unit.BattleshipRepo()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get game repository in %s: %w", operationName, err)
+		}
+		allGames, err := gameGetter.GamesBySessionID(ctx, session.ID())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get games by session ID: %w", err)
+		}
+
+		games := make([]domainSession.IGame, len(allGames))
+		for i, g := range allGames {
+			games[i] = g
+		}
+
+		manager := domainSession.NewManager(session, games)
+		result := manager.CalculateResult()
+
+		player1, err := userGetter.UserByID(ctx, game.Player1ID())
+		if err != nil {
+			return nil, err
+		}
+
+		player2, err := userGetter.UserByID(ctx, game.Player2ID())
+		if err != nil {
+			return nil, err
+		}
+
+		shotAnswer := &CallbackQueryResponse{
+			CallbackQueryID: query.ID,
+			Text:            msgs.BattleshipShot(cell, shot),
+		}
+
+		if !game.IsFinished() {
+			return ResponseChain{
+				&EditMessageTextResponse{
+					InlineMessageID: query.InlineMessageID,
+					Text: msgs.BattleshipRound(
+						allGames,
+						game,
+						player1,
+						player2,
+						result.Scores[player1.ID()],
+						result.Scores[player2.ID()],
+						session.Bet(),
+					),
+					ParseMode:   "HTML",
+					ReplyMarkup: BuildBattleshipGameBoardKeyboard(&game, session.MoveDeadline(game.UpdatedAt())),
+				},
+				shotAnswer,
+			}, nil
+		}
+
+		// Sinking the whole fleet always has a winner, so the series can't end in a draw
+		if result.IsCompleted && len(result.SeriesWinners) > 0 {
+			err = unit.Do(ctx, func(uow uow.IUnitOfWork) error {
+				gsRepo, err := uow.SessionRepo()
+				if err != nil {
+					return fmt.Errorf("failed to get game session repository in %s: %w", operationName, err)
+				}
+				betRepo, err := uow.BetRepo()
+				if err != nil {
+					return fmt.Errorf("failed to get bet repository in %s: %w", operationName, err)
+				}
+
+				session, err = session.ChangeStatus(domain.GameStatusFinished)
+				if err != nil {
+					return fmt.Errorf("failed to change status of game session: %w", err)
+				}
+				session, err = gsRepo.UpdateSession(ctx, session)
+				if err != nil {
+					return fmt.Errorf("failed to update game session: %w", err)
+				}
+
+				// Update bets status: RUNNING -> WAITING
+				if session.Bet() > 0 {
+					err = betRepo.UpdateBetsStatusBatch(ctx, session.ID(), domainBet.StatusWaiting)
+					if err != nil {
+						return fmt.Errorf("failed to update bets status in %s: %w", operationName, err)
+					}
+					outbox, err := uow.Outbox()
+					if err != nil {
+						return fmt.Errorf("failed to get task outbox in %s: %w", operationName, err)
+					}
+					if err := queue.PublishPayoutTask(ctx, outbox); err != nil {
+						return fmt.Errorf("failed to publish payout task in %s: %w", operationName, err)
+					}
+				}
+
+				return nil
+			})
+			if err != nil {
+				return nil, uow.ErrFailedToDoTransaction(operationName, err)
+			}
+
+			var winner domainUser.User
+			if result.SeriesWinners[0] == player1.ID() {
+				winner = player1
+			} else {
+				winner = player2
+			}
+
+			return ResponseChain{
+				&EditMessageTextResponse{
+					InlineMessageID: query.InlineMessageID,
+					Text: msgs.BattleshipSeriesCompleted(
+						allGames,
+						player1,
+						player2,
+						result.Scores[player1.ID()],
+						result.Scores[player2.ID()],
+						winner,
+					),
+					ParseMode: "HTML",
+				},
+				shotAnswer,
+			}, nil
+		}
+
+		// The round is over but the series goes on, the next round starts with a new placement
+		nextGame := game
+		if result.NeedsNewRound {
+			err = unit.Do(ctx, func(uow uow.IUnitOfWork) error {
+				gameRepo, err := uow.BattleshipRepo()
+				if err != nil {
+					return fmt.Errorf("failed to get game repository in %s: %w", operationName, err)
+				}
+				nextGame, err = battleship.New(
+					battleship.WithNewID(),
+					battleship.WithSessionID(session.ID()),
+					battleship.WithCreatorID(game.CreatorID()),
+					battleship.WithPlayer1ID(game.Player1ID()),
+					battleship.WithPlayer2ID(game.Player2ID()),
+					battleship.WithStatus(domain.GameStatusInProgress),
+				)
+				if err != nil {
+					return fmt.Errorf("failed to create new game in %s: %w", operationName, err)
+				}
+
+				nextGame, err = gameRepo.CreateGame(ctx, nextGame)
+				if err != nil {
+					return fmt.Errorf("failed to store new game in %s: %w", operationName, err)
+				}
+
+				return scheduleMoveTimeout(ctx, uow, session, nextGame.IDtoUUID(), nextGame.UpdatedAt())
+			})
+			if err != nil {
+				return nil, uow.ErrFailedToDoTransaction(operationName, err)
+			}
+			allGames = append(allGames, nextGame)
+		}
+
+		return ResponseChain{
+			&EditMessageTextResponse{
+				InlineMessageID: query.InlineMessageID,
+				Text: msgs.BattleshipRound(
+					allGames,
+					nextGame,
+					player1,
+					player2,
+					result.Scores[player1.ID()],
+					result.Scores[player2.ID()],
+					session.Bet(),
+				),
+				ParseMode:   "HTML",
+				ReplyMarkup: BuildBattleshipGameBoardKeyboard(&nextGame, session.MoveDeadline(nextGame.UpdatedAt())),
+			},
+			shotAnswer,
+		}, nil
+	}
+}
@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"fmt"
+	"microgame-bot/internal/domain/blackjack"
+	"microgame-bot/internal/msgs"
+	"strings"
+	"time"
+
+	"github.com/mymmrac/telego"
+	tu "github.com/mymmrac/telego/telegoutil"
+)
+
+// BuildBlackjackGameBoardKeyboard creates inline keyboard with the decisions of the player.
+// Non-zero deadline is shown as a countdown below the buttons.
+func BuildBlackjackGameBoardKeyboard(game *blackjack.Blackjack, deadline time.Time) *telego.InlineKeyboardMarkup {
+	//nolint:mnd // Rows count is constant.
+	rows := make([][]telego.InlineKeyboardButton, 0, 3)
+	if game.IsFinished() {
+		return &telego.InlineKeyboardMarkup{
+			InlineKeyboard: rows,
+		}
+	}
+
+	rows = append(rows, []telego.InlineKeyboardButton{
+		{
+			Text:         "➕ Ещё",
+			CallbackData: "g::bj::" + blackjack.ActionHit.String() + "::" + game.ID().String(),
+		},
+		{
+			Text:         "✋ Хватит",
+			CallbackData: "g::bj::" + blackjack.ActionStand.String() + "::" + game.ID().String(),
+		},
+	})
+	if game.CanDouble() {
+		rows = append(rows, []telego.InlineKeyboardButton{
+			{
+				Text:         "✖️2 Удвоить",
+				CallbackData: "g::bj::" + blackjack.ActionDouble.String() + "::" + game.ID().String(),
+			},
+		})
+	}
+
+	if !deadline.IsZero() {
+		rows = append(rows, []telego.InlineKeyboardButton{
+			{
+				Text:         msgs.ClockLabel(deadline),
+				CallbackData: "empty",
+			},
+		})
+	}
+
+	return &telego.InlineKeyboardMarkup{
+		InlineKeyboard: rows,
+	}
+}
+
+// blackjackArticle offers a hand against the house, a blackjack session is always a single hand.
+func blackjackArticle(args gameArgs) telego.InlineQueryResult {
+	args.rounds = 1
+	msg := fmt.Sprintf(
+		"🎮 <b>🃏 Блэкджек</b>\n<i>%s</i>\n\nИграй против казино, блэкджек платит 3:2. Нажми кнопку, чтобы сесть за стол!",
+		args.label(),
+	)
+	return tu.ResultArticle(
+		"game::bj",
+		"🃏 Блэкджек "+args.label(),
+		tu.TextMessage(msg).WithParseMode("HTML"),
+	).WithReplyMarkup(tu.InlineKeyboard(
+		tu.InlineKeyboardRow(
+			tu.InlineKeyboardButton("🃏 Раздать").
+				WithCallbackData("create::bj::" + args.callbackData()),
+		),
+	))
+}
+
+// Extracts the decision of the player from the callback data: g::bj::<action>::<game id>.
+func extractBlackjackAction(callbackData string) (blackjack.Action, error) {
+	parts := strings.Split(callbackData, "::")
+	//nolint:mnd // Callback data params is constant.
+	if len(parts) < 3 {
+		return "", blackjack.ErrInvalidAction
+	}
+	return blackjack.ActionFromString(parts[2])
+}
@@ -0,0 +1,166 @@
+package handlers
+
+import (
+	"fmt"
+	"log/slog"
+
+	"microgame-bot/internal/core/logger"
+	"microgame-bot/internal/domain"
+	domainBet "microgame-bot/internal/domain/bet"
+	"microgame-bot/internal/domain/blackjack"
+	domainSession "microgame-bot/internal/domain/session"
+	"microgame-bot/internal/msgs"
+	"microgame-bot/internal/queue"
+	"microgame-bot/internal/uow"
+
+	"github.com/mymmrac/telego"
+	th "github.com/mymmrac/telego/telegohandler"
+)
+
+// BlackjackCreate seats the player at the table and deals the hand right away.
+// There is nobody to wait for, the stake is taken and the session starts at once.
+func BlackjackCreate(
+	unit uow.IUnitOfWork,
+) CallbackQueryHandlerFunc {
+	const operationName = "handlers::blackjack_create"
+	l := slog.With(slog.String(logger.OperationField, operationName))
+	return func(ctx *th.Context, query telego.CallbackQuery) (IResponse, error) {
+		l.DebugContext(ctx, "Create blackjack game callback received")
+
+		user, err := userFromContext(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user from context in %s: %w", operationName, err)
+		}
+
+		inlineMessageID, err := inlineMessageIDFromContext(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get inline message ID from context in %s: %w", operationName, err)
+		}
+
+		betAmount := extractBetAmount(query.Data, domainBet.MaxBet)
+		moveTimeout := extractMoveTimeout(query.Data)
+
+		session, err := domainSession.New(
+			domainSession.WithNewID(),
+			domainSession.WithGameType(domain.GameTypeBlackjack),
+			domainSession.WithInlineMessageID(inlineMessageID),
+			domainSession.WithGameCount(1),
+			domainSession.WithBet(betAmount),
+			domainSession.WithWinCondition(domainSession.WinConditionFirstTo),
+			domainSession.WithMoveTimeout(moveTimeout),
+			domainSession.WithStatus(domain.GameStatusInProgress),
+			domainSession.WithNewSeed(),
+		)
+		if err != nil {
+			return nil, err
+		}
+		game, err := blackjack.New(
+			blackjack.WithNewID(),
+			blackjack.WithCreatorID(user.ID()),
+			blackjack.WithSessionID(session.ID()),
+			blackjack.WithSeed(session.Seed()),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create blackjack game in %s: %w", operationName, err)
+		}
+		game, err = game.Deal()
+		if err != nil {
+			return nil, fmt.Errorf("failed to deal blackjack hand in %s: %w", operationName, err)
+		}
+		if game.IsFinished() {
+			session, err = session.ChangeStatus(domain.GameStatusFinished)
+			if err != nil {
+				return nil, fmt.Errorf("failed to change status of game session: %w", err)
+			}
+		}
+
+		err = unit.Do(ctx, func(unit uow.IUnitOfWork) error {
+			sR, err := unit.SessionRepo()
+			if err != nil {
+				return err
+			}
+			gR, err := unit.BlackjackRepo()
+			if err != nil {
+				return err
+			}
+			betRepo, err := unit.BetRepo()
+			if err != nil {
+				return fmt.Errorf("failed to get bet repository in %s: %w", operationName, err)
+			}
+			session, err = sR.CreateSession(ctx, session)
+			if err != nil {
+				return err
+			}
+
+			err = processPlayerBet(ctx, unit, user.ID(), session.ID(), session.Bet(), operationName)
+			if err != nil {
+				return err
+			}
+
+			game, err = gR.CreateGame(ctx, game)
+			if err != nil {
+				return err
+			}
+
+			if session.Bet() > 0 {
+				// A natural settles the hand on the deal, the bet goes straight to the payout.
+				status := domainBet.StatusRunning
+				if game.IsFinished() {
+					status = domainBet.StatusWaiting
+				}
+				err = betRepo.UpdateBetsStatusBatch(ctx, session.ID(), status)
+				if err != nil {
+					return fmt.Errorf("failed to update bets status in %s: %w", operationName, err)
+				}
+				if game.IsFinished() {
+					outbox, err := unit.Outbox()
+					if err != nil {
+						return fmt.Errorf("failed to get task outbox in %s: %w", operationName, err)
+					}
+					if err := queue.PublishPayoutTask(ctx, outbox); err != nil {
+						return fmt.Errorf("failed to publish payout task in %s: %w", operationName, err)
+					}
+				}
+			}
+			if game.IsFinished() {
+				return nil
+			}
+			return scheduleMoveTimeout(ctx, unit, session, game.IDtoUUID(), game.UpdatedAt())
+		})
+		if err != nil {
+			return nil, uow.ErrFailedToDoTransaction(operationName, err)
+		}
+
+		if game.IsFinished() {
+			return ResponseChain{
+				&EditMessageTextResponse{
+					InlineMessageID: query.InlineMessageID,
+					Text: msgs.BlackjackFinished(
+						game,
+						user,
+						session.Bet(),
+						game.Payout(session.Bet()),
+						session.Seed(),
+					),
+					ParseMode: "HTML",
+				},
+				&CallbackQueryResponse{
+					CallbackQueryID: query.ID,
+				},
+			}, nil
+		}
+
+		return ResponseChain{
+			&EditMessageTextResponse{
+				InlineMessageID: query.InlineMessageID,
+				Text:            msgs.BlackjackTable(game, user, session.Bet(), session.SeedHash()),
+				ParseMode:       "HTML",
+				ReplyMarkup:     BuildBlackjackGameBoardKeyboard(&game, session.MoveDeadline(game.UpdatedAt())),
+			},
+			&CallbackQueryResponse{
+				CallbackQueryID: query.ID,
+				Text:            "Карты розданы!",
+			},
+		}, nil
+	}
+}
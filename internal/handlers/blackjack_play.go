@@ -0,0 +1,169 @@
+package handlers
+
+import (
+	"fmt"
+	"log/slog"
+	"microgame-bot/internal/core/logger"
+	"microgame-bot/internal/domain"
+	domainBet "microgame-bot/internal/domain/bet"
+	"microgame-bot/internal/domain/blackjack"
+	domainSession "microgame-bot/internal/domain/session"
+	"microgame-bot/internal/msgs"
+	"microgame-bot/internal/queue"
+	"microgame-bot/internal/uow"
+	"microgame-bot/internal/utils"
+
+	"github.com/mymmrac/telego"
+	th "github.com/mymmrac/telego/telegohandler"
+)
+
+// BlackjackPlay applies the decision of the player: hit, stand or double.
+// Doubling takes the second stake with the same locked balance check as the first one.
+func BlackjackPlay(
+	unit uow.IUnitOfWork,
+) CallbackQueryHandlerFunc {
+	const operationName = "handler::blackjack_play"
+	return func(ctx *th.Context, query telego.CallbackQuery) (IResponse, error) {
+		slog.DebugContext(ctx, "Blackjack play callback received", logger.OperationField, operationName)
+
+		player, err := userFromContext(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user from context in %s: %w", operationName, err)
+		}
+
+		gameID, err := extractGameID[blackjack.ID](query.Data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract game ID from callback data in %s: %w", operationName, err)
+		}
+
+		action, err := extractBlackjackAction(query.Data)
+		if err != nil {
+			return nil, err
+		}
+
+		rawCtx := ctx.Context()
+		rawCtx = logger.WithLogValue(rawCtx, logger.GameIDField, utils.UUIDString(gameID))
+		ctx = ctx.WithContext(rawCtx)
+
+		var game blackjack.Blackjack
+		var session domainSession.Session
+		err = unit.Do(ctx, func(uow uow.IUnitOfWork) error {
+			gameRepo, err := uow.BlackjackRepo()
+			if err != nil {
+				return fmt.Errorf("failed to get game repository in %s: %w", operationName, err)
+			}
+			gsRepo, err := uow.SessionRepo()
+			if err != nil {
+				return fmt.Errorf("failed to get game session repository in %s: %w", operationName, err)
+			}
+			betRepo, err := uow.BetRepo()
+			if err != nil {
+				return fmt.Errorf("failed to get bet repository in %s: %w", operationName, err)
+			}
+
+			game, err = gameRepo.GameByIDLocked(ctx, gameID)
+			if err != nil {
+				return fmt.Errorf("failed to get game by ID with lock in %s: %w", operationName, err)
+			}
+			session, err = gsRepo.SessionByIDLocked(ctx, game.SessionID())
+			if err != nil {
+				return fmt.Errorf("failed to get game session by ID with lock in %s: %w", operationName, err)
+			}
+
+			switch action {
+			case blackjack.ActionHit:
+				game, err = game.Hit(player.ID())
+			case blackjack.ActionStand:
+				game, err = game.Stand(player.ID())
+			case blackjack.ActionDouble:
+				if err = game.CanAct(player.ID()); err != nil {
+					return err
+				}
+				if !game.CanDouble() {
+					return blackjack.ErrCantDouble
+				}
+				err = processPlayerBet(ctx, uow, player.ID(), session.ID(), session.Bet(), operationName)
+				if err != nil {
+					return err
+				}
+				game, err = game.Double(player.ID())
+			}
+			if err != nil {
+				return err
+			}
+
+			game, err = gameRepo.UpdateGame(ctx, game)
+			if err != nil {
+				return fmt.Errorf("failed to update game in %s: %w", operationName, err)
+			}
+
+			if !game.IsFinished() {
+				return scheduleMoveTimeout(ctx, uow, session, game.IDtoUUID(), game.UpdatedAt())
+			}
+
+			session, err = session.ChangeStatus(domain.GameStatusFinished)
+			if err != nil {
+				return fmt.Errorf("failed to change status of game session: %w", err)
+			}
+			session, err = gsRepo.UpdateSession(ctx, session)
+			if err != nil {
+				return fmt.Errorf("failed to update game session: %w", err)
+			}
+
+			// Update bets status: RUNNING -> WAITING, the pending stake of a double goes along
+			if session.Bet() > 0 {
+				err = betRepo.UpdateBetsStatusBatch(ctx, session.ID(), domainBet.StatusWaiting)
+				if err != nil {
+					return fmt.Errorf("failed to update bets status in %s: %w", operationName, err)
+				}
+				outbox, err := uow.Outbox()
+				if err != nil {
+					return fmt.Errorf("failed to get task outbox in %s: %w", operationName, err)
+				}
+				if err := queue.PublishPayoutTask(ctx, outbox); err != nil {
+					return fmt.Errorf("failed to publish payout task in %s: %w", operationName, err)
+				}
+			}
+
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed do transaction in %s: %w", operationName, err)
+		}
+
+		answer := &CallbackQueryResponse{
+			CallbackQueryID: query.ID,
+		}
+		if action != blackjack.ActionStand {
+			hand := game.PlayerHand()
+			answer.Text = msgs.BlackjackCardDrawn(hand[len(hand)-1])
+		}
+
+		if !game.IsFinished() {
+			return ResponseChain{
+				&EditMessageTextResponse{
+					InlineMessageID: query.InlineMessageID,
+					Text:            msgs.BlackjackTable(game, player, session.Bet(), session.SeedHash()),
+					ParseMode:       "HTML",
+					ReplyMarkup:     BuildBlackjackGameBoardKeyboard(&game, session.MoveDeadline(game.UpdatedAt())),
+				},
+				answer,
+			}, nil
+		}
+
+		staked := session.Bet()
+		if game.Doubled() {
+			//nolint:mnd // Doubling puts a second stake on the hand.
+			staked *= 2
+		}
+
+		return ResponseChain{
+			&EditMessageTextResponse{
+				InlineMessageID: query.InlineMessageID,
+				Text:            msgs.BlackjackFinished(game, player, session.Bet(), game.Payout(staked), session.Seed()),
+				ParseMode:       "HTML",
+			},
+			answer,
+		}, nil
+	}
+}
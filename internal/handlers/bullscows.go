@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"fmt"
+	"microgame-bot/internal/domain/bullscows"
+	domainUser "microgame-bot/internal/domain/user"
+	"microgame-bot/internal/msgs"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mymmrac/telego"
+	tu "github.com/mymmrac/telego/telegoutil"
+)
+
+// BuildBullsCowsGameBoardKeyboard creates the keyboard of the shared message.
+// While the secrets are set it only has the button sending the secret keypad to the private chat.
+// Then it is the keypad of the player to guess with the digits typed so far on top.
+// Non-zero deadline is shown as a countdown below the keypad.
+func BuildBullsCowsGameBoardKeyboard(game *bullscows.BullsCows, deadline time.Time) *telego.InlineKeyboardMarkup {
+	//nolint:mnd // Draft row, four keypad rows and the clock row.
+	rows := make([][]telego.InlineKeyboardButton, 0, 6)
+	if game.IsFinished() {
+		return &telego.InlineKeyboardMarkup{
+			InlineKeyboard: rows,
+		}
+	}
+
+	if game.IsSetting() {
+		rows = append(rows, []telego.InlineKeyboardButton{
+			{
+				Text:         "🔐 Загадать число",
+				CallbackData: "g::bc::setup::" + game.ID().String(),
+			},
+		})
+	} else {
+		rows = append(rows, buildBullsCowsKeypad(game.ID(), game.DraftOf(game.Turn()), "")...)
+	}
+
+	if !deadline.IsZero() {
+		rows = append(rows, []telego.InlineKeyboardButton{
+			{
+				Text:         msgs.ClockLabel(deadline),
+				CallbackData: "empty",
+			},
+		})
+	}
+
+	return &telego.InlineKeyboardMarkup{
+		InlineKeyboard: rows,
+	}
+}
+
+// buildBullsCowsSecretKeyboard creates the private keypad the player types the secret on.
+func buildBullsCowsSecretKeyboard(game *bullscows.BullsCows, playerID domainUser.ID) *telego.InlineKeyboardMarkup {
+	rows := buildBullsCowsKeypad(game.ID(), game.DraftOf(playerID), "s")
+	rows = append(rows, tu.InlineKeyboardRow(
+		tu.InlineKeyboardButton("🎲 Случайно").WithCallbackData("g::bc::srandom::"+game.ID().String()),
+	))
+	return &telego.InlineKeyboardMarkup{
+		InlineKeyboard: rows,
+	}
+}
+
+// buildBullsCowsKeypad creates the draft row and the numeric keypad. Digits already typed are disabled,
+// the submit button appears once the number is complete. The prefix tells the secret keypad from the guess one.
+func buildBullsCowsKeypad(gameID bullscows.ID, draft bullscows.Number, prefix string) [][]telego.InlineKeyboardButton {
+	//nolint:mnd // Draft row and four keypad rows.
+	rows := make([][]telego.InlineKeyboardButton, 0, 5)
+	rows = append(rows, tu.InlineKeyboardRow(
+		tu.InlineKeyboardButton(msgs.BullsCowsDraft(draft)).WithCallbackData("empty"),
+	))
+
+	digitButton := func(digit int) telego.InlineKeyboardButton {
+		if draft.Contains(digit) || draft.IsComplete() {
+			return tu.InlineKeyboardButton("·").WithCallbackData("empty")
+		}
+		return tu.InlineKeyboardButton(strconv.Itoa(digit)).
+			WithCallbackData(fmt.Sprintf("g::bc::%sdigit::%s::%d", prefix, gameID, digit))
+	}
+	//nolint:mnd // Keypad rows 1-2-3, 4-5-6 and 7-8-9.
+	for row := range 3 {
+		rows = append(rows, tu.InlineKeyboardRow(
+			digitButton(row*3+1),
+			digitButton(row*3+2),
+			digitButton(row*3+3),
+		))
+	}
+
+	submit := tu.InlineKeyboardButton("·").WithCallbackData("empty")
+	if draft.IsComplete() {
+		submit = tu.InlineKeyboardButton("✅").WithCallbackData("g::bc::" + prefix + "submit::" + gameID.String())
+	}
+	rows = append(rows, tu.InlineKeyboardRow(
+		tu.InlineKeyboardButton("⌫").WithCallbackData("g::bc::"+prefix+"erase::"+gameID.String()),
+		digitButton(0),
+		submit,
+	))
+
+	return rows
+}
+
+// buildBullsCowsWaitingKeyboard creates inline keyboard for a game waiting for players.
+func buildBullsCowsWaitingKeyboard(game *bullscows.BullsCows) *telego.InlineKeyboardMarkup {
+	return tu.InlineKeyboard(
+		tu.InlineKeyboardRow(
+			tu.InlineKeyboardButton("Присоединиться").
+				WithCallbackData("g::bc::join::"+game.ID().String()),
+		),
+		tu.InlineKeyboardRow(
+			tu.InlineKeyboardButton("❌ Отменить").
+				WithCallbackData("g::bc::cancel::"+game.ID().String()),
+		),
+	)
+}
+
+// bullsCowsArticle offers the bulls and cows game with the series settings of the query.
+func bullsCowsArticle(args gameArgs) telego.InlineQueryResult {
+	msg := fmt.Sprintf(
+		"🎮 <b>🐂 Быки и коровы</b>\n<i>%s</i>\n\nЧисла загадываются втайне, угадывание идёт здесь. "+
+			"Нажми кнопку, чтобы начать игру!",
+		args.label(),
+	)
+	return tu.ResultArticle(
+		"game::bc",
+		"🐂 Быки и коровы "+args.label(),
+		tu.TextMessage(msg).WithParseMode("HTML"),
+	).WithReplyMarkup(tu.InlineKeyboard(
+		tu.InlineKeyboardRow(
+			tu.InlineKeyboardButton("🎯 Начать игру").
+				WithCallbackData("create::bc::" + args.callbackData()),
+		),
+	))
+}
+
+// Extracts the keypad action from the callback data: g::bc::<action>::<game id>.
+func extractBullsCowsAction(callbackData string) string {
+	parts := strings.Split(callbackData, "::")
+	//nolint:mnd // Callback data params is constant.
+	if len(parts) < 3 {
+		return ""
+	}
+	return parts[2]
+}
+
+// Extracts the digit from the callback data: g::bc::<digit action>::<game id>::<digit>.
+func extractBullsCowsDigit(callbackData string) (int, error) {
+	parts := strings.Split(callbackData, "::")
+	//nolint:mnd // Callback data params is constant.
+	if len(parts) < 5 {
+		return 0, ErrInvalidCallbackData
+	}
+	//nolint:mnd // Callback data params is constant.
+	digit, err := strconv.Atoi(parts[4])
+	if err != nil {
+		return 0, bullscows.ErrInvalidDigit
+	}
+	return digit, nil
+}
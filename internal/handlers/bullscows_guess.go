@@ -0,0 +1,294 @@
+package handlers
+
+import (
+	"fmt"
+	"log/slog"
+	"microgame-bot/internal/core/logger"
+	"microgame-bot/internal/domain"
+	domainBet "microgame-bot/internal/domain/bet"
+	"microgame-bot/internal/domain/bullscows"
+	domainSession "microgame-bot/internal/domain/session"
+	domainUser "microgame-bot/internal/domain/user"
+	"microgame-bot/internal/msgs"
+	"microgame-bot/internal/queue"
+	userRepository "microgame-bot/internal/repo/user"
+	"microgame-bot/internal/uow"
+	"microgame-bot/internal/utils"
+
+	"github.com/mymmrac/telego"
+	th "github.com/mymmrac/telego/telegohandler"
+)
+
+// BullsCowsGuess handles the keypad of the shared message: the player to guess types the number
+// digit by digit and submits it. The answer only tells the bulls and cows, the secret stays hidden.
+func BullsCowsGuess(
+	userGetter userRepository.IUserGetter,
+	unit uow.IUnitOfWork,
+) CallbackQueryHandlerFunc {
+	const operationName = "handler::bullscows_guess"
+	return func(ctx *th.Context, query telego.CallbackQuery) (IResponse, error) {
+		slog.DebugContext(ctx, "Bulls and cows guess callback received", logger.OperationField, operationName)
+
+		player, err := userFromContext(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user from context in %s: %w", operationName, err)
+		}
+
+		gameID, err := extractGameID[bullscows.ID](query.Data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract game ID from callback data in %s: %w", operationName, err)
+		}
+		action := extractBullsCowsAction(query.Data)
+		var digit int
+		if action == "digit" {
+			digit, err = extractBullsCowsDigit(query.Data)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		rawCtx := ctx.Context()
+		rawCtx = logger.WithLogValue(rawCtx, logger.GameIDField, utils.UUIDString(gameID))
+		ctx = ctx.WithContext(rawCtx)
+
+		var game bullscows.BullsCows
+		var guess bullscows.Guess
+		err = unit.Do(ctx, func(uow uow.IUnitOfWork) error {
+			gameRepo, err := uow.BullsCowsRepo()
+			if err != nil {
+				return fmt.Errorf("failed to get game repository in %s: %w", operationName, err)
+			}
+			game, err = gameRepo.GameByIDLocked(ctx, gameID)
+			if err != nil {
+				return fmt.Errorf("failed to get game by ID with lock in %s: %w", operationName, err)
+			}
+
+			switch action {
+			case "digit":
+				game, err = game.TypeDigit(player.ID(), digit)
+			case "erase":
+				game, err = game.EraseDigit(player.ID())
+			case "submit":
+				game, guess, err = game.Guess(player.ID(), game.DraftOf(player.ID()))
+			default:
+				err = ErrInvalidCallbackData
+			}
+			if err != nil {
+				return err
+			}
+
+			game, err = gameRepo.UpdateGame(ctx, game)
+			if err != nil {
+				return fmt.Errorf("failed to update game in %s: %w", operationName, err)
+			}
+
+			if game.IsFinished() {
+				return nil
+			}
+			// Typing updates the game too, so the pending move timeout is scheduled again
+			return scheduleGameMoveTimeout(ctx, uow, game.SessionID(), game.IDtoUUID(), game.UpdatedAt())
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		session, err := bullsCowsSession(ctx, unit, game)
+		if err != nil {
+			return nil, err
+		}
+
+		if action != "submit" {
+			return ResponseChain{
+				&EditMessageReplyMarkupResponse{
+					InlineMessageID: query.InlineMessageID,
+					ReplyMarkup:     BuildBullsCowsGameBoardKeyboard(&game, session.MoveDeadline(game.UpdatedAt())),
+				},
+				&CallbackQueryResponse{CallbackQueryID: query.ID},
+			}, nil
+		}
+
+		gameGetter, err := unit.BullsCowsRepo()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get game repository in %s: %w", operationName, err)
+		}
+		allGames, err := gameGetter.GamesBySessionID(ctx, session.ID())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get games by session ID: %w", err)
+		}
+
+		games := make([]domainSession.IGame, len(allGames))
+		for i, g := range allGames {
+			games[i] = g
+		}
+
+		manager := domainSession.NewManager(session, games)
+		result := manager.CalculateResult()
+
+		player1, err := userGetter.UserByID(ctx, game.Player1ID())
+		if err != nil {
+			return nil, err
+		}
+
+		player2, err := userGetter.UserByID(ctx, game.Player2ID())
+		if err != nil {
+			return nil, err
+		}
+
+		guessAnswer := &CallbackQueryResponse{
+			CallbackQueryID: query.ID,
+			Text:            msgs.BullsCowsGuessed(guess),
+		}
+
+		if !game.IsFinished() {
+			return ResponseChain{
+				&EditMessageTextResponse{
+					InlineMessageID: query.InlineMessageID,
+					Text: msgs.BullsCowsRound(
+						allGames,
+						game,
+						player1,
+						player2,
+						result.Scores[player1.ID()],
+						result.Scores[player2.ID()],
+						result.Draws,
+						session.Bet(),
+					),
+					ParseMode:   "HTML",
+					ReplyMarkup: BuildBullsCowsGameBoardKeyboard(&game, session.MoveDeadline(game.UpdatedAt())),
+				},
+				guessAnswer,
+			}, nil
+		}
+
+		if result.IsCompleted {
+			err = unit.Do(ctx, func(uow uow.IUnitOfWork) error {
+				gsRepo, err := uow.SessionRepo()
+				if err != nil {
+					return fmt.Errorf("failed to get game session repository in %s: %w", operationName, err)
+				}
+				betRepo, err := uow.BetRepo()
+				if err != nil {
+					return fmt.Errorf("failed to get bet repository in %s: %w", operationName, err)
+				}
+
+				session, err = session.ChangeStatus(domain.GameStatusFinished)
+				if err != nil {
+					return fmt.Errorf("failed to change status of game session: %w", err)
+				}
+				session, err = gsRepo.UpdateSession(ctx, session)
+				if err != nil {
+					return fmt.Errorf("failed to update game session: %w", err)
+				}
+
+				// Update bets status: RUNNING -> WAITING
+				if session.Bet() > 0 {
+					err = betRepo.UpdateBetsStatusBatch(ctx, session.ID(), domainBet.StatusWaiting)
+					if err != nil {
+						return fmt.Errorf("failed to update bets status in %s: %w", operationName, err)
+					}
+					outbox, err := uow.Outbox()
+					if err != nil {
+						return fmt.Errorf("failed to get task outbox in %s: %w", operationName, err)
+					}
+					if err := queue.PublishPayoutTask(ctx, outbox); err != nil {
+						return fmt.Errorf("failed to publish payout task in %s: %w", operationName, err)
+					}
+				}
+
+				return nil
+			})
+			if err != nil {
+				return nil, uow.ErrFailedToDoTransaction(operationName, err)
+			}
+
+			var msg string
+			if result.IsDraw {
+				msg = msgs.BullsCowsSeriesDraw(
+					allGames,
+					player1,
+					player2,
+					result.Scores[player1.ID()],
+					result.Scores[player2.ID()],
+					result.Draws,
+				)
+			} else {
+				var winner domainUser.User
+				if result.SeriesWinners[0] == player1.ID() {
+					winner = player1
+				} else {
+					winner = player2
+				}
+				msg = msgs.BullsCowsSeriesCompleted(
+					allGames,
+					player1,
+					player2,
+					result.Scores[player1.ID()],
+					result.Scores[player2.ID()],
+					result.Draws,
+					winner,
+				)
+			}
+
+			return ResponseChain{
+				&EditMessageTextResponse{
+					InlineMessageID: query.InlineMessageID,
+					Text:            msg,
+					ParseMode:       "HTML",
+				},
+				guessAnswer,
+			}, nil
+		}
+
+		// The round is over but the series goes on, the next round starts with new secrets
+		nextGame := game
+		if result.NeedsNewRound {
+			err = unit.Do(ctx, func(uow uow.IUnitOfWork) error {
+				gameRepo, err := uow.BullsCowsRepo()
+				if err != nil {
+					return fmt.Errorf("failed to get game repository in %s: %w", operationName, err)
+				}
+				nextGame, err = bullscows.New(
+					bullscows.WithNewID(),
+					bullscows.WithSessionID(session.ID()),
+					bullscows.WithCreatorID(game.CreatorID()),
+					bullscows.WithPlayer1ID(game.Player1ID()),
+					bullscows.WithPlayer2ID(game.Player2ID()),
+					bullscows.WithStatus(domain.GameStatusInProgress),
+				)
+				if err != nil {
+					return fmt.Errorf("failed to create new game in %s: %w", operationName, err)
+				}
+
+				nextGame, err = gameRepo.CreateGame(ctx, nextGame)
+				if err != nil {
+					return fmt.Errorf("failed to store new game in %s: %w", operationName, err)
+				}
+
+				return scheduleMoveTimeout(ctx, uow, session, nextGame.IDtoUUID(), nextGame.UpdatedAt())
+			})
+			if err != nil {
+				return nil, uow.ErrFailedToDoTransaction(operationName, err)
+			}
+			allGames = append(allGames, nextGame)
+		}
+
+		return ResponseChain{
+			&EditMessageTextResponse{
+				InlineMessageID: query.InlineMessageID,
+				Text: msgs.BullsCowsRound(
+					allGames,
+					nextGame,
+					player1,
+					player2,
+					result.Scores[player1.ID()],
+					result.Scores[player2.ID()],
+					result.Draws,
+					session.Bet(),
+				),
+				ParseMode:   "HTML",
+				ReplyMarkup: BuildBullsCowsGameBoardKeyboard(&nextGame, session.MoveDeadline(nextGame.UpdatedAt())),
+			},
+			guessAnswer,
+		}, nil
+	}
+}
@@ -0,0 +1,184 @@
+package handlers
+
+import (
+	"fmt"
+	"log/slog"
+	"microgame-bot/internal/core/logger"
+	"microgame-bot/internal/domain/bullscows"
+	domainSession "microgame-bot/internal/domain/session"
+	domainUser "microgame-bot/internal/domain/user"
+	"microgame-bot/internal/msgs"
+	userRepository "microgame-bot/internal/repo/user"
+	"microgame-bot/internal/uow"
+	"microgame-bot/internal/utils"
+
+	"github.com/mymmrac/telego"
+	th "github.com/mymmrac/telego/telegohandler"
+)
+
+// BullsCowsSecret handles the secret keypad in the private chat of the player:
+// typing and erasing digits, a random number and the secret confirmation.
+// Once the secret is set the shared message is updated, it only learns that the player is ready.
+func BullsCowsSecret(
+	userGetter userRepository.IUserGetter,
+	unit uow.IUnitOfWork,
+) CallbackQueryHandlerFunc {
+	const operationName = "handler::bullscows_secret"
+	return func(ctx *th.Context, query telego.CallbackQuery) (IResponse, error) {
+		slog.DebugContext(ctx, "Bulls and cows secret callback received", logger.OperationField, operationName)
+
+		if query.Message == nil || !query.Message.IsAccessible() {
+			return nil, ErrInvalidCallbackData
+		}
+		chatID := query.Message.GetChat().ID
+		messageID := query.Message.GetMessageID()
+
+		player, err := userFromContext(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user from context in %s: %w", operationName, err)
+		}
+
+		gameID, err := extractGameID[bullscows.ID](query.Data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract game ID from callback data in %s: %w", operationName, err)
+		}
+		action := extractBullsCowsAction(query.Data)
+		var digit int
+		if action == "sdigit" {
+			digit, err = extractBullsCowsDigit(query.Data)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		rawCtx := ctx.Context()
+		rawCtx = logger.WithLogValue(rawCtx, logger.GameIDField, utils.UUIDString(gameID))
+		ctx = ctx.WithContext(rawCtx)
+
+		var game bullscows.BullsCows
+		var secret bullscows.Number
+		err = unit.Do(ctx, func(uow uow.IUnitOfWork) error {
+			gameRepo, err := uow.BullsCowsRepo()
+			if err != nil {
+				return fmt.Errorf("failed to get game repository in %s: %w", operationName, err)
+			}
+			game, err = gameRepo.GameByIDLocked(ctx, gameID)
+			if err != nil {
+				return fmt.Errorf("failed to get game by ID with lock in %s: %w", operationName, err)
+			}
+
+			switch action {
+			case "sdigit":
+				game, err = game.TypeDigit(player.ID(), digit)
+			case "serase":
+				game, err = game.EraseDigit(player.ID())
+			case "srandom":
+				game, err = game.DraftRandomly(player.ID(), utils.RandInt)
+			case "ssubmit":
+				secret = game.DraftOf(player.ID())
+				game, err = game.SetSecret(player.ID(), secret)
+			default:
+				err = ErrInvalidCallbackData
+			}
+			if err != nil {
+				return err
+			}
+
+			game, err = gameRepo.UpdateGame(ctx, game)
+			if err != nil {
+				return fmt.Errorf("failed to update game in %s: %w", operationName, err)
+			}
+
+			// Every update of the game invalidates the pending move timeout, so it is scheduled again
+			return scheduleGameMoveTimeout(ctx, uow, game.SessionID(), game.IDtoUUID(), game.UpdatedAt())
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		session, err := bullsCowsSession(ctx, unit, game)
+		if err != nil {
+			return nil, err
+		}
+
+		answer := &CallbackQueryResponse{CallbackQueryID: query.ID}
+		if action != "ssubmit" {
+			return ResponseChain{
+				&EditMessageTextResponse{
+					ChatID:      chatID,
+					MessageID:   messageID,
+					Text:        msgs.BullsCowsSecretPrompt(),
+					ParseMode:   "HTML",
+					ReplyMarkup: buildBullsCowsSecretKeyboard(&game, player.ID()),
+				},
+				answer,
+			}, nil
+		}
+
+		gameGetter, err := unit.BullsCowsRepo()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get game repository in %s: %w", operationName, err)
+		}
+		allGames, err := gameGetter.GamesBySessionID(ctx, session.ID())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get games by session ID in %s: %w", operationName, err)
+		}
+		games := make([]domainSession.IGame, len(allGames))
+		for i, g := range allGames {
+			games[i] = g
+		}
+		result := domainSession.NewManager(session, games).CalculateResult()
+
+		var player1, player2 domainUser.User
+		player1, err = userGetter.UserByID(ctx, game.Player1ID())
+		if err != nil {
+			return nil, err
+		}
+		player2, err = userGetter.UserByID(ctx, game.Player2ID())
+		if err != nil {
+			return nil, err
+		}
+
+		return ResponseChain{
+			&EditMessageTextResponse{
+				ChatID:    chatID,
+				MessageID: messageID,
+				Text:      msgs.BullsCowsSecretSet(secret),
+				ParseMode: "HTML",
+			},
+			&EditMessageTextResponse{
+				InlineMessageID: session.InlineMessageID().String(),
+				Text: msgs.BullsCowsRound(
+					allGames,
+					game,
+					player1,
+					player2,
+					result.Scores[player1.ID()],
+					result.Scores[player2.ID()],
+					result.Draws,
+					session.Bet(),
+				),
+				ParseMode:   "HTML",
+				ReplyMarkup: BuildBullsCowsGameBoardKeyboard(&game, session.MoveDeadline(game.UpdatedAt())),
+			},
+			answer,
+		}, nil
+	}
+}
+
+// bullsCowsSession returns the session of the game.
+func bullsCowsSession(
+	ctx *th.Context,
+	unit uow.IUnitOfWork,
+	game bullscows.BullsCows,
+) (domainSession.Session, error) {
+	sessionGetter, err := unit.SessionRepo()
+	if err != nil {
+		return domainSession.Session{}, fmt.Errorf("failed to get game session repository: %w", err)
+	}
+	session, err := sessionGetter.SessionByID(ctx, game.SessionID())
+	if err != nil {
+		return domainSession.Session{}, fmt.Errorf("failed to get game session by ID: %w", err)
+	}
+	return session, nil
+}
@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"fmt"
+	"log/slog"
+	"microgame-bot/internal/core/logger"
+	"microgame-bot/internal/domain/bullscows"
+	"microgame-bot/internal/msgs"
+	"microgame-bot/internal/uow"
+	"microgame-bot/internal/utils"
+
+	"github.com/mymmrac/telego"
+	th "github.com/mymmrac/telego/telegohandler"
+)
+
+// BullsCowsSetup sends the secret keypad to the private chat of the player.
+// The secret is never typed in the shared message, so the opponent can't see it.
+func BullsCowsSetup(unit uow.IUnitOfWork) CallbackQueryHandlerFunc {
+	const operationName = "handler::bullscows_setup"
+	return func(ctx *th.Context, query telego.CallbackQuery) (IResponse, error) {
+		slog.DebugContext(ctx, "Bulls and cows setup callback received", logger.OperationField, operationName)
+
+		player, err := userFromContext(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user from context in %s: %w", operationName, err)
+		}
+
+		gameID, err := extractGameID[bullscows.ID](query.Data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract game ID from callback data in %s: %w", operationName, err)
+		}
+
+		rawCtx := ctx.Context()
+		rawCtx = logger.WithLogValue(rawCtx, logger.GameIDField, utils.UUIDString(gameID))
+		ctx = ctx.WithContext(rawCtx)
+
+		gameRepo, err := unit.BullsCowsRepo()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get game repository in %s: %w", operationName, err)
+		}
+
+		game, err := gameRepo.GameByID(ctx, gameID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get game by ID in %s: %w", operationName, err)
+		}
+		if err := game.CanSetSecret(player.ID()); err != nil {
+			return nil, err
+		}
+		if player.ChatID().IsZero() {
+			return nil, bullscows.ErrChatRequired
+		}
+
+		return ResponseChain{
+			&SendMessageResponse{
+				ChatID:      int64(*player.ChatID()),
+				Text:        msgs.BullsCowsSecretPrompt(),
+				ParseMode:   "HTML",
+				ReplyMarkup: buildBullsCowsSecretKeyboard(&game, player.ID()),
+			},
+			&CallbackQueryResponse{
+				CallbackQueryID: query.ID,
+				Text:            msgs.BullsCowsSetupSent(),
+			},
+		}, nil
+	}
+}
@@ -0,0 +1,173 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"microgame-bot/internal/domain/checkers"
+	domainUser "microgame-bot/internal/domain/user"
+	"microgame-bot/internal/metastore"
+	"microgame-bot/internal/msgs"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mymmrac/telego"
+	tu "github.com/mymmrac/telego/telegoutil"
+)
+
+// checkersSelectionTTL keeps the piece chosen by the first tap, a forgotten selection goes away by itself.
+const checkersSelectionTTL = 10 * time.Minute
+
+// BuildCheckersGameBoardKeyboard creates the 8x8 board, a piece in the middle of a capture chain is shown selected.
+// Non-zero deadline is shown as a countdown below the board.
+func BuildCheckersGameBoardKeyboard(game *checkers.Checkers, deadline time.Time) *telego.InlineKeyboardMarkup {
+	var selected *checkers.Cell
+	if chain, ok := game.Chain(); ok {
+		selected = &chain
+	}
+	return buildCheckersBoardKeyboard(game, selected, deadline)
+}
+
+// buildCheckersBoardKeyboard creates the board with the selected piece and the squares it may go to marked.
+// Every dark square is a button: the first tap selects a piece, the second one moves it.
+func buildCheckersBoardKeyboard(
+	game *checkers.Checkers,
+	selected *checkers.Cell,
+	deadline time.Time,
+) *telego.InlineKeyboardMarkup {
+	rows := make([][]telego.InlineKeyboardButton, 0, checkers.BoardSize+1)
+
+	var targets []checkers.Cell
+	if selected != nil {
+		steps, err := game.StepsFrom(game.Turn(), *selected)
+		if err != nil {
+			selected = nil
+		}
+		for _, step := range steps {
+			targets = append(targets, step.To)
+		}
+	}
+
+	board := game.Board()
+	for row := range checkers.BoardSize {
+		buttons := make([]telego.InlineKeyboardButton, 0, checkers.BoardSize)
+		for col := range checkers.BoardSize {
+			cell := checkers.Cell{Row: row, Col: col}
+			button := telego.InlineKeyboardButton{
+				Text:         board.Get(cell).Icon(),
+				CallbackData: "empty",
+			}
+			switch {
+			case !cell.IsDark():
+				button.Text = checkers.LightSquareIcon
+			case selected != nil && cell == *selected:
+				button.Text = checkers.SelectedIcon
+			case slices.Contains(targets, cell):
+				button.Text = checkers.TargetIcon
+			}
+			if cell.IsDark() && !game.IsFinished() {
+				button.CallbackData = fmt.Sprintf("g::ck::cell::%s::%d", game.ID(), row*checkers.BoardSize+col)
+			}
+			buttons = append(buttons, button)
+		}
+		rows = append(rows, buttons)
+	}
+
+	if !deadline.IsZero() && !game.IsFinished() {
+		rows = append(rows, []telego.InlineKeyboardButton{
+			{
+				Text:         msgs.ClockLabel(deadline),
+				CallbackData: "empty",
+			},
+		})
+	}
+
+	return &telego.InlineKeyboardMarkup{
+		InlineKeyboard: rows,
+	}
+}
+
+// buildCheckersWaitingKeyboard creates inline keyboard for a game waiting for players.
+func buildCheckersWaitingKeyboard(game *checkers.Checkers) *telego.InlineKeyboardMarkup {
+	return tu.InlineKeyboard(
+		tu.InlineKeyboardRow(
+			tu.InlineKeyboardButton("Присоединиться").
+				WithCallbackData("g::ck::join::"+game.ID().String()),
+		),
+		tu.InlineKeyboardRow(
+			tu.InlineKeyboardButton("❌ Отменить").
+				WithCallbackData("g::ck::cancel::"+game.ID().String()),
+		),
+	)
+}
+
+func checkersArticle(args gameArgs) telego.InlineQueryResult {
+	msg := fmt.Sprintf(
+		"🎮 <b>%s%s Шашки</b>\n<i>%s</i>\n\nРусские шашки: бить обязательно, дамки ходят через всю доску. "+
+			"Нажми кнопку, чтобы начать игру!",
+		checkers.PieceWhiteManIcon,
+		checkers.PieceBlackManIcon,
+		args.label(),
+	)
+	return tu.ResultArticle(
+		"game::ck",
+		checkers.PieceWhiteManIcon+checkers.PieceBlackManIcon+" Шашки "+args.label(),
+		tu.TextMessage(msg).WithParseMode("HTML"),
+	).WithReplyMarkup(tu.InlineKeyboard(
+		tu.InlineKeyboardRow(
+			tu.InlineKeyboardButton("🎯 Начать игру").
+				WithCallbackData("create::ck::" + args.callbackData()),
+		),
+	))
+}
+
+// Extracts the board square from the callback data: g::ck::cell::<game id>::<cell number>.
+func extractCheckersCell(callbackData string) (checkers.Cell, error) {
+	parts := strings.Split(callbackData, "::")
+	//nolint:mnd // Callback data params is constant.
+	if len(parts) < 5 {
+		return checkers.Cell{}, ErrInvalidCallbackData
+	}
+	number, err := strconv.Atoi(parts[4])
+	if err != nil {
+		return checkers.Cell{}, ErrInvalidCallbackData
+	}
+	cell := checkers.Cell{Row: number / checkers.BoardSize, Col: number % checkers.BoardSize}
+	if number < 0 || !cell.IsValid() {
+		return checkers.Cell{}, checkers.ErrOutOfBounds
+	}
+	return cell, nil
+}
+
+// checkersIsTarget checks that the selected piece of the player may go to the square.
+func checkersIsTarget(game checkers.Checkers, userID domainUser.ID, from checkers.Cell, to checkers.Cell) bool {
+	steps, err := game.StepsFrom(userID, from)
+	if err != nil {
+		return false
+	}
+	return slices.ContainsFunc(steps, func(step checkers.Step) bool { return step.To == to })
+}
+
+// checkersSelectionKey is the metastore key of the piece the player has selected in the game.
+func checkersSelectionKey(userID domainUser.ID) string {
+	return "checkers_selection::" + userID.String()
+}
+
+// loadCheckersSelection returns the piece the player has selected, false if there is none or it has expired.
+func loadCheckersSelection(
+	ctx context.Context,
+	store metastore.IMetastoreGetter,
+	gameID checkers.ID,
+	userID domainUser.ID,
+) (checkers.Cell, bool, error) {
+	cell, err := metastore.TypedJSONMeta[checkers.Cell](ctx, store, gameID.String(), checkersSelectionKey(userID))
+	if errors.Is(err, metastore.ErrKeyNotFound) {
+		return checkers.Cell{}, false, nil
+	}
+	if err != nil {
+		return checkers.Cell{}, false, err
+	}
+	return cell, true, nil
+}
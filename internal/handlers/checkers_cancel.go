@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"fmt"
+	"log/slog"
+	"microgame-bot/internal/core/logger"
+	"microgame-bot/internal/domain"
+	domainBet "microgame-bot/internal/domain/bet"
+	"microgame-bot/internal/domain/checkers"
+	"microgame-bot/internal/msgs"
+	"microgame-bot/internal/queue"
+	"microgame-bot/internal/uow"
+
+	"github.com/mymmrac/telego"
+	th "github.com/mymmrac/telego/telegohandler"
+)
+
+func CheckersCancel(unit uow.IUnitOfWork) CallbackQueryHandlerFunc {
+	const operationName = "handlers::checkers_cancel"
+	l := slog.With(slog.String(logger.OperationField, operationName))
+	return func(ctx *th.Context, query telego.CallbackQuery) (IResponse, error) {
+		l.DebugContext(ctx, "Checkers Cancel callback received")
+
+		user, err := userFromContext(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user from context in %s: %w", operationName, err)
+		}
+
+		gameID, err := extractGameID[checkers.ID](query.Data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract game ID from callback data in %s: %w", operationName, err)
+		}
+
+		err = unit.Do(ctx, func(uow uow.IUnitOfWork) error {
+			gameRepo, err := uow.CheckersRepo()
+			if err != nil {
+				return fmt.Errorf("failed to get game repository in %s: %w", operationName, err)
+			}
+			sessionRepo, err := uow.SessionRepo()
+			if err != nil {
+				return fmt.Errorf("failed to get game session repository in %s: %w", operationName, err)
+			}
+			betRepo, err := uow.BetRepo()
+			if err != nil {
+				return fmt.Errorf("failed to get bet repository in %s: %w", operationName, err)
+			}
+
+			game, err := gameRepo.GameByIDLocked(ctx, gameID)
+			if err != nil {
+				return fmt.Errorf("failed to get game by ID with lock in %s: %w", operationName, err)
+			}
+
+			session, err := sessionRepo.SessionByIDLocked(ctx, game.SessionID())
+			if err != nil {
+				return fmt.Errorf("failed to get game session by ID with lock in %s: %w", operationName, err)
+			}
+
+			game, err = game.Cancel(user.ID())
+			if err != nil {
+				return err
+			}
+
+			_, err = gameRepo.UpdateGame(ctx, game)
+			if err != nil {
+				return fmt.Errorf("failed to update game in %s: %w", operationName, err)
+			}
+
+			session, err = session.ChangeStatus(domain.GameStatusCancelled)
+			if err != nil {
+				return err
+			}
+
+			_, err = sessionRepo.UpdateSession(ctx, session)
+			if err != nil {
+				return fmt.Errorf("failed to update session in %s: %w", operationName, err)
+			}
+
+			// Update bets status: PENDING -> WAITING, payout refunds cancelled sessions
+			if session.Bet() > 0 {
+				err = betRepo.UpdateBetsStatusBatch(ctx, session.ID(), domainBet.StatusWaiting)
+				if err != nil {
+					return fmt.Errorf("failed to update bets status in %s: %w", operationName, err)
+				}
+				outbox, err := uow.Outbox()
+				if err != nil {
+					return fmt.Errorf("failed to get task outbox in %s: %w", operationName, err)
+				}
+				if err := queue.PublishPayoutTask(ctx, outbox); err != nil {
+					return fmt.Errorf("failed to publish payout task in %s: %w", operationName, err)
+				}
+			}
+
+			return nil
+		})
+		if err != nil {
+			return nil, uow.ErrFailedToDoTransaction(operationName, err)
+		}
+
+		return ResponseChain{
+			&EditMessageTextResponse{
+				InlineMessageID: query.InlineMessageID,
+				Text:            msgs.GameCancelledByCreator(user),
+				ParseMode:       "HTML",
+			},
+			&CallbackQueryResponse{
+				CallbackQueryID: query.ID,
+				Text:            "Игра отменена",
+			},
+		}, nil
+	}
+}
@@ -0,0 +1,349 @@
+package handlers
+
+import (
+	"fmt"
+	"log/slog"
+	"microgame-bot/internal/core/logger"
+	"microgame-bot/internal/domain"
+	domainBet "microgame-bot/internal/domain/bet"
+	"microgame-bot/internal/domain/checkers"
+	domainSession "microgame-bot/internal/domain/session"
+	domainUser "microgame-bot/internal/domain/user"
+	"microgame-bot/internal/metastore"
+	"microgame-bot/internal/msgs"
+	"microgame-bot/internal/queue"
+	sRepository "microgame-bot/internal/repo/session"
+	userRepository "microgame-bot/internal/repo/user"
+	"microgame-bot/internal/uow"
+	"microgame-bot/internal/utils"
+	"time"
+
+	"github.com/mymmrac/telego"
+	th "github.com/mymmrac/telego/telegohandler"
+)
+
+// CheckersCell handles a tap on a dark square. The first tap selects a piece of the player to move
+// and keeps it in the metastore, the second one moves the selected piece to one of the marked squares.
+// In the middle of a capture chain the capturing piece stays selected until the chain is over.
+func CheckersCell(
+	userGetter userRepository.IUserGetter,
+	unit uow.IUnitOfWork,
+	store metastore.IMetastore,
+) CallbackQueryHandlerFunc {
+	const operationName = "handler::checkers_cell"
+	return func(ctx *th.Context, query telego.CallbackQuery) (IResponse, error) {
+		slog.DebugContext(ctx, "Checkers cell callback received", logger.OperationField, operationName)
+
+		player, err := userFromContext(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user from context in %s: %w", operationName, err)
+		}
+
+		gameID, err := extractGameID[checkers.ID](query.Data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract game ID from callback data in %s: %w", operationName, err)
+		}
+
+		rawCtx := ctx.Context()
+		rawCtx = logger.WithLogValue(rawCtx, logger.GameIDField, utils.UUIDString(gameID))
+		ctx = ctx.WithContext(rawCtx)
+
+		cell, err := extractCheckersCell(query.Data)
+		if err != nil {
+			return nil, err
+		}
+
+		gameGetter, err := unit.CheckersRepo()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get game repository in %s: %w", operationName, err)
+		}
+		game, err := gameGetter.GameByID(ctx, gameID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get game by ID in %s: %w", operationName, err)
+		}
+
+		var gsGetter sRepository.ISessionGetter
+		gsGetter, err = unit.SessionRepo()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get game session repository in %s: %w", operationName, err)
+		}
+
+		session, err := gsGetter.SessionByID(ctx, game.SessionID())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get game session by ID in %s: %w", operationName, err)
+		}
+
+		selectionKey := checkersSelectionKey(player.ID())
+		selected, hasSelection := game.Chain()
+		if !hasSelection {
+			selected, hasSelection, err = loadCheckersSelection(ctx, store, gameID, player.ID())
+			if err != nil {
+				return nil, fmt.Errorf("failed to load selected piece in %s: %w", operationName, err)
+			}
+		}
+
+		// First tap: select the piece, tapping the selected piece again drops the selection
+		if !hasSelection || !checkersIsTarget(game, player.ID(), selected, cell) {
+			deadline := session.MoveDeadline(game.UpdatedAt())
+			if hasSelection && cell == selected {
+				if _, ok := game.Chain(); ok {
+					return nil, checkers.ErrMustContinueChain
+				}
+				err = store.Delete(ctx, gameID.String(), selectionKey)
+				if err != nil {
+					return nil, fmt.Errorf("failed to drop selected piece in %s: %w", operationName, err)
+				}
+				return ResponseChain{
+					&EditMessageReplyMarkupResponse{
+						InlineMessageID: query.InlineMessageID,
+						ReplyMarkup:     buildCheckersBoardKeyboard(&game, nil, deadline),
+					},
+					&CallbackQueryResponse{
+						CallbackQueryID: query.ID,
+						Text:            "Выбор снят",
+					},
+				}, nil
+			}
+
+			if _, err := game.StepsFrom(player.ID(), cell); err != nil {
+				if hasSelection && game.Board().Get(cell) == checkers.PieceEmpty {
+					return nil, checkers.ErrIllegalMove
+				}
+				return nil, err
+			}
+			err = store.SetWithTTL(ctx, gameID.String(), selectionKey, cell, checkersSelectionTTL)
+			if err != nil {
+				return nil, fmt.Errorf("failed to store selected piece in %s: %w", operationName, err)
+			}
+
+			return ResponseChain{
+				&EditMessageReplyMarkupResponse{
+					InlineMessageID: query.InlineMessageID,
+					ReplyMarkup:     buildCheckersBoardKeyboard(&game, &cell, deadline),
+				},
+				&CallbackQueryResponse{
+					CallbackQueryID: query.ID,
+					Text:            msgs.CheckersSelected(cell),
+				},
+			}, nil
+		}
+
+		// Second tap: move the selected piece
+		err = unit.Do(ctx, func(uow uow.IUnitOfWork) error {
+			gameRepo, err := uow.CheckersRepo()
+			if err != nil {
+				return fmt.Errorf("failed to get game repository in %s: %w", operationName, err)
+			}
+			game, err = gameRepo.GameByIDLocked(ctx, gameID)
+			if err != nil {
+				return fmt.Errorf("failed to get game by ID with lock in %s: %w", operationName, err)
+			}
+
+			game, err = game.MakeMove(selected, cell, player.ID())
+			if err != nil {
+				return fmt.Errorf("failed to make move in %s: %w", operationName, err)
+			}
+
+			game, err = gameRepo.UpdateGame(ctx, game)
+			if err != nil {
+				return fmt.Errorf("failed to update game in %s: %w", operationName, err)
+			}
+
+			if game.IsFinished() {
+				return nil
+			}
+			// The move restarts the clock for the player to move, it is the same player while the chain goes on.
+			return scheduleMoveTimeout(ctx, uow, session, game.IDtoUUID(), game.UpdatedAt())
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed do transaction in %s: %w", operationName, err)
+		}
+		// The selection expires by itself, a failed delete only leaves it for a while
+		_ = store.Delete(ctx, gameID.String(), selectionKey)
+
+		allGames, err := gameGetter.GamesBySessionID(ctx, session.ID())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get games by session ID: %w", err)
+		}
+
+		games := make([]domainSession.IGame, len(allGames))
+		for i, g := range allGames {
+			games[i] = g
+		}
+
+		manager := domainSession.NewManager(session, games)
+		result := manager.CalculateResult()
+
+		// Colors change between rounds, the players keep the order of the first round in the message
+		player1, err := userGetter.UserByID(ctx, allGames[0].PlayerWhiteID())
+		if err != nil {
+			return nil, err
+		}
+
+		player2, err := userGetter.UserByID(ctx, allGames[0].PlayerBlackID())
+		if err != nil {
+			return nil, err
+		}
+
+		_, isChain := game.Chain()
+		moved := &CallbackQueryResponse{
+			CallbackQueryID: query.ID,
+			Text:            msgs.CheckersMoved(game.Moves()[len(game.Moves())-1], isChain),
+		}
+
+		if !game.IsFinished() {
+			return ResponseChain{
+				&EditMessageTextResponse{
+					InlineMessageID: query.InlineMessageID,
+					Text: msgs.CheckersRound(
+						allGames,
+						game,
+						player1,
+						player2,
+						result.Scores[player1.ID()],
+						result.Scores[player2.ID()],
+						result.Draws,
+						session.Bet(),
+					),
+					ParseMode:   "HTML",
+					ReplyMarkup: BuildCheckersGameBoardKeyboard(&game, session.MoveDeadline(game.UpdatedAt())),
+				},
+				moved,
+			}, nil
+		}
+
+		if result.IsCompleted {
+			err = unit.Do(ctx, func(uow uow.IUnitOfWork) error {
+				gsRepo, err := uow.SessionRepo()
+				if err != nil {
+					return fmt.Errorf("failed to get game session repository in %s: %w", operationName, err)
+				}
+				betRepo, err := uow.BetRepo()
+				if err != nil {
+					return fmt.Errorf("failed to get bet repository in %s: %w", operationName, err)
+				}
+
+				session, err = session.ChangeStatus(domain.GameStatusFinished)
+				if err != nil {
+					return fmt.Errorf("failed to change status of game session: %w", err)
+				}
+				session, err = gsRepo.UpdateSession(ctx, session)
+				if err != nil {
+					return fmt.Errorf("failed to update game session: %w", err)
+				}
+
+				// Update bets status: RUNNING -> WAITING
+				if session.Bet() > 0 {
+					err = betRepo.UpdateBetsStatusBatch(ctx, session.ID(), domainBet.StatusWaiting)
+					if err != nil {
+						return fmt.Errorf("failed to update bets status in %s: %w", operationName, err)
+					}
+					outbox, err := uow.Outbox()
+					if err != nil {
+						return fmt.Errorf("failed to get task outbox in %s: %w", operationName, err)
+					}
+					if err := queue.PublishPayoutTask(ctx, outbox); err != nil {
+						return fmt.Errorf("failed to publish payout task in %s: %w", operationName, err)
+					}
+				}
+
+				return nil
+			})
+			if err != nil {
+				return nil, uow.ErrFailedToDoTransaction(operationName, err)
+			}
+
+			var msg string
+			if result.IsDraw {
+				msg = msgs.CheckersSeriesDraw(
+					allGames,
+					player1,
+					player2,
+					result.Scores[player1.ID()],
+					result.Scores[player2.ID()],
+					result.Draws,
+				)
+			} else {
+				var winner domainUser.User
+				if result.SeriesWinners[0] == player1.ID() {
+					winner = player1
+				} else {
+					winner = player2
+				}
+				msg = msgs.CheckersSeriesCompleted(
+					allGames,
+					player1,
+					player2,
+					result.Scores[player1.ID()],
+					result.Scores[player2.ID()],
+					result.Draws,
+					winner,
+				)
+			}
+
+			return ResponseChain{
+				&EditMessageTextResponse{
+					InlineMessageID: query.InlineMessageID,
+					Text:            msg,
+					ParseMode:       "HTML",
+					ReplyMarkup:     BuildCheckersGameBoardKeyboard(&game, time.Time{}),
+				},
+				moved,
+			}, nil
+		}
+
+		// The round is over but the series goes on
+		nextGame := game
+		if result.NeedsNewRound {
+			err = unit.Do(ctx, func(uow uow.IUnitOfWork) error {
+				gameRepo, err := uow.CheckersRepo()
+				if err != nil {
+					return fmt.Errorf("failed to get game repository in %s: %w", operationName, err)
+				}
+				// Players swap colors every round, so white doesn't always go to the same player
+				nextGame, err = checkers.New(
+					checkers.WithNewID(),
+					checkers.WithSessionID(session.ID()),
+					checkers.WithCreatorID(game.CreatorID()),
+					checkers.WithPlayerWhiteID(game.PlayerBlackID()),
+					checkers.WithPlayerBlackID(game.PlayerWhiteID()),
+					checkers.WithSeed(session.Seed()),
+					checkers.WithStatus(domain.GameStatusInProgress),
+				)
+				if err != nil {
+					return fmt.Errorf("failed to create new game in %s: %w", operationName, err)
+				}
+
+				nextGame, err = gameRepo.CreateGame(ctx, nextGame)
+				if err != nil {
+					return fmt.Errorf("failed to store new game in %s: %w", operationName, err)
+				}
+
+				return scheduleMoveTimeout(ctx, uow, session, nextGame.IDtoUUID(), nextGame.UpdatedAt())
+			})
+			if err != nil {
+				return nil, uow.ErrFailedToDoTransaction(operationName, err)
+			}
+			allGames = append(allGames, nextGame)
+		}
+
+		return ResponseChain{
+			&EditMessageTextResponse{
+				InlineMessageID: query.InlineMessageID,
+				Text: msgs.CheckersRound(
+					allGames,
+					nextGame,
+					player1,
+					player2,
+					result.Scores[player1.ID()],
+					result.Scores[player2.ID()],
+					result.Draws,
+					session.Bet(),
+				),
+				ParseMode:   "HTML",
+				ReplyMarkup: BuildCheckersGameBoardKeyboard(&nextGame, session.MoveDeadline(nextGame.UpdatedAt())),
+			},
+			moved,
+		}, nil
+	}
+}
@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"microgame-bot/internal/core/logger"
+	"microgame-bot/internal/msgs"
+	"microgame-bot/internal/queue"
+	"microgame-bot/internal/scheduler"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mymmrac/telego"
+	th "github.com/mymmrac/telego/telegohandler"
+	tu "github.com/mymmrac/telego/telegoutil"
+)
+
+// CronJobsCommand manages the scheduler jobs at runtime.
+const CronJobsCommand = "cron"
+
+// cronExpressionFields is the number of the fields of a cron expression, seconds included.
+const cronExpressionFields = 6
+
+// cronJobRunsLimit is the number of the latest runs shown by /cron runs.
+const cronJobRunsLimit = 10
+
+var errCronJobArgs = errors.New("invalid cron job command arguments")
+
+// CronJobs answers the scheduler command of the admins in the private chat with the bot:
+// /cron lists the jobs, /cron add and /cron at create a recurring or a one-shot job with an empty payload,
+// /cron pause, resume, run, delete and runs take the name of the job, runs shows its latest runs.
+// Commands of other users are ignored.
+func CronJobs(jobs scheduler.IJobs, adminIDs []int64) MessageHandlerFunc {
+	const operationName = "handlers::cron_jobs"
+	l := slog.With(slog.String(logger.OperationField, operationName))
+	return func(ctx *th.Context, message telego.Message) (IResponse, error) {
+		if message.Chat.Type != telego.ChatTypePrivate || message.From == nil ||
+			!slices.Contains(adminIDs, message.From.ID) {
+			return nil, nil
+		}
+		l.DebugContext(ctx, "Cron jobs command received")
+
+		answer := func(text string) (IResponse, error) {
+			return &SendMessageResponse{ChatID: message.Chat.ID, Text: text, ParseMode: "HTML"}, nil
+		}
+		answerJob := func(job scheduler.CronJob, err error) (IResponse, error) {
+			switch {
+			case errors.Is(err, scheduler.ErrJobNotFound):
+				return answer(msgs.CronJobNotFound())
+			case errors.Is(err, scheduler.ErrJobExists):
+				return answer(msgs.CronJobExists())
+			case errors.Is(err, scheduler.ErrInvalidJob):
+				return answer(msgs.CronJobInvalid(err))
+			case err != nil:
+				return nil, err
+			}
+			return answer(msgs.CronJob(job))
+		}
+
+		_, _, args := tu.ParseCommand(message.Text)
+		if len(args) == 0 {
+			list, err := jobs.Jobs(ctx)
+			if err != nil {
+				return nil, err
+			}
+			return answer(msgs.CronJobList(list))
+		}
+
+		action, args := args[0], args[1:]
+		switch action {
+		case "add", "at":
+			job, err := cronJobFromArgs(action, args)
+			if err != nil {
+				return answer(msgs.CronJobUsage())
+			}
+			return answerJob(jobs.AddJob(ctx, job))
+
+		case "runs":
+			if len(args) != 1 {
+				return answer(msgs.CronJobUsage())
+			}
+			runs, err := jobs.Runs(ctx, args[0], cronJobRunsLimit)
+			if err != nil {
+				return nil, err
+			}
+			return answer(msgs.CronJobRuns(args[0], runs))
+
+		case "pause", "resume", "run", "delete":
+			if len(args) != 1 {
+				return answer(msgs.CronJobUsage())
+			}
+			name := args[0]
+			switch action {
+			case "pause":
+				return answerJob(jobs.PauseJob(ctx, name))
+			case "resume":
+				return answerJob(jobs.ResumeJob(ctx, name))
+			case "run":
+				return answerJob(jobs.TriggerJob(ctx, name))
+			}
+			if err := jobs.DeleteJob(ctx, name); err != nil {
+				return answerJob(scheduler.CronJob{}, err)
+			}
+			return answer(msgs.CronJobDeleted(name))
+
+		default:
+			return answer(msgs.CronJobUsage())
+		}
+	}
+}
+
+// cronJobFromArgs takes the name and the subject of the job, then the cron expression of a recurring job
+// or the RFC 3339 run time of a one-shot one.
+func cronJobFromArgs(action string, args []string) (scheduler.CronJob, error) {
+	if action == "at" {
+		if len(args) != 3 { //nolint:mnd // Name, subject and the run time.
+			return scheduler.CronJob{}, errCronJobArgs
+		}
+		runAt, err := time.Parse(time.RFC3339, args[2])
+		if err != nil {
+			return scheduler.CronJob{}, errCronJobArgs
+		}
+		return scheduler.OneShotJob(args[0], args[1], queue.EmptyPayload, runAt), nil
+	}
+
+	if len(args) != 2+cronExpressionFields { //nolint:mnd // Name and subject before the expression.
+		return scheduler.CronJob{}, errCronJobArgs
+	}
+	expression := scheduler.CronExpression(strings.Join(args[2:], " "))
+	if err := expression.Validate(); err != nil {
+		return scheduler.CronJob{}, errCronJobArgs
+	}
+	return scheduler.CronJob{
+		Name:       args[0],
+		Subject:    args[1],
+		Expression: expression,
+		Status:     scheduler.CronJobStatusActive,
+		Payload:    queue.EmptyPayload,
+	}, nil
+}
@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"microgame-bot/internal/core/logger"
+	"microgame-bot/internal/msgs"
+	"microgame-bot/internal/queue"
+	"microgame-bot/internal/utils"
+	"slices"
+	"time"
+
+	"github.com/mymmrac/telego"
+	th "github.com/mymmrac/telego/telegohandler"
+	tu "github.com/mymmrac/telego/telegoutil"
+)
+
+// DeadLetterCommand inspects and replays the failed tasks of the queue.
+const DeadLetterCommand = "dlq"
+
+const deadLetterListLimit = 10
+
+var errDeadLetterArgs = errors.New("invalid dead letter command arguments")
+
+// DeadLetter answers the dead-letter command of the admins in the private chat with the bot:
+// /dlq [subject] lists the failed tasks, /dlq show <id> shows one of them,
+// /dlq requeue and /dlq discard take the ids of the tasks or a subject with the optional start of the period.
+// Commands of other users are ignored.
+func DeadLetter(dl queue.IDeadLetter, adminIDs []int64) MessageHandlerFunc {
+	const operationName = "handlers::dead_letter"
+	l := slog.With(slog.String(logger.OperationField, operationName))
+	return func(ctx *th.Context, message telego.Message) (IResponse, error) {
+		if message.Chat.Type != telego.ChatTypePrivate || message.From == nil ||
+			!slices.Contains(adminIDs, message.From.ID) {
+			return nil, nil
+		}
+		l.DebugContext(ctx, "Dead letter command received")
+
+		answer := func(text string) (IResponse, error) {
+			return &SendMessageResponse{ChatID: message.Chat.ID, Text: text, ParseMode: "HTML"}, nil
+		}
+
+		_, _, args := tu.ParseCommand(message.Text)
+		action := ""
+		if len(args) > 0 {
+			action = args[0]
+		}
+
+		switch action {
+		case "show":
+			if len(args) != 2 { //nolint:mnd // Action and the task ID.
+				return answer(msgs.DeadLetterUsage())
+			}
+			id, err := utils.UUIDFromString[utils.UniqueID](args[1])
+			if err != nil {
+				return answer(msgs.DeadLetterUsage())
+			}
+			task, err := dl.FailedTask(ctx, id)
+			if errors.Is(err, queue.ErrTaskNotFound) {
+				return answer(msgs.DeadLetterNotFound())
+			}
+			if err != nil {
+				return nil, err
+			}
+			return answer(msgs.DeadLetterTask(task))
+
+		case "requeue", "discard":
+			filter, err := deadLetterFilterFromArgs(args[1:])
+			if err != nil {
+				return answer(msgs.DeadLetterUsage())
+			}
+			if action == "requeue" {
+				requeued, err := dl.RequeueFailed(ctx, filter)
+				if err != nil {
+					return nil, err
+				}
+				return answer(msgs.DeadLetterRequeued(requeued))
+			}
+			discarded, err := dl.DiscardFailed(ctx, filter)
+			if err != nil {
+				return nil, err
+			}
+			return answer(msgs.DeadLetterDiscarded(discarded))
+
+		default:
+			// Anything else is the subject to list, no arguments list every subject
+			tasks, err := dl.FailedTasks(ctx, queue.DeadLetterFilter{Subject: action, Limit: deadLetterListLimit})
+			if err != nil {
+				return nil, err
+			}
+			return answer(msgs.DeadLetterList(tasks, action))
+		}
+	}
+}
+
+// deadLetterFilterFromArgs takes the ids of the tasks, or the subject with the optional RFC 3339 start of the period.
+func deadLetterFilterFromArgs(args []string) (queue.DeadLetterFilter, error) {
+	if len(args) == 0 {
+		return queue.DeadLetterFilter{}, errDeadLetterArgs
+	}
+
+	ids := make([]utils.UniqueID, 0, len(args))
+	for _, arg := range args {
+		id, err := utils.UUIDFromString[utils.UniqueID](arg)
+		if err != nil {
+			break
+		}
+		ids = append(ids, id)
+	}
+	if len(ids) == len(args) {
+		return queue.DeadLetterFilter{IDs: ids}, nil
+	}
+
+	filter := queue.DeadLetterFilter{Subject: args[0]}
+	switch len(args) {
+	case 1:
+	case 2: //nolint:mnd // Subject and the start of the period.
+		since, err := time.Parse(time.RFC3339, args[1])
+		if err != nil {
+			return queue.DeadLetterFilter{}, errDeadLetterArgs
+		}
+		filter.Since = since
+	default:
+		return queue.DeadLetterFilter{}, errDeadLetterArgs
+	}
+	return filter, nil
+}
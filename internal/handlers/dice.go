@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"microgame-bot/internal/domain/dice"
+	domainUser "microgame-bot/internal/domain/user"
+	"microgame-bot/internal/msgs"
+	"strings"
+	"time"
+
+	"github.com/mymmrac/telego"
+	tu "github.com/mymmrac/telego/telegoutil"
+)
+
+// diceQueryKeyword opens the dice kinds in the game selector: dice <rounds> <bet> <move timeout>.
+const diceQueryKeyword = "dice"
+
+// BuildDiceGameBoardKeyboard creates inline keyboard with the throw button.
+// Non-zero deadline is shown as a countdown below the button.
+func BuildDiceGameBoardKeyboard(game *dice.Dice, deadline time.Time) *telego.InlineKeyboardMarkup {
+	//nolint:mnd // Rows count is constant.
+	rows := make([][]telego.InlineKeyboardButton, 0, 2)
+	if game.IsFinished() {
+		return &telego.InlineKeyboardMarkup{
+			InlineKeyboard: rows,
+		}
+	}
+
+	rows = append(rows, []telego.InlineKeyboardButton{
+		{
+			Text:         game.Kind().Emoji() + " Бросить",
+			CallbackData: "g::dice::throw::" + game.ID().String(),
+		},
+	})
+
+	if !deadline.IsZero() {
+		rows = append(rows, []telego.InlineKeyboardButton{
+			{
+				Text:         msgs.ClockLabel(deadline),
+				CallbackData: "empty",
+			},
+		})
+	}
+
+	return &telego.InlineKeyboardMarkup{
+		InlineKeyboard: rows,
+	}
+}
+
+// buildDiceWaitingKeyboard creates inline keyboard for a game waiting for players.
+func buildDiceWaitingKeyboard(game *dice.Dice) *telego.InlineKeyboardMarkup {
+	return tu.InlineKeyboard(
+		tu.InlineKeyboardRow(
+			tu.InlineKeyboardButton("Присоединиться").
+				WithCallbackData("g::dice::join::"+game.ID().String()),
+		),
+		tu.InlineKeyboardRow(
+			tu.InlineKeyboardButton("❌ Отменить").
+				WithCallbackData("g::dice::cancel::"+game.ID().String()),
+		),
+	)
+}
+
+// Extracts the dice kind from the create callback data. If the kind is missing, returns the classic dice.
+func extractDiceKind(callbackData string) (dice.Kind, error) {
+	parts := strings.Split(callbackData, "::")
+	//nolint:mnd // Callback data params is constant.
+	if len(parts) < 6 {
+		return dice.KindDice, nil
+	}
+
+	return dice.KindFromString(parts[5])
+}
+
+// diceThrowChatID returns the chat the dice is sent to.
+// Inline messages have no chat the bot can post in, so the dice goes to the private chat of the player,
+// games living in a regular message get the dice right below the board.
+func diceThrowChatID(query telego.CallbackQuery, player domainUser.User) (int64, error) {
+	if query.Message != nil && query.Message.IsAccessible() {
+		return query.Message.GetChat().ID, nil
+	}
+	if player.ChatID().IsZero() {
+		return 0, dice.ErrChatRequired
+	}
+	return int64(*player.ChatID()), nil
+}
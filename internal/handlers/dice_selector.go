@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"fmt"
+	"microgame-bot/internal/core"
+	"microgame-bot/internal/domain/dice"
+
+	"github.com/mymmrac/telego"
+	tu "github.com/mymmrac/telego/telegoutil"
+)
+
+// diceSelector offers a dice duel of every kind with the series settings of the query.
+func diceSelector(query telego.InlineQuery, fields []string, cfg core.AppConfig) IResponse {
+	args := parseGameArgs(fields, cfg)
+
+	kinds := dice.Kinds()
+	results := make([]telego.InlineQueryResult, 0, len(kinds))
+	for _, kind := range kinds {
+		results = append(results, diceArticle(kind, args))
+	}
+
+	return &InlineQueryResponse{
+		QueryID:   query.ID,
+		Results:   results,
+		CacheTime: 1,
+	}
+}
+
+func diceArticle(kind dice.Kind, args gameArgs) telego.InlineQueryResult {
+	title := kind.Emoji() + " " + kind.Title()
+	msg := fmt.Sprintf(
+		"🎮 <b>Дуэль: %s</b>\n<i>%s</i>\n\nУ кого выпадет больше, тот и победил. Нажми кнопку, чтобы начать игру!",
+		title,
+		args.label(),
+	)
+	return tu.ResultArticle(
+		"game::dice::"+kind.String(),
+		"Дуэль: "+title+" "+args.label(),
+		tu.TextMessage(msg).WithParseMode("HTML"),
+	).WithReplyMarkup(tu.InlineKeyboard(
+		tu.InlineKeyboardRow(
+			tu.InlineKeyboardButton("🎯 Начать игру").
+				WithCallbackData("create::dice::" + args.callbackData() + "::" + kind.String()),
+		),
+	))
+}
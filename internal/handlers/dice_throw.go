@@ -0,0 +1,303 @@
+package handlers
+
+import (
+	"fmt"
+	"log/slog"
+	"microgame-bot/internal/core/logger"
+	"microgame-bot/internal/domain"
+	domainBet "microgame-bot/internal/domain/bet"
+	"microgame-bot/internal/domain/dice"
+	domainSession "microgame-bot/internal/domain/session"
+	domainUser "microgame-bot/internal/domain/user"
+	"microgame-bot/internal/msgs"
+	"microgame-bot/internal/queue"
+	diceRepository "microgame-bot/internal/repo/game/dice"
+	sRepository "microgame-bot/internal/repo/session"
+	userRepository "microgame-bot/internal/repo/user"
+	"microgame-bot/internal/uow"
+	"microgame-bot/internal/utils"
+
+	"github.com/mymmrac/telego"
+	th "github.com/mymmrac/telego/telegohandler"
+	tu "github.com/mymmrac/telego/telegoutil"
+)
+
+// DiceThrow rolls the dice of the player through the Bot API and records the value Telegram returned.
+// The bot never picks the value itself, the dice message is the proof of the throw.
+func DiceThrow(
+	userGetter userRepository.IUserGetter,
+	unit uow.IUnitOfWork,
+) CallbackQueryHandlerFunc {
+	const operationName = "handler::dice_throw"
+	return func(ctx *th.Context, query telego.CallbackQuery) (IResponse, error) {
+		slog.DebugContext(ctx, "Dice throw callback received", logger.OperationField, operationName)
+
+		player, err := userFromContext(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user from context in %s: %w", operationName, err)
+		}
+
+		gameID, err := extractGameID[dice.ID](query.Data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract game ID from callback data in %s: %w", operationName, err)
+		}
+
+		rawCtx := ctx.Context()
+		rawCtx = logger.WithLogValue(rawCtx, logger.GameIDField, utils.UUIDString(gameID))
+		ctx = ctx.WithContext(rawCtx)
+
+		var gameGetter diceRepository.IDiceGetter
+		gameGetter, err = unit.DiceRepo()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get game repository in %s: %w", operationName, err)
+		}
+
+		// Check the throw before the dice is sent, a rejected throw must not spend a roll.
+		// Callbacks of the message are serialized by the inline message lock, so the check holds.
+		game, err := gameGetter.GameByID(ctx, gameID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get game by ID in %s: %w", operationName, err)
+		}
+		if err := game.CanThrow(player.ID()); err != nil {
+			return nil, err
+		}
+
+		chatID, err := diceThrowChatID(query, player)
+		if err != nil {
+			return nil, err
+		}
+		sent, err := ctx.Bot().SendDice(ctx, tu.Dice(tu.ID(chatID), game.Kind().Emoji()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to send dice in %s: %w", operationName, err)
+		}
+		if sent.Dice == nil {
+			return nil, dice.ErrUnexpectedThrow
+		}
+		throw := dice.Throw{
+			Value:     sent.Dice.Value,
+			ChatID:    chatID,
+			MessageID: sent.MessageID,
+		}
+
+		err = unit.Do(ctx, func(uow uow.IUnitOfWork) error {
+			gameRepo, err := uow.DiceRepo()
+			if err != nil {
+				return fmt.Errorf("failed to get game repository in %s: %w", operationName, err)
+			}
+			game, err = gameRepo.GameByIDLocked(ctx, gameID)
+			if err != nil {
+				return fmt.Errorf("failed to get game by ID with lock in %s: %w", operationName, err)
+			}
+
+			game, err = game.Throw(player.ID(), throw)
+			if err != nil {
+				return fmt.Errorf("failed to throw dice in %s: %w", operationName, err)
+			}
+
+			game, err = gameRepo.UpdateGame(ctx, game)
+			if err != nil {
+				return fmt.Errorf("failed to update game in %s: %w", operationName, err)
+			}
+
+			if game.IsFinished() {
+				return nil
+			}
+			// The throw restarts the clock for the opponent.
+			return scheduleGameMoveTimeout(ctx, uow, game.SessionID(), game.IDtoUUID(), game.UpdatedAt())
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed do transaction in %s: %w", operationName, err)
+		}
+
+		var gsGetter sRepository.ISessionGetter
+		gsGetter, err = unit.SessionRepo()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get game session repository in %s: %w", operationName, err)
+		}
+
+		session, err := gsGetter.SessionByID(ctx, game.SessionID())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get game session by ID in %s: %w", operationName, err)
+		}
+
+		allGames, err := gameGetter.GamesBySessionID(ctx, session.ID())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get games by session ID: %w", err)
+		}
+
+		games := make([]domainSession.IGame, len(allGames))
+		for i, g := range allGames {
+			games[i] = g
+		}
+
+		manager := domainSession.NewManager(session, games)
+		result := manager.CalculateResult()
+
+		player1, err := userGetter.UserByID(ctx, game.Player1ID())
+		if err != nil {
+			return nil, err
+		}
+
+		player2, err := userGetter.UserByID(ctx, game.Player2ID())
+		if err != nil {
+			return nil, err
+		}
+
+		thrown := &CallbackQueryResponse{
+			CallbackQueryID: query.ID,
+			Text:            msgs.DiceThrown(game.Kind(), throw.Value),
+		}
+
+		if !game.IsFinished() {
+			return ResponseChain{
+				&EditMessageTextResponse{
+					InlineMessageID: query.InlineMessageID,
+					Text: msgs.DiceRound(
+						allGames,
+						game,
+						player1,
+						player2,
+						result.Scores[player1.ID()],
+						result.Scores[player2.ID()],
+						result.Draws,
+						session.Bet(),
+					),
+					ParseMode:   "HTML",
+					ReplyMarkup: BuildDiceGameBoardKeyboard(&game, session.MoveDeadline(game.UpdatedAt())),
+				},
+				thrown,
+			}, nil
+		}
+
+		if result.IsCompleted {
+			err = unit.Do(ctx, func(uow uow.IUnitOfWork) error {
+				gsRepo, err := uow.SessionRepo()
+				if err != nil {
+					return fmt.Errorf("failed to get game session repository in %s: %w", operationName, err)
+				}
+				betRepo, err := uow.BetRepo()
+				if err != nil {
+					return fmt.Errorf("failed to get bet repository in %s: %w", operationName, err)
+				}
+
+				session, err = session.ChangeStatus(domain.GameStatusFinished)
+				if err != nil {
+					return fmt.Errorf("failed to change status of game session: %w", err)
+				}
+				session, err = gsRepo.UpdateSession(ctx, session)
+				if err != nil {
+					return fmt.Errorf("failed to update game session: %w", err)
+				}
+
+				// Update bets status: RUNNING -> WAITING
+				if session.Bet() > 0 {
+					err = betRepo.UpdateBetsStatusBatch(ctx, session.ID(), domainBet.StatusWaiting)
+					if err != nil {
+						return fmt.Errorf("failed to update bets status in %s: %w", operationName, err)
+					}
+					outbox, err := uow.Outbox()
+					if err != nil {
+						return fmt.Errorf("failed to get task outbox in %s: %w", operationName, err)
+					}
+					if err := queue.PublishPayoutTask(ctx, outbox); err != nil {
+						return fmt.Errorf("failed to publish payout task in %s: %w", operationName, err)
+					}
+				}
+
+				return nil
+			})
+			if err != nil {
+				return nil, uow.ErrFailedToDoTransaction(operationName, err)
+			}
+
+			var msg string
+			if result.IsDraw {
+				msg = msgs.DiceSeriesDraw(
+					allGames,
+					player1,
+					player2,
+					result.Scores[player1.ID()],
+					result.Scores[player2.ID()],
+					result.Draws,
+				)
+			} else {
+				var winner domainUser.User
+				if result.SeriesWinners[0] == player1.ID() {
+					winner = player1
+				} else {
+					winner = player2
+				}
+				msg = msgs.DiceSeriesCompleted(
+					allGames,
+					player1,
+					player2,
+					result.Scores[player1.ID()],
+					result.Scores[player2.ID()],
+					result.Draws,
+					winner,
+				)
+			}
+
+			return ResponseChain{
+				&EditMessageTextResponse{
+					InlineMessageID: query.InlineMessageID,
+					Text:            msg,
+					ParseMode:       "HTML",
+				},
+				thrown,
+			}, nil
+		}
+
+		// The round is over but the series goes on
+		nextGame := game
+		if result.NeedsNewRound {
+			err = unit.Do(ctx, func(uow uow.IUnitOfWork) error {
+				gameRepo, err := uow.DiceRepo()
+				if err != nil {
+					return fmt.Errorf("failed to get game repository in %s: %w", operationName, err)
+				}
+				nextGame, err = dice.New(
+					dice.WithNewID(),
+					dice.WithSessionID(session.ID()),
+					dice.WithCreatorID(game.CreatorID()),
+					dice.WithKind(game.Kind()),
+					dice.WithPlayer1ID(game.Player1ID()),
+					dice.WithPlayer2ID(game.Player2ID()),
+					dice.WithStatus(domain.GameStatusInProgress),
+				)
+				if err != nil {
+					return fmt.Errorf("failed to create new game in %s: %w", operationName, err)
+				}
+
+				nextGame, err = gameRepo.CreateGame(ctx, nextGame)
+				if err != nil {
+					return fmt.Errorf("failed to store new game in %s: %w", operationName, err)
+				}
+
+				return scheduleMoveTimeout(ctx, uow, session, nextGame.IDtoUUID(), nextGame.UpdatedAt())
+			})
+			if err != nil {
+				return nil, uow.ErrFailedToDoTransaction(operationName, err)
+			}
+		}
+
+		return ResponseChain{
+			&EditMessageTextResponse{
+				InlineMessageID: query.InlineMessageID,
+				Text: msgs.DiceRound(
+					allGames,
+					nextGame,
+					player1,
+					player2,
+					result.Scores[player1.ID()],
+					result.Scores[player2.ID()],
+					result.Draws,
+					session.Bet(),
+				),
+				ParseMode:   "HTML",
+				ReplyMarkup: BuildDiceGameBoardKeyboard(&nextGame, session.MoveDeadline(nextGame.UpdatedAt())),
+			},
+			thrown,
+		}, nil
+	}
+}
@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"microgame-bot/internal/domain"
+	domainSession "microgame-bot/internal/domain/session"
+	"microgame-bot/internal/queue"
+	"microgame-bot/internal/uow"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// scheduleJoinTimeout publishes a delayed task that cancels the game
+// if it is still waiting for players when the join timeout expires.
+// Must be called within transaction.
+func scheduleJoinTimeout(
+	ctx context.Context,
+	unit uow.IUnitOfWork,
+	session domainSession.Session,
+	gameID uuid.UUID,
+	createdAt time.Time,
+) error {
+	deadline := session.JoinDeadline(createdAt)
+	if deadline.IsZero() {
+		return nil
+	}
+
+	task := domainSession.TimeoutTask{
+		Kind:            domainSession.TimeoutKindJoin,
+		GameType:        session.GameType(),
+		InlineMessageID: session.InlineMessageID(),
+		SessionID:       session.ID(),
+		GameID:          gameID,
+		Deadline:        deadline,
+	}
+	return publishTimeoutTask(ctx, unit, task, false)
+}
+
+// scheduleMoveTimeout publishes a delayed task that forfeits the game of the player
+// who has not moved before the deadline, plus the first tick of the live countdown.
+// Tasks are bound to updatedAt, so any later move makes them stale.
+// Must be called within transaction.
+func scheduleMoveTimeout(
+	ctx context.Context,
+	unit uow.IUnitOfWork,
+	session domainSession.Session,
+	gameID uuid.UUID,
+	updatedAt time.Time,
+) error {
+	deadline := session.MoveDeadline(updatedAt)
+	if deadline.IsZero() {
+		return nil
+	}
+
+	task := domainSession.TimeoutTask{
+		Kind:            domainSession.TimeoutKindMove,
+		GameType:        session.GameType(),
+		InlineMessageID: session.InlineMessageID(),
+		SessionID:       session.ID(),
+		GameID:          gameID,
+		UpdatedAt:       updatedAt,
+		Deadline:        deadline,
+	}
+	return publishTimeoutTask(ctx, unit, task, session.HasLiveClock())
+}
+
+// scheduleGameMoveTimeout is scheduleMoveTimeout for the handlers that don't hold the session of the game.
+// Must be called within transaction.
+func scheduleGameMoveTimeout(
+	ctx context.Context,
+	unit uow.IUnitOfWork,
+	sessionID domainSession.ID,
+	gameID uuid.UUID,
+	updatedAt time.Time,
+) error {
+	sessionRepo, err := unit.SessionRepo()
+	if err != nil {
+		return fmt.Errorf("failed to get session repository: %w", err)
+	}
+	session, err := sessionRepo.SessionByID(ctx, sessionID)
+	if err != nil {
+		return fmt.Errorf("failed to get game session by ID: %w", err)
+	}
+	return scheduleMoveTimeout(ctx, unit, session, gameID, updatedAt)
+}
+
+// publishTimeoutTask publishes the timeout task through the outbox of the unit,
+// so the timer is started only together with the move that armed it.
+func publishTimeoutTask(
+	ctx context.Context,
+	unit uow.IUnitOfWork,
+	task domainSession.TimeoutTask,
+	withClock bool,
+) error {
+	outbox, err := unit.Outbox()
+	if err != nil {
+		return fmt.Errorf("failed to get task outbox: %w", err)
+	}
+
+	payload, err := json.Marshal(task)
+	if err != nil {
+		return fmt.Errorf("failed to marshal timeout task: %w", err)
+	}
+
+	if err := queue.PublishGameAFKTask(ctx, outbox, payload, task.Deadline); err != nil {
+		return fmt.Errorf("failed to publish AFK task: %w", err)
+	}
+
+	if !withClock {
+		return nil
+	}
+
+	nextTick := time.Now().Add(domainSession.ClockTickInterval)
+	if !nextTick.Before(task.Deadline) {
+		return nil
+	}
+	if err := queue.PublishGameClockTask(ctx, outbox, payload, nextTick); err != nil {
+		return fmt.Errorf("failed to publish clock task: %w", err)
+	}
+	return nil
+}
+
+// Extracts the move timeout from the callback data. If the callback data is invalid, returns 0 (no clock).
+func extractMoveTimeout(callbackData string) time.Duration {
+	parts := strings.Split(callbackData, "::")
+	//nolint:mnd // Callback data params is constant.
+	if len(parts) < 5 {
+		return 0
+	}
+
+	var seconds int
+	_, err := fmt.Sscanf(parts[4], "%d", &seconds)
+	if err != nil {
+		return 0
+	}
+
+	return domainSession.ClampMoveTimeout(time.Duration(seconds) * time.Second)
+}
+
+// moveDeadline returns the deadline shown on the board of an unfinished game.
+func moveDeadline(session domainSession.Session, status domain.GameStatus, updatedAt time.Time) time.Time {
+	if status != domain.GameStatusInProgress {
+		return time.Time{}
+	}
+	return session.MoveDeadline(updatedAt)
+}
@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"fmt"
+	"microgame-bot/internal/domain/hangman"
+	"microgame-bot/internal/msgs"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/mymmrac/telego"
+	tu "github.com/mymmrac/telego/telegoutil"
+)
+
+const (
+	// hangmanLettersPerRow is the width of the letter keyboard.
+	hangmanLettersPerRow = 8
+	// hangmanLettersPerPage keeps the letter keyboard short, the rest of the alphabet is on the next pages.
+	hangmanLettersPerPage = 16
+)
+
+// hangmanPages returns the number of letter pages of the language.
+func hangmanPages(language hangman.Language) int {
+	return (len(language.Alphabet()) + hangmanLettersPerPage - 1) / hangmanLettersPerPage
+}
+
+// BuildHangmanGameBoardKeyboard creates the letter keyboard opened on the first page.
+// Non-zero deadline is shown as a countdown below the letters.
+func BuildHangmanGameBoardKeyboard(game *hangman.Hangman, deadline time.Time) *telego.InlineKeyboardMarkup {
+	return buildHangmanLettersKeyboard(game, 0, deadline)
+}
+
+// buildHangmanLettersKeyboard creates the keyboard with one page of the alphabet.
+// Named letters stay in place without a callback, so the letters don't jump between guesses.
+func buildHangmanLettersKeyboard(game *hangman.Hangman, page int, deadline time.Time) *telego.InlineKeyboardMarkup {
+	//nolint:mnd // Letter rows, the page row and the clock row.
+	rows := make([][]telego.InlineKeyboardButton, 0, hangmanLettersPerPage/hangmanLettersPerRow+2)
+	if game.IsFinished() {
+		return &telego.InlineKeyboardMarkup{
+			InlineKeyboard: rows,
+		}
+	}
+
+	pages := hangmanPages(game.Language())
+	page = max(min(page, pages-1), 0)
+	alphabet := game.Language().Alphabet()
+	letters := alphabet[page*hangmanLettersPerPage : min((page+1)*hangmanLettersPerPage, len(alphabet))]
+
+	for start := 0; start < len(letters); start += hangmanLettersPerRow {
+		end := min(start+hangmanLettersPerRow, len(letters))
+		buttons := make([]telego.InlineKeyboardButton, 0, end-start)
+		for _, letter := range letters[start:end] {
+			button := telego.InlineKeyboardButton{
+				Text:         string(letter),
+				CallbackData: fmt.Sprintf("g::hm::guess::%s::%d::%c", game.ID(), page, letter),
+			}
+			if game.IsGuessed(letter) {
+				button.Text = "·"
+				button.CallbackData = "empty"
+			}
+			buttons = append(buttons, button)
+		}
+		rows = append(rows, buttons)
+	}
+
+	if pages > 1 {
+		rows = append(rows, []telego.InlineKeyboardButton{
+			{
+				Text:         "◀️",
+				CallbackData: fmt.Sprintf("g::hm::page::%s::%d", game.ID(), (page+pages-1)%pages),
+			},
+			{
+				Text:         fmt.Sprintf("%d/%d", page+1, pages),
+				CallbackData: "empty",
+			},
+			{
+				Text:         "▶️",
+				CallbackData: fmt.Sprintf("g::hm::page::%s::%d", game.ID(), (page+1)%pages),
+			},
+		})
+	}
+
+	if !deadline.IsZero() {
+		rows = append(rows, []telego.InlineKeyboardButton{
+			{
+				Text:         msgs.ClockLabel(deadline),
+				CallbackData: "empty",
+			},
+		})
+	}
+
+	return &telego.InlineKeyboardMarkup{
+		InlineKeyboard: rows,
+	}
+}
+
+// buildHangmanWaitingKeyboard creates inline keyboard for a game waiting for players.
+func buildHangmanWaitingKeyboard(game *hangman.Hangman) *telego.InlineKeyboardMarkup {
+	return tu.InlineKeyboard(
+		tu.InlineKeyboardRow(
+			tu.InlineKeyboardButton("Присоединиться").
+				WithCallbackData("g::hm::join::"+game.ID().String()),
+		),
+		tu.InlineKeyboardRow(
+			tu.InlineKeyboardButton("❌ Отменить").
+				WithCallbackData("g::hm::cancel::"+game.ID().String()),
+		),
+	)
+}
+
+// hangmanArticle offers the word game on the list of the language with the series settings of the query.
+func hangmanArticle(language hangman.Language, args gameArgs) telego.InlineQueryResult {
+	title := "🔤 " + language.Title() + " " + language.Flag()
+	msg := fmt.Sprintf(
+		"🎮 <b>%s</b>\n<i>%s</i>\n\nУгадывайте буквы по очереди, за каждую открытую букву - очко. "+
+			"Нажми кнопку, чтобы начать игру!",
+		title,
+		args.label(),
+	)
+	return tu.ResultArticle(
+		"game::hm::"+language.String(),
+		title+" "+args.label(),
+		tu.TextMessage(msg).WithParseMode("HTML"),
+	).WithReplyMarkup(tu.InlineKeyboard(
+		tu.InlineKeyboardRow(
+			tu.InlineKeyboardButton("🎯 Начать игру").
+				WithCallbackData("create::hm::" + args.callbackData() + "::" + language.String()),
+		),
+	))
+}
+
+// Extracts the word list language from the create callback data. If the language is missing, returns Russian.
+func extractHangmanLanguage(callbackData string) (hangman.Language, error) {
+	parts := strings.Split(callbackData, "::")
+	//nolint:mnd // Callback data params is constant.
+	if len(parts) < 6 {
+		return hangman.LanguageRU, nil
+	}
+
+	return hangman.LanguageFromString(parts[5])
+}
+
+// Extracts the keyboard page from the callback data: g::hm::<action>::<game id>::<page>.
+func extractHangmanPage(callbackData string) int {
+	parts := strings.Split(callbackData, "::")
+	//nolint:mnd // Callback data params is constant.
+	if len(parts) < 5 {
+		return 0
+	}
+	page, err := strconv.Atoi(parts[4])
+	if err != nil || page < 0 {
+		return 0
+	}
+	return page
+}
+
+// Extracts the named letter from the callback data: g::hm::guess::<game id>::<page>::<letter>.
+func extractHangmanLetter(callbackData string) (rune, error) {
+	parts := strings.Split(callbackData, "::")
+	//nolint:mnd // Callback data params is constant.
+	if len(parts) < 6 {
+		return 0, ErrInvalidCallbackData
+	}
+	letter, size := utf8.DecodeRuneInString(parts[5])
+	if letter == utf8.RuneError || size != len(parts[5]) {
+		return 0, hangman.ErrInvalidLetter
+	}
+	return letter, nil
+}
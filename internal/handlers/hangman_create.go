@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"fmt"
+	"log/slog"
+
+	"microgame-bot/internal/core"
+	"microgame-bot/internal/core/logger"
+	"microgame-bot/internal/domain"
+	domainBet "microgame-bot/internal/domain/bet"
+	"microgame-bot/internal/domain/hangman"
+	domainSession "microgame-bot/internal/domain/session"
+	"microgame-bot/internal/msgs"
+	"microgame-bot/internal/uow"
+	"microgame-bot/internal/utils"
+
+	"github.com/mymmrac/telego"
+	th "github.com/mymmrac/telego/telegohandler"
+)
+
+func HangmanCreate(
+	unit uow.IUnitOfWork,
+	cfg core.AppConfig,
+) CallbackQueryHandlerFunc {
+	const operationName = "handlers::hangman_create"
+	l := slog.With(slog.String(logger.OperationField, operationName))
+	return func(ctx *th.Context, query telego.CallbackQuery) (IResponse, error) {
+		l.DebugContext(ctx, "Create hangman game callback received")
+
+		user, err := userFromContext(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user from context in %s: %w", operationName, err)
+		}
+
+		inlineMessageID, err := inlineMessageIDFromContext(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get inline message ID from context in %s: %w", operationName, err)
+		}
+
+		gameCount := extractGameCount(query.Data, cfg.MaxGameCount)
+		betAmount := extractBetAmount(query.Data, domainBet.MaxBet)
+		moveTimeout := extractMoveTimeout(query.Data)
+		language, err := extractHangmanLanguage(query.Data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract word list language in %s: %w", operationName, err)
+		}
+
+		session, err := domainSession.New(
+			domainSession.WithNewID(),
+			domainSession.WithGameType(domain.GameTypeHangman),
+			domainSession.WithInlineMessageID(inlineMessageID),
+			domainSession.WithGameCount(gameCount),
+			domainSession.WithBet(betAmount),
+			domainSession.WithWinCondition(domainSession.WinConditionFirstTo),
+			domainSession.WithMoveTimeout(moveTimeout),
+			domainSession.WithJoinTimeout(cfg.JoinTimeout),
+			domainSession.WithNewSeed(),
+		)
+		if err != nil {
+			return nil, err
+		}
+		gameID := hangman.ID(utils.NewUniqueID())
+		word, err := hangman.RandomWord(language, session.Seed(), gameID.String())
+		if err != nil {
+			return nil, fmt.Errorf("failed to pick word in %s: %w", operationName, err)
+		}
+		game, err := hangman.New(
+			hangman.WithID(gameID),
+			hangman.WithCreatorID(user.ID()),
+			hangman.WithLanguage(language),
+			hangman.WithWord(word),
+			hangman.WithStatus(domain.GameStatusWaitingForPlayers),
+			hangman.WithSessionID(session.ID()),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create hangman game in %s: %w", operationName, err)
+		}
+		err = unit.Do(ctx, func(unit uow.IUnitOfWork) error {
+			sR, err := unit.SessionRepo()
+			if err != nil {
+				return err
+			}
+			gR, err := unit.HangmanRepo()
+			if err != nil {
+				return err
+			}
+			session, err = sR.CreateSession(ctx, session)
+			if err != nil {
+				return err
+			}
+			game, err = gR.CreateGame(ctx, game)
+			if err != nil {
+				return err
+			}
+			return scheduleJoinTimeout(ctx, unit, session, game.IDtoUUID(), game.CreatedAt())
+		})
+		if err != nil {
+			return nil, uow.ErrFailedToDoTransaction(operationName, err)
+		}
+
+		return ResponseChain{
+			&EditMessageTextResponse{
+				InlineMessageID: query.InlineMessageID,
+				Text:            msgs.HangmanStart(user, game.Language(), session.Bet()),
+				ParseMode:       "HTML",
+				ReplyMarkup:     buildHangmanWaitingKeyboard(&game),
+			},
+			&CallbackQueryResponse{
+				CallbackQueryID: query.ID,
+				Text:            "Игра создана! Ждём игроков...",
+			},
+		}, nil
+	}
+}
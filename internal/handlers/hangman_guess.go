@@ -0,0 +1,293 @@
+package handlers
+
+import (
+	"fmt"
+	"log/slog"
+	"microgame-bot/internal/core/logger"
+	"microgame-bot/internal/domain"
+	domainBet "microgame-bot/internal/domain/bet"
+	"microgame-bot/internal/domain/hangman"
+	domainSession "microgame-bot/internal/domain/session"
+	domainUser "microgame-bot/internal/domain/user"
+	"microgame-bot/internal/msgs"
+	"microgame-bot/internal/queue"
+	sRepository "microgame-bot/internal/repo/session"
+	userRepository "microgame-bot/internal/repo/user"
+	"microgame-bot/internal/uow"
+	"microgame-bot/internal/utils"
+
+	"github.com/mymmrac/telego"
+	th "github.com/mymmrac/telego/telegohandler"
+)
+
+// HangmanGuess names the letter on behalf of the player whose turn it is.
+func HangmanGuess(
+	userGetter userRepository.IUserGetter,
+	unit uow.IUnitOfWork,
+) CallbackQueryHandlerFunc {
+	const operationName = "handler::hangman_guess"
+	return func(ctx *th.Context, query telego.CallbackQuery) (IResponse, error) {
+		slog.DebugContext(ctx, "Hangman guess callback received", logger.OperationField, operationName)
+
+		player, err := userFromContext(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user from context in %s: %w", operationName, err)
+		}
+
+		gameID, err := extractGameID[hangman.ID](query.Data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract game ID from callback data in %s: %w", operationName, err)
+		}
+
+		rawCtx := ctx.Context()
+		rawCtx = logger.WithLogValue(rawCtx, logger.GameIDField, utils.UUIDString(gameID))
+		ctx = ctx.WithContext(rawCtx)
+
+		letter, err := extractHangmanLetter(query.Data)
+		if err != nil {
+			return nil, err
+		}
+		page := extractHangmanPage(query.Data)
+
+		var game hangman.Hangman
+		var occurrences int
+		err = unit.Do(ctx, func(uow uow.IUnitOfWork) error {
+			gameRepo, err := uow.HangmanRepo()
+			if err != nil {
+				return fmt.Errorf("failed to get game repository in %s: %w", operationName, err)
+			}
+			game, err = gameRepo.GameByIDLocked(ctx, gameID)
+			if err != nil {
+				return fmt.Errorf("failed to get game by ID with lock in %s: %w", operationName, err)
+			}
+
+			game, occurrences, err = game.Guess(player.ID(), letter)
+			if err != nil {
+				return fmt.Errorf("failed to guess letter in %s: %w", operationName, err)
+			}
+
+			game, err = gameRepo.UpdateGame(ctx, game)
+			if err != nil {
+				return fmt.Errorf("failed to update game in %s: %w", operationName, err)
+			}
+
+			if game.IsFinished() {
+				return nil
+			}
+			// The guess restarts the clock.
+			return scheduleGameMoveTimeout(ctx, uow, game.SessionID(), game.IDtoUUID(), game.UpdatedAt())
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed do transaction in %s: %w", operationName, err)
+		}
+
+		var gsGetter sRepository.ISessionGetter
+		gsGetter, err = unit.SessionRepo()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get game session repository in %s: %w", operationName, err)
+		}
+
+		session, err := gsGetter.SessionByID(ctx, game.SessionID())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get game session by ID in %s: %w", operationName, err)
+		}
+
+		gameGetter, err := unit.HangmanRepo()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get game repository in %s: %w", operationName, err)
+		}
+		allGames, err := gameGetter.GamesBySessionID(ctx, session.ID())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get games by session ID: %w", err)
+		}
+
+		games := make([]domainSession.IGame, len(allGames))
+		for i, g := range allGames {
+			games[i] = g
+		}
+
+		manager := domainSession.NewManager(session, games)
+		result := manager.CalculateResult()
+
+		player1, err := userGetter.UserByID(ctx, game.Player1ID())
+		if err != nil {
+			return nil, err
+		}
+
+		player2, err := userGetter.UserByID(ctx, game.Player2ID())
+		if err != nil {
+			return nil, err
+		}
+
+		guessed := &CallbackQueryResponse{
+			CallbackQueryID: query.ID,
+			Text:            msgs.HangmanGuessed(hangman.NormalizeLetter(letter), occurrences),
+		}
+
+		if !game.IsFinished() {
+			// The letter keyboard stays on the page it was named from.
+			return ResponseChain{
+				&EditMessageTextResponse{
+					InlineMessageID: query.InlineMessageID,
+					Text: msgs.HangmanRound(
+						allGames,
+						game,
+						player1,
+						player2,
+						result.Scores[player1.ID()],
+						result.Scores[player2.ID()],
+						result.Draws,
+						session.Bet(),
+					),
+					ParseMode:   "HTML",
+					ReplyMarkup: buildHangmanLettersKeyboard(&game, page, session.MoveDeadline(game.UpdatedAt())),
+				},
+				guessed,
+			}, nil
+		}
+
+		if result.IsCompleted {
+			err = unit.Do(ctx, func(uow uow.IUnitOfWork) error {
+				gsRepo, err := uow.SessionRepo()
+				if err != nil {
+					return fmt.Errorf("failed to get game session repository in %s: %w", operationName, err)
+				}
+				betRepo, err := uow.BetRepo()
+				if err != nil {
+					return fmt.Errorf("failed to get bet repository in %s: %w", operationName, err)
+				}
+
+				session, err = session.ChangeStatus(domain.GameStatusFinished)
+				if err != nil {
+					return fmt.Errorf("failed to change status of game session: %w", err)
+				}
+				session, err = gsRepo.UpdateSession(ctx, session)
+				if err != nil {
+					return fmt.Errorf("failed to update game session: %w", err)
+				}
+
+				// Update bets status: RUNNING -> WAITING
+				if session.Bet() > 0 {
+					err = betRepo.UpdateBetsStatusBatch(ctx, session.ID(), domainBet.StatusWaiting)
+					if err != nil {
+						return fmt.Errorf("failed to update bets status in %s: %w", operationName, err)
+					}
+					outbox, err := uow.Outbox()
+					if err != nil {
+						return fmt.Errorf("failed to get task outbox in %s: %w", operationName, err)
+					}
+					if err := queue.PublishPayoutTask(ctx, outbox); err != nil {
+						return fmt.Errorf("failed to publish payout task in %s: %w", operationName, err)
+					}
+				}
+
+				return nil
+			})
+			if err != nil {
+				return nil, uow.ErrFailedToDoTransaction(operationName, err)
+			}
+
+			var msg string
+			if result.IsDraw {
+				msg = msgs.HangmanSeriesDraw(
+					allGames,
+					player1,
+					player2,
+					result.Scores[player1.ID()],
+					result.Scores[player2.ID()],
+					result.Draws,
+				)
+			} else {
+				var winner domainUser.User
+				if result.SeriesWinners[0] == player1.ID() {
+					winner = player1
+				} else {
+					winner = player2
+				}
+				msg = msgs.HangmanSeriesCompleted(
+					allGames,
+					player1,
+					player2,
+					result.Scores[player1.ID()],
+					result.Scores[player2.ID()],
+					result.Draws,
+					winner,
+				)
+			}
+
+			return ResponseChain{
+				&EditMessageTextResponse{
+					InlineMessageID: query.InlineMessageID,
+					Text:            msg,
+					ParseMode:       "HTML",
+				},
+				guessed,
+			}, nil
+		}
+
+		// The round is over but the series goes on
+		nextGame := game
+		if result.NeedsNewRound {
+			err = unit.Do(ctx, func(uow uow.IUnitOfWork) error {
+				gameRepo, err := uow.HangmanRepo()
+				if err != nil {
+					return fmt.Errorf("failed to get game repository in %s: %w", operationName, err)
+				}
+				// Rounds alternate the player naming the first letter
+				firstTurn := game.Player1ID()
+				if len(allGames)%2 == 1 {
+					firstTurn = game.Player2ID()
+				}
+				nextGameID := hangman.ID(utils.NewUniqueID())
+				word, err := hangman.RandomWord(game.Language(), session.Seed(), nextGameID.String())
+				if err != nil {
+					return fmt.Errorf("failed to pick word in %s: %w", operationName, err)
+				}
+				nextGame, err = hangman.New(
+					hangman.WithID(nextGameID),
+					hangman.WithSessionID(session.ID()),
+					hangman.WithCreatorID(game.CreatorID()),
+					hangman.WithLanguage(game.Language()),
+					hangman.WithWord(word),
+					hangman.WithPlayer1ID(game.Player1ID()),
+					hangman.WithPlayer2ID(game.Player2ID()),
+					hangman.WithTurn(firstTurn),
+					hangman.WithStatus(domain.GameStatusInProgress),
+				)
+				if err != nil {
+					return fmt.Errorf("failed to create new game in %s: %w", operationName, err)
+				}
+
+				nextGame, err = gameRepo.CreateGame(ctx, nextGame)
+				if err != nil {
+					return fmt.Errorf("failed to store new game in %s: %w", operationName, err)
+				}
+
+				return scheduleMoveTimeout(ctx, uow, session, nextGame.IDtoUUID(), nextGame.UpdatedAt())
+			})
+			if err != nil {
+				return nil, uow.ErrFailedToDoTransaction(operationName, err)
+			}
+			allGames = append(allGames, nextGame)
+		}
+
+		return ResponseChain{
+			&EditMessageTextResponse{
+				InlineMessageID: query.InlineMessageID,
+				Text: msgs.HangmanRound(
+					allGames,
+					nextGame,
+					player1,
+					player2,
+					result.Scores[player1.ID()],
+					result.Scores[player2.ID()],
+					result.Draws,
+					session.Bet(),
+				),
+				ParseMode:   "HTML",
+				ReplyMarkup: BuildHangmanGameBoardKeyboard(&nextGame, session.MoveDeadline(nextGame.UpdatedAt())),
+			},
+			guessed,
+		}, nil
+	}
+}
@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"fmt"
+	"log/slog"
+	"microgame-bot/internal/core/logger"
+	"microgame-bot/internal/domain"
+	"microgame-bot/internal/domain/hangman"
+	"microgame-bot/internal/uow"
+	"slices"
+
+	"github.com/mymmrac/telego"
+	th "github.com/mymmrac/telego/telegohandler"
+)
+
+// HangmanPage switches the page of the letter keyboard.
+// The page lives in the keyboard only, switching it doesn't touch the game and doesn't restart the clock.
+func HangmanPage(unit uow.IUnitOfWork) CallbackQueryHandlerFunc {
+	const operationName = "handler::hangman_page"
+	return func(ctx *th.Context, query telego.CallbackQuery) (IResponse, error) {
+		slog.DebugContext(ctx, "Hangman page callback received", logger.OperationField, operationName)
+
+		player, err := userFromContext(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user from context in %s: %w", operationName, err)
+		}
+
+		gameID, err := extractGameID[hangman.ID](query.Data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract game ID from callback data in %s: %w", operationName, err)
+		}
+
+		gameGetter, err := unit.HangmanRepo()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get game repository in %s: %w", operationName, err)
+		}
+		game, err := gameGetter.GameByID(ctx, gameID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get game by ID in %s: %w", operationName, err)
+		}
+		if game.IsFinished() {
+			return nil, domain.ErrGameOver
+		}
+		if !slices.Contains(game.Participants(), player.ID()) {
+			return nil, domain.ErrPlayerNotInGame
+		}
+
+		sessionGetter, err := unit.SessionRepo()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get game session repository in %s: %w", operationName, err)
+		}
+		session, err := sessionGetter.SessionByID(ctx, game.SessionID())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get game session by ID in %s: %w", operationName, err)
+		}
+
+		return ResponseChain{
+			&EditMessageReplyMarkupResponse{
+				InlineMessageID: query.InlineMessageID,
+				ReplyMarkup: buildHangmanLettersKeyboard(
+					&game,
+					extractHangmanPage(query.Data),
+					session.MoveDeadline(game.UpdatedAt()),
+				),
+			},
+			&CallbackQueryResponse{
+				CallbackQueryID: query.ID,
+			},
+		}, nil
+	}
+}
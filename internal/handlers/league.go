@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"microgame-bot/internal/domain"
+	"microgame-bot/internal/domain/league"
+	domainUser "microgame-bot/internal/domain/user"
+	"microgame-bot/internal/msgs"
+	userRepository "microgame-bot/internal/repo/user"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mymmrac/telego"
+	tu "github.com/mymmrac/telego/telegoutil"
+)
+
+const (
+	// leagueQueryKeyword opens the league creation in the inline selector.
+	leagueQueryKeyword = "league"
+	// fixtureQueryKeyword posts a league fixture, buttons of the table fill it in.
+	fixtureQueryKeyword = "fixture"
+)
+
+// BuildLeagueMessage renders the league message for its current status.
+func BuildLeagueMessage(
+	ctx context.Context,
+	userGetter userRepository.IUserGetter,
+	l league.League,
+) (string, *telego.InlineKeyboardMarkup, error) {
+	organizer, err := userGetter.UserByID(ctx, l.OrganizerID())
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to get league organizer: %w", err)
+	}
+
+	users, err := LeaguePlayers(ctx, userGetter, l)
+	if err != nil {
+		return "", nil, err
+	}
+
+	switch l.Status() {
+	case domain.GameStatusWaitingForPlayers:
+		return msgs.LeagueRegistration(l, organizer, users), buildLeagueRegistrationKeyboard(&l), nil
+	case domain.GameStatusInProgress:
+		return msgs.LeagueTable(l, organizer, users), buildLeagueFixturesKeyboard(&l), nil
+	case domain.GameStatusFinished:
+		return msgs.LeagueFinished(l, organizer, users), nil, nil
+	default:
+		return msgs.LeagueCancelled(l, organizer), nil, nil
+	}
+}
+
+// LeaguePlayers loads every registered player of the league.
+func LeaguePlayers(
+	ctx context.Context,
+	userGetter userRepository.IUserGetter,
+	l league.League,
+) (map[domainUser.ID]domainUser.User, error) {
+	users := make(map[domainUser.ID]domainUser.User, len(l.Players()))
+	for _, playerID := range l.Players() {
+		player, err := userGetter.UserByID(ctx, playerID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get league player: %w", err)
+		}
+		users[playerID] = player
+	}
+	return users, nil
+}
+
+func buildLeagueRegistrationKeyboard(l *league.League) *telego.InlineKeyboardMarkup {
+	return tu.InlineKeyboard(
+		tu.InlineKeyboardRow(
+			tu.InlineKeyboardButton("✋ Участвовать").
+				WithCallbackData("g::league::join::"+l.ID().String()),
+		),
+		tu.InlineKeyboardRow(
+			tu.InlineKeyboardButton("🚀 Запустить").
+				WithCallbackData("g::league::start::"+l.ID().String()),
+			tu.InlineKeyboardButton("❌ Отменить").
+				WithCallbackData("g::league::cancel::"+l.ID().String()),
+		),
+	)
+}
+
+// buildLeagueFixturesKeyboard returns a button for every fixture that can be started.
+// The button prefills the inline query, so the fixture is posted to the same chat as the table.
+func buildLeagueFixturesKeyboard(l *league.League) *telego.InlineKeyboardMarkup {
+	pending := l.PendingFixtures()
+	if len(pending) == 0 {
+		return nil
+	}
+
+	const buttonsPerRow = 4
+	rows := make([][]telego.InlineKeyboardButton, 0, (len(pending)+buttonsPerRow-1)/buttonsPerRow)
+	for i, number := range pending {
+		button := tu.InlineKeyboardButton(fmt.Sprintf("⚔️ %d", number+1)).
+			WithSwitchInlineQueryCurrentChat(fmt.Sprintf("%s %s %d", fixtureQueryKeyword, l.ID().String(), number))
+		if i%buttonsPerRow == 0 {
+			rows = append(rows, tu.InlineKeyboardRow(button))
+		} else {
+			rows[len(rows)-1] = append(rows[len(rows)-1], button)
+		}
+	}
+
+	return tu.InlineKeyboard(rows...)
+}
+
+// leagueParams are the settings of a new league taken from the create callback:
+// create::league::<game>::<rounds>::<days>.
+type leagueParams struct {
+	gameType  domain.GameType
+	gameCount int
+	duration  time.Duration
+}
+
+func extractLeagueParams(callbackData string, maxGameCount int) (leagueParams, error) {
+	parts := strings.Split(callbackData, "::")
+	//nolint:mnd // Callback data params is constant.
+	if len(parts) < 5 {
+		return leagueParams{}, ErrInvalidCallbackData
+	}
+
+	params := leagueParams{gameType: domain.GameType(parts[2])}
+	if params.gameType != domain.GameTypeTTT && params.gameType != domain.GameTypeRPS {
+		return leagueParams{}, domain.ErrInvalidGameType
+	}
+
+	params.gameCount = 1
+	if gameCount, err := strconv.Atoi(parts[3]); err == nil && gameCount > 0 {
+		params.gameCount = min(gameCount, maxGameCount)
+	}
+
+	params.duration = league.DefaultDuration
+	if days, err := strconv.Atoi(parts[4]); err == nil && days > 0 {
+		//nolint:mnd // Hours in a day.
+		params.duration = league.ClampDuration(time.Duration(days) * 24 * time.Hour)
+	}
+
+	return params, nil
+}
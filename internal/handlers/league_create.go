@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"fmt"
+	"log/slog"
+	"microgame-bot/internal/core"
+	"microgame-bot/internal/core/logger"
+	"microgame-bot/internal/domain/league"
+	userRepository "microgame-bot/internal/repo/user"
+	"microgame-bot/internal/uow"
+
+	"github.com/mymmrac/telego"
+	th "github.com/mymmrac/telego/telegohandler"
+)
+
+// LeagueCreate opens registration for a new league.
+func LeagueCreate(
+	userGetter userRepository.IUserGetter,
+	unit uow.IUnitOfWork,
+	cfg core.AppConfig,
+) CallbackQueryHandlerFunc {
+	const operationName = "handlers::league_create"
+	l := slog.With(slog.String(logger.OperationField, operationName))
+	return func(ctx *th.Context, query telego.CallbackQuery) (IResponse, error) {
+		l.DebugContext(ctx, "Create league callback received")
+
+		organizer, err := userFromContext(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user from context in %s: %w", operationName, err)
+		}
+
+		if query.InlineMessageID == "" {
+			return nil, core.ErrInvalidUpdate
+		}
+
+		params, err := extractLeagueParams(query.Data, cfg.MaxGameCount)
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract league params in %s: %w", operationName, err)
+		}
+
+		lg, err := league.New(
+			league.WithNewID(),
+			league.WithOrganizerID(organizer.ID()),
+			league.WithGameType(params.gameType),
+			league.WithInlineMessageIDFromString(query.InlineMessageID),
+			league.WithGameCount(params.gameCount),
+			league.WithDuration(params.duration),
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		err = unit.Do(ctx, func(unit uow.IUnitOfWork) error {
+			leagueRepo, err := unit.LeagueRepo()
+			if err != nil {
+				return fmt.Errorf("failed to get league repository in %s: %w", operationName, err)
+			}
+			lg, err = leagueRepo.CreateLeague(ctx, lg)
+			if err != nil {
+				return fmt.Errorf("failed to create league in %s: %w", operationName, err)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, uow.ErrFailedToDoTransaction(operationName, err)
+		}
+
+		msg, markup, err := BuildLeagueMessage(ctx, userGetter, lg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build league message in %s: %w", operationName, err)
+		}
+
+		return ResponseChain{
+			&EditMessageTextResponse{
+				InlineMessageID: query.InlineMessageID,
+				Text:            msg,
+				ParseMode:       "HTML",
+				ReplyMarkup:     markup,
+			},
+			&CallbackQueryResponse{
+				CallbackQueryID: query.ID,
+				Text:            "Лига создана! Ждём участников...",
+			},
+		}, nil
+	}
+}
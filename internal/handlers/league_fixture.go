@@ -0,0 +1,144 @@
+package handlers
+
+import (
+	"fmt"
+	"log/slog"
+	"microgame-bot/internal/core"
+	"microgame-bot/internal/core/logger"
+	"microgame-bot/internal/domain"
+	"microgame-bot/internal/domain/league"
+	domainSession "microgame-bot/internal/domain/session"
+	"microgame-bot/internal/msgs"
+	userRepository "microgame-bot/internal/repo/user"
+	"microgame-bot/internal/uow"
+	"time"
+
+	"github.com/mymmrac/telego"
+	th "github.com/mymmrac/telego/telegohandler"
+)
+
+// LeagueFixtureStart starts a league fixture in the message it was posted to.
+// The fixture session is tagged with the league ID, its result is picked up by the league advance task.
+func LeagueFixtureStart(
+	userGetter userRepository.IUserGetter,
+	unit uow.IUnitOfWork,
+) CallbackQueryHandlerFunc {
+	const operationName = "handlers::league_fixture_start"
+	l := slog.With(slog.String(logger.OperationField, operationName))
+	return func(ctx *th.Context, query telego.CallbackQuery) (IResponse, error) {
+		l.DebugContext(ctx, "League fixture start callback received")
+
+		player, err := userFromContext(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user from context in %s: %w", operationName, err)
+		}
+
+		if query.InlineMessageID == "" {
+			return nil, core.ErrInvalidUpdate
+		}
+
+		leagueID, err := extractGameID[league.ID](query.Data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract league ID from callback data in %s: %w", operationName, err)
+		}
+		number, err := extractMatchNumber(query.Data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract fixture number from callback data in %s: %w", operationName, err)
+		}
+
+		var lg league.League
+		var session domainSession.Session
+		var game SeatedGame
+		err = unit.Do(ctx, func(unit uow.IUnitOfWork) error {
+			leagueRepo, err := unit.LeagueRepo()
+			if err != nil {
+				return fmt.Errorf("failed to get league repository in %s: %w", operationName, err)
+			}
+			sessionRepo, err := unit.SessionRepo()
+			if err != nil {
+				return fmt.Errorf("failed to get session repository in %s: %w", operationName, err)
+			}
+
+			lg, err = leagueRepo.LeagueByIDLocked(ctx, leagueID)
+			if err != nil {
+				return fmt.Errorf("failed to get league by ID with lock in %s: %w", operationName, err)
+			}
+
+			fixture, err := lg.Fixture(number)
+			if err != nil {
+				return err
+			}
+			if !fixture.HasPlayer(player.ID()) {
+				return domain.ErrPlayerNotInGame
+			}
+
+			session, err = domainSession.New(
+				domainSession.WithNewID(),
+				domainSession.WithGameType(lg.GameType()),
+				domainSession.WithInlineMessageIDFromString(query.InlineMessageID),
+				domainSession.WithGameCount(lg.GameCount()),
+				domainSession.WithWinCondition(domainSession.WinConditionFirstTo),
+				domainSession.WithMoveTimeout(league.FixtureMoveTimeout),
+				domainSession.WithStatus(domain.GameStatusInProgress),
+				domainSession.WithLeagueID(lg.ID().UUID()),
+				domainSession.WithNewSeed(),
+			)
+			if err != nil {
+				return err
+			}
+
+			lg, err = lg.StartFixture(number, session.ID(), time.Now())
+			if err != nil {
+				return err
+			}
+
+			session, err = sessionRepo.CreateSession(ctx, session)
+			if err != nil {
+				return fmt.Errorf("failed to create fixture session in %s: %w", operationName, err)
+			}
+
+			game, err = CreateSeatedGame(ctx, unit, session, fixture.Home, fixture.Away)
+			if err != nil {
+				return fmt.Errorf("failed to create fixture game in %s: %w", operationName, err)
+			}
+
+			lg, err = leagueRepo.UpdateLeague(ctx, lg)
+			if err != nil {
+				return fmt.Errorf("failed to update league in %s: %w", operationName, err)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, uow.ErrFailedToDoTransaction(operationName, err)
+		}
+
+		msg, boardKeyboard, err := game.Start(ctx, userGetter, player, session)
+		if err != nil {
+			return nil, fmt.Errorf("failed to start fixture game in %s: %w", operationName, err)
+		}
+
+		tableMsg, tableKeyboard, err := BuildLeagueMessage(ctx, userGetter, lg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build league message in %s: %w", operationName, err)
+		}
+
+		return ResponseChain{
+			&EditMessageTextResponse{
+				InlineMessageID: query.InlineMessageID,
+				Text:            msgs.LeagueFixtureHeader(number) + msg,
+				ParseMode:       "HTML",
+				ReplyMarkup:     boardKeyboard,
+			},
+			&EditMessageTextResponse{
+				InlineMessageID: lg.InlineMessageID().String(),
+				Text:            tableMsg,
+				ParseMode:       "HTML",
+				ReplyMarkup:     tableKeyboard,
+			},
+			&CallbackQueryResponse{
+				CallbackQueryID: query.ID,
+				Text:            "Матч начался!",
+			},
+		}, nil
+	}
+}
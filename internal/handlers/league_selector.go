@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"fmt"
+	"microgame-bot/internal/core"
+	"microgame-bot/internal/domain"
+	"microgame-bot/internal/domain/league"
+	"strconv"
+
+	"github.com/mymmrac/telego"
+	tu "github.com/mymmrac/telego/telegoutil"
+)
+
+// leagueSelector offers league creation: league <rounds> <days>.
+// Leagues are played in group chats only, elsewhere nobody could register.
+func leagueSelector(query telego.InlineQuery, args []string, cfg core.AppConfig) IResponse {
+	if query.ChatType != telego.ChatTypeGroup && query.ChatType != telego.ChatTypeSupergroup {
+		return &InlineQueryResponse{
+			QueryID: query.ID,
+			Results: []telego.InlineQueryResult{
+				tu.ResultArticle(
+					"league::unavailable",
+					"📅 Лиги доступны только в групповых чатах",
+					tu.TextMessage("📅 Лиги проводятся в групповых чатах."),
+				),
+			},
+			CacheTime: 1,
+		}
+	}
+
+	gameCount := 1
+	//nolint:mnd // Hours in a day.
+	days := int(league.DefaultDuration.Hours() / 24)
+	if len(args) > 0 {
+		if parsed, err := strconv.Atoi(args[0]); err == nil && parsed > 0 {
+			gameCount = min(parsed, cfg.MaxGameCount)
+		}
+	}
+	if len(args) > 1 {
+		//nolint:mnd // Hours in a day.
+		if parsed, err := strconv.Atoi(args[1]); err == nil && parsed > 0 {
+			days = min(parsed, int(league.MaxDuration.Hours()/24))
+		}
+	}
+
+	label := fmt.Sprintf("(до %d побед, %d дн.)", gameCount, days)
+
+	article := func(gameType domain.GameType, title string) telego.InlineQueryResult {
+		msg := fmt.Sprintf("📅 <b>Лига: %s</b>\n<i>%s</i>\n\nНажми кнопку, чтобы открыть регистрацию!", title, label)
+		data := fmt.Sprintf("create::league::%s::%d::%d", gameType, gameCount, days)
+		return tu.ResultArticle(
+			"league::"+gameType.String(),
+			"📅 Лига: "+title+" "+label,
+			tu.TextMessage(msg).WithParseMode("HTML"),
+		).WithReplyMarkup(tu.InlineKeyboard(
+			tu.InlineKeyboardRow(
+				tu.InlineKeyboardButton("📅 Открыть регистрацию").WithCallbackData(data),
+			),
+		))
+	}
+
+	return &InlineQueryResponse{
+		QueryID: query.ID,
+		Results: []telego.InlineQueryResult{
+			article(domain.GameTypeTTT, "Крестики-Нолики"),
+			article(domain.GameTypeRPS, "Камень-Ножницы-Бумага"),
+		},
+		CacheTime: 1,
+	}
+}
+
+// fixtureSelector posts a league fixture: fixture <league id> <fixture number>.
+// The query is filled in by the table buttons, the fixture itself is validated when it is started.
+func fixtureSelector(query telego.InlineQuery, args []string) IResponse {
+	//nolint:mnd // League ID and fixture number.
+	if len(args) < 2 {
+		return nil
+	}
+	id, number := args[0], args[1]
+	if _, err := strconv.Atoi(number); err != nil {
+		return nil
+	}
+
+	return &InlineQueryResponse{
+		QueryID: query.ID,
+		Results: []telego.InlineQueryResult{
+			tu.ResultArticle(
+				"league::fixture",
+				"⚔️ Матч лиги",
+				tu.TextMessage("⚔️ <b>Матч лиги</b>\n\nИгроки матча начинают его кнопкой ниже!").
+					WithParseMode("HTML"),
+			).WithReplyMarkup(tu.InlineKeyboard(
+				tu.InlineKeyboardRow(
+					tu.InlineKeyboardButton("⚔️ Начать матч").
+						WithCallbackData("g::league::play::" + id + "::" + number),
+				),
+			)),
+		},
+		CacheTime: 1,
+	}
+}
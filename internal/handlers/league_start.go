@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"fmt"
+	"log/slog"
+	"microgame-bot/internal/core/logger"
+	"microgame-bot/internal/domain/league"
+	userRepository "microgame-bot/internal/repo/user"
+	"microgame-bot/internal/uow"
+	"time"
+
+	"github.com/mymmrac/telego"
+	th "github.com/mymmrac/telego/telegohandler"
+)
+
+// LeagueStart closes registration on behalf of the organizer and publishes the fixtures.
+func LeagueStart(
+	userGetter userRepository.IUserGetter,
+	unit uow.IUnitOfWork,
+) CallbackQueryHandlerFunc {
+	const operationName = "handlers::league_start"
+	l := slog.With(slog.String(logger.OperationField, operationName))
+	return func(ctx *th.Context, query telego.CallbackQuery) (IResponse, error) {
+		l.DebugContext(ctx, "League start callback received")
+
+		user, err := userFromContext(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user from context in %s: %w", operationName, err)
+		}
+
+		leagueID, err := extractGameID[league.ID](query.Data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract league ID from callback data in %s: %w", operationName, err)
+		}
+
+		var lg league.League
+		err = unit.Do(ctx, func(unit uow.IUnitOfWork) error {
+			leagueRepo, err := unit.LeagueRepo()
+			if err != nil {
+				return fmt.Errorf("failed to get league repository in %s: %w", operationName, err)
+			}
+
+			lg, err = leagueRepo.LeagueByIDLocked(ctx, leagueID)
+			if err != nil {
+				return fmt.Errorf("failed to get league by ID with lock in %s: %w", operationName, err)
+			}
+
+			lg, err = lg.Start(user.ID(), time.Now())
+			if err != nil {
+				return err
+			}
+
+			lg, err = leagueRepo.UpdateLeague(ctx, lg)
+			if err != nil {
+				return fmt.Errorf("failed to update league in %s: %w", operationName, err)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, uow.ErrFailedToDoTransaction(operationName, err)
+		}
+
+		msg, markup, err := BuildLeagueMessage(ctx, userGetter, lg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build league message in %s: %w", operationName, err)
+		}
+
+		return ResponseChain{
+			&EditMessageTextResponse{
+				InlineMessageID: query.InlineMessageID,
+				Text:            msg,
+				ParseMode:       "HTML",
+				ReplyMarkup:     markup,
+			},
+			&CallbackQueryResponse{
+				CallbackQueryID: query.ID,
+				Text:            "Лига началась! Расписание готово.",
+			},
+		}, nil
+	}
+}
@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"fmt"
+	"log/slog"
+	"microgame-bot/internal/core"
+	"microgame-bot/internal/core/logger"
+	"microgame-bot/internal/domain"
+	"microgame-bot/internal/domain/matchmaking"
+	"microgame-bot/internal/msgs"
+	"strconv"
+	"strings"
+
+	"github.com/mymmrac/telego"
+	th "github.com/mymmrac/telego/telegohandler"
+	tu "github.com/mymmrac/telego/telegoutil"
+)
+
+// QuickPlayCommand opens the quick play menu in the private chat with the bot.
+const QuickPlayCommand = "play"
+
+// QuickPlayMenu answers the command with the game and stake choice.
+// Quick play needs the private chat, the found game is sent there.
+func QuickPlayMenu() MessageHandlerFunc {
+	const operationName = "handlers::quick_play_menu"
+	l := slog.With(slog.String(logger.OperationField, operationName))
+	return func(ctx *th.Context, message telego.Message) (IResponse, error) {
+		l.DebugContext(ctx, "Quick play command received")
+
+		if message.Chat.Type != telego.ChatTypePrivate {
+			return nil, nil
+		}
+
+		return &SendMessageResponse{
+			ChatID:      message.Chat.ID,
+			Text:        msgs.QuickPlayMenu(),
+			ParseMode:   "HTML",
+			ReplyMarkup: BuildQuickPlayMenuKeyboard(),
+		}, nil
+	}
+}
+
+// BuildQuickPlayMenuKeyboard returns a button for every game and stake of the pool.
+func BuildQuickPlayMenuKeyboard() *telego.InlineKeyboardMarkup {
+	stakes := matchmaking.Stakes()
+	rows := make([][]telego.InlineKeyboardButton, 0, len(stakes))
+	for _, stake := range stakes {
+		label := "без ставки"
+		if stake > 0 {
+			label = fmt.Sprintf("💰 %d", stake)
+		}
+		rows = append(rows, tu.InlineKeyboardRow(
+			tu.InlineKeyboardButton("❌⭕ "+label).
+				WithCallbackData(fmt.Sprintf("create::quick::%s::%d", domain.GameTypeTTT, stake)),
+			tu.InlineKeyboardButton("✂️ "+label).
+				WithCallbackData(fmt.Sprintf("create::quick::%s::%d", domain.GameTypeRPS, stake)),
+		))
+	}
+	return tu.InlineKeyboard(rows...)
+}
+
+func buildQuickPlaySearchingKeyboard(ticket *matchmaking.Ticket) *telego.InlineKeyboardMarkup {
+	return tu.InlineKeyboard(
+		tu.InlineKeyboardRow(
+			tu.InlineKeyboardButton("❌ Отменить поиск").
+				WithCallbackData("g::quick::leave::" + ticket.ID().String()),
+		),
+	)
+}
+
+// quickPlayParams are the pool settings taken from the create callback: create::quick::<game>::<stake>.
+type quickPlayParams struct {
+	gameType domain.GameType
+	stake    domain.Token
+}
+
+func extractQuickPlayParams(callbackData string) (quickPlayParams, error) {
+	parts := strings.Split(callbackData, "::")
+	//nolint:mnd // Callback data params is constant.
+	if len(parts) < 4 {
+		return quickPlayParams{}, ErrInvalidCallbackData
+	}
+
+	params := quickPlayParams{gameType: domain.GameType(parts[2])}
+	if params.gameType != domain.GameTypeTTT && params.gameType != domain.GameTypeRPS {
+		return quickPlayParams{}, domain.ErrInvalidGameType
+	}
+
+	stake, err := strconv.ParseUint(parts[3], 10, 64)
+	if err != nil || !matchmaking.IsValidStake(domain.Token(stake)) {
+		return quickPlayParams{}, matchmaking.ErrInvalidStake
+	}
+	params.stake = domain.Token(stake)
+
+	return params, nil
+}
+
+// privateMessage returns the private chat message the callback was pressed in.
+func privateMessage(query telego.CallbackQuery) (matchmaking.Message, error) {
+	if query.Message == nil || !query.Message.IsAccessible() {
+		return matchmaking.Message{}, core.ErrInvalidUpdate
+	}
+	chat := query.Message.GetChat()
+	if chat.Type != telego.ChatTypePrivate {
+		return matchmaking.Message{}, core.ErrInvalidUpdate
+	}
+	return matchmaking.Message{ChatID: chat.ID, MessageID: query.Message.GetMessageID()}, nil
+}
+
+// quickPlayMessages returns the private chat messages of the quick play game behind the inline message ID.
+// They are put into the context by the quick play middleware.
+func quickPlayMessages(ctx *th.Context, inlineMessageID string) ([]matchmaking.Message, bool) {
+	if _, ok := matchmaking.SessionIDFromInlineMessageID(domain.InlineMessageID(inlineMessageID)); !ok {
+		return nil, false
+	}
+	messages, _ := ctx.Value(core.ContextKeyQuickPlayMessages).([]matchmaking.Message)
+	return messages, true
+}
@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"fmt"
+	"log/slog"
+	"microgame-bot/internal/core/logger"
+	"microgame-bot/internal/domain"
+	"microgame-bot/internal/domain/matchmaking"
+	"microgame-bot/internal/msgs"
+	"microgame-bot/internal/uow"
+
+	"github.com/mymmrac/telego"
+	th "github.com/mymmrac/telego/telegohandler"
+)
+
+// QuickPlayJoin puts the player into the matchmaking pool and holds the stake from the balance.
+// The message the button was pressed in becomes the ticket message, the matcher reports to it.
+func QuickPlayJoin(unit uow.IUnitOfWork) CallbackQueryHandlerFunc {
+	const operationName = "handlers::quick_play_join"
+	l := slog.With(slog.String(logger.OperationField, operationName))
+	return func(ctx *th.Context, query telego.CallbackQuery) (IResponse, error) {
+		l.DebugContext(ctx, "Quick play join callback received")
+
+		player, err := userFromContext(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user from context in %s: %w", operationName, err)
+		}
+
+		message, err := privateMessage(query)
+		if err != nil {
+			return nil, err
+		}
+
+		params, err := extractQuickPlayParams(query.Data)
+		if err != nil {
+			return nil, err
+		}
+
+		var ticket matchmaking.Ticket
+		err = unit.Do(ctx, func(unit uow.IUnitOfWork) error {
+			ticketRepo, err := unit.MatchmakingRepo()
+			if err != nil {
+				return fmt.Errorf("failed to get matchmaking repository in %s: %w", operationName, err)
+			}
+			userRepo, err := unit.UserRepo()
+			if err != nil {
+				return fmt.Errorf("failed to get user repository in %s: %w", operationName, err)
+			}
+
+			// Lock the player first, so two taps can not put them into the pool twice
+			player, err := userRepo.UserByIDLocked(ctx, player.ID())
+			if err != nil {
+				return fmt.Errorf("failed to get player with lock in %s: %w", operationName, err)
+			}
+
+			waiting, err := ticketRepo.TicketsByUserID(ctx, player.ID(), matchmaking.TicketStatusWaiting)
+			if err != nil {
+				return fmt.Errorf("failed to get waiting tickets in %s: %w", operationName, err)
+			}
+			if len(waiting) > 0 {
+				return matchmaking.ErrAlreadyQueued
+			}
+
+			ticket, err = matchmaking.New(
+				matchmaking.WithNewID(),
+				matchmaking.WithUserID(player.ID()),
+				matchmaking.WithGameType(params.gameType),
+				matchmaking.WithStake(params.stake),
+				matchmaking.WithMessage(message),
+			)
+			if err != nil {
+				return err
+			}
+
+			if params.stake > 0 {
+				if player.Tokens() < params.stake {
+					return domain.ErrInsufficientTokens
+				}
+				player, err = player.SubtractTokens(params.stake)
+				if err != nil {
+					return fmt.Errorf("failed to hold stake in %s: %w", operationName, err)
+				}
+				if _, err := userRepo.UpdateUser(ctx, player); err != nil {
+					return fmt.Errorf("failed to update player in %s: %w", operationName, err)
+				}
+			}
+
+			ticket, err = ticketRepo.CreateTicket(ctx, ticket)
+			if err != nil {
+				return fmt.Errorf("failed to create ticket in %s: %w", operationName, err)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, uow.ErrFailedToDoTransaction(operationName, err)
+		}
+
+		return ResponseChain{
+			&EditMessageTextResponse{
+				ChatID:      message.ChatID,
+				MessageID:   message.MessageID,
+				Text:        msgs.QuickPlaySearching(ticket.GameType(), ticket.Stake()),
+				ParseMode:   "HTML",
+				ReplyMarkup: buildQuickPlaySearchingKeyboard(&ticket),
+			},
+			&CallbackQueryResponse{
+				CallbackQueryID: query.ID,
+				Text:            "Ищем соперника...",
+			},
+		}, nil
+	}
+}
@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"fmt"
+	"log/slog"
+	"microgame-bot/internal/core/logger"
+	"microgame-bot/internal/domain/matchmaking"
+	"microgame-bot/internal/msgs"
+	"microgame-bot/internal/uow"
+
+	"github.com/mymmrac/telego"
+	th "github.com/mymmrac/telego/telegohandler"
+)
+
+// QuickPlayLeave takes the player's ticket out of the pool and returns the held stake.
+func QuickPlayLeave(unit uow.IUnitOfWork) CallbackQueryHandlerFunc {
+	const operationName = "handlers::quick_play_leave"
+	l := slog.With(slog.String(logger.OperationField, operationName))
+	return func(ctx *th.Context, query telego.CallbackQuery) (IResponse, error) {
+		l.DebugContext(ctx, "Quick play leave callback received")
+
+		player, err := userFromContext(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user from context in %s: %w", operationName, err)
+		}
+
+		message, err := privateMessage(query)
+		if err != nil {
+			return nil, err
+		}
+
+		ticketID, err := extractGameID[matchmaking.ID](query.Data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract ticket ID from callback data in %s: %w", operationName, err)
+		}
+
+		err = unit.Do(ctx, func(unit uow.IUnitOfWork) error {
+			ticketRepo, err := unit.MatchmakingRepo()
+			if err != nil {
+				return fmt.Errorf("failed to get matchmaking repository in %s: %w", operationName, err)
+			}
+			userRepo, err := unit.UserRepo()
+			if err != nil {
+				return fmt.Errorf("failed to get user repository in %s: %w", operationName, err)
+			}
+
+			ticket, err := ticketRepo.TicketByIDLocked(ctx, ticketID)
+			if err != nil {
+				return fmt.Errorf("failed to get ticket by ID with lock in %s: %w", operationName, err)
+			}
+
+			ticket, err = ticket.Cancel(player.ID())
+			if err != nil {
+				return err
+			}
+
+			if ticket.Stake() > 0 {
+				player, err := userRepo.UserByIDLocked(ctx, player.ID())
+				if err != nil {
+					return fmt.Errorf("failed to get player with lock in %s: %w", operationName, err)
+				}
+				player, err = player.AddTokens(ticket.Stake())
+				if err != nil {
+					return fmt.Errorf("failed to return stake in %s: %w", operationName, err)
+				}
+				if _, err := userRepo.UpdateUser(ctx, player); err != nil {
+					return fmt.Errorf("failed to update player in %s: %w", operationName, err)
+				}
+			}
+
+			if _, err := ticketRepo.UpdateTicket(ctx, ticket); err != nil {
+				return fmt.Errorf("failed to update ticket in %s: %w", operationName, err)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, uow.ErrFailedToDoTransaction(operationName, err)
+		}
+
+		return ResponseChain{
+			&EditMessageTextResponse{
+				ChatID:      message.ChatID,
+				MessageID:   message.MessageID,
+				Text:        msgs.QuickPlayCancelled(),
+				ParseMode:   "HTML",
+				ReplyMarkup: BuildQuickPlayMenuKeyboard(),
+			},
+			&CallbackQueryResponse{
+				CallbackQueryID: query.ID,
+				Text:            "Поиск отменён",
+			},
+		}, nil
+	}
+}
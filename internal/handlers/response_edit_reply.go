@@ -20,6 +20,20 @@ func (r *EditMessageReplyMarkupResponse) Handle(ctx *th.Context) error {
 		MessageID:       r.MessageID,
 		ReplyMarkup:     r.ReplyMarkup,
 	}
+	// Quick play games are mirrored to the private chat of every player
+	if messages, ok := quickPlayMessages(ctx, r.InlineMessageID); ok {
+		for _, message := range messages {
+			mirrored := *params
+			mirrored.InlineMessageID = ""
+			mirrored.ChatID = telego.ChatID{ID: message.ChatID}
+			mirrored.MessageID = message.MessageID
+			if _, err := ctx.Bot().EditMessageReplyMarkup(ctx, &mirrored); err != nil && !r.SkipError {
+				return err
+			}
+		}
+		return nil
+	}
+
 	_, err := ctx.Bot().EditMessageReplyMarkup(ctx, params)
 	if err != nil && !r.SkipError {
 		return err
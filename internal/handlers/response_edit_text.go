@@ -33,6 +33,24 @@ func (r *EditMessageTextResponse) Handle(ctx *th.Context) error {
 		ReplyMarkup:        r.ReplyMarkup,
 	}
 
+	// Quick play games are mirrored to the private chat of every player
+	if messages, ok := quickPlayMessages(ctx, r.InlineMessageID); ok {
+		for _, message := range messages {
+			mirrored := *params
+			mirrored.InlineMessageID = ""
+			mirrored.ChatID = telego.ChatID{ID: message.ChatID}
+			mirrored.MessageID = message.MessageID
+			if err := editMessageText(ctx, &mirrored); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return editMessageText(ctx, params)
+}
+
+func editMessageText(ctx *th.Context, params *telego.EditMessageTextParams) error {
 	_, err := ctx.Bot().EditMessageText(ctx, params)
 	if err != nil {
 		if isMessageNotModifiedError(err) {
@@ -0,0 +1,26 @@
+package handlers
+
+import (
+	"github.com/mymmrac/telego"
+	th "github.com/mymmrac/telego/telegohandler"
+)
+
+type SendMessageResponse struct {
+	ReplyMarkup *telego.InlineKeyboardMarkup
+	Text        string
+	ParseMode   string
+	ChatID      int64
+}
+
+func (r *SendMessageResponse) Handle(ctx *th.Context) error {
+	params := &telego.SendMessageParams{
+		ChatID:    telego.ChatID{ID: r.ChatID},
+		Text:      r.Text,
+		ParseMode: r.ParseMode,
+	}
+	if r.ReplyMarkup != nil {
+		params.ReplyMarkup = r.ReplyMarkup
+	}
+	_, err := ctx.Bot().SendMessage(ctx, params)
+	return err
+}
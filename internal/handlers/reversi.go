@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"fmt"
+	"microgame-bot/internal/domain/reversi"
+	"microgame-bot/internal/msgs"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mymmrac/telego"
+	tu "github.com/mymmrac/telego/telegoutil"
+)
+
+// BuildReversiGameBoardKeyboard creates the 8x8 board, every cell is a button.
+// Legal moves of the player to move are marked, empty cells take a move, discs are not clickable.
+// Non-zero deadline is shown as a countdown below the board.
+func BuildReversiGameBoardKeyboard(game *reversi.Reversi, deadline time.Time) *telego.InlineKeyboardMarkup {
+	rows := make([][]telego.InlineKeyboardButton, 0, reversi.BoardSize+1)
+
+	legal := make(map[reversi.Cell]bool)
+	for _, cell := range game.LegalMoves() {
+		legal[cell] = true
+	}
+
+	board := game.Board()
+	for row := range reversi.BoardSize {
+		buttons := make([]telego.InlineKeyboardButton, 0, reversi.BoardSize)
+		for col := range reversi.BoardSize {
+			cell := reversi.Cell{Row: row, Col: col}
+			button := telego.InlineKeyboardButton{
+				Text:         board.Get(cell).Icon(),
+				CallbackData: "empty",
+			}
+			if board.Get(cell) == reversi.DiscEmpty && !game.IsFinished() {
+				button.CallbackData = fmt.Sprintf("g::rv::move::%s::%d", game.ID(), row*reversi.BoardSize+col)
+				if legal[cell] {
+					button.Text = reversi.LegalMoveIcon
+				}
+			}
+			buttons = append(buttons, button)
+		}
+		rows = append(rows, buttons)
+	}
+
+	if !deadline.IsZero() && !game.IsFinished() {
+		rows = append(rows, []telego.InlineKeyboardButton{
+			{
+				Text:         msgs.ClockLabel(deadline),
+				CallbackData: "empty",
+			},
+		})
+	}
+
+	return &telego.InlineKeyboardMarkup{
+		InlineKeyboard: rows,
+	}
+}
+
+// buildReversiWaitingKeyboard creates inline keyboard for a game waiting for players.
+func buildReversiWaitingKeyboard(game *reversi.Reversi) *telego.InlineKeyboardMarkup {
+	return tu.InlineKeyboard(
+		tu.InlineKeyboardRow(
+			tu.InlineKeyboardButton("Присоединиться").
+				WithCallbackData("g::rv::join::"+game.ID().String()),
+		),
+		tu.InlineKeyboardRow(
+			tu.InlineKeyboardButton("❌ Отменить").
+				WithCallbackData("g::rv::cancel::"+game.ID().String()),
+		),
+	)
+}
+
+func reversiArticle(args gameArgs) telego.InlineQueryResult {
+	msg := fmt.Sprintf(
+		"🎮 <b>%s%s Реверси</b>\n<i>%s</i>\n\nЗажимайте фишки соперника и переворачивайте их на свой цвет. "+
+			"Нажми кнопку, чтобы начать игру!",
+		reversi.DiscBlackIcon,
+		reversi.DiscWhiteIcon,
+		args.label(),
+	)
+	return tu.ResultArticle(
+		"game::rv",
+		reversi.DiscBlackIcon+reversi.DiscWhiteIcon+" Реверси "+args.label(),
+		tu.TextMessage(msg).WithParseMode("HTML"),
+	).WithReplyMarkup(tu.InlineKeyboard(
+		tu.InlineKeyboardRow(
+			tu.InlineKeyboardButton("🎯 Начать игру").
+				WithCallbackData("create::rv::" + args.callbackData()),
+		),
+	))
+}
+
+// Extracts the board cell from the move callback data: g::rv::move::<game id>::<cell number>.
+func extractReversiCell(callbackData string) (reversi.Cell, error) {
+	parts := strings.Split(callbackData, "::")
+	//nolint:mnd // Callback data params is constant.
+	if len(parts) < 5 {
+		return reversi.Cell{}, ErrInvalidCallbackData
+	}
+	number, err := strconv.Atoi(parts[4])
+	if err != nil {
+		return reversi.Cell{}, ErrInvalidCallbackData
+	}
+	cell := reversi.Cell{Row: number / reversi.BoardSize, Col: number % reversi.BoardSize}
+	if number < 0 || !cell.IsValid() {
+		return reversi.Cell{}, reversi.ErrOutOfBounds
+	}
+	return cell, nil
+}
@@ -3,14 +3,19 @@ package handlers
 import (
 	"fmt"
 	"microgame-bot/internal/domain/rps"
+	"microgame-bot/internal/msgs"
 	"strings"
+	"time"
 
 	"github.com/mymmrac/telego"
+	tu "github.com/mymmrac/telego/telegoutil"
 )
 
-func buildRPSGameBoardKeyboard(game *rps.RPS) *telego.InlineKeyboardMarkup {
+// BuildRPSGameBoardKeyboard creates inline keyboard with choices.
+// Non-zero deadline is shown as a countdown below the choices.
+func BuildRPSGameBoardKeyboard(game *rps.RPS, deadline time.Time) *telego.InlineKeyboardMarkup {
 	//nolint:mnd // Rows count is constant.
-	rows := make([][]telego.InlineKeyboardButton, 0, 3)
+	rows := make([][]telego.InlineKeyboardButton, 0, 4)
 	if game.IsFinished() {
 		return &telego.InlineKeyboardMarkup{
 			InlineKeyboard: rows,
@@ -30,11 +35,34 @@ func buildRPSGameBoardKeyboard(game *rps.RPS) *telego.InlineKeyboardMarkup {
 		rows = append(rows, []telego.InlineKeyboardButton{button})
 	}
 
+	if !deadline.IsZero() {
+		rows = append(rows, []telego.InlineKeyboardButton{
+			{
+				Text:         msgs.ClockLabel(deadline),
+				CallbackData: "empty",
+			},
+		})
+	}
+
 	return &telego.InlineKeyboardMarkup{
 		InlineKeyboard: rows,
 	}
 }
 
+// buildRPSWaitingKeyboard creates inline keyboard for a game waiting for players.
+func buildRPSWaitingKeyboard(game *rps.RPS) *telego.InlineKeyboardMarkup {
+	return tu.InlineKeyboard(
+		tu.InlineKeyboardRow(
+			tu.InlineKeyboardButton("Присоединиться").
+				WithCallbackData("g::rps::join::"+game.ID().String()),
+		),
+		tu.InlineKeyboardRow(
+			tu.InlineKeyboardButton("❌ Отменить").
+				WithCallbackData("g::rps::cancel::"+game.ID().String()),
+		),
+	)
+}
+
 func extractRPSChoice(callbackData string) (rps.Choice, error) {
 	parts := strings.Split(callbackData, "::")
 	//nolint:mnd // Callback data params is constant.
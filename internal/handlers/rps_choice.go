@@ -16,6 +16,7 @@ import (
 	userRepository "microgame-bot/internal/repo/user"
 	"microgame-bot/internal/uow"
 	"microgame-bot/internal/utils"
+	"time"
 
 	"github.com/mymmrac/telego"
 	th "github.com/mymmrac/telego/telegohandler"
@@ -24,7 +25,6 @@ import (
 func RPSChoice(
 	userGetter userRepository.IUserGetter,
 	unit uow.IUnitOfWork,
-	qPublisher queue.IQueuePublisher,
 ) CallbackQueryHandlerFunc {
 	const operationName = "handler::rps_choice"
 	return func(ctx *th.Context, query telego.CallbackQuery) (IResponse, error) {
@@ -73,21 +73,16 @@ func RPSChoice(
 				return fmt.Errorf("failed to update game in %s: %w", operationName, err)
 			}
 
-			return nil
+			if game.IsFinished() {
+				return nil
+			}
+			// The choice restarts the clock for the opponent.
+			return scheduleGameMoveTimeout(ctx, uow, game.SessionID(), game.IDtoUUID(), game.UpdatedAt())
 		})
 		if err != nil {
 			return nil, fmt.Errorf("failed do transaction in %s: %w", operationName, err)
 		}
 
-		if !game.IsFinished() {
-			return ResponseChain{
-				&CallbackQueryResponse{
-					CallbackQueryID: query.ID,
-					Text:            "Выбор сделан! Ждём второго игрока...",
-				},
-			}, nil
-		}
-
 		var gsGetter sRepository.ISessionGetter
 		gsGetter, err = unit.SessionRepo()
 		if err != nil {
@@ -99,6 +94,29 @@ func RPSChoice(
 			return nil, fmt.Errorf("failed to get game session by ID in %s: %w", operationName, err)
 		}
 
+		if !game.IsFinished() {
+			if !session.HasClock() {
+				return ResponseChain{
+					&CallbackQueryResponse{
+						CallbackQueryID: query.ID,
+						Text:            msgs.RPSChoiceCommitted(game.CommitmentOf(player.ID())),
+					},
+				}, nil
+			}
+
+			return ResponseChain{
+				&CallbackQueryResponse{
+					CallbackQueryID: query.ID,
+					Text:            msgs.RPSChoiceCommitted(game.CommitmentOf(player.ID())),
+				},
+				&EditMessageReplyMarkupResponse{
+					InlineMessageID: query.InlineMessageID,
+					ReplyMarkup:     BuildRPSGameBoardKeyboard(&game, session.MoveDeadline(game.UpdatedAt())),
+					SkipError:       true,
+				},
+			}, nil
+		}
+
 		var gameGetter rpsRepository.IRPSGetter
 		gameGetter, err = unit.RPSRepo()
 		if err != nil {
@@ -154,7 +172,13 @@ func RPSChoice(
 					if err != nil {
 						return fmt.Errorf("failed to update bets status in %s: %w", operationName, err)
 					}
-					_ = queue.PublishPayoutTask(ctx, qPublisher)
+					outbox, err := uow.Outbox()
+					if err != nil {
+						return fmt.Errorf("failed to get task outbox in %s: %w", operationName, err)
+					}
+					if err := queue.PublishPayoutTask(ctx, outbox); err != nil {
+						return fmt.Errorf("failed to publish payout task in %s: %w", operationName, err)
+					}
 				}
 
 				return nil
@@ -238,7 +262,7 @@ func RPSChoice(
 					return fmt.Errorf("failed to store new game in %s: %w", operationName, err)
 				}
 
-				return nil
+				return scheduleMoveTimeout(ctx, uow, session, nextGame.IDtoUUID(), nextGame.UpdatedAt())
 			})
 			if err != nil {
 				return nil, uow.ErrFailedToDoTransaction(operationName, err)
@@ -253,7 +277,7 @@ func RPSChoice(
 				result.Draws,
 			)
 
-			keyboard := buildRPSGameBoardKeyboard(&nextGame)
+			keyboard := BuildRPSGameBoardKeyboard(&nextGame, session.MoveDeadline(nextGame.UpdatedAt()))
 
 			return ResponseChain{
 				&EditMessageTextResponse{
@@ -281,7 +305,7 @@ func RPSChoice(
 				InlineMessageID: query.InlineMessageID,
 				Text:            msg,
 				ParseMode:       "HTML",
-				ReplyMarkup:     buildRPSGameBoardKeyboard(&game),
+				ReplyMarkup:     BuildRPSGameBoardKeyboard(&game, time.Time{}),
 			},
 			&CallbackQueryResponse{
 				CallbackQueryID: query.ID,
@@ -15,10 +15,12 @@ import (
 
 	"github.com/mymmrac/telego"
 	th "github.com/mymmrac/telego/telegohandler"
-	tu "github.com/mymmrac/telego/telegoutil"
 )
 
-func RPSCreate(unit uow.IUnitOfWork, cfg core.AppConfig) CallbackQueryHandlerFunc {
+func RPSCreate(
+	unit uow.IUnitOfWork,
+	cfg core.AppConfig,
+) CallbackQueryHandlerFunc {
 	const operationName = "handlers::rps_create"
 	l := slog.With(slog.String(logger.OperationField, operationName))
 	return func(ctx *th.Context, query telego.CallbackQuery) (IResponse, error) {
@@ -36,6 +38,7 @@ func RPSCreate(unit uow.IUnitOfWork, cfg core.AppConfig) CallbackQueryHandlerFun
 
 		gameCount := extractGameCount(query.Data, cfg.MaxGameCount)
 		betAmount := extractBetAmount(query.Data, domainBet.MaxBet)
+		moveTimeout := extractMoveTimeout(query.Data)
 
 		session, err := domainSession.New(
 			domainSession.WithNewID(),
@@ -44,6 +47,9 @@ func RPSCreate(unit uow.IUnitOfWork, cfg core.AppConfig) CallbackQueryHandlerFun
 			domainSession.WithGameCount(gameCount),
 			domainSession.WithBet(betAmount),
 			domainSession.WithWinCondition(domainSession.WinConditionFirstTo),
+			domainSession.WithMoveTimeout(moveTimeout),
+			domainSession.WithJoinTimeout(cfg.JoinTimeout),
+			domainSession.WithNewSeed(),
 		)
 		if err != nil {
 			return nil, err
@@ -74,7 +80,7 @@ func RPSCreate(unit uow.IUnitOfWork, cfg core.AppConfig) CallbackQueryHandlerFun
 			if err != nil {
 				return err
 			}
-			return nil
+			return scheduleJoinTimeout(ctx, unit, session, game.IDtoUUID(), game.CreatedAt())
 		})
 		if err != nil {
 			return nil, err
@@ -90,12 +96,7 @@ func RPSCreate(unit uow.IUnitOfWork, cfg core.AppConfig) CallbackQueryHandlerFun
 				InlineMessageID: query.InlineMessageID,
 				Text:            msg,
 				ParseMode:       "HTML",
-				ReplyMarkup: tu.InlineKeyboard(
-					tu.InlineKeyboardRow(
-						tu.InlineKeyboardButton("Присоединиться").
-							WithCallbackData("g::rps::join::" + game.ID().String()),
-					),
-				),
+				ReplyMarkup:     buildRPSWaitingKeyboard(&game),
 			},
 			&CallbackQueryResponse{
 				CallbackQueryID: query.ID,
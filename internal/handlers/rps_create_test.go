@@ -0,0 +1,110 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"os"
+	"testing"
+	"time"
+
+	"microgame-bot/internal/core"
+	"microgame-bot/internal/core/database"
+	"microgame-bot/internal/domain"
+	domainSession "microgame-bot/internal/domain/session"
+	domainUser "microgame-bot/internal/domain/user"
+	"microgame-bot/internal/handlers"
+	"microgame-bot/internal/queue"
+	gormGameRepository "microgame-bot/internal/repo/game"
+	gormRPSRepository "microgame-bot/internal/repo/game/rps"
+	gormSessionRepository "microgame-bot/internal/repo/session"
+	gormUserRepository "microgame-bot/internal/repo/user"
+	uowGorm "microgame-bot/internal/uow"
+
+	"github.com/mymmrac/telego"
+	th "github.com/mymmrac/telego/telegohandler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// postgresEnv is the database of the queue conformance suite, the test is skipped without it.
+const postgresEnv = "QUEUE_TEST_POSTGRES_URL"
+
+// testSchema keeps the tables of the test apart from the ones the queue tests truncate in parallel.
+const testSchema = "handlers_test"
+
+func TestRPSCreate_SchedulesJoinTimeout(t *testing.T) {
+	db := openPostgres(t)
+	q := queue.New(db, 1)
+	cfg := core.AppConfig{MaxGameCount: 10, JoinTimeout: 5 * time.Minute}
+
+	user, err := domainUser.New(
+		domainUser.WithNewID(),
+		domainUser.WithTelegramIDFromInt(time.Now().UnixNano()),
+		domainUser.WithFirstNameFromString("Creator"),
+	)
+	require.NoError(t, err)
+	user, err = gormUserRepository.New(db).CreateUser(t.Context(), user)
+	require.NoError(t, err)
+
+	// Built as rpsCreateUnit in cmd/main.go
+	rpsCreateUnit := uowGorm.New(db,
+		uowGorm.WithSessionRepo(gormSessionRepository.New(db)),
+		uowGorm.WithRPSRepo(gormRPSRepository.New(db)),
+		uowGorm.WithOutbox(q),
+	)
+
+	ctx := (&th.Context{}).WithContext(context.Background()).
+		WithValue(core.ContextKeyUser, user).
+		WithValue(core.ContextKeyInlineMessageID, domain.InlineMessageID("inline-"+user.ID().String()))
+	_, err = handlers.RPSCreate(rpsCreateUnit, cfg)(ctx, telego.CallbackQuery{
+		ID:              "query",
+		Data:            "create::rps",
+		InlineMessageID: "inline-" + user.ID().String(),
+	})
+	require.NoError(t, err)
+
+	var game gormGameRepository.Game
+	require.NoError(t, db.Where("creator_id = ?", user.ID()).Take(&game).Error)
+	assert.Equal(t, domain.GameStatusWaitingForPlayers, game.Status)
+
+	var task queue.Task
+	require.NoError(t, db.
+		Where("subject = ? AND payload->>'game_id' = ?", queue.GameAFKSubject, game.ID.String()).
+		Take(&task).Error)
+	var timeout domainSession.TimeoutTask
+	require.NoError(t, json.Unmarshal(task.Payload, &timeout))
+	assert.Equal(t, domainSession.TimeoutKindJoin, timeout.Kind)
+	assert.Equal(t, game.SessionID, timeout.SessionID)
+	assert.WithinDuration(t, game.CreatedAt.Add(cfg.JoinTimeout), task.RunAfter, time.Second)
+}
+
+func openPostgres(t *testing.T) *gorm.DB {
+	t.Helper()
+	dsn := os.Getenv(postgresEnv)
+	if dsn == "" {
+		t.Skip(postgresEnv + " is not set, see the task queue section of README.md")
+	}
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Discard})
+	require.NoError(t, err)
+	require.NoError(t, db.Exec("CREATE SCHEMA IF NOT EXISTS "+testSchema).Error)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	require.NoError(t, sqlDB.Close())
+
+	u, err := url.Parse(dsn)
+	require.NoError(t, err)
+	params := u.Query()
+	params.Set("search_path", testSchema)
+	u.RawQuery = params.Encode()
+
+	db, err = database.MustInit(core.Config{Postgres: core.PostgresConfig{URL: u.String()}})
+	require.NoError(t, err)
+	sqlDB, err = db.DB()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+	return db
+}
@@ -13,10 +13,12 @@ import (
 
 	"github.com/mymmrac/telego"
 	th "github.com/mymmrac/telego/telegohandler"
-	tu "github.com/mymmrac/telego/telegoutil"
 )
 
-func RPSJoin(userRepo userRepository.IUserRepository, unit uow.IUnitOfWork) CallbackQueryHandlerFunc {
+func RPSJoin(
+	userRepo userRepository.IUserRepository,
+	unit uow.IUnitOfWork,
+) CallbackQueryHandlerFunc {
 	const operationName = "handlers::rps_join"
 	l := slog.With(slog.String(logger.OperationField, operationName))
 	return func(ctx *th.Context, query telego.CallbackQuery) (IResponse, error) {
@@ -96,6 +98,9 @@ func RPSJoin(userRepo userRepository.IUserRepository, unit uow.IUnitOfWork) Call
 						return fmt.Errorf("failed to update bets status in %s: %w", operationName, err)
 					}
 				}
+				if err := scheduleMoveTimeout(ctx, uow, session, game.IDtoUUID(), game.UpdatedAt()); err != nil {
+					return err
+				}
 			}
 
 			return nil
@@ -130,12 +135,7 @@ func RPSJoin(userRepo userRepository.IUserRepository, unit uow.IUnitOfWork) Call
 					InlineMessageID: query.InlineMessageID,
 					Text:            msg,
 					ParseMode:       "HTML",
-					ReplyMarkup: tu.InlineKeyboard(
-						tu.InlineKeyboardRow(
-							tu.InlineKeyboardButton("Присоединиться").
-								WithCallbackData("g::rps::join::" + game.ID().String()),
-						),
-					),
+					ReplyMarkup:     buildRPSWaitingKeyboard(&game),
 				},
 				&CallbackQueryResponse{
 					CallbackQueryID: query.ID,
@@ -150,7 +150,7 @@ func RPSJoin(userRepo userRepository.IUserRepository, unit uow.IUnitOfWork) Call
 			return nil, fmt.Errorf("failed to get player1 by ID in %s: %w", operationName, err)
 		}
 
-		boardKeyboard := buildRPSGameBoardKeyboard(&game)
+		boardKeyboard := BuildRPSGameBoardKeyboard(&game, gameSession.MoveDeadline(game.UpdatedAt()))
 		msg, err := msgs.RPSGameStarted(player1, player2, gameSession.Bet())
 		if err != nil {
 			return nil, err
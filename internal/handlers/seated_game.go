@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"microgame-bot/internal/domain"
+	"microgame-bot/internal/domain/rps"
+	domainSession "microgame-bot/internal/domain/session"
+	"microgame-bot/internal/domain/ttt"
+	domainUser "microgame-bot/internal/domain/user"
+	"microgame-bot/internal/msgs"
+	userRepository "microgame-bot/internal/repo/user"
+	"microgame-bot/internal/uow"
+
+	"github.com/mymmrac/telego"
+)
+
+// SeatedGame is the first game of a session whose players are known in advance,
+// e.g. a tournament match, a league fixture or a quick play match.
+type SeatedGame struct {
+	ttt ttt.TTT
+	rps rps.RPS
+}
+
+// CreateSeatedGame creates the first game of the session with both players already seated
+// and schedules its move timeout. Must be called within transaction.
+func CreateSeatedGame(
+	ctx context.Context,
+	unit uow.IUnitOfWork,
+	session domainSession.Session,
+	player1 domainUser.ID,
+	player2 domainUser.ID,
+) (SeatedGame, error) {
+	switch session.GameType() {
+	case domain.GameTypeTTT:
+		gameRepo, err := unit.TTTRepo()
+		if err != nil {
+			return SeatedGame{}, fmt.Errorf("failed to get TTT repository: %w", err)
+		}
+		game, err := ttt.New(
+			ttt.WithNewID(),
+			ttt.WithSessionID(session.ID()),
+			ttt.WithCreatorID(player1),
+			ttt.WithPlayerXID(player1),
+			ttt.WithPlayerOID(player2),
+			ttt.WithStatus(domain.GameStatusInProgress),
+			ttt.WithSeed(session.Seed()),
+		)
+		if err != nil {
+			return SeatedGame{}, err
+		}
+		game, err = gameRepo.CreateGame(ctx, game.AssignPlayersRandomly())
+		if err != nil {
+			return SeatedGame{}, err
+		}
+		if err := scheduleMoveTimeout(ctx, unit, session, game.IDtoUUID(), game.UpdatedAt()); err != nil {
+			return SeatedGame{}, err
+		}
+		return SeatedGame{ttt: game}, nil
+	case domain.GameTypeRPS:
+		gameRepo, err := unit.RPSRepo()
+		if err != nil {
+			return SeatedGame{}, fmt.Errorf("failed to get RPS repository: %w", err)
+		}
+		game, err := rps.New(
+			rps.WithNewID(),
+			rps.WithSessionID(session.ID()),
+			rps.WithCreatorID(player1),
+			rps.WithPlayer1ID(player1),
+			rps.WithPlayer2ID(player2),
+			rps.WithStatus(domain.GameStatusInProgress),
+		)
+		if err != nil {
+			return SeatedGame{}, err
+		}
+		game, err = gameRepo.CreateGame(ctx, game)
+		if err != nil {
+			return SeatedGame{}, err
+		}
+		if err := scheduleMoveTimeout(ctx, unit, session, game.IDtoUUID(), game.UpdatedAt()); err != nil {
+			return SeatedGame{}, err
+		}
+		return SeatedGame{rps: game}, nil
+	default:
+		return SeatedGame{}, domain.ErrInvalidGameType
+	}
+}
+
+// Start renders the game board of the seated game.
+func (g SeatedGame) Start(
+	ctx context.Context,
+	userGetter userRepository.IUserGetter,
+	player domainUser.User,
+	session domainSession.Session,
+) (string, *telego.InlineKeyboardMarkup, error) {
+	var msg string
+	var boardKeyboard *telego.InlineKeyboardMarkup
+	switch session.GameType() {
+	case domain.GameTypeTTT:
+		playerX, err := userGetter.UserByID(ctx, g.ttt.PlayerXID())
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to get playerX by ID: %w", err)
+		}
+		playerO, err := userGetter.UserByID(ctx, g.ttt.PlayerOID())
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to get playerO by ID: %w", err)
+		}
+		msg, err = msgs.TTTGameStarted(player, playerX, playerO, session.Bet())
+		if err != nil {
+			return "", nil, err
+		}
+		boardKeyboard = BuildTTTGameBoardKeyboard(&g.ttt, playerX, playerO, session.MoveDeadline(g.ttt.UpdatedAt()))
+	case domain.GameTypeRPS:
+		player1, err := userGetter.UserByID(ctx, g.rps.Player1ID())
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to get player1 by ID: %w", err)
+		}
+		player2, err := userGetter.UserByID(ctx, g.rps.Player2ID())
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to get player2 by ID: %w", err)
+		}
+		msg, err = msgs.RPSGameStarted(player1, player2, session.Bet())
+		if err != nil {
+			return "", nil, err
+		}
+		boardKeyboard = BuildRPSGameBoardKeyboard(&g.rps, session.MoveDeadline(g.rps.UpdatedAt()))
+	default:
+		return "", nil, domain.ErrInvalidGameType
+	}
+
+	return msg, boardKeyboard, nil
+}
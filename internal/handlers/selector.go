@@ -6,68 +6,51 @@ import (
 	"microgame-bot/internal/core"
 	"microgame-bot/internal/core/logger"
 	domainBet "microgame-bot/internal/domain/bet"
+	"microgame-bot/internal/domain/dice"
+	"microgame-bot/internal/domain/hangman"
+	domainSession "microgame-bot/internal/domain/session"
+	"microgame-bot/internal/domain/trivia"
+	"microgame-bot/internal/msgs"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/mymmrac/telego"
 	th "github.com/mymmrac/telego/telegohandler"
 	tu "github.com/mymmrac/telego/telegoutil"
 )
 
-func GameSelector(cfg core.AppConfig) InlineQueryHandlerFunc {
+func GameSelector(cfg core.AppConfig, library trivia.Library) InlineQueryHandlerFunc {
 	const operationName = "handlers::game_selector"
 	l := slog.With(slog.String(logger.OperationField, operationName))
 	return func(ctx *th.Context, query telego.InlineQuery) (IResponse, error) {
 		l.DebugContext(ctx, "Inline query received")
 
-		rounds := 1
-		bet := 0
 		queryText := strings.TrimSpace(query.Query)
-		if queryText != "" {
-			fields := strings.Fields(queryText)
-			if len(fields) > 0 {
-				if parsed, err := strconv.Atoi(fields[0]); err == nil && parsed > 0 {
-					rounds = parsed
-				}
+		fields := strings.Fields(queryText)
+		if len(fields) > 0 {
+			switch strings.ToLower(fields[0]) {
+			case tournamentQueryKeyword:
+				return tournamentSelector(query, fields[1:], cfg), nil
+			case matchQueryKeyword:
+				return matchSelector(query, fields[1:]), nil
+			case leagueQueryKeyword:
+				return leagueSelector(query, fields[1:], cfg), nil
+			case fixtureQueryKeyword:
+				return fixtureSelector(query, fields[1:]), nil
+			case diceQueryKeyword:
+				return diceSelector(query, fields[1:], cfg), nil
+			case triviaQueryKeyword:
+				return triviaSelector(query, fields[1:], cfg, library), nil
 			}
-			if len(fields) > 1 {
-				if parsed, err := (strconv.Atoi(fields[1])); err == nil && parsed > 0 {
-					bet = min(parsed, int(domainBet.MaxBet))
-				}
-			}
-		}
-		if rounds > cfg.MaxGameCount {
-			rounds = cfg.MaxGameCount
-		}
-
-		roundsStr := strconv.Itoa(rounds)
-		betStr := strconv.Itoa(bet)
-		roundsLabel := fmt.Sprintf("(%d раунд", rounds)
-		switch rounds {
-		case 1:
-			roundsLabel += ")"
-		//nolint:mnd // Ending of a numeral.
-		case 2, 3, 4:
-			roundsLabel += "а)"
-		default:
-			roundsLabel += "ов)"
 		}
 
-		betLabel := ""
-		if bet > 0 {
-			betLabel = fmt.Sprintf(" 💰 %d токенов", bet)
-		}
+		args := parseGameArgs(fields, cfg)
+		argsData := args.callbackData()
+		label := args.label()
 
-		tttMsg := fmt.Sprintf(
-			"🎮 <b>Крестики-Нолики</b>\n<i>%s%s</i>\n\nНажми кнопку, чтобы начать игру!",
-			roundsLabel,
-			betLabel,
-		)
-		rpsMsg := fmt.Sprintf(
-			"🎮 <b>Камень-Ножницы-Бумага</b>\n<i>%s%s</i>\n\nНажми кнопку, чтобы начать игру!",
-			roundsLabel,
-			betLabel,
-		)
+		tttMsg := fmt.Sprintf("🎮 <b>Крестики-Нолики</b>\n<i>%s</i>\n\nНажми кнопку, чтобы начать игру!", label)
+		rpsMsg := fmt.Sprintf("🎮 <b>Камень-Ножницы-Бумага</b>\n<i>%s</i>\n\nНажми кнопку, чтобы начать игру!", label)
 
 		return &InlineQueryResponse{
 			QueryID: query.ID,
@@ -83,22 +66,31 @@ func GameSelector(cfg core.AppConfig) InlineQueryHandlerFunc {
 				)),
 				tu.ResultArticle(
 					"game::ttt",
-					"Крестики-Нолики "+roundsLabel+betLabel,
+					"Крестики-Нолики "+label,
 					tu.TextMessage(tttMsg).WithParseMode("HTML"),
 				).WithReplyMarkup(tu.InlineKeyboard(
 					tu.InlineKeyboardRow(
 						tu.InlineKeyboardButton("🎯 Начать игру").
-							WithCallbackData("create::ttt::" + roundsStr + "::" + betStr),
+							WithCallbackData("create::ttt::" + argsData),
 					),
 				)),
+				diceArticle(dice.KindDice, args),
+				blackjackArticle(args),
+				battleshipArticle(args),
+				bullsCowsArticle(args),
+				hangmanArticle(hangman.LanguageRU, args),
+				hangmanArticle(hangman.LanguageEN, args),
+				triviaArticle(library, trivia.Filter{}, args),
+				reversiArticle(args),
+				checkersArticle(args),
 				tu.ResultArticle(
 					"game::rps",
-					"Камень-Ножницы-Бумага "+roundsLabel+betLabel,
+					"Камень-Ножницы-Бумага "+label,
 					tu.TextMessage(rpsMsg).WithParseMode("HTML"),
 				).WithReplyMarkup(tu.InlineKeyboard(
 					tu.InlineKeyboardRow(
 						tu.InlineKeyboardButton("🎯 Начать игру").
-							WithCallbackData("create::rps::" + roundsStr + "::" + betStr),
+							WithCallbackData("create::rps::" + argsData),
 					),
 				)),
 			},
@@ -106,3 +98,62 @@ func GameSelector(cfg core.AppConfig) InlineQueryHandlerFunc {
 		}, nil
 	}
 }
+
+// gameArgs are the series settings typed after the bot name: <rounds> <bet> <move timeout>.
+type gameArgs struct {
+	rounds      int
+	bet         int
+	moveTimeout time.Duration
+}
+
+func parseGameArgs(fields []string, cfg core.AppConfig) gameArgs {
+	args := gameArgs{rounds: 1}
+	if len(fields) > 0 {
+		if parsed, err := strconv.Atoi(fields[0]); err == nil && parsed > 0 {
+			args.rounds = parsed
+		}
+	}
+	if len(fields) > 1 {
+		if parsed, err := (strconv.Atoi(fields[1])); err == nil && parsed > 0 {
+			args.bet = min(parsed, int(domainBet.MaxBet))
+		}
+	}
+	//nolint:mnd // Third field is the move timeout in seconds.
+	if len(fields) > 2 {
+		if parsed, err := strconv.Atoi(fields[2]); err == nil && parsed > 0 {
+			args.moveTimeout = domainSession.ClampMoveTimeout(time.Duration(parsed) * time.Second)
+		}
+	}
+	if args.rounds > cfg.MaxGameCount {
+		args.rounds = cfg.MaxGameCount
+	}
+	return args
+}
+
+// callbackData returns the settings in the order the create handlers extract them.
+func (a gameArgs) callbackData() string {
+	return strconv.Itoa(a.rounds) + "::" + strconv.Itoa(a.bet) + "::" + strconv.Itoa(int(a.moveTimeout.Seconds()))
+}
+
+func (a gameArgs) label() string {
+	roundsLabel := fmt.Sprintf("(%d раунд", a.rounds)
+	switch a.rounds {
+	case 1:
+		roundsLabel += ")"
+	//nolint:mnd // Ending of a numeral.
+	case 2, 3, 4:
+		roundsLabel += "а)"
+	default:
+		roundsLabel += "ов)"
+	}
+
+	betLabel := ""
+	if a.bet > 0 {
+		betLabel = fmt.Sprintf(" 💰 %d токенов", a.bet)
+	}
+	timeoutLabel := ""
+	if a.moveTimeout > 0 {
+		timeoutLabel = " " + msgs.MoveTimeoutLabel(a.moveTimeout)
+	}
+	return roundsLabel + betLabel + timeoutLabel
+}
@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"microgame-bot/internal/domain"
+	domainBet "microgame-bot/internal/domain/bet"
+	"microgame-bot/internal/domain/tournament"
+	domainUser "microgame-bot/internal/domain/user"
+	"microgame-bot/internal/msgs"
+	userRepository "microgame-bot/internal/repo/user"
+	"strconv"
+	"strings"
+
+	"github.com/mymmrac/telego"
+	tu "github.com/mymmrac/telego/telegoutil"
+)
+
+const (
+	// tournamentQueryKeyword opens the tournament creation in the inline selector.
+	tournamentQueryKeyword = "tour"
+	// matchQueryKeyword posts a tournament match, buttons of the bracket fill it in.
+	matchQueryKeyword = "match"
+)
+
+// BuildTournamentMessage renders the tournament message for its current status.
+func BuildTournamentMessage(
+	ctx context.Context,
+	userGetter userRepository.IUserGetter,
+	t tournament.Tournament,
+) (string, *telego.InlineKeyboardMarkup, error) {
+	organizer, err := userGetter.UserByID(ctx, t.OrganizerID())
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to get tournament organizer: %w", err)
+	}
+
+	users := make(map[domainUser.ID]domainUser.User, len(t.Players()))
+	for _, playerID := range t.Players() {
+		player, err := userGetter.UserByID(ctx, playerID)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to get tournament player: %w", err)
+		}
+		users[playerID] = player
+	}
+
+	switch t.Status() {
+	case domain.GameStatusWaitingForPlayers:
+		return msgs.TournamentRegistration(t, organizer, users), buildTournamentRegistrationKeyboard(&t), nil
+	case domain.GameStatusInProgress:
+		return msgs.TournamentBracket(t, organizer, users), buildTournamentBracketKeyboard(&t), nil
+	case domain.GameStatusFinished:
+		return msgs.TournamentFinished(t, organizer, users, t.Prizes(t.PrizeFund())), nil, nil
+	default:
+		return msgs.TournamentCancelled(t, organizer), nil, nil
+	}
+}
+
+func buildTournamentRegistrationKeyboard(t *tournament.Tournament) *telego.InlineKeyboardMarkup {
+	return tu.InlineKeyboard(
+		tu.InlineKeyboardRow(
+			tu.InlineKeyboardButton("✋ Участвовать").
+				WithCallbackData("g::tour::join::"+t.ID().String()),
+		),
+		tu.InlineKeyboardRow(
+			tu.InlineKeyboardButton("❌ Отменить").
+				WithCallbackData("g::tour::cancel::"+t.ID().String()),
+		),
+	)
+}
+
+// buildTournamentBracketKeyboard returns a button for every match that can be started.
+// The button prefills the inline query, so the match is posted to the same chat as the bracket.
+func buildTournamentBracketKeyboard(t *tournament.Tournament) *telego.InlineKeyboardMarkup {
+	pending := t.PendingMatches()
+	if len(pending) == 0 {
+		return nil
+	}
+
+	//nolint:mnd // Two match buttons per row.
+	rows := make([][]telego.InlineKeyboardButton, 0, (len(pending)+1)/2)
+	for i, number := range pending {
+		button := tu.InlineKeyboardButton(fmt.Sprintf("⚔️ Матч %d", number+1)).
+			WithSwitchInlineQueryCurrentChat(fmt.Sprintf("%s %s %d", matchQueryKeyword, t.ID().String(), number))
+		//nolint:mnd // Two match buttons per row.
+		if i%2 == 0 {
+			rows = append(rows, tu.InlineKeyboardRow(button))
+		} else {
+			rows[len(rows)-1] = append(rows[len(rows)-1], button)
+		}
+	}
+
+	return tu.InlineKeyboard(rows...)
+}
+
+// tournamentParams are the settings of a new tournament taken from the create callback:
+// create::tour::<game>::<size>::<rounds>::<fee>.
+type tournamentParams struct {
+	gameType  domain.GameType
+	size      int
+	gameCount int
+	entryFee  domain.Token
+}
+
+func extractTournamentParams(callbackData string, maxGameCount int) (tournamentParams, error) {
+	parts := strings.Split(callbackData, "::")
+	//nolint:mnd // Callback data params is constant.
+	if len(parts) < 6 {
+		return tournamentParams{}, ErrInvalidCallbackData
+	}
+
+	params := tournamentParams{gameType: domain.GameType(parts[2])}
+	if params.gameType != domain.GameTypeTTT && params.gameType != domain.GameTypeRPS {
+		return tournamentParams{}, domain.ErrInvalidGameType
+	}
+
+	size, err := strconv.Atoi(parts[3])
+	if err != nil || !tournament.IsValidSize(size) {
+		return tournamentParams{}, tournament.ErrInvalidSize
+	}
+	params.size = size
+
+	params.gameCount = 1
+	if gameCount, err := strconv.Atoi(parts[4]); err == nil && gameCount > 0 {
+		params.gameCount = min(gameCount, maxGameCount)
+	}
+
+	if fee, err := strconv.Atoi(parts[5]); err == nil && fee > 0 {
+		params.entryFee = min(domain.Token(fee), domainBet.MaxBet)
+	}
+
+	return params, nil
+}
+
+// extractMatchNumber returns the match number from g::<tour|league>::play::<id>::<number> callback data.
+func extractMatchNumber(callbackData string) (int, error) {
+	parts := strings.Split(callbackData, "::")
+	//nolint:mnd // Callback data params is constant.
+	if len(parts) < 5 {
+		return 0, ErrInvalidCallbackData
+	}
+	number, err := strconv.Atoi(parts[4])
+	if err != nil || number < 0 {
+		return 0, ErrInvalidCallbackData
+	}
+	return number, nil
+}
@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"microgame-bot/internal/core/logger"
+	"microgame-bot/internal/domain"
+	domainBet "microgame-bot/internal/domain/bet"
+	"microgame-bot/internal/domain/tournament"
+	"microgame-bot/internal/queue"
+	userRepository "microgame-bot/internal/repo/user"
+	"microgame-bot/internal/uow"
+
+	"github.com/mymmrac/telego"
+	th "github.com/mymmrac/telego/telegohandler"
+)
+
+// TournamentCancel cancels the tournament on behalf of its organizer while registration is open.
+// Entry fees are refunded by the bet payout.
+func TournamentCancel(
+	userGetter userRepository.IUserGetter,
+	unit uow.IUnitOfWork,
+) CallbackQueryHandlerFunc {
+	const operationName = "handlers::tournament_cancel"
+	l := slog.With(slog.String(logger.OperationField, operationName))
+	return func(ctx *th.Context, query telego.CallbackQuery) (IResponse, error) {
+		l.DebugContext(ctx, "Tournament cancel callback received")
+
+		user, err := userFromContext(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user from context in %s: %w", operationName, err)
+		}
+
+		tournamentID, err := extractGameID[tournament.ID](query.Data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract tournament ID from callback data in %s: %w", operationName, err)
+		}
+
+		var t tournament.Tournament
+		err = unit.Do(ctx, func(unit uow.IUnitOfWork) error {
+			tournamentRepo, err := unit.TournamentRepo()
+			if err != nil {
+				return fmt.Errorf("failed to get tournament repository in %s: %w", operationName, err)
+			}
+
+			t, err = tournamentRepo.TournamentByIDLocked(ctx, tournamentID)
+			if err != nil {
+				return fmt.Errorf("failed to get tournament by ID with lock in %s: %w", operationName, err)
+			}
+
+			t, err = t.Cancel(user.ID())
+			if err != nil {
+				return err
+			}
+
+			if err := CloseTournamentPool(ctx, unit, t, domain.GameStatusCancelled); err != nil {
+				return fmt.Errorf("failed to close prize pool in %s: %w", operationName, err)
+			}
+
+			t, err = tournamentRepo.UpdateTournament(ctx, t)
+			if err != nil {
+				return fmt.Errorf("failed to update tournament in %s: %w", operationName, err)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, uow.ErrFailedToDoTransaction(operationName, err)
+		}
+
+		msg, markup, err := BuildTournamentMessage(ctx, userGetter, t)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build tournament message in %s: %w", operationName, err)
+		}
+
+		return ResponseChain{
+			&EditMessageTextResponse{
+				InlineMessageID: query.InlineMessageID,
+				Text:            msg,
+				ParseMode:       "HTML",
+				ReplyMarkup:     markup,
+			},
+			&CallbackQueryResponse{
+				CallbackQueryID: query.ID,
+				Text:            "Турнир отменён",
+			},
+		}, nil
+	}
+}
+
+// CloseTournamentPool finishes or cancels the prize pool session of the tournament
+// and hands its bets over to the payout published through the outbox of the unit.
+// Must be called within transaction.
+func CloseTournamentPool(
+	ctx context.Context,
+	unit uow.IUnitOfWork,
+	t tournament.Tournament,
+	status domain.GameStatus,
+) error {
+	sessionRepo, err := unit.SessionRepo()
+	if err != nil {
+		return fmt.Errorf("failed to get session repository: %w", err)
+	}
+	betRepo, err := unit.BetRepo()
+	if err != nil {
+		return fmt.Errorf("failed to get bet repository: %w", err)
+	}
+
+	pool, err := sessionRepo.SessionByIDLocked(ctx, t.SessionID())
+	if err != nil {
+		return fmt.Errorf("failed to get prize pool session with lock: %w", err)
+	}
+	pool, err = pool.ChangeStatus(status)
+	if err != nil {
+		return err
+	}
+	if _, err := sessionRepo.UpdateSession(ctx, pool); err != nil {
+		return fmt.Errorf("failed to update prize pool session: %w", err)
+	}
+
+	if pool.Bet() <= 0 {
+		return nil
+	}
+	// Update bets status: PENDING/RUNNING -> WAITING
+	if err := betRepo.UpdateBetsStatusBatch(ctx, pool.ID(), domainBet.StatusWaiting); err != nil {
+		return fmt.Errorf("failed to update bets status: %w", err)
+	}
+	outbox, err := unit.Outbox()
+	if err != nil {
+		return fmt.Errorf("failed to get task outbox: %w", err)
+	}
+	if err := queue.PublishPayoutTask(ctx, outbox); err != nil {
+		return fmt.Errorf("failed to publish payout task: %w", err)
+	}
+	return nil
+}
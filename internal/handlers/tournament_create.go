@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"fmt"
+	"log/slog"
+	"microgame-bot/internal/core"
+	"microgame-bot/internal/core/logger"
+	"microgame-bot/internal/domain"
+	domainSession "microgame-bot/internal/domain/session"
+	"microgame-bot/internal/domain/tournament"
+	userRepository "microgame-bot/internal/repo/user"
+	"microgame-bot/internal/uow"
+
+	"github.com/mymmrac/telego"
+	th "github.com/mymmrac/telego/telegohandler"
+)
+
+// TournamentCreate opens registration for a new tournament.
+// The tournament message holds the prize pool session, entry fees are bets on it.
+func TournamentCreate(
+	userGetter userRepository.IUserGetter,
+	unit uow.IUnitOfWork,
+	cfg core.AppConfig,
+) CallbackQueryHandlerFunc {
+	const operationName = "handlers::tournament_create"
+	l := slog.With(slog.String(logger.OperationField, operationName))
+	return func(ctx *th.Context, query telego.CallbackQuery) (IResponse, error) {
+		l.DebugContext(ctx, "Create tournament callback received")
+
+		organizer, err := userFromContext(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user from context in %s: %w", operationName, err)
+		}
+
+		if query.InlineMessageID == "" {
+			return nil, core.ErrInvalidUpdate
+		}
+
+		params, err := extractTournamentParams(query.Data, cfg.MaxGameCount)
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract tournament params in %s: %w", operationName, err)
+		}
+
+		pool, err := domainSession.New(
+			domainSession.WithNewID(),
+			domainSession.WithGameType(domain.GameTypeTournament),
+			domainSession.WithInlineMessageIDFromString(query.InlineMessageID),
+			domainSession.WithBet(params.entryFee),
+			domainSession.WithNewSeed(),
+		)
+		if err != nil {
+			return nil, err
+		}
+		t, err := tournament.New(
+			tournament.WithNewID(),
+			tournament.WithOrganizerID(organizer.ID()),
+			tournament.WithSessionID(pool.ID()),
+			tournament.WithGameType(params.gameType),
+			tournament.WithInlineMessageIDFromString(query.InlineMessageID),
+			tournament.WithSize(params.size),
+			tournament.WithGameCount(params.gameCount),
+			tournament.WithEntryFee(params.entryFee),
+			tournament.WithSeed(pool.Seed()),
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		err = unit.Do(ctx, func(unit uow.IUnitOfWork) error {
+			sessionRepo, err := unit.SessionRepo()
+			if err != nil {
+				return fmt.Errorf("failed to get session repository in %s: %w", operationName, err)
+			}
+			tournamentRepo, err := unit.TournamentRepo()
+			if err != nil {
+				return fmt.Errorf("failed to get tournament repository in %s: %w", operationName, err)
+			}
+			if _, err := sessionRepo.CreateSession(ctx, pool); err != nil {
+				return fmt.Errorf("failed to create prize pool session in %s: %w", operationName, err)
+			}
+			t, err = tournamentRepo.CreateTournament(ctx, t)
+			if err != nil {
+				return fmt.Errorf("failed to create tournament in %s: %w", operationName, err)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, uow.ErrFailedToDoTransaction(operationName, err)
+		}
+
+		msg, markup, err := BuildTournamentMessage(ctx, userGetter, t)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build tournament message in %s: %w", operationName, err)
+		}
+
+		return ResponseChain{
+			&EditMessageTextResponse{
+				InlineMessageID: query.InlineMessageID,
+				Text:            msg,
+				ParseMode:       "HTML",
+				ReplyMarkup:     markup,
+			},
+			&CallbackQueryResponse{
+				CallbackQueryID: query.ID,
+				Text:            "Турнир создан! Ждём участников...",
+			},
+		}, nil
+	}
+}
@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"fmt"
+	"log/slog"
+	"microgame-bot/internal/core/logger"
+	"microgame-bot/internal/domain"
+	domainBet "microgame-bot/internal/domain/bet"
+	"microgame-bot/internal/domain/tournament"
+	userRepository "microgame-bot/internal/repo/user"
+	"microgame-bot/internal/uow"
+
+	"github.com/mymmrac/telego"
+	th "github.com/mymmrac/telego/telegohandler"
+)
+
+// TournamentJoin registers the player and draws the bracket once the last seat is taken.
+func TournamentJoin(
+	userGetter userRepository.IUserGetter,
+	unit uow.IUnitOfWork,
+) CallbackQueryHandlerFunc {
+	const operationName = "handlers::tournament_join"
+	l := slog.With(slog.String(logger.OperationField, operationName))
+	return func(ctx *th.Context, query telego.CallbackQuery) (IResponse, error) {
+		l.DebugContext(ctx, "Tournament join callback received")
+
+		player, err := userFromContext(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user from context in %s: %w", operationName, err)
+		}
+
+		tournamentID, err := extractGameID[tournament.ID](query.Data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract tournament ID from callback data in %s: %w", operationName, err)
+		}
+
+		var t tournament.Tournament
+		err = unit.Do(ctx, func(unit uow.IUnitOfWork) error {
+			tournamentRepo, err := unit.TournamentRepo()
+			if err != nil {
+				return fmt.Errorf("failed to get tournament repository in %s: %w", operationName, err)
+			}
+			sessionRepo, err := unit.SessionRepo()
+			if err != nil {
+				return fmt.Errorf("failed to get session repository in %s: %w", operationName, err)
+			}
+			betRepo, err := unit.BetRepo()
+			if err != nil {
+				return fmt.Errorf("failed to get bet repository in %s: %w", operationName, err)
+			}
+
+			t, err = tournamentRepo.TournamentByIDLocked(ctx, tournamentID)
+			if err != nil {
+				return fmt.Errorf("failed to get tournament by ID with lock in %s: %w", operationName, err)
+			}
+
+			t, err = t.Register(player.ID())
+			if err != nil {
+				return err
+			}
+
+			// Entry fee is a bet on the prize pool session
+			err = processPlayerBet(ctx, unit, player.ID(), t.SessionID(), t.EntryFee(), operationName)
+			if err != nil {
+				return err
+			}
+
+			if t.IsFull() {
+				t, err = t.Start()
+				if err != nil {
+					return fmt.Errorf("failed to start tournament in %s: %w", operationName, err)
+				}
+
+				pool, err := sessionRepo.SessionByIDLocked(ctx, t.SessionID())
+				if err != nil {
+					return fmt.Errorf("failed to get prize pool session with lock in %s: %w", operationName, err)
+				}
+				pool, err = pool.ChangeStatus(domain.GameStatusInProgress)
+				if err != nil {
+					return err
+				}
+				if _, err := sessionRepo.UpdateSession(ctx, pool); err != nil {
+					return fmt.Errorf("failed to update prize pool session in %s: %w", operationName, err)
+				}
+
+				// Update bets status: PENDING -> RUNNING
+				if t.EntryFee() > 0 {
+					err = betRepo.UpdateBetsStatusBatch(ctx, t.SessionID(), domainBet.StatusRunning)
+					if err != nil {
+						return fmt.Errorf("failed to update bets status in %s: %w", operationName, err)
+					}
+				}
+			}
+
+			t, err = tournamentRepo.UpdateTournament(ctx, t)
+			if err != nil {
+				return fmt.Errorf("failed to update tournament in %s: %w", operationName, err)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, uow.ErrFailedToDoTransaction(operationName, err)
+		}
+
+		msg, markup, err := BuildTournamentMessage(ctx, userGetter, t)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build tournament message in %s: %w", operationName, err)
+		}
+
+		callbackText := "Вы в турнире! Ждём остальных участников..."
+		if t.Status() == domain.GameStatusInProgress {
+			callbackText = "Турнир начался! Сетка готова."
+		}
+
+		return ResponseChain{
+			&EditMessageTextResponse{
+				InlineMessageID: query.InlineMessageID,
+				Text:            msg,
+				ParseMode:       "HTML",
+				ReplyMarkup:     markup,
+			},
+			&CallbackQueryResponse{
+				CallbackQueryID: query.ID,
+				Text:            callbackText,
+			},
+		}, nil
+	}
+}
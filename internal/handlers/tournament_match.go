@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"fmt"
+	"log/slog"
+	"microgame-bot/internal/core"
+	"microgame-bot/internal/core/logger"
+	"microgame-bot/internal/domain"
+	domainSession "microgame-bot/internal/domain/session"
+	"microgame-bot/internal/domain/tournament"
+	"microgame-bot/internal/msgs"
+	userRepository "microgame-bot/internal/repo/user"
+	"microgame-bot/internal/uow"
+
+	"github.com/mymmrac/telego"
+	th "github.com/mymmrac/telego/telegohandler"
+)
+
+// TournamentMatchStart starts a bracket match in the message it was posted to.
+// Both players are seated right away, the winner is picked up by the tournament advance task.
+func TournamentMatchStart(
+	userGetter userRepository.IUserGetter,
+	unit uow.IUnitOfWork,
+) CallbackQueryHandlerFunc {
+	const operationName = "handlers::tournament_match_start"
+	l := slog.With(slog.String(logger.OperationField, operationName))
+	return func(ctx *th.Context, query telego.CallbackQuery) (IResponse, error) {
+		l.DebugContext(ctx, "Tournament match start callback received")
+
+		player, err := userFromContext(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user from context in %s: %w", operationName, err)
+		}
+
+		if query.InlineMessageID == "" {
+			return nil, core.ErrInvalidUpdate
+		}
+
+		tournamentID, err := extractGameID[tournament.ID](query.Data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract tournament ID from callback data in %s: %w", operationName, err)
+		}
+		number, err := extractMatchNumber(query.Data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract match number from callback data in %s: %w", operationName, err)
+		}
+
+		var t tournament.Tournament
+		var session domainSession.Session
+		var game SeatedGame
+		err = unit.Do(ctx, func(unit uow.IUnitOfWork) error {
+			tournamentRepo, err := unit.TournamentRepo()
+			if err != nil {
+				return fmt.Errorf("failed to get tournament repository in %s: %w", operationName, err)
+			}
+			sessionRepo, err := unit.SessionRepo()
+			if err != nil {
+				return fmt.Errorf("failed to get session repository in %s: %w", operationName, err)
+			}
+
+			t, err = tournamentRepo.TournamentByIDLocked(ctx, tournamentID)
+			if err != nil {
+				return fmt.Errorf("failed to get tournament by ID with lock in %s: %w", operationName, err)
+			}
+
+			match, err := t.Match(number)
+			if err != nil {
+				return err
+			}
+			if !match.HasPlayer(player.ID()) {
+				return domain.ErrPlayerNotInGame
+			}
+
+			session, err = domainSession.New(
+				domainSession.WithNewID(),
+				domainSession.WithGameType(t.GameType()),
+				domainSession.WithInlineMessageIDFromString(query.InlineMessageID),
+				domainSession.WithGameCount(t.GameCount()),
+				domainSession.WithWinCondition(domainSession.WinConditionFirstTo),
+				domainSession.WithMoveTimeout(tournament.MatchMoveTimeout),
+				domainSession.WithStatus(domain.GameStatusInProgress),
+				domainSession.WithNewSeed(),
+			)
+			if err != nil {
+				return err
+			}
+
+			t, err = t.StartMatch(number, session.ID())
+			if err != nil {
+				return err
+			}
+
+			session, err = sessionRepo.CreateSession(ctx, session)
+			if err != nil {
+				return fmt.Errorf("failed to create match session in %s: %w", operationName, err)
+			}
+
+			game, err = CreateSeatedGame(ctx, unit, session, match.Player1, match.Player2)
+			if err != nil {
+				return fmt.Errorf("failed to create match game in %s: %w", operationName, err)
+			}
+
+			t, err = tournamentRepo.UpdateTournament(ctx, t)
+			if err != nil {
+				return fmt.Errorf("failed to update tournament in %s: %w", operationName, err)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, uow.ErrFailedToDoTransaction(operationName, err)
+		}
+
+		msg, boardKeyboard, err := game.Start(ctx, userGetter, player, session)
+		if err != nil {
+			return nil, fmt.Errorf("failed to start match game in %s: %w", operationName, err)
+		}
+
+		bracketMsg, bracketKeyboard, err := BuildTournamentMessage(ctx, userGetter, t)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build tournament message in %s: %w", operationName, err)
+		}
+
+		return ResponseChain{
+			&EditMessageTextResponse{
+				InlineMessageID: query.InlineMessageID,
+				Text:            msgs.TournamentMatchHeader(t, number) + msg,
+				ParseMode:       "HTML",
+				ReplyMarkup:     boardKeyboard,
+			},
+			&EditMessageTextResponse{
+				InlineMessageID: t.InlineMessageID().String(),
+				Text:            bracketMsg,
+				ParseMode:       "HTML",
+				ReplyMarkup:     bracketKeyboard,
+			},
+			&CallbackQueryResponse{
+				CallbackQueryID: query.ID,
+				Text:            "Матч начался!",
+			},
+		}, nil
+	}
+}
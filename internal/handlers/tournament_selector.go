@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"fmt"
+	"microgame-bot/internal/core"
+	"microgame-bot/internal/domain"
+	domainBet "microgame-bot/internal/domain/bet"
+	"microgame-bot/internal/domain/tournament"
+	"strconv"
+
+	"github.com/mymmrac/telego"
+	tu "github.com/mymmrac/telego/telegoutil"
+)
+
+// tournamentSelector offers tournament creation: tour <size> <rounds> <fee>.
+// Tournaments are played in group chats only, elsewhere nobody could register.
+func tournamentSelector(query telego.InlineQuery, args []string, cfg core.AppConfig) IResponse {
+	if query.ChatType != telego.ChatTypeGroup && query.ChatType != telego.ChatTypeSupergroup {
+		return &InlineQueryResponse{
+			QueryID: query.ID,
+			Results: []telego.InlineQueryResult{
+				tu.ResultArticle(
+					"tournament::unavailable",
+					"🏆 Турниры доступны только в групповых чатах",
+					tu.TextMessage("🏆 Турниры проводятся в групповых чатах."),
+				),
+			},
+			CacheTime: 1,
+		}
+	}
+
+	size := tournament.DefaultSize
+	gameCount := 1
+	fee := 0
+	if len(args) > 0 {
+		if parsed, err := strconv.Atoi(args[0]); err == nil && tournament.IsValidSize(parsed) {
+			size = parsed
+		}
+	}
+	if len(args) > 1 {
+		if parsed, err := strconv.Atoi(args[1]); err == nil && parsed > 0 {
+			gameCount = min(parsed, cfg.MaxGameCount)
+		}
+	}
+	//nolint:mnd // Third argument is the entry fee.
+	if len(args) > 2 {
+		if parsed, err := strconv.Atoi(args[2]); err == nil && parsed > 0 {
+			fee = min(parsed, int(domainBet.MaxBet))
+		}
+	}
+
+	label := fmt.Sprintf("(%d игроков, до %d побед", size, gameCount)
+	if fee > 0 {
+		label += fmt.Sprintf(", 💰 взнос %d", fee)
+	}
+	label += ")"
+
+	article := func(gameType domain.GameType, title string) telego.InlineQueryResult {
+		msg := fmt.Sprintf("🏆 <b>Турнир: %s</b>\n<i>%s</i>\n\nНажми кнопку, чтобы открыть регистрацию!", title, label)
+		data := fmt.Sprintf("create::tour::%s::%d::%d::%d", gameType, size, gameCount, fee)
+		return tu.ResultArticle(
+			"tournament::"+gameType.String(),
+			"🏆 Турнир: "+title+" "+label,
+			tu.TextMessage(msg).WithParseMode("HTML"),
+		).WithReplyMarkup(tu.InlineKeyboard(
+			tu.InlineKeyboardRow(
+				tu.InlineKeyboardButton("🏆 Открыть регистрацию").WithCallbackData(data),
+			),
+		))
+	}
+
+	return &InlineQueryResponse{
+		QueryID: query.ID,
+		Results: []telego.InlineQueryResult{
+			article(domain.GameTypeTTT, "Крестики-Нолики"),
+			article(domain.GameTypeRPS, "Камень-Ножницы-Бумага"),
+		},
+		CacheTime: 1,
+	}
+}
+
+// matchSelector posts a tournament match: match <tournament id> <match number>.
+// The query is filled in by the bracket buttons, the match itself is validated when it is started.
+func matchSelector(query telego.InlineQuery, args []string) IResponse {
+	//nolint:mnd // Tournament ID and match number.
+	if len(args) < 2 {
+		return nil
+	}
+	id, number := args[0], args[1]
+	if _, err := strconv.Atoi(number); err != nil {
+		return nil
+	}
+
+	return &InlineQueryResponse{
+		QueryID: query.ID,
+		Results: []telego.InlineQueryResult{
+			tu.ResultArticle(
+				"tournament::match",
+				"⚔️ Турнирный матч",
+				tu.TextMessage("⚔️ <b>Турнирный матч</b>\n\nИгроки матча начинают его кнопкой ниже!").
+					WithParseMode("HTML"),
+			).WithReplyMarkup(tu.InlineKeyboard(
+				tu.InlineKeyboardRow(
+					tu.InlineKeyboardButton("⚔️ Начать матч").
+						WithCallbackData("g::tour::play::" + id + "::" + number),
+				),
+			)),
+		},
+		CacheTime: 1,
+	}
+}
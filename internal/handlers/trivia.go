@@ -0,0 +1,263 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"microgame-bot/internal/core"
+	"microgame-bot/internal/domain"
+	domainSession "microgame-bot/internal/domain/session"
+	"microgame-bot/internal/domain/trivia"
+	"microgame-bot/internal/msgs"
+	"microgame-bot/internal/queue"
+	"microgame-bot/internal/uow"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mymmrac/telego"
+	tu "github.com/mymmrac/telego/telegoutil"
+)
+
+// triviaQueryKeyword opens the quiz topics in the game selector: quiz <rounds> <bet> <seconds per question>.
+const triviaQueryKeyword = "quiz"
+
+// triviaAnyFilter stands for an empty filter field in the callback data.
+const triviaAnyFilter = "any"
+
+// BuildTriviaGameBoardKeyboard creates the answer buttons of the open question, one option per row.
+// Non-zero deadline is shown as a countdown below the options.
+func BuildTriviaGameBoardKeyboard(game *trivia.Trivia, deadline time.Time) *telego.InlineKeyboardMarkup {
+	//nolint:mnd // Option rows and the clock row.
+	rows := make([][]telego.InlineKeyboardButton, 0, trivia.OptionsCount+1)
+	question, ok := game.Question()
+	if game.IsFinished() || !ok {
+		return &telego.InlineKeyboardMarkup{
+			InlineKeyboard: rows,
+		}
+	}
+
+	for i, option := range question.Options {
+		rows = append(rows, []telego.InlineKeyboardButton{
+			{
+				Text:         option,
+				CallbackData: fmt.Sprintf("g::tv::answer::%s::%d::%d", game.ID(), game.Current(), i),
+			},
+		})
+	}
+
+	if !deadline.IsZero() {
+		rows = append(rows, []telego.InlineKeyboardButton{
+			{
+				Text:         msgs.ClockLabel(deadline),
+				CallbackData: "empty",
+			},
+		})
+	}
+
+	return &telego.InlineKeyboardMarkup{
+		InlineKeyboard: rows,
+	}
+}
+
+// buildTriviaWaitingKeyboard creates inline keyboard for a game waiting for players.
+func buildTriviaWaitingKeyboard(game *trivia.Trivia) *telego.InlineKeyboardMarkup {
+	return tu.InlineKeyboard(
+		tu.InlineKeyboardRow(
+			tu.InlineKeyboardButton("Присоединиться").
+				WithCallbackData("g::tv::join::"+game.ID().String()),
+		),
+		tu.InlineKeyboardRow(
+			tu.InlineKeyboardButton("❌ Отменить").
+				WithCallbackData("g::tv::cancel::"+game.ID().String()),
+		),
+	)
+}
+
+// triviaSelector offers the quiz on every topic of the library with the series settings of the query.
+// The time to answer a question is taken from the move clock of the query.
+func triviaSelector(
+	query telego.InlineQuery,
+	fields []string,
+	cfg core.AppConfig,
+	library trivia.Library,
+) IResponse {
+	args := parseGameArgs(fields, cfg)
+
+	categories := library.Categories()
+	difficulties := trivia.Difficulties()
+	results := make([]telego.InlineQueryResult, 0, 1+len(difficulties)+len(categories))
+	results = append(results, triviaArticle(library, trivia.Filter{}, args))
+	for _, difficulty := range difficulties {
+		results = append(results, triviaArticle(library, trivia.Filter{Difficulty: difficulty}, args))
+	}
+	for _, category := range categories {
+		results = append(results, triviaArticle(library, trivia.Filter{Category: category.ID}, args))
+	}
+
+	return &InlineQueryResponse{
+		QueryID:   query.ID,
+		Results:   results,
+		CacheTime: 1,
+	}
+}
+
+// triviaArticle offers the quiz on the topic of the filter with the series settings of the query.
+func triviaArticle(library trivia.Library, filter trivia.Filter, args gameArgs) telego.InlineQueryResult {
+	topic := triviaTopic(library, filter)
+	msg := fmt.Sprintf(
+		"🎮 <b>🧠 Викторина</b>\n<i>%s</i>\n<i>%s</i>\n\nКто первым ответит верно, получает очко. "+
+			"Нажми кнопку, чтобы начать игру!",
+		topic,
+		args.label(),
+	)
+	return tu.ResultArticle(
+		"game::tv::"+triviaFilterData(filter),
+		"🧠 Викторина: "+topic+" "+args.label(),
+		tu.TextMessage(msg).WithParseMode("HTML"),
+	).WithReplyMarkup(tu.InlineKeyboard(
+		tu.InlineKeyboardRow(
+			tu.InlineKeyboardButton("🎯 Начать игру").
+				WithCallbackData("create::tv::" + args.callbackData() + "::" + triviaFilterData(filter)),
+		),
+	))
+}
+
+// triviaTopic describes the filter to players, e.g. "🌍 География · 🟢 Лёгкие".
+func triviaTopic(library trivia.Library, filter trivia.Filter) string {
+	topic := "📚 Все темы"
+	if category, ok := library.Category(filter.Category); ok {
+		topic = category.Title
+	}
+	if filter.Difficulty != trivia.DifficultyAny {
+		topic += " · " + filter.Difficulty.Title()
+	}
+	return topic
+}
+
+// triviaFilterData returns the filter in the order extractTriviaFilter reads it: <category>::<difficulty>.
+func triviaFilterData(filter trivia.Filter) string {
+	category := filter.Category
+	if category == "" {
+		category = triviaAnyFilter
+	}
+	difficulty := filter.Difficulty.String()
+	if difficulty == "" {
+		difficulty = triviaAnyFilter
+	}
+	return category + "::" + difficulty
+}
+
+// Extracts the question filter from the create callback data. If the filter is missing, every question matches.
+func extractTriviaFilter(callbackData string) (trivia.Filter, error) {
+	parts := strings.Split(callbackData, "::")
+	//nolint:mnd // Callback data params is constant.
+	if len(parts) < 7 {
+		return trivia.Filter{}, nil
+	}
+
+	//nolint:mnd // Callback data params is constant.
+	difficulty, err := trivia.DifficultyFromString(parts[6])
+	if err != nil {
+		return trivia.Filter{}, err
+	}
+	filter := trivia.Filter{Difficulty: difficulty}
+	if parts[5] != triviaAnyFilter {
+		filter.Category = parts[5]
+	}
+	return filter, nil
+}
+
+// Extracts the question and the option from the callback data: g::tv::answer::<game id>::<question>::<option>.
+func extractTriviaAnswer(callbackData string) (int, int, error) {
+	parts := strings.Split(callbackData, "::")
+	//nolint:mnd // Callback data params is constant.
+	if len(parts) < 6 {
+		return 0, 0, trivia.ErrInvalidOption
+	}
+
+	//nolint:mnd // Callback data params is constant.
+	question, err := strconv.Atoi(parts[4])
+	if err != nil {
+		return 0, 0, trivia.ErrInvalidOption
+	}
+	//nolint:mnd // Callback data params is constant.
+	option, err := strconv.Atoi(parts[5])
+	if err != nil {
+		return 0, 0, trivia.ErrInvalidOption
+	}
+	return question, option, nil
+}
+
+// ScheduleTriviaQuestion starts the timer of the question the game has moved on to, e.g. after an answer
+// or an expired question. Nothing is scheduled for a finished game. Must be called within transaction.
+func ScheduleTriviaQuestion(ctx context.Context, unit uow.IUnitOfWork, game trivia.Trivia) error {
+	if game.IsFinished() {
+		return nil
+	}
+	sessionRepo, err := unit.SessionRepo()
+	if err != nil {
+		return fmt.Errorf("failed to get session repository: %w", err)
+	}
+	session, err := sessionRepo.SessionByID(ctx, game.SessionID())
+	if err != nil {
+		return fmt.Errorf("failed to get game session by ID: %w", err)
+	}
+	return scheduleTriviaQuestion(ctx, unit, session, game)
+}
+
+// scheduleTriviaQuestion starts the timer of the open question and the countdown on the board.
+// The timer is only started for a question nobody has answered yet, a wrong answer keeps the timer running.
+// Must be called within transaction.
+func scheduleTriviaQuestion(
+	ctx context.Context,
+	unit uow.IUnitOfWork,
+	session domainSession.Session,
+	game trivia.Trivia,
+) error {
+	deadline := game.QuestionDeadline()
+	if deadline.IsZero() {
+		return nil
+	}
+
+	outbox, err := unit.Outbox()
+	if err != nil {
+		return fmt.Errorf("failed to get task outbox: %w", err)
+	}
+
+	if len(game.AnswersTo(game.Current())) == 0 {
+		payload, err := json.Marshal(trivia.QuestionTask{
+			InlineMessageID: session.InlineMessageID(),
+			SessionID:       session.ID(),
+			GameID:          game.IDtoUUID(),
+			Question:        game.Current(),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to marshal question task: %w", err)
+		}
+		if err := queue.PublishTriviaQuestionTask(ctx, outbox, payload, deadline); err != nil {
+			return fmt.Errorf("failed to publish question task: %w", err)
+		}
+	}
+
+	nextTick := time.Now().Add(domainSession.ClockTickInterval)
+	if session.InlineMessageID().IsZero() || !nextTick.Before(deadline) {
+		return nil
+	}
+	payload, err := json.Marshal(domainSession.TimeoutTask{
+		Kind:            domainSession.TimeoutKindMove,
+		GameType:        domain.GameTypeTrivia,
+		InlineMessageID: session.InlineMessageID(),
+		SessionID:       session.ID(),
+		GameID:          game.IDtoUUID(),
+		UpdatedAt:       game.UpdatedAt(),
+		Deadline:        deadline,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal clock task: %w", err)
+	}
+	if err := queue.PublishGameClockTask(ctx, outbox, payload, nextTick); err != nil {
+		return fmt.Errorf("failed to publish clock task: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,230 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"microgame-bot/internal/domain"
+	domainBet "microgame-bot/internal/domain/bet"
+	domainSession "microgame-bot/internal/domain/session"
+	"microgame-bot/internal/domain/trivia"
+	domainUser "microgame-bot/internal/domain/user"
+	"microgame-bot/internal/msgs"
+	"microgame-bot/internal/queue"
+	"microgame-bot/internal/uow"
+	"microgame-bot/internal/utils"
+	"time"
+
+	"github.com/mymmrac/telego"
+)
+
+// AdvanceTriviaSession moves the series on after the game has changed, either by an answer or by the question timer:
+// it finishes the session or starts the next round. The timer of the next question of the game itself
+// is started by ScheduleTriviaQuestion within the transaction that changed the game.
+// Returns the text and the keyboard of the session message.
+func AdvanceTriviaSession(
+	ctx context.Context,
+	unit uow.IUnitOfWork,
+	library trivia.Library,
+	game trivia.Trivia,
+) (string, *telego.InlineKeyboardMarkup, error) {
+	const operationName = "handlers::advance_trivia_session"
+
+	sessionRepo, err := unit.SessionRepo()
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to get game session repository in %s: %w", operationName, err)
+	}
+	session, err := sessionRepo.SessionByID(ctx, game.SessionID())
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to get game session by ID in %s: %w", operationName, err)
+	}
+
+	gameGetter, err := unit.TriviaRepo()
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to get game repository in %s: %w", operationName, err)
+	}
+	allGames, err := gameGetter.GamesBySessionID(ctx, session.ID())
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to get games by session ID in %s: %w", operationName, err)
+	}
+
+	games := make([]domainSession.IGame, len(allGames))
+	for i, g := range allGames {
+		games[i] = g
+	}
+
+	manager := domainSession.NewManager(session, games)
+	result := manager.CalculateResult()
+
+	userGetter, err := unit.UserRepo()
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to get user repository in %s: %w", operationName, err)
+	}
+	player1, err := userGetter.UserByID(ctx, allGames[0].Player1ID())
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to get player1 by ID in %s: %w", operationName, err)
+	}
+	player2, err := userGetter.UserByID(ctx, allGames[0].Player2ID())
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to get player2 by ID in %s: %w", operationName, err)
+	}
+
+	topic := triviaTopic(library, game.Filter())
+
+	if !game.IsFinished() {
+		return msgs.TriviaRound(
+			allGames,
+			game,
+			player1,
+			player2,
+			result.Scores[player1.ID()],
+			result.Scores[player2.ID()],
+			result.Draws,
+			session.Bet(),
+			topic,
+		), BuildTriviaGameBoardKeyboard(&game, game.QuestionDeadline()), nil
+	}
+
+	if result.IsCompleted {
+		err = unit.Do(ctx, func(uow uow.IUnitOfWork) error {
+			gsRepo, err := uow.SessionRepo()
+			if err != nil {
+				return fmt.Errorf("failed to get game session repository in %s: %w", operationName, err)
+			}
+			betRepo, err := uow.BetRepo()
+			if err != nil {
+				return fmt.Errorf("failed to get bet repository in %s: %w", operationName, err)
+			}
+
+			session, err = session.ChangeStatus(domain.GameStatusFinished)
+			if err != nil {
+				return fmt.Errorf("failed to change status of game session: %w", err)
+			}
+			session, err = gsRepo.UpdateSession(ctx, session)
+			if err != nil {
+				return fmt.Errorf("failed to update game session: %w", err)
+			}
+
+			// Update bets status: RUNNING -> WAITING
+			if session.Bet() > 0 {
+				err = betRepo.UpdateBetsStatusBatch(ctx, session.ID(), domainBet.StatusWaiting)
+				if err != nil {
+					return fmt.Errorf("failed to update bets status in %s: %w", operationName, err)
+				}
+				outbox, err := uow.Outbox()
+				if err != nil {
+					return fmt.Errorf("failed to get task outbox in %s: %w", operationName, err)
+				}
+				if err := queue.PublishPayoutTask(ctx, outbox); err != nil {
+					return fmt.Errorf("failed to publish payout task in %s: %w", operationName, err)
+				}
+			}
+
+			return nil
+		})
+		if err != nil {
+			return "", nil, uow.ErrFailedToDoTransaction(operationName, err)
+		}
+
+		if result.IsDraw {
+			return msgs.TriviaSeriesDraw(
+				allGames,
+				player1,
+				player2,
+				result.Scores[player1.ID()],
+				result.Scores[player2.ID()],
+				result.Draws,
+				topic,
+			), BuildTriviaGameBoardKeyboard(&game, game.QuestionDeadline()), nil
+		}
+
+		var winner domainUser.User
+		if result.SeriesWinners[0] == player1.ID() {
+			winner = player1
+		} else {
+			winner = player2
+		}
+		return msgs.TriviaSeriesCompleted(
+			allGames,
+			player1,
+			player2,
+			result.Scores[player1.ID()],
+			result.Scores[player2.ID()],
+			result.Draws,
+			winner,
+			topic,
+		), BuildTriviaGameBoardKeyboard(&game, game.QuestionDeadline()), nil
+	}
+
+	// The round is over but the series goes on
+	nextGame := game
+	if result.NeedsNewRound {
+		err = unit.Do(ctx, func(uow uow.IUnitOfWork) error {
+			gameRepo, err := uow.TriviaRepo()
+			if err != nil {
+				return fmt.Errorf("failed to get game repository in %s: %w", operationName, err)
+			}
+
+			nextGame, err = newTriviaGame(
+				library,
+				session,
+				game.Filter(),
+				game.CreatorID(),
+				trivia.WithPlayer1ID(game.Player1ID()),
+				trivia.WithPlayer2ID(game.Player2ID()),
+				trivia.WithStatus(domain.GameStatusInProgress),
+				trivia.WithAskedAt(time.Now()),
+			)
+			if err != nil {
+				return fmt.Errorf("failed to create new game in %s: %w", operationName, err)
+			}
+
+			nextGame, err = gameRepo.CreateGame(ctx, nextGame)
+			if err != nil {
+				return fmt.Errorf("failed to store new game in %s: %w", operationName, err)
+			}
+
+			return scheduleTriviaQuestion(ctx, uow, session, nextGame)
+		})
+		if err != nil {
+			return "", nil, uow.ErrFailedToDoTransaction(operationName, err)
+		}
+		allGames = append(allGames, nextGame)
+	}
+
+	return msgs.TriviaRound(
+		allGames,
+		nextGame,
+		player1,
+		player2,
+		result.Scores[player1.ID()],
+		result.Scores[player2.ID()],
+		result.Draws,
+		session.Bet(),
+		topic,
+	), BuildTriviaGameBoardKeyboard(&nextGame, nextGame.QuestionDeadline()), nil
+}
+
+// newTriviaGame draws the questions of a new game of the session from the library.
+// The questions depend on the session seed and the game ID, like the words of hangman.
+func newTriviaGame(
+	library trivia.Library,
+	session domainSession.Session,
+	filter trivia.Filter,
+	creatorID domainUser.ID,
+	opts ...trivia.Opt,
+) (trivia.Trivia, error) {
+	gameID := trivia.ID(utils.NewUniqueID())
+	questions, err := library.Pick(filter, trivia.QuestionsPerGame, session.Seed(), gameID.String())
+	if err != nil {
+		return trivia.Trivia{}, fmt.Errorf("failed to pick questions: %w", err)
+	}
+
+	return trivia.New(append([]trivia.Opt{
+		trivia.WithID(gameID),
+		trivia.WithSessionID(session.ID()),
+		trivia.WithCreatorID(creatorID),
+		trivia.WithFilter(filter),
+		trivia.WithQuestions(questions),
+		trivia.WithQuestionTimeout(trivia.ClampQuestionTimeout(session.MoveTimeout())),
+	}, opts...)...)
+}
@@ -5,17 +5,22 @@ import (
 	"fmt"
 	"microgame-bot/internal/domain/ttt"
 	domainUser "microgame-bot/internal/domain/user"
+	"microgame-bot/internal/msgs"
 	"strings"
+	"time"
 
 	"github.com/mymmrac/telego"
+	tu "github.com/mymmrac/telego/telegoutil"
 )
 
-// buildTTTGameBoardKeyboard creates inline keyboard with game board
+// BuildTTTGameBoardKeyboard creates inline keyboard with game board
 // playerX must be the actual X player, playerO must be the actual O player.
-func buildTTTGameBoardKeyboard(
+// Non-zero deadline is shown as a countdown next to the current turn.
+func BuildTTTGameBoardKeyboard(
 	game *ttt.TTT,
 	playerX domainUser.User,
 	playerO domainUser.User,
+	deadline time.Time,
 ) *telego.InlineKeyboardMarkup {
 	//nolint:mnd // Cell number is constant.
 	rows := make([][]telego.InlineKeyboardButton, 0, 4)
@@ -26,13 +31,7 @@ func buildTTTGameBoardKeyboard(
 		for col := range 3 {
 			cell, _ := game.GetCell(row, col)
 
-			icon := ttt.CellEmptyIcon
-			switch cell {
-			case ttt.CellX:
-				icon = ttt.CellXIcon
-			case ttt.CellO:
-				icon = ttt.CellOIcon
-			}
+			icon := cellIcon(cell)
 
 			//nolint:mnd // Cell number is constant.
 			cellNumber := row*3 + col
@@ -54,6 +53,9 @@ func buildTTTGameBoardKeyboard(
 			currentPlayer = playerO
 		}
 		turnText := fmt.Sprintf("🎯 Ход: @%s %s", currentPlayer.Username(), game.PlayerCell(game.Turn()).Icon())
+		if !deadline.IsZero() {
+			turnText += " " + msgs.ClockLabel(deadline)
+		}
 		rows = append(rows, []telego.InlineKeyboardButton{
 			{
 				Text:         turnText,
@@ -66,11 +68,34 @@ func buildTTTGameBoardKeyboard(
 		})
 	}
 
+	if game.IsFinished() && len(game.Moves()) > 0 {
+		rows = append(rows, []telego.InlineKeyboardButton{
+			{
+				Text:         "▶️ Повтор",
+				CallbackData: fmt.Sprintf("g::ttt::replay::%s::0", game.ID().String()),
+			},
+		})
+	}
+
 	return &telego.InlineKeyboardMarkup{
 		InlineKeyboard: rows,
 	}
 }
 
+// buildTTTWaitingKeyboard creates inline keyboard for a game waiting for players.
+func buildTTTWaitingKeyboard(game *ttt.TTT) *telego.InlineKeyboardMarkup {
+	return tu.InlineKeyboard(
+		tu.InlineKeyboardRow(
+			tu.InlineKeyboardButton("Присоединиться").
+				WithCallbackData("g::ttt::join::"+game.ID().String()),
+		),
+		tu.InlineKeyboardRow(
+			tu.InlineKeyboardButton("❌ Отменить").
+				WithCallbackData("g::ttt::cancel::"+game.ID().String()),
+		),
+	)
+}
+
 func tttExtractCellNumber(callbackData string) (int, error) {
 	parts := strings.Split(callbackData, "::")
 	//nolint:mnd // Callback data params is constant.
@@ -13,10 +13,12 @@ import (
 
 	"github.com/mymmrac/telego"
 	th "github.com/mymmrac/telego/telegohandler"
-	tu "github.com/mymmrac/telego/telegoutil"
 )
 
-func TTTJoin(userRepo userRepository.IUserRepository, unit uow.IUnitOfWork) CallbackQueryHandlerFunc {
+func TTTJoin(
+	userRepo userRepository.IUserRepository,
+	unit uow.IUnitOfWork,
+) CallbackQueryHandlerFunc {
 	const operationName = "handlers::ttt_join"
 	l := slog.With(slog.String(logger.OperationField, operationName))
 	return func(ctx *th.Context, query telego.CallbackQuery) (IResponse, error) {
@@ -96,6 +98,9 @@ func TTTJoin(userRepo userRepository.IUserRepository, unit uow.IUnitOfWork) Call
 						return fmt.Errorf("failed to update bets status in %s: %w", operationName, err)
 					}
 				}
+				if err := scheduleMoveTimeout(ctx, uow, session, game.IDtoUUID(), game.UpdatedAt()); err != nil {
+					return err
+				}
 			}
 
 			return nil
@@ -120,7 +125,7 @@ func TTTJoin(userRepo userRepository.IUserRepository, unit uow.IUnitOfWork) Call
 
 		// First player joined - wait for second
 		if !isSecondPlayer {
-			msg, err := msgs.TTTFirstPlayerJoined(creator, player2, gameSession.Bet())
+			msg, err := msgs.TTTFirstPlayerJoined(creator, player2, gameSession.Bet(), gameSession.SeedHash())
 			if err != nil {
 				return nil, err
 			}
@@ -130,12 +135,7 @@ func TTTJoin(userRepo userRepository.IUserRepository, unit uow.IUnitOfWork) Call
 					InlineMessageID: query.InlineMessageID,
 					Text:            msg,
 					ParseMode:       "HTML",
-					ReplyMarkup: tu.InlineKeyboard(
-						tu.InlineKeyboardRow(
-							tu.InlineKeyboardButton("Присоединиться").
-								WithCallbackData("g::ttt::join::" + game.ID().String()),
-						),
-					),
+					ReplyMarkup:     buildTTTWaitingKeyboard(&game),
 				},
 				&CallbackQueryResponse{
 					CallbackQueryID: query.ID,
@@ -155,7 +155,7 @@ func TTTJoin(userRepo userRepository.IUserRepository, unit uow.IUnitOfWork) Call
 			return nil, fmt.Errorf("failed to get playerO by ID in %s: %w", operationName, err)
 		}
 
-		boardKeyboard := buildTTTGameBoardKeyboard(&game, playerX, playerO)
+		boardKeyboard := BuildTTTGameBoardKeyboard(&game, playerX, playerO, gameSession.MoveDeadline(game.UpdatedAt()))
 		msg, err := msgs.TTTGameStarted(creator, playerX, playerO, gameSession.Bet())
 		if err != nil {
 			return nil, err
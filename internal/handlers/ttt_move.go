@@ -16,6 +16,7 @@ import (
 	userRepository "microgame-bot/internal/repo/user"
 	"microgame-bot/internal/uow"
 	"microgame-bot/internal/utils"
+	"time"
 
 	"github.com/mymmrac/telego"
 	th "github.com/mymmrac/telego/telegohandler"
@@ -24,7 +25,6 @@ import (
 func TTTMove(
 	userGetter userRepository.IUserGetter,
 	unit uow.IUnitOfWork,
-	qPublisher queue.IQueuePublisher,
 ) CallbackQueryHandlerFunc {
 	const operationName = "handler::ttt_move"
 	return func(ctx *th.Context, query telego.CallbackQuery) (IResponse, error) {
@@ -75,7 +75,10 @@ func TTTMove(
 				return fmt.Errorf("failed to update game in %s: %w", operationName, err)
 			}
 
-			return nil
+			if game.IsFinished() {
+				return nil
+			}
+			return scheduleGameMoveTimeout(ctx, uow, game.SessionID(), game.IDtoUUID(), game.UpdatedAt())
 		})
 		if err != nil {
 			return nil, fmt.Errorf("failed do transaction in %s: %w", operationName, err)
@@ -91,8 +94,19 @@ func TTTMove(
 			return nil, fmt.Errorf("failed to get playerO by ID in %s: %w", operationName, err)
 		}
 
+		var gsGetter sRepository.ISessionGetter
+		gsGetter, err = unit.SessionRepo()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get game session repository in %s: %w", operationName, err)
+		}
+
+		session, err := gsGetter.SessionByID(ctx, game.SessionID())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get game session by ID in %s: %w", operationName, err)
+		}
+
 		if !game.IsFinished() {
-			boardKeyboard := buildTTTGameBoardKeyboard(&game, playerX, playerO)
+			boardKeyboard := BuildTTTGameBoardKeyboard(&game, playerX, playerO, session.MoveDeadline(game.UpdatedAt()))
 			return ResponseChain{
 				&EditMessageReplyMarkupResponse{
 					InlineMessageID: query.InlineMessageID,
@@ -105,17 +119,6 @@ func TTTMove(
 			}, nil
 		}
 
-		var gsGetter sRepository.ISessionGetter
-		gsGetter, err = unit.SessionRepo()
-		if err != nil {
-			return nil, fmt.Errorf("failed to get game session repository in %s: %w", operationName, err)
-		}
-
-		session, err := gsGetter.SessionByID(ctx, game.SessionID())
-		if err != nil {
-			return nil, fmt.Errorf("failed to get game session by ID in %s: %w", operationName, err)
-		}
-
 		var gameGetter tttRepository.ITTTGetter
 		gameGetter, err = unit.TTTRepo()
 		if err != nil {
@@ -161,7 +164,13 @@ func TTTMove(
 					if err != nil {
 						return fmt.Errorf("failed to update bets status in %s: %w", operationName, err)
 					}
-					_ = queue.PublishPayoutTask(ctx, qPublisher)
+					outbox, err := uow.Outbox()
+					if err != nil {
+						return fmt.Errorf("failed to get task outbox in %s: %w", operationName, err)
+					}
+					if err := queue.PublishPayoutTask(ctx, outbox); err != nil {
+						return fmt.Errorf("failed to publish payout task in %s: %w", operationName, err)
+					}
 				}
 
 				return nil
@@ -175,7 +184,7 @@ func TTTMove(
 				return nil, fmt.Errorf("failed to build series completed message in %s: %w", operationName, err)
 			}
 
-			boardKeyboard := buildTTTGameBoardKeyboard(&game, playerX, playerO)
+			boardKeyboard := BuildTTTGameBoardKeyboard(&game, playerX, playerO, time.Time{})
 
 			return ResponseChain{
 				&EditMessageTextResponse{
@@ -218,6 +227,7 @@ func TTTMove(
 					ttt.WithPlayerOID(newPlayerOID),
 					ttt.WithStatus(domain.GameStatusInProgress),
 					ttt.WithTurn(newPlayerXID),
+					ttt.WithSeed(session.Seed()),
 				)
 				if err != nil {
 					return fmt.Errorf("failed to create new game in %s: %w", operationName, err)
@@ -231,7 +241,7 @@ func TTTMove(
 					return fmt.Errorf("failed to store new game in %s: %w", operationName, err)
 				}
 
-				return nil
+				return scheduleMoveTimeout(ctx, uow, session, nextGame.IDtoUUID(), nextGame.UpdatedAt())
 			})
 			if err != nil {
 				return nil, uow.ErrFailedToDoTransaction(operationName, err)
@@ -255,7 +265,12 @@ func TTTMove(
 				return nil, fmt.Errorf("invalid player ID in %s", operationName)
 			}
 
-			boardKeyboard := buildTTTGameBoardKeyboard(&nextGame, nextPlayerX, nextPlayerO)
+			boardKeyboard := BuildTTTGameBoardKeyboard(
+				&nextGame,
+				nextPlayerX,
+				nextPlayerO,
+				session.MoveDeadline(nextGame.UpdatedAt()),
+			)
 
 			return ResponseChain{
 				&EditMessageTextResponse{
@@ -275,7 +290,7 @@ func TTTMove(
 			return nil, fmt.Errorf("failed to build game state message in %s: %w", operationName, err)
 		}
 
-		boardKeyboard := buildTTTGameBoardKeyboard(&game, playerX, playerO)
+		boardKeyboard := BuildTTTGameBoardKeyboard(&game, playerX, playerO, time.Time{})
 
 		return ResponseChain{
 			&EditMessageTextResponse{
@@ -6,13 +6,18 @@ import (
 	"microgame-bot/internal/core/logger"
 	"microgame-bot/internal/domain/ttt"
 	tttRepository "microgame-bot/internal/repo/game/ttt"
+	sRepository "microgame-bot/internal/repo/session"
 	userRepository "microgame-bot/internal/repo/user"
 
 	"github.com/mymmrac/telego"
 	th "github.com/mymmrac/telego/telegohandler"
 )
 
-func TTTRebuild(userGetter userRepository.IUserGetter, gameGetter tttRepository.ITTTGetter) CallbackQueryHandlerFunc {
+func TTTRebuild(
+	userGetter userRepository.IUserGetter,
+	gameGetter tttRepository.ITTTGetter,
+	sessionGetter sRepository.ISessionGetter,
+) CallbackQueryHandlerFunc {
 	const operationName = "handler::ttt_rebuild"
 	return func(ctx *th.Context, query telego.CallbackQuery) (IResponse, error) {
 		slog.DebugContext(ctx, "TTT Rebuild callback received", logger.OperationField, operationName)
@@ -37,7 +42,17 @@ func TTTRebuild(userGetter userRepository.IUserGetter, gameGetter tttRepository.
 			return nil, fmt.Errorf("failed to get playerO by ID in %s: %w", operationName, err)
 		}
 
-		boardKeyboard := buildTTTGameBoardKeyboard(&game, playerX, playerO)
+		session, err := sessionGetter.SessionByID(ctx, game.SessionID())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get game session by ID in %s: %w", operationName, err)
+		}
+
+		boardKeyboard := BuildTTTGameBoardKeyboard(
+			&game,
+			playerX,
+			playerO,
+			moveDeadline(session, game.Status(), game.UpdatedAt()),
+		)
 
 		return ResponseChain{
 			&CallbackQueryResponse{
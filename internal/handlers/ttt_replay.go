@@ -0,0 +1,194 @@
+package handlers
+
+import (
+	"fmt"
+	"log/slog"
+	"microgame-bot/internal/core/logger"
+	"microgame-bot/internal/domain/ttt"
+	"microgame-bot/internal/msgs"
+	tttRepository "microgame-bot/internal/repo/game/ttt"
+	userRepository "microgame-bot/internal/repo/user"
+	"slices"
+	"strings"
+
+	"github.com/mymmrac/telego"
+	th "github.com/mymmrac/telego/telegohandler"
+)
+
+// TTTReplay steps through the moves of a finished game in the inline message.
+// A game that is still going on can't be replayed, its moves would leak into the live board.
+func TTTReplay(gameGetter tttRepository.ITTTGetter) CallbackQueryHandlerFunc {
+	const operationName = "handler::ttt_replay"
+	return func(ctx *th.Context, query telego.CallbackQuery) (IResponse, error) {
+		slog.DebugContext(ctx, "TTT Replay callback received", logger.OperationField, operationName)
+
+		gameID, err := extractGameID[ttt.ID](query.Data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract game ID from callback data in %s: %w", operationName, err)
+		}
+
+		step, err := tttExtractReplayStep(query.Data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract replay step in %s: %w", operationName, err)
+		}
+
+		game, err := gameGetter.GameByID(ctx, gameID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get game by ID in %s: %w", operationName, err)
+		}
+		if !game.IsFinished() {
+			return nil, ttt.ErrReplayUnfinished
+		}
+
+		games, err := gameGetter.GamesBySessionID(ctx, game.SessionID())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get games by session ID in %s: %w", operationName, err)
+		}
+
+		return ResponseChain{
+			&CallbackQueryResponse{
+				CallbackQueryID: query.ID,
+			},
+			&EditMessageReplyMarkupResponse{
+				InlineMessageID: query.InlineMessageID,
+				ReplyMarkup:     buildTTTReplayKeyboard(&game, games, step),
+				SkipError:       true,
+			},
+		}, nil
+	}
+}
+
+// TTTNotation shows the move list of the game in compact text notation.
+func TTTNotation(userGetter userRepository.IUserGetter, gameGetter tttRepository.ITTTGetter) CallbackQueryHandlerFunc {
+	const operationName = "handler::ttt_notation"
+	return func(ctx *th.Context, query telego.CallbackQuery) (IResponse, error) {
+		slog.DebugContext(ctx, "TTT Notation callback received", logger.OperationField, operationName)
+
+		gameID, err := extractGameID[ttt.ID](query.Data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract game ID from callback data in %s: %w", operationName, err)
+		}
+
+		game, err := gameGetter.GameByID(ctx, gameID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get game by ID in %s: %w", operationName, err)
+		}
+
+		playerX, err := userGetter.UserByID(ctx, game.PlayerXID())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get playerX by ID in %s: %w", operationName, err)
+		}
+
+		playerO, err := userGetter.UserByID(ctx, game.PlayerOID())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get playerO by ID in %s: %w", operationName, err)
+		}
+
+		return &CallbackQueryResponse{
+			CallbackQueryID: query.ID,
+			Text:            msgs.TTTNotation(game, playerX, playerO),
+			ShowAlert:       true,
+		}, nil
+	}
+}
+
+// buildTTTReplayKeyboard renders the board after the given step with navigation between moves and rounds.
+// Only the finished rounds are navigated, the exit leads back to the latest round of the session.
+func buildTTTReplayKeyboard(game *ttt.TTT, games []ttt.TTT, step int) *telego.InlineKeyboardMarkup {
+	moves := game.Moves()
+	step = min(max(step, 0), len(moves))
+	board := game.BoardAt(step)
+	gameID := game.ID().String()
+
+	//nolint:mnd // Rows count is constant.
+	rows := make([][]telego.InlineKeyboardButton, 0, 6)
+	for row := range 3 {
+		//nolint:mnd // buttons count is constant.
+		buttons := make([]telego.InlineKeyboardButton, 3)
+		for col := range 3 {
+			buttons[col] = telego.InlineKeyboardButton{
+				Text:         cellIcon(board[row][col]),
+				CallbackData: "empty",
+			}
+		}
+		rows = append(rows, buttons)
+	}
+
+	stepText := fmt.Sprintf("%d/%d", step, len(moves))
+	if step > 0 {
+		move := moves[step-1]
+		stepText += " " + cellIcon(move.Cell) + " " + move.Square()
+	}
+	rows = append(rows, []telego.InlineKeyboardButton{
+		replayNavButton("⏮", gameID, step-1, step > 0),
+		{Text: stepText, CallbackData: "empty"},
+		replayNavButton("⏭", gameID, step+1, step < len(moves)),
+	})
+
+	finished := slices.DeleteFunc(slices.Clone(games), func(g ttt.TTT) bool { return !g.IsFinished() })
+	if len(finished) > 1 {
+		idx := slices.IndexFunc(finished, func(g ttt.TTT) bool { return g.ID() == game.ID() })
+		var prevID, nextID string
+		if idx > 0 {
+			prevID = finished[idx-1].ID().String()
+		}
+		if idx >= 0 && idx < len(finished)-1 {
+			nextID = finished[idx+1].ID().String()
+		}
+		rows = append(rows, []telego.InlineKeyboardButton{
+			replayNavButton("◀️", prevID, 0, prevID != ""),
+			{Text: fmt.Sprintf("Раунд %d/%d", idx+1, len(finished)), CallbackData: "empty"},
+			replayNavButton("▶️", nextID, 0, nextID != ""),
+		})
+	}
+
+	exitGameID := gameID
+	if len(games) > 0 {
+		exitGameID = games[len(games)-1].ID().String()
+	}
+	rows = append(rows, []telego.InlineKeyboardButton{
+		{Text: "📜 Нотация", CallbackData: "g::ttt::notation::" + gameID},
+		{Text: "↩️ Выйти", CallbackData: "g::ttt::rebuild::" + exitGameID},
+	})
+
+	return &telego.InlineKeyboardMarkup{
+		InlineKeyboard: rows,
+	}
+}
+
+func replayNavButton(text string, gameID string, step int, enabled bool) telego.InlineKeyboardButton {
+	if !enabled {
+		return telego.InlineKeyboardButton{Text: " ", CallbackData: "empty"}
+	}
+	return telego.InlineKeyboardButton{
+		Text:         text,
+		CallbackData: fmt.Sprintf("g::ttt::replay::%s::%d", gameID, step),
+	}
+}
+
+func cellIcon(cell ttt.Cell) string {
+	switch cell {
+	case ttt.CellX:
+		return ttt.CellXIcon
+	case ttt.CellO:
+		return ttt.CellOIcon
+	default:
+		return ttt.CellEmptyIcon
+	}
+}
+
+func tttExtractReplayStep(callbackData string) (int, error) {
+	parts := strings.Split(callbackData, "::")
+	//nolint:mnd // Callback data params is constant.
+	if len(parts) < 5 {
+		return 0, ErrInvalidCallbackData
+	}
+
+	var step int
+	_, err := fmt.Sscanf(parts[4], "%d", &step)
+	if err != nil {
+		return 0, err
+	}
+
+	return step, nil
+}
@@ -5,6 +5,18 @@ import (
 	"log/slog"
 	"microgame-bot/internal/core/logger"
 	"microgame-bot/internal/domain"
+	"microgame-bot/internal/domain/battleship"
+	"microgame-bot/internal/domain/blackjack"
+	"microgame-bot/internal/domain/bullscows"
+	"microgame-bot/internal/domain/checkers"
+	"microgame-bot/internal/domain/dice"
+	"microgame-bot/internal/domain/hangman"
+	"microgame-bot/internal/domain/league"
+	"microgame-bot/internal/domain/matchmaking"
+	"microgame-bot/internal/domain/reversi"
+	"microgame-bot/internal/domain/rps"
+	"microgame-bot/internal/domain/tournament"
+	"microgame-bot/internal/domain/trivia"
 	"microgame-bot/internal/domain/ttt"
 
 	"github.com/mymmrac/telego"
@@ -12,17 +24,82 @@ import (
 )
 
 var errorStatusMap = map[error]string{
-	domain.ErrGameNotFound:        "Игра не найдена",
-	domain.ErrGameFull:            "Игра уже заполнена",
-	domain.ErrPlayerAlreadyInGame: "Вы уже в игре",
-	domain.ErrWaitingForOpponent:  "Ожидание второго игрока",
-	domain.ErrGameOver:            "Игра завершена",
-	domain.ErrPlayerNotInGame:     "Вы не участвуете в игре",
-	domain.ErrNotPlayersTurn:      "Не ваш ход",
-	ttt.ErrInvalidMove:            "Неверный ход",
-	ttt.ErrCellOccupied:           "Ячейка уже занята",
-	ttt.ErrOutOfBounds:            "Координаты выходят за пределы доски",
-	domain.ErrInsufficientTokens:  "Недостаточно токенов для ставки",
+	domain.ErrGameNotFound:            "Игра не найдена",
+	domain.ErrGameFull:                "Игра уже заполнена",
+	domain.ErrPlayerAlreadyInGame:     "Вы уже в игре",
+	domain.ErrWaitingForOpponent:      "Ожидание второго игрока",
+	domain.ErrGameOver:                "Игра завершена",
+	domain.ErrPlayerNotInGame:         "Вы не участвуете в игре",
+	domain.ErrNotPlayersTurn:          "Не ваш ход",
+	ttt.ErrInvalidMove:                "Неверный ход",
+	ttt.ErrCellOccupied:               "Ячейка уже занята",
+	ttt.ErrOutOfBounds:                "Координаты выходят за пределы доски",
+	ttt.ErrReplayUnfinished:           "Повтор доступен после окончания раунда",
+	domain.ErrInsufficientTokens:      "Недостаточно токенов для ставки",
+	domain.ErrNotGameCreator:          "Отменить игру может только её создатель",
+	domain.ErrGameAlreadyStarted:      "Игра уже началась",
+	rps.ErrChoiceAlreadyMade:          "Вы уже сделали выбор",
+	rps.ErrInvalidChoice:              "Неверный выбор",
+	dice.ErrAlreadyThrown:             "Вы уже бросили",
+	dice.ErrInvalidKind:               "Неизвестный вид броска",
+	dice.ErrChatRequired:              "Напишите боту в личные сообщения, туда придёт ваш бросок",
+	domain.ErrGameNotStarted:          "Игра ещё не началась",
+	blackjack.ErrCantDouble:           "Удвоить можно только на первых двух картах",
+	blackjack.ErrInvalidAction:        "Неизвестное действие",
+	hangman.ErrInvalidLetter:          "Такой буквы нет в алфавите",
+	hangman.ErrAlreadyGuessed:         "Эту букву уже называли",
+	trivia.ErrAlreadyAnswered:         "Вы уже ответили на этот вопрос",
+	trivia.ErrQuestionClosed:          "Вопрос уже закрыт",
+	trivia.ErrInvalidOption:           "Неизвестный вариант ответа",
+	trivia.ErrNoQuestions:             "Нет вопросов по этой теме",
+	trivia.ErrUnknownCategory:         "Неизвестная тема",
+	reversi.ErrIllegalMove:            "Сюда ходить нельзя: ход должен перевернуть хотя бы одну фишку",
+	reversi.ErrCellOccupied:           "Клетка уже занята",
+	checkers.ErrNotOwnPiece:           "Выберите свою шашку",
+	checkers.ErrPieceCantMove:         "Этой шашке некуда ходить",
+	checkers.ErrCaptureRequired:       "Бить обязательно: выберите шашку, которая может бить",
+	checkers.ErrMustContinueChain:     "Продолжайте взятие той же шашкой",
+	checkers.ErrIllegalMove:           "Сюда ходить нельзя",
+	checkers.ErrOutOfBounds:           "Координаты выходят за пределы доски",
+	reversi.ErrOutOfBounds:            "Координаты выходят за пределы доски",
+	battleship.ErrInvalidCell:         "Неизвестная клетка",
+	battleship.ErrOutOfBoard:          "Корабль не помещается на поле",
+	battleship.ErrShipsTouch:          "Корабли не должны касаться друг друга",
+	battleship.ErrFleetComplete:       "Все корабли уже расставлены",
+	battleship.ErrFleetIncomplete:     "Сначала расставьте все корабли",
+	battleship.ErrNoRoom:              "Не удалось расставить флот, попробуйте ещё раз",
+	battleship.ErrAlreadyShot:         "Сюда уже стреляли",
+	battleship.ErrAlreadyReady:        "Ваш флот уже готов к бою",
+	battleship.ErrPlacementOver:       "Расстановка уже закончилась",
+	battleship.ErrChatRequired:        "Напишите боту в личные сообщения, туда придёт расстановка",
+	bullscows.ErrInvalidNumber:        "Наберите число из 4 разных цифр",
+	bullscows.ErrRepeatedDigit:        "Цифры в числе не должны повторяться",
+	bullscows.ErrInvalidDigit:         "Неизвестная цифра",
+	bullscows.ErrAlreadyGuessed:       "Это число вы уже называли",
+	bullscows.ErrSecretAlreadySet:     "Ваше число уже загадано",
+	bullscows.ErrSettingOver:          "Числа уже загаданы",
+	bullscows.ErrChatRequired:         "Напишите боту в личные сообщения, туда придёт клавиатура для числа",
+	domain.ErrTournamentNotFound:      "Турнир не найден",
+	tournament.ErrAlreadyRegistered:   "Вы уже участвуете в турнире",
+	tournament.ErrTournamentFull:      "Все места в турнире заняты",
+	tournament.ErrRegistrationClosed:  "Регистрация на турнир закрыта",
+	tournament.ErrMatchNotFound:       "Матч не найден",
+	tournament.ErrMatchNotReady:       "Матч ещё не готов",
+	tournament.ErrMatchAlreadyStarted: "Матч уже начат",
+	domain.ErrLeagueNotFound:          "Лига не найдена",
+	league.ErrAlreadyRegistered:       "Вы уже участвуете в лиге",
+	league.ErrLeagueFull:              "Все места в лиге заняты",
+	league.ErrRegistrationClosed:      "Регистрация в лигу закрыта",
+	league.ErrNotEnoughPlayers:        "Для запуска лиги нужно больше участников",
+	league.ErrDeadlinePassed:          "Время лиги вышло",
+	league.ErrFixtureNotFound:         "Матч не найден",
+	league.ErrFixtureAlreadyStarted:   "Матч уже начат",
+	league.ErrFixtureAlreadyPlayed:    "Матч уже сыгран",
+	domain.ErrTicketNotFound:          "Поиск не найден",
+	matchmaking.ErrAlreadyQueued:      "Вы уже ищете соперника",
+	matchmaking.ErrTicketNotWaiting:   "Поиск уже завершён",
+	matchmaking.ErrNotTicketOwner:     "Это не ваш поиск",
+	matchmaking.ErrInvalidStake:       "Недопустимая ставка",
 }
 
 func getCustomErrorMessage(target error) string {
@@ -133,3 +210,20 @@ func (w *HandlerWrapper) WrapChosenInlineResult(
 		return w.bufferedHandler.Handle(response, ctx)
 	}
 }
+
+type MessageHandlerFunc func(ctx *th.Context, message telego.Message) (IResponse, error)
+
+func (w *HandlerWrapper) WrapMessage(handler MessageHandlerFunc) func(*th.Context, telego.Message) error {
+	return func(ctx *th.Context, message telego.Message) error {
+		response, err := handler(ctx, message)
+		if err != nil {
+			return err
+		}
+
+		if response == nil {
+			return nil
+		}
+
+		return w.bufferedHandler.Handle(response, ctx)
+	}
+}
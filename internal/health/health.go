@@ -3,8 +3,10 @@ package health
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"log/slog"
 	"microgame-bot/internal/core/logger"
+	"microgame-bot/internal/queue"
 	"net/http"
 	"sync"
 	"time"
@@ -21,6 +23,7 @@ const (
 )
 
 type ComponentHealth struct {
+	Details any    `json:"details,omitempty"`
 	Status  Status `json:"status"`
 	Message string `json:"message,omitempty"`
 	Latency string `json:"latency,omitempty"`
@@ -79,46 +82,109 @@ func (c *DatabaseChecker) Check(ctx context.Context) ComponentHealth {
 	}
 }
 
+// QueueStatsProvider reports the registered subjects, the pending and stuck tasks and the last retention run
+// of the task queue.
+type QueueStatsProvider interface {
+	SubjectStats() []queue.SubjectStats
+	PendingTasks(ctx context.Context) (map[string]int64, error)
+	StuckTasks(ctx context.Context, olderThan time.Duration) (int64, error)
+	LastRetention() queue.RetentionRun
+}
+
+const (
+	// stuckTaskAge is the time a task runs before it is counted as stuck.
+	stuckTaskAge = 10 * time.Minute
+	// maxStuckTasks is the number of the stuck tasks that degrades the queue.
+	maxStuckTasks = 10
+)
+
+// QueueDetails are the details of the queue health, retention is missing until the first run of this instance.
+// Stuck is missing if the backend doesn't track the running tasks, StuckCheck says why.
+type QueueDetails struct {
+	Retention  *queue.RetentionRun  `json:"retention,omitempty"`
+	Stuck      *int64               `json:"stuck,omitempty"`
+	StuckCheck string               `json:"stuck_check,omitempty"`
+	Subjects   []QueueSubjectHealth `json:"subjects"`
+}
+
+// QueueSubjectHealth is a subject of the task queue with its limits, running and pending tasks.
+type QueueSubjectHealth struct {
+	queue.SubjectStats
+	Pending int64 `json:"pending"`
+}
+
 type QueueChecker struct {
-	db *gorm.DB
+	stats QueueStatsProvider
 }
 
-func NewQueueChecker(db *gorm.DB) *QueueChecker {
-	return &QueueChecker{db: db}
+func NewQueueChecker(stats QueueStatsProvider) *QueueChecker {
+	return &QueueChecker{stats: stats}
 }
 
 func (c *QueueChecker) Check(ctx context.Context) ComponentHealth {
 	start := time.Now()
 
-	var stuckCount int64
-	stuckThreshold := time.Now().Add(-10 * time.Minute)
-
-	err := c.db.WithContext(ctx).
-		Table("tasks").
-		Where("status = ? AND updated_at < ?", "processing", stuckThreshold).
-		Count(&stuckCount).Error
+	stuckCount, stuckErr := c.stats.StuckTasks(ctx, stuckTaskAge)
+	untracked := errors.Is(stuckErr, queue.ErrStuckTasksUntracked)
+	if stuckErr != nil && !untracked {
+		return ComponentHealth{
+			Status:  StatusDown,
+			Message: "failed to check queue status: " + stuckErr.Error(),
+		}
+	}
 
+	subjects, err := c.subjectsHealth(ctx)
 	if err != nil {
 		return ComponentHealth{
 			Status:  StatusDown,
-			Message: "failed to check queue status: " + err.Error(),
+			Message: "failed to check queue subjects: " + err.Error(),
 		}
 	}
 
+	details := QueueDetails{Subjects: subjects}
+	if run := c.stats.LastRetention(); !run.At.IsZero() {
+		details.Retention = &run
+	}
+	if untracked {
+		details.StuckCheck = "skipped: " + stuckErr.Error()
+	} else {
+		details.Stuck = &stuckCount
+	}
+
 	latency := time.Since(start)
 
-	if stuckCount > 10 {
+	if stuckCount > maxStuckTasks {
 		return ComponentHealth{
 			Status:  StatusDegraded,
 			Message: "high number of stuck tasks detected",
 			Latency: latency.String(),
+			Details: details,
 		}
 	}
 
 	return ComponentHealth{
 		Status:  StatusOK,
 		Latency: latency.String(),
+		Details: details,
+	}
+}
+
+// subjectsHealth joins the registered subjects with the counts of their pending tasks.
+func (c *QueueChecker) subjectsHealth(ctx context.Context) ([]QueueSubjectHealth, error) {
+	counts, err := c.stats.PendingTasks(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := c.stats.SubjectStats()
+	subjects := make([]QueueSubjectHealth, 0, len(stats))
+	for _, s := range stats {
+		subjects = append(subjects, QueueSubjectHealth{
+			SubjectStats: s,
+			Pending:      counts[s.Subject],
+		})
 	}
+	return subjects, nil
 }
 
 type SchedulerChecker struct {
@@ -0,0 +1,87 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"microgame-bot/internal/queue"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeQueueStats struct {
+	stuckErr error
+	stuck    int64
+}
+
+func (f fakeQueueStats) SubjectStats() []queue.SubjectStats {
+	return []queue.SubjectStats{{Subject: "bets.payout"}}
+}
+
+func (f fakeQueueStats) PendingTasks(context.Context) (map[string]int64, error) {
+	return map[string]int64{"bets.payout": 2}, nil
+}
+
+func (f fakeQueueStats) StuckTasks(context.Context, time.Duration) (int64, error) {
+	return f.stuck, f.stuckErr
+}
+
+func (f fakeQueueStats) LastRetention() queue.RetentionRun {
+	return queue.RetentionRun{}
+}
+
+func TestQueueChecker_Check(t *testing.T) {
+	tests := []struct {
+		name       string
+		stats      fakeQueueStats
+		status     Status
+		stuck      *int64
+		stuckCheck string
+	}{
+		{
+			name:   "few stuck tasks",
+			stats:  fakeQueueStats{stuck: 3},
+			status: StatusOK,
+			stuck:  ptr(int64(3)),
+		},
+		{
+			name:   "many stuck tasks",
+			stats:  fakeQueueStats{stuck: maxStuckTasks + 1},
+			status: StatusDegraded,
+			stuck:  ptr(int64(maxStuckTasks + 1)),
+		},
+		{
+			name:       "untracked stuck tasks",
+			stats:      fakeQueueStats{stuckErr: fmt.Errorf("%w: jetstream", queue.ErrStuckTasksUntracked)},
+			status:     StatusOK,
+			stuckCheck: "skipped: stuck tasks aren't tracked by the queue backend: jetstream",
+		},
+		{
+			name:   "failed stuck check",
+			stats:  fakeQueueStats{stuckErr: context.DeadlineExceeded},
+			status: StatusDown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			health := NewQueueChecker(tt.stats).Check(t.Context())
+			assert.Equal(t, tt.status, health.Status)
+			if tt.status == StatusDown {
+				return
+			}
+			details, ok := health.Details.(QueueDetails)
+			require.True(t, ok)
+			assert.Equal(t, tt.stuck, details.Stuck)
+			assert.Equal(t, tt.stuckCheck, details.StuckCheck)
+			require.Len(t, details.Subjects, 1)
+			assert.Equal(t, int64(2), details.Subjects[0].Pending)
+		})
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
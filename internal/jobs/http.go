@@ -0,0 +1,258 @@
+package jobs
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"microgame-bot/internal/core/logger"
+	"microgame-bot/internal/scheduler"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Path is the prefix of the scheduler endpoints, they are served next to /health.
+const Path = "/jobs"
+
+// JobView is a scheduler job as the endpoints return it, the runs are missing until there are any.
+type JobView struct {
+	NextRunAt    *time.Time      `json:"next_run_at,omitempty"`
+	LastRunAt    *time.Time      `json:"last_run_at,omitempty"`
+	Name         string          `json:"name"`
+	Expression   string          `json:"expression,omitempty"`
+	Status       string          `json:"status"`
+	Subject      string          `json:"subject"`
+	Payload      json.RawMessage `json:"payload"`
+	TaskTimeout  string          `json:"task_timeout,omitempty"`
+	Timezone     string          `json:"timezone,omitempty"`
+	Misfire      string          `json:"misfire"`
+	MisfireLimit int             `json:"misfire_limit,omitempty"`
+	OneShot      bool            `json:"one_shot"`
+}
+
+func NewJobView(job scheduler.CronJob) JobView {
+	view := JobView{
+		Name:         job.Name,
+		Expression:   job.Expression.String(),
+		Status:       string(job.Status),
+		Subject:      job.Subject,
+		Payload:      job.Payload,
+		Timezone:     job.Timezone,
+		Misfire:      string(job.Misfire),
+		MisfireLimit: job.MisfireLimit,
+		OneShot:      job.IsOneShot(),
+	}
+	if job.TaskTimeout > 0 {
+		view.TaskTimeout = job.TaskTimeout.String()
+	}
+	// A done one-shot job has no next run
+	if !job.NextRunAt.IsZero() && job.Status != scheduler.CronJobStatusDone {
+		view.NextRunAt = &job.NextRunAt
+	}
+	if !job.LastRunAt.IsZero() {
+		view.LastRunAt = &job.LastRunAt
+	}
+	return view
+}
+
+// RunView is a run of a job, the task is missing for a skipped run.
+type RunView struct {
+	ScheduledAt time.Time `json:"scheduled_at"`
+	FiredAt     time.Time `json:"fired_at"`
+	TaskID      *string   `json:"task_id,omitempty"`
+	Outcome     string    `json:"outcome"`
+	Missed      int       `json:"missed"`
+	Manual      bool      `json:"manual"`
+}
+
+func NewRunView(run scheduler.CronJobRun) RunView {
+	view := RunView{
+		ScheduledAt: run.ScheduledAt,
+		FiredAt:     run.FiredAt,
+		Outcome:     string(run.Outcome),
+		Missed:      run.Missed,
+		Manual:      run.Manual,
+	}
+	if !run.TaskID.IsZero() {
+		taskID := run.TaskID.String()
+		view.TaskID = &taskID
+	}
+	return view
+}
+
+// CreateRequest is the body of a new job, either the expression or the run time of a one-shot job is set.
+type CreateRequest struct {
+	RunAt        time.Time       `json:"run_at"`
+	Name         string          `json:"name"`
+	Subject      string          `json:"subject"`
+	Expression   string          `json:"expression"`
+	TaskTimeout  string          `json:"task_timeout"`
+	Timezone     string          `json:"timezone"`
+	Misfire      string          `json:"misfire"`
+	MisfireLimit int             `json:"misfire_limit"`
+	Payload      json.RawMessage `json:"payload"`
+}
+
+func (r CreateRequest) job() (scheduler.CronJob, error) {
+	if r.Expression != "" && !r.RunAt.IsZero() {
+		return scheduler.CronJob{}, errors.New("expression and run_at are exclusive")
+	}
+	job := scheduler.OneShotJob(r.Name, r.Subject, r.Payload, r.RunAt)
+	job.Expression = scheduler.CronExpression(r.Expression)
+	job.Timezone = r.Timezone
+	job.Misfire = scheduler.MisfirePolicy(r.Misfire)
+	job.MisfireLimit = r.MisfireLimit
+	if r.TaskTimeout != "" {
+		timeout, err := time.ParseDuration(r.TaskTimeout)
+		if err != nil {
+			return scheduler.CronJob{}, err
+		}
+		job.TaskTimeout = timeout
+	}
+	return job, nil
+}
+
+type Handler struct {
+	jobs  scheduler.IJobs
+	mux   *http.ServeMux
+	token string
+}
+
+// NewHandler serves the scheduler API, every request must carry the token as a bearer:
+//
+//	GET    /jobs                 lists the jobs with their last and next runs
+//	GET    /jobs/{name}          shows the job
+//	GET    /jobs/{name}/runs     lists the latest runs of the job with their tasks and outcomes, ?limit= caps them
+//	POST   /jobs                 creates a cron job or a one-shot one, see CreateRequest
+//	POST   /jobs/{name}/pause    pauses the job
+//	POST   /jobs/{name}/resume   resumes the job
+//	POST   /jobs/{name}/trigger  publishes the task of the job right away
+//	DELETE /jobs/{name}          deletes the job
+func NewHandler(jobs scheduler.IJobs, token string) *Handler {
+	h := &Handler{jobs: jobs, token: token, mux: http.NewServeMux()}
+	h.mux.HandleFunc("GET "+Path, h.list)
+	h.mux.HandleFunc("GET "+Path+"/{name}", h.show)
+	h.mux.HandleFunc("GET "+Path+"/{name}/runs", h.runs)
+	h.mux.HandleFunc("POST "+Path, h.create)
+	h.mux.HandleFunc("POST "+Path+"/{name}/pause", h.update(jobs.PauseJob))
+	h.mux.HandleFunc("POST "+Path+"/{name}/resume", h.update(jobs.ResumeJob))
+	h.mux.HandleFunc("POST "+Path+"/{name}/trigger", h.update(jobs.TriggerJob))
+	h.mux.HandleFunc("DELETE "+Path+"/{name}", h.delete)
+	return h
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	auth := []byte(r.Header.Get("Authorization"))
+	if h.token == "" || subtle.ConstantTimeCompare(auth, []byte("Bearer "+h.token)) != 1 {
+		writeError(w, http.StatusUnauthorized, errors.New("unauthorized"))
+		return
+	}
+	h.mux.ServeHTTP(w, r)
+}
+
+func (h *Handler) list(w http.ResponseWriter, r *http.Request) {
+	jobs, err := h.jobs.Jobs(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	views := make([]JobView, 0, len(jobs))
+	for _, job := range jobs {
+		views = append(views, NewJobView(job))
+	}
+	writeJSON(w, http.StatusOK, views)
+}
+
+func (h *Handler) show(w http.ResponseWriter, r *http.Request) {
+	job, err := h.jobs.Job(r.Context(), r.PathValue("name"))
+	writeJob(w, http.StatusOK, job, err)
+}
+
+func (h *Handler) runs(w http.ResponseWriter, r *http.Request) {
+	var limit int
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		var err error
+		if limit, err = strconv.Atoi(raw); err != nil || limit < 0 {
+			writeError(w, http.StatusBadRequest, errors.New("invalid limit"))
+			return
+		}
+	}
+	runs, err := h.jobs.Runs(r.Context(), r.PathValue("name"), limit)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	views := make([]RunView, 0, len(runs))
+	for _, run := range runs {
+		views = append(views, NewRunView(run))
+	}
+	writeJSON(w, http.StatusOK, views)
+}
+
+func (h *Handler) create(w http.ResponseWriter, r *http.Request) {
+	var req CreateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	job, err := req.job()
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	job, err = h.jobs.AddJob(r.Context(), job)
+	writeJob(w, http.StatusCreated, job, err)
+}
+
+func (h *Handler) update(
+	action func(ctx context.Context, name string) (scheduler.CronJob, error),
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		job, err := action(r.Context(), r.PathValue("name"))
+		writeJob(w, http.StatusOK, job, err)
+	}
+}
+
+func (h *Handler) delete(w http.ResponseWriter, r *http.Request) {
+	err := h.jobs.DeleteJob(r.Context(), r.PathValue("name"))
+	if err != nil {
+		writeError(w, errorStatus(err), err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeJob(w http.ResponseWriter, status int, job scheduler.CronJob, err error) {
+	if err != nil {
+		writeError(w, errorStatus(err), err)
+		return
+	}
+	writeJSON(w, status, NewJobView(job))
+}
+
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, scheduler.ErrJobNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, scheduler.ErrJobExists):
+		return http.StatusConflict
+	case errors.Is(err, scheduler.ErrInvalidJob):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		slog.Error("Failed to encode jobs response", logger.ErrorField, err.Error())
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,186 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"microgame-bot/internal/scheduler"
+	"microgame-bot/internal/utils"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type jobsStub struct {
+	added   scheduler.CronJob
+	jobs    []scheduler.CronJob
+	actions []string
+	runs    []scheduler.CronJobRun
+	limit   int
+}
+
+func (s *jobsStub) AddJob(_ context.Context, job scheduler.CronJob) (scheduler.CronJob, error) {
+	if _, err := s.Job(context.Background(), job.Name); err == nil {
+		return scheduler.CronJob{}, scheduler.ErrJobExists
+	}
+	s.added = job
+	return job, nil
+}
+
+func (s *jobsStub) Jobs(context.Context) ([]scheduler.CronJob, error) {
+	return s.jobs, nil
+}
+
+func (s *jobsStub) Job(_ context.Context, name string) (scheduler.CronJob, error) {
+	for _, job := range s.jobs {
+		if job.Name == name {
+			return job, nil
+		}
+	}
+	return scheduler.CronJob{}, scheduler.ErrJobNotFound
+}
+
+func (s *jobsStub) action(ctx context.Context, action, name string) (scheduler.CronJob, error) {
+	s.actions = append(s.actions, action+" "+name)
+	return s.Job(ctx, name)
+}
+
+func (s *jobsStub) PauseJob(ctx context.Context, name string) (scheduler.CronJob, error) {
+	return s.action(ctx, "pause", name)
+}
+
+func (s *jobsStub) ResumeJob(ctx context.Context, name string) (scheduler.CronJob, error) {
+	return s.action(ctx, "resume", name)
+}
+
+func (s *jobsStub) TriggerJob(ctx context.Context, name string) (scheduler.CronJob, error) {
+	return s.action(ctx, "trigger", name)
+}
+
+func (s *jobsStub) DeleteJob(ctx context.Context, name string) error {
+	_, err := s.action(ctx, "delete", name)
+	return err
+}
+
+func (s *jobsStub) Runs(_ context.Context, name string, limit int) ([]scheduler.CronJobRun, error) {
+	s.limit = limit
+	var runs []scheduler.CronJobRun
+	for _, run := range s.runs {
+		if run.JobName == name {
+			runs = append(runs, run)
+		}
+	}
+	return runs, nil
+}
+
+func serve(h http.Handler, method, target, body string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(method, target, strings.NewReader(body))
+	r.Header.Set("Authorization", "Bearer secret")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	return w
+}
+
+func TestHandler(t *testing.T) {
+	lastRun := time.Date(2026, 1, 5, 10, 30, 0, 0, time.UTC)
+	stub := &jobsStub{jobs: []scheduler.CronJob{{
+		Name:       "bets-payout",
+		Expression: "0 */5 * * * *",
+		Status:     scheduler.CronJobStatusActive,
+		Subject:    "bets.payout",
+		Payload:    []byte(`{}`),
+		NextRunAt:  lastRun.Add(5 * time.Minute),
+		LastRunAt:  lastRun,
+	}}}
+	h := NewHandler(stub, "secret")
+
+	r := httptest.NewRequest(http.MethodGet, Path, nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	w = serve(h, http.MethodGet, Path, "")
+	require.Equal(t, http.StatusOK, w.Code)
+	var list []JobView
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	require.Len(t, list, 1)
+	assert.Equal(t, "bets-payout", list[0].Name)
+	require.NotNil(t, list[0].LastRunAt)
+	assert.True(t, lastRun.Equal(*list[0].LastRunAt))
+	assert.False(t, list[0].OneShot)
+
+	assert.Equal(t, http.StatusNotFound, serve(h, http.MethodGet, Path+"/missing", "").Code)
+
+	for _, action := range []string{"pause", "resume", "trigger"} {
+		assert.Equal(t, http.StatusOK, serve(h, http.MethodPost, Path+"/bets-payout/"+action, "").Code)
+	}
+	assert.Equal(t, http.StatusNoContent, serve(h, http.MethodDelete, Path+"/bets-payout", "").Code)
+	assert.Equal(t, []string{
+		"pause bets-payout", "resume bets-payout", "trigger bets-payout", "delete bets-payout",
+	}, stub.actions)
+}
+
+func TestHandler_Create(t *testing.T) {
+	stub := &jobsStub{jobs: []scheduler.CronJob{{Name: "bets-payout"}}}
+	h := NewHandler(stub, "secret")
+
+	w := serve(h, http.MethodPost, Path,
+		`{"name":"promo","subject":"promo.start","run_at":"2026-02-01T12:00:00Z","payload":{"id":1}}`)
+	require.Equal(t, http.StatusCreated, w.Code)
+	assert.True(t, stub.added.IsOneShot())
+	assert.Equal(t, "promo.start", stub.added.Subject)
+	assert.JSONEq(t, `{"id":1}`, string(stub.added.Payload))
+	assert.True(t, time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC).Equal(stub.added.NextRunAt))
+
+	w = serve(h, http.MethodPost, Path, `{"name":"tick","subject":"tick","expression":"0 * * * * *","task_timeout":"1m",`+
+		`"timezone":"Europe/Moscow","misfire":"all","misfire_limit":3}`)
+	require.Equal(t, http.StatusCreated, w.Code)
+	assert.False(t, stub.added.IsOneShot())
+	assert.Equal(t, time.Minute, stub.added.TaskTimeout)
+	assert.Equal(t, "Europe/Moscow", stub.added.Timezone)
+	assert.Equal(t, scheduler.MisfireAll, stub.added.Misfire)
+	assert.Equal(t, 3, stub.added.MisfireLimit)
+
+	assert.Equal(t, http.StatusConflict,
+		serve(h, http.MethodPost, Path, `{"name":"bets-payout","subject":"bets.payout","expression":"0 * * * * *"}`).Code)
+	assert.Equal(t, http.StatusBadRequest,
+		serve(h, http.MethodPost, Path, `{"name":"x","expression":"0 * * * * *","run_at":"2026-02-01T12:00:00Z"}`).Code)
+	assert.Equal(t, http.StatusBadRequest, serve(h, http.MethodPost, Path, `{`).Code)
+}
+
+func TestHandler_Runs(t *testing.T) {
+	firedAt := time.Date(2026, 1, 5, 10, 30, 0, 0, time.UTC)
+	taskID := utils.NewUniqueID()
+	stub := &jobsStub{runs: []scheduler.CronJobRun{
+		{
+			JobName:     "locks-cleanup",
+			ScheduledAt: firedAt.Add(-time.Second),
+			FiredAt:     firedAt,
+			Outcome:     scheduler.RunOutcomeCompleted,
+			TaskID:      taskID,
+			Missed:      2,
+		},
+		{JobName: "locks-cleanup", FiredAt: firedAt, Outcome: scheduler.RunOutcomeSkipped},
+		{JobName: "bets-payout", FiredAt: firedAt, Outcome: scheduler.RunOutcomePublished},
+	}}
+	h := NewHandler(stub, "secret")
+
+	w := serve(h, http.MethodGet, Path+"/locks-cleanup/runs?limit=5", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, 5, stub.limit)
+	var runs []RunView
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &runs))
+	require.Len(t, runs, 2)
+	assert.Equal(t, "completed", runs[0].Outcome)
+	assert.Equal(t, 2, runs[0].Missed)
+	require.NotNil(t, runs[0].TaskID)
+	assert.Equal(t, taskID.String(), *runs[0].TaskID)
+	assert.Equal(t, "skipped", runs[1].Outcome)
+	assert.Nil(t, runs[1].TaskID)
+
+	assert.Equal(t, http.StatusBadRequest, serve(h, http.MethodGet, Path+"/locks-cleanup/runs?limit=x", "").Code)
+}
@@ -0,0 +1,69 @@
+package mdw
+
+import (
+	"errors"
+	"log/slog"
+	"microgame-bot/internal/core"
+	"microgame-bot/internal/core/logger"
+	"microgame-bot/internal/domain"
+	"microgame-bot/internal/domain/matchmaking"
+	repository "microgame-bot/internal/repo/matchmaking"
+	"strings"
+
+	"github.com/mymmrac/telego"
+	th "github.com/mymmrac/telego/telegohandler"
+)
+
+// QuickPlayProvider lets game handlers serve quick play games, which live in private chats.
+// A game callback pressed in a matched ticket message gets the session inline message ID,
+// and the messages of both players are put into the context, so the responses redraw both of them.
+// Must run before InlineMsgProvider, the game is locked by the inline message ID.
+func QuickPlayProvider(
+	ticketRepo repository.ITicketGetter,
+) func(ctx *th.Context, update telego.Update) error {
+	const operationName = "middleware::quick_play_provider"
+	l := slog.With(slog.String(logger.OperationField, operationName))
+	return func(ctx *th.Context, update telego.Update) error {
+		query := update.CallbackQuery
+		if query == nil || query.InlineMessageID != "" || query.Message == nil || !query.Message.IsAccessible() {
+			return ctx.Next(update)
+		}
+		if !strings.HasPrefix(query.Data, "g::") || strings.HasPrefix(query.Data, "g::quick::") {
+			return ctx.Next(update)
+		}
+		chat := query.Message.GetChat()
+		if chat.Type != telego.ChatTypePrivate {
+			return ctx.Next(update)
+		}
+
+		l.DebugContext(ctx, "QuickPlayProvider middleware started")
+		ticket, err := ticketRepo.TicketByMessage(ctx, matchmaking.Message{
+			ChatID:    chat.ID,
+			MessageID: query.Message.GetMessageID(),
+		})
+		if err != nil {
+			if errors.Is(err, domain.ErrTicketNotFound) {
+				return ctx.Next(update)
+			}
+			return err
+		}
+		if ticket.Status() != matchmaking.TicketStatusMatched {
+			return ctx.Next(update)
+		}
+
+		tickets, err := ticketRepo.TicketsBySessionID(ctx, ticket.SessionID())
+		if err != nil {
+			return err
+		}
+		messages := make([]matchmaking.Message, 0, len(tickets))
+		for _, t := range tickets {
+			messages = append(messages, t.Message())
+		}
+
+		query.InlineMessageID = matchmaking.InlineMessageID(ticket.SessionID()).String()
+		ctx = ctx.WithValue(core.ContextKeyQuickPlayMessages, messages)
+
+		l.DebugContext(ctx, "QuickPlayProvider middleware finished")
+		return ctx.Next(update)
+	}
+}
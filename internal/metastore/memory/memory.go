@@ -5,53 +5,89 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"log/slog"
+	"microgame-bot/internal/metastore"
 	"sync"
 	"time"
 )
 
+// entry is a stored value, zero expiresAt keeps the value until it is deleted.
+type entry struct {
+	expiresAt time.Time
+	data      []byte
+}
+
+func (e entry) isExpired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && !now.Before(e.expiresAt)
+}
+
 type Metastore struct {
-	store     map[string][]byte
+	store     map[string]entry
 	keyPrefix string
 	mu        sync.RWMutex
+	now       func() time.Time
 }
 
 func New(keyPrefix string) *Metastore {
 	return &Metastore{
-		store:     make(map[string][]byte),
+		store:     make(map[string]entry),
 		keyPrefix: keyPrefix,
+		now:       time.Now,
 	}
 }
 
 func (m *Metastore) Get(_ context.Context, uniqueID string, key string) ([]byte, error) {
-	m.mu.RLock()
-	defer m.mu.RUnlock()
+	dataKey := m.dataKey(uniqueID, key)
 
-	data, ok := m.store[m.dataKey(uniqueID, key)]
+	m.mu.RLock()
+	e, ok := m.store[dataKey]
+	m.mu.RUnlock()
 	if !ok {
-		return nil, errors.New("data not found")
+		return nil, metastore.ErrKeyNotFound
+	}
+	if e.isExpired(m.now()) {
+		m.deleteExpired(dataKey)
+		return nil, metastore.ErrKeyNotFound
 	}
 
-	return data, nil
+	return e.data, nil
 }
 
-func (m *Metastore) Set(_ context.Context, uniqueID string, key string, value any) error {
-	m.mu.Lock()
-	defer m.mu.Unlock()
+func (m *Metastore) GetString(ctx context.Context, uniqueID string, key string) (string, error) {
+	data, err := m.Get(ctx, uniqueID, key)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func (m *Metastore) Set(ctx context.Context, uniqueID string, key string, value any) error {
+	return m.SetWithTTL(ctx, uniqueID, key, value, 0)
+}
+
+func (m *Metastore) SetString(ctx context.Context, uniqueID string, key string, value string) error {
+	return m.SetStringWithTTL(ctx, uniqueID, key, value, 0)
+}
 
+// SetWithTTL stores the value for the ttl, zero ttl keeps the value until it is deleted.
+func (m *Metastore) SetWithTTL(_ context.Context, uniqueID string, key string, value any, ttl time.Duration) error {
 	dataBytes, err := json.Marshal(value)
 	if err != nil {
 		return fmt.Errorf("failed to json marshal value: %w", err)
 	}
 
-	m.store[m.dataKey(uniqueID, key)] = dataBytes
+	m.put(m.dataKey(uniqueID, key), dataBytes, ttl)
 	return nil
 }
 
-func (m *Metastore) SetWithTTL(ctx context.Context, uniqueID string, key string, value any, _ time.Duration) error {
-	// TODO: Implement TTL
-	slog.WarnContext(ctx, "Memory metastore does not support TTL")
-	return m.Set(ctx, uniqueID, key, value)
+func (m *Metastore) SetStringWithTTL(
+	_ context.Context,
+	uniqueID string,
+	key string,
+	value string,
+	ttl time.Duration,
+) error {
+	m.put(m.dataKey(uniqueID, key), []byte(value), ttl)
+	return nil
 }
 
 func (m *Metastore) Delete(_ context.Context, uniqueID string, key string) error {
@@ -62,12 +98,36 @@ func (m *Metastore) Delete(_ context.Context, uniqueID string, key string) error
 	return nil
 }
 
-func (m *Metastore) Exists(_ context.Context, uniqueID string, key string) (bool, error) {
-	m.mu.RLock()
-	defer m.mu.RUnlock()
+func (m *Metastore) Exists(ctx context.Context, uniqueID string, key string) (bool, error) {
+	_, err := m.Get(ctx, uniqueID, key)
+	if errors.Is(err, metastore.ErrKeyNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (m *Metastore) put(dataKey string, data []byte, ttl time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e := entry{data: data}
+	if ttl > 0 {
+		e.expiresAt = m.now().Add(ttl)
+	}
+	m.store[dataKey] = e
+}
 
-	_, ok := m.store[m.dataKey(uniqueID, key)]
-	return ok, nil
+// deleteExpired removes the key unless it was overwritten with a fresh value in the meantime.
+func (m *Metastore) deleteExpired(dataKey string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if e, ok := m.store[dataKey]; ok && e.isExpired(m.now()) {
+		delete(m.store, dataKey)
+	}
 }
 
 func (m *Metastore) dataKey(uniqueID string, key string) string {
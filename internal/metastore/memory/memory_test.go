@@ -0,0 +1,44 @@
+package memory
+
+import (
+	"context"
+	"microgame-bot/internal/metastore"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetastore_SetGet(t *testing.T) {
+	store := New("test")
+	ctx := context.Background()
+
+	require.NoError(t, store.Set(ctx, "game", "cell", 42))
+	value, err := metastore.TypedJSONMeta[int](ctx, store, "game", "cell")
+	require.NoError(t, err)
+	assert.Equal(t, 42, value)
+
+	require.NoError(t, store.Delete(ctx, "game", "cell"))
+	_, err = store.Get(ctx, "game", "cell")
+	assert.ErrorIs(t, err, metastore.ErrKeyNotFound)
+}
+
+func TestMetastore_TTLExpires(t *testing.T) {
+	store := New("test")
+	now := time.Now()
+	store.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	require.NoError(t, store.SetStringWithTTL(ctx, "game", "cell", "a1", time.Minute))
+	value, err := store.GetString(ctx, "game", "cell")
+	require.NoError(t, err)
+	assert.Equal(t, "a1", value)
+
+	now = now.Add(time.Minute)
+	_, err = store.GetString(ctx, "game", "cell")
+	require.ErrorIs(t, err, metastore.ErrKeyNotFound)
+	exists, err := store.Exists(ctx, "game", "cell")
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
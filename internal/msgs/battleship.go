@@ -0,0 +1,235 @@
+package msgs
+
+import (
+	"fmt"
+	"microgame-bot/internal/domain"
+	"microgame-bot/internal/domain/battleship"
+	domainUser "microgame-bot/internal/domain/user"
+	"strings"
+)
+
+// BattleshipCellIcon returns the icon of a board cell, shared by the keyboards and the text boards.
+func BattleshipCellIcon(mark battleship.Mark) string {
+	switch mark {
+	case battleship.MarkWater:
+		return "🌊"
+	case battleship.MarkMiss:
+		return "⚪"
+	case battleship.MarkHit:
+		return "🔥"
+	case battleship.MarkSunk:
+		return "💥"
+	case battleship.MarkShip:
+		return "🚢"
+	default:
+		return "⬜"
+	}
+}
+
+func battleshipHeader(sb *strings.Builder, creator domainUser.Username, bet domain.Token) {
+	sb.WriteString(fmt.Sprintf("@%s запустил игру <b>🚢 Морской бой</b>", creator))
+	if bet > 0 {
+		sb.WriteString(fmt.Sprintf(" 💰 <i>(ставка: %d токенов)</i>", bet))
+	}
+	sb.WriteString("\n")
+}
+
+// battleshipGrid draws the board with column letters and row numbers.
+// The owner view shows the intact ships, so it may only go to the owner or to a finished game.
+func battleshipGrid(board battleship.Board, owner bool) string {
+	var sb strings.Builder
+	sb.WriteString("<code>  ")
+	for col := range battleship.BoardSize {
+		sb.WriteString(string(rune('A' + col)))
+	}
+	sb.WriteString("</code>\n")
+	for row := range battleship.BoardSize {
+		sb.WriteString(fmt.Sprintf("<code>%d </code>", row+1))
+		for col := range battleship.BoardSize {
+			cell := battleship.Cell{Row: row, Col: col}
+			mark := board.Mark(cell)
+			if owner {
+				mark = board.OwnerMark(cell)
+			}
+			sb.WriteString(BattleshipCellIcon(mark))
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+func BattleshipStart(user domainUser.User, bet domain.Token) string {
+	var sb strings.Builder
+	battleshipHeader(&sb, user.Username(), bet)
+	sb.WriteString("\n")
+	sb.WriteString("👤 <i>Ожидание игроков...</i>")
+
+	return sb.String()
+}
+
+func BattleshipFirstPlayerJoined(creator domainUser.User, player1 domainUser.User, bet domain.Token) string {
+	var sb strings.Builder
+	battleshipHeader(&sb, creator.Username(), bet)
+	sb.WriteString(fmt.Sprintf("👤 <b>Игрок 1:</b> @%s", player1.Username()))
+	sb.WriteString("\n")
+	sb.WriteString("👤 <b>Игрок 2:</b> <i>Ожидание второго игрока...</i>")
+
+	return sb.String()
+}
+
+// buildBattleshipRoundsHistory lists the winners of the finished games.
+func buildBattleshipRoundsHistory(
+	games []battleship.Battleship,
+	player1 domainUser.User,
+	player2 domainUser.User,
+) string {
+	var sb strings.Builder
+
+	roundNum := 1
+	for _, game := range games {
+		if !game.IsFinished() {
+			continue
+		}
+		winner := player1
+		if game.WinnerID() == player2.ID() {
+			winner = player2
+		}
+		sb.WriteString(fmt.Sprintf("<b>Раунд %d:</b> победил @%s\n", roundNum, winner.Username()))
+		roundNum++
+	}
+
+	return sb.String()
+}
+
+func battleshipReadyIcon(ready bool) string {
+	if ready {
+		return "✅"
+	}
+	return "⏳"
+}
+
+// BattleshipRound generates the shared message of a series in progress.
+// It never shows the fleets: during placement only the readiness, during the battle only the ships left.
+func BattleshipRound(
+	games []battleship.Battleship,
+	current battleship.Battleship,
+	player1 domainUser.User,
+	player2 domainUser.User,
+	player1Score int,
+	player2Score int,
+	bet domain.Token,
+) string {
+	var sb strings.Builder
+	creatorUsername := getCreatorUsername(games[0].CreatorID(), player1, player2)
+	battleshipHeader(&sb, domainUser.Username(creatorUsername), bet)
+	sb.WriteString("\n")
+	if history := buildBattleshipRoundsHistory(games, player1, player2); history != "" {
+		sb.WriteString(history)
+		sb.WriteString(fmt.Sprintf("Текущий счёт: %d - %d\n", player1Score, player2Score))
+		sb.WriteString("\n")
+	}
+
+	if current.IsPlacing() {
+		sb.WriteString("⚓ <b>Расстановка кораблей</b>\n")
+		sb.WriteString(fmt.Sprintf("%s @%s\n", battleshipReadyIcon(current.Ready1()), player1.Username()))
+		sb.WriteString(fmt.Sprintf("%s @%s\n", battleshipReadyIcon(current.Ready2()), player2.Username()))
+		sb.WriteString("\n")
+		sb.WriteString("<i>Корабли расставляются в личных сообщениях с ботом, соперник их не увидит.</i>")
+		return sb.String()
+	}
+
+	sb.WriteString(fmt.Sprintf(
+		"🚢 @%s: кораблей осталось %d\n",
+		player1.Username(),
+		current.Board1().ShipsLeft(),
+	))
+	sb.WriteString(fmt.Sprintf(
+		"🚢 @%s: кораблей осталось %d\n",
+		player2.Username(),
+		current.Board2().ShipsLeft(),
+	))
+	sb.WriteString("\n")
+	shooter, target := player1, player2
+	if current.Turn() == player2.ID() {
+		shooter, target = player2, player1
+	}
+	sb.WriteString(fmt.Sprintf("🎯 Стреляет @%s по полю @%s", shooter.Username(), target.Username()))
+
+	return sb.String()
+}
+
+// BattleshipSeriesCompleted generates message when series is finished.
+// The game is over, so both fleets of the last game are revealed.
+func BattleshipSeriesCompleted(
+	games []battleship.Battleship,
+	player1 domainUser.User,
+	player2 domainUser.User,
+	player1Score int,
+	player2Score int,
+	winner domainUser.User,
+) string {
+	var sb strings.Builder
+	creatorUsername := getCreatorUsername(games[0].CreatorID(), player1, player2)
+	battleshipHeader(&sb, domainUser.Username(creatorUsername), 0)
+	sb.WriteString("\n")
+	sb.WriteString(buildBattleshipRoundsHistory(games, player1, player2))
+	sb.WriteString("\n")
+
+	last := games[len(games)-1]
+	sb.WriteString(fmt.Sprintf("Поле @%s:\n", player1.Username()))
+	sb.WriteString(battleshipGrid(last.Board1(), true))
+	sb.WriteString(fmt.Sprintf("Поле @%s:\n", player2.Username()))
+	sb.WriteString(battleshipGrid(last.Board2(), true))
+	sb.WriteString("\n")
+	sb.WriteString(fmt.Sprintf("🏆 <b>Победитель:</b> @%s (%d - %d)", winner.Username(), player1Score, player2Score))
+
+	return sb.String()
+}
+
+// BattleshipPlacement generates the private message with the fleet being placed.
+func BattleshipPlacement(board battleship.Board, orientation battleship.Orientation) string {
+	var sb strings.Builder
+	sb.WriteString("⚓ <b>Расстановка кораблей</b>\n\n")
+	size, ok := board.NextShipSize()
+	if !ok {
+		sb.WriteString("Все корабли на месте. Нажмите <b>Готово</b>, чтобы начать бой.")
+		return sb.String()
+	}
+
+	direction := "вправо ➡️"
+	if orientation == battleship.OrientationVertical {
+		direction = "вниз ⬇️"
+	}
+	sb.WriteString(fmt.Sprintf("Следующий корабль: <b>%d-палубный</b>, от выбранной клетки %s.\n", size, direction))
+	sb.WriteString("<i>Корабли не должны касаться друг друга, даже углами.</i>")
+
+	return sb.String()
+}
+
+// BattleshipFleetConfirmed generates the private message once the fleet is confirmed.
+func BattleshipFleetConfirmed(board battleship.Board) string {
+	var sb strings.Builder
+	sb.WriteString("✅ <b>Флот готов к бою</b>\n\n")
+	sb.WriteString(battleshipGrid(board, true))
+	sb.WriteString("\n")
+	sb.WriteString("<i>Бой идёт в общем сообщении, там видны только попадания и промахи.</i>")
+
+	return sb.String()
+}
+
+// BattleshipSetupSent generates callback alert when the placement keyboard is sent to the private chat.
+func BattleshipSetupSent() string {
+	return "⚓ Расстановка отправлена вам в личные сообщения"
+}
+
+// BattleshipShot generates callback alert with the result of the shot.
+func BattleshipShot(cell battleship.Cell, result battleship.ShotResult) string {
+	switch result {
+	case battleship.ShotSunk:
+		return fmt.Sprintf("💥 %s: убит!", cell)
+	case battleship.ShotHit:
+		return fmt.Sprintf("🔥 %s: ранен! Стреляйте ещё", cell)
+	default:
+		return fmt.Sprintf("⚪ %s: мимо", cell)
+	}
+}
@@ -0,0 +1,97 @@
+package msgs
+
+import (
+	"fmt"
+	"microgame-bot/internal/domain"
+	"microgame-bot/internal/domain/blackjack"
+	domainUser "microgame-bot/internal/domain/user"
+	"strings"
+)
+
+const blackjackHiddenCard = "🂠"
+
+func blackjackHeader(sb *strings.Builder, player domainUser.Username, bet domain.Token, doubled bool) {
+	sb.WriteString(fmt.Sprintf("@%s играет в <b>🃏 Блэкджек</b> против казино", player))
+	if bet > 0 {
+		sb.WriteString(fmt.Sprintf(" 💰 <i>(ставка: %d токенов", bet))
+		if doubled {
+			sb.WriteString(", удвоена")
+		}
+		sb.WriteString(")</i>")
+	}
+	sb.WriteString("\n\n")
+}
+
+func blackjackHand(hand blackjack.Hand) string {
+	return fmt.Sprintf("%s <b>(%d)</b>", hand.String(), hand.Value())
+}
+
+func blackjackOutcome(outcome blackjack.Outcome) string {
+	switch outcome {
+	case blackjack.OutcomeBlackjack:
+		return "🃏 <b>Блэкджек!</b> Выплата 3:2"
+	case blackjack.OutcomeWin:
+		return "🏆 <b>Победа!</b>"
+	case blackjack.OutcomePush:
+		return "🤝 <b>Ничья</b>, ставка возвращена"
+	default:
+		return "🏦 <b>Казино выиграло</b>"
+	}
+}
+
+// BlackjackTable generates message of a hand in progress.
+// Only the up card of the dealer is shown, the hole card is revealed when the hand is settled.
+func BlackjackTable(game blackjack.Blackjack, player domainUser.User, bet domain.Token, seedHash string) string {
+	var sb strings.Builder
+	blackjackHeader(&sb, player.Username(), bet, game.Doubled())
+
+	dealer := blackjackHiddenCard
+	if upCard, ok := game.DealerUpCard(); ok {
+		dealer = upCard.String() + " " + blackjackHiddenCard
+	}
+	sb.WriteString(fmt.Sprintf("🏦 <b>Дилер:</b> %s", dealer))
+	sb.WriteString("\n")
+	sb.WriteString(fmt.Sprintf("👤 <b>@%s:</b> %s", player.Username(), blackjackHand(game.PlayerHand())))
+	sb.WriteString("\n\n")
+	sb.WriteString("<i>Ещё карту или хватит?</i>")
+	sb.WriteString(seedHashLine(seedHash))
+
+	return sb.String()
+}
+
+// BlackjackFinished generates message of a settled hand with both hands open and the seed revealed,
+// so the dealt cards can be checked against the shoe.
+func BlackjackFinished(
+	game blackjack.Blackjack,
+	player domainUser.User,
+	bet domain.Token,
+	payout domain.Token,
+	seed string,
+) string {
+	var sb strings.Builder
+	blackjackHeader(&sb, player.Username(), bet, game.Doubled())
+	sb.WriteString(fmt.Sprintf("🏦 <b>Дилер:</b> %s", blackjackHand(game.DealerHand())))
+	sb.WriteString("\n")
+	sb.WriteString(fmt.Sprintf("👤 <b>@%s:</b> %s", player.Username(), blackjackHand(game.PlayerHand())))
+	sb.WriteString("\n\n")
+	sb.WriteString(blackjackOutcome(game.Outcome()))
+	if bet > 0 {
+		sb.WriteString("\n")
+		sb.WriteString(fmt.Sprintf("💰 <b>Выплата:</b> %d токенов", payout))
+	}
+	sb.WriteString(seedRevealLine(seed))
+	if seed != "" {
+		sb.WriteString(fmt.Sprintf(
+			"\n<i>Шуз: %d колод, перемешанных по сиду и id раздачи</i> <code>%s</code>",
+			blackjack.ShoeDecks,
+			game.ID().String(),
+		))
+	}
+
+	return sb.String()
+}
+
+// BlackjackCardDrawn generates callback alert with the card the player has drawn.
+func BlackjackCardDrawn(card blackjack.Card) string {
+	return "🃏 Карта: " + card.String()
+}
@@ -0,0 +1,242 @@
+package msgs
+
+import (
+	"fmt"
+	"microgame-bot/internal/domain"
+	"microgame-bot/internal/domain/bullscows"
+	domainUser "microgame-bot/internal/domain/user"
+	"strings"
+)
+
+func bullsCowsHeader(sb *strings.Builder, creator domainUser.Username, bet domain.Token) {
+	sb.WriteString(fmt.Sprintf("@%s запустил игру <b>🐂 Быки и коровы</b>", creator))
+	if bet > 0 {
+		sb.WriteString(fmt.Sprintf(" 💰 <i>(ставка: %d токенов)</i>", bet))
+	}
+	sb.WriteString("\n")
+}
+
+func BullsCowsStart(user domainUser.User, bet domain.Token) string {
+	var sb strings.Builder
+	bullsCowsHeader(&sb, user.Username(), bet)
+	sb.WriteString("\n")
+	sb.WriteString("👤 <i>Ожидание игроков...</i>")
+
+	return sb.String()
+}
+
+func BullsCowsFirstPlayerJoined(creator domainUser.User, player1 domainUser.User, bet domain.Token) string {
+	var sb strings.Builder
+	bullsCowsHeader(&sb, creator.Username(), bet)
+	sb.WriteString(fmt.Sprintf("👤 <b>Игрок 1:</b> @%s", player1.Username()))
+	sb.WriteString("\n")
+	sb.WriteString("👤 <b>Игрок 2:</b> <i>Ожидание второго игрока...</i>")
+
+	return sb.String()
+}
+
+// buildBullsCowsRoundsHistory lists the results of the finished games, their secrets are no longer secret.
+func buildBullsCowsRoundsHistory(games []bullscows.BullsCows, player1 domainUser.User, player2 domainUser.User) string {
+	var sb strings.Builder
+
+	roundNum := 1
+	for _, game := range games {
+		if !game.IsFinished() {
+			continue
+		}
+		result := "🤝 ничья"
+		switch game.WinnerID() {
+		case player1.ID():
+			result = "🏆 @" + string(player1.Username())
+		case player2.ID():
+			result = "🏆 @" + string(player2.Username())
+		}
+		sb.WriteString(fmt.Sprintf(
+			"<b>Раунд %d:</b> <code>%s</code> / <code>%s</code> %s\n",
+			roundNum,
+			bullsCowsSecretLabel(game.Secret1()),
+			bullsCowsSecretLabel(game.Secret2()),
+			result,
+		))
+		roundNum++
+	}
+
+	return sb.String()
+}
+
+// bullsCowsSecretLabel shows a secret of a finished game, a game may end before the secret is set.
+func bullsCowsSecretLabel(secret bullscows.Number) string {
+	if secret == "" {
+		return "????"
+	}
+	return secret.String()
+}
+
+func bullsCowsReadyIcon(ready bool) string {
+	if ready {
+		return "✅"
+	}
+	return "⏳"
+}
+
+// buildBullsCowsGuesses lists the guesses of the player with their bulls and cows.
+func buildBullsCowsGuesses(game bullscows.BullsCows, player domainUser.User) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf(
+		"🔢 <b>@%s</b> <i>(попыток осталось: %d)</i>\n",
+		player.Username(),
+		game.GuessesLeft(player.ID()),
+	))
+	for i, guess := range game.GuessesOf(player.ID()) {
+		sb.WriteString(fmt.Sprintf("<code>%2d. %s</code> %s\n", i+1, guess.Number, bullsCowsScore(guess)))
+	}
+	return sb.String()
+}
+
+func bullsCowsScore(guess bullscows.Guess) string {
+	if guess.IsHit() {
+		return "🎯"
+	}
+	return fmt.Sprintf("🐂 %d 🐄 %d", guess.Bulls, guess.Cows)
+}
+
+// BullsCowsRound generates the shared message of a series in progress.
+// It never shows the secrets: while they are set only the readiness, then only the scored guesses.
+func BullsCowsRound(
+	games []bullscows.BullsCows,
+	current bullscows.BullsCows,
+	player1 domainUser.User,
+	player2 domainUser.User,
+	player1Score int,
+	player2Score int,
+	draws int,
+	bet domain.Token,
+) string {
+	var sb strings.Builder
+	creatorUsername := getCreatorUsername(games[0].CreatorID(), player1, player2)
+	bullsCowsHeader(&sb, domainUser.Username(creatorUsername), bet)
+	sb.WriteString("\n")
+	if history := buildBullsCowsRoundsHistory(games, player1, player2); history != "" {
+		sb.WriteString(history)
+		sb.WriteString(fmt.Sprintf("Текущий счёт: %d - %d", player1Score, player2Score))
+		if draws > 0 {
+			sb.WriteString(fmt.Sprintf(" 🏳️ <b>Ничьих:</b> %d", draws))
+		}
+		sb.WriteString("\n\n")
+	}
+
+	if current.IsSetting() {
+		sb.WriteString("🔐 <b>Загадываем числа</b>\n")
+		sb.WriteString(fmt.Sprintf("%s @%s\n", bullsCowsReadyIcon(current.IsReady(player1.ID())), player1.Username()))
+		sb.WriteString(fmt.Sprintf("%s @%s\n", bullsCowsReadyIcon(current.IsReady(player2.ID())), player2.Username()))
+		sb.WriteString("\n")
+		sb.WriteString(fmt.Sprintf(
+			"<i>Число из %d разных цифр загадывается в личных сообщениях с ботом, соперник его не увидит.</i>",
+			bullscows.SecretLength,
+		))
+		return sb.String()
+	}
+
+	sb.WriteString(buildBullsCowsGuesses(current, player1))
+	sb.WriteString("\n")
+	sb.WriteString(buildBullsCowsGuesses(current, player2))
+	sb.WriteString("\n")
+
+	guesser, owner := player1, player2
+	if current.Turn() == player2.ID() {
+		guesser, owner = player2, player1
+	}
+	if guesses := current.GuessesOf(owner.ID()); len(guesses) > 0 && guesses[len(guesses)-1].IsHit() {
+		sb.WriteString(fmt.Sprintf("🎯 @%s угадал число! У @%s последняя попытка\n", owner.Username(), guesser.Username()))
+	}
+	sb.WriteString(fmt.Sprintf("❓ Угадывает @%s число @%s", guesser.Username(), owner.Username()))
+
+	return sb.String()
+}
+
+// BullsCowsSeriesCompleted generates message when series is finished.
+func BullsCowsSeriesCompleted(
+	games []bullscows.BullsCows,
+	player1 domainUser.User,
+	player2 domainUser.User,
+	player1Score int,
+	player2Score int,
+	draws int,
+	winner domainUser.User,
+) string {
+	var sb strings.Builder
+	creatorUsername := getCreatorUsername(games[0].CreatorID(), player1, player2)
+	bullsCowsHeader(&sb, domainUser.Username(creatorUsername), 0)
+	sb.WriteString("\n")
+	sb.WriteString(buildBullsCowsRoundsHistory(games, player1, player2))
+	sb.WriteString("\n")
+	sb.WriteString(fmt.Sprintf("🏆 <b>Победитель:</b> @%s (%d - %d)", winner.Username(), player1Score, player2Score))
+	if draws > 0 {
+		sb.WriteString("\n")
+		sb.WriteString(fmt.Sprintf("🏳️ <b>Ничьих:</b> %d", draws))
+	}
+
+	return sb.String()
+}
+
+// BullsCowsSeriesDraw generates message when series ends in a draw.
+func BullsCowsSeriesDraw(
+	games []bullscows.BullsCows,
+	player1 domainUser.User,
+	player2 domainUser.User,
+	player1Score int,
+	player2Score int,
+	draws int,
+) string {
+	var sb strings.Builder
+	creatorUsername := getCreatorUsername(games[0].CreatorID(), player1, player2)
+	bullsCowsHeader(&sb, domainUser.Username(creatorUsername), 0)
+	sb.WriteString("\n")
+	sb.WriteString(buildBullsCowsRoundsHistory(games, player1, player2))
+	sb.WriteString("\n")
+	sb.WriteString(fmt.Sprintf("🤝 <b>Ничья!</b> (%d - %d)", player1Score, player2Score))
+	if draws > 0 {
+		sb.WriteString("\n")
+		sb.WriteString(fmt.Sprintf("🏳️ <b>Ничьих:</b> %d", draws))
+	}
+
+	return sb.String()
+}
+
+// BullsCowsDraft shows the digits typed so far on the keypad, e.g. "🔢 12••".
+func BullsCowsDraft(draft bullscows.Number) string {
+	return "🔢 " + draft.String() + strings.Repeat("•", bullscows.SecretLength-len(draft))
+}
+
+// BullsCowsSecretPrompt generates the private message with the secret keypad.
+func BullsCowsSecretPrompt() string {
+	var sb strings.Builder
+	sb.WriteString("🔐 <b>Загадайте число</b>\n\n")
+	sb.WriteString(fmt.Sprintf("Наберите %d разные цифры, число может начинаться с нуля. ", bullscows.SecretLength))
+	sb.WriteString("Нажмите ✅, когда число готово, изменить его потом будет нельзя.\n")
+	sb.WriteString("<i>🐂 Бык — цифра на своём месте, 🐄 корова — цифра есть, но на другом месте.</i>")
+
+	return sb.String()
+}
+
+// BullsCowsSecretSet generates the private message once the secret is set.
+func BullsCowsSecretSet(secret bullscows.Number) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("✅ <b>Ваше число:</b> <code>%s</code>\n\n", secret))
+	sb.WriteString("<i>Угадывание идёт в общем сообщении, там видны только быки и коровы.</i>")
+
+	return sb.String()
+}
+
+// BullsCowsSetupSent generates callback alert when the secret keypad is sent to the private chat.
+func BullsCowsSetupSent() string {
+	return "🔐 Клавиатура для числа отправлена вам в личные сообщения"
+}
+
+// BullsCowsGuessed generates callback alert with the bulls and cows of the guess.
+func BullsCowsGuessed(guess bullscows.Guess) string {
+	if guess.IsHit() {
+		return fmt.Sprintf("🎯 %s: число угадано!", guess.Number)
+	}
+	return fmt.Sprintf("%s: быков %d, коров %d", guess.Number, guess.Bulls, guess.Cows)
+}
@@ -0,0 +1,16 @@
+package msgs
+
+import (
+	"fmt"
+	domainUser "microgame-bot/internal/domain/user"
+	"strings"
+)
+
+func GameCancelledByCreator(creator domainUser.User) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("❌ @%s отменил игру", creator.Username()))
+	sb.WriteString("\n\n")
+	sb.WriteString("<i>Ставки возвращены.</i>")
+
+	return sb.String()
+}
@@ -0,0 +1,194 @@
+package msgs
+
+import (
+	"fmt"
+	"microgame-bot/internal/domain"
+	"microgame-bot/internal/domain/checkers"
+	domainUser "microgame-bot/internal/domain/user"
+	"strings"
+)
+
+func checkersHeader(sb *strings.Builder, creator domainUser.Username, bet domain.Token) {
+	sb.WriteString(fmt.Sprintf(
+		"@%s запустил игру <b>%s%s Шашки</b>",
+		creator,
+		checkers.PieceWhiteManIcon,
+		checkers.PieceBlackManIcon,
+	))
+	if bet > 0 {
+		sb.WriteString(fmt.Sprintf(" 💰 <i>(ставка: %d токенов)</i>", bet))
+	}
+	sb.WriteString("\n")
+}
+
+func CheckersStart(user domainUser.User, bet domain.Token) string {
+	var sb strings.Builder
+	checkersHeader(&sb, user.Username(), bet)
+	sb.WriteString("\n")
+	sb.WriteString("👤 <i>Ожидание игроков...</i>")
+
+	return sb.String()
+}
+
+func CheckersFirstPlayerJoined(creator domainUser.User, player1 domainUser.User, bet domain.Token) string {
+	var sb strings.Builder
+	checkersHeader(&sb, creator.Username(), bet)
+	sb.WriteString(fmt.Sprintf("👤 <b>Игрок 1:</b> @%s", player1.Username()))
+	sb.WriteString("\n")
+	sb.WriteString("👤 <b>Игрок 2:</b> <i>Ожидание второго игрока...</i>")
+
+	return sb.String()
+}
+
+// buildCheckersRoundsHistory lists the pieces left in the finished games, colors change between rounds.
+func buildCheckersRoundsHistory(games []checkers.Checkers, player1 domainUser.User, player2 domainUser.User) string {
+	var sb strings.Builder
+
+	roundNum := 1
+	for _, game := range games {
+		if !game.IsFinished() {
+			continue
+		}
+		result := "🤝 ничья"
+		switch game.WinnerID() {
+		case player1.ID():
+			result = "🏆 @" + string(player1.Username())
+		case player2.ID():
+			result = "🏆 @" + string(player2.Username())
+		}
+		sb.WriteString(fmt.Sprintf(
+			"<b>Раунд %d:</b> %s %d - %d %s %s\n",
+			roundNum,
+			checkers.PieceWhiteManIcon,
+			game.Board().Count(checkers.ColorWhite),
+			game.Board().Count(checkers.ColorBlack),
+			checkers.PieceBlackManIcon,
+			result,
+		))
+		roundNum++
+	}
+
+	return sb.String()
+}
+
+func checkersPlayerLine(game checkers.Checkers, player domainUser.User) string {
+	mark := "👤"
+	if game.Turn() == player.ID() && !game.IsFinished() {
+		mark = "▶️"
+	}
+	return fmt.Sprintf(
+		"%s %s @%s - %d",
+		mark,
+		checkersColorIcon(game.PlayerColor(player.ID())),
+		player.Username(),
+		game.Pieces(player.ID()),
+	)
+}
+
+// CheckersRound generates message of a series in progress: finished rounds,
+// the series score and pieces left in the current game.
+func CheckersRound(
+	games []checkers.Checkers,
+	current checkers.Checkers,
+	player1 domainUser.User,
+	player2 domainUser.User,
+	player1Score int,
+	player2Score int,
+	draws int,
+	bet domain.Token,
+) string {
+	var sb strings.Builder
+	creatorUsername := getCreatorUsername(games[0].CreatorID(), player1, player2)
+	checkersHeader(&sb, domainUser.Username(creatorUsername), bet)
+	sb.WriteString("\n")
+	if history := buildCheckersRoundsHistory(games, player1, player2); history != "" {
+		sb.WriteString(history)
+		sb.WriteString(fmt.Sprintf("Текущий счёт: %d - %d", player1Score, player2Score))
+		if draws > 0 {
+			sb.WriteString(fmt.Sprintf(" 🏳️ <b>Ничьих:</b> %d", draws))
+		}
+		sb.WriteString("\n\n")
+	}
+
+	sb.WriteString(checkersPlayerLine(current, player1))
+	sb.WriteString("\n")
+	sb.WriteString(checkersPlayerLine(current, player2))
+	if chain, ok := current.Chain(); ok {
+		sb.WriteString("\n\n")
+		sb.WriteString(fmt.Sprintf("⛓ Взятие продолжается шашкой на %s", chain))
+	}
+
+	return sb.String()
+}
+
+// CheckersSeriesCompleted generates message when series is finished.
+func CheckersSeriesCompleted(
+	games []checkers.Checkers,
+	player1 domainUser.User,
+	player2 domainUser.User,
+	player1Score int,
+	player2Score int,
+	draws int,
+	winner domainUser.User,
+) string {
+	var sb strings.Builder
+	creatorUsername := getCreatorUsername(games[0].CreatorID(), player1, player2)
+	checkersHeader(&sb, domainUser.Username(creatorUsername), 0)
+	sb.WriteString("\n")
+	sb.WriteString(buildCheckersRoundsHistory(games, player1, player2))
+	sb.WriteString("\n")
+	sb.WriteString(fmt.Sprintf("🏆 <b>Победитель:</b> @%s (%d - %d)", winner.Username(), player1Score, player2Score))
+	if draws > 0 {
+		sb.WriteString("\n")
+		sb.WriteString(fmt.Sprintf("🏳️ <b>Ничьих:</b> %d", draws))
+	}
+
+	return sb.String()
+}
+
+// CheckersSeriesDraw generates message when series ends in a draw.
+func CheckersSeriesDraw(
+	games []checkers.Checkers,
+	player1 domainUser.User,
+	player2 domainUser.User,
+	player1Score int,
+	player2Score int,
+	draws int,
+) string {
+	var sb strings.Builder
+	creatorUsername := getCreatorUsername(games[0].CreatorID(), player1, player2)
+	checkersHeader(&sb, domainUser.Username(creatorUsername), 0)
+	sb.WriteString("\n")
+	sb.WriteString(buildCheckersRoundsHistory(games, player1, player2))
+	sb.WriteString("\n")
+	sb.WriteString(fmt.Sprintf("🤝 <b>Ничья!</b> (%d - %d)", player1Score, player2Score))
+	if draws > 0 {
+		sb.WriteString("\n")
+		sb.WriteString(fmt.Sprintf("🏳️ <b>Ничьих:</b> %d", draws))
+	}
+
+	return sb.String()
+}
+
+func checkersColorIcon(color checkers.Color) string {
+	if color == checkers.ColorBlack {
+		return checkers.PieceBlackManIcon
+	}
+	return checkers.PieceWhiteManIcon
+}
+
+// CheckersSelected generates callback alert for the piece chosen by the first tap.
+func CheckersSelected(cell checkers.Cell) string {
+	return fmt.Sprintf("Шашка %s выбрана, куда ходим?", cell)
+}
+
+// CheckersMoved generates callback alert with the step made, a capture is written with a colon.
+func CheckersMoved(step checkers.Move, chainContinues bool) string {
+	if !step.IsCapture() {
+		return fmt.Sprintf("✅ Ход %s-%s", step.From, step.To)
+	}
+	if chainContinues {
+		return fmt.Sprintf("⚔️ Взятие %s:%s, бейте дальше!", step.From, step.To)
+	}
+	return fmt.Sprintf("⚔️ Взятие %s:%s", step.From, step.To)
+}
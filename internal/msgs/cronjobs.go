@@ -0,0 +1,137 @@
+package msgs
+
+import (
+	"fmt"
+	"html"
+	"microgame-bot/internal/scheduler"
+	"strings"
+)
+
+const cronJobTimeLayout = "02.01 15:04:05"
+
+// CronJobList lists the scheduler jobs with their last and next runs.
+func CronJobList(jobs []scheduler.CronJob) string {
+	var sb strings.Builder
+	sb.WriteString("⏰ <b>Задачи планировщика</b>\n\n")
+	if len(jobs) == 0 {
+		sb.WriteString("<i>Задач нет</i>\n\n")
+	}
+	for _, job := range jobs {
+		sb.WriteString(CronJob(job))
+		sb.WriteString("\n")
+	}
+	sb.WriteString(CronJobUsage())
+	return sb.String()
+}
+
+// CronJob shows the job with its schedule and runs.
+func CronJob(job scheduler.CronJob) string {
+	schedule := "однократно"
+	if !job.IsOneShot() {
+		schedule = "<code>" + html.EscapeString(job.Expression.String()) + "</code>"
+		if job.Timezone != "" {
+			schedule += " " + html.EscapeString(job.Timezone)
+		}
+		schedule += " · пропуски: " + cronJobMisfire(job)
+	}
+	lastRun, nextRun := "—", "—"
+	if !job.LastRunAt.IsZero() {
+		lastRun = job.LastRunAt.Format(cronJobTimeLayout)
+	}
+	if !job.NextRunAt.IsZero() && job.Status != scheduler.CronJobStatusDone {
+		nextRun = job.NextRunAt.Format(cronJobTimeLayout)
+	}
+	return fmt.Sprintf(
+		"<b>%s</b> · %s · %s\n%s · последний %s · следующий %s\n",
+		html.EscapeString(job.Name),
+		html.EscapeString(job.Subject),
+		job.Status,
+		schedule,
+		lastRun,
+		nextRun,
+	)
+}
+
+func cronJobMisfire(job scheduler.CronJob) string {
+	switch job.Misfire {
+	case scheduler.MisfireAll:
+		if job.MisfireLimit > 0 {
+			return fmt.Sprintf("все, до %d", job.MisfireLimit)
+		}
+		return "все"
+	case scheduler.MisfireSkip:
+		return "пропустить"
+	default:
+		return "один раз"
+	}
+}
+
+// CronJobRuns lists the latest runs of the job with their outcomes.
+func CronJobRuns(name string, runs []scheduler.CronJobRun) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "📜 <b>Запуски %s</b>\n\n", html.EscapeString(name))
+	if len(runs) == 0 {
+		sb.WriteString("<i>Запусков нет</i>\n")
+	}
+	for _, run := range runs {
+		fmt.Fprintf(&sb, "%s · %s", run.FiredAt.Format(cronJobTimeLayout), cronJobRunOutcome(run.Outcome))
+		if !run.ScheduledAt.Equal(run.FiredAt) {
+			fmt.Fprintf(&sb, " · по плану %s", run.ScheduledAt.Format(cronJobTimeLayout))
+		}
+		if run.Manual {
+			sb.WriteString(" · вручную")
+		}
+		if run.Missed > 0 {
+			fmt.Fprintf(&sb, " · пропущено %d", run.Missed)
+		}
+		if !run.TaskID.IsZero() {
+			fmt.Fprintf(&sb, "\n<code>%s</code>", run.TaskID)
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+func cronJobRunOutcome(outcome scheduler.RunOutcome) string {
+	switch outcome {
+	case scheduler.RunOutcomeCompleted:
+		return "✅ выполнен"
+	case scheduler.RunOutcomeFailed:
+		return "❌ ошибка"
+	case scheduler.RunOutcomeSkipped:
+		return "⏭ пропущен"
+	case scheduler.RunOutcomeDeduplicated:
+		return "🔁 уже в очереди"
+	default:
+		return "⏳ в очереди"
+	}
+}
+
+// CronJobNotFound reports that there is no job with the name.
+func CronJobNotFound() string {
+	return "🤷 Задача планировщика не найдена"
+}
+
+// CronJobExists reports that the name of the new job is taken.
+func CronJobExists() string {
+	return "⚠️ Задача с таким именем уже есть"
+}
+
+// CronJobInvalid reports why the new job is rejected.
+func CronJobInvalid(err error) string {
+	return fmt.Sprintf("⚠️ Неверная задача: <i>%s</i>", html.EscapeString(err.Error()))
+}
+
+// CronJobDeleted reports the deleted job.
+func CronJobDeleted(name string) string {
+	return fmt.Sprintf("🗑 Задача <b>%s</b> удалена", html.EscapeString(name))
+}
+
+// CronJobUsage lists the subcommands of the scheduler command.
+func CronJobUsage() string {
+	return "<i>/cron — список\n" +
+		"/cron add &lt;имя&gt; &lt;тема&gt; &lt;сек мин час день месяц день_недели&gt; — по расписанию\n" +
+		"/cron at &lt;имя&gt; &lt;тема&gt; &lt;время, RFC 3339&gt; — однократно\n" +
+		"/cron pause | resume | run | delete &lt;имя&gt;\n" +
+		"/cron runs &lt;имя&gt; — последние запуски</i>"
+}
@@ -0,0 +1,97 @@
+package msgs
+
+import (
+	"fmt"
+	"html"
+	"microgame-bot/internal/queue"
+	"strings"
+)
+
+const (
+	deadLetterTimeLayout = "02.01 15:04:05"
+	// Telegram messages are limited to 4096 characters, long errors and payloads are cut.
+	deadLetterErrorLimit   = 200
+	deadLetterPayloadLimit = 2000
+)
+
+// DeadLetterList lists the failed tasks for the admin, the latest failures first.
+func DeadLetterList(tasks []queue.Task, subject string) string {
+	var sb strings.Builder
+	sb.WriteString("☠️ <b>Упавшие задачи</b>")
+	if subject != "" {
+		sb.WriteString(fmt.Sprintf(" <code>%s</code>", html.EscapeString(subject)))
+	}
+	sb.WriteString("\n\n")
+	if len(tasks) == 0 {
+		sb.WriteString("<i>Очередь мёртвых писем пуста</i>")
+		return sb.String()
+	}
+	for _, task := range tasks {
+		sb.WriteString(fmt.Sprintf(
+			"<code>%s</code>\n%s · %s · попыток %d/%d\n<i>%s</i>\n\n",
+			task.ID,
+			html.EscapeString(task.Subject),
+			task.UpdatedAt.Format(deadLetterTimeLayout),
+			task.Attempts,
+			task.MaxAttempts,
+			html.EscapeString(cutDeadLetter(task.LastError, deadLetterErrorLimit)),
+		))
+	}
+	sb.WriteString(DeadLetterUsage())
+	return sb.String()
+}
+
+// DeadLetterTask shows the payload and every failed attempt of the task.
+func DeadLetterTask(task queue.Task) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("☠️ <b>Задача</b> <code>%s</code>\n", task.ID))
+	sb.WriteString(fmt.Sprintf("<b>Тема:</b> %s\n", html.EscapeString(task.Subject)))
+	sb.WriteString(fmt.Sprintf("<b>Создана:</b> %s\n", task.CreatedAt.Format(deadLetterTimeLayout)))
+	sb.WriteString(fmt.Sprintf("<b>Попыток:</b> %d/%d\n\n", task.Attempts, task.MaxAttempts))
+	sb.WriteString(fmt.Sprintf("<b>Данные:</b>\n<pre>%s</pre>\n\n", html.EscapeString(cutDeadLetter(string(task.Payload), deadLetterPayloadLimit))))
+	sb.WriteString("<b>Ошибки:</b>\n")
+	if len(task.Errors) == 0 {
+		// Tasks failed before the history was kept only have the last error
+		sb.WriteString(fmt.Sprintf("<i>%s</i>\n", html.EscapeString(cutDeadLetter(task.LastError, deadLetterErrorLimit))))
+	}
+	for _, attempt := range task.Errors {
+		sb.WriteString(fmt.Sprintf(
+			"%d. %s <i>%s</i>\n",
+			attempt.Attempt,
+			attempt.At.Format(deadLetterTimeLayout),
+			html.EscapeString(cutDeadLetter(attempt.Error, deadLetterErrorLimit)),
+		))
+	}
+	return sb.String()
+}
+
+// DeadLetterNotFound reports that there is no failed task with the ID.
+func DeadLetterNotFound() string {
+	return "🤷 Упавшая задача не найдена"
+}
+
+// DeadLetterRequeued reports the number of the requeued tasks.
+func DeadLetterRequeued(count int64) string {
+	return fmt.Sprintf("🔁 Возвращено в очередь: %d", count)
+}
+
+// DeadLetterDiscarded reports the number of the deleted tasks.
+func DeadLetterDiscarded(count int64) string {
+	return fmt.Sprintf("🗑 Удалено: %d", count)
+}
+
+// DeadLetterUsage lists the subcommands of the dead-letter command.
+func DeadLetterUsage() string {
+	return "<i>/dlq [тема] — список\n" +
+		"/dlq show &lt;id&gt; — данные и ошибки\n" +
+		"/dlq requeue &lt;id…&gt; | &lt;тема&gt; [с, RFC 3339] — вернуть в очередь\n" +
+		"/dlq discard &lt;id…&gt; | &lt;тема&gt; [с, RFC 3339] — удалить</i>"
+}
+
+func cutDeadLetter(text string, limit int) string {
+	runes := []rune(text)
+	if len(runes) <= limit {
+		return text
+	}
+	return string(runes[:limit]) + "…"
+}
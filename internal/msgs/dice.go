@@ -0,0 +1,170 @@
+package msgs
+
+import (
+	"fmt"
+	"microgame-bot/internal/domain"
+	"microgame-bot/internal/domain/dice"
+	domainUser "microgame-bot/internal/domain/user"
+	"strings"
+)
+
+const diceValueEmptyIcon = "⬜"
+
+func diceHeader(sb *strings.Builder, creator domainUser.Username, kind dice.Kind, bet domain.Token) {
+	sb.WriteString(fmt.Sprintf("@%s запустил дуэль <b>%s %s</b>", creator, kind.Emoji(), kind.Title()))
+	if bet > 0 {
+		sb.WriteString(fmt.Sprintf(" 💰 <i>(ставка: %d токенов)</i>", bet))
+	}
+	sb.WriteString("\n")
+}
+
+func diceValue(throw dice.Throw) string {
+	if throw.IsZero() {
+		return diceValueEmptyIcon
+	}
+	return fmt.Sprintf("<b>%d</b>", throw.Value)
+}
+
+func DiceStart(user domainUser.User, kind dice.Kind, bet domain.Token) string {
+	var sb strings.Builder
+	diceHeader(&sb, user.Username(), kind, bet)
+	sb.WriteString("\n")
+	sb.WriteString("👤 <i>Ожидание игроков...</i>")
+
+	return sb.String()
+}
+
+func DiceFirstPlayerJoined(
+	creator domainUser.User,
+	player1 domainUser.User,
+	kind dice.Kind,
+	bet domain.Token,
+) string {
+	var sb strings.Builder
+	diceHeader(&sb, creator.Username(), kind, bet)
+	sb.WriteString(fmt.Sprintf("👤 <b>Игрок 1:</b> @%s", player1.Username()))
+	sb.WriteString("\n")
+	sb.WriteString("👤 <b>Игрок 2:</b> <i>Ожидание второго игрока...</i>")
+
+	return sb.String()
+}
+
+// buildDiceRoundsHistory generates rounds history section of the finished games.
+func buildDiceRoundsHistory(games []dice.Dice, player1 domainUser.User, player2 domainUser.User) string {
+	var sb strings.Builder
+
+	roundNum := 1
+	for _, game := range games {
+		if game.IsFinished() {
+			sb.WriteString(fmt.Sprintf(
+				"<b>Раунд %d:</b> \n@%s %s\n@%s %s\n",
+				roundNum,
+				player1.Username(),
+				diceValue(game.Throw1()),
+				player2.Username(),
+				diceValue(game.Throw2()),
+			))
+			roundNum++
+		}
+	}
+
+	return sb.String()
+}
+
+// DiceRound generates message of a series in progress: finished rounds,
+// throws of the current round and the score.
+func DiceRound(
+	games []dice.Dice,
+	current dice.Dice,
+	player1 domainUser.User,
+	player2 domainUser.User,
+	player1Score int,
+	player2Score int,
+	draws int,
+	bet domain.Token,
+) string {
+	var sb strings.Builder
+	creatorUsername := getCreatorUsername(games[0].CreatorID(), player1, player2)
+	diceHeader(&sb, domainUser.Username(creatorUsername), current.Kind(), bet)
+	sb.WriteString("\n")
+	if history := buildDiceRoundsHistory(games, player1, player2); history != "" {
+		sb.WriteString(history)
+		sb.WriteString("\n")
+	}
+	sb.WriteString("Текущий счёт:\n")
+	sb.WriteString(fmt.Sprintf(
+		"👤 <b>Игрок 1:</b> @%s %s - %d",
+		player1.Username(),
+		diceValue(current.Throw1()),
+		player1Score,
+	))
+	sb.WriteString("\n")
+	sb.WriteString(fmt.Sprintf(
+		"👤 <b>Игрок 2:</b> @%s %s - %d",
+		player2.Username(),
+		diceValue(current.Throw2()),
+		player2Score,
+	))
+	sb.WriteString("\n")
+	if draws > 0 {
+		sb.WriteString(fmt.Sprintf("🏳️ <b>Ничьих:</b> %d", draws))
+		sb.WriteString("\n")
+	}
+	sb.WriteString(fmt.Sprintf("%s Игроки бросают...", current.Kind().Emoji()))
+
+	return sb.String()
+}
+
+// DiceSeriesCompleted generates message when series is finished.
+func DiceSeriesCompleted(
+	games []dice.Dice,
+	player1 domainUser.User,
+	player2 domainUser.User,
+	player1Score int,
+	player2Score int,
+	draws int,
+	winner domainUser.User,
+) string {
+	var sb strings.Builder
+	creatorUsername := getCreatorUsername(games[0].CreatorID(), player1, player2)
+	diceHeader(&sb, domainUser.Username(creatorUsername), games[0].Kind(), 0)
+	sb.WriteString("\n")
+	sb.WriteString(buildDiceRoundsHistory(games, player1, player2))
+	sb.WriteString("\n")
+	sb.WriteString(fmt.Sprintf("🏆 <b>Победитель:</b> @%s (%d - %d)", winner.Username(), player1Score, player2Score))
+	if draws > 0 {
+		sb.WriteString("\n")
+		sb.WriteString(fmt.Sprintf("🏳️ <b>Ничьих:</b> %d", draws))
+	}
+
+	return sb.String()
+}
+
+// DiceSeriesDraw generates message when series ends in a draw.
+func DiceSeriesDraw(
+	games []dice.Dice,
+	player1 domainUser.User,
+	player2 domainUser.User,
+	player1Score int,
+	player2Score int,
+	draws int,
+) string {
+	var sb strings.Builder
+	creatorUsername := getCreatorUsername(games[0].CreatorID(), player1, player2)
+	diceHeader(&sb, domainUser.Username(creatorUsername), games[0].Kind(), 0)
+	sb.WriteString("\n")
+	sb.WriteString(buildDiceRoundsHistory(games, player1, player2))
+	sb.WriteString("\n")
+	sb.WriteString(fmt.Sprintf("🤝 <b>Ничья!</b> (%d - %d)", player1Score, player2Score))
+	if draws > 0 {
+		sb.WriteString("\n")
+		sb.WriteString(fmt.Sprintf("🏳️ <b>Ничьих:</b> %d", draws))
+	}
+
+	return sb.String()
+}
+
+// DiceThrown generates callback alert with the value the player has rolled.
+func DiceThrown(kind dice.Kind, value int) string {
+	return fmt.Sprintf("%s Выпало %d!", kind.Emoji(), value)
+}
@@ -0,0 +1,69 @@
+package msgs
+
+import (
+	"fmt"
+	"microgame-bot/internal/domain/rps"
+	domainUser "microgame-bot/internal/domain/user"
+	"strings"
+)
+
+// seedHashLine publishes the session seed commitment before the random decisions are made.
+func seedHashLine(seedHash string) string {
+	if seedHash == "" {
+		return ""
+	}
+	return fmt.Sprintf("\n\n🎲 <b>Хеш сида:</b> <code>%s</code>", seedHash)
+}
+
+// seedRevealLine reveals the session seed, so anyone can check it against the published hash.
+func seedRevealLine(seed string) string {
+	if seed == "" {
+		return ""
+	}
+	return fmt.Sprintf("\n\n🎲 <b>Сид:</b> <code>%s</code>\n<i>Проверка: SHA256(сид) = хеш сида</i>", seed)
+}
+
+// fairnessProofMaxLen keeps long series within the Telegram message length limit.
+const fairnessProofMaxLen = 2000
+
+// buildRPSFairnessProof lists salts and commitments of every revealed choice.
+// A commitment is SHA256(gameID:choice:salt).
+func buildRPSFairnessProof(games []rps.RPS, player1 domainUser.User, player2 domainUser.User) string {
+	var sb strings.Builder
+
+	sb.WriteString("🔐 <b>Проверка честности</b>\n")
+	sb.WriteString("<i>Коммит = SHA256(id игры:выбор:соль)</i>\n")
+
+	roundNum := 1
+	for _, game := range games {
+		if !game.IsRevealed() {
+			continue
+		}
+		if sb.Len() > fairnessProofMaxLen {
+			sb.WriteString("<i>…остальные раунды не поместились в сообщение</i>\n")
+			break
+		}
+		sb.WriteString(fmt.Sprintf("<b>Раунд %d</b> <code>%s</code>\n", roundNum, game.ID().String()))
+		writeRPSCommitment(&sb, player1, game.Choice1(), game.Commitment1())
+		writeRPSCommitment(&sb, player2, game.Choice2(), game.Commitment2())
+		roundNum++
+	}
+
+	return sb.String()
+}
+
+func writeRPSCommitment(sb *strings.Builder, player domainUser.User, choice rps.Choice, commitment rps.Commitment) {
+	sb.WriteString(fmt.Sprintf(
+		"@%s %s <code>%s</code>\nсоль: <code>%s</code>\nкоммит: <code>%s</code>\n",
+		player.Username(),
+		choice.Icon(),
+		choice.String(),
+		commitment.Salt(),
+		commitment.Hash(),
+	))
+}
+
+// RPSChoiceCommitted is a private receipt for the player with the commitment of their choice.
+func RPSChoiceCommitted(commitment rps.Commitment) string {
+	return "Выбор сделан! Ждём второго игрока...\n🔐 Коммит: " + commitment.Hash()
+}
@@ -0,0 +1,210 @@
+package msgs
+
+import (
+	"fmt"
+	"microgame-bot/internal/domain"
+	"microgame-bot/internal/domain/hangman"
+	domainUser "microgame-bot/internal/domain/user"
+	"strings"
+)
+
+func hangmanHeader(sb *strings.Builder, creator domainUser.Username, language hangman.Language, bet domain.Token) {
+	sb.WriteString(fmt.Sprintf(
+		"@%s запустил игру <b>🔤 %s</b> %s",
+		creator,
+		language.Title(),
+		language.Flag(),
+	))
+	if bet > 0 {
+		sb.WriteString(fmt.Sprintf(" 💰 <i>(ставка: %d токенов)</i>", bet))
+	}
+	sb.WriteString("\n")
+}
+
+// hangmanPattern spaces out the letters of the word, hidden letters stay as underscores.
+func hangmanPattern(pattern []rune) string {
+	letters := make([]string, len(pattern))
+	for i, r := range pattern {
+		letters[i] = string(r)
+	}
+	return "<code>" + strings.Join(letters, " ") + "</code>"
+}
+
+// hangmanAttempts draws the attempts left as hearts.
+func hangmanAttempts(left int) string {
+	return strings.Repeat("❤️", left) + strings.Repeat("🖤", hangman.MaxMisses-left)
+}
+
+func HangmanStart(user domainUser.User, language hangman.Language, bet domain.Token) string {
+	var sb strings.Builder
+	hangmanHeader(&sb, user.Username(), language, bet)
+	sb.WriteString("\n")
+	sb.WriteString("👤 <i>Ожидание игроков...</i>")
+
+	return sb.String()
+}
+
+func HangmanFirstPlayerJoined(
+	creator domainUser.User,
+	player1 domainUser.User,
+	language hangman.Language,
+	bet domain.Token,
+) string {
+	var sb strings.Builder
+	hangmanHeader(&sb, creator.Username(), language, bet)
+	sb.WriteString(fmt.Sprintf("👤 <b>Игрок 1:</b> @%s", player1.Username()))
+	sb.WriteString("\n")
+	sb.WriteString("👤 <b>Игрок 2:</b> <i>Ожидание второго игрока...</i>")
+
+	return sb.String()
+}
+
+// buildHangmanRoundsHistory lists the words of the finished games with the round points.
+func buildHangmanRoundsHistory(games []hangman.Hangman, player1 domainUser.User, player2 domainUser.User) string {
+	var sb strings.Builder
+
+	roundNum := 1
+	for _, game := range games {
+		if !game.IsFinished() {
+			continue
+		}
+		result := "🤝 ничья"
+		switch game.WinnerID() {
+		case player1.ID():
+			result = "🏆 @" + string(player1.Username())
+		case player2.ID():
+			result = "🏆 @" + string(player2.Username())
+		}
+		sb.WriteString(fmt.Sprintf(
+			"<b>Раунд %d:</b> %s (%d - %d) %s\n",
+			roundNum,
+			game.Word(),
+			game.Score1(),
+			game.Score2(),
+			result,
+		))
+		roundNum++
+	}
+
+	return sb.String()
+}
+
+func hangmanTurnMark(game hangman.Hangman, player domainUser.User) string {
+	if game.Turn() == player.ID() && !game.IsFinished() {
+		return "▶️"
+	}
+	return "👤"
+}
+
+// HangmanRound generates message of a series in progress: finished rounds,
+// the word with the guessed letters, attempts left and round points.
+func HangmanRound(
+	games []hangman.Hangman,
+	current hangman.Hangman,
+	player1 domainUser.User,
+	player2 domainUser.User,
+	player1Score int,
+	player2Score int,
+	draws int,
+	bet domain.Token,
+) string {
+	var sb strings.Builder
+	creatorUsername := getCreatorUsername(games[0].CreatorID(), player1, player2)
+	hangmanHeader(&sb, domainUser.Username(creatorUsername), current.Language(), bet)
+	sb.WriteString("\n")
+	if history := buildHangmanRoundsHistory(games, player1, player2); history != "" {
+		sb.WriteString(history)
+		sb.WriteString(fmt.Sprintf("Текущий счёт: %d - %d", player1Score, player2Score))
+		if draws > 0 {
+			sb.WriteString(fmt.Sprintf(" 🏳️ <b>Ничьих:</b> %d", draws))
+		}
+		sb.WriteString("\n\n")
+	}
+
+	sb.WriteString(hangmanPattern(current.Pattern()))
+	sb.WriteString("\n\n")
+	attemptsLeft := current.AttemptsLeft()
+	sb.WriteString(fmt.Sprintf("%s <b>Попыток:</b> %d", hangmanAttempts(attemptsLeft), attemptsLeft))
+	sb.WriteString("\n")
+	if wrong := current.WrongLetters(); len(wrong) > 0 {
+		letters := make([]string, len(wrong))
+		for i, r := range wrong {
+			letters[i] = string(r)
+		}
+		sb.WriteString(fmt.Sprintf("❌ <b>Мимо:</b> %s", strings.Join(letters, ", ")))
+		sb.WriteString("\n")
+	}
+	sb.WriteString("\n")
+	sb.WriteString(fmt.Sprintf(
+		"%s <b>Игрок 1:</b> @%s - %d",
+		hangmanTurnMark(current, player1),
+		player1.Username(),
+		current.Score1(),
+	))
+	sb.WriteString("\n")
+	sb.WriteString(fmt.Sprintf(
+		"%s <b>Игрок 2:</b> @%s - %d",
+		hangmanTurnMark(current, player2),
+		player2.Username(),
+		current.Score2(),
+	))
+
+	return sb.String()
+}
+
+// HangmanSeriesCompleted generates message when series is finished.
+func HangmanSeriesCompleted(
+	games []hangman.Hangman,
+	player1 domainUser.User,
+	player2 domainUser.User,
+	player1Score int,
+	player2Score int,
+	draws int,
+	winner domainUser.User,
+) string {
+	var sb strings.Builder
+	creatorUsername := getCreatorUsername(games[0].CreatorID(), player1, player2)
+	hangmanHeader(&sb, domainUser.Username(creatorUsername), games[0].Language(), 0)
+	sb.WriteString("\n")
+	sb.WriteString(buildHangmanRoundsHistory(games, player1, player2))
+	sb.WriteString("\n")
+	sb.WriteString(fmt.Sprintf("🏆 <b>Победитель:</b> @%s (%d - %d)", winner.Username(), player1Score, player2Score))
+	if draws > 0 {
+		sb.WriteString("\n")
+		sb.WriteString(fmt.Sprintf("🏳️ <b>Ничьих:</b> %d", draws))
+	}
+
+	return sb.String()
+}
+
+// HangmanSeriesDraw generates message when series ends in a draw.
+func HangmanSeriesDraw(
+	games []hangman.Hangman,
+	player1 domainUser.User,
+	player2 domainUser.User,
+	player1Score int,
+	player2Score int,
+	draws int,
+) string {
+	var sb strings.Builder
+	creatorUsername := getCreatorUsername(games[0].CreatorID(), player1, player2)
+	hangmanHeader(&sb, domainUser.Username(creatorUsername), games[0].Language(), 0)
+	sb.WriteString("\n")
+	sb.WriteString(buildHangmanRoundsHistory(games, player1, player2))
+	sb.WriteString("\n")
+	sb.WriteString(fmt.Sprintf("🤝 <b>Ничья!</b> (%d - %d)", player1Score, player2Score))
+	if draws > 0 {
+		sb.WriteString("\n")
+		sb.WriteString(fmt.Sprintf("🏳️ <b>Ничьих:</b> %d", draws))
+	}
+
+	return sb.String()
+}
+
+// HangmanGuessed generates callback alert with the result of the named letter.
+func HangmanGuessed(letter rune, occurrences int) string {
+	if occurrences == 0 {
+		return fmt.Sprintf("❌ Буквы %c нет в слове", letter)
+	}
+	return fmt.Sprintf("✅ Буква %c есть в слове: +%d", letter, occurrences)
+}
@@ -0,0 +1,193 @@
+package msgs
+
+import (
+	"fmt"
+	"microgame-bot/internal/domain/league"
+	domainUser "microgame-bot/internal/domain/user"
+	"strings"
+	"time"
+)
+
+// leagueDeadlineLayout formats league deadlines, they are shown in UTC.
+const leagueDeadlineLayout = "02.01.2006 15:04 UTC"
+
+func leagueHeader(l league.League, organizer domainUser.User) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("📅 @%s ", organizer.Username()))
+	sb.WriteString(fmt.Sprintf("открыл лигу по игре <b>%s</b>", gameTitle(l.GameType())))
+	sb.WriteString("\n")
+	sb.WriteString(fmt.Sprintf("<i>(каждый с каждым, до %d побед в матче", l.GameCount()))
+	//nolint:mnd // Hours in a day.
+	sb.WriteString(fmt.Sprintf(", %d дн. на все матчи)</i>", int(l.Duration().Hours()/24)))
+
+	return sb.String()
+}
+
+func LeagueRegistration(
+	l league.League,
+	organizer domainUser.User,
+	users map[domainUser.ID]domainUser.User,
+) string {
+	name := usernameResolver(users)
+
+	var sb strings.Builder
+	sb.WriteString(leagueHeader(l, organizer))
+	sb.WriteString("\n\n")
+	sb.WriteString(fmt.Sprintf("👥 <b>Участники (%d/%d):</b>", len(l.Players()), league.MaxPlayers))
+	for i, playerID := range l.Players() {
+		sb.WriteString(fmt.Sprintf("\n%d. %s", i+1, name(playerID)))
+	}
+	if len(l.Players()) == 0 {
+		sb.WriteString("\n<i>Пока никого, жми «Участвовать»!</i>")
+	}
+	sb.WriteString("\n\n")
+	sb.WriteString(fmt.Sprintf(
+		"<i>Организатор запускает лигу, когда соберётся от %d до %d игроков.</i>",
+		league.MinPlayers,
+		league.MaxPlayers,
+	))
+
+	return sb.String()
+}
+
+func LeagueTable(
+	l league.League,
+	organizer domainUser.User,
+	users map[domainUser.ID]domainUser.User,
+) string {
+	name := usernameResolver(users)
+
+	var sb strings.Builder
+	sb.WriteString(leagueHeader(l, organizer))
+	sb.WriteString("\n\n")
+	sb.WriteString(leagueStandings(l, name))
+	sb.WriteString("\n\n")
+	sb.WriteString(leagueFixtures(l, name))
+	sb.WriteString("\n\n")
+	sb.WriteString(fmt.Sprintf("⏳ <b>Дедлайн:</b> %s", l.Deadline().UTC().Format(leagueDeadlineLayout)))
+	if len(l.PendingFixtures()) > 0 {
+		sb.WriteString("\n")
+		sb.WriteString("<i>Участники матча начинают его кнопкой ниже прямо в этом чате. ")
+		sb.WriteString("Несыгранные к дедлайну матчи засчитываются поражением обоим.</i>")
+	}
+
+	return sb.String()
+}
+
+func LeagueFinished(
+	l league.League,
+	organizer domainUser.User,
+	users map[domainUser.ID]domainUser.User,
+) string {
+	name := usernameResolver(users)
+
+	var sb strings.Builder
+	sb.WriteString(leagueHeader(l, organizer))
+	sb.WriteString("\n\n")
+	sb.WriteString(leagueStandings(l, name))
+	sb.WriteString("\n\n")
+	sb.WriteString(leagueFixtures(l, name))
+	sb.WriteString("\n\n")
+	if leader := l.Leader(); !leader.IsZero() {
+		sb.WriteString(fmt.Sprintf("🥇 <b>Победитель лиги:</b> %s", name(leader)))
+	} else {
+		sb.WriteString("🏁 <b>Лига завершена без победителя</b>")
+	}
+
+	return sb.String()
+}
+
+func LeagueCancelled(l league.League, organizer domainUser.User) string {
+	var sb strings.Builder
+	sb.WriteString(leagueHeader(l, organizer))
+	sb.WriteString("\n\n")
+	sb.WriteString("❌ <b>Лига отменена</b>")
+
+	return sb.String()
+}
+
+// LeagueFixtureHeader prefixes the game message of a league fixture.
+func LeagueFixtureHeader(number int) string {
+	return fmt.Sprintf("📅 <b>Лига:</b> матч %d\n\n", number+1)
+}
+
+// LeagueReminder lists unplayed fixtures of the player, it is sent to the private chat.
+func LeagueReminder(
+	l league.League,
+	playerID domainUser.ID,
+	users map[domainUser.ID]domainUser.User,
+	now time.Time,
+) string {
+	name := usernameResolver(users)
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("⏰ <b>Лига по игре %s</b>\n\n", gameTitle(l.GameType())))
+	sb.WriteString("Тебе ещё нужно сыграть:")
+	for _, number := range l.UnplayedFixtures(playerID) {
+		f, err := l.Fixture(number)
+		if err != nil {
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("\n%d. против %s", number+1, name(f.Opponent(playerID))))
+		if f.IsStarted() {
+			sb.WriteString(" <i>(идёт)</i>")
+		}
+	}
+	sb.WriteString("\n\n")
+	sb.WriteString(fmt.Sprintf(
+		"⏳ До дедлайна %s (%s). Несыгранные матчи засчитываются поражением.",
+		leagueTimeLeft(l.Deadline().Sub(now)),
+		l.Deadline().UTC().Format(leagueDeadlineLayout),
+	))
+
+	return sb.String()
+}
+
+func leagueStandings(l league.League, name func(domainUser.ID) string) string {
+	var sb strings.Builder
+	sb.WriteString("📊 <b>Таблица:</b>")
+	for i, row := range l.Table() {
+		sb.WriteString(fmt.Sprintf(
+			"\n%d. %s — <b>%d</b> (И %d, В %d, Н %d, П %d)",
+			i+1,
+			name(row.UserID),
+			row.Points,
+			row.Played,
+			row.Won,
+			row.Drawn,
+			row.Lost,
+		))
+	}
+	return sb.String()
+}
+
+func leagueFixtures(l league.League, name func(domainUser.ID) string) string {
+	var sb strings.Builder
+	sb.WriteString("🗓 <b>Матчи:</b>")
+	for i, f := range l.Fixtures() {
+		sb.WriteString(fmt.Sprintf("\n%d. %s — %s ", i+1, name(f.Home), name(f.Away)))
+		switch {
+		case f.Result == league.FixtureResultDraw:
+			sb.WriteString("🤝")
+		case f.Result == league.FixtureResultForfeit && f.Winner.IsZero():
+			sb.WriteString("🏳️")
+		case f.Result == league.FixtureResultForfeit:
+			sb.WriteString(fmt.Sprintf("🏳️ %s", name(f.Winner)))
+		case f.IsPlayed():
+			sb.WriteString(fmt.Sprintf("✅ %s", name(f.Winner)))
+		case f.IsStarted():
+			sb.WriteString("⚔️")
+		default:
+			sb.WriteString("❔")
+		}
+	}
+	return sb.String()
+}
+
+func leagueTimeLeft(d time.Duration) string {
+	//nolint:mnd // Hours in a day.
+	if days := int(d.Hours()) / 24; days > 0 {
+		return fmt.Sprintf("%d дн.", days)
+	}
+	return fmt.Sprintf("%d ч.", max(int(d.Hours()), 1))
+}
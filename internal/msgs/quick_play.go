@@ -0,0 +1,49 @@
+package msgs
+
+import (
+	"fmt"
+	"microgame-bot/internal/domain"
+	"microgame-bot/internal/domain/matchmaking"
+	domainUser "microgame-bot/internal/domain/user"
+	"strings"
+)
+
+func QuickPlayMenu() string {
+	var sb strings.Builder
+	sb.WriteString("⚡ <b>Быстрая игра</b>\n\n")
+	sb.WriteString("Выбери игру и ставку, а соперника мы найдём сами.\n")
+	sb.WriteString("<i>Ставка списывается сразу и возвращается, если соперник не найдётся.</i>")
+	return sb.String()
+}
+
+func QuickPlaySearching(gameType domain.GameType, stake domain.Token) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("🔎 Ищем соперника для игры <b>%s</b>", gameTitle(gameType)))
+	if stake > 0 {
+		sb.WriteString(fmt.Sprintf(" 💰 <i>(ставка: %d токенов)</i>", stake))
+	}
+	sb.WriteString("\n\n")
+	//nolint:mnd // Minutes of the ticket timeout.
+	sb.WriteString(fmt.Sprintf(
+		"<i>Поиск длится до %d мин. Игра придёт отдельным сообщением.</i>",
+		int(matchmaking.TicketTimeout.Minutes()),
+	))
+	return sb.String()
+}
+
+func QuickPlayMatched(opponent domainUser.User) string {
+	return fmt.Sprintf("✅ Соперник найден: @%s\n\n<i>Игра отправлена следующим сообщением.</i>", opponent.Username())
+}
+
+func QuickPlayExpired() string {
+	return "⌛ <b>Соперник не найден</b>\n\nСтавка возвращена. Попробуй ещё раз или выбери другую ставку."
+}
+
+func QuickPlayCancelled() string {
+	return "❌ <b>Поиск отменён</b>\n\nСтавка возвращена. Выбери игру, чтобы начать новый поиск."
+}
+
+// QuickPlayHeader opens the game message sent to both players of a quick play match.
+func QuickPlayHeader() string {
+	return "⚡ <b>Быстрая игра</b>\n\n"
+}
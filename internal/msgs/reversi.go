@@ -0,0 +1,180 @@
+package msgs
+
+import (
+	"fmt"
+	"microgame-bot/internal/domain"
+	"microgame-bot/internal/domain/reversi"
+	domainUser "microgame-bot/internal/domain/user"
+	"strings"
+)
+
+func reversiHeader(sb *strings.Builder, creator domainUser.Username, bet domain.Token) {
+	sb.WriteString(fmt.Sprintf(
+		"@%s запустил игру <b>%s%s Реверси</b>",
+		creator,
+		reversi.DiscBlackIcon,
+		reversi.DiscWhiteIcon,
+	))
+	if bet > 0 {
+		sb.WriteString(fmt.Sprintf(" 💰 <i>(ставка: %d токенов)</i>", bet))
+	}
+	sb.WriteString("\n")
+}
+
+func ReversiStart(user domainUser.User, bet domain.Token) string {
+	var sb strings.Builder
+	reversiHeader(&sb, user.Username(), bet)
+	sb.WriteString("\n")
+	sb.WriteString("👤 <i>Ожидание игроков...</i>")
+
+	return sb.String()
+}
+
+func ReversiFirstPlayerJoined(creator domainUser.User, player1 domainUser.User, bet domain.Token) string {
+	var sb strings.Builder
+	reversiHeader(&sb, creator.Username(), bet)
+	sb.WriteString(fmt.Sprintf("👤 <b>Игрок 1:</b> @%s", player1.Username()))
+	sb.WriteString("\n")
+	sb.WriteString("👤 <b>Игрок 2:</b> <i>Ожидание второго игрока...</i>")
+
+	return sb.String()
+}
+
+// buildReversiRoundsHistory lists the disc counts of the finished games, colors change between rounds.
+func buildReversiRoundsHistory(games []reversi.Reversi, player1 domainUser.User, player2 domainUser.User) string {
+	var sb strings.Builder
+
+	roundNum := 1
+	for _, game := range games {
+		if !game.IsFinished() {
+			continue
+		}
+		result := "🤝 ничья"
+		switch game.WinnerID() {
+		case player1.ID():
+			result = "🏆 @" + string(player1.Username())
+		case player2.ID():
+			result = "🏆 @" + string(player2.Username())
+		}
+		sb.WriteString(fmt.Sprintf(
+			"<b>Раунд %d:</b> %s %d - %d %s %s\n",
+			roundNum,
+			reversi.DiscBlackIcon,
+			game.Board().Count(reversi.DiscBlack),
+			game.Board().Count(reversi.DiscWhite),
+			reversi.DiscWhiteIcon,
+			result,
+		))
+		roundNum++
+	}
+
+	return sb.String()
+}
+
+func reversiPlayerLine(game reversi.Reversi, player domainUser.User) string {
+	mark := "👤"
+	if game.Turn() == player.ID() && !game.IsFinished() {
+		mark = "▶️"
+	}
+	return fmt.Sprintf(
+		"%s %s @%s - %d",
+		mark,
+		game.PlayerDisc(player.ID()).Icon(),
+		player.Username(),
+		game.Score(player.ID()),
+	)
+}
+
+// ReversiRound generates message of a series in progress: finished rounds,
+// the series score and disc counts of the current game.
+func ReversiRound(
+	games []reversi.Reversi,
+	current reversi.Reversi,
+	player1 domainUser.User,
+	player2 domainUser.User,
+	player1Score int,
+	player2Score int,
+	draws int,
+	bet domain.Token,
+) string {
+	var sb strings.Builder
+	creatorUsername := getCreatorUsername(games[0].CreatorID(), player1, player2)
+	reversiHeader(&sb, domainUser.Username(creatorUsername), bet)
+	sb.WriteString("\n")
+	if history := buildReversiRoundsHistory(games, player1, player2); history != "" {
+		sb.WriteString(history)
+		sb.WriteString(fmt.Sprintf("Текущий счёт: %d - %d", player1Score, player2Score))
+		if draws > 0 {
+			sb.WriteString(fmt.Sprintf(" 🏳️ <b>Ничьих:</b> %d", draws))
+		}
+		sb.WriteString("\n\n")
+	}
+
+	sb.WriteString(reversiPlayerLine(current, player1))
+	sb.WriteString("\n")
+	sb.WriteString(reversiPlayerLine(current, player2))
+	if passed := current.LastPass(); !passed.IsZero() {
+		username := player1.Username()
+		if passed == player2.ID() {
+			username = player2.Username()
+		}
+		sb.WriteString("\n\n")
+		sb.WriteString(fmt.Sprintf("⏭ @%s нечем ходить, ход пропущен", username))
+	}
+
+	return sb.String()
+}
+
+// ReversiSeriesCompleted generates message when series is finished.
+func ReversiSeriesCompleted(
+	games []reversi.Reversi,
+	player1 domainUser.User,
+	player2 domainUser.User,
+	player1Score int,
+	player2Score int,
+	draws int,
+	winner domainUser.User,
+) string {
+	var sb strings.Builder
+	creatorUsername := getCreatorUsername(games[0].CreatorID(), player1, player2)
+	reversiHeader(&sb, domainUser.Username(creatorUsername), 0)
+	sb.WriteString("\n")
+	sb.WriteString(buildReversiRoundsHistory(games, player1, player2))
+	sb.WriteString("\n")
+	sb.WriteString(fmt.Sprintf("🏆 <b>Победитель:</b> @%s (%d - %d)", winner.Username(), player1Score, player2Score))
+	if draws > 0 {
+		sb.WriteString("\n")
+		sb.WriteString(fmt.Sprintf("🏳️ <b>Ничьих:</b> %d", draws))
+	}
+
+	return sb.String()
+}
+
+// ReversiSeriesDraw generates message when series ends in a draw.
+func ReversiSeriesDraw(
+	games []reversi.Reversi,
+	player1 domainUser.User,
+	player2 domainUser.User,
+	player1Score int,
+	player2Score int,
+	draws int,
+) string {
+	var sb strings.Builder
+	creatorUsername := getCreatorUsername(games[0].CreatorID(), player1, player2)
+	reversiHeader(&sb, domainUser.Username(creatorUsername), 0)
+	sb.WriteString("\n")
+	sb.WriteString(buildReversiRoundsHistory(games, player1, player2))
+	sb.WriteString("\n")
+	sb.WriteString(fmt.Sprintf("🤝 <b>Ничья!</b> (%d - %d)", player1Score, player2Score))
+	if draws > 0 {
+		sb.WriteString("\n")
+		sb.WriteString(fmt.Sprintf("🏳️ <b>Ничьих:</b> %d", draws))
+	}
+
+	return sb.String()
+}
+
+// ReversiMoved generates callback alert with the placed disc and the number of flipped discs.
+func ReversiMoved(cell reversi.Cell, flipped int) string {
+	return fmt.Sprintf("✅ Ход %s: перевёрнуто %d", cell, flipped)
+}
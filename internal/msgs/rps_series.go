@@ -59,6 +59,8 @@ func RPSSeriesCompleted(
 		sb.WriteString("\n")
 		sb.WriteString(fmt.Sprintf("🏳️ <b>Ничьих:</b> %d", draws))
 	}
+	sb.WriteString("\n\n")
+	sb.WriteString(buildRPSFairnessProof(games, player1, player2))
 
 	return sb.String()
 }
@@ -89,6 +91,8 @@ func RPSSeriesDraw(
 		sb.WriteString("\n")
 		sb.WriteString(fmt.Sprintf("🏳️ <b>Ничьих:</b> %d", draws))
 	}
+	sb.WriteString("\n\n")
+	sb.WriteString(buildRPSFairnessProof(games, player1, player2))
 
 	return sb.String()
 }
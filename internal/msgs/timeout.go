@@ -0,0 +1,71 @@
+package msgs
+
+import (
+	"fmt"
+	domainUser "microgame-bot/internal/domain/user"
+	"strings"
+	"time"
+)
+
+// ClockLabel renders the time left until the deadline, e.g. "⏱ 25с" or "⏱ 4:05".
+func ClockLabel(deadline time.Time) string {
+	left := max(time.Until(deadline).Round(time.Second), 0)
+	if left < time.Minute {
+		return fmt.Sprintf("⏱ %dс", int(left.Seconds()))
+	}
+	//nolint:mnd // Seconds in a minute.
+	return fmt.Sprintf("⏱ %d:%02d", int(left.Minutes()), int(left.Seconds())%60)
+}
+
+// MoveTimeoutLabel describes the time control chosen for the game, e.g. "⏱ 30с на ход".
+func MoveTimeoutLabel(timeout time.Duration) string {
+	if timeout < time.Minute {
+		return fmt.Sprintf("⏱ %dс на ход", int(timeout.Seconds()))
+	}
+	if timeout%time.Minute == 0 {
+		return fmt.Sprintf("⏱ %dм на ход", int(timeout.Minutes()))
+	}
+	//nolint:mnd // Seconds in a minute.
+	return fmt.Sprintf("⏱ %d:%02d на ход", int(timeout.Minutes()), int(timeout.Seconds())%60)
+}
+
+func GameCancelledByTimeout() string {
+	var sb strings.Builder
+	sb.WriteString("⏱ <b>Время ожидания истекло</b>")
+	sb.WriteString("\n\n")
+	sb.WriteString("<i>Игра отменена, ставки возвращены.</i>")
+
+	return sb.String()
+}
+
+func GameForfeitedByTimeout(afkPlayer domainUser.User, winner domainUser.User) string {
+	var sb strings.Builder
+	sb.WriteString("⏱ <b>Время на ход истекло</b>")
+	sb.WriteString("\n\n")
+	sb.WriteString(fmt.Sprintf("😴 @%s не успел сделать ход", afkPlayer.Username()))
+	sb.WriteString("\n")
+	sb.WriteString(fmt.Sprintf("🏆 <b>Победитель:</b> @%s", winner.Username()))
+
+	return sb.String()
+}
+
+// GameForfeitedToHouseByTimeout generates message when the player of a solo game runs out of time.
+func GameForfeitedToHouseByTimeout(afkPlayer domainUser.User) string {
+	var sb strings.Builder
+	sb.WriteString("⏱ <b>Время на ход истекло</b>")
+	sb.WriteString("\n\n")
+	sb.WriteString(fmt.Sprintf("😴 @%s не успел сделать ход", afkPlayer.Username()))
+	sb.WriteString("\n")
+	sb.WriteString("🏦 <b>Ставка уходит казино</b>")
+
+	return sb.String()
+}
+
+func GameAbandonedByTimeout() string {
+	var sb strings.Builder
+	sb.WriteString("⏱ <b>Время на ход истекло</b>")
+	sb.WriteString("\n\n")
+	sb.WriteString("<i>Никто из игроков не сделал ход, игра прервана.</i>")
+
+	return sb.String()
+}
@@ -0,0 +1,140 @@
+package msgs
+
+import (
+	"fmt"
+	"microgame-bot/internal/domain"
+	"microgame-bot/internal/domain/tournament"
+	domainUser "microgame-bot/internal/domain/user"
+	"strings"
+)
+
+func gameTitle(gameType domain.GameType) string {
+	switch gameType {
+	case domain.GameTypeTTT:
+		return "крестики-нолики"
+	case domain.GameTypeRPS:
+		return "камень-ножницы-бумага"
+	default:
+		return gameType.String()
+	}
+}
+
+func tournamentHeader(t tournament.Tournament, organizer domainUser.User) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("🏆 @%s ", organizer.Username()))
+	sb.WriteString(fmt.Sprintf("открыл турнир по игре <b>%s</b>", gameTitle(t.GameType())))
+	sb.WriteString("\n")
+	sb.WriteString(fmt.Sprintf("<i>(%d игроков, до %d побед в матче", t.Size(), t.GameCount()))
+	if t.EntryFee() > 0 {
+		sb.WriteString(fmt.Sprintf(", взнос: %d токенов", t.EntryFee()))
+	}
+	sb.WriteString(")</i>")
+
+	return sb.String()
+}
+
+// usernameResolver returns a bracket name function over the loaded players.
+func usernameResolver(users map[domainUser.ID]domainUser.User) func(domainUser.ID) string {
+	return func(id domainUser.ID) string {
+		u, ok := users[id]
+		if !ok {
+			return "???"
+		}
+		return "@" + string(u.Username())
+	}
+}
+
+func TournamentRegistration(
+	t tournament.Tournament,
+	organizer domainUser.User,
+	users map[domainUser.ID]domainUser.User,
+) string {
+	name := usernameResolver(users)
+
+	var sb strings.Builder
+	sb.WriteString(tournamentHeader(t, organizer))
+	sb.WriteString("\n\n")
+	sb.WriteString(fmt.Sprintf("👥 <b>Участники (%d/%d):</b>", len(t.Players()), t.Size()))
+	for i, playerID := range t.Players() {
+		sb.WriteString(fmt.Sprintf("\n%d. %s", i+1, name(playerID)))
+	}
+	if len(t.Players()) == 0 {
+		sb.WriteString("\n<i>Пока никого, жми «Участвовать»!</i>")
+	}
+	sb.WriteString(seedHashLine(t.SeedHash()))
+
+	return sb.String()
+}
+
+func TournamentBracket(
+	t tournament.Tournament,
+	organizer domainUser.User,
+	users map[domainUser.ID]domainUser.User,
+) string {
+	var sb strings.Builder
+	sb.WriteString(tournamentHeader(t, organizer))
+	sb.WriteString("\n\n")
+	sb.WriteString(t.Bracket(usernameResolver(users)))
+	if len(t.PendingMatches()) > 0 {
+		sb.WriteString("\n\n")
+		sb.WriteString("<i>Участники матча начинают его кнопкой ниже прямо в этом чате.</i>")
+	}
+	sb.WriteString(seedHashLine(t.SeedHash()))
+
+	return sb.String()
+}
+
+func TournamentFinished(
+	t tournament.Tournament,
+	organizer domainUser.User,
+	users map[domainUser.ID]domainUser.User,
+	prizes []tournament.Prize,
+) string {
+	name := usernameResolver(users)
+
+	var sb strings.Builder
+	sb.WriteString(tournamentHeader(t, organizer))
+	sb.WriteString("\n\n")
+	sb.WriteString(t.Bracket(name))
+	sb.WriteString("\n\n")
+	sb.WriteString(fmt.Sprintf("🥇 <b>Чемпион:</b> %s", name(t.Champion())))
+	for _, prize := range prizes {
+		sb.WriteString(fmt.Sprintf("\n%s %s: +%d токенов", placeIcon(prize.Place), name(prize.UserID), prize.Amount))
+	}
+	sb.WriteString(seedRevealLine(t.Seed()))
+
+	return sb.String()
+}
+
+func placeIcon(place int) string {
+	switch place {
+	case tournament.PlaceChampion:
+		return "🥇"
+	case tournament.PlaceFinalist:
+		return "🥈"
+	default:
+		return "🥉"
+	}
+}
+
+func TournamentCancelled(t tournament.Tournament, organizer domainUser.User) string {
+	var sb strings.Builder
+	sb.WriteString(tournamentHeader(t, organizer))
+	sb.WriteString("\n\n")
+	sb.WriteString("❌ <b>Турнир отменён</b>")
+	if t.EntryFee() > 0 {
+		sb.WriteString("\n\n")
+		sb.WriteString("<i>Взносы возвращены.</i>")
+	}
+
+	return sb.String()
+}
+
+// TournamentMatchHeader prefixes the game message of a tournament match.
+func TournamentMatchHeader(t tournament.Tournament, number int) string {
+	m, err := t.Match(number)
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf("🏆 <b>Турнир:</b> %s, матч %d\n\n", t.RoundName(m.Round), number+1)
+}
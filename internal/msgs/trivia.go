@@ -0,0 +1,231 @@
+package msgs
+
+import (
+	"fmt"
+	"html"
+	"microgame-bot/internal/domain"
+	"microgame-bot/internal/domain/trivia"
+	domainUser "microgame-bot/internal/domain/user"
+	"strings"
+)
+
+// triviaHeader writes the creator of the quiz and the topic the questions are drawn from.
+func triviaHeader(sb *strings.Builder, creator domainUser.Username, topic string, bet domain.Token) {
+	sb.WriteString(fmt.Sprintf("@%s запустил <b>🧠 Викторину</b>", creator))
+	if bet > 0 {
+		sb.WriteString(fmt.Sprintf(" 💰 <i>(ставка: %d токенов)</i>", bet))
+	}
+	sb.WriteString("\n")
+	sb.WriteString(fmt.Sprintf("<i>%s</i>", topic))
+	sb.WriteString("\n")
+}
+
+func TriviaStart(user domainUser.User, topic string, bet domain.Token) string {
+	var sb strings.Builder
+	triviaHeader(&sb, user.Username(), topic, bet)
+	sb.WriteString("\n")
+	sb.WriteString("👤 <i>Ожидание игроков...</i>")
+
+	return sb.String()
+}
+
+func TriviaFirstPlayerJoined(
+	creator domainUser.User,
+	player1 domainUser.User,
+	topic string,
+	bet domain.Token,
+) string {
+	var sb strings.Builder
+	triviaHeader(&sb, creator.Username(), topic, bet)
+	sb.WriteString("\n")
+	sb.WriteString(fmt.Sprintf("👤 <b>Игрок 1:</b> @%s", player1.Username()))
+	sb.WriteString("\n")
+	sb.WriteString("👤 <b>Игрок 2:</b> <i>Ожидание второго игрока...</i>")
+
+	return sb.String()
+}
+
+// buildTriviaRoundsHistory lists the points of the finished games with the round results.
+func buildTriviaRoundsHistory(games []trivia.Trivia, player1 domainUser.User, player2 domainUser.User) string {
+	var sb strings.Builder
+
+	roundNum := 1
+	for _, game := range games {
+		if !game.IsFinished() {
+			continue
+		}
+		result := "🤝 ничья"
+		switch game.WinnerID() {
+		case player1.ID():
+			result = "🏆 @" + string(player1.Username())
+		case player2.ID():
+			result = "🏆 @" + string(player2.Username())
+		}
+		sb.WriteString(fmt.Sprintf(
+			"<b>Раунд %d:</b> (%d - %d) %s\n",
+			roundNum,
+			game.ScoreOf(player1.ID()),
+			game.ScoreOf(player2.ID()),
+			result,
+		))
+		roundNum++
+	}
+
+	return sb.String()
+}
+
+// triviaLastQuestion tells who has taken the question closed last, or the right answer if nobody has.
+func triviaLastQuestion(game trivia.Trivia, player1 domainUser.User, player2 domainUser.User) string {
+	last := game.Current() - 1
+	questions := game.Questions()
+	if last < 0 || last >= len(questions) {
+		return ""
+	}
+	rightOption := html.EscapeString(questions[last].RightOption())
+
+	for _, answer := range game.AnswersTo(last) {
+		if !answer.Correct {
+			continue
+		}
+		username := player1.Username()
+		if answer.PlayerID == player2.ID() {
+			username = player2.Username()
+		}
+		return fmt.Sprintf("✅ @%s ответил верно: <b>%s</b>", username, rightOption)
+	}
+	return fmt.Sprintf("⌛ Никто не ответил верно, правильный ответ: <b>%s</b>", rightOption)
+}
+
+// triviaAnswerMark shows whether the player has already missed the open question.
+func triviaAnswerMark(game trivia.Trivia, player domainUser.User) string {
+	if answer, ok := game.AnswerOf(player.ID(), game.Current()); ok && !answer.Correct {
+		return "❌"
+	}
+	return "👤"
+}
+
+// TriviaRound generates message of a series in progress: finished rounds,
+// the result of the previous question, the open question and round points.
+func TriviaRound(
+	games []trivia.Trivia,
+	current trivia.Trivia,
+	player1 domainUser.User,
+	player2 domainUser.User,
+	player1Score int,
+	player2Score int,
+	draws int,
+	bet domain.Token,
+	topic string,
+) string {
+	var sb strings.Builder
+	creatorUsername := getCreatorUsername(games[0].CreatorID(), player1, player2)
+	triviaHeader(&sb, domainUser.Username(creatorUsername), topic, bet)
+	sb.WriteString("\n")
+	if history := buildTriviaRoundsHistory(games, player1, player2); history != "" {
+		sb.WriteString(history)
+		sb.WriteString(fmt.Sprintf("Текущий счёт: %d - %d", player1Score, player2Score))
+		if draws > 0 {
+			sb.WriteString(fmt.Sprintf(" 🏳️ <b>Ничьих:</b> %d", draws))
+		}
+		sb.WriteString("\n\n")
+	}
+
+	if last := triviaLastQuestion(current, player1, player2); last != "" {
+		sb.WriteString(last)
+		sb.WriteString("\n\n")
+	}
+
+	if question, ok := current.Question(); ok {
+		sb.WriteString(fmt.Sprintf(
+			"❓ <b>Вопрос %d/%d</b> %s\n%s",
+			current.Current()+1,
+			len(current.Questions()),
+			question.Difficulty.Title(),
+			html.EscapeString(question.Text),
+		))
+		sb.WriteString("\n\n")
+	}
+
+	sb.WriteString(fmt.Sprintf(
+		"%s <b>Игрок 1:</b> @%s - %d",
+		triviaAnswerMark(current, player1),
+		player1.Username(),
+		current.ScoreOf(player1.ID()),
+	))
+	sb.WriteString("\n")
+	sb.WriteString(fmt.Sprintf(
+		"%s <b>Игрок 2:</b> @%s - %d",
+		triviaAnswerMark(current, player2),
+		player2.Username(),
+		current.ScoreOf(player2.ID()),
+	))
+
+	return sb.String()
+}
+
+// TriviaSeriesCompleted generates message when series is finished.
+func TriviaSeriesCompleted(
+	games []trivia.Trivia,
+	player1 domainUser.User,
+	player2 domainUser.User,
+	player1Score int,
+	player2Score int,
+	draws int,
+	winner domainUser.User,
+	topic string,
+) string {
+	var sb strings.Builder
+	creatorUsername := getCreatorUsername(games[0].CreatorID(), player1, player2)
+	triviaHeader(&sb, domainUser.Username(creatorUsername), topic, 0)
+	sb.WriteString("\n")
+	if last := triviaLastQuestion(games[len(games)-1], player1, player2); last != "" {
+		sb.WriteString(last)
+		sb.WriteString("\n\n")
+	}
+	sb.WriteString(buildTriviaRoundsHistory(games, player1, player2))
+	sb.WriteString("\n")
+	sb.WriteString(fmt.Sprintf("🏆 <b>Победитель:</b> @%s (%d - %d)", winner.Username(), player1Score, player2Score))
+	if draws > 0 {
+		sb.WriteString("\n")
+		sb.WriteString(fmt.Sprintf("🏳️ <b>Ничьих:</b> %d", draws))
+	}
+
+	return sb.String()
+}
+
+// TriviaSeriesDraw generates message when series ends in a draw.
+func TriviaSeriesDraw(
+	games []trivia.Trivia,
+	player1 domainUser.User,
+	player2 domainUser.User,
+	player1Score int,
+	player2Score int,
+	draws int,
+	topic string,
+) string {
+	var sb strings.Builder
+	creatorUsername := getCreatorUsername(games[0].CreatorID(), player1, player2)
+	triviaHeader(&sb, domainUser.Username(creatorUsername), topic, 0)
+	sb.WriteString("\n")
+	if last := triviaLastQuestion(games[len(games)-1], player1, player2); last != "" {
+		sb.WriteString(last)
+		sb.WriteString("\n\n")
+	}
+	sb.WriteString(buildTriviaRoundsHistory(games, player1, player2))
+	sb.WriteString("\n")
+	sb.WriteString(fmt.Sprintf("🤝 <b>Ничья!</b> (%d - %d)", player1Score, player2Score))
+	if draws > 0 {
+		sb.WriteString("\n")
+		sb.WriteString(fmt.Sprintf("🏳️ <b>Ничьих:</b> %d", draws))
+	}
+
+	return sb.String()
+}
+
+// TriviaAnswered generates callback alert with the result of the chosen option.
+func TriviaAnswered(correct bool) string {
+	if correct {
+		return "✅ Верно! +1"
+	}
+	return "❌ Неверно"
+}
@@ -0,0 +1,21 @@
+package msgs
+
+import (
+	"fmt"
+	"microgame-bot/internal/domain/ttt"
+	domainUser "microgame-bot/internal/domain/user"
+	"strings"
+)
+
+// TTTNotation exports the game move list, short enough for a callback query alert.
+func TTTNotation(game ttt.TTT, playerX domainUser.User, playerO domainUser.User) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%s @%s vs %s @%s\n", ttt.CellXIcon, playerX.Username(), ttt.CellOIcon, playerO.Username()))
+	notation := game.Notation()
+	if notation == "" {
+		notation = "Ходов нет"
+	}
+	sb.WriteString(notation)
+
+	return sb.String()
+}
@@ -82,6 +82,7 @@ func TTTSeriesCompleted(
 		sb.WriteString("\n")
 		sb.WriteString(fmt.Sprintf("🏳️ <b>Ничьих:</b> %d", result.Draws))
 	}
+	sb.WriteString(seedRevealLine(result.Session.Seed()))
 
 	return sb.String(), nil
 }
@@ -8,7 +8,7 @@ import (
 	"strings"
 )
 
-func TTTStart(creator domainUser.User, bet domain.Token) (string, error) {
+func TTTStart(creator domainUser.User, bet domain.Token, seedHash string) (string, error) {
 	var sb strings.Builder
 	sb.WriteString(fmt.Sprintf("@%s ", creator.Username()))
 	sb.WriteString("запустил игру <b>крестики-нолики</b>")
@@ -17,11 +17,17 @@ func TTTStart(creator domainUser.User, bet domain.Token) (string, error) {
 	}
 	sb.WriteString("\n\n")
 	sb.WriteString("👤 <i>Ожидание игроков...</i>")
+	sb.WriteString(seedHashLine(seedHash))
 
 	return sb.String(), nil
 }
 
-func TTTFirstPlayerJoined(creator domainUser.User, firstPlayer domainUser.User, bet domain.Token) (string, error) {
+func TTTFirstPlayerJoined(
+	creator domainUser.User,
+	firstPlayer domainUser.User,
+	bet domain.Token,
+	seedHash string,
+) (string, error) {
 	var sb strings.Builder
 	sb.WriteString(fmt.Sprintf("@%s ", creator.Username()))
 	sb.WriteString("запустил игру <b>крестики-нолики</b>")
@@ -32,6 +38,7 @@ func TTTFirstPlayerJoined(creator domainUser.User, firstPlayer domainUser.User,
 	sb.WriteString(fmt.Sprintf("👤 @%s %s", firstPlayer.Username(), ttt.CellEmptyIcon))
 	sb.WriteString("\n")
 	sb.WriteString("👤 <i>Ожидание второго игрока...</i>")
+	sb.WriteString(seedHashLine(seedHash))
 
 	return sb.String(), nil
 }
@@ -4,13 +4,22 @@ import (
 	"context"
 	"log/slog"
 	"microgame-bot/internal/core/logger"
-	"microgame-bot/internal/utils"
+
+	"gorm.io/gorm"
 )
 
-func (q *Queue) ack(ctx context.Context, taskID utils.UniqueID) {
-	err := q.db.WithContext(ctx).Model(&Task{}).
-		Where("id = ?", taskID).
-		Update("status", TaskStatusCompleted).Error
+// ack completes the task and publishes its follow-ups and continuations in the same transaction.
+func (q *Queue) ack(ctx context.Context, task *Task, followUps []Task) {
+	err := q.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&Task{}).
+			Where("id = ?", task.ID).
+			Update("status", TaskStatusCompleted).Error
+		if err != nil {
+			return err
+		}
+		task.Status = TaskStatusCompleted
+		return q.finish(ctx, tx, *task, followUps)
+	})
 	if err != nil {
 		slog.ErrorContext(ctx, "Failed to ack task", logger.ErrorField, err.Error())
 	}
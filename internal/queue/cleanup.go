@@ -2,6 +2,7 @@ package queue
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 	"time"
 
@@ -9,6 +10,19 @@ import (
 	"gorm.io/gorm/clause"
 )
 
+// ErrStuckTasksUntracked is returned by StuckTasks of the backends that don't track the running tasks.
+var ErrStuckTasksUntracked = errors.New("stuck tasks aren't tracked by the queue backend")
+
+// StuckTasks counts the running tasks whose attempt started more than olderThan ago.
+func (q *Queue) StuckTasks(ctx context.Context, olderThan time.Duration) (int64, error) {
+	var count int64
+	err := q.db.WithContext(ctx).
+		Model(&Task{}).
+		Where("status = ? AND last_attempt < ?", TaskStatusRunning, time.Now().Add(-olderThan)).
+		Count(&count).Error
+	return count, err
+}
+
 func (q *Queue) CleanupStuckTasks(ctx context.Context) error {
 	now := time.Now()
 
@@ -32,6 +46,7 @@ func (q *Queue) CleanupStuckTasks(ctx context.Context) error {
 			threshold := tasks[i].LastAttempt.Add(timeout)
 
 			if now.After(threshold) {
+				tasks[i].withFailure("execution timed out", now)
 				if tasks[i].Attempts >= tasks[i].MaxAttempts {
 					tasks[i].Status = TaskStatusFailed
 				} else {
@@ -46,7 +61,16 @@ func (q *Queue) CleanupStuckTasks(ctx context.Context) error {
 			return nil
 		}
 
-		return tx.Save(&toUpdate).Error
+		if err := tx.Save(&toUpdate).Error; err != nil {
+			return err
+		}
+		for i := range toUpdate {
+			taskCtx, _ := withRunningTask(ctx, &toUpdate[i])
+			if err := q.finish(taskCtx, tx, toUpdate[i], nil); err != nil {
+				return err
+			}
+		}
+		return nil
 	})
 
 	if err != nil {
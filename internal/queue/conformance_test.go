@@ -0,0 +1,135 @@
+package queue_test
+
+import (
+	"fmt"
+	"microgame-bot/internal/queue"
+	"microgame-bot/internal/queue/queuetest"
+	"microgame-bot/internal/utils"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// postgresEnv points the Postgres backend to an empty database, the suite is skipped without it.
+const postgresEnv = "QUEUE_TEST_POSTGRES_URL"
+
+func TestConformance_Postgres(t *testing.T) {
+	db := openPostgres(t)
+
+	queuetest.Run(t, func(t *testing.T) queue.IQueue {
+		require.NoError(t, db.Exec("TRUNCATE tasks, task_groups").Error)
+		return queue.New(db, 4, queue.WithRetryBackoff(queuetest.RetryBackoff))
+	}, func(t *testing.T, _ queue.IQueue) {
+		require.NoError(t, db.Exec("UPDATE tasks SET status = ? WHERE status = ?",
+			queue.TaskStatusCompleted, queue.TaskStatusPending).Error)
+	})
+}
+
+func TestConformance_JetStream(t *testing.T) {
+	conn := runNATS(t)
+
+	js, err := jetstream.New(conn)
+	require.NoError(t, err)
+
+	streams := 0
+	queuetest.Run(t, func(t *testing.T) queue.IQueue {
+		streams++
+		q, err := queue.NewJetStream(t.Context(), conn, 4,
+			queue.WithStream(fmt.Sprintf("tasks%d", streams)),
+			queue.WithRetryBackoff(queuetest.RetryBackoff),
+		)
+		require.NoError(t, err)
+		return q
+	}, func(t *testing.T, _ queue.IQueue) {
+		// The messages leave the work-queue stream as on ack, the dedup keys stay
+		stream, err := js.Stream(t.Context(), fmt.Sprintf("tasks%d", streams))
+		require.NoError(t, err)
+		require.NoError(t, stream.Purge(t.Context()))
+	})
+}
+
+func TestRetention_Postgres(t *testing.T) {
+	db := openPostgres(t)
+	require.NoError(t, queue.MigrateArchive(db))
+	require.NoError(t, db.Exec("TRUNCATE tasks, task_archives").Error)
+
+	// More than one batch of the retention
+	const expired = 1500
+	finishedAt := time.Now().Add(-2 * time.Hour)
+	tasks := make([]queue.Task, 0, expired+1)
+	for i := range expired {
+		task := queue.NewTask("bets.payout", []byte(`{}`), time.Time{}, 0, time.Minute).
+			WithDedupKey(fmt.Sprintf("payout-%d", i)).
+			Then(queue.NewTask("bets.notify", []byte(`{}`), time.Time{}, 0, 0))
+		task.GroupID = utils.NewUniqueID()
+		task.Status = queue.TaskStatusCompleted
+		task.UpdatedAt = finishedAt
+		tasks = append(tasks, task)
+	}
+	fresh := queue.NewTask("bets.payout", []byte(`{}`), time.Time{}, 0, 0)
+	fresh.Status = queue.TaskStatusCompleted
+	tasks = append(tasks, fresh)
+	require.NoError(t, db.CreateInBatches(tasks, 500).Error)
+
+	q := queue.New(db, 4, queue.WithDefaultRetention(queue.RetentionPolicy{Completed: time.Hour, Archive: true}))
+	run, err := q.ApplyRetention(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, int64(expired), run.Archived)
+
+	var left int64
+	require.NoError(t, db.Model(&queue.Task{}).Count(&left).Error)
+	assert.Equal(t, int64(1), left)
+
+	var archived queue.Task
+	require.NoError(t, db.Table("task_archives").Where("id = ?", tasks[0].ID).Take(&archived).Error)
+	assert.Equal(t, tasks[0].DedupKey, archived.DedupKey)
+	assert.Equal(t, tasks[0].GroupID, archived.GroupID)
+	assert.Equal(t, tasks[0].Timeout, archived.Timeout)
+	require.Len(t, archived.OnSuccess, 1)
+	assert.Equal(t, "bets.notify", archived.OnSuccess[0].Subject)
+}
+
+// openPostgres connects to the database of postgresEnv and migrates the tasks, the test is skipped without it.
+func openPostgres(t *testing.T) *gorm.DB {
+	t.Helper()
+	dsn := os.Getenv(postgresEnv)
+	if dsn == "" {
+		t.Skip(postgresEnv + " is not set, see the task queue section of README.md")
+	}
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Discard})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&queue.Task{}, &queue.TaskGroup{}))
+	require.NoError(t, queue.DropStaleIndexes(db))
+	return db
+}
+
+// runNATS starts an embedded nats-server with JetStream for the test.
+func runNATS(t *testing.T) *nats.Conn {
+	t.Helper()
+	srv, err := server.NewServer(&server.Options{
+		Host:      "127.0.0.1",
+		Port:      server.RANDOM_PORT,
+		JetStream: true,
+		StoreDir:  t.TempDir(),
+		NoLog:     true,
+		NoSigs:    true,
+	})
+	require.NoError(t, err)
+	go srv.Start()
+	t.Cleanup(srv.Shutdown)
+	require.True(t, srv.ReadyForConnections(5*time.Second))
+
+	conn, err := nats.Connect(srv.ClientURL())
+	require.NoError(t, err)
+	t.Cleanup(conn.Close)
+	return conn
+}
@@ -0,0 +1,142 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"microgame-bot/internal/utils"
+	"slices"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const defaultDeadLetterLimit = 50
+
+var (
+	ErrTaskNotFound = errors.New("task not found")
+	// ErrEmptyFilter guards the bulk operations from touching every failed task by mistake.
+	ErrEmptyFilter = errors.New("dead letter filter is empty")
+)
+
+// TaskAttempt is a failed attempt of the task kept in its error history.
+type TaskAttempt struct {
+	At      time.Time `json:"at"`
+	Error   string    `json:"error"`
+	Attempt int       `json:"attempt"`
+}
+
+// DeadLetterFilter selects the failed tasks. IDs take precedence over the rest of the filter,
+// zero fields don't filter.
+type DeadLetterFilter struct {
+	Since   time.Time
+	Until   time.Time
+	Subject string
+	IDs     []utils.UniqueID
+	Limit   int
+}
+
+func (f DeadLetterFilter) isEmpty() bool {
+	return len(f.IDs) == 0 && f.Subject == "" && f.Since.IsZero() && f.Until.IsZero()
+}
+
+// matches applies the filter to a task, failedQuery applies it in SQL.
+func (f DeadLetterFilter) matches(task Task) bool {
+	if len(f.IDs) > 0 {
+		return slices.Contains(f.IDs, task.ID)
+	}
+	if f.Subject != "" && task.Subject != f.Subject {
+		return false
+	}
+	if !f.Since.IsZero() && task.UpdatedAt.Before(f.Since) {
+		return false
+	}
+	return f.Until.IsZero() || task.UpdatedAt.Before(f.Until)
+}
+
+// withFailure records the failed attempt in the error history of the task.
+func (t *Task) withFailure(err string, at time.Time) {
+	t.LastError = err
+	t.Errors = append(t.Errors, TaskAttempt{
+		Attempt: t.Attempts,
+		Error:   err,
+		At:      at,
+	})
+}
+
+// FailedTasks lists the failed tasks matching the filter, the latest failures first.
+func (q *Queue) FailedTasks(ctx context.Context, filter DeadLetterFilter) ([]Task, error) {
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultDeadLetterLimit
+	}
+	var tasks []Task
+	err := q.failedQuery(q.db.WithContext(ctx), filter).
+		Order("updated_at DESC").
+		Limit(limit).
+		Find(&tasks).Error
+	return tasks, err
+}
+
+// FailedTask returns the failed task with its payload and error history.
+func (q *Queue) FailedTask(ctx context.Context, id utils.UniqueID) (Task, error) {
+	var task Task
+	err := q.db.WithContext(ctx).
+		Where("id = ? AND status = ?", id, TaskStatusFailed).
+		First(&task).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return Task{}, ErrTaskNotFound
+	}
+	return task, err
+}
+
+// RequeueFailed puts the failed tasks matching the filter back to the queue with reset attempts.
+// The error history is kept. Requeued tasks lose the dedup key, a replay can't be skipped.
+func (q *Queue) RequeueFailed(ctx context.Context, filter DeadLetterFilter) (int64, error) {
+	if filter.isEmpty() {
+		return 0, ErrEmptyFilter
+	}
+	var requeued int64
+	err := q.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := q.failedQuery(tx.Model(&Task{}), filter).Updates(map[string]any{
+			"status":    TaskStatusPending,
+			"attempts":  0,
+			"run_after": time.Now(),
+			"dedup_key": "",
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+		requeued = result.RowsAffected
+		if requeued == 0 {
+			return nil
+		}
+		return tx.Exec("SELECT pg_notify(?, '')", notifyChannel).Error
+	})
+	return requeued, err
+}
+
+// DiscardFailed deletes the failed tasks matching the filter.
+func (q *Queue) DiscardFailed(ctx context.Context, filter DeadLetterFilter) (int64, error) {
+	if filter.isEmpty() {
+		return 0, ErrEmptyFilter
+	}
+	result := q.failedQuery(q.db.WithContext(ctx), filter).Delete(&Task{})
+	return result.RowsAffected, result.Error
+}
+
+func (q *Queue) failedQuery(db *gorm.DB, filter DeadLetterFilter) *gorm.DB {
+	db = db.Where("status = ?", TaskStatusFailed)
+	if len(filter.IDs) > 0 {
+		return db.Where("id IN ?", filter.IDs)
+	}
+	if filter.Subject != "" {
+		db = db.Where("subject = ?", filter.Subject)
+	}
+	if !filter.Since.IsZero() {
+		db = db.Where("updated_at >= ?", filter.Since)
+	}
+	if !filter.Until.IsZero() {
+		db = db.Where("updated_at < ?", filter.Until)
+	}
+	return db
+}
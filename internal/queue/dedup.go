@@ -0,0 +1,153 @@
+package queue
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"microgame-bot/internal/utils"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// dedupPredicate matches the rows of the partial unique index over the dedup keys, only tasks waiting
+// for their first attempt take part in it. A running task may have read its input already, so a duplicate
+// published meanwhile runs after it, and a retried task doesn't keep the key either.
+// It must match the where of the index on Task.DedupKey, the index tag can't hold commas.
+const dedupPredicate = "dedup_key <> '' AND status = 'pending' AND attempts = 0"
+
+// WithDedupKey returns the task with the dedup key set. Publishing a task is a no-op
+// while a task with the same key waits for its first attempt.
+func (t Task) WithDedupKey(key string) Task {
+	t.DedupKey = key
+	return t
+}
+
+// WithDebounce makes Publish move the pending task with the same dedup key to the RunAfter
+// of the published one instead of skipping it, so the work runs once after the last publish.
+func (t Task) WithDebounce() Task {
+	t.debounce = true
+	return t
+}
+
+// UniqueKey is the dedup key of the tasks with the same subject and payload.
+func UniqueKey(subject string, payload []byte) string {
+	sum := sha256.Sum256(payload)
+	return subject + ":" + hex.EncodeToString(sum[:])
+}
+
+// PublishUnique publishes the task unless a task with the same subject and payload waits for its first attempt.
+func PublishUnique(ctx context.Context, publisher IQueuePublisher, task Task) error {
+	return publisher.Publish(ctx, []Task{task.WithDedupKey(UniqueKey(task.Subject, task.Payload))})
+}
+
+// createTasks inserts the tasks, the ones with the dedup key of an existing task are skipped or debounced.
+// It returns the IfSkipped tasks of the skipped ones.
+func createTasks(tx *gorm.DB, tasks []Task) ([]Task, error) {
+	unique, duplicates := uniqueTasks(tasks)
+	var skip, debounce []Task
+	for _, task := range unique {
+		if task.debounce && task.DedupKey != "" {
+			debounce = append(debounce, task)
+		} else {
+			skip = append(skip, task)
+		}
+	}
+
+	target := clause.OnConflict{
+		Columns:     []clause.Column{{Name: "dedup_key"}},
+		TargetWhere: clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: dedupPredicate}}},
+	}
+	skipped := skippedBy(duplicates)
+	if len(skip) > 0 {
+		onConflict := target
+		onConflict.DoNothing = true
+		result := tx.Clauses(onConflict).Create(&skip)
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		if int(result.RowsAffected) < len(skip) {
+			conflicts, err := conflictedTasks(tx, skip)
+			if err != nil {
+				return nil, err
+			}
+			skipped = append(skipped, conflicts...)
+		}
+	}
+	if len(debounce) > 0 {
+		// Only a task waiting for its first attempt is moved, see dedupPredicate
+		onConflict := target
+		onConflict.DoUpdates = clause.AssignmentColumns([]string{"run_after", "updated_at"})
+		onConflict.Where = clause.Where{Exprs: []clause.Expression{
+			clause.Expr{SQL: "tasks.status = ?", Vars: []any{TaskStatusPending}},
+		}}
+		if err := tx.Clauses(onConflict).Create(&debounce).Error; err != nil {
+			return nil, err
+		}
+	}
+	return skipped, nil
+}
+
+// conflictedTasks returns the IfSkipped tasks of the tasks that weren't inserted for their dedup key.
+// Only the tasks with IfSkipped tasks are looked up.
+func conflictedTasks(tx *gorm.DB, tasks []Task) ([]Task, error) {
+	var ids []utils.UniqueID
+	for _, task := range tasks {
+		if task.DedupKey != "" && len(task.OnSkip) > 0 {
+			ids = append(ids, task.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	var inserted []utils.UniqueID
+	if err := tx.Model(&Task{}).Where("id IN ?", ids).Pluck("id", &inserted).Error; err != nil {
+		return nil, err
+	}
+	found := make(map[utils.UniqueID]bool, len(inserted))
+	for _, id := range inserted {
+		found[id] = true
+	}
+	var conflicts []Task
+	for _, task := range tasks {
+		if task.DedupKey != "" && len(task.OnSkip) > 0 && !found[task.ID] {
+			conflicts = append(conflicts, task)
+		}
+	}
+	return skippedBy(conflicts), nil
+}
+
+// skippedBy returns the IfSkipped tasks of the skipped tasks with new IDs.
+// A debounced duplicate is superseded by the last task of its key, it isn't skipped.
+func skippedBy(tasks []Task) []Task {
+	var next []Task
+	for _, task := range tasks {
+		if task.debounce {
+			continue
+		}
+		next = append(next, instances(task.OnSkip)...)
+	}
+	return next
+}
+
+// uniqueTasks keeps the last task of every dedup key, a statement can't conflict with itself.
+// The earlier tasks with the key are returned as the duplicates, they are skipped.
+func uniqueTasks(tasks []Task) ([]Task, []Task) {
+	last := make(map[string]int, len(tasks))
+	for i, task := range tasks {
+		if task.DedupKey != "" {
+			last[task.DedupKey] = i
+		}
+	}
+
+	unique := make([]Task, 0, len(tasks))
+	var duplicates []Task
+	for i, task := range tasks {
+		if task.DedupKey != "" && last[task.DedupKey] != i {
+			duplicates = append(duplicates, task)
+			continue
+		}
+		unique = append(unique, task)
+	}
+	return unique, duplicates
+}
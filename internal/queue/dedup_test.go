@@ -0,0 +1,52 @@
+package queue
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type publisherStub struct {
+	tasks []Task
+}
+
+func (p *publisherStub) Publish(_ context.Context, tasks []Task) error {
+	p.tasks = append(p.tasks, tasks...)
+	return nil
+}
+
+func TestUniqueKey(t *testing.T) {
+	assert.Equal(t, UniqueKey("bets.payout", EmptyPayload), UniqueKey("bets.payout", EmptyPayload))
+	assert.NotEqual(t, UniqueKey("bets.payout", EmptyPayload), UniqueKey("games.timeout", EmptyPayload))
+	assert.NotEqual(t, UniqueKey("games.afk", []byte(`{"id":1}`)), UniqueKey("games.afk", []byte(`{"id":2}`)))
+	assert.LessOrEqual(t, len(UniqueKey("tournaments.advance", EmptyPayload)), 255, "fits the column")
+}
+
+func TestPublishUnique(t *testing.T) {
+	publisher := &publisherStub{}
+	require.NoError(t, PublishPayoutTask(context.Background(), publisher))
+	require.NoError(t, PublishPayoutTask(context.Background(), publisher))
+
+	require.Len(t, publisher.tasks, 2)
+	assert.NotEmpty(t, publisher.tasks[0].DedupKey)
+	assert.Equal(t, publisher.tasks[0].DedupKey, publisher.tasks[1].DedupKey, "payouts share the key")
+}
+
+func TestUniqueTasks(t *testing.T) {
+	now := time.Now()
+	first := NewTask(GameAFKSubject, EmptyPayload, now, 1, DefaultTimeout).WithDedupKey("afk")
+	plain := NewTask("profile.load", EmptyPayload, now, 1, DefaultTimeout)
+	last := NewTask(GameAFKSubject, EmptyPayload, now.Add(time.Minute), 1, DefaultTimeout).
+		WithDedupKey("afk").
+		WithDebounce()
+
+	unique, duplicates := uniqueTasks([]Task{first, plain, plain, last})
+	require.Len(t, unique, 3, "tasks without a key are never deduplicated")
+	assert.Equal(t, last.ID, unique[2].ID, "the last task of the key wins")
+	assert.True(t, unique[2].debounce)
+	require.Len(t, duplicates, 1)
+	assert.Equal(t, first.ID, duplicates[0].ID)
+}
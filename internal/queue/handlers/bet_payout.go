@@ -109,6 +109,103 @@ func processSessionPayout(ctx context.Context, unit uow.IUnitOfWork, sessionID d
 			games = append(games, g)
 		}
 
+	case domain.GameTypeDice:
+		diceRepo, err := unit.DiceRepo()
+		if err != nil {
+			return fmt.Errorf("failed to get dice repository in %s: %w", operationName, err)
+		}
+		diceGames, err := diceRepo.GamesBySessionID(ctx, sessionID)
+		if err != nil {
+			return fmt.Errorf("failed to get dice games in %s: %w", operationName, err)
+		}
+		for _, g := range diceGames {
+			games = append(games, g)
+		}
+
+	case domain.GameTypeCheckers:
+		ckRepo, err := unit.CheckersRepo()
+		if err != nil {
+			return fmt.Errorf("failed to get checkers repository in %s: %w", operationName, err)
+		}
+		ckGames, err := ckRepo.GamesBySessionID(ctx, sessionID)
+		if err != nil {
+			return fmt.Errorf("failed to get checkers games in %s: %w", operationName, err)
+		}
+		for _, g := range ckGames {
+			games = append(games, g)
+		}
+
+	case domain.GameTypeReversi:
+		rvRepo, err := unit.ReversiRepo()
+		if err != nil {
+			return fmt.Errorf("failed to get reversi repository in %s: %w", operationName, err)
+		}
+		rvGames, err := rvRepo.GamesBySessionID(ctx, sessionID)
+		if err != nil {
+			return fmt.Errorf("failed to get reversi games in %s: %w", operationName, err)
+		}
+		for _, g := range rvGames {
+			games = append(games, g)
+		}
+
+	case domain.GameTypeHangman:
+		hmRepo, err := unit.HangmanRepo()
+		if err != nil {
+			return fmt.Errorf("failed to get hangman repository in %s: %w", operationName, err)
+		}
+		hmGames, err := hmRepo.GamesBySessionID(ctx, sessionID)
+		if err != nil {
+			return fmt.Errorf("failed to get hangman games in %s: %w", operationName, err)
+		}
+		for _, g := range hmGames {
+			games = append(games, g)
+		}
+
+	case domain.GameTypeTrivia:
+		tvRepo, err := unit.TriviaRepo()
+		if err != nil {
+			return fmt.Errorf("failed to get trivia repository in %s: %w", operationName, err)
+		}
+		tvGames, err := tvRepo.GamesBySessionID(ctx, sessionID)
+		if err != nil {
+			return fmt.Errorf("failed to get trivia games in %s: %w", operationName, err)
+		}
+		for _, g := range tvGames {
+			games = append(games, g)
+		}
+
+	case domain.GameTypeBattleship:
+		bsRepo, err := unit.BattleshipRepo()
+		if err != nil {
+			return fmt.Errorf("failed to get battleship repository in %s: %w", operationName, err)
+		}
+		bsGames, err := bsRepo.GamesBySessionID(ctx, sessionID)
+		if err != nil {
+			return fmt.Errorf("failed to get battleship games in %s: %w", operationName, err)
+		}
+		for _, g := range bsGames {
+			games = append(games, g)
+		}
+
+	case domain.GameTypeBullsCows:
+		bcRepo, err := unit.BullsCowsRepo()
+		if err != nil {
+			return fmt.Errorf("failed to get bulls and cows repository in %s: %w", operationName, err)
+		}
+		bcGames, err := bcRepo.GamesBySessionID(ctx, sessionID)
+		if err != nil {
+			return fmt.Errorf("failed to get bulls and cows games in %s: %w", operationName, err)
+		}
+		for _, g := range bcGames {
+			games = append(games, g)
+		}
+
+	case domain.GameTypeTournament:
+		return processTournamentPayout(ctx, unit, session, bets)
+
+	case domain.GameTypeBlackjack:
+		return processHousePayout(ctx, unit, session, bets)
+
 	default:
 		l.WarnContext(ctx, "Unknown game type", "game_type", session.GameType())
 		return nil
@@ -0,0 +1,289 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"microgame-bot/internal/core/logger"
+	"microgame-bot/internal/domain"
+	"microgame-bot/internal/domain/battleship"
+	domainBet "microgame-bot/internal/domain/bet"
+	"microgame-bot/internal/domain/blackjack"
+	"microgame-bot/internal/domain/bullscows"
+	"microgame-bot/internal/domain/checkers"
+	"microgame-bot/internal/domain/dice"
+	"microgame-bot/internal/domain/hangman"
+	"microgame-bot/internal/domain/reversi"
+	"microgame-bot/internal/domain/rps"
+	domainSession "microgame-bot/internal/domain/session"
+	"microgame-bot/internal/domain/trivia"
+	"microgame-bot/internal/domain/ttt"
+	domainUser "microgame-bot/internal/domain/user"
+	"microgame-bot/internal/msgs"
+	"microgame-bot/internal/queue"
+	"microgame-bot/internal/uow"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mymmrac/telego"
+)
+
+type timedGame interface {
+	domainSession.IGame
+	Status() domain.GameStatus
+	UpdatedAt() time.Time
+}
+
+// GameAFKHandler returns a handler function for processing per-game timeout tasks.
+// Join timeout cancels the game if it is still waiting for players.
+// Move timeout forfeits the game of the AFK player, unless somebody has moved since the task was scheduled.
+func GameAFKHandler(
+	u uow.IUnitOfWork,
+	sender iMessageSender,
+) func(ctx context.Context, data []byte) error {
+	const operationName = "queue::handler::game_afk"
+	return func(ctx context.Context, data []byte) error {
+		l := slog.With(slog.String(logger.OperationField, operationName))
+
+		var task domainSession.TimeoutTask
+		if err := json.Unmarshal(data, &task); err != nil {
+			return fmt.Errorf("failed to unmarshal payload in %s: %w", operationName, err)
+		}
+
+		ctx = logger.WithLogValue(ctx, logger.SessionIDField, task.SessionID.String())
+		l.DebugContext(ctx, "Processing game AFK task", "kind", task.Kind)
+
+		var msg string
+		err := u.Do(ctx, func(unit uow.IUnitOfWork) error {
+			sessionRepo, err := unit.SessionRepo()
+			if err != nil {
+				return fmt.Errorf("failed to get session repository in %s: %w", operationName, err)
+			}
+
+			session, err := sessionRepo.SessionByIDLocked(ctx, task.SessionID)
+			if err != nil {
+				if errors.Is(err, domain.ErrSessionNotFound) {
+					return nil
+				}
+				return fmt.Errorf("failed to get session in %s: %w", operationName, err)
+			}
+			if !isSessionActive(session.Status()) {
+				l.DebugContext(ctx, "Session is already over, skipping")
+				return nil
+			}
+
+			game, err := timedGameByID(ctx, unit, task.GameType, task.GameID)
+			if err != nil {
+				if errors.Is(err, domain.ErrGameNotFound) {
+					return nil
+				}
+				return fmt.Errorf("failed to get game in %s: %w", operationName, err)
+			}
+			if !isTimeoutDue(task, game) {
+				l.DebugContext(ctx, "Game has changed since the task was scheduled, skipping")
+				return nil
+			}
+
+			afkPlayerID, afkErr := game.AFKPlayerID()
+
+			if err := processTimedOutSession(ctx, unit, session); err != nil {
+				return fmt.Errorf("failed to process timed out session in %s: %w", operationName, err)
+			}
+			if err := releaseSessionBets(ctx, unit, session); err != nil {
+				return fmt.Errorf("failed to release session bets in %s: %w", operationName, err)
+			}
+
+			session, err = sessionRepo.SessionByID(ctx, session.ID())
+			if err != nil {
+				return fmt.Errorf("failed to get session in %s: %w", operationName, err)
+			}
+
+			msg, err = timeoutMessage(ctx, unit, session, game, afkPlayerID, afkErr)
+			if err != nil {
+				return fmt.Errorf("failed to build timeout message in %s: %w", operationName, err)
+			}
+
+			return nil
+		})
+		if err != nil {
+			return uow.ErrFailedToDoTransaction(operationName, err)
+		}
+
+		if msg == "" || task.InlineMessageID.IsZero() {
+			return nil
+		}
+
+		_, err = sender.EditMessageText(ctx, &telego.EditMessageTextParams{
+			InlineMessageID: task.InlineMessageID.String(),
+			Text:            msg,
+			ParseMode:       "HTML",
+		})
+		if err != nil {
+			// The game is already expired, retrying the task would only try to edit the message again.
+			l.WarnContext(ctx, "Failed to edit timed out game message", logger.ErrorField, err.Error())
+		}
+
+		return nil
+	}
+}
+
+func isSessionActive(status domain.GameStatus) bool {
+	switch status {
+	case domain.GameStatusCreated, domain.GameStatusWaitingForPlayers, domain.GameStatusInProgress:
+		return true
+	default:
+		return false
+	}
+}
+
+func isTimeoutDue(task domainSession.TimeoutTask, game timedGame) bool {
+	if game.IsFinished() {
+		return false
+	}
+	switch task.Kind {
+	case domainSession.TimeoutKindJoin:
+		return game.Status() == domain.GameStatusWaitingForPlayers
+	case domainSession.TimeoutKindMove:
+		return game.Status() == domain.GameStatusInProgress && !task.IsStale(game.UpdatedAt())
+	default:
+		return false
+	}
+}
+
+func timedGameByID(ctx context.Context, unit uow.IUnitOfWork, gameType domain.GameType, id uuid.UUID) (timedGame, error) {
+	switch gameType {
+	case domain.GameTypeTTT:
+		tttRepo, err := unit.TTTRepo()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get TTT repository: %w", err)
+		}
+		return tttRepo.GameByIDLocked(ctx, ttt.ID(id))
+	case domain.GameTypeRPS:
+		rpsRepo, err := unit.RPSRepo()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get RPS repository: %w", err)
+		}
+		return rpsRepo.GameByIDLocked(ctx, rps.ID(id))
+	case domain.GameTypeDice:
+		diceRepo, err := unit.DiceRepo()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get dice repository: %w", err)
+		}
+		return diceRepo.GameByIDLocked(ctx, dice.ID(id))
+	case domain.GameTypeBlackjack:
+		bjRepo, err := unit.BlackjackRepo()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get blackjack repository: %w", err)
+		}
+		return bjRepo.GameByIDLocked(ctx, blackjack.ID(id))
+	case domain.GameTypeCheckers:
+		ckRepo, err := unit.CheckersRepo()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get checkers repository: %w", err)
+		}
+		return ckRepo.GameByIDLocked(ctx, checkers.ID(id))
+	case domain.GameTypeReversi:
+		rvRepo, err := unit.ReversiRepo()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get reversi repository: %w", err)
+		}
+		return rvRepo.GameByIDLocked(ctx, reversi.ID(id))
+	case domain.GameTypeHangman:
+		hmRepo, err := unit.HangmanRepo()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get hangman repository: %w", err)
+		}
+		return hmRepo.GameByIDLocked(ctx, hangman.ID(id))
+	case domain.GameTypeTrivia:
+		tvRepo, err := unit.TriviaRepo()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get trivia repository: %w", err)
+		}
+		return tvRepo.GameByIDLocked(ctx, trivia.ID(id))
+	case domain.GameTypeBattleship:
+		bsRepo, err := unit.BattleshipRepo()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get battleship repository: %w", err)
+		}
+		return bsRepo.GameByIDLocked(ctx, battleship.ID(id))
+	case domain.GameTypeBullsCows:
+		bcRepo, err := unit.BullsCowsRepo()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get bulls and cows repository: %w", err)
+		}
+		return bcRepo.GameByIDLocked(ctx, bullscows.ID(id))
+	default:
+		return nil, domain.ErrGameNotFound
+	}
+}
+
+// releaseSessionBets moves bets of the expired session to waiting and publishes the payout through the outbox
+// of the unit, so the payout is queued together with the finished session. Must be called within transaction.
+func releaseSessionBets(
+	ctx context.Context,
+	unit uow.IUnitOfWork,
+	session domainSession.Session,
+) error {
+	if session.Bet() <= 0 {
+		return nil
+	}
+
+	betRepo, err := unit.BetRepo()
+	if err != nil {
+		return fmt.Errorf("failed to get bet repository: %w", err)
+	}
+	err = betRepo.UpdateBetsStatusBatch(ctx, session.ID(), domainBet.StatusWaiting)
+	if err != nil {
+		return fmt.Errorf("failed to update bets status: %w", err)
+	}
+	outbox, err := unit.Outbox()
+	if err != nil {
+		return fmt.Errorf("failed to get task outbox: %w", err)
+	}
+	if err := queue.PublishPayoutTask(ctx, outbox); err != nil {
+		return fmt.Errorf("failed to publish payout task: %w", err)
+	}
+
+	return nil
+}
+
+func timeoutMessage(
+	ctx context.Context,
+	unit uow.IUnitOfWork,
+	session domainSession.Session,
+	game timedGame,
+	afkPlayerID domainUser.ID,
+	afkErr error,
+) (string, error) {
+	if session.Status() == domain.GameStatusCancelled {
+		return msgs.GameCancelledByTimeout(), nil
+	}
+	if afkErr != nil {
+		return msgs.GameAbandonedByTimeout(), nil
+	}
+
+	userRepo, err := unit.UserRepo()
+	if err != nil {
+		return "", fmt.Errorf("failed to get user repository: %w", err)
+	}
+
+	var afkPlayer, winner domainUser.User
+	for _, id := range game.Participants() {
+		player, err := userRepo.UserByID(ctx, id)
+		if err != nil {
+			return "", fmt.Errorf("failed to get player by ID: %w", err)
+		}
+		if id == afkPlayerID {
+			afkPlayer = player
+		} else {
+			winner = player
+		}
+	}
+
+	if session.GameType().IsSolo() {
+		return msgs.GameForfeitedToHouseByTimeout(afkPlayer), nil
+	}
+	return msgs.GameForfeitedByTimeout(afkPlayer, winner), nil
+}
@@ -0,0 +1,259 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"microgame-bot/internal/core/logger"
+	"microgame-bot/internal/domain"
+	"microgame-bot/internal/domain/battleship"
+	"microgame-bot/internal/domain/blackjack"
+	"microgame-bot/internal/domain/bullscows"
+	"microgame-bot/internal/domain/checkers"
+	"microgame-bot/internal/domain/dice"
+	"microgame-bot/internal/domain/hangman"
+	"microgame-bot/internal/domain/reversi"
+	"microgame-bot/internal/domain/rps"
+	domainSession "microgame-bot/internal/domain/session"
+	"microgame-bot/internal/domain/trivia"
+	"microgame-bot/internal/domain/ttt"
+	tgHandlers "microgame-bot/internal/handlers"
+	"microgame-bot/internal/queue"
+	"microgame-bot/internal/uow"
+	"time"
+
+	"github.com/mymmrac/telego"
+)
+
+var errClockStopped = errors.New("game clock stopped")
+
+type iMarkupEditor interface {
+	EditMessageReplyMarkup(ctx context.Context, params *telego.EditMessageReplyMarkupParams) (*telego.Message, error)
+}
+
+// GameClockHandler returns a handler function that redraws the move countdown on the game board.
+// Each tick schedules the next one until the deadline passes or somebody moves.
+func GameClockHandler(
+	u uow.IUnitOfWork,
+	publisher queue.IQueuePublisher,
+	editor iMarkupEditor,
+) func(ctx context.Context, data []byte) error {
+	const operationName = "queue::handler::game_clock"
+	return func(ctx context.Context, data []byte) error {
+		l := slog.With(slog.String(logger.OperationField, operationName))
+
+		var task domainSession.TimeoutTask
+		if err := json.Unmarshal(data, &task); err != nil {
+			return fmt.Errorf("failed to unmarshal payload in %s: %w", operationName, err)
+		}
+
+		if !time.Now().Before(task.Deadline) || task.InlineMessageID.IsZero() {
+			return nil
+		}
+
+		ctx = logger.WithLogValue(ctx, logger.SessionIDField, task.SessionID.String())
+
+		markup, err := clockBoardKeyboard(ctx, u, task)
+		if err != nil {
+			if errors.Is(err, errClockStopped) {
+				l.DebugContext(ctx, "Game has changed since the clock was started, stopping")
+				return nil
+			}
+			return fmt.Errorf("failed to build game board in %s: %w", operationName, err)
+		}
+
+		_, err = editor.EditMessageReplyMarkup(ctx, &telego.EditMessageReplyMarkupParams{
+			InlineMessageID: task.InlineMessageID.String(),
+			ReplyMarkup:     markup,
+		})
+		if err != nil {
+			// The countdown is cosmetic, the AFK task expires the game regardless.
+			l.WarnContext(ctx, "Failed to redraw game clock", logger.ErrorField, err.Error())
+		}
+
+		nextTick := time.Now().Add(domainSession.ClockTickInterval)
+		if !nextTick.Before(task.Deadline) {
+			return nil
+		}
+
+		return queue.PublishGameClockTask(ctx, publisher, data, nextTick)
+	}
+}
+
+// clockBoardKeyboard returns the board of the game the task is bound to,
+// or errClockStopped if the game has finished or changed since the task was scheduled.
+func clockBoardKeyboard(
+	ctx context.Context,
+	u uow.IUnitOfWork,
+	task domainSession.TimeoutTask,
+) (*telego.InlineKeyboardMarkup, error) {
+	switch task.GameType {
+	case domain.GameTypeTTT:
+		tttRepo, err := u.TTTRepo()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get TTT repository: %w", err)
+		}
+		game, err := tttRepo.GameByID(ctx, ttt.ID(task.GameID))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get TTT game: %w", err)
+		}
+		if game.IsFinished() || task.IsStale(game.UpdatedAt()) {
+			return nil, errClockStopped
+		}
+
+		userRepo, err := u.UserRepo()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user repository: %w", err)
+		}
+		playerX, err := userRepo.UserByID(ctx, game.PlayerXID())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get playerX by ID: %w", err)
+		}
+		playerO, err := userRepo.UserByID(ctx, game.PlayerOID())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get playerO by ID: %w", err)
+		}
+
+		return tgHandlers.BuildTTTGameBoardKeyboard(&game, playerX, playerO, task.Deadline), nil
+
+	case domain.GameTypeRPS:
+		rpsRepo, err := u.RPSRepo()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get RPS repository: %w", err)
+		}
+		game, err := rpsRepo.GameByID(ctx, rps.ID(task.GameID))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get RPS game: %w", err)
+		}
+		if game.IsFinished() || task.IsStale(game.UpdatedAt()) {
+			return nil, errClockStopped
+		}
+
+		return tgHandlers.BuildRPSGameBoardKeyboard(&game, task.Deadline), nil
+
+	case domain.GameTypeDice:
+		diceRepo, err := u.DiceRepo()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get dice repository: %w", err)
+		}
+		game, err := diceRepo.GameByID(ctx, dice.ID(task.GameID))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get dice game: %w", err)
+		}
+		if game.IsFinished() || task.IsStale(game.UpdatedAt()) {
+			return nil, errClockStopped
+		}
+
+		return tgHandlers.BuildDiceGameBoardKeyboard(&game, task.Deadline), nil
+
+	case domain.GameTypeBlackjack:
+		bjRepo, err := u.BlackjackRepo()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get blackjack repository: %w", err)
+		}
+		game, err := bjRepo.GameByID(ctx, blackjack.ID(task.GameID))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get blackjack game: %w", err)
+		}
+		if game.IsFinished() || task.IsStale(game.UpdatedAt()) {
+			return nil, errClockStopped
+		}
+
+		return tgHandlers.BuildBlackjackGameBoardKeyboard(&game, task.Deadline), nil
+
+	case domain.GameTypeBattleship:
+		bsRepo, err := u.BattleshipRepo()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get battleship repository: %w", err)
+		}
+		game, err := bsRepo.GameByID(ctx, battleship.ID(task.GameID))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get battleship game: %w", err)
+		}
+		if game.IsFinished() || task.IsStale(game.UpdatedAt()) {
+			return nil, errClockStopped
+		}
+
+		return tgHandlers.BuildBattleshipGameBoardKeyboard(&game, task.Deadline), nil
+
+	case domain.GameTypeBullsCows:
+		bcRepo, err := u.BullsCowsRepo()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get bulls and cows repository: %w", err)
+		}
+		game, err := bcRepo.GameByID(ctx, bullscows.ID(task.GameID))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get bulls and cows game: %w", err)
+		}
+		if game.IsFinished() || task.IsStale(game.UpdatedAt()) {
+			return nil, errClockStopped
+		}
+
+		return tgHandlers.BuildBullsCowsGameBoardKeyboard(&game, task.Deadline), nil
+
+	case domain.GameTypeCheckers:
+		ckRepo, err := u.CheckersRepo()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get checkers repository: %w", err)
+		}
+		game, err := ckRepo.GameByID(ctx, checkers.ID(task.GameID))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get checkers game: %w", err)
+		}
+		if game.IsFinished() || task.IsStale(game.UpdatedAt()) {
+			return nil, errClockStopped
+		}
+
+		return tgHandlers.BuildCheckersGameBoardKeyboard(&game, task.Deadline), nil
+
+	case domain.GameTypeReversi:
+		rvRepo, err := u.ReversiRepo()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get reversi repository: %w", err)
+		}
+		game, err := rvRepo.GameByID(ctx, reversi.ID(task.GameID))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get reversi game: %w", err)
+		}
+		if game.IsFinished() || task.IsStale(game.UpdatedAt()) {
+			return nil, errClockStopped
+		}
+
+		return tgHandlers.BuildReversiGameBoardKeyboard(&game, task.Deadline), nil
+
+	case domain.GameTypeHangman:
+		hmRepo, err := u.HangmanRepo()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get hangman repository: %w", err)
+		}
+		game, err := hmRepo.GameByID(ctx, hangman.ID(task.GameID))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get hangman game: %w", err)
+		}
+		if game.IsFinished() || task.IsStale(game.UpdatedAt()) {
+			return nil, errClockStopped
+		}
+
+		return tgHandlers.BuildHangmanGameBoardKeyboard(&game, task.Deadline), nil
+
+	case domain.GameTypeTrivia:
+		tvRepo, err := u.TriviaRepo()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get trivia repository: %w", err)
+		}
+		game, err := tvRepo.GameByID(ctx, trivia.ID(task.GameID))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get trivia game: %w", err)
+		}
+		if game.IsFinished() || task.IsStale(game.UpdatedAt()) {
+			return nil, errClockStopped
+		}
+
+		return tgHandlers.BuildTriviaGameBoardKeyboard(&game, task.Deadline), nil
+
+	default:
+		return nil, errClockStopped
+	}
+}
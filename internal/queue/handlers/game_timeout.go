@@ -7,12 +7,18 @@ import (
 	"log/slog"
 	"microgame-bot/internal/core/logger"
 	"microgame-bot/internal/domain"
-	domainBet "microgame-bot/internal/domain/bet"
+	"microgame-bot/internal/domain/battleship"
+	"microgame-bot/internal/domain/blackjack"
+	"microgame-bot/internal/domain/bullscows"
+	"microgame-bot/internal/domain/checkers"
+	"microgame-bot/internal/domain/dice"
+	"microgame-bot/internal/domain/hangman"
+	"microgame-bot/internal/domain/reversi"
 	"microgame-bot/internal/domain/rps"
 	domainSession "microgame-bot/internal/domain/session"
+	"microgame-bot/internal/domain/trivia"
 	"microgame-bot/internal/domain/ttt"
 	"microgame-bot/internal/domain/user"
-	"microgame-bot/internal/queue"
 	"microgame-bot/internal/uow"
 	"time"
 )
@@ -23,10 +29,8 @@ const (
 
 // GameTimeoutHandler returns a handler function for processing game timeouts.
 // It finds old games and either cancels them (if not started) or marks as abandoned (if started).
-func GameTimeoutHandler(
-	u uow.IUnitOfWork,
-	publisher queue.IQueuePublisher,
-) func(ctx context.Context, data []byte) error {
+// Sessions with a move clock are normally expired earlier by GameAFKHandler, this is a fallback sweep.
+func GameTimeoutHandler(u uow.IUnitOfWork) func(ctx context.Context, data []byte) error {
 	const operationName = "queue::handler::game_timeout"
 	return func(ctx context.Context, _ []byte) error {
 		err := u.Do(ctx, func(unit uow.IUnitOfWork) error {
@@ -50,16 +54,8 @@ func GameTimeoutHandler(
 				return fmt.Errorf("failed to process timed out session: %w", err)
 			}
 
-			if session.Bet() > 0 {
-				betRepo, err := unit.BetRepo()
-				if err != nil {
-					return fmt.Errorf("failed to get bet repository in %s: %w", operationName, err)
-				}
-				err = betRepo.UpdateBetsStatusBatch(ctx, session.ID(), domainBet.StatusWaiting)
-				if err != nil {
-					return fmt.Errorf("failed to update bets status in %s: %w", operationName, err)
-				}
-				_ = queue.PublishPayoutTask(ctx, publisher)
+			if err := releaseSessionBets(ctx, unit, session); err != nil {
+				return fmt.Errorf("failed to release session bets in %s: %w", operationName, err)
 			}
 
 			return nil
@@ -106,6 +102,110 @@ func processTimedOutSession(ctx context.Context, unit uow.IUnitOfWork, session d
 			games = append(games, g)
 		}
 
+	case domain.GameTypeDice:
+		diceRepo, err := unit.DiceRepo()
+		if err != nil {
+			return fmt.Errorf("failed to get dice repository: %w", err)
+		}
+		diceGames, err := diceRepo.GamesBySessionIDLocked(ctx, session.ID())
+		if err != nil {
+			return fmt.Errorf("failed to get dice games: %w", err)
+		}
+		for _, g := range diceGames {
+			games = append(games, g)
+		}
+
+	case domain.GameTypeBlackjack:
+		bjRepo, err := unit.BlackjackRepo()
+		if err != nil {
+			return fmt.Errorf("failed to get blackjack repository: %w", err)
+		}
+		bjGames, err := bjRepo.GamesBySessionIDLocked(ctx, session.ID())
+		if err != nil {
+			return fmt.Errorf("failed to get blackjack games: %w", err)
+		}
+		for _, g := range bjGames {
+			games = append(games, g)
+		}
+
+	case domain.GameTypeCheckers:
+		ckRepo, err := unit.CheckersRepo()
+		if err != nil {
+			return fmt.Errorf("failed to get checkers repository: %w", err)
+		}
+		ckGames, err := ckRepo.GamesBySessionIDLocked(ctx, session.ID())
+		if err != nil {
+			return fmt.Errorf("failed to get checkers games: %w", err)
+		}
+		for _, g := range ckGames {
+			games = append(games, g)
+		}
+
+	case domain.GameTypeReversi:
+		rvRepo, err := unit.ReversiRepo()
+		if err != nil {
+			return fmt.Errorf("failed to get reversi repository: %w", err)
+		}
+		rvGames, err := rvRepo.GamesBySessionIDLocked(ctx, session.ID())
+		if err != nil {
+			return fmt.Errorf("failed to get reversi games: %w", err)
+		}
+		for _, g := range rvGames {
+			games = append(games, g)
+		}
+
+	case domain.GameTypeHangman:
+		hmRepo, err := unit.HangmanRepo()
+		if err != nil {
+			return fmt.Errorf("failed to get hangman repository: %w", err)
+		}
+		hmGames, err := hmRepo.GamesBySessionIDLocked(ctx, session.ID())
+		if err != nil {
+			return fmt.Errorf("failed to get hangman games: %w", err)
+		}
+		for _, g := range hmGames {
+			games = append(games, g)
+		}
+
+	case domain.GameTypeTrivia:
+		tvRepo, err := unit.TriviaRepo()
+		if err != nil {
+			return fmt.Errorf("failed to get trivia repository: %w", err)
+		}
+		tvGames, err := tvRepo.GamesBySessionIDLocked(ctx, session.ID())
+		if err != nil {
+			return fmt.Errorf("failed to get trivia games: %w", err)
+		}
+		for _, g := range tvGames {
+			games = append(games, g)
+		}
+
+	case domain.GameTypeBattleship:
+		bsRepo, err := unit.BattleshipRepo()
+		if err != nil {
+			return fmt.Errorf("failed to get battleship repository: %w", err)
+		}
+		bsGames, err := bsRepo.GamesBySessionIDLocked(ctx, session.ID())
+		if err != nil {
+			return fmt.Errorf("failed to get battleship games: %w", err)
+		}
+		for _, g := range bsGames {
+			games = append(games, g)
+		}
+
+	case domain.GameTypeBullsCows:
+		bcRepo, err := unit.BullsCowsRepo()
+		if err != nil {
+			return fmt.Errorf("failed to get bulls and cows repository: %w", err)
+		}
+		bcGames, err := bcRepo.GamesBySessionIDLocked(ctx, session.ID())
+		if err != nil {
+			return fmt.Errorf("failed to get bulls and cows games: %w", err)
+		}
+		for _, g := range bcGames {
+			games = append(games, g)
+		}
+
 	default:
 		l.WarnContext(ctx, "Unknown game type", "game_type", session.GameType())
 		return nil
@@ -210,6 +310,174 @@ func cancelSession(
 		if err != nil {
 			return fmt.Errorf("failed to update RPS game in %s: %w", operationName, err)
 		}
+
+	case domain.GameTypeDice:
+		diceGame, ok := activeGame.(dice.Dice)
+		if !ok {
+			return fmt.Errorf("failed to cast game to dice in %s", operationName)
+		}
+
+		diceRepo, err := unit.DiceRepo()
+		if err != nil {
+			return fmt.Errorf("failed to get dice repository in %s: %w", operationName, err)
+		}
+
+		diceGame, err = diceGame.SetStatus(domain.GameStatusCancelled)
+		if err != nil {
+			return fmt.Errorf("failed to set status to cancelled in dice in %s: %w", operationName, err)
+		}
+
+		_, err = diceRepo.UpdateGame(ctx, diceGame)
+		if err != nil {
+			return fmt.Errorf("failed to update dice game in %s: %w", operationName, err)
+		}
+
+	case domain.GameTypeBlackjack:
+		bjGame, ok := activeGame.(blackjack.Blackjack)
+		if !ok {
+			return fmt.Errorf("failed to cast game to blackjack in %s", operationName)
+		}
+
+		bjRepo, err := unit.BlackjackRepo()
+		if err != nil {
+			return fmt.Errorf("failed to get blackjack repository in %s: %w", operationName, err)
+		}
+
+		bjGame, err = bjGame.SetStatus(domain.GameStatusCancelled)
+		if err != nil {
+			return fmt.Errorf("failed to set status to cancelled in blackjack in %s: %w", operationName, err)
+		}
+
+		_, err = bjRepo.UpdateGame(ctx, bjGame)
+		if err != nil {
+			return fmt.Errorf("failed to update blackjack game in %s: %w", operationName, err)
+		}
+
+	case domain.GameTypeBattleship:
+		bsGame, ok := activeGame.(battleship.Battleship)
+		if !ok {
+			return fmt.Errorf("failed to cast game to battleship in %s", operationName)
+		}
+
+		bsRepo, err := unit.BattleshipRepo()
+		if err != nil {
+			return fmt.Errorf("failed to get battleship repository in %s: %w", operationName, err)
+		}
+
+		bsGame, err = bsGame.SetStatus(domain.GameStatusCancelled)
+		if err != nil {
+			return fmt.Errorf("failed to set status to cancelled in battleship in %s: %w", operationName, err)
+		}
+
+		_, err = bsRepo.UpdateGame(ctx, bsGame)
+		if err != nil {
+			return fmt.Errorf("failed to update battleship game in %s: %w", operationName, err)
+		}
+
+	case domain.GameTypeBullsCows:
+		bcGame, ok := activeGame.(bullscows.BullsCows)
+		if !ok {
+			return fmt.Errorf("failed to cast game to bulls and cows in %s", operationName)
+		}
+
+		bcRepo, err := unit.BullsCowsRepo()
+		if err != nil {
+			return fmt.Errorf("failed to get bulls and cows repository in %s: %w", operationName, err)
+		}
+
+		bcGame, err = bcGame.SetStatus(domain.GameStatusCancelled)
+		if err != nil {
+			return fmt.Errorf("failed to set status to cancelled in bulls and cows in %s: %w", operationName, err)
+		}
+
+		_, err = bcRepo.UpdateGame(ctx, bcGame)
+		if err != nil {
+			return fmt.Errorf("failed to update bulls and cows game in %s: %w", operationName, err)
+		}
+
+	case domain.GameTypeCheckers:
+		ckGame, ok := activeGame.(checkers.Checkers)
+		if !ok {
+			return fmt.Errorf("failed to cast game to checkers in %s", operationName)
+		}
+
+		ckRepo, err := unit.CheckersRepo()
+		if err != nil {
+			return fmt.Errorf("failed to get checkers repository in %s: %w", operationName, err)
+		}
+
+		ckGame, err = ckGame.SetStatus(domain.GameStatusCancelled)
+		if err != nil {
+			return fmt.Errorf("failed to set status to cancelled in checkers in %s: %w", operationName, err)
+		}
+
+		_, err = ckRepo.UpdateGame(ctx, ckGame)
+		if err != nil {
+			return fmt.Errorf("failed to update checkers game in %s: %w", operationName, err)
+		}
+
+	case domain.GameTypeReversi:
+		rvGame, ok := activeGame.(reversi.Reversi)
+		if !ok {
+			return fmt.Errorf("failed to cast game to reversi in %s", operationName)
+		}
+
+		rvRepo, err := unit.ReversiRepo()
+		if err != nil {
+			return fmt.Errorf("failed to get reversi repository in %s: %w", operationName, err)
+		}
+
+		rvGame, err = rvGame.SetStatus(domain.GameStatusCancelled)
+		if err != nil {
+			return fmt.Errorf("failed to set status to cancelled in reversi in %s: %w", operationName, err)
+		}
+
+		_, err = rvRepo.UpdateGame(ctx, rvGame)
+		if err != nil {
+			return fmt.Errorf("failed to update reversi game in %s: %w", operationName, err)
+		}
+
+	case domain.GameTypeHangman:
+		hmGame, ok := activeGame.(hangman.Hangman)
+		if !ok {
+			return fmt.Errorf("failed to cast game to hangman in %s", operationName)
+		}
+
+		hmRepo, err := unit.HangmanRepo()
+		if err != nil {
+			return fmt.Errorf("failed to get hangman repository in %s: %w", operationName, err)
+		}
+
+		hmGame, err = hmGame.SetStatus(domain.GameStatusCancelled)
+		if err != nil {
+			return fmt.Errorf("failed to set status to cancelled in hangman in %s: %w", operationName, err)
+		}
+
+		_, err = hmRepo.UpdateGame(ctx, hmGame)
+		if err != nil {
+			return fmt.Errorf("failed to update hangman game in %s: %w", operationName, err)
+		}
+
+	case domain.GameTypeTrivia:
+		tvGame, ok := activeGame.(trivia.Trivia)
+		if !ok {
+			return fmt.Errorf("failed to cast game to trivia in %s", operationName)
+		}
+
+		tvRepo, err := unit.TriviaRepo()
+		if err != nil {
+			return fmt.Errorf("failed to get trivia repository in %s: %w", operationName, err)
+		}
+
+		tvGame, err = tvGame.SetStatus(domain.GameStatusCancelled)
+		if err != nil {
+			return fmt.Errorf("failed to set status to cancelled in trivia in %s: %w", operationName, err)
+		}
+
+		_, err = tvRepo.UpdateGame(ctx, tvGame)
+		if err != nil {
+			return fmt.Errorf("failed to update trivia game in %s: %w", operationName, err)
+		}
 	}
 
 	l.DebugContext(ctx, "Session cancelled successfully")
@@ -320,6 +588,135 @@ func abandonSession(
 		if err != nil {
 			return err
 		}
+
+	case domain.GameTypeDice:
+		diceGame, ok := activeGame.(dice.Dice)
+		if !ok {
+			return fmt.Errorf("failed to cast game to dice in %s", operationName)
+		}
+
+		diceRepo, err := unit.DiceRepo()
+		if err != nil {
+			return fmt.Errorf("failed to get dice repository in %s: %w", operationName, err)
+		}
+
+		_, err = handleAbandonedGame(ctx, diceGame, diceRepo.UpdateGame, operationName, "dice")
+		if err != nil {
+			return err
+		}
+
+	case domain.GameTypeBlackjack:
+		bjGame, ok := activeGame.(blackjack.Blackjack)
+		if !ok {
+			return fmt.Errorf("failed to cast game to blackjack in %s", operationName)
+		}
+
+		bjRepo, err := unit.BlackjackRepo()
+		if err != nil {
+			return fmt.Errorf("failed to get blackjack repository in %s: %w", operationName, err)
+		}
+
+		// There is no other player to win the hand, the house keeps the stake.
+		_, err = bjRepo.UpdateGame(ctx, bjGame.Forfeit())
+		if err != nil {
+			return fmt.Errorf("failed to update blackjack game in %s: %w", operationName, err)
+		}
+
+	case domain.GameTypeBattleship:
+		bsGame, ok := activeGame.(battleship.Battleship)
+		if !ok {
+			return fmt.Errorf("failed to cast game to battleship in %s", operationName)
+		}
+
+		bsRepo, err := unit.BattleshipRepo()
+		if err != nil {
+			return fmt.Errorf("failed to get battleship repository in %s: %w", operationName, err)
+		}
+
+		_, err = handleAbandonedGame(ctx, bsGame, bsRepo.UpdateGame, operationName, "battleship")
+		if err != nil {
+			return err
+		}
+
+	case domain.GameTypeBullsCows:
+		bcGame, ok := activeGame.(bullscows.BullsCows)
+		if !ok {
+			return fmt.Errorf("failed to cast game to bulls and cows in %s", operationName)
+		}
+
+		bcRepo, err := unit.BullsCowsRepo()
+		if err != nil {
+			return fmt.Errorf("failed to get bulls and cows repository in %s: %w", operationName, err)
+		}
+
+		_, err = handleAbandonedGame(ctx, bcGame, bcRepo.UpdateGame, operationName, "bulls and cows")
+		if err != nil {
+			return err
+		}
+
+	case domain.GameTypeCheckers:
+		ckGame, ok := activeGame.(checkers.Checkers)
+		if !ok {
+			return fmt.Errorf("failed to cast game to checkers in %s", operationName)
+		}
+
+		ckRepo, err := unit.CheckersRepo()
+		if err != nil {
+			return fmt.Errorf("failed to get checkers repository in %s: %w", operationName, err)
+		}
+
+		_, err = handleAbandonedGame(ctx, ckGame, ckRepo.UpdateGame, operationName, "checkers")
+		if err != nil {
+			return err
+		}
+
+	case domain.GameTypeReversi:
+		rvGame, ok := activeGame.(reversi.Reversi)
+		if !ok {
+			return fmt.Errorf("failed to cast game to reversi in %s", operationName)
+		}
+
+		rvRepo, err := unit.ReversiRepo()
+		if err != nil {
+			return fmt.Errorf("failed to get reversi repository in %s: %w", operationName, err)
+		}
+
+		_, err = handleAbandonedGame(ctx, rvGame, rvRepo.UpdateGame, operationName, "reversi")
+		if err != nil {
+			return err
+		}
+
+	case domain.GameTypeHangman:
+		hmGame, ok := activeGame.(hangman.Hangman)
+		if !ok {
+			return fmt.Errorf("failed to cast game to hangman in %s", operationName)
+		}
+
+		hmRepo, err := unit.HangmanRepo()
+		if err != nil {
+			return fmt.Errorf("failed to get hangman repository in %s: %w", operationName, err)
+		}
+
+		_, err = handleAbandonedGame(ctx, hmGame, hmRepo.UpdateGame, operationName, "hangman")
+		if err != nil {
+			return err
+		}
+
+	case domain.GameTypeTrivia:
+		tvGame, ok := activeGame.(trivia.Trivia)
+		if !ok {
+			return fmt.Errorf("failed to cast game to trivia in %s", operationName)
+		}
+
+		tvRepo, err := unit.TriviaRepo()
+		if err != nil {
+			return fmt.Errorf("failed to get trivia repository in %s: %w", operationName, err)
+		}
+
+		_, err = handleAbandonedGame(ctx, tvGame, tvRepo.UpdateGame, operationName, "trivia")
+		if err != nil {
+			return err
+		}
 	}
 
 	l.DebugContext(ctx, "Determined abandoned game winner")
@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"microgame-bot/internal/core/logger"
+	"microgame-bot/internal/domain"
+	domainBet "microgame-bot/internal/domain/bet"
+	domainHouse "microgame-bot/internal/domain/house"
+	domainSession "microgame-bot/internal/domain/session"
+	"microgame-bot/internal/uow"
+)
+
+// processHousePayout settles a solo session against the house account.
+// A blackjack session is a single hand: the house pays the player what the hand returns
+// and keeps the difference if the stake was lost. Cancelled sessions are refunded in full.
+func processHousePayout(
+	ctx context.Context,
+	unit uow.IUnitOfWork,
+	session domainSession.Session,
+	bets []domainBet.Bet,
+) error {
+	const operationName = "handler::process_house_payout"
+	l := slog.With(
+		slog.String(logger.OperationField, operationName),
+	)
+
+	betRepo, err := unit.BetRepo()
+	if err != nil {
+		return fmt.Errorf("failed to get bet repository in %s: %w", operationName, err)
+	}
+	userRepo, err := unit.UserRepo()
+	if err != nil {
+		return fmt.Errorf("failed to get user repository in %s: %w", operationName, err)
+	}
+	houseRepo, err := unit.HouseRepo()
+	if err != nil {
+		return fmt.Errorf("failed to get house repository in %s: %w", operationName, err)
+	}
+	bjRepo, err := unit.BlackjackRepo()
+	if err != nil {
+		return fmt.Errorf("failed to get blackjack repository in %s: %w", operationName, err)
+	}
+
+	staked := domain.Token(0)
+	for _, bet := range bets {
+		staked += bet.Amount()
+	}
+	ctx = logger.WithLogValue(ctx, logger.TotalPoolField, staked)
+
+	// Every bet of a solo session belongs to the same player, doubling adds a second one.
+	playerID := bets[0].UserID()
+	payout := staked
+
+	switch session.Status() {
+	case domain.GameStatusCancelled:
+		l.InfoContext(ctx, "Processing cancelled solo session - full refund")
+
+	case domain.GameStatusFinished, domain.GameStatusAbandoned:
+		games, err := bjRepo.GamesBySessionID(ctx, session.ID())
+		if err != nil {
+			return fmt.Errorf("failed to get blackjack games in %s: %w", operationName, err)
+		}
+		if len(games) == 0 || !games[len(games)-1].IsFinished() {
+			l.WarnContext(ctx, "Solo session has no settled hand - full refund")
+			break
+		}
+		payout = games[len(games)-1].Payout(staked)
+
+		account, err := houseRepo.AccountLocked(ctx, domainHouse.MainAccountID)
+		if err != nil {
+			return fmt.Errorf("failed to get house account in %s: %w", operationName, err)
+		}
+		if payout > staked {
+			account = account.Pay(payout - staked)
+		} else {
+			account = account.Collect(staked - payout)
+		}
+		if _, err := houseRepo.UpdateAccount(ctx, account); err != nil {
+			return fmt.Errorf("failed to update house account in %s: %w", operationName, err)
+		}
+
+	default:
+		l.WarnContext(ctx, "Solo session is not over yet - skipping payout", "status", session.Status())
+		return nil
+	}
+
+	if payout > 0 {
+		player, err := userRepo.UserByIDLocked(ctx, playerID)
+		if err != nil {
+			return fmt.Errorf("failed to get player in %s: %w", operationName, err)
+		}
+		player, err = player.AddTokens(payout)
+		if err != nil {
+			return fmt.Errorf("failed to add tokens to player in %s: %w", operationName, err)
+		}
+		if _, err := userRepo.UpdateUser(ctx, player); err != nil {
+			return fmt.Errorf("failed to update player in %s: %w", operationName, err)
+		}
+	}
+	l.DebugContext(ctx, "Settled solo session with the house",
+		logger.UserIDField, playerID.String(),
+		"payout", payout)
+
+	if err := betRepo.UpdateBetsStatusBatch(ctx, session.ID(), domainBet.StatusPaid); err != nil {
+		return fmt.Errorf("failed to update bets batch in %s: %w", operationName, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,218 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"microgame-bot/internal/core/logger"
+	"microgame-bot/internal/domain"
+	"microgame-bot/internal/domain/league"
+	domainSession "microgame-bot/internal/domain/session"
+	"microgame-bot/internal/domain/user"
+	tgHandlers "microgame-bot/internal/handlers"
+	"microgame-bot/internal/uow"
+	"time"
+
+	"github.com/mymmrac/telego"
+)
+
+// LeagueAdvanceHandler returns a handler function that keeps league tables up to date.
+// Results of finished fixture sessions are recorded, leagues are finalised at the deadline
+// and registrations the organizer never started expire.
+func LeagueAdvanceHandler(
+	u uow.IUnitOfWork,
+	sender iMessageSender,
+) func(ctx context.Context, data []byte) error {
+	const operationName = "queue::handler::league_advance"
+	return func(ctx context.Context, _ []byte) error {
+		l := slog.With(slog.String(logger.OperationField, operationName))
+
+		leagueRepo, err := u.LeagueRepo()
+		if err != nil {
+			return fmt.Errorf("failed to get league repository in %s: %w", operationName, err)
+		}
+
+		userRepo, err := u.UserRepo()
+		if err != nil {
+			return fmt.Errorf("failed to get user repository in %s: %w", operationName, err)
+		}
+
+		leagues, err := leagueRepo.LeaguesByStatus(
+			ctx,
+			domain.GameStatusWaitingForPlayers,
+			domain.GameStatusInProgress,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to get active leagues in %s: %w", operationName, err)
+		}
+
+		for _, lg := range leagues {
+			ctx := logger.WithLogValue(ctx, logger.GameIDField, lg.ID().String())
+
+			var changed bool
+			err := u.Do(ctx, func(unit uow.IUnitOfWork) error {
+				var err error
+				lg, changed, err = advanceLeague(ctx, unit, lg.ID(), time.Now())
+				return err
+			})
+			if err != nil {
+				// One broken league should not block the others.
+				l.ErrorContext(ctx, "Failed to advance league", logger.ErrorField, err.Error())
+				continue
+			}
+			if !changed {
+				continue
+			}
+
+			msg, markup, err := tgHandlers.BuildLeagueMessage(ctx, userRepo, lg)
+			if err != nil {
+				l.WarnContext(ctx, "Failed to build league message", logger.ErrorField, err.Error())
+				continue
+			}
+			_, err = sender.EditMessageText(ctx, &telego.EditMessageTextParams{
+				InlineMessageID: lg.InlineMessageID().String(),
+				Text:            msg,
+				ParseMode:       "HTML",
+				ReplyMarkup:     markup,
+			})
+			if err != nil {
+				// The table is redrawn on the next change anyway.
+				l.WarnContext(ctx, "Failed to edit league message", logger.ErrorField, err.Error())
+			}
+		}
+
+		return nil
+	}
+}
+
+// advanceLeague records results of finished fixtures and finalises the league at the deadline.
+// Must be called within transaction.
+func advanceLeague(
+	ctx context.Context,
+	unit uow.IUnitOfWork,
+	id league.ID,
+	now time.Time,
+) (league.League, bool, error) {
+	leagueRepo, err := unit.LeagueRepo()
+	if err != nil {
+		return league.League{}, false, fmt.Errorf("failed to get league repository: %w", err)
+	}
+	sessionRepo, err := unit.SessionRepo()
+	if err != nil {
+		return league.League{}, false, fmt.Errorf("failed to get session repository: %w", err)
+	}
+
+	lg, err := leagueRepo.LeagueByIDLocked(ctx, id)
+	if err != nil {
+		return league.League{}, false, fmt.Errorf("failed to get league with lock: %w", err)
+	}
+
+	changed := false
+	switch lg.Status() {
+	case domain.GameStatusWaitingForPlayers:
+		if now.Sub(lg.CreatedAt()) < league.RegistrationTimeout {
+			return lg, false, nil
+		}
+		lg, err = lg.Expire()
+		if err != nil {
+			return lg, false, err
+		}
+		changed = true
+
+	case domain.GameStatusInProgress:
+		sessions, err := sessionRepo.SessionsByLeagueID(ctx, lg.ID().UUID())
+		if err != nil {
+			return lg, false, fmt.Errorf("failed to get fixture sessions: %w", err)
+		}
+		byID := make(map[domainSession.ID]domainSession.Session, len(sessions))
+		for _, s := range sessions {
+			byID[s.ID()] = s
+		}
+
+		deadlinePassed := lg.IsDeadlinePassed(now)
+		for _, number := range lg.RunningFixtures() {
+			fixture, err := lg.Fixture(number)
+			if err != nil {
+				return lg, false, err
+			}
+			session, ok := byID[fixture.SessionID]
+			if !ok {
+				continue
+			}
+			lg, err = recordFixture(ctx, unit, lg, number, session, deadlinePassed)
+			if err != nil {
+				return lg, false, fmt.Errorf("failed to record result of fixture %d: %w", number, err)
+			}
+			if fixtureRecorded(lg, number) {
+				changed = true
+			}
+		}
+
+		if deadlinePassed && lg.Status() == domain.GameStatusInProgress {
+			lg, err = lg.Finalise()
+			if err != nil {
+				return lg, false, err
+			}
+			changed = true
+		}
+
+	default:
+		return lg, false, nil
+	}
+
+	if !changed {
+		return lg, false, nil
+	}
+
+	lg, err = leagueRepo.UpdateLeague(ctx, lg)
+	if err != nil {
+		return lg, false, fmt.Errorf("failed to update league: %w", err)
+	}
+	return lg, true, nil
+}
+
+// recordFixture applies the session result to the fixture.
+// A session that is still being played is left alone until the deadline,
+// then whoever leads by score takes the fixture by forfeit.
+func recordFixture(
+	ctx context.Context,
+	unit uow.IUnitOfWork,
+	lg league.League,
+	number int,
+	session domainSession.Session,
+	deadlinePassed bool,
+) (league.League, error) {
+	active := isSessionActive(session.Status())
+	if active && !deadlinePassed {
+		return lg, nil
+	}
+
+	games, err := sessionGames(ctx, unit, session)
+	if err != nil {
+		return lg, err
+	}
+	manager := domainSession.NewManager(session, games)
+
+	if !active && session.Status() == domain.GameStatusFinished {
+		result := manager.CalculateResult()
+		if result.IsCompleted {
+			winnerID := user.ID{}
+			if len(result.SeriesWinners) == 1 {
+				winnerID = result.SeriesWinners[0]
+			}
+			return lg.ReportResult(number, winnerID)
+		}
+	}
+
+	// Abandoned, cancelled or cut short by the deadline.
+	winnerID := user.ID{}
+	if winners := manager.DetermineWinnersByCurrentScore(); len(winners) == 1 {
+		winnerID = winners[0]
+	}
+	return lg.Forfeit(number, winnerID)
+}
+
+func fixtureRecorded(lg league.League, number int) bool {
+	fixture, err := lg.Fixture(number)
+	return err == nil && fixture.IsPlayed()
+}
@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"microgame-bot/internal/core/logger"
+	"microgame-bot/internal/domain"
+	tgHandlers "microgame-bot/internal/handlers"
+	"microgame-bot/internal/msgs"
+	"microgame-bot/internal/uow"
+	"time"
+
+	"github.com/mymmrac/telego"
+	tu "github.com/mymmrac/telego/telegoutil"
+)
+
+type iMessageDispatcher interface {
+	SendMessage(ctx context.Context, params *telego.SendMessageParams) (*telego.Message, error)
+}
+
+// LeagueRemindHandler returns a handler function that reminds league players about unplayed fixtures.
+// Reminders go to private chats, players who never started the bot are skipped.
+func LeagueRemindHandler(u uow.IUnitOfWork, sender iMessageDispatcher) func(ctx context.Context, data []byte) error {
+	const operationName = "queue::handler::league_remind"
+	return func(ctx context.Context, _ []byte) error {
+		l := slog.With(slog.String(logger.OperationField, operationName))
+
+		leagueRepo, err := u.LeagueRepo()
+		if err != nil {
+			return fmt.Errorf("failed to get league repository in %s: %w", operationName, err)
+		}
+		userRepo, err := u.UserRepo()
+		if err != nil {
+			return fmt.Errorf("failed to get user repository in %s: %w", operationName, err)
+		}
+
+		leagues, err := leagueRepo.LeaguesByStatus(ctx, domain.GameStatusInProgress)
+		if err != nil {
+			return fmt.Errorf("failed to get active leagues in %s: %w", operationName, err)
+		}
+
+		now := time.Now()
+		for _, lg := range leagues {
+			if lg.IsDeadlinePassed(now) {
+				continue
+			}
+			ctx := logger.WithLogValue(ctx, logger.GameIDField, lg.ID().String())
+
+			users, err := tgHandlers.LeaguePlayers(ctx, userRepo, lg)
+			if err != nil {
+				l.WarnContext(ctx, "Failed to load league players", logger.ErrorField, err.Error())
+				continue
+			}
+
+			for _, playerID := range lg.Players() {
+				player := users[playerID]
+				if len(lg.UnplayedFixtures(playerID)) == 0 || player.ChatID().IsZero() {
+					continue
+				}
+				_, err := sender.SendMessage(ctx, tu.Message(
+					tu.ID(int64(*player.ChatID())),
+					msgs.LeagueReminder(lg, playerID, users, now),
+				).WithParseMode("HTML"))
+				if err != nil {
+					// The player may have blocked the bot, the others still get their reminders.
+					l.WarnContext(ctx, "Failed to send league reminder",
+						logger.UserIDField, playerID.String(),
+						logger.ErrorField, err.Error())
+				}
+			}
+		}
+
+		return nil
+	}
+}
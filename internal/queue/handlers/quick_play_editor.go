@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"microgame-bot/internal/domain"
+	"microgame-bot/internal/domain/matchmaking"
+	domainSession "microgame-bot/internal/domain/session"
+
+	"github.com/mymmrac/telego"
+)
+
+type iMessageEditor interface {
+	iMessageSender
+	iMarkupEditor
+}
+
+type iSessionTicketsGetter interface {
+	TicketsBySessionID(ctx context.Context, sessionID domainSession.ID) ([]matchmaking.Ticket, error)
+}
+
+// QuickPlayEditor edits game messages on behalf of the queue handlers.
+// Quick play games have no inline message, their edits go to the private chat message of every player,
+// the rest is passed to the bot as is.
+type QuickPlayEditor struct {
+	tickets iSessionTicketsGetter
+	editor  iMessageEditor
+}
+
+func NewQuickPlayEditor(tickets iSessionTicketsGetter, editor iMessageEditor) *QuickPlayEditor {
+	return &QuickPlayEditor{tickets: tickets, editor: editor}
+}
+
+func (e *QuickPlayEditor) EditMessageText(
+	ctx context.Context,
+	params *telego.EditMessageTextParams,
+) (*telego.Message, error) {
+	messages, ok, err := e.messages(ctx, params.InlineMessageID)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return e.editor.EditMessageText(ctx, params)
+	}
+
+	var edited *telego.Message
+	for _, message := range messages {
+		mirrored := *params
+		mirrored.InlineMessageID = ""
+		mirrored.ChatID = telego.ChatID{ID: message.ChatID}
+		mirrored.MessageID = message.MessageID
+		edited, err = e.editor.EditMessageText(ctx, &mirrored)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return edited, nil
+}
+
+func (e *QuickPlayEditor) EditMessageReplyMarkup(
+	ctx context.Context,
+	params *telego.EditMessageReplyMarkupParams,
+) (*telego.Message, error) {
+	messages, ok, err := e.messages(ctx, params.InlineMessageID)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return e.editor.EditMessageReplyMarkup(ctx, params)
+	}
+
+	var edited *telego.Message
+	for _, message := range messages {
+		mirrored := *params
+		mirrored.InlineMessageID = ""
+		mirrored.ChatID = telego.ChatID{ID: message.ChatID}
+		mirrored.MessageID = message.MessageID
+		edited, err = e.editor.EditMessageReplyMarkup(ctx, &mirrored)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return edited, nil
+}
+
+// messages resolves the quick play inline message ID to the private chat messages of its players.
+func (e *QuickPlayEditor) messages(ctx context.Context, inlineMessageID string) ([]matchmaking.Message, bool, error) {
+	sessionID, ok := matchmaking.SessionIDFromInlineMessageID(domain.InlineMessageID(inlineMessageID))
+	if !ok {
+		return nil, false, nil
+	}
+	tickets, err := e.tickets.TicketsBySessionID(ctx, sessionID)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get quick play tickets: %w", err)
+	}
+	messages := make([]matchmaking.Message, 0, len(tickets))
+	for _, t := range tickets {
+		messages = append(messages, t.Message())
+	}
+	return messages, true, nil
+}
@@ -0,0 +1,353 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"microgame-bot/internal/core/logger"
+	"microgame-bot/internal/domain"
+	domainBet "microgame-bot/internal/domain/bet"
+	"microgame-bot/internal/domain/matchmaking"
+	domainSession "microgame-bot/internal/domain/session"
+	domainUser "microgame-bot/internal/domain/user"
+	tgHandlers "microgame-bot/internal/handlers"
+	"microgame-bot/internal/msgs"
+	"microgame-bot/internal/uow"
+	"microgame-bot/internal/utils"
+	"time"
+
+	"github.com/mymmrac/telego"
+	tu "github.com/mymmrac/telego/telegoutil"
+)
+
+type iQuickPlaySender interface {
+	iMessageSender
+	iMessageDispatcher
+}
+
+// QuickPlayMatchHandler returns a handler function that runs the quick play pool.
+// Tickets past their timeout are expired with the stake returned, the rest are paired by game, stake and rating.
+// Every pair gets a game session whose board is sent to the private chats of both players.
+func QuickPlayMatchHandler(
+	u uow.IUnitOfWork,
+	sender iQuickPlaySender,
+) func(ctx context.Context, data []byte) error {
+	const operationName = "queue::handler::quick_play_match"
+	return func(ctx context.Context, _ []byte) error {
+		l := slog.With(slog.String(logger.OperationField, operationName))
+
+		ticketRepo, err := u.MatchmakingRepo()
+		if err != nil {
+			return fmt.Errorf("failed to get matchmaking repository in %s: %w", operationName, err)
+		}
+
+		tickets, err := ticketRepo.TicketsByStatus(ctx, matchmaking.TicketStatusWaiting)
+		if err != nil {
+			return fmt.Errorf("failed to get waiting tickets in %s: %w", operationName, err)
+		}
+
+		now := time.Now()
+		waiting := make([]matchmaking.Ticket, 0, len(tickets))
+		for _, t := range tickets {
+			if !t.IsExpired(now) {
+				waiting = append(waiting, t)
+				continue
+			}
+			if err := expireTicket(ctx, u, t.ID(), now); err != nil {
+				l.WarnContext(ctx, "Failed to expire ticket",
+					logger.UserIDField, t.UserID().String(),
+					logger.ErrorField, err.Error())
+				continue
+			}
+			_, err := sender.EditMessageText(ctx, &telego.EditMessageTextParams{
+				ChatID:      telego.ChatID{ID: t.Message().ChatID},
+				MessageID:   t.Message().MessageID,
+				Text:        msgs.QuickPlayExpired(),
+				ParseMode:   "HTML",
+				ReplyMarkup: tgHandlers.BuildQuickPlayMenuKeyboard(),
+			})
+			if err != nil {
+				l.WarnContext(ctx, "Failed to report expired ticket", logger.ErrorField, err.Error())
+			}
+		}
+
+		ratings := make(map[domainUser.ID]int, len(waiting))
+		for _, t := range waiting {
+			rating, err := quickPlayRating(ctx, u, t.UserID(), t.GameType())
+			if err != nil {
+				l.WarnContext(ctx, "Failed to rate player",
+					logger.UserIDField, t.UserID().String(),
+					logger.ErrorField, err.Error())
+				continue
+			}
+			ratings[t.UserID()] = rating
+		}
+
+		for _, pair := range matchmaking.PairTickets(waiting, ratings, now) {
+			if err := startQuickPlayMatch(ctx, u, sender, pair, now); err != nil {
+				l.WarnContext(ctx, "Failed to start quick play match", logger.ErrorField, err.Error())
+			}
+		}
+
+		return nil
+	}
+}
+
+// expireTicket takes the ticket out of the pool and returns the held stake.
+func expireTicket(ctx context.Context, u uow.IUnitOfWork, id matchmaking.ID, now time.Time) error {
+	return u.Do(ctx, func(unit uow.IUnitOfWork) error {
+		ticketRepo, err := unit.MatchmakingRepo()
+		if err != nil {
+			return fmt.Errorf("failed to get matchmaking repository: %w", err)
+		}
+		userRepo, err := unit.UserRepo()
+		if err != nil {
+			return fmt.Errorf("failed to get user repository: %w", err)
+		}
+
+		ticket, err := ticketRepo.TicketByIDLocked(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to get ticket by ID with lock: %w", err)
+		}
+		ticket, err = ticket.Expire(now)
+		if err != nil {
+			return err
+		}
+
+		if ticket.Stake() > 0 {
+			player, err := userRepo.UserByIDLocked(ctx, ticket.UserID())
+			if err != nil {
+				return fmt.Errorf("failed to get player with lock: %w", err)
+			}
+			player, err = player.AddTokens(ticket.Stake())
+			if err != nil {
+				return fmt.Errorf("failed to return stake: %w", err)
+			}
+			if _, err := userRepo.UpdateUser(ctx, player); err != nil {
+				return fmt.Errorf("failed to update player: %w", err)
+			}
+		}
+
+		if _, err := ticketRepo.UpdateTicket(ctx, ticket); err != nil {
+			return fmt.Errorf("failed to update ticket: %w", err)
+		}
+		return nil
+	})
+}
+
+// quickPlayRating rates the player by their decided games of the given type.
+func quickPlayRating(
+	ctx context.Context,
+	u uow.IUnitOfWork,
+	userID domainUser.ID,
+	gameType domain.GameType,
+) (int, error) {
+	userRepo, err := u.UserRepo()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get user repository: %w", err)
+	}
+	sessionRepo, err := u.SessionRepo()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get session repository: %w", err)
+	}
+	rpsRepo, err := u.RPSRepo()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get RPS repository: %w", err)
+	}
+	tttRepo, err := u.TTTRepo()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get TTT repository: %w", err)
+	}
+
+	sessionIDs, err := userRepo.GetUserSessionIDs(ctx, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get user session IDs: %w", err)
+	}
+	ids := sessionIDs.RPSSessionIDs
+	if gameType == domain.GameTypeTTT {
+		ids = sessionIDs.TTTSessionIDs
+	}
+
+	stats := calculateGameStats(ctx, userID, ids, sessionRepo, rpsRepo, tttRepo)
+	return matchmaking.Rating(stats.Wins, stats.Losses), nil
+}
+
+// startQuickPlayMatch seats both players into a new session and sends them the game.
+// The held stakes become the bets of the session.
+func startQuickPlayMatch(
+	ctx context.Context,
+	u uow.IUnitOfWork,
+	sender iQuickPlaySender,
+	pair matchmaking.Pair,
+	now time.Time,
+) error {
+	var session domainSession.Session
+	var game tgHandlers.SeatedGame
+	var first, second matchmaking.Ticket
+	err := u.Do(ctx, func(unit uow.IUnitOfWork) error {
+		ticketRepo, err := unit.MatchmakingRepo()
+		if err != nil {
+			return fmt.Errorf("failed to get matchmaking repository: %w", err)
+		}
+		sessionRepo, err := unit.SessionRepo()
+		if err != nil {
+			return fmt.Errorf("failed to get session repository: %w", err)
+		}
+		betRepo, err := unit.BetRepo()
+		if err != nil {
+			return fmt.Errorf("failed to get bet repository: %w", err)
+		}
+
+		first, err = ticketRepo.TicketByIDLocked(ctx, pair.First.ID())
+		if err != nil {
+			return fmt.Errorf("failed to get first ticket with lock: %w", err)
+		}
+		second, err = ticketRepo.TicketByIDLocked(ctx, pair.Second.ID())
+		if err != nil {
+			return fmt.Errorf("failed to get second ticket with lock: %w", err)
+		}
+		// Either player may have left the pool since the tickets were read
+		if !first.IsWaiting() || !second.IsWaiting() {
+			return matchmaking.ErrTicketNotWaiting
+		}
+
+		sessionID := domainSession.ID(utils.NewUniqueID())
+		session, err = domainSession.New(
+			domainSession.WithID(sessionID),
+			domainSession.WithGameType(first.GameType()),
+			domainSession.WithInlineMessageID(matchmaking.InlineMessageID(sessionID)),
+			domainSession.WithGameCount(1),
+			domainSession.WithBet(first.Stake()),
+			domainSession.WithMoveTimeout(matchmaking.MoveTimeout),
+			domainSession.WithStatus(domain.GameStatusInProgress),
+			domainSession.WithNewSeed(),
+		)
+		if err != nil {
+			return err
+		}
+		session, err = sessionRepo.CreateSession(ctx, session)
+		if err != nil {
+			return fmt.Errorf("failed to create session: %w", err)
+		}
+
+		for _, t := range []matchmaking.Ticket{first, second} {
+			if t.Stake() == 0 {
+				continue
+			}
+			bet, err := domainBet.New(
+				domainBet.WithNewID(),
+				domainBet.WithUserID(t.UserID()),
+				domainBet.WithSessionID(sessionID),
+				domainBet.WithAmount(t.Stake()),
+				domainBet.WithStatus(domainBet.StatusRunning),
+			)
+			if err != nil {
+				return fmt.Errorf("failed to create bet: %w", err)
+			}
+			if _, err := betRepo.CreateBet(ctx, bet); err != nil {
+				return fmt.Errorf("failed to save bet: %w", err)
+			}
+		}
+
+		first, err = first.Match(second.UserID(), sessionID, now)
+		if err != nil {
+			return err
+		}
+		second, err = second.Match(first.UserID(), sessionID, now)
+		if err != nil {
+			return err
+		}
+		if _, err := ticketRepo.UpdateTicket(ctx, first); err != nil {
+			return fmt.Errorf("failed to update first ticket: %w", err)
+		}
+		if _, err := ticketRepo.UpdateTicket(ctx, second); err != nil {
+			return fmt.Errorf("failed to update second ticket: %w", err)
+		}
+
+		game, err = tgHandlers.CreateSeatedGame(ctx, unit, session, first.UserID(), second.UserID())
+		if err != nil {
+			return fmt.Errorf("failed to create game: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	userRepo, err := u.UserRepo()
+	if err != nil {
+		return fmt.Errorf("failed to get user repository: %w", err)
+	}
+	player1, err := userRepo.UserByID(ctx, first.UserID())
+	if err != nil {
+		return fmt.Errorf("failed to get first player: %w", err)
+	}
+	player2, err := userRepo.UserByID(ctx, second.UserID())
+	if err != nil {
+		return fmt.Errorf("failed to get second player: %w", err)
+	}
+
+	msg, boardKeyboard, err := game.Start(ctx, userRepo, player1, session)
+	if err != nil {
+		return fmt.Errorf("failed to start game: %w", err)
+	}
+
+	for _, seat := range []struct {
+		ticket   matchmaking.Ticket
+		opponent domainUser.User
+	}{
+		{ticket: first, opponent: player2},
+		{ticket: second, opponent: player1},
+	} {
+		pool := seat.ticket.Message()
+		_, err := sender.EditMessageText(ctx, &telego.EditMessageTextParams{
+			ChatID:    telego.ChatID{ID: pool.ChatID},
+			MessageID: pool.MessageID,
+			Text:      msgs.QuickPlayMatched(seat.opponent),
+			ParseMode: "HTML",
+		})
+		if err != nil {
+			return fmt.Errorf("failed to report match: %w", err)
+		}
+
+		sent, err := sender.SendMessage(ctx, tu.Message(
+			tu.ID(pool.ChatID),
+			msgs.QuickPlayHeader()+msg,
+		).WithParseMode("HTML").WithReplyMarkup(boardKeyboard))
+		if err != nil {
+			return fmt.Errorf("failed to send game: %w", err)
+		}
+
+		err = attachTicketMessage(ctx, u, seat.ticket.ID(), matchmaking.Message{
+			ChatID:    pool.ChatID,
+			MessageID: sent.MessageID,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// attachTicketMessage binds the matched ticket to the game message, from now on the game is drawn there.
+func attachTicketMessage(ctx context.Context, u uow.IUnitOfWork, id matchmaking.ID, message matchmaking.Message) error {
+	return u.Do(ctx, func(unit uow.IUnitOfWork) error {
+		ticketRepo, err := unit.MatchmakingRepo()
+		if err != nil {
+			return fmt.Errorf("failed to get matchmaking repository: %w", err)
+		}
+		ticket, err := ticketRepo.TicketByIDLocked(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to get ticket by ID with lock: %w", err)
+		}
+		ticket, err = ticket.AttachMessage(message)
+		if err != nil {
+			return err
+		}
+		if _, err := ticketRepo.UpdateTicket(ctx, ticket); err != nil {
+			return fmt.Errorf("failed to update ticket: %w", err)
+		}
+		return nil
+	})
+}
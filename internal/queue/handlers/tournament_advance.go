@@ -0,0 +1,236 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"microgame-bot/internal/core/logger"
+	"microgame-bot/internal/domain"
+	domainSession "microgame-bot/internal/domain/session"
+	"microgame-bot/internal/domain/tournament"
+	"microgame-bot/internal/domain/user"
+	tgHandlers "microgame-bot/internal/handlers"
+	"microgame-bot/internal/uow"
+	"time"
+
+	"github.com/mymmrac/telego"
+)
+
+// TournamentAdvanceHandler returns a handler function that moves tournaments forward.
+// Winners of finished match sessions advance through the bracket, the prize pool is released
+// once the final is over, and registrations that never filled up expire.
+func TournamentAdvanceHandler(
+	u uow.IUnitOfWork,
+	sender iMessageSender,
+) func(ctx context.Context, data []byte) error {
+	const operationName = "queue::handler::tournament_advance"
+	return func(ctx context.Context, _ []byte) error {
+		l := slog.With(slog.String(logger.OperationField, operationName))
+
+		tournamentRepo, err := u.TournamentRepo()
+		if err != nil {
+			return fmt.Errorf("failed to get tournament repository in %s: %w", operationName, err)
+		}
+
+		userRepo, err := u.UserRepo()
+		if err != nil {
+			return fmt.Errorf("failed to get user repository in %s: %w", operationName, err)
+		}
+
+		tournaments, err := tournamentRepo.TournamentsByStatus(
+			ctx,
+			domain.GameStatusWaitingForPlayers,
+			domain.GameStatusInProgress,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to get active tournaments in %s: %w", operationName, err)
+		}
+
+		for _, t := range tournaments {
+			ctx := logger.WithLogValue(ctx, logger.GameIDField, t.ID().String())
+
+			var changed bool
+			err := u.Do(ctx, func(unit uow.IUnitOfWork) error {
+				var err error
+				t, changed, err = advanceTournament(ctx, unit, t.ID())
+				return err
+			})
+			if err != nil {
+				// One broken tournament should not block the others.
+				l.ErrorContext(ctx, "Failed to advance tournament", logger.ErrorField, err.Error())
+				continue
+			}
+			if !changed {
+				continue
+			}
+
+			msg, markup, err := tgHandlers.BuildTournamentMessage(ctx, userRepo, t)
+			if err != nil {
+				l.WarnContext(ctx, "Failed to build tournament message", logger.ErrorField, err.Error())
+				continue
+			}
+			_, err = sender.EditMessageText(ctx, &telego.EditMessageTextParams{
+				InlineMessageID: t.InlineMessageID().String(),
+				Text:            msg,
+				ParseMode:       "HTML",
+				ReplyMarkup:     markup,
+			})
+			if err != nil {
+				// The bracket is redrawn on the next change anyway.
+				l.WarnContext(ctx, "Failed to edit tournament message", logger.ErrorField, err.Error())
+			}
+		}
+
+		return nil
+	}
+}
+
+// advanceTournament applies the results of finished matches to the tournament.
+// Must be called within transaction.
+func advanceTournament(
+	ctx context.Context,
+	unit uow.IUnitOfWork,
+	id tournament.ID,
+) (tournament.Tournament, bool, error) {
+	tournamentRepo, err := unit.TournamentRepo()
+	if err != nil {
+		return tournament.Tournament{}, false, fmt.Errorf("failed to get tournament repository: %w", err)
+	}
+
+	t, err := tournamentRepo.TournamentByIDLocked(ctx, id)
+	if err != nil {
+		return tournament.Tournament{}, false, fmt.Errorf("failed to get tournament with lock: %w", err)
+	}
+
+	changed := false
+	switch t.Status() {
+	case domain.GameStatusWaitingForPlayers:
+		if time.Since(t.CreatedAt()) < tournament.RegistrationTimeout {
+			return t, false, nil
+		}
+		t, err = t.Expire()
+		if err != nil {
+			return t, false, err
+		}
+		if err := tgHandlers.CloseTournamentPool(ctx, unit, t, domain.GameStatusCancelled); err != nil {
+			return t, false, fmt.Errorf("failed to close prize pool: %w", err)
+		}
+		changed = true
+
+	case domain.GameStatusInProgress:
+		for _, number := range t.RunningMatches() {
+			match, err := t.Match(number)
+			if err != nil {
+				return t, false, err
+			}
+			winnerID, decided, err := matchWinner(ctx, unit, t, number, match)
+			if err != nil {
+				return t, false, fmt.Errorf("failed to determine winner of match %d: %w", number, err)
+			}
+			if !decided {
+				continue
+			}
+			t, err = t.ReportWinner(number, winnerID)
+			if err != nil {
+				return t, false, err
+			}
+			changed = true
+		}
+		if t.IsOver() {
+			if err := tgHandlers.CloseTournamentPool(ctx, unit, t, domain.GameStatusFinished); err != nil {
+				return t, false, fmt.Errorf("failed to close prize pool: %w", err)
+			}
+		}
+
+	default:
+		return t, false, nil
+	}
+
+	if !changed {
+		return t, false, nil
+	}
+
+	t, err = tournamentRepo.UpdateTournament(ctx, t)
+	if err != nil {
+		return t, false, fmt.Errorf("failed to update tournament: %w", err)
+	}
+	return t, true, nil
+}
+
+// matchWinner returns the winner of the match once its session is over.
+// A series without a clear winner is decided by the seeded tie-break.
+func matchWinner(
+	ctx context.Context,
+	unit uow.IUnitOfWork,
+	t tournament.Tournament,
+	number int,
+	match tournament.Match,
+) (user.ID, bool, error) {
+	sessionRepo, err := unit.SessionRepo()
+	if err != nil {
+		return user.ID{}, false, fmt.Errorf("failed to get session repository: %w", err)
+	}
+
+	session, err := sessionRepo.SessionByID(ctx, match.SessionID)
+	if err != nil {
+		return user.ID{}, false, fmt.Errorf("failed to get match session: %w", err)
+	}
+	if isSessionActive(session.Status()) {
+		return user.ID{}, false, nil
+	}
+
+	games, err := sessionGames(ctx, unit, session)
+	if err != nil {
+		return user.ID{}, false, err
+	}
+	manager := domainSession.NewManager(session, games)
+
+	result := manager.CalculateResult()
+	if result.IsCompleted && len(result.SeriesWinners) == 1 {
+		return result.SeriesWinners[0], true, nil
+	}
+	if session.Status() == domain.GameStatusAbandoned {
+		if winners := manager.DetermineWinnersByCurrentScore(); len(winners) == 1 {
+			return winners[0], true, nil
+		}
+	}
+
+	winnerID, err := t.TieBreak(number)
+	if err != nil {
+		return user.ID{}, false, err
+	}
+	return winnerID, true, nil
+}
+
+func sessionGames(ctx context.Context, unit uow.IUnitOfWork, session domainSession.Session) ([]domainSession.IGame, error) {
+	var games []domainSession.IGame
+	switch session.GameType() {
+	case domain.GameTypeTTT:
+		tttRepo, err := unit.TTTRepo()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get TTT repository: %w", err)
+		}
+		tttGames, err := tttRepo.GamesBySessionID(ctx, session.ID())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get TTT games: %w", err)
+		}
+		for _, g := range tttGames {
+			games = append(games, g)
+		}
+	case domain.GameTypeRPS:
+		rpsRepo, err := unit.RPSRepo()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get RPS repository: %w", err)
+		}
+		rpsGames, err := rpsRepo.GamesBySessionID(ctx, session.ID())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get RPS games: %w", err)
+		}
+		for _, g := range rpsGames {
+			games = append(games, g)
+		}
+	default:
+		return nil, domain.ErrInvalidGameType
+	}
+	return games, nil
+}
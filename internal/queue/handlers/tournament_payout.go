@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"microgame-bot/internal/core/logger"
+	"microgame-bot/internal/domain"
+	domainBet "microgame-bot/internal/domain/bet"
+	domainSession "microgame-bot/internal/domain/session"
+	"microgame-bot/internal/uow"
+)
+
+// processTournamentPayout pays the prize pool of a finished tournament to its top finishers,
+// or refunds entry fees if the tournament was cancelled.
+func processTournamentPayout(
+	ctx context.Context,
+	unit uow.IUnitOfWork,
+	session domainSession.Session,
+	bets []domainBet.Bet,
+) error {
+	const operationName = "handler::process_tournament_payout"
+	l := slog.With(
+		slog.String(logger.OperationField, operationName),
+	)
+
+	betRepo, err := unit.BetRepo()
+	if err != nil {
+		return fmt.Errorf("failed to get bet repository in %s: %w", operationName, err)
+	}
+	userRepo, err := unit.UserRepo()
+	if err != nil {
+		return fmt.Errorf("failed to get user repository in %s: %w", operationName, err)
+	}
+
+	switch session.Status() {
+	case domain.GameStatusCancelled:
+		l.InfoContext(ctx, "Processing cancelled tournament - full refund")
+
+		for _, bet := range bets {
+			user, err := userRepo.UserByIDLocked(ctx, bet.UserID())
+			if err != nil {
+				return fmt.Errorf("failed to get user in %s: %w", operationName, err)
+			}
+			user, err = user.AddTokens(bet.Amount())
+			if err != nil {
+				return fmt.Errorf("failed to add tokens to user in %s: %w", operationName, err)
+			}
+			if _, err := userRepo.UpdateUser(ctx, user); err != nil {
+				return fmt.Errorf("failed to update user in %s: %w", operationName, err)
+			}
+
+			l.DebugContext(ctx, "Refunded entry fee for cancelled tournament",
+				logger.UserIDField, bet.UserID().String(),
+				"amount", bet.Amount())
+		}
+
+	case domain.GameStatusFinished:
+		tournamentRepo, err := unit.TournamentRepo()
+		if err != nil {
+			return fmt.Errorf("failed to get tournament repository in %s: %w", operationName, err)
+		}
+		t, err := tournamentRepo.TournamentBySessionID(ctx, session.ID())
+		if err != nil {
+			return fmt.Errorf("failed to get tournament in %s: %w", operationName, err)
+		}
+
+		totalPool := domain.Token(0)
+		for _, bet := range bets {
+			totalPool += bet.Amount()
+		}
+		ctx = logger.WithLogValue(ctx, logger.TotalPoolField, totalPool)
+
+		prizes := t.Prizes(domainBet.CalculateWinPayout(totalPool))
+		ctx = logger.WithLogValue(ctx, logger.WinnersCountField, len(prizes))
+		l.InfoContext(ctx, "Processing finished tournament - paying prizes")
+
+		for _, prize := range prizes {
+			winner, err := userRepo.UserByIDLocked(ctx, prize.UserID)
+			if err != nil {
+				return fmt.Errorf("failed to get prize winner in %s: %w", operationName, err)
+			}
+			winner, err = winner.AddTokens(prize.Amount)
+			if err != nil {
+				return fmt.Errorf("failed to add tokens to prize winner in %s: %w", operationName, err)
+			}
+			if _, err := userRepo.UpdateUser(ctx, winner); err != nil {
+				return fmt.Errorf("failed to update prize winner in %s: %w", operationName, err)
+			}
+		}
+
+	default:
+		l.WarnContext(ctx, "Tournament is not over yet - skipping payout", "status", session.Status())
+		return nil
+	}
+
+	if err := betRepo.UpdateBetsStatusBatch(ctx, session.ID(), domainBet.StatusPaid); err != nil {
+		return fmt.Errorf("failed to update bets batch in %s: %w", operationName, err)
+	}
+
+	return nil
+}
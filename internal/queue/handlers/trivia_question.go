@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"microgame-bot/internal/core/logger"
+	"microgame-bot/internal/domain"
+	"microgame-bot/internal/domain/trivia"
+	tgHandlers "microgame-bot/internal/handlers"
+	"microgame-bot/internal/uow"
+
+	"github.com/mymmrac/telego"
+)
+
+// TriviaQuestionHandler returns a handler function that closes a trivia question once its time has run out.
+// The task is skipped if the question has been closed by an answer since it was scheduled.
+func TriviaQuestionHandler(
+	u uow.IUnitOfWork,
+	library trivia.Library,
+	sender iMessageSender,
+) func(ctx context.Context, data []byte) error {
+	const operationName = "queue::handler::trivia_question"
+	return func(ctx context.Context, data []byte) error {
+		l := slog.With(slog.String(logger.OperationField, operationName))
+
+		var task trivia.QuestionTask
+		if err := json.Unmarshal(data, &task); err != nil {
+			return fmt.Errorf("failed to unmarshal payload in %s: %w", operationName, err)
+		}
+
+		ctx = logger.WithLogValue(ctx, logger.SessionIDField, task.SessionID.String())
+		l.DebugContext(ctx, "Processing trivia question task", "question", task.Question)
+
+		var game trivia.Trivia
+		var expired bool
+		err := u.Do(ctx, func(unit uow.IUnitOfWork) error {
+			gameRepo, err := unit.TriviaRepo()
+			if err != nil {
+				return fmt.Errorf("failed to get game repository in %s: %w", operationName, err)
+			}
+
+			game, err = gameRepo.GameByIDLocked(ctx, trivia.ID(task.GameID))
+			if err != nil {
+				if errors.Is(err, domain.ErrGameNotFound) {
+					return nil
+				}
+				return fmt.Errorf("failed to get game by ID with lock in %s: %w", operationName, err)
+			}
+
+			game, err = game.ExpireQuestion(task.Question)
+			if err != nil {
+				if errors.Is(err, trivia.ErrQuestionClosed) ||
+					errors.Is(err, domain.ErrGameOver) ||
+					errors.Is(err, domain.ErrGameNotStarted) {
+					return nil
+				}
+				return fmt.Errorf("failed to expire question in %s: %w", operationName, err)
+			}
+
+			game, err = gameRepo.UpdateGame(ctx, game)
+			if err != nil {
+				return fmt.Errorf("failed to update game in %s: %w", operationName, err)
+			}
+
+			expired = true
+			return tgHandlers.ScheduleTriviaQuestion(ctx, unit, game)
+		})
+		if err != nil {
+			return uow.ErrFailedToDoTransaction(operationName, err)
+		}
+
+		if !expired {
+			l.DebugContext(ctx, "Question has been closed since the task was scheduled, skipping")
+			return nil
+		}
+
+		text, markup, err := tgHandlers.AdvanceTriviaSession(ctx, u, library, game)
+		if err != nil {
+			return fmt.Errorf("failed to advance trivia session in %s: %w", operationName, err)
+		}
+
+		if task.InlineMessageID.IsZero() {
+			return nil
+		}
+
+		_, err = sender.EditMessageText(ctx, &telego.EditMessageTextParams{
+			InlineMessageID: task.InlineMessageID.String(),
+			Text:            text,
+			ParseMode:       "HTML",
+			ReplyMarkup:     markup,
+		})
+		if err != nil {
+			// The question is already closed, retrying the task would only try to edit the message again.
+			l.WarnContext(ctx, "Failed to edit trivia message", logger.ErrorField, err.Error())
+		}
+
+		return nil
+	}
+}
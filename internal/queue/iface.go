@@ -2,16 +2,48 @@ package queue
 
 import (
 	"context"
+	"microgame-bot/internal/utils"
+	"time"
+
+	"gorm.io/gorm"
 )
 
 type IQueuePublisher interface {
 	Publish(ctx context.Context, tasks []Task) error
 }
 
+// IQueue is a backend of the task queue, Postgres (Queue) or NATS JetStream (JetStream).
 type IQueue interface {
 	IQueuePublisher
-	Register(subject string, handler Handler)
+	IDeadLetter
+	IRetention
+	IOutbox
+	Register(subject string, handler Handler, opts ...SubjectOpt)
+	SubjectStats() []SubjectStats
+	PendingTasks(ctx context.Context) (map[string]int64, error)
 	Start(ctx context.Context)
 	Stop(ctx context.Context) error
 	CleanupStuckTasks(ctx context.Context) error
+	// StuckTasks counts the running tasks whose attempt started more than olderThan ago,
+	// ErrStuckTasksUntracked if the backend doesn't track the running tasks.
+	StuckTasks(ctx context.Context, olderThan time.Duration) (int64, error)
+}
+
+// IOutbox publishes tasks through the transaction of the caller, they become visible on its commit.
+type IOutbox interface {
+	Outbox(tx *gorm.DB) IQueuePublisher
+}
+
+// IRetention removes the finished tasks by the retention policies of their subjects.
+type IRetention interface {
+	ApplyRetention(ctx context.Context) (RetentionRun, error)
+	LastRetention() RetentionRun
+}
+
+// IDeadLetter inspects and replays the tasks that ran out of attempts.
+type IDeadLetter interface {
+	FailedTasks(ctx context.Context, filter DeadLetterFilter) ([]Task, error)
+	FailedTask(ctx context.Context, id utils.UniqueID) (Task, error)
+	RequeueFailed(ctx context.Context, filter DeadLetterFilter) (int64, error)
+	DiscardFailed(ctx context.Context, filter DeadLetterFilter) (int64, error)
 }
@@ -0,0 +1,660 @@
+package queue
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"microgame-bot/internal/core/logger"
+	"microgame-bot/internal/utils"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultStream = "tasks"
+	// jetStreamAckWait is the time a dead worker holds a task, running tasks keep it with heartbeats.
+	jetStreamAckWait = 30 * time.Second
+	// saturatedDelay is the delay of a task whose subject is at its concurrency cap.
+	saturatedDelay = time.Second
+	// dedupClaimGrace is the time a claimed dedup key may go without the sequence of its message,
+	// a claim older than that was left by a publisher that crashed before publishing.
+	dedupClaimGrace = time.Minute
+	// dedupClaimAttempts bounds the retries of a claim racing with other publishers.
+	dedupClaimAttempts = 3
+)
+
+// WithStream sets the name of the JetStream stream of the tasks, it prefixes the subjects
+// of the messages and the names of the key-value buckets of the queue.
+func WithStream(name string) Opt {
+	return func(o *options) {
+		o.stream = name
+	}
+}
+
+// WithReplicas sets the number of the replicas of the JetStream stream and buckets.
+func WithReplicas(replicas int) Opt {
+	return func(o *options) {
+		o.replicas = max(replicas, 1)
+	}
+}
+
+// JetStream is the NATS JetStream backend of the task queue. Tasks are messages of a work-queue stream,
+// failed ones, the dedup keys and the fan-ins of the groups are kept in key-value buckets.
+//
+// Unlike Queue it doesn't order due tasks by priority, a task leaves the stream on ack so completed tasks
+// aren't kept, and archiving retention policies delete the failed tasks. Publish isn't atomic,
+// a failed batch may be published in part. Outbox needs WithOutbox.
+type JetStream struct {
+	js       jetstream.JetStream
+	stream   jetstream.Stream
+	consumer jetstream.Consumer
+	failed   jetstream.KeyValue
+	dedup    jetstream.KeyValue
+	groups   jetstream.KeyValue
+	sem      utils.ISemaphore
+	outboxDB *gorm.DB
+	prefix   string
+	registry
+	wg           sync.WaitGroup
+	pollPeriod   time.Duration
+	retryBackoff time.Duration
+	batchSize    int
+}
+
+// NewJetStream creates the stream, the durable consumer and the buckets of the queue if they are missing.
+func NewJetStream(ctx context.Context, conn *nats.Conn, maxWorkers int, opts ...Opt) (*JetStream, error) {
+	if maxWorkers <= 0 {
+		maxWorkers = defaultMaxWorkers
+	}
+	o := newOptions(opts)
+
+	js, err := jetstream.New(conn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create jetstream: %w", err)
+	}
+	prefix := o.stream + "."
+	stream, err := js.CreateOrUpdateStream(ctx, jetstream.StreamConfig{
+		Name:      o.stream,
+		Subjects:  []string{prefix + ">"},
+		Retention: jetstream.WorkQueuePolicy,
+		Storage:   jetstream.FileStorage,
+		Replicas:  o.replicas,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create or update task stream: %w", err)
+	}
+	consumer, err := stream.CreateOrUpdateConsumer(ctx, jetstream.ConsumerConfig{
+		Durable:    o.stream + "_workers",
+		AckPolicy:  jetstream.AckExplicitPolicy,
+		AckWait:    jetStreamAckWait,
+		MaxDeliver: -1,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create or update task consumer: %w", err)
+	}
+	failed, err := js.CreateOrUpdateKeyValue(ctx, jetstream.KeyValueConfig{
+		Bucket:   o.stream + "_failed",
+		Storage:  jetstream.FileStorage,
+		Replicas: o.replicas,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create or update failed task bucket: %w", err)
+	}
+	dedup, err := js.CreateOrUpdateKeyValue(ctx, jetstream.KeyValueConfig{
+		Bucket:   o.stream + "_dedup",
+		Storage:  jetstream.FileStorage,
+		Replicas: o.replicas,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create or update task dedup bucket: %w", err)
+	}
+	groups, err := js.CreateOrUpdateKeyValue(ctx, jetstream.KeyValueConfig{
+		Bucket:   o.stream + "_groups",
+		Storage:  jetstream.FileStorage,
+		Replicas: o.replicas,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create or update task group bucket: %w", err)
+	}
+
+	sem, _ := utils.NewSemaphore(maxWorkers)
+	return &JetStream{
+		js:           js,
+		stream:       stream,
+		consumer:     consumer,
+		failed:       failed,
+		dedup:        dedup,
+		groups:       groups,
+		sem:          sem,
+		prefix:       prefix,
+		outboxDB:     o.outboxDB,
+		registry:     newRegistry(o.retention),
+		pollPeriod:   o.pollPeriod,
+		retryBackoff: o.retryBackoff,
+		batchSize:    defaultBatchSize,
+	}, nil
+}
+
+// Publish sends the tasks to the stream. The weights of the subjects are added to the priorities of the tasks.
+// A task with the dedup key of a task waiting for its first attempt is skipped, or supersedes it if it is debounced.
+// The IfSkipped tasks of the skipped ones are published instead.
+func (q *JetStream) Publish(ctx context.Context, tasks []Task) error {
+	tasks = withLineage(ctx, q.withWeights(tasks))
+	now := time.Now()
+	unique, duplicates := uniqueTasks(tasks)
+	skipped := skippedBy(duplicates)
+	for _, task := range unique {
+		task.Status = TaskStatusPending
+		task.CreatedAt = now
+		if task.Join != nil {
+			if err := q.createGroup(ctx, task); err != nil {
+				return err
+			}
+			task.Join = nil
+		}
+		var revision uint64
+		if task.DedupKey != "" {
+			var claimed bool
+			var err error
+			if revision, claimed, err = q.claimDedupKey(ctx, task); err != nil {
+				return err
+			}
+			if !claimed {
+				skipped = append(skipped, skippedBy([]Task{task})...)
+				continue
+			}
+		}
+		seq, err := q.publish(ctx, task)
+		if err != nil {
+			q.releaseDedupKey(ctx, task)
+			return err
+		}
+		if task.DedupKey != "" {
+			q.recordDedupSeq(ctx, task, revision, seq)
+		}
+	}
+	if len(skipped) == 0 {
+		return nil
+	}
+	return q.Publish(ctx, skipped)
+}
+
+func (q *JetStream) Start(ctx context.Context) {
+	const operationName = "queue::JetStream::Start"
+	q.wg.Add(1)
+	go q.consume(ctx)
+	if q.outboxDB != nil {
+		q.wg.Add(1)
+		go q.relay(ctx)
+	}
+	slog.InfoContext(ctx, "Task queue started", "backend", "jetstream", logger.OperationField, operationName)
+}
+
+func (q *JetStream) Stop(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		q.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// CleanupStuckTasks is a no-op, the tasks of dead workers are redelivered once their ack wait is over.
+func (q *JetStream) CleanupStuckTasks(_ context.Context) error {
+	return nil
+}
+
+// StuckTasks returns ErrStuckTasksUntracked. A running task keeps its message with heartbeats,
+// the message of a dead worker is redelivered once its ack wait is over.
+func (q *JetStream) StuckTasks(_ context.Context, _ time.Duration) (int64, error) {
+	return 0, fmt.Errorf("%w: the jetstream backend redelivers the tasks of dead workers", ErrStuckTasksUntracked)
+}
+
+// PendingTasks counts the tasks in the stream by subject, the running and delayed ones included.
+func (q *JetStream) PendingTasks(ctx context.Context) (map[string]int64, error) {
+	info, err := q.stream.Info(ctx, jetstream.WithSubjectFilter(q.prefix+">"))
+	if err != nil {
+		return nil, err
+	}
+	counts := make(map[string]int64, len(info.State.Subjects))
+	for subject, count := range info.State.Subjects {
+		counts[strings.TrimPrefix(subject, q.prefix)] = int64(count) //nolint:gosec // Message counts fit int64.
+	}
+	return counts, nil
+}
+
+// ApplyRetention deletes the failed tasks older than the retention policies of their subjects.
+func (q *JetStream) ApplyRetention(ctx context.Context) (RetentionRun, error) {
+	const operationName = "queue::JetStream::ApplyRetention"
+	now := time.Now()
+	run := RetentionRun{At: now, Subjects: make(map[string]RetentionCount)}
+
+	tasks, err := q.failedTasks(ctx, DeadLetterFilter{})
+	if err != nil {
+		return RetentionRun{}, fmt.Errorf("failed to list failed tasks in %s: %w", operationName, err)
+	}
+	for _, task := range tasks {
+		policy := q.retentionFor(task.Subject)
+		if policy.Failed <= 0 || !task.UpdatedAt.Before(now.Add(-policy.Failed)) {
+			continue
+		}
+		if err := q.failed.Delete(ctx, task.ID.String()); err != nil {
+			return RetentionRun{}, fmt.Errorf("failed to delete failed task in %s: %w", operationName, err)
+		}
+		count := run.Subjects[task.Subject]
+		count.Deleted++
+		run.Subjects[task.Subject] = count
+		run.Deleted++
+	}
+
+	q.setLastRetention(ctx, run, operationName)
+	return run, nil
+}
+
+// FailedTasks lists the failed tasks matching the filter, the latest failures first.
+func (q *JetStream) FailedTasks(ctx context.Context, filter DeadLetterFilter) ([]Task, error) {
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultDeadLetterLimit
+	}
+	tasks, err := q.failedTasks(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return tasks[:min(limit, len(tasks))], nil
+}
+
+// FailedTask returns the failed task with its payload and error history.
+func (q *JetStream) FailedTask(ctx context.Context, id utils.UniqueID) (Task, error) {
+	entry, err := q.failed.Get(ctx, id.String())
+	if errors.Is(err, jetstream.ErrKeyNotFound) {
+		return Task{}, ErrTaskNotFound
+	}
+	if err != nil {
+		return Task{}, err
+	}
+	var task Task
+	err = json.Unmarshal(entry.Value(), &task)
+	return task, err
+}
+
+// RequeueFailed publishes the failed tasks matching the filter again with reset attempts.
+// The error history is kept. Requeued tasks lose the dedup key, a replay can't be skipped.
+func (q *JetStream) RequeueFailed(ctx context.Context, filter DeadLetterFilter) (int64, error) {
+	if filter.isEmpty() {
+		return 0, ErrEmptyFilter
+	}
+	tasks, err := q.failedTasks(ctx, filter)
+	if err != nil {
+		return 0, err
+	}
+	var requeued int64
+	for _, task := range tasks {
+		task.Status = TaskStatusPending
+		task.Attempts = 0
+		task.RunAfter = time.Now()
+		task.DedupKey = ""
+		if _, err := q.publish(ctx, task); err != nil {
+			return requeued, err
+		}
+		if err := q.failed.Delete(ctx, task.ID.String()); err != nil {
+			return requeued, err
+		}
+		requeued++
+	}
+	return requeued, nil
+}
+
+// DiscardFailed deletes the failed tasks matching the filter.
+func (q *JetStream) DiscardFailed(ctx context.Context, filter DeadLetterFilter) (int64, error) {
+	if filter.isEmpty() {
+		return 0, ErrEmptyFilter
+	}
+	tasks, err := q.failedTasks(ctx, filter)
+	if err != nil {
+		return 0, err
+	}
+	var discarded int64
+	for _, task := range tasks {
+		if err := q.failed.Delete(ctx, task.ID.String()); err != nil {
+			return discarded, err
+		}
+		discarded++
+	}
+	return discarded, nil
+}
+
+// failedTasks reads the whole failed task bucket, the latest failures first.
+func (q *JetStream) failedTasks(ctx context.Context, filter DeadLetterFilter) ([]Task, error) {
+	watcher, err := q.failed.WatchAll(ctx, jetstream.IgnoreDeletes())
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = watcher.Stop() }()
+
+	var tasks []Task
+	for entry := range watcher.Updates() {
+		// nil marks the end of the current values
+		if entry == nil {
+			break
+		}
+		var task Task
+		if err := json.Unmarshal(entry.Value(), &task); err != nil {
+			return nil, err
+		}
+		if filter.matches(task) {
+			tasks = append(tasks, task)
+		}
+	}
+	slices.SortFunc(tasks, func(a, b Task) int {
+		return b.UpdatedAt.Compare(a.UpdatedAt)
+	})
+	return tasks, nil
+}
+
+// publish sends the task to the stream and returns the sequence of its message.
+func (q *JetStream) publish(ctx context.Context, task Task) (uint64, error) {
+	task.UpdatedAt = time.Now()
+	data, err := json.Marshal(task)
+	if err != nil {
+		return 0, err
+	}
+	ack, err := q.js.Publish(ctx, q.prefix+task.Subject, data)
+	if err != nil {
+		return 0, err
+	}
+	return ack.Sequence, nil
+}
+
+func (q *JetStream) consume(ctx context.Context) {
+	const operationName = "queue::JetStream::consume"
+	defer q.wg.Done()
+
+	messages, err := q.consumer.Messages(jetstream.PullMaxMessages(q.batchSize))
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to consume tasks",
+			logger.OperationField, operationName,
+			logger.ErrorField, err.Error(),
+		)
+		return
+	}
+	defer messages.Stop()
+
+	for {
+		if err := q.sem.Acquire(ctx); err != nil {
+			return
+		}
+		msg, err := messages.Next(jetstream.NextContext(ctx))
+		if err != nil {
+			q.sem.Release()
+			if ctx.Err() != nil || errors.Is(err, jetstream.ErrMsgIteratorClosed) {
+				return
+			}
+			slog.WarnContext(ctx, "Failed to fetch tasks", "error", err)
+			continue
+		}
+
+		q.wg.Add(1)
+		go q.processMessage(ctx, msg)
+	}
+}
+
+func (q *JetStream) processMessage(ctx context.Context, msg jetstream.Msg) {
+	defer q.wg.Done()
+	defer q.sem.Release()
+
+	var task Task
+	if err := json.Unmarshal(msg.Data(), &task); err != nil {
+		slog.ErrorContext(ctx, "Failed to decode task, dropping it", logger.ErrorField, err.Error())
+		settle(ctx, msg.Term())
+		return
+	}
+
+	taskCtx := logger.WithLogValue(ctx, "task_id", task.ID.String())
+	taskCtx = logger.WithLogValue(taskCtx, "subject", task.Subject)
+	taskCtx = logger.WithLogValue(taskCtx, logger.CorrelationIDField, task.CorrelationID)
+	taskCtx, run := withRunningTask(taskCtx, &task)
+
+	// A delayed or retried task waits in the stream, its attempts are counted in the task itself
+	if wait := time.Until(task.RunAfter); wait > 0 {
+		settle(taskCtx, msg.NakWithDelay(wait))
+		return
+	}
+	if ctx.Err() != nil {
+		settle(taskCtx, msg.Nak())
+		return
+	}
+	if q.isSuperseded(taskCtx, task) {
+		settle(taskCtx, msg.Ack())
+		return
+	}
+	if len(q.reserve([]Task{task})) == 0 {
+		settle(taskCtx, msg.NakWithDelay(saturatedDelay))
+		return
+	}
+	defer q.release(task.Subject)
+
+	handler := q.findHandler(task.Subject)
+	if handler == nil {
+		slog.WarnContext(taskCtx, "No handler found for subject, requeueing")
+		settle(taskCtx, msg.NakWithDelay(requeueDelay))
+		return
+	}
+
+	// The key is only held until the task starts, a duplicate published while it runs isn't skipped.
+	// A redelivered task whose key a newer duplicate took meanwhile is dropped as superseded.
+	q.releaseDedupKey(taskCtx, task)
+
+	task.Status = TaskStatusRunning
+	task.Attempts++
+	task.LastAttempt = time.Now()
+
+	if err := q.run(taskCtx, msg, handler, task); err != nil {
+		slog.ErrorContext(taskCtx, "Task handler failed", logger.ErrorField, err.Error())
+		q.nack(taskCtx, msg, task, err)
+		return
+	}
+
+	// The task runs again if its continuations can't be published, it keeps the attempt
+	task.Status = TaskStatusCompleted
+	if err := q.finish(taskCtx, task, run.takeFollowUps()); err != nil {
+		slog.ErrorContext(taskCtx, "Failed to publish task continuations", logger.ErrorField, err.Error())
+		settle(taskCtx, msg.NakWithDelay(requeueDelay))
+		return
+	}
+	settle(taskCtx, msg.Ack())
+}
+
+// run calls the handler within the timeout of the task, the message is kept in progress meanwhile.
+func (q *JetStream) run(ctx context.Context, msg jetstream.Msg, handler Handler, task Task) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, task.Timeout.Duration())
+	defer cancel()
+
+	go func() {
+		ticker := time.NewTicker(jetStreamAckWait / 3) //nolint:mnd // A few heartbeats per ack wait.
+		defer ticker.Stop()
+		for {
+			select {
+			case <-timeoutCtx.Done():
+				return
+			case <-ticker.C:
+				_ = msg.InProgress()
+			}
+		}
+	}()
+
+	return handler(timeoutCtx, task.Payload)
+}
+
+// nack records the failure, the task is published again with a backoff or moved to the failed ones.
+func (q *JetStream) nack(ctx context.Context, msg jetstream.Msg, task Task, handlerErr error) {
+	now := time.Now()
+	task.withFailure(handlerErr.Error(), now)
+	task.UpdatedAt = now
+
+	if task.Attempts < task.MaxAttempts {
+		task.Status = TaskStatusPending
+		task.RunAfter = now.Add(retryDelay(q.retryBackoff, task.Attempts))
+		if _, err := q.publish(ctx, task); err != nil {
+			slog.ErrorContext(ctx, "Failed to nack task", logger.ErrorField, err.Error())
+			// The message is retried as it was, the attempt is lost
+			settle(ctx, msg.NakWithDelay(retryDelay(q.retryBackoff, task.Attempts)))
+			return
+		}
+		settle(ctx, msg.Ack())
+		return
+	}
+
+	task.Status = TaskStatusFailed
+	data, err := json.Marshal(task)
+	if err == nil {
+		_, err = q.failed.Put(ctx, task.ID.String(), data)
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to nack task", logger.ErrorField, err.Error())
+		settle(ctx, msg.NakWithDelay(requeueDelay))
+		return
+	}
+	if err := q.finish(ctx, task, nil); err != nil {
+		slog.ErrorContext(ctx, "Failed to publish task continuations", logger.ErrorField, err.Error())
+	}
+	settle(ctx, msg.Ack())
+}
+
+// claimDedupKey takes the dedup key of the task, false means a task with the key waits for its first attempt.
+// The key is released once the delivery of the task starts, see processMessage. A key whose holder
+// is gone without releasing it, dropped or settled by a worker that crashed, is taken over.
+// A debounced task takes the key over, the superseded task is dropped when it is delivered.
+func (q *JetStream) claimDedupKey(ctx context.Context, task Task) (uint64, bool, error) {
+	key := dedupBucketKey(task.DedupKey)
+	value := dedupHolder(task.ID, 0)
+	if task.debounce {
+		revision, err := q.dedup.Put(ctx, key, value)
+		return revision, err == nil, err
+	}
+	for range dedupClaimAttempts {
+		revision, err := q.dedup.Create(ctx, key, value)
+		if !errors.Is(err, jetstream.ErrKeyExists) {
+			return revision, err == nil, err
+		}
+		entry, err := q.dedup.Get(ctx, key)
+		if errors.Is(err, jetstream.ErrKeyNotFound) {
+			// Released meanwhile
+			continue
+		}
+		if err != nil {
+			return 0, false, err
+		}
+		stale, err := q.isStaleDedupKey(ctx, entry)
+		if err != nil || !stale {
+			return 0, false, err
+		}
+		revision, err = q.dedup.Update(ctx, key, value, entry.Revision())
+		if errors.Is(err, jetstream.ErrKeyExists) {
+			// Changed meanwhile
+			continue
+		}
+		return revision, err == nil, err
+	}
+	return 0, false, nil
+}
+
+// recordDedupSeq stores the sequence of the published message of the task with its dedup key,
+// so the key can be told stale once the message is gone. The key may have been released
+// or taken over by a debounced task meanwhile, it is then left as it is.
+func (q *JetStream) recordDedupSeq(ctx context.Context, task Task, revision, seq uint64) {
+	_, err := q.dedup.Update(ctx, dedupBucketKey(task.DedupKey), dedupHolder(task.ID, seq), revision)
+	if err != nil && !errors.Is(err, jetstream.ErrKeyExists) && !errors.Is(err, jetstream.ErrKeyNotFound) {
+		slog.WarnContext(ctx, "Failed to record task dedup key sequence", logger.ErrorField, err.Error())
+	}
+}
+
+// isStaleDedupKey reports whether the message of the task holding the key has left the stream
+// without the key being released, or the publisher of the task never recorded its message.
+func (q *JetStream) isStaleDedupKey(ctx context.Context, entry jetstream.KeyValueEntry) (bool, error) {
+	_, seq := parseDedupHolder(entry.Value())
+	if seq == 0 {
+		return time.Since(entry.Created()) > dedupClaimGrace, nil
+	}
+	_, err := q.stream.GetMsg(ctx, seq)
+	if errors.Is(err, jetstream.ErrMsgNotFound) {
+		return true, nil
+	}
+	return false, err
+}
+
+// releaseDedupKey frees the dedup key held by the task unless another task took it over.
+func (q *JetStream) releaseDedupKey(ctx context.Context, task Task) {
+	if task.DedupKey == "" {
+		return
+	}
+	key := dedupBucketKey(task.DedupKey)
+	entry, err := q.dedup.Get(ctx, key)
+	if errors.Is(err, jetstream.ErrKeyNotFound) {
+		return
+	}
+	if err == nil {
+		if id, _ := parseDedupHolder(entry.Value()); id == task.ID.String() {
+			err = q.dedup.Delete(ctx, key, jetstream.LastRevision(entry.Revision()))
+		}
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to release task dedup key", logger.ErrorField, err.Error())
+	}
+}
+
+// isSuperseded reports whether a debounced task took the dedup key of the task over.
+// A retried task doesn't hold its key, so it is never superseded.
+func (q *JetStream) isSuperseded(ctx context.Context, task Task) bool {
+	if task.DedupKey == "" || task.Attempts > 0 {
+		return false
+	}
+	entry, err := q.dedup.Get(ctx, dedupBucketKey(task.DedupKey))
+	if err != nil {
+		return false
+	}
+	id, _ := parseDedupHolder(entry.Value())
+	return id != task.ID.String()
+}
+
+// dedupHolder is the value of a dedup key: the task holding it and the stream sequence of its message,
+// zero until the message is published.
+func dedupHolder(id utils.UniqueID, seq uint64) []byte {
+	return []byte(id.String() + "/" + strconv.FormatUint(seq, 10))
+}
+
+// parseDedupHolder reads the value of a dedup key, a value without the sequence reads as zero.
+func parseDedupHolder(value []byte) (string, uint64) {
+	id, rawSeq, _ := strings.Cut(string(value), "/")
+	seq, _ := strconv.ParseUint(rawSeq, 10, 64)
+	return id, seq
+}
+
+// dedupBucketKey hashes the dedup key, bucket keys can't hold every character of a subject and payload key.
+func dedupBucketKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+func settle(ctx context.Context, err error) {
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to settle task message", logger.ErrorField, err.Error())
+	}
+}
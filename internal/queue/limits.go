@@ -0,0 +1,205 @@
+package queue
+
+import (
+	"context"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// SubjectOpt configures the handling of a subject at Register time.
+type SubjectOpt func(*subjectConfig)
+
+// WithWeight raises the priority of every task published for the subject by weight,
+// so tasks of heavier subjects are fetched first.
+func WithWeight(weight int) SubjectOpt {
+	return func(c *subjectConfig) {
+		c.weight = weight
+	}
+}
+
+// WithConcurrency caps the number of tasks of the subject running at once.
+// Zero means the subject is only bounded by the workers of the queue.
+func WithConcurrency(limit int) SubjectOpt {
+	return func(c *subjectConfig) {
+		c.concurrency = max(limit, 0)
+	}
+}
+
+// SubjectStats describes a registered subject for the health check.
+type SubjectStats struct {
+	Subject     string `json:"subject"`
+	Weight      int    `json:"weight"`
+	Concurrency int    `json:"concurrency,omitempty"`
+	Retention   string `json:"retention,omitempty"`
+	Running     int    `json:"running"`
+}
+
+type subjectConfig struct {
+	handler     Handler
+	retention   *RetentionPolicy
+	pattern     string
+	weight      int
+	concurrency int
+	running     int
+}
+
+func (c *subjectConfig) isSaturated() bool {
+	return c.concurrency > 0 && c.running >= c.concurrency
+}
+
+func (c *subjectConfig) isWildcard() bool {
+	return strings.ContainsAny(c.pattern, "*>")
+}
+
+// SubjectStats returns the registered subjects with their limits and running tasks.
+func (r *registry) SubjectStats() []SubjectStats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stats := make([]SubjectStats, 0, len(r.subjects))
+	for _, cfg := range r.subjects {
+		retention := r.retention
+		if cfg.retention != nil {
+			retention = *cfg.retention
+		}
+		stats = append(stats, SubjectStats{
+			Subject:     cfg.pattern,
+			Weight:      cfg.weight,
+			Concurrency: cfg.concurrency,
+			Retention:   retention.String(),
+			Running:     cfg.running,
+		})
+	}
+	slices.SortFunc(stats, func(a, b SubjectStats) int {
+		return strings.Compare(a.Subject, b.Subject)
+	})
+	return stats
+}
+
+// PendingTasks counts the pending tasks of every subject that has them.
+func (q *Queue) PendingTasks(ctx context.Context) (map[string]int64, error) {
+	var pending []struct {
+		Subject string
+		Count   int64
+	}
+	err := q.db.WithContext(ctx).
+		Model(&Task{}).
+		Select("subject, COUNT(*) AS count").
+		Where("status = ?", TaskStatusPending).
+		Group("subject").
+		Scan(&pending).Error
+	if err != nil {
+		return nil, err
+	}
+	counts := make(map[string]int64, len(pending))
+	for _, p := range pending {
+		counts[p.Subject] = p.Count
+	}
+	return counts, nil
+}
+
+// saturation is what fetchBatch skips as the caps are reached.
+type saturation struct {
+	// subjects are the saturated subjects registered by name
+	subjects []string
+	// patterns are the saturated wildcard patterns
+	patterns []saturatedPattern
+}
+
+// saturatedPattern is a wildcard pattern as a POSIX regular expression for SQL.
+type saturatedPattern struct {
+	regexp string
+	// exempt are the subjects registered by name that match the pattern, they have caps of their own
+	exempt []string
+}
+
+// saturatedSubjects returns what fetchBatch must skip as the caps are reached.
+func (r *registry) saturatedSubjects() saturation {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var s saturation
+	for _, cfg := range r.subjects {
+		if !cfg.isSaturated() {
+			continue
+		}
+		if !cfg.isWildcard() {
+			s.subjects = append(s.subjects, cfg.pattern)
+			continue
+		}
+		pattern := saturatedPattern{regexp: subjectRegexp(cfg.pattern)}
+		for _, other := range r.subjects {
+			if !other.isWildcard() && matchSubject(other.pattern, cfg.pattern) {
+				pattern.exempt = append(pattern.exempt, other.pattern)
+			}
+		}
+		s.patterns = append(s.patterns, pattern)
+	}
+	return s
+}
+
+// subjectRegexp translates the wildcard pattern to a regular expression matching the same subjects
+// as matchSubject: '*' is one token and '>' is the rest of them.
+func subjectRegexp(pattern string) string {
+	tokens := strings.Split(pattern, ".")
+	for i, token := range tokens {
+		switch token {
+		case "*":
+			tokens[i] = `[^.]+`
+		case ">":
+			tokens[i] = `.+`
+		default:
+			tokens[i] = regexp.QuoteMeta(token)
+		}
+	}
+	return "^" + strings.Join(tokens, `\.`) + "$"
+}
+
+// reserve takes the slots for the fetched tasks in order and returns the tasks that got one,
+// the rest stay pending for the next fetch.
+func (r *registry) reserve(tasks []Task) []Task {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	reserved := tasks[:0]
+	for _, task := range tasks {
+		cfg := r.findSubject(task.Subject)
+		if cfg != nil {
+			if cfg.isSaturated() {
+				continue
+			}
+			cfg.running++
+		}
+		r.running[task.Subject]++
+		reserved = append(reserved, task)
+	}
+	return reserved
+}
+
+// release frees the slot of the task once it is done.
+func (r *registry) release(subject string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if cfg := r.findSubject(subject); cfg != nil {
+		cfg.running--
+	}
+	r.running[subject]--
+	if r.running[subject] <= 0 {
+		delete(r.running, subject)
+	}
+}
+
+// withWeights adds the weights of the subjects to the priorities of the tasks.
+func (r *registry) withWeights(tasks []Task) []Task {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range tasks {
+		if cfg := r.findSubject(tasks[i].Subject); cfg != nil {
+			tasks[i].Priority += cfg.weight
+		}
+	}
+	return tasks
+}
@@ -0,0 +1,107 @@
+package queue
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func noopHandler(context.Context, []byte) error { return nil }
+
+func newTasks(subjects ...string) []Task {
+	tasks := make([]Task, 0, len(subjects))
+	for _, subject := range subjects {
+		tasks = append(tasks, NewTask(subject, EmptyPayload, time.Now(), 1, DefaultTimeout))
+	}
+	return tasks
+}
+
+func TestQueue_Reserve(t *testing.T) {
+	q := New(nil, 10)
+	q.Register("profile.load", noopHandler, WithConcurrency(2))
+	q.Register("games.*", noopHandler, WithConcurrency(1))
+	q.Register("bets.payout", noopHandler)
+
+	reserved := q.reserve(newTasks(
+		"profile.load", "profile.load", "profile.load", "games.afk", "games.clock", "bets.payout",
+	))
+	subjects := make([]string, 0, len(reserved))
+	for _, task := range reserved {
+		subjects = append(subjects, task.Subject)
+	}
+	assert.Equal(t, []string{"profile.load", "profile.load", "games.afk", "bets.payout"}, subjects)
+
+	// Exact subjects are skipped by name, wildcards by their patterns
+	assert.Equal(t, saturation{
+		subjects: []string{"profile.load"},
+		patterns: []saturatedPattern{{regexp: `^games\.[^.]+$`}},
+	}, q.saturatedSubjects())
+
+	q.release("profile.load")
+	q.release("games.afk")
+	assert.Empty(t, q.saturatedSubjects())
+	assert.NotContains(t, q.running, "games.afk")
+}
+
+func TestQueue_WithWeights(t *testing.T) {
+	q := New(nil, 10)
+	q.Register("bets.payout", noopHandler, WithWeight(10))
+	q.Register("profile.load", noopHandler)
+
+	tasks := newTasks("bets.payout", "profile.load", "unknown.subject")
+	tasks[0] = tasks[0].WithPriority(1)
+	tasks = q.withWeights(tasks)
+
+	assert.Equal(t, 11, tasks[0].Priority)
+	assert.Zero(t, tasks[1].Priority)
+	assert.Zero(t, tasks[2].Priority)
+}
+
+func TestQueue_SubjectStats(t *testing.T) {
+	q := New(nil, 10)
+	q.Register("profile.load", noopHandler, WithConcurrency(3))
+	q.Register("bets.payout", noopHandler, WithWeight(10), WithConcurrency(1))
+	q.reserve(newTasks("profile.load"))
+
+	stats := q.SubjectStats()
+	require.Len(t, stats, 2)
+	assert.Equal(t, SubjectStats{Subject: "bets.payout", Weight: 10, Concurrency: 1, Retention: "keep"}, stats[0])
+	assert.Equal(t, SubjectStats{Subject: "profile.load", Concurrency: 3, Retention: "keep", Running: 1}, stats[1])
+}
+
+func TestQueue_SaturatedPatternExempt(t *testing.T) {
+	q := New(nil, 10)
+	q.Register("games.*", noopHandler, WithConcurrency(1))
+	q.Register("games.clock", noopHandler)
+	q.reserve(newTasks("games.afk"))
+
+	assert.Equal(t, []saturatedPattern{{regexp: `^games\.[^.]+$`, exempt: []string{"games.clock"}}},
+		q.saturatedSubjects().patterns)
+}
+
+func TestSubjectRegexp(t *testing.T) {
+	tests := []struct {
+		pattern string
+		match   []string
+		miss    []string
+	}{
+		{pattern: "games.*", match: []string{"games.afk"}, miss: []string{"games", "games.afk.x", "gamesXafk"}},
+		{pattern: "games.>", match: []string{"games.afk", "games.afk.x"}, miss: []string{"games", "bets.payout"}},
+		{pattern: "*.payout", match: []string{"bets.payout"}, miss: []string{"bets.payout.x", "payout"}},
+	}
+	for _, tt := range tests {
+		re := regexp.MustCompile(subjectRegexp(tt.pattern))
+		for _, subject := range tt.match {
+			assert.True(t, re.MatchString(subject), "%s ~ %s", subject, tt.pattern)
+			assert.True(t, matchSubject(subject, tt.pattern), "%s ~ %s", subject, tt.pattern)
+		}
+		for _, subject := range tt.miss {
+			assert.False(t, re.MatchString(subject), "%s !~ %s", subject, tt.pattern)
+			assert.False(t, matchSubject(subject, tt.pattern), "%s !~ %s", subject, tt.pattern)
+		}
+	}
+}
@@ -14,6 +14,11 @@ const (
 	maxBackoffTimeout = 10 * time.Minute
 )
 
+// retryDelay doubles the backoff with every failed attempt up to maxBackoffTimeout.
+func retryDelay(backoff time.Duration, attempts int) time.Duration {
+	return min(time.Duration(1<<attempts)*backoff, maxBackoffTimeout)
+}
+
 func (q *Queue) nack(ctx context.Context, taskID utils.UniqueID, handlerErr error) {
 	err := q.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		var task Task
@@ -21,17 +26,20 @@ func (q *Queue) nack(ctx context.Context, taskID utils.UniqueID, handlerErr erro
 			return err
 		}
 
-		task.LastError = handlerErr.Error()
+		task.withFailure(handlerErr.Error(), time.Now())
 
 		if task.Attempts >= task.MaxAttempts {
 			task.Status = TaskStatusFailed
 		} else {
 			task.Status = TaskStatusPending
-			backoff := min(time.Duration(1<<task.Attempts)*10*time.Second, maxBackoffTimeout)
-			task.RunAfter = time.Now().Add(backoff)
+			task.RunAfter = time.Now().Add(retryDelay(q.retryBackoff, task.Attempts))
 		}
 
-		return tx.Save(&task).Error
+		if err := tx.Save(&task).Error; err != nil {
+			return err
+		}
+		taskCtx, _ := withRunningTask(ctx, &task)
+		return q.finish(taskCtx, tx, task, nil)
 	})
 
 	if err != nil {
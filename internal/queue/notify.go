@@ -0,0 +1,100 @@
+package queue
+
+import (
+	"context"
+	"log/slog"
+	"microgame-bot/internal/core/logger"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"gorm.io/gorm"
+)
+
+const (
+	// notifyChannel is the Postgres channel Publish notifies when a task is due right away.
+	notifyChannel = "queue_tasks"
+	// defaultIdlePollPeriod is the poll period with notifications on, it only picks up delayed tasks.
+	defaultIdlePollPeriod = time.Second
+	listenRetryDelay      = 5 * time.Second
+)
+
+// WithNotifications makes the poller LISTEN on a dedicated connection to the database,
+// so tasks due right away are fetched as soon as they are published instead of on the next poll.
+// The poll slows down to defaultIdlePollPeriod, it only picks up delayed and retried tasks.
+func WithNotifications(dsn string) Opt {
+	return func(o *options) {
+		o.listenDSN = dsn
+		o.pollPeriod = defaultIdlePollPeriod
+	}
+}
+
+// notify wakes up the pollers listening to the queue, the notification is sent on commit of tx.
+func notify(tx *gorm.DB, tasks []Task) error {
+	if !hasDueTask(tasks, time.Now()) {
+		return nil
+	}
+	return tx.Exec("SELECT pg_notify(?, '')", notifyChannel).Error
+}
+
+// hasDueTask reports whether any of the tasks should run right away.
+// Delayed tasks don't need a wake up, the poll picks them up in time.
+func hasDueTask(tasks []Task, now time.Time) bool {
+	for i := range tasks {
+		if !tasks[i].RunAfter.After(now) {
+			return true
+		}
+	}
+	return false
+}
+
+// wake asks the poller to fetch tasks right away, a pending wake up is enough.
+func (q *Queue) wake() {
+	select {
+	case q.wakeup <- struct{}{}:
+	default:
+	}
+}
+
+// listen keeps the LISTEN connection open until ctx is done, reconnecting after failures.
+func (q *Queue) listen(ctx context.Context) {
+	const operationName = "queue::listen"
+	defer q.wg.Done()
+
+	for {
+		err := q.listenOnce(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		slog.WarnContext(ctx, "Task queue listener failed, retrying",
+			logger.OperationField, operationName,
+			logger.ErrorField, err.Error(),
+		)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(listenRetryDelay):
+		}
+	}
+}
+
+func (q *Queue) listenOnce(ctx context.Context) error {
+	conn, err := pgx.Connect(ctx, q.listenDSN)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = conn.Close(context.Background()) }()
+
+	if _, err := conn.Exec(ctx, "LISTEN "+notifyChannel); err != nil {
+		return err
+	}
+	// Notifications sent while the listener was down are lost, so the backlog is fetched once
+	q.wake()
+
+	for {
+		if _, err := conn.WaitForNotification(ctx); err != nil {
+			return err
+		}
+		q.wake()
+	}
+}
@@ -0,0 +1,28 @@
+package queue
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHasDueTask(t *testing.T) {
+	now := time.Now()
+	due := NewTask("bets.payout", EmptyPayload, now, 1, DefaultTimeout)
+	delayed := NewTask(GameAFKSubject, EmptyPayload, now.Add(time.Minute), 1, DefaultTimeout)
+
+	assert.False(t, hasDueTask(nil, now))
+	assert.False(t, hasDueTask([]Task{delayed}, now), "delayed tasks are left to the poll")
+	assert.True(t, hasDueTask([]Task{delayed, due}, now))
+}
+
+func TestQueue_Wake(t *testing.T) {
+	q := New(nil, 1, WithNotifications("postgres://localhost/app"))
+	assert.Equal(t, defaultIdlePollPeriod, q.pollPeriod)
+
+	// Wake ups don't block and collapse into one pending fetch
+	q.wake()
+	q.wake()
+	assert.Len(t, q.wakeup, 1)
+}
@@ -0,0 +1,47 @@
+package queue
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	defaultPollPeriod   = 100 * time.Millisecond
+	defaultRetryBackoff = 10 * time.Second
+)
+
+// Opt configures the queue. Options are shared by the backends, the ones a backend has no use for are ignored.
+type Opt func(*options)
+
+type options struct {
+	outboxDB     *gorm.DB
+	listenDSN    string
+	stream       string
+	retention    RetentionPolicy
+	pollPeriod   time.Duration
+	retryBackoff time.Duration
+	replicas     int
+}
+
+func newOptions(opts []Opt) options {
+	o := options{
+		stream:       defaultStream,
+		pollPeriod:   defaultPollPeriod,
+		retryBackoff: defaultRetryBackoff,
+		replicas:     1,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithRetryBackoff sets the delay before the first retry of a failed task, it doubles with every attempt.
+func WithRetryBackoff(backoff time.Duration) Opt {
+	return func(o *options) {
+		if backoff > 0 {
+			o.retryBackoff = backoff
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"microgame-bot/internal/core/logger"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var errOutboxRelayOff = errors.New("task outbox relay is off, see WithOutbox")
+
+// Outbox publishes the tasks through tx, the tasks table is the outbox of the Postgres backend.
+// Pollers are notified on commit.
+func (q *Queue) Outbox(tx *gorm.DB) IQueuePublisher {
+	return txPublisher{q: q, tx: tx}
+}
+
+type txPublisher struct {
+	q  *Queue
+	tx *gorm.DB
+}
+
+func (p txPublisher) Publish(ctx context.Context, tasks []Task) error {
+	tasks = withLineage(ctx, p.q.withWeights(tasks))
+	tx := p.tx.WithContext(ctx)
+	if err := createGroups(tx, tasks); err != nil {
+		return err
+	}
+	skipped, err := createTasks(tx, tasks)
+	if err != nil {
+		return err
+	}
+	if err := notify(tx, tasks); err != nil {
+		return err
+	}
+	if len(skipped) == 0 {
+		return nil
+	}
+	return p.Publish(ctx, skipped)
+}
+
+// WithOutbox makes the JetStream backend relay the tasks published through its outbox from db to the stream.
+func WithOutbox(db *gorm.DB) Opt {
+	return func(o *options) {
+		o.outboxDB = db
+	}
+}
+
+// OutboxTask is a task published through the outbox of the JetStream backend, waiting for the relay.
+type OutboxTask struct {
+	CreatedAt time.Time `gorm:"not null"`
+	Task      []byte    `gorm:"not null;type:jsonb"`
+	ID        int64     `gorm:"primaryKey;autoIncrement"`
+	Debounce  bool      `gorm:"not null;default:false"`
+}
+
+func (OutboxTask) TableName() string {
+	return "task_outbox"
+}
+
+func newOutboxTask(task Task, now time.Time) (OutboxTask, error) {
+	data, err := json.Marshal(task)
+	return OutboxTask{CreatedAt: now, Task: data, Debounce: task.debounce}, err
+}
+
+func (t OutboxTask) task() (Task, error) {
+	var task Task
+	err := json.Unmarshal(t.Task, &task)
+	task.debounce = t.Debounce
+	return task, err
+}
+
+// Outbox stores the tasks in the outbox table through tx, the relay publishes them to the stream after commit.
+func (q *JetStream) Outbox(tx *gorm.DB) IQueuePublisher {
+	return outboxPublisher{tx: tx, off: q.outboxDB == nil}
+}
+
+type outboxPublisher struct {
+	tx  *gorm.DB
+	off bool
+}
+
+func (p outboxPublisher) Publish(ctx context.Context, tasks []Task) error {
+	if p.off {
+		return errOutboxRelayOff
+	}
+	if len(tasks) == 0 {
+		return nil
+	}
+	now := time.Now()
+	rows := make([]OutboxTask, 0, len(tasks))
+	for _, task := range withLineage(ctx, tasks) {
+		row, err := newOutboxTask(task, now)
+		if err != nil {
+			return err
+		}
+		rows = append(rows, row)
+	}
+	return p.tx.WithContext(ctx).Create(&rows).Error
+}
+
+// relay moves the committed outbox tasks to the stream until ctx is done.
+// A task is published again if the relay fails between the publish and the delete.
+func (q *JetStream) relay(ctx context.Context) {
+	const operationName = "queue::JetStream::relay"
+	defer q.wg.Done()
+
+	ticker := time.NewTicker(q.pollPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		for {
+			relayed, err := q.relayBatch(ctx)
+			if err != nil {
+				if ctx.Err() == nil {
+					slog.WarnContext(ctx, "Failed to relay outbox tasks",
+						logger.OperationField, operationName,
+						logger.ErrorField, err.Error(),
+					)
+				}
+				break
+			}
+			// A full batch means more tasks may wait
+			if relayed < q.batchSize {
+				break
+			}
+		}
+	}
+}
+
+func (q *JetStream) relayBatch(ctx context.Context) (int, error) {
+	var relayed int
+	err := q.outboxDB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var rows []OutboxTask
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Order("id").
+			Limit(q.batchSize).
+			Find(&rows).Error
+		if err != nil || len(rows) == 0 {
+			return err
+		}
+
+		tasks := make([]Task, 0, len(rows))
+		for _, row := range rows {
+			task, err := row.task()
+			if err != nil {
+				return err
+			}
+			tasks = append(tasks, task)
+		}
+		if err := q.Publish(ctx, tasks); err != nil {
+			return err
+		}
+		relayed = len(rows)
+		return tx.Delete(&rows).Error
+	})
+	return relayed, err
+}
@@ -0,0 +1,41 @@
+package queue
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutboxTask_RoundTrip(t *testing.T) {
+	runAfter := time.Now().Add(time.Minute).Truncate(time.Second)
+	task := NewTask("bets.payout", []byte(`{"id":1}`), runAfter, 2, time.Second).
+		WithPriority(5).
+		WithDedupKey("bets.payout").
+		WithDebounce()
+
+	row, err := newOutboxTask(task, time.Now())
+	require.NoError(t, err)
+	assert.True(t, row.Debounce)
+
+	restored, err := row.task()
+	require.NoError(t, err)
+	assert.Equal(t, task.ID, restored.ID)
+	assert.Equal(t, task.Subject, restored.Subject)
+	assert.Equal(t, task.Payload, restored.Payload)
+	assert.True(t, task.RunAfter.Equal(restored.RunAfter))
+	assert.Equal(t, task.MaxAttempts, restored.MaxAttempts)
+	assert.Equal(t, task.Timeout, restored.Timeout)
+	assert.Equal(t, task.Priority, restored.Priority)
+	assert.Equal(t, task.DedupKey, restored.DedupKey)
+	// The debounce flag isn't a column of the task, it travels next to it
+	assert.True(t, restored.debounce)
+}
+
+func TestJetStreamOutbox_RelayOff(t *testing.T) {
+	q := &JetStream{}
+	err := q.Outbox(nil).Publish(context.Background(), newTasks("bets.payout"))
+	assert.ErrorIs(t, err, errOutboxRelayOff)
+}
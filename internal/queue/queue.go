@@ -19,47 +19,57 @@ type Handler func(ctx context.Context, data []byte) error
 
 const (
 	defaultBatchSize  = 10
-	defaultPollPeriod = 100 * time.Millisecond
 	defaultMaxWorkers = 10
 )
 
+// Queue is the Postgres backend of the task queue, workers poll the tasks table.
 type Queue struct {
-	sem        utils.ISemaphore
-	db         *gorm.DB
-	handlers   map[string]Handler
-	wg         sync.WaitGroup
-	batchSize  int
-	pollPeriod time.Duration
-	maxWorkers int
+	sem       utils.ISemaphore
+	db        *gorm.DB
+	wakeup    chan struct{}
+	listenDSN string
+	registry
+	wg           sync.WaitGroup
+	batchSize    int
+	pollPeriod   time.Duration
+	retryBackoff time.Duration
+	maxWorkers   int
 }
 
-func New(db *gorm.DB, maxWorkers int) *Queue {
+func New(db *gorm.DB, maxWorkers int, opts ...Opt) *Queue {
 	if maxWorkers <= 0 {
 		maxWorkers = defaultMaxWorkers
 	}
+	o := newOptions(opts)
 	sem, _ := utils.NewSemaphore(maxWorkers)
 	return &Queue{
-		db:         db,
-		handlers:   make(map[string]Handler),
-		batchSize:  defaultBatchSize,
-		pollPeriod: defaultPollPeriod,
-		maxWorkers: maxWorkers,
-		sem:        sem,
+		db:           db,
+		registry:     newRegistry(o.retention),
+		wakeup:       make(chan struct{}, 1),
+		listenDSN:    o.listenDSN,
+		batchSize:    defaultBatchSize,
+		pollPeriod:   o.pollPeriod,
+		retryBackoff: o.retryBackoff,
+		maxWorkers:   maxWorkers,
+		sem:          sem,
 	}
 }
 
-func (q *Queue) Register(subject string, handler Handler) {
-	const operationName = "queue::Register"
-	slog.Info("Registering task handler", "subject", subject, logger.OperationField, operationName)
-	q.handlers[subject] = handler
-}
-
+// Publish stores the tasks and notifies the listening pollers if any of them is due right away.
+// The weights of the subjects are added to the priorities of the tasks. A task with the dedup key
+// of a task waiting for its first attempt is skipped, or moves that task if it is debounced.
 func (q *Queue) Publish(ctx context.Context, tasks []Task) error {
-	return q.db.WithContext(ctx).Create(&tasks).Error
+	return q.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return q.Outbox(tx).Publish(ctx, tasks)
+	})
 }
 
 func (q *Queue) Start(ctx context.Context) {
 	const operationName = "queue::Start"
+	if q.listenDSN != "" {
+		q.wg.Add(1)
+		go q.listen(ctx)
+	}
 	q.wg.Add(1)
 	go q.pollTasks(ctx)
 	slog.InfoContext(ctx, "Task queue started", logger.OperationField, operationName)
@@ -91,36 +101,63 @@ func (q *Queue) pollTasks(ctx context.Context) {
 		case <-ctx.Done():
 			return
 		case <-ticker.C:
-			tasks, err := q.fetchBatch(ctx)
-			if err != nil {
-				if ctx.Err() == nil {
-					slog.WarnContext(ctx, "Failed to fetch tasks", "error", err)
-				}
-				continue
+		case <-q.wakeup:
+		}
+
+		tasks, more, err := q.fetchBatch(ctx)
+		if err != nil {
+			if ctx.Err() == nil {
+				slog.WarnContext(ctx, "Failed to fetch tasks", "error", err)
 			}
+			continue
+		}
+		// More tasks may be due, they are fetched without waiting for the next tick
+		if more {
+			q.wake()
+		}
 
-			for i := range tasks {
-				task := &tasks[i]
+		for i := range tasks {
+			task := &tasks[i]
 
-				if err := q.sem.Acquire(ctx); err != nil {
-					q.nack(context.Background(), task.ID, err)
-					return
+			if err := q.sem.Acquire(ctx); err != nil {
+				// The tasks that didn't get a worker give their slots back
+				for _, rest := range tasks[i:] {
+					q.release(rest.Subject)
+					q.nack(context.Background(), rest.ID, err)
 				}
-
-				q.wg.Add(1)
-				go q.processTask(ctx, task)
+				return
 			}
+
+			q.wg.Add(1)
+			go q.processTask(ctx, task)
 		}
 	}
 }
 
-func (q *Queue) fetchBatch(ctx context.Context) ([]Task, error) {
+// fetchBatch takes the due tasks by priority, skipping the subjects at their concurrency caps.
+// more reports a full batch, so the rest of the due tasks can be fetched right away.
+func (q *Queue) fetchBatch(ctx context.Context) ([]Task, bool, error) {
 	var tasks []Task
+	var more bool
 	err := q.db.Transaction(func(tx *gorm.DB) error {
-		var err error
-		tasks, err = gorm.G[Task](tx, clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		query := gorm.G[Task](tx, clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
 			Where("status = ?", TaskStatusPending).
-			Where("run_after <= ?", time.Now()).
+			Where("run_after <= ?", time.Now())
+		saturated := q.saturatedSubjects()
+		if len(saturated.subjects) > 0 {
+			query = query.Where("subject NOT IN ?", saturated.subjects)
+		}
+		for _, pattern := range saturated.patterns {
+			if len(pattern.exempt) == 0 {
+				query = query.Where("subject !~ ?", pattern.regexp)
+				continue
+			}
+			query = query.Where("(subject !~ ? OR subject IN ?)", pattern.regexp, pattern.exempt)
+		}
+
+		var err error
+		tasks, err = query.
+			Order("priority DESC").
 			Order("run_after ASC").
 			Limit(q.batchSize).
 			Find(ctx)
@@ -129,6 +166,10 @@ func (q *Queue) fetchBatch(ctx context.Context) ([]Task, error) {
 			return err
 		}
 
+		// Tasks over the caps are left pending, their rows are unlocked on commit.
+		// Only a batch reserved in full means more due tasks, the dropped ones would be fetched again.
+		tasks = q.reserve(tasks)
+		more = len(tasks) == q.batchSize
 		if len(tasks) == 0 {
 			return nil
 		}
@@ -140,22 +181,31 @@ func (q *Queue) fetchBatch(ctx context.Context) ([]Task, error) {
 			tasks[i].LastAttempt = now
 		}
 
-		return tx.Save(&tasks).Error
+		if err := tx.Save(&tasks).Error; err != nil {
+			for i := range tasks {
+				q.release(tasks[i].Subject)
+			}
+			return err
+		}
+		return nil
 	})
 
 	if errors.Is(err, gorm.ErrRecordNotFound) {
-		return nil, nil
+		return nil, false, nil
 	}
 
-	return tasks, err
+	return tasks, more, err
 }
 
 func (q *Queue) processTask(ctx context.Context, task *Task) {
 	defer q.wg.Done()
 	defer q.sem.Release()
+	defer q.release(task.Subject)
 
 	taskCtx := logger.WithLogValue(ctx, "task_id", task.ID.String())
 	taskCtx = logger.WithLogValue(taskCtx, "subject", task.Subject)
+	taskCtx = logger.WithLogValue(taskCtx, logger.CorrelationIDField, task.CorrelationID)
+	taskCtx, run := withRunningTask(taskCtx, task)
 
 	if ctx.Err() != nil {
 		q.nack(context.Background(), task.ID, ctx.Err())
@@ -178,21 +228,5 @@ func (q *Queue) processTask(ctx context.Context, task *Task) {
 		return
 	}
 
-	q.ack(taskCtx, task.ID)
-}
-
-func (q *Queue) findHandler(subject string) Handler {
-	// exact match first
-	if handler, ok := q.handlers[subject]; ok {
-		return handler
-	}
-
-	// wildcard matching
-	for pattern, handler := range q.handlers {
-		if matchSubject(subject, pattern) {
-			return handler
-		}
-	}
-
-	return nil
+	q.ack(taskCtx, task, run.takeFollowUps())
 }
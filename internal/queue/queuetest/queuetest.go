@@ -0,0 +1,413 @@
+// Package queuetest is the conformance suite of the task queue backends.
+package queuetest
+
+import (
+	"context"
+	"errors"
+	"microgame-bot/internal/core"
+	"microgame-bot/internal/queue"
+	"microgame-bot/internal/utils"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	// RetryBackoff is the backoff the backends under the suite must be created with.
+	RetryBackoff = 50 * time.Millisecond
+	waitFor      = 10 * time.Second
+	tick         = 20 * time.Millisecond
+	// quietPeriod is long enough for a task that must not run to be picked up by a backend.
+	quietPeriod = 1500 * time.Millisecond
+)
+
+var errHandler = errors.New("handler failed")
+
+// NewQueue creates an empty queue of the backend under test with RetryBackoff.
+type NewQueue func(t *testing.T) queue.IQueue
+
+// Crash settles the queued tasks of the queue behind its back, as a worker that acked them
+// and crashed before anything else would.
+type Crash func(t *testing.T, q queue.IQueue)
+
+// Run checks that the backend keeps the semantics of the task queue.
+func Run(t *testing.T, newQueue NewQueue, crash Crash) {
+	t.Run("HandlesPublishedTask", func(t *testing.T) { testHandlesPublishedTask(t, newQueue(t)) })
+	t.Run("DelaysTaskUntilRunAfter", func(t *testing.T) { testDelaysTaskUntilRunAfter(t, newQueue(t)) })
+	t.Run("MatchesWildcardSubjects", func(t *testing.T) { testMatchesWildcardSubjects(t, newQueue(t)) })
+	t.Run("RetriesFailedTask", func(t *testing.T) { testRetriesFailedTask(t, newQueue(t)) })
+	t.Run("FailsTaskAfterMaxAttempts", func(t *testing.T) { testFailsTaskAfterMaxAttempts(t, newQueue(t)) })
+	t.Run("FailsTaskOverTimeout", func(t *testing.T) { testFailsTaskOverTimeout(t, newQueue(t)) })
+	t.Run("RequeuesFailedTask", func(t *testing.T) { testRequeuesFailedTask(t, newQueue(t)) })
+	t.Run("DiscardsFailedTask", func(t *testing.T) { testDiscardsFailedTask(t, newQueue(t)) })
+	t.Run("SkipsDuplicateTask", func(t *testing.T) { testSkipsDuplicateTask(t, newQueue(t)) })
+	t.Run("PublishesSkippedContinuations", func(t *testing.T) { testPublishesSkippedContinuations(t, newQueue(t)) })
+	t.Run("RunsDuplicateOfRunningTask", func(t *testing.T) { testRunsDuplicateOfRunningTask(t, newQueue(t)) })
+	t.Run("FreesDedupKeyAfterCrash", func(t *testing.T) { testFreesDedupKeyAfterCrash(t, newQueue(t), crash) })
+	t.Run("PublishesFollowUpsOnSuccess", func(t *testing.T) { testPublishesFollowUpsOnSuccess(t, newQueue(t)) })
+	t.Run("RunsContinuations", func(t *testing.T) { testRunsContinuations(t, newQueue(t)) })
+	t.Run("JoinsGroup", func(t *testing.T) { testJoinsGroup(t, newQueue(t)) })
+	t.Run("TracesLineage", func(t *testing.T) { testTracesLineage(t, newQueue(t)) })
+}
+
+// start runs the queue until the end of the test, the handlers must be registered before.
+func start(t *testing.T, q queue.IQueue) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	q.Start(ctx)
+	t.Cleanup(func() {
+		cancel()
+		stopCtx, stopCancel := context.WithTimeout(context.Background(), waitFor)
+		defer stopCancel()
+		assert.NoError(t, q.Stop(stopCtx))
+	})
+}
+
+func publish(t *testing.T, q queue.IQueue, tasks ...queue.Task) {
+	t.Helper()
+	require.NoError(t, q.Publish(t.Context(), tasks))
+}
+
+func failedTask(t *testing.T, q queue.IQueue, task queue.Task) queue.Task {
+	t.Helper()
+	var failed queue.Task
+	require.EventuallyWithT(t, func(c *assert.CollectT) {
+		var err error
+		failed, err = q.FailedTask(t.Context(), task.ID)
+		assert.NoError(c, err)
+	}, waitFor, tick)
+	return failed
+}
+
+// recorder collects the payloads the handler was called with.
+type recorder struct {
+	calls    []string
+	mu       sync.Mutex
+	failures int
+}
+
+func (r *recorder) handler(_ context.Context, data []byte) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, string(data))
+	if len(r.calls) <= r.failures {
+		return errHandler
+	}
+	return nil
+}
+
+func (r *recorder) Calls() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.calls...)
+}
+
+func testHandlesPublishedTask(t *testing.T, q queue.IQueue) {
+	var r recorder
+	q.Register("suite.echo", r.handler)
+	start(t, q)
+
+	publish(t, q, queue.NewTask("suite.echo", []byte(`"echo"`), time.Now(), 1, time.Second))
+	require.Eventually(t, func() bool { return len(r.Calls()) == 1 }, waitFor, tick)
+	assert.Equal(t, []string{`"echo"`}, r.Calls())
+}
+
+func testDelaysTaskUntilRunAfter(t *testing.T, q queue.IQueue) {
+	var handledAt atomic.Pointer[time.Time]
+	q.Register("suite.delayed", func(context.Context, []byte) error {
+		now := time.Now()
+		handledAt.Store(&now)
+		return nil
+	})
+	start(t, q)
+
+	runAfter := time.Now().Add(time.Second)
+	publish(t, q, queue.NewTask("suite.delayed", queue.EmptyPayload, runAfter, 1, time.Second))
+	require.Eventually(t, func() bool { return handledAt.Load() != nil }, waitFor, tick)
+	assert.False(t, handledAt.Load().Before(runAfter))
+}
+
+func testMatchesWildcardSubjects(t *testing.T, q queue.IQueue) {
+	var one, tail recorder
+	q.Register("suite.games.*", one.handler)
+	q.Register("suite.bets.>", tail.handler)
+	start(t, q)
+
+	publish(t, q,
+		queue.NewTask("suite.games.afk", []byte(`"afk"`), time.Now(), 1, time.Second),
+		queue.NewTask("suite.bets.payout.settle", []byte(`"settle"`), time.Now(), 1, time.Second),
+	)
+	require.Eventually(t, func() bool { return len(one.Calls()) == 1 && len(tail.Calls()) == 1 }, waitFor, tick)
+	assert.Equal(t, []string{`"afk"`}, one.Calls())
+	assert.Equal(t, []string{`"settle"`}, tail.Calls())
+}
+
+func testRetriesFailedTask(t *testing.T, q queue.IQueue) {
+	r := recorder{failures: 1}
+	q.Register("suite.retry", r.handler)
+	start(t, q)
+
+	task := queue.NewTask("suite.retry", queue.EmptyPayload, time.Now(), 3, time.Second)
+	publish(t, q, task)
+	require.Eventually(t, func() bool { return len(r.Calls()) == 2 }, waitFor, tick)
+
+	time.Sleep(quietPeriod)
+	assert.Len(t, r.Calls(), 2)
+	_, err := q.FailedTask(t.Context(), task.ID)
+	assert.ErrorIs(t, err, queue.ErrTaskNotFound)
+}
+
+func testFailsTaskAfterMaxAttempts(t *testing.T, q queue.IQueue) {
+	r := recorder{failures: 10}
+	q.Register("suite.failing", r.handler)
+	start(t, q)
+
+	task := queue.NewTask("suite.failing", []byte(`"lost"`), time.Now(), 2, time.Second)
+	publish(t, q, task)
+	failed := failedTask(t, q, task)
+
+	assert.Equal(t, queue.TaskStatusFailed, failed.Status)
+	assert.Equal(t, 2, failed.Attempts)
+	assert.JSONEq(t, `"lost"`, string(failed.Payload))
+	require.Len(t, failed.Errors, 2)
+	assert.Equal(t, errHandler.Error(), failed.LastError)
+
+	time.Sleep(quietPeriod)
+	assert.Len(t, r.Calls(), 2)
+
+	tasks, err := q.FailedTasks(t.Context(), queue.DeadLetterFilter{Subject: "suite.failing"})
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, task.ID, tasks[0].ID)
+}
+
+func testFailsTaskOverTimeout(t *testing.T, q queue.IQueue) {
+	q.Register("suite.slow", func(ctx context.Context, _ []byte) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	start(t, q)
+
+	task := queue.NewTask("suite.slow", queue.EmptyPayload, time.Now(), 1, 100*time.Millisecond)
+	publish(t, q, task)
+	failed := failedTask(t, q, task)
+	assert.Equal(t, context.DeadlineExceeded.Error(), failed.LastError)
+}
+
+func testRequeuesFailedTask(t *testing.T, q queue.IQueue) {
+	r := recorder{failures: 1}
+	q.Register("suite.requeue", r.handler)
+	start(t, q)
+
+	task := queue.NewTask("suite.requeue", queue.EmptyPayload, time.Now(), 1, time.Second)
+	publish(t, q, task)
+	failedTask(t, q, task)
+
+	_, err := q.RequeueFailed(t.Context(), queue.DeadLetterFilter{})
+	require.ErrorIs(t, err, queue.ErrEmptyFilter)
+
+	requeued, err := q.RequeueFailed(t.Context(), queue.DeadLetterFilter{Subject: "suite.requeue"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), requeued)
+	require.Eventually(t, func() bool { return len(r.Calls()) == 2 }, waitFor, tick)
+
+	_, err = q.FailedTask(t.Context(), task.ID)
+	assert.ErrorIs(t, err, queue.ErrTaskNotFound)
+}
+
+func testDiscardsFailedTask(t *testing.T, q queue.IQueue) {
+	r := recorder{failures: 1}
+	q.Register("suite.discard", r.handler)
+	start(t, q)
+
+	task := queue.NewTask("suite.discard", queue.EmptyPayload, time.Now(), 1, time.Second)
+	publish(t, q, task)
+	failedTask(t, q, task)
+
+	discarded, err := q.DiscardFailed(t.Context(), queue.DeadLetterFilter{IDs: []utils.UniqueID{task.ID}})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), discarded)
+
+	_, err = q.FailedTask(t.Context(), task.ID)
+	assert.ErrorIs(t, err, queue.ErrTaskNotFound)
+}
+
+func testSkipsDuplicateTask(t *testing.T, q queue.IQueue) {
+	var r recorder
+	q.Register("suite.unique", r.handler)
+	start(t, q)
+
+	// The first task is still pending when the duplicate is published
+	runAfter := time.Now().Add(500 * time.Millisecond)
+	task := queue.NewTask("suite.unique", []byte(`"first"`), runAfter, 1, time.Second).WithDedupKey("suite")
+	publish(t, q, task)
+	publish(t, q, queue.NewTask("suite.unique", []byte(`"second"`), runAfter, 1, time.Second).WithDedupKey("suite"))
+
+	require.Eventually(t, func() bool { return len(r.Calls()) == 1 }, waitFor, tick)
+	time.Sleep(quietPeriod)
+	assert.Equal(t, []string{`"first"`}, r.Calls())
+
+	// The key is free once the task has started
+	publish(t, q, queue.NewTask("suite.unique", []byte(`"third"`), time.Now(), 1, time.Second).WithDedupKey("suite"))
+	require.Eventually(t, func() bool { return len(r.Calls()) == 2 }, waitFor, tick)
+}
+
+func testPublishesSkippedContinuations(t *testing.T, q queue.IQueue) {
+	var unique, skipped recorder
+	q.Register("suite.unique", unique.handler)
+	q.Register("suite.skipped", skipped.handler)
+	start(t, q)
+
+	runAfter := time.Now().Add(500 * time.Millisecond)
+	task := func(payload string) queue.Task {
+		return queue.NewTask("suite.unique", []byte(payload), runAfter, 1, time.Second).
+			WithDedupKey("suite").
+			IfSkipped(queue.NewTask("suite.skipped", []byte(payload), time.Now(), 1, time.Second))
+	}
+	publish(t, q, task(`"first"`))
+	publish(t, q, task(`"second"`))
+
+	require.Eventually(t, func() bool { return len(unique.Calls()) == 1 && len(skipped.Calls()) == 1 }, waitFor, tick)
+	time.Sleep(quietPeriod)
+	assert.Equal(t, []string{`"first"`}, unique.Calls())
+	assert.Equal(t, []string{`"second"`}, skipped.Calls())
+}
+
+func testRunsDuplicateOfRunningTask(t *testing.T, q queue.IQueue) {
+	var r recorder
+	started := make(chan struct{})
+	unblock := make(chan struct{})
+	var once sync.Once
+	q.Register("suite.unique", func(ctx context.Context, data []byte) error {
+		once.Do(func() {
+			close(started)
+			<-unblock
+		})
+		return r.handler(ctx, data)
+	})
+	start(t, q)
+
+	publish(t, q, queue.NewTask("suite.unique", []byte(`"first"`), time.Now(), 1, 5*time.Second).WithDedupKey("suite"))
+	select {
+	case <-started:
+	case <-time.After(waitFor):
+		t.Fatal("the first task hasn't started")
+	}
+
+	// The running task may have read its input already, the duplicate runs after it
+	publish(t, q, queue.NewTask("suite.unique", []byte(`"second"`), time.Now(), 1, 5*time.Second).WithDedupKey("suite"))
+	close(unblock)
+	require.Eventually(t, func() bool { return len(r.Calls()) == 2 }, waitFor, tick)
+}
+
+func testFreesDedupKeyAfterCrash(t *testing.T, q queue.IQueue, crash Crash) {
+	var r recorder
+	q.Register("suite.unique", r.handler)
+
+	publish(t, q, queue.NewTask("suite.unique", []byte(`"first"`), time.Now().Add(time.Hour), 1, time.Second).
+		WithDedupKey("suite"))
+	crash(t, q)
+	start(t, q)
+
+	// The key of the task settled by the crashed worker doesn't skip the next one for good
+	publish(t, q, queue.NewTask("suite.unique", []byte(`"second"`), time.Now(), 1, time.Second).WithDedupKey("suite"))
+	require.Eventually(t, func() bool { return len(r.Calls()) == 1 }, waitFor, tick)
+	assert.Equal(t, []string{`"second"`}, r.Calls())
+}
+
+func testPublishesFollowUpsOnSuccess(t *testing.T, q queue.IQueue) {
+	var attempts atomic.Int32
+	var next recorder
+	q.Register("suite.flow.step", queue.Returning(func(context.Context, []byte) ([]queue.Task, error) {
+		followUp := queue.NewTask("suite.flow.next", []byte(`"next"`), time.Now(), 1, time.Second)
+		// The follow-up of the failed attempt is dropped
+		if attempts.Add(1) == 1 {
+			return []queue.Task{followUp}, errHandler
+		}
+		return []queue.Task{followUp}, nil
+	}))
+	q.Register("suite.flow.next", next.handler)
+	start(t, q)
+
+	assert.ErrorIs(t, queue.FollowUp(t.Context()), queue.ErrNoRunningTask)
+
+	publish(t, q, queue.NewTask("suite.flow.step", queue.EmptyPayload, time.Now(), 2, time.Second))
+	require.Eventually(t, func() bool { return len(next.Calls()) == 1 }, waitFor, tick)
+	time.Sleep(quietPeriod)
+	assert.Equal(t, []string{`"next"`}, next.Calls())
+	assert.Equal(t, int32(2), attempts.Load())
+}
+
+func testRunsContinuations(t *testing.T, q queue.IQueue) {
+	var ok, failing, done, recovered recorder
+	failing.failures = 10
+	q.Register("suite.cont.ok", ok.handler)
+	q.Register("suite.cont.failing", failing.handler)
+	q.Register("suite.cont.done", done.handler)
+	q.Register("suite.cont.recovered", recovered.handler)
+	start(t, q)
+
+	publish(t, q,
+		queue.NewTask("suite.cont.ok", queue.EmptyPayload, time.Now(), 1, time.Second).
+			Then(queue.NewTask("suite.cont.done", []byte(`"ok"`), time.Now(), 1, time.Second)).
+			OrElse(queue.NewTask("suite.cont.recovered", []byte(`"ok"`), time.Now(), 1, time.Second)),
+		queue.NewTask("suite.cont.failing", queue.EmptyPayload, time.Now(), 1, time.Second).
+			Then(queue.NewTask("suite.cont.done", []byte(`"failing"`), time.Now(), 1, time.Second)).
+			OrElse(queue.NewTask("suite.cont.recovered", []byte(`"failing"`), time.Now(), 1, time.Second)),
+	)
+	require.Eventually(t, func() bool { return len(done.Calls()) == 1 && len(recovered.Calls()) == 1 }, waitFor, tick)
+	time.Sleep(quietPeriod)
+	assert.Equal(t, []string{`"ok"`}, done.Calls())
+	assert.Equal(t, []string{`"failing"`}, recovered.Calls())
+}
+
+func testJoinsGroup(t *testing.T, q queue.IQueue) {
+	var parts, failing, done, recovered recorder
+	failing.failures = 10
+	q.Register("suite.group.part", parts.handler)
+	q.Register("suite.group.failing", failing.handler)
+	q.Register("suite.group.done", done.handler)
+	q.Register("suite.group.recovered", recovered.handler)
+	start(t, q)
+
+	part := func(subject string) queue.Task {
+		return queue.NewTask(subject, queue.EmptyPayload, time.Now(), 1, time.Second)
+	}
+	succeeding := queue.NewGroup(part("suite.group.part"), part("suite.group.part"), part("suite.group.part")).
+		Then(queue.NewTask("suite.group.done", []byte(`"all"`), time.Now(), 1, time.Second)).
+		OrElse(queue.NewTask("suite.group.recovered", []byte(`"all"`), time.Now(), 1, time.Second))
+	failed := queue.NewGroup(part("suite.group.part"), part("suite.group.failing")).
+		Then(queue.NewTask("suite.group.done", []byte(`"some"`), time.Now(), 1, time.Second)).
+		OrElse(queue.NewTask("suite.group.recovered", []byte(`"some"`), time.Now(), 1, time.Second))
+	publish(t, q, succeeding.Tasks()...)
+	publish(t, q, failed.Tasks()...)
+
+	require.Eventually(t, func() bool { return len(done.Calls()) == 1 && len(recovered.Calls()) == 1 }, waitFor, tick)
+	time.Sleep(quietPeriod)
+	assert.Len(t, parts.Calls(), 4)
+	assert.Equal(t, []string{`"all"`}, done.Calls())
+	assert.Equal(t, []string{`"some"`}, recovered.Calls())
+}
+
+func testTracesLineage(t *testing.T, q queue.IQueue) {
+	child := queue.NewTask("suite.lineage.child", queue.EmptyPayload, time.Now(), 1, time.Second)
+	q.Register("suite.lineage.root", func(ctx context.Context, _ []byte) error {
+		return queue.FollowUp(ctx, child)
+	})
+	q.Register("suite.lineage.child", func(context.Context, []byte) error { return errHandler })
+	start(t, q)
+
+	correlationID := uuid.New()
+	ctx := context.WithValue(t.Context(), core.ContextKeyCorrelationID, correlationID)
+	root := queue.NewTask("suite.lineage.root", queue.EmptyPayload, time.Now(), 1, time.Second)
+	require.NoError(t, q.Publish(ctx, []queue.Task{root}))
+
+	failed := failedTask(t, q, child)
+	assert.Equal(t, root.ID, failed.ParentID)
+	assert.Equal(t, correlationID.String(), failed.CorrelationID)
+}
@@ -0,0 +1,70 @@
+package queue
+
+import (
+	"log/slog"
+	"microgame-bot/internal/core/logger"
+	"sync"
+)
+
+// registry keeps the registered subjects of a queue backend with their limits and running tasks.
+type registry struct {
+	subjects      map[string]*subjectConfig
+	running       map[string]int
+	lastRetention RetentionRun
+	retention     RetentionPolicy
+	mu            sync.Mutex
+}
+
+func newRegistry(retention RetentionPolicy) registry {
+	return registry{
+		subjects:  make(map[string]*subjectConfig),
+		running:   make(map[string]int),
+		retention: retention,
+	}
+}
+
+// Register sets the handler of the subject, opts set its weight, concurrency cap and retention.
+func (r *registry) Register(subject string, handler Handler, opts ...SubjectOpt) {
+	const operationName = "queue::Register"
+	cfg := &subjectConfig{
+		pattern: subject,
+		handler: handler,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	slog.Info("Registering task handler",
+		"subject", subject,
+		"weight", cfg.weight,
+		"concurrency", cfg.concurrency,
+		logger.OperationField, operationName,
+	)
+	r.mu.Lock()
+	r.subjects[subject] = cfg
+	r.mu.Unlock()
+}
+
+func (r *registry) findHandler(subject string) Handler {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if cfg := r.findSubject(subject); cfg != nil {
+		return cfg.handler
+	}
+	return nil
+}
+
+func (r *registry) findSubject(subject string) *subjectConfig {
+	// exact match first
+	if cfg, ok := r.subjects[subject]; ok {
+		return cfg
+	}
+
+	// wildcard matching
+	for pattern, cfg := range r.subjects {
+		if matchSubject(subject, pattern) {
+			return cfg
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,264 @@
+package queue
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"microgame-bot/internal/core/logger"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const archivePartitionLayout = "2006_01"
+
+// RetentionPolicy tells how long the finished tasks of a subject are kept.
+// Zero age keeps the tasks of the status forever. Archive moves the expired tasks
+// to the partitioned task_archives table instead of deleting them.
+type RetentionPolicy struct {
+	Completed time.Duration
+	Failed    time.Duration
+	Archive   bool
+}
+
+func (p RetentionPolicy) String() string {
+	if p.Completed <= 0 && p.Failed <= 0 {
+		return "keep"
+	}
+	parts := make([]string, 0, 3) //nolint:mnd // Completed, failed and the archive flag.
+	if p.Completed > 0 {
+		parts = append(parts, "completed "+p.Completed.String())
+	}
+	if p.Failed > 0 {
+		parts = append(parts, "failed "+p.Failed.String())
+	}
+	if p.Archive {
+		parts = append(parts, "archive")
+	}
+	return strings.Join(parts, ", ")
+}
+
+// WithRetention sets the retention policy of the subject, it overrides the default one of the queue.
+func WithRetention(policy RetentionPolicy) SubjectOpt {
+	return func(c *subjectConfig) {
+		c.retention = &policy
+	}
+}
+
+// WithDefaultRetention sets the retention policy of the subjects without their own one.
+func WithDefaultRetention(policy RetentionPolicy) Opt {
+	return func(o *options) {
+		o.retention = policy
+	}
+}
+
+// RetentionRun is the result of the last retention run, it is reported by the health check.
+type RetentionRun struct {
+	At       time.Time                 `json:"at"`
+	Subjects map[string]RetentionCount `json:"subjects,omitempty"`
+	RetentionCount
+}
+
+// RetentionCount is the number of the tasks removed by the retention.
+type RetentionCount struct {
+	Deleted  int64 `json:"deleted"`
+	Archived int64 `json:"archived"`
+}
+
+func (c *RetentionCount) add(other RetentionCount) {
+	c.Deleted += other.Deleted
+	c.Archived += other.Archived
+}
+
+// LastRetention returns the result of the last retention run of this instance, zero if there was none.
+func (r *registry) LastRetention() RetentionRun {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.lastRetention
+}
+
+func (r *registry) setLastRetention(ctx context.Context, run RetentionRun, operationName string) {
+	r.mu.Lock()
+	r.lastRetention = run
+	r.mu.Unlock()
+
+	slog.InfoContext(ctx, "Task retention applied",
+		"deleted", run.Deleted,
+		"archived", run.Archived,
+		"subjects", len(run.Subjects),
+		logger.OperationField, operationName,
+	)
+}
+
+// ApplyRetention deletes or archives the finished tasks older than the retention policies of their subjects.
+func (q *Queue) ApplyRetention(ctx context.Context) (RetentionRun, error) {
+	const operationName = "queue::ApplyRetention"
+	now := time.Now()
+	run := RetentionRun{At: now, Subjects: make(map[string]RetentionCount)}
+
+	var subjects []string
+	err := q.db.WithContext(ctx).Model(&Task{}).
+		Where("status IN ?", []TaskStatus{TaskStatusCompleted, TaskStatusFailed}).
+		Distinct("subject").
+		Pluck("subject", &subjects).Error
+	if err != nil {
+		return RetentionRun{}, fmt.Errorf("failed to get subjects of finished tasks in %s: %w", operationName, err)
+	}
+
+	for _, subject := range subjects {
+		policy := q.retentionFor(subject)
+		var count RetentionCount
+		for status, age := range map[TaskStatus]time.Duration{
+			TaskStatusCompleted: policy.Completed,
+			TaskStatusFailed:    policy.Failed,
+		} {
+			if age <= 0 {
+				continue
+			}
+			removed, err := q.expire(ctx, subject, status, now.Add(-age), policy.Archive)
+			if err != nil {
+				return RetentionRun{}, fmt.Errorf("failed to expire %s tasks of %s in %s: %w",
+					status, subject, operationName, err)
+			}
+			count.add(removed)
+		}
+		if count.Deleted > 0 || count.Archived > 0 {
+			run.Subjects[subject] = count
+			run.add(count)
+		}
+	}
+
+	q.setLastRetention(ctx, run, operationName)
+	return run, nil
+}
+
+func (r *registry) retentionFor(subject string) RetentionPolicy {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if cfg := r.findSubject(subject); cfg != nil && cfg.retention != nil {
+		return *cfg.retention
+	}
+	return r.retention
+}
+
+// retentionBatchSize caps the tasks removed by one statement, so the retention doesn't lock
+// all the expired tasks of a subject at once.
+const retentionBatchSize = 1000
+
+// archiveColumns are the columns of the tasks copied to the archive, all of them.
+const archiveColumns = `id, subject, status, payload, errors, last_error, attempts, max_attempts, priority, run_after,
+	last_attempt, timeout, dedup_key, created_at, updated_at, parent_id, correlation_id, group_id, on_success, on_failure`
+
+// expiredTasks picks a batch of the tasks of the subject and status finished before the cutoff.
+const expiredTasks = `ctid IN (
+	SELECT ctid FROM tasks WHERE subject = ? AND status = ? AND updated_at < ? LIMIT ?
+)`
+
+// expire removes the tasks of the subject and status finished before the cutoff.
+func (q *Queue) expire(
+	ctx context.Context,
+	subject string,
+	status TaskStatus,
+	cutoff time.Time,
+	archive bool,
+) (RetentionCount, error) {
+	db := q.db.WithContext(ctx)
+	if !archive {
+		deleted, err := inBatches(func() *gorm.DB {
+			return db.Exec("DELETE FROM tasks WHERE "+expiredTasks, subject, status, cutoff, retentionBatchSize)
+		})
+		return RetentionCount{Deleted: deleted}, err
+	}
+
+	var months []time.Time
+	err := db.Model(&Task{}).
+		Select("DISTINCT date_trunc('month', updated_at AT TIME ZONE 'UTC')").
+		Where("subject = ? AND status = ? AND updated_at < ?", subject, status, cutoff).
+		Scan(&months).Error
+	if err != nil {
+		return RetentionCount{}, err
+	}
+	for _, month := range months {
+		if err := createArchivePartition(db, month); err != nil {
+			return RetentionCount{}, err
+		}
+	}
+
+	archived, err := inBatches(func() *gorm.DB {
+		return db.Exec(`
+			WITH moved AS (
+				DELETE FROM tasks WHERE `+expiredTasks+`
+				RETURNING `+archiveColumns+`
+			)
+			INSERT INTO task_archives (`+archiveColumns+`, archived_at)
+			SELECT `+archiveColumns+`, NOW()
+			FROM moved`,
+			subject, status, cutoff, retentionBatchSize,
+		)
+	})
+	return RetentionCount{Archived: archived}, err
+}
+
+// inBatches runs the statement of a batch until a batch comes out short, returns the number of the affected rows.
+func inBatches(batch func() *gorm.DB) (int64, error) {
+	var total int64
+	for {
+		result := batch()
+		if result.Error != nil {
+			return total, result.Error
+		}
+		total += result.RowsAffected
+		if result.RowsAffected < retentionBatchSize {
+			return total, nil
+		}
+	}
+}
+
+// MigrateArchive creates the archive of the expired tasks, partitioned by month of their finish.
+// AutoMigrate can't create partitioned tables, so the table is created by hand.
+func MigrateArchive(db *gorm.DB) error {
+	err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS task_archives (
+			id uuid NOT NULL,
+			subject varchar(255) NOT NULL,
+			status text NOT NULL,
+			payload jsonb NOT NULL,
+			errors jsonb,
+			last_error text,
+			attempts bigint NOT NULL,
+			max_attempts bigint NOT NULL,
+			priority bigint NOT NULL,
+			run_after timestamptz NOT NULL,
+			created_at timestamptz NOT NULL,
+			updated_at timestamptz NOT NULL,
+			archived_at timestamptz NOT NULL,
+			PRIMARY KEY (id, updated_at)
+		) PARTITION BY RANGE (updated_at)`).Error
+	if err != nil {
+		return err
+	}
+	// The lineage, dedup keys, groups and continuations of the tasks came after the archive
+	return db.Exec(`
+		ALTER TABLE task_archives
+			ADD COLUMN IF NOT EXISTS parent_id uuid,
+			ADD COLUMN IF NOT EXISTS correlation_id varchar(64) NOT NULL DEFAULT '',
+			ADD COLUMN IF NOT EXISTS last_attempt timestamptz,
+			ADD COLUMN IF NOT EXISTS timeout bigint NOT NULL DEFAULT 0,
+			ADD COLUMN IF NOT EXISTS dedup_key varchar(255) NOT NULL DEFAULT '',
+			ADD COLUMN IF NOT EXISTS group_id uuid,
+			ADD COLUMN IF NOT EXISTS on_success jsonb,
+			ADD COLUMN IF NOT EXISTS on_failure jsonb`).Error
+}
+
+// createArchivePartition creates the partition of the archive for the month if it is missing.
+func createArchivePartition(tx *gorm.DB, month time.Time) error {
+	from := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+	return tx.Exec(fmt.Sprintf(
+		"CREATE TABLE IF NOT EXISTS task_archives_%s PARTITION OF task_archives FOR VALUES FROM ('%s') TO ('%s')",
+		from.Format(archivePartitionLayout),
+		from.Format(time.RFC3339),
+		to.Format(time.RFC3339),
+	)).Error
+}
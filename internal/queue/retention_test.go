@@ -0,0 +1,43 @@
+package queue
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueue_RetentionFor(t *testing.T) {
+	defaultPolicy := RetentionPolicy{Completed: 7 * 24 * time.Hour, Archive: true}
+	shortPolicy := RetentionPolicy{Completed: time.Hour}
+
+	q := New(nil, 10, WithDefaultRetention(defaultPolicy))
+	q.Register("bets.payout", noopHandler, WithRetention(shortPolicy))
+	q.Register("games.*", noopHandler, WithRetention(RetentionPolicy{}))
+	q.Register("profile.load", noopHandler)
+
+	assert.Equal(t, shortPolicy, q.retentionFor("bets.payout"))
+	assert.Equal(t, RetentionPolicy{}, q.retentionFor("games.afk"))
+	assert.Equal(t, defaultPolicy, q.retentionFor("profile.load"))
+	// Subjects of the tasks left from removed handlers fall back to the default
+	assert.Equal(t, defaultPolicy, q.retentionFor("duels.timeout"))
+}
+
+func TestRetentionPolicy_String(t *testing.T) {
+	assert.Equal(t, "keep", RetentionPolicy{Archive: true}.String())
+	assert.Equal(t, "completed 1h0m0s", RetentionPolicy{Completed: time.Hour}.String())
+	assert.Equal(t,
+		"completed 24h0m0s, failed 168h0m0s, archive",
+		RetentionPolicy{Completed: 24 * time.Hour, Failed: 7 * 24 * time.Hour, Archive: true}.String(),
+	)
+}
+
+func TestQueue_SubjectStatsRetention(t *testing.T) {
+	q := New(nil, 10, WithDefaultRetention(RetentionPolicy{Failed: time.Hour}))
+	q.Register("bets.payout", noopHandler, WithRetention(RetentionPolicy{Completed: time.Hour}))
+	q.Register("profile.load", noopHandler)
+
+	stats := q.SubjectStats()
+	assert.Equal(t, "completed 1h0m0s", stats[0].Retention)
+	assert.Equal(t, "failed 1h0m0s", stats[1].Retention)
+}
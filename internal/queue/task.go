@@ -7,6 +7,7 @@ import (
 	"reflect"
 	"time"
 
+	"gorm.io/gorm"
 	"gorm.io/gorm/schema"
 )
 
@@ -68,18 +69,49 @@ const (
 )
 
 type Task struct {
-	RunAfter    time.Time        `gorm:"not null;index:idx_tasks_pending,priority:3,where:status = 'pending'"`
+	RunAfter    time.Time        `gorm:"not null;index:idx_tasks_due,priority:2,where:status = 'pending'"`
 	LastAttempt time.Time        `gorm:"index:idx_tasks_stuck,where:status = 'running'"`
 	CreatedAt   time.Time        `gorm:"not null"`
 	UpdatedAt   time.Time        `gorm:"not null"`
-	Subject     string           `gorm:"not null;size:255;index:idx_tasks_pending,priority:1,where:status = 'pending'"`
-	Status      TaskStatus       `gorm:"not null;default:pending;index:idx_tasks_pending,priority:2,where:status = 'pending';index:idx_tasks_stuck,where:status = 'running'"`
+	Subject     string           `gorm:"not null;size:255;index:idx_tasks_due,priority:3,where:status = 'pending'"`
+	DedupKey    string           `gorm:"not null;default:'';size:255;uniqueIndex:idx_tasks_dedup_pending,where:dedup_key <> '' AND status = 'pending' AND attempts = 0"`
+	Status      TaskStatus       `gorm:"not null;default:pending;index:idx_tasks_stuck,where:status = 'running'"`
 	LastError   string           `gorm:"default:''"`
 	Payload     []byte           `gorm:"not null;type:jsonb"`
+	Errors      []TaskAttempt    `gorm:"type:jsonb;serializer:json"`
 	MaxAttempts int              `gorm:"not null;default:3"`
+	Priority    int              `gorm:"not null;default:0;index:idx_tasks_due,priority:1,sort:desc,where:status = 'pending'"`
 	Attempts    int              `gorm:"not null;default:0"`
 	Timeout     ExecutionTimeout `gorm:"not null;type:bigint;serializer:gorm"`
 	ID          utils.UniqueID   `gorm:"primaryKey;type:uuid"`
+	// ParentID is the task whose handler or continuation published the task, zero for a root task.
+	ParentID utils.UniqueID `gorm:"type:uuid;index"`
+	// CorrelationID is shared by the tasks of one update or root task, see withLineage.
+	CorrelationID string `gorm:"not null;default:'';size:64;index"`
+	// GroupID is the group the task joins when it is finished, zero outside a group.
+	GroupID utils.UniqueID `gorm:"type:uuid"`
+	// OnSuccess and OnFailure are published once the task completes or runs out of attempts.
+	OnSuccess []Task `gorm:"type:jsonb;serializer:json"`
+	OnFailure []Task `gorm:"type:jsonb;serializer:json"`
+	// OnSkip is published instead of the task if it is skipped by its dedup key, it isn't stored with the task.
+	OnSkip []Task `gorm:"-"`
+	// Join creates the group of the task on Publish, it isn't stored with the task.
+	Join     *GroupJoin `gorm:"-"`
+	debounce bool
+}
+
+// staleIndexes are the indexes of the tasks replaced under new names, AutoMigrate doesn't change
+// the predicate or the columns of an existing index.
+var staleIndexes = []string{"idx_tasks_dedup", "idx_tasks_pending"}
+
+// DropStaleIndexes drops the replaced indexes of the tasks, AutoMigrate creates their successors.
+func DropStaleIndexes(db *gorm.DB) error {
+	for _, name := range staleIndexes {
+		if err := db.Exec("DROP INDEX IF EXISTS " + name).Error; err != nil {
+			return fmt.Errorf("failed to drop index %s: %w", name, err)
+		}
+	}
+	return nil
 }
 
 func NewTask(subject string, payload []byte, runAfter time.Time, maxAttempts int, timeout time.Duration) Task {
@@ -99,3 +131,10 @@ func NewTask(subject string, payload []byte, runAfter time.Time, maxAttempts int
 		Status:      TaskStatusPending,
 	}
 }
+
+// WithPriority returns the task with the priority set. Due tasks are fetched by priority, higher first,
+// the weight of the subject is added to it on Publish.
+func (t Task) WithPriority(priority int) Task {
+	t.Priority = priority
+	return t
+}
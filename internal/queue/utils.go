@@ -5,6 +5,42 @@ import (
 	"time"
 )
 
+const (
+	GameAFKSubject           = "games.afk"
+	GameClockSubject         = "games.clock"
+	TournamentAdvanceSubject = "tournaments.advance"
+	LeagueAdvanceSubject     = "leagues.advance"
+	LeagueRemindSubject      = "leagues.remind"
+	QuickPlayMatchSubject    = "quickplay.match"
+	TriviaQuestionSubject    = "trivia.question"
+	RetentionSubject         = "queue.retention"
+)
+
+// PayoutTask is a payout of the waiting bets. A payout settles the bets committed before it starts,
+// so it is skipped while another one waits to start, but not while one is running.
+func PayoutTask() Task {
+	task := NewTask("bets.payout", EmptyPayload, time.Now(), 1, DefaultTimeout)
+	return task.WithDedupKey(UniqueKey(task.Subject, task.Payload))
+}
+
+// PublishPayoutTask schedules a payout of the waiting bets, see PayoutTask.
 func PublishPayoutTask(ctx context.Context, publisher IQueuePublisher) error {
-	return publisher.Publish(ctx, []Task{NewTask("bets.payout", EmptyPayload, time.Now(), 1, DefaultTimeout)})
+	return publisher.Publish(ctx, []Task{PayoutTask()})
+}
+
+// PublishGameAFKTask schedules a check that expires the game at runAfter
+// if nobody has moved since the task was published.
+func PublishGameAFKTask(ctx context.Context, publisher IQueuePublisher, payload []byte, runAfter time.Time) error {
+	return publisher.Publish(ctx, []Task{NewTask(GameAFKSubject, payload, runAfter, DefaultMaxAttempts, DefaultTimeout)})
+}
+
+// PublishGameClockTask schedules a redraw of the countdown shown on the game board.
+func PublishGameClockTask(ctx context.Context, publisher IQueuePublisher, payload []byte, runAfter time.Time) error {
+	return publisher.Publish(ctx, []Task{NewTask(GameClockSubject, payload, runAfter, 1, DefaultTimeout)})
+}
+
+// PublishTriviaQuestionTask schedules closing of the trivia question at runAfter
+// unless it has been answered or closed by then.
+func PublishTriviaQuestionTask(ctx context.Context, publisher IQueuePublisher, payload []byte, runAfter time.Time) error {
+	return publisher.Publish(ctx, []Task{NewTask(TriviaQuestionSubject, payload, runAfter, DefaultMaxAttempts, DefaultTimeout)})
 }
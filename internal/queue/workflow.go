@@ -0,0 +1,373 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"microgame-bot/internal/core"
+	"microgame-bot/internal/utils"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nats-io/nats.go/jetstream"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var ErrNoRunningTask = errors.New("no running task in context")
+
+type runningTaskKey struct{}
+
+// runningTask is the task being handled, it collects the follow-ups of its handler.
+type runningTask struct {
+	task      *Task
+	followUps []Task
+	mu        sync.Mutex
+}
+
+// withRunningTask marks ctx as the context of the task handler, tasks published within it
+// become the children of the task.
+func withRunningTask(ctx context.Context, task *Task) (context.Context, *runningTask) {
+	run := &runningTask{task: task}
+	return context.WithValue(ctx, runningTaskKey{}, run), run
+}
+
+func runningTaskFrom(ctx context.Context) *runningTask {
+	run, _ := ctx.Value(runningTaskKey{}).(*runningTask)
+	return run
+}
+
+func (r *runningTask) takeFollowUps() []Task {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	followUps := r.followUps
+	r.followUps = nil
+	return followUps
+}
+
+// FollowUp publishes the tasks once the running task succeeds, a failed attempt drops them.
+// The Postgres backend publishes them in the transaction that completes the task.
+func FollowUp(ctx context.Context, tasks ...Task) error {
+	run := runningTaskFrom(ctx)
+	if run == nil {
+		return ErrNoRunningTask
+	}
+	run.mu.Lock()
+	defer run.mu.Unlock()
+	run.followUps = append(run.followUps, tasks...)
+	return nil
+}
+
+// Returning adapts a handler that returns the follow-up tasks of a successful run.
+func Returning(fn func(ctx context.Context, data []byte) ([]Task, error)) Handler {
+	return func(ctx context.Context, data []byte) error {
+		tasks, err := fn(ctx, data)
+		if err != nil {
+			return err
+		}
+		return FollowUp(ctx, tasks...)
+	}
+}
+
+// Then returns the task with the tasks to publish once it completes.
+func (t Task) Then(next ...Task) Task {
+	t.OnSuccess = append(t.OnSuccess[:len(t.OnSuccess):len(t.OnSuccess)], next...)
+	return t
+}
+
+// OrElse returns the task with the tasks to publish once it runs out of attempts.
+func (t Task) OrElse(next ...Task) Task {
+	t.OnFailure = append(t.OnFailure[:len(t.OnFailure):len(t.OnFailure)], next...)
+	return t
+}
+
+// IfSkipped returns the task with the tasks to publish instead of it if it is skipped by its dedup key.
+// A debounced task isn't skipped, it moves the pending one.
+func (t Task) IfSkipped(next ...Task) Task {
+	t.OnSkip = append(t.OnSkip[:len(t.OnSkip):len(t.OnSkip)], next...)
+	return t
+}
+
+// continuations returns the tasks to publish for the finished task by its status.
+func (t Task) continuations() []Task {
+	switch t.Status {
+	case TaskStatusCompleted:
+		return t.OnSuccess
+	case TaskStatusFailed:
+		return t.OnFailure
+	default:
+		return nil
+	}
+}
+
+// instances copies the continuations with new IDs, a task finished again after a requeue
+// publishes its continuations again.
+func instances(tasks []Task) []Task {
+	copied := make([]Task, len(tasks))
+	for i, task := range tasks {
+		task.ID = utils.NewUniqueID()
+		copied[i] = task
+	}
+	return copied
+}
+
+// withLineage sets the parent of the tasks published by a task handler and the correlation ID.
+// The correlation ID is inherited from the running task or taken from the update of mdw.CorrelationIDProvider,
+// a task without either starts its own correlation.
+func withLineage(ctx context.Context, tasks []Task) []Task {
+	var parentID utils.UniqueID
+	var correlationID string
+	if run := runningTaskFrom(ctx); run != nil {
+		parentID = run.task.ID
+		correlationID = run.task.CorrelationID
+	}
+	if id, ok := ctx.Value(core.ContextKeyCorrelationID).(uuid.UUID); ok && correlationID == "" {
+		correlationID = id.String()
+	}
+
+	for i := range tasks {
+		if tasks[i].ParentID.IsZero() {
+			tasks[i].ParentID = parentID
+		}
+		if tasks[i].CorrelationID == "" {
+			tasks[i].CorrelationID = correlationID
+		}
+		if tasks[i].CorrelationID == "" {
+			tasks[i].CorrelationID = tasks[i].ID.String()
+		}
+	}
+	return tasks
+}
+
+// GroupJoin is the fan-in of a group, the first task of the group carries it to Publish.
+type GroupJoin struct {
+	OnSuccess []Task
+	OnFailure []Task
+	Size      int
+}
+
+// Group is a fan-out of tasks with a fan-in. Once every task of the group is finished, the on-success
+// continuations are published if all of them completed, the on-failure ones if any ran out of attempts.
+type Group struct {
+	tasks []Task
+	join  GroupJoin
+	id    utils.UniqueID
+}
+
+func NewGroup(tasks ...Task) Group {
+	return Group{tasks: tasks, id: utils.NewUniqueID()}
+}
+
+func (g Group) ID() utils.UniqueID {
+	return g.id
+}
+
+// Then returns the group with the tasks to publish once all of its tasks complete.
+func (g Group) Then(next ...Task) Group {
+	g.join.OnSuccess = append(g.join.OnSuccess[:len(g.join.OnSuccess):len(g.join.OnSuccess)], next...)
+	return g
+}
+
+// OrElse returns the group with the tasks to publish once all of its tasks are finished and any of them failed.
+func (g Group) OrElse(next ...Task) Group {
+	g.join.OnFailure = append(g.join.OnFailure[:len(g.join.OnFailure):len(g.join.OnFailure)], next...)
+	return g
+}
+
+// Tasks returns the tasks of the group, they must be published in one call. Dedup keys are dropped,
+// a skipped task would never join the group. An empty group is its on-success continuations.
+func (g Group) Tasks() []Task {
+	if len(g.tasks) == 0 {
+		return g.join.OnSuccess
+	}
+	tasks := make([]Task, len(g.tasks))
+	for i, task := range g.tasks {
+		task.GroupID = g.id
+		task.DedupKey = ""
+		task.debounce = false
+		tasks[i] = task
+	}
+	join := g.join
+	join.Size = len(tasks)
+	tasks[0].Join = &join
+	return tasks
+}
+
+// TaskGroup is the fan-in of a group published to the Postgres backend, it is deleted once the group is finished.
+type TaskGroup struct {
+	CreatedAt time.Time      `gorm:"not null"`
+	OnSuccess []Task         `gorm:"type:jsonb;serializer:json"`
+	OnFailure []Task         `gorm:"type:jsonb;serializer:json"`
+	Pending   int            `gorm:"not null"`
+	Failed    int            `gorm:"not null;default:0"`
+	ID        utils.UniqueID `gorm:"primaryKey;type:uuid"`
+}
+
+func newTaskGroup(task Task, now time.Time) TaskGroup {
+	return TaskGroup{
+		ID:        task.GroupID,
+		CreatedAt: now,
+		OnSuccess: task.Join.OnSuccess,
+		OnFailure: task.Join.OnFailure,
+		Pending:   task.Join.Size,
+	}
+}
+
+// next returns the continuations of the finished group.
+func (g TaskGroup) next() []Task {
+	if g.Failed > 0 {
+		return g.OnFailure
+	}
+	return g.OnSuccess
+}
+
+// createGroups stores the fan-ins of the groups whose first task is published.
+func createGroups(tx *gorm.DB, tasks []Task) error {
+	var groups []TaskGroup
+	now := time.Now()
+	for _, task := range tasks {
+		if task.Join != nil {
+			groups = append(groups, newTaskGroup(task, now))
+		}
+	}
+	if len(groups) == 0 {
+		return nil
+	}
+	return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&groups).Error
+}
+
+// finish publishes the continuations of the finished task through tx and joins its group.
+// ctx must hold the task as the running one, so the continuations become its children.
+func (q *Queue) finish(ctx context.Context, tx *gorm.DB, task Task, followUps []Task) error {
+	if task.Status != TaskStatusCompleted && task.Status != TaskStatusFailed {
+		return nil
+	}
+	next := append(followUps, instances(task.continuations())...)
+	if !task.GroupID.IsZero() {
+		joined, err := joinGroup(tx, task)
+		if err != nil {
+			return err
+		}
+		next = append(next, instances(joined)...)
+	}
+	if len(next) == 0 {
+		return nil
+	}
+	return q.Outbox(tx).Publish(ctx, next)
+}
+
+// joinGroup counts the finished task in its group, the continuations are returned by the last task of the group.
+func joinGroup(tx *gorm.DB, task Task) ([]Task, error) {
+	failed := 0
+	if task.Status == TaskStatusFailed {
+		failed = 1
+	}
+	var group TaskGroup
+	result := tx.Model(&group).
+		Clauses(clause.Returning{}).
+		Where("id = ? AND pending > 0", task.GroupID).
+		Updates(map[string]any{
+			"pending": gorm.Expr("pending - 1"),
+			"failed":  gorm.Expr("failed + ?", failed),
+		})
+	// A requeued task of a finished group has nothing to join
+	if result.Error != nil || result.RowsAffected == 0 || group.Pending > 0 {
+		return nil, result.Error
+	}
+	if err := tx.Delete(&group).Error; err != nil {
+		return nil, err
+	}
+	return group.next(), nil
+}
+
+// groupState is the fan-in of a group published to the JetStream backend. The finished tasks are listed,
+// so a redelivered task isn't counted twice.
+type groupState struct {
+	Join     GroupJoin
+	Finished []utils.UniqueID
+	Failed   int
+}
+
+func (g groupState) next() []Task {
+	if g.Failed > 0 {
+		return g.Join.OnFailure
+	}
+	return g.Join.OnSuccess
+}
+
+// createGroup stores the fan-in of the group, a relayed task may create it again.
+func (q *JetStream) createGroup(ctx context.Context, task Task) error {
+	data, err := json.Marshal(groupState{Join: *task.Join})
+	if err != nil {
+		return err
+	}
+	_, err = q.groups.Create(ctx, task.GroupID.String(), data)
+	if errors.Is(err, jetstream.ErrKeyExists) {
+		return nil
+	}
+	return err
+}
+
+// finish publishes the follow-ups and continuations of the finished task and joins its group.
+func (q *JetStream) finish(ctx context.Context, task Task, followUps []Task) error {
+	next := append(followUps, instances(task.continuations())...)
+	if len(next) > 0 {
+		if err := q.Publish(ctx, next); err != nil {
+			return err
+		}
+	}
+	if task.GroupID.IsZero() {
+		return nil
+	}
+	return q.joinGroup(ctx, task)
+}
+
+// joinGroup counts the finished task in its group, the last task of the group publishes the continuations.
+// Concurrent tasks of the group retry on the revision conflict.
+func (q *JetStream) joinGroup(ctx context.Context, task Task) error {
+	key := task.GroupID.String()
+	for {
+		entry, err := q.groups.Get(ctx, key)
+		// A requeued task of a finished group has nothing to join
+		if errors.Is(err, jetstream.ErrKeyNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		var state groupState
+		if err := json.Unmarshal(entry.Value(), &state); err != nil {
+			return err
+		}
+		if slices.Contains(state.Finished, task.ID) {
+			return nil
+		}
+		state.Finished = append(state.Finished, task.ID)
+		if task.Status == TaskStatusFailed {
+			state.Failed++
+		}
+
+		if len(state.Finished) >= state.Join.Size {
+			// The continuations go first, a failed delete only publishes them again on redelivery
+			if err := q.Publish(ctx, instances(state.next())); err != nil {
+				return err
+			}
+			err := q.groups.Delete(ctx, key, jetstream.LastRevision(entry.Revision()))
+			if errors.Is(err, jetstream.ErrKeyExists) {
+				return nil
+			}
+			return err
+		}
+
+		data, err := json.Marshal(state)
+		if err != nil {
+			return err
+		}
+		_, err = q.groups.Update(ctx, key, data, entry.Revision())
+		if !errors.Is(err, jetstream.ErrKeyExists) {
+			return err
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package queue
+
+import (
+	"context"
+	"microgame-bot/internal/core"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTask_ThenDoesNotShareContinuations(t *testing.T) {
+	base := NewTask("flow.step", EmptyPayload, time.Now(), 1, time.Second).
+		Then(NewTask("flow.a", EmptyPayload, time.Now(), 1, time.Second))
+	first := base.Then(NewTask("flow.b", EmptyPayload, time.Now(), 1, time.Second))
+	second := base.Then(NewTask("flow.c", EmptyPayload, time.Now(), 1, time.Second))
+
+	require.Len(t, first.OnSuccess, 2)
+	require.Len(t, second.OnSuccess, 2)
+	assert.Equal(t, "flow.b", first.OnSuccess[1].Subject)
+	assert.Equal(t, "flow.c", second.OnSuccess[1].Subject)
+}
+
+func TestTask_Continuations(t *testing.T) {
+	task := NewTask("flow.step", EmptyPayload, time.Now(), 1, time.Second).
+		Then(NewTask("flow.done", EmptyPayload, time.Now(), 1, time.Second)).
+		OrElse(NewTask("flow.recovered", EmptyPayload, time.Now(), 1, time.Second))
+
+	assert.Empty(t, task.continuations())
+
+	task.Status = TaskStatusCompleted
+	require.Len(t, task.continuations(), 1)
+	assert.Equal(t, "flow.done", task.continuations()[0].Subject)
+
+	task.Status = TaskStatusFailed
+	require.Len(t, task.continuations(), 1)
+	assert.Equal(t, "flow.recovered", task.continuations()[0].Subject)
+}
+
+func TestInstances_RenewIDs(t *testing.T) {
+	tasks := []Task{NewTask("flow.done", EmptyPayload, time.Now(), 1, time.Second)}
+	copied := instances(tasks)
+
+	require.Len(t, copied, 1)
+	assert.NotEqual(t, tasks[0].ID, copied[0].ID)
+	assert.Equal(t, tasks[0].Subject, copied[0].Subject)
+}
+
+func TestGroup_Tasks(t *testing.T) {
+	group := NewGroup(
+		NewTask("flow.part", EmptyPayload, time.Now(), 1, time.Second).WithDedupKey("part").WithDebounce(),
+		NewTask("flow.part", EmptyPayload, time.Now(), 1, time.Second),
+	).Then(NewTask("flow.done", EmptyPayload, time.Now(), 1, time.Second))
+
+	tasks := group.Tasks()
+	require.Len(t, tasks, 2)
+	for _, task := range tasks {
+		assert.Equal(t, group.ID(), task.GroupID)
+		assert.Empty(t, task.DedupKey)
+		assert.False(t, task.debounce)
+	}
+	require.NotNil(t, tasks[0].Join)
+	assert.Nil(t, tasks[1].Join)
+	assert.Equal(t, 2, tasks[0].Join.Size)
+	require.Len(t, tasks[0].Join.OnSuccess, 1)
+	assert.Equal(t, "flow.done", tasks[0].Join.OnSuccess[0].Subject)
+}
+
+func TestGroup_EmptyIsItsContinuations(t *testing.T) {
+	done := NewTask("flow.done", EmptyPayload, time.Now(), 1, time.Second)
+	assert.Equal(t, []Task{done}, NewGroup().Then(done).Tasks())
+}
+
+func TestTaskGroup_Next(t *testing.T) {
+	group := newTaskGroup(NewGroup(NewTask("flow.part", EmptyPayload, time.Now(), 1, time.Second)).
+		Then(NewTask("flow.done", EmptyPayload, time.Now(), 1, time.Second)).
+		OrElse(NewTask("flow.recovered", EmptyPayload, time.Now(), 1, time.Second)).
+		Tasks()[0], time.Now())
+
+	assert.Equal(t, 1, group.Pending)
+	assert.Equal(t, "flow.done", group.next()[0].Subject)
+	group.Failed = 1
+	assert.Equal(t, "flow.recovered", group.next()[0].Subject)
+}
+
+func TestWithLineage(t *testing.T) {
+	t.Run("root task starts its correlation", func(t *testing.T) {
+		tasks := withLineage(context.Background(), []Task{NewTask("flow.root", EmptyPayload, time.Now(), 1, time.Second)})
+		assert.True(t, tasks[0].ParentID.IsZero())
+		assert.Equal(t, tasks[0].ID.String(), tasks[0].CorrelationID)
+	})
+
+	t.Run("update correlation ID", func(t *testing.T) {
+		correlationID := uuid.New()
+		ctx := context.WithValue(context.Background(), core.ContextKeyCorrelationID, correlationID)
+		tasks := withLineage(ctx, []Task{NewTask("flow.root", EmptyPayload, time.Now(), 1, time.Second)})
+		assert.Equal(t, correlationID.String(), tasks[0].CorrelationID)
+	})
+
+	t.Run("running task is the parent", func(t *testing.T) {
+		parent := NewTask("flow.parent", EmptyPayload, time.Now(), 1, time.Second)
+		parent.CorrelationID = "update"
+		ctx, _ := withRunningTask(context.Background(), &parent)
+		ctx = context.WithValue(ctx, core.ContextKeyCorrelationID, uuid.New())
+
+		tasks := withLineage(ctx, []Task{NewTask("flow.child", EmptyPayload, time.Now(), 1, time.Second)})
+		assert.Equal(t, parent.ID, tasks[0].ParentID)
+		assert.Equal(t, "update", tasks[0].CorrelationID)
+	})
+}
+
+func TestFollowUp(t *testing.T) {
+	next := NewTask("flow.next", EmptyPayload, time.Now(), 1, time.Second)
+	require.ErrorIs(t, FollowUp(context.Background(), next), ErrNoRunningTask)
+
+	task := NewTask("flow.step", EmptyPayload, time.Now(), 1, time.Second)
+	ctx, run := withRunningTask(context.Background(), &task)
+	handler := Returning(func(context.Context, []byte) ([]Task, error) { return []Task{next}, nil })
+	require.NoError(t, handler(ctx, EmptyPayload))
+
+	assert.Equal(t, []Task{next}, run.takeFollowUps())
+	assert.Empty(t, run.takeFollowUps())
+}
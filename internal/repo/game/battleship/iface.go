@@ -0,0 +1,30 @@
+package battleship
+
+import (
+	"context"
+	"microgame-bot/internal/domain/battleship"
+	"microgame-bot/internal/domain/session"
+	"microgame-bot/internal/domain/user"
+)
+
+type IBattleshipGetter interface {
+	GameByID(ctx context.Context, id battleship.ID) (battleship.Battleship, error)
+	GameByIDLocked(ctx context.Context, id battleship.ID) (battleship.Battleship, error)
+	GamesByCreatorID(ctx context.Context, id user.ID) ([]battleship.Battleship, error)
+	GamesBySessionID(ctx context.Context, id session.ID) ([]battleship.Battleship, error)
+	GamesBySessionIDLocked(ctx context.Context, id session.ID) ([]battleship.Battleship, error)
+}
+
+type IBattleshipCreator interface {
+	CreateGame(ctx context.Context, game battleship.Battleship) (battleship.Battleship, error)
+}
+
+type IBattleshipUpdater interface {
+	UpdateGame(ctx context.Context, game battleship.Battleship) (battleship.Battleship, error)
+}
+
+type IBattleshipRepository interface {
+	IBattleshipCreator
+	IBattleshipUpdater
+	IBattleshipGetter
+}
@@ -0,0 +1,169 @@
+package battleship
+
+import (
+	"encoding/json"
+	"fmt"
+	bsD "microgame-bot/internal/domain/battleship"
+	"microgame-bot/internal/domain/user"
+	gM "microgame-bot/internal/repo/game"
+
+	"github.com/google/uuid"
+)
+
+type battleshipPlayers []battleshipPlayer
+
+type battleshipPlayer struct {
+	Number   int       `json:"number"`
+	ID       uuid.UUID `json:"id"`
+	IsWinner bool      `json:"is_winner"`
+	IsReady  bool      `json:"is_ready"`
+}
+
+// battleshipData keeps both fleets, they are never sent to the shared message.
+type battleshipData struct {
+	Boards   []battleshipBoard `json:"boards"`
+	Turn     uuid.UUID         `json:"turn"`
+	WinnerID uuid.UUID         `json:"winner"`
+}
+
+type battleshipBoard struct {
+	Number int              `json:"number"`
+	Ships  []battleshipShip `json:"ships"`
+	Shots  []battleshipCell `json:"shots"`
+}
+
+type battleshipShip struct {
+	Row         int             `json:"row"`
+	Col         int             `json:"col"`
+	Size        int             `json:"size"`
+	Orientation bsD.Orientation `json:"orientation"`
+}
+
+type battleshipCell struct {
+	Row int `json:"row"`
+	Col int `json:"col"`
+}
+
+func (Repository) FromDomain(gm gM.Game, dm bsD.Battleship) (gM.Game, error) {
+	const operationName = "repo::game::battleship::model::FromDomain"
+	players, err := json.Marshal(battleshipPlayers{
+		battleshipPlayerFromDomain(dm, dm.Player1ID(), 1),
+		//nolint:mnd // Player number is constant.
+		battleshipPlayerFromDomain(dm, dm.Player2ID(), 2),
+	})
+	if err != nil {
+		return gM.Game{}, fmt.Errorf("failed to marshal players in %s: %w", operationName, err)
+	}
+
+	data, err := json.Marshal(battleshipData{
+		Boards: []battleshipBoard{
+			battleshipBoardFromDomain(1, dm.Board1()),
+			//nolint:mnd // Player number is constant.
+			battleshipBoardFromDomain(2, dm.Board2()),
+		},
+		Turn:     dm.Turn().UUID(),
+		WinnerID: dm.WinnerID().UUID(),
+	})
+	if err != nil {
+		return gM.Game{}, fmt.Errorf("failed to marshal data in %s: %w", operationName, err)
+	}
+	gm = gm.SetCommonFields(dm)
+	gm.Players = players
+	gm.Data = data
+
+	return gm, nil
+}
+
+func (Repository) ToDomain(gm gM.Game) (bsD.Battleship, error) {
+	const operationName = "repo::game::battleship::model::ToDomain"
+	var players battleshipPlayers
+	var data battleshipData
+	err := gm.DecodeBinaryFields(gm.Players, &players, gm.Data, &data)
+	if err != nil {
+		return bsD.Battleship{}, fmt.Errorf("failed to decode binary fields in %s: %w", operationName, err)
+	}
+	player1 := battleshipPlayerByNumber(players, 1)
+	//nolint:mnd // Player number is constant.
+	player2 := battleshipPlayerByNumber(players, 2)
+
+	model, err := bsD.New(
+		// common fields
+		bsD.WithIDFromUUID(gm.ID),
+		bsD.WithCreatorID(gm.CreatorID),
+		bsD.WithStatus(gm.Status),
+		bsD.WithSessionID(gm.SessionID),
+		bsD.WithCreatedAt(gm.CreatedAt),
+		bsD.WithUpdatedAt(gm.UpdatedAt),
+		// game-specific fields
+		bsD.WithWinnerIDFromUUID(data.WinnerID),
+		bsD.WithTurnFromUUID(data.Turn),
+		bsD.WithPlayer1IDFromUUID(player1.ID),
+		bsD.WithPlayer2IDFromUUID(player2.ID),
+		bsD.WithReady1(player1.IsReady),
+		bsD.WithReady2(player2.IsReady),
+		bsD.WithBoard1(battleshipBoardByNumber(data.Boards, 1)),
+		//nolint:mnd // Player number is constant.
+		bsD.WithBoard2(battleshipBoardByNumber(data.Boards, 2)),
+	)
+	if err != nil {
+		return bsD.Battleship{}, fmt.Errorf("failed to create Battleship in %s: %w", operationName, err)
+	}
+	return model, nil
+}
+
+func battleshipPlayerByNumber(players battleshipPlayers, number int) battleshipPlayer {
+	for _, player := range players {
+		if player.Number == number {
+			return player
+		}
+	}
+	return battleshipPlayer{}
+}
+
+func battleshipPlayerFromDomain(dm bsD.Battleship, id user.ID, number int) battleshipPlayer {
+	return battleshipPlayer{
+		ID:       id.UUID(),
+		Number:   number,
+		IsWinner: !id.IsZero() && dm.WinnerID() == id,
+		IsReady:  dm.IsReady(id),
+	}
+}
+
+func battleshipBoardFromDomain(number int, board bsD.Board) battleshipBoard {
+	ships := make([]battleshipShip, 0, len(board.Ships()))
+	for _, ship := range board.Ships() {
+		ships = append(ships, battleshipShip{
+			Row:         ship.Bow.Row,
+			Col:         ship.Bow.Col,
+			Size:        ship.Size,
+			Orientation: ship.Orientation,
+		})
+	}
+	shots := make([]battleshipCell, 0, len(board.Shots()))
+	for _, shot := range board.Shots() {
+		shots = append(shots, battleshipCell{Row: shot.Row, Col: shot.Col})
+	}
+	return battleshipBoard{Number: number, Ships: ships, Shots: shots}
+}
+
+func battleshipBoardByNumber(boards []battleshipBoard, number int) bsD.Board {
+	for _, board := range boards {
+		if board.Number != number {
+			continue
+		}
+		ships := make([]bsD.Ship, 0, len(board.Ships))
+		for _, ship := range board.Ships {
+			ships = append(ships, bsD.Ship{
+				Bow:         bsD.Cell{Row: ship.Row, Col: ship.Col},
+				Size:        ship.Size,
+				Orientation: ship.Orientation,
+			})
+		}
+		shots := make([]bsD.Cell, 0, len(board.Shots))
+		for _, shot := range board.Shots {
+			shots = append(shots, bsD.Cell{Row: shot.Row, Col: shot.Col})
+		}
+		return bsD.NewBoard(ships, shots)
+	}
+	return bsD.Board{}
+}
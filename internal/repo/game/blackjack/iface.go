@@ -0,0 +1,30 @@
+package blackjack
+
+import (
+	"context"
+	"microgame-bot/internal/domain/blackjack"
+	"microgame-bot/internal/domain/session"
+	"microgame-bot/internal/domain/user"
+)
+
+type IBlackjackGetter interface {
+	GameByID(ctx context.Context, id blackjack.ID) (blackjack.Blackjack, error)
+	GameByIDLocked(ctx context.Context, id blackjack.ID) (blackjack.Blackjack, error)
+	GamesByCreatorID(ctx context.Context, id user.ID) ([]blackjack.Blackjack, error)
+	GamesBySessionID(ctx context.Context, id session.ID) ([]blackjack.Blackjack, error)
+	GamesBySessionIDLocked(ctx context.Context, id session.ID) ([]blackjack.Blackjack, error)
+}
+
+type IBlackjackCreator interface {
+	CreateGame(ctx context.Context, game blackjack.Blackjack) (blackjack.Blackjack, error)
+}
+
+type IBlackjackUpdater interface {
+	UpdateGame(ctx context.Context, game blackjack.Blackjack) (blackjack.Blackjack, error)
+}
+
+type IBlackjackRepository interface {
+	IBlackjackCreator
+	IBlackjackUpdater
+	IBlackjackGetter
+}
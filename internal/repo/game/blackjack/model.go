@@ -0,0 +1,111 @@
+package blackjack
+
+import (
+	"encoding/json"
+	"fmt"
+	bjD "microgame-bot/internal/domain/blackjack"
+	gM "microgame-bot/internal/repo/game"
+
+	"github.com/google/uuid"
+)
+
+type blackjackPlayers []blackjackPlayer
+
+type blackjackPlayer struct {
+	ID       uuid.UUID `json:"id"`
+	IsWinner bool      `json:"is_winner"`
+}
+
+// blackjackData keeps the shoe seed and the position in the shoe,
+// the whole shoe is rebuilt from them and every card dealt can be checked once the seed is revealed.
+type blackjackData struct {
+	Seed    string      `json:"seed"`
+	Cursor  int         `json:"cursor"`
+	Player  []int       `json:"player"`
+	Dealer  []int       `json:"dealer"`
+	Doubled bool        `json:"doubled"`
+	Outcome bjD.Outcome `json:"outcome"`
+}
+
+func (Repository) FromDomain(gm gM.Game, dm bjD.Blackjack) (gM.Game, error) {
+	const operationName = "repo::game::blackjack::model::FromDomain"
+	players, err := json.Marshal(blackjackPlayers{
+		{
+			ID:       dm.PlayerID().UUID(),
+			IsWinner: len(dm.Winners()) > 0,
+		},
+	})
+	if err != nil {
+		return gM.Game{}, fmt.Errorf("failed to marshal players in %s: %w", operationName, err)
+	}
+
+	data, err := json.Marshal(blackjackData{
+		Seed:    dm.Seed(),
+		Cursor:  dm.Cursor(),
+		Player:  handToModel(dm.PlayerHand()),
+		Dealer:  handToModel(dm.DealerHand()),
+		Doubled: dm.Doubled(),
+		Outcome: dm.Outcome(),
+	})
+	if err != nil {
+		return gM.Game{}, fmt.Errorf("failed to marshal data in %s: %w", operationName, err)
+	}
+	gm = gm.SetCommonFields(dm)
+	gm.Players = players
+	gm.Data = data
+
+	return gm, nil
+}
+
+func (Repository) ToDomain(gm gM.Game) (bjD.Blackjack, error) {
+	const operationName = "repo::game::blackjack::model::ToDomain"
+	var players blackjackPlayers
+	var data blackjackData
+	err := gm.DecodeBinaryFields(gm.Players, &players, gm.Data, &data)
+	if err != nil {
+		return bjD.Blackjack{}, fmt.Errorf("failed to decode binary fields in %s: %w", operationName, err)
+	}
+	var playerID uuid.UUID
+	if len(players) > 0 {
+		playerID = players[0].ID
+	}
+
+	model, err := bjD.New(
+		// common fields
+		bjD.WithIDFromUUID(gm.ID),
+		bjD.WithCreatorID(gm.CreatorID),
+		bjD.WithStatus(gm.Status),
+		bjD.WithSessionID(gm.SessionID),
+		bjD.WithCreatedAt(gm.CreatedAt),
+		bjD.WithUpdatedAt(gm.UpdatedAt),
+		// game-specific fields
+		bjD.WithPlayerIDFromUUID(playerID),
+		bjD.WithSeed(data.Seed),
+		bjD.WithCursor(data.Cursor),
+		bjD.WithPlayerHand(handFromModel(data.Player)),
+		bjD.WithDealerHand(handFromModel(data.Dealer)),
+		bjD.WithDoubled(data.Doubled),
+		bjD.WithOutcome(data.Outcome),
+	)
+	if err != nil {
+		return bjD.Blackjack{}, fmt.Errorf("failed to create Blackjack in %s: %w", operationName, err)
+	}
+	return model, nil
+}
+
+// Cards are stored as plain numbers, a slice of bytes would be encoded as a base64 string.
+func handToModel(hand bjD.Hand) []int {
+	cards := make([]int, len(hand))
+	for i, card := range hand {
+		cards[i] = int(card)
+	}
+	return cards
+}
+
+func handFromModel(cards []int) bjD.Hand {
+	hand := make(bjD.Hand, len(cards))
+	for i, card := range cards {
+		hand[i] = bjD.Card(card) //nolint:gosec // Cards are stored from a valid hand.
+	}
+	return hand
+}
@@ -0,0 +1,30 @@
+package bullscows
+
+import (
+	"context"
+	"microgame-bot/internal/domain/bullscows"
+	"microgame-bot/internal/domain/session"
+	"microgame-bot/internal/domain/user"
+)
+
+type IBullsCowsGetter interface {
+	GameByID(ctx context.Context, id bullscows.ID) (bullscows.BullsCows, error)
+	GameByIDLocked(ctx context.Context, id bullscows.ID) (bullscows.BullsCows, error)
+	GamesByCreatorID(ctx context.Context, id user.ID) ([]bullscows.BullsCows, error)
+	GamesBySessionID(ctx context.Context, id session.ID) ([]bullscows.BullsCows, error)
+	GamesBySessionIDLocked(ctx context.Context, id session.ID) ([]bullscows.BullsCows, error)
+}
+
+type IBullsCowsCreator interface {
+	CreateGame(ctx context.Context, game bullscows.BullsCows) (bullscows.BullsCows, error)
+}
+
+type IBullsCowsUpdater interface {
+	UpdateGame(ctx context.Context, game bullscows.BullsCows) (bullscows.BullsCows, error)
+}
+
+type IBullsCowsRepository interface {
+	IBullsCowsCreator
+	IBullsCowsUpdater
+	IBullsCowsGetter
+}
@@ -0,0 +1,160 @@
+package bullscows
+
+import (
+	"encoding/json"
+	"fmt"
+	bcD "microgame-bot/internal/domain/bullscows"
+	"microgame-bot/internal/domain/user"
+	gM "microgame-bot/internal/repo/game"
+
+	"github.com/google/uuid"
+)
+
+type bullsCowsPlayers []bullsCowsPlayer
+
+type bullsCowsPlayer struct {
+	Number   int       `json:"number"`
+	ID       uuid.UUID `json:"id"`
+	IsWinner bool      `json:"is_winner"`
+	IsReady  bool      `json:"is_ready"`
+}
+
+// bullsCowsData keeps both secrets and the numbers being typed,
+// the secrets are never sent to the shared message while the game goes on.
+type bullsCowsData struct {
+	Secrets  []bullsCowsSecret `json:"secrets"`
+	Guesses  []bullsCowsGuess  `json:"guesses"`
+	Turn     uuid.UUID         `json:"turn"`
+	WinnerID uuid.UUID         `json:"winner"`
+}
+
+type bullsCowsSecret struct {
+	Number int        `json:"number"`
+	Secret bcD.Number `json:"secret"`
+	Draft  bcD.Number `json:"draft"`
+}
+
+type bullsCowsGuess struct {
+	PlayerID uuid.UUID  `json:"player_id"`
+	Number   bcD.Number `json:"number"`
+	Bulls    int        `json:"bulls"`
+	Cows     int        `json:"cows"`
+}
+
+func (Repository) FromDomain(gm gM.Game, dm bcD.BullsCows) (gM.Game, error) {
+	const operationName = "repo::game::bullscows::model::FromDomain"
+	players, err := json.Marshal(bullsCowsPlayers{
+		bullsCowsPlayerFromDomain(dm, dm.Player1ID(), 1),
+		//nolint:mnd // Player number is constant.
+		bullsCowsPlayerFromDomain(dm, dm.Player2ID(), 2),
+	})
+	if err != nil {
+		return gM.Game{}, fmt.Errorf("failed to marshal players in %s: %w", operationName, err)
+	}
+
+	guesses := make([]bullsCowsGuess, 0, len(dm.Guesses()))
+	for _, guess := range dm.Guesses() {
+		guesses = append(guesses, bullsCowsGuess{
+			PlayerID: guess.PlayerID.UUID(),
+			Number:   guess.Number,
+			Bulls:    guess.Bulls,
+			Cows:     guess.Cows,
+		})
+	}
+
+	data, err := json.Marshal(bullsCowsData{
+		Secrets: []bullsCowsSecret{
+			{Number: 1, Secret: dm.Secret1(), Draft: dm.Draft1()},
+			//nolint:mnd // Player number is constant.
+			{Number: 2, Secret: dm.Secret2(), Draft: dm.Draft2()},
+		},
+		Guesses:  guesses,
+		Turn:     dm.Turn().UUID(),
+		WinnerID: dm.WinnerID().UUID(),
+	})
+	if err != nil {
+		return gM.Game{}, fmt.Errorf("failed to marshal data in %s: %w", operationName, err)
+	}
+	gm = gm.SetCommonFields(dm)
+	gm.Players = players
+	gm.Data = data
+
+	return gm, nil
+}
+
+func (Repository) ToDomain(gm gM.Game) (bcD.BullsCows, error) {
+	const operationName = "repo::game::bullscows::model::ToDomain"
+	var players bullsCowsPlayers
+	var data bullsCowsData
+	err := gm.DecodeBinaryFields(gm.Players, &players, gm.Data, &data)
+	if err != nil {
+		return bcD.BullsCows{}, fmt.Errorf("failed to decode binary fields in %s: %w", operationName, err)
+	}
+	player1 := bullsCowsPlayerByNumber(players, 1)
+	//nolint:mnd // Player number is constant.
+	player2 := bullsCowsPlayerByNumber(players, 2)
+	secret1 := bullsCowsSecretByNumber(data.Secrets, 1)
+	//nolint:mnd // Player number is constant.
+	secret2 := bullsCowsSecretByNumber(data.Secrets, 2)
+
+	guesses := make([]bcD.Guess, 0, len(data.Guesses))
+	for _, guess := range data.Guesses {
+		guesses = append(guesses, bcD.Guess{
+			PlayerID: user.ID(guess.PlayerID),
+			Number:   guess.Number,
+			Bulls:    guess.Bulls,
+			Cows:     guess.Cows,
+		})
+	}
+
+	model, err := bcD.New(
+		// common fields
+		bcD.WithIDFromUUID(gm.ID),
+		bcD.WithCreatorID(gm.CreatorID),
+		bcD.WithStatus(gm.Status),
+		bcD.WithSessionID(gm.SessionID),
+		bcD.WithCreatedAt(gm.CreatedAt),
+		bcD.WithUpdatedAt(gm.UpdatedAt),
+		// game-specific fields
+		bcD.WithWinnerIDFromUUID(data.WinnerID),
+		bcD.WithTurnFromUUID(data.Turn),
+		bcD.WithPlayer1IDFromUUID(player1.ID),
+		bcD.WithPlayer2IDFromUUID(player2.ID),
+		bcD.WithSecret1(secret1.Secret),
+		bcD.WithSecret2(secret2.Secret),
+		bcD.WithDraft1(secret1.Draft),
+		bcD.WithDraft2(secret2.Draft),
+		bcD.WithGuesses(guesses),
+	)
+	if err != nil {
+		return bcD.BullsCows{}, fmt.Errorf("failed to create BullsCows in %s: %w", operationName, err)
+	}
+	return model, nil
+}
+
+func bullsCowsPlayerByNumber(players bullsCowsPlayers, number int) bullsCowsPlayer {
+	for _, player := range players {
+		if player.Number == number {
+			return player
+		}
+	}
+	return bullsCowsPlayer{}
+}
+
+func bullsCowsPlayerFromDomain(dm bcD.BullsCows, id user.ID, number int) bullsCowsPlayer {
+	return bullsCowsPlayer{
+		ID:       id.UUID(),
+		Number:   number,
+		IsWinner: !id.IsZero() && dm.WinnerID() == id,
+		IsReady:  dm.IsReady(id),
+	}
+}
+
+func bullsCowsSecretByNumber(secrets []bullsCowsSecret, number int) bullsCowsSecret {
+	for _, secret := range secrets {
+		if secret.Number == number {
+			return secret
+		}
+	}
+	return bullsCowsSecret{}
+}
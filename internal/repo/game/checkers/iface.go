@@ -0,0 +1,30 @@
+package checkers
+
+import (
+	"context"
+	"microgame-bot/internal/domain/checkers"
+	"microgame-bot/internal/domain/session"
+	"microgame-bot/internal/domain/user"
+)
+
+type ICheckersGetter interface {
+	GameByID(ctx context.Context, id checkers.ID) (checkers.Checkers, error)
+	GameByIDLocked(ctx context.Context, id checkers.ID) (checkers.Checkers, error)
+	GamesByCreatorID(ctx context.Context, id user.ID) ([]checkers.Checkers, error)
+	GamesBySessionID(ctx context.Context, id session.ID) ([]checkers.Checkers, error)
+	GamesBySessionIDLocked(ctx context.Context, id session.ID) ([]checkers.Checkers, error)
+}
+
+type ICheckersCreator interface {
+	CreateGame(ctx context.Context, game checkers.Checkers) (checkers.Checkers, error)
+}
+
+type ICheckersUpdater interface {
+	UpdateGame(ctx context.Context, game checkers.Checkers) (checkers.Checkers, error)
+}
+
+type ICheckersRepository interface {
+	ICheckersCreator
+	ICheckersUpdater
+	ICheckersGetter
+}
@@ -0,0 +1,206 @@
+package checkers
+
+import (
+	"encoding/json"
+	"fmt"
+	checkersD "microgame-bot/internal/domain/checkers"
+	"microgame-bot/internal/domain/user"
+	gM "microgame-bot/internal/repo/game"
+
+	"github.com/google/uuid"
+)
+
+type checkersPlayers []checkersPlayer
+
+type checkersPlayer struct {
+	Color    checkersD.Color `json:"color"`
+	ID       uuid.UUID       `json:"id"`
+	IsWinner bool            `json:"is_winner"`
+	Pieces   int             `json:"pieces"`
+}
+
+// checkersMove keeps every step of the game, a capture chain is stored jump by jump.
+type checkersMove struct {
+	PlayerID uuid.UUID     `json:"player_id"`
+	From     checkersCell  `json:"from"`
+	To       checkersCell  `json:"to"`
+	Captured *checkersCell `json:"captured,omitempty"`
+	Promoted bool          `json:"promoted,omitempty"`
+}
+
+type checkersCell struct {
+	Row int `json:"row"`
+	Col int `json:"col"`
+}
+
+// checkersData keeps the capture chain in progress: the jumping piece and the pieces it has captured so far.
+type checkersData struct {
+	Board      checkersD.Board `json:"board"`
+	WinnerID   uuid.UUID       `json:"winner"`
+	Turn       uuid.UUID       `json:"turn"`
+	Seed       string          `json:"seed,omitempty"`
+	Moves      []checkersMove  `json:"moves,omitempty"`
+	Chain      *checkersCell   `json:"chain,omitempty"`
+	Taken      []checkersCell  `json:"taken,omitempty"`
+	QuietMoves int             `json:"quiet_moves,omitempty"`
+}
+
+func (Repository) FromDomain(gm gM.Game, dm checkersD.Checkers) (gM.Game, error) {
+	const operationName = "repo::game::checkers::model::FromDomain"
+	players, err := json.Marshal(checkersPlayers{
+		checkersPlayerFromDomain(dm, dm.PlayerWhiteID(), checkersD.ColorWhite),
+		checkersPlayerFromDomain(dm, dm.PlayerBlackID(), checkersD.ColorBlack),
+	})
+	if err != nil {
+		return gM.Game{}, fmt.Errorf("failed to marshal players in %s: %w", operationName, err)
+	}
+	data, err := json.Marshal(checkersData{
+		Board:      dm.Board(),
+		WinnerID:   dm.WinnerID().UUID(),
+		Turn:       dm.Turn().UUID(),
+		Seed:       dm.Seed(),
+		Moves:      checkersMovesFromDomain(dm.Moves()),
+		Chain:      checkersChainFromDomain(dm),
+		Taken:      checkersCellsFromDomain(dm.Taken()),
+		QuietMoves: dm.QuietMoves(),
+	})
+	if err != nil {
+		return gM.Game{}, fmt.Errorf("failed to marshal data in %s: %w", operationName, err)
+	}
+
+	gm = gm.SetCommonFields(dm)
+	gm.Players = players
+	gm.Data = data
+
+	return gm, nil
+}
+
+func (Repository) ToDomain(gm gM.Game) (checkersD.Checkers, error) {
+	const operationName = "repo::game::checkers::model::ToDomain"
+	var players checkersPlayers
+	var data checkersData
+	err := gm.DecodeBinaryFields(gm.Players, &players, gm.Data, &data)
+	if err != nil {
+		return checkersD.Checkers{}, fmt.Errorf("failed to decode binary fields in %s: %w", operationName, err)
+	}
+	playerWhite := checkersPlayerByColor(players, checkersD.ColorWhite)
+	playerBlack := checkersPlayerByColor(players, checkersD.ColorBlack)
+
+	model, err := checkersD.New(
+		// common fields
+		checkersD.WithIDFromUUID(gm.ID),
+		checkersD.WithCreatorID(gm.CreatorID),
+		checkersD.WithStatus(gm.Status),
+		checkersD.WithSessionID(gm.SessionID),
+		checkersD.WithCreatedAt(gm.CreatedAt),
+		checkersD.WithUpdatedAt(gm.UpdatedAt),
+		// game-specific fields
+		checkersD.WithPlayerWhiteIDFromUUID(playerWhite.ID),
+		checkersD.WithPlayerBlackIDFromUUID(playerBlack.ID),
+		checkersD.WithBoard(data.Board),
+		checkersD.WithTurnFromUUID(data.Turn),
+		checkersD.WithWinnerIDFromUUID(data.WinnerID),
+		checkersD.WithSeed(data.Seed),
+		checkersD.WithMoves(checkersMovesToDomain(data.Moves)),
+		checkersD.WithChain(checkersCellToDomainPtr(data.Chain), checkersCellsToDomain(data.Taken)),
+		checkersD.WithQuietMoves(data.QuietMoves),
+	)
+	if err != nil {
+		return checkersD.Checkers{}, fmt.Errorf("failed to create Checkers in %s: %w", operationName, err)
+	}
+	return model, nil
+}
+
+func checkersPlayerByColor(players checkersPlayers, color checkersD.Color) checkersPlayer {
+	for _, player := range players {
+		if player.Color == color {
+			return player
+		}
+	}
+	return checkersPlayer{}
+}
+
+func checkersPlayerFromDomain(dm checkersD.Checkers, id user.ID, color checkersD.Color) checkersPlayer {
+	return checkersPlayer{
+		Color:    color,
+		ID:       id.UUID(),
+		IsWinner: !id.IsZero() && dm.WinnerID() == id,
+		Pieces:   dm.Board().Count(color),
+	}
+}
+
+func checkersCellFromDomain(cell checkersD.Cell) checkersCell {
+	return checkersCell{Row: cell.Row, Col: cell.Col}
+}
+
+func checkersCellFromDomainPtr(cell *checkersD.Cell) *checkersCell {
+	if cell == nil {
+		return nil
+	}
+	c := checkersCellFromDomain(*cell)
+	return &c
+}
+
+func checkersCellToDomain(cell checkersCell) checkersD.Cell {
+	return checkersD.Cell{Row: cell.Row, Col: cell.Col}
+}
+
+func checkersCellToDomainPtr(cell *checkersCell) *checkersD.Cell {
+	if cell == nil {
+		return nil
+	}
+	c := checkersCellToDomain(*cell)
+	return &c
+}
+
+func checkersCellsFromDomain(cells []checkersD.Cell) []checkersCell {
+	result := make([]checkersCell, len(cells))
+	for i, cell := range cells {
+		result[i] = checkersCellFromDomain(cell)
+	}
+	return result
+}
+
+func checkersCellsToDomain(cells []checkersCell) []checkersD.Cell {
+	result := make([]checkersD.Cell, len(cells))
+	for i, cell := range cells {
+		result[i] = checkersCellToDomain(cell)
+	}
+	return result
+}
+
+func checkersChainFromDomain(dm checkersD.Checkers) *checkersCell {
+	chain, ok := dm.Chain()
+	if !ok {
+		return nil
+	}
+	return checkersCellFromDomainPtr(&chain)
+}
+
+func checkersMovesFromDomain(moves []checkersD.Move) []checkersMove {
+	result := make([]checkersMove, len(moves))
+	for i, move := range moves {
+		result[i] = checkersMove{
+			PlayerID: move.PlayerID.UUID(),
+			From:     checkersCellFromDomain(move.From),
+			To:       checkersCellFromDomain(move.To),
+			Captured: checkersCellFromDomainPtr(move.Captured),
+			Promoted: move.Promoted,
+		}
+	}
+	return result
+}
+
+func checkersMovesToDomain(moves []checkersMove) []checkersD.Move {
+	result := make([]checkersD.Move, len(moves))
+	for i, move := range moves {
+		result[i] = checkersD.Move{
+			PlayerID: user.ID(move.PlayerID),
+			From:     checkersCellToDomain(move.From),
+			To:       checkersCellToDomain(move.To),
+			Captured: checkersCellToDomainPtr(move.Captured),
+			Promoted: move.Promoted,
+		}
+	}
+	return result
+}
@@ -0,0 +1,30 @@
+package dice
+
+import (
+	"context"
+	"microgame-bot/internal/domain/dice"
+	"microgame-bot/internal/domain/session"
+	"microgame-bot/internal/domain/user"
+)
+
+type IDiceGetter interface {
+	GameByID(ctx context.Context, id dice.ID) (dice.Dice, error)
+	GameByIDLocked(ctx context.Context, id dice.ID) (dice.Dice, error)
+	GamesByCreatorID(ctx context.Context, id user.ID) ([]dice.Dice, error)
+	GamesBySessionID(ctx context.Context, id session.ID) ([]dice.Dice, error)
+	GamesBySessionIDLocked(ctx context.Context, id session.ID) ([]dice.Dice, error)
+}
+
+type IDiceCreator interface {
+	CreateGame(ctx context.Context, game dice.Dice) (dice.Dice, error)
+}
+
+type IDiceUpdater interface {
+	UpdateGame(ctx context.Context, game dice.Dice) (dice.Dice, error)
+}
+
+type IDiceRepository interface {
+	IDiceCreator
+	IDiceUpdater
+	IDiceGetter
+}
@@ -0,0 +1,138 @@
+package dice
+
+import (
+	"encoding/json"
+	"fmt"
+	diceD "microgame-bot/internal/domain/dice"
+	"microgame-bot/internal/domain/user"
+	gM "microgame-bot/internal/repo/game"
+
+	"github.com/google/uuid"
+)
+
+type dicePlayers []dicePlayer
+
+type dicePlayer struct {
+	Number   int       `json:"number"`
+	ID       uuid.UUID `json:"id"`
+	IsWinner bool      `json:"is_winner"`
+}
+
+// diceData keeps every throw with the Telegram message it was rolled in,
+// so a payout can be traced back to the dice both players saw.
+type diceData struct {
+	Kind     diceD.Kind  `json:"kind"`
+	Throws   []diceThrow `json:"throws"`
+	WinnerID uuid.UUID   `json:"winner"`
+}
+
+type diceThrow struct {
+	Number    int   `json:"number"`
+	Value     int   `json:"value"`
+	ChatID    int64 `json:"chat_id"`
+	MessageID int   `json:"message_id"`
+}
+
+func (Repository) FromDomain(gm gM.Game, dm diceD.Dice) (gM.Game, error) {
+	const operationName = "repo::game::dice::model::FromDomain"
+	players, err := json.Marshal(dicePlayers{
+		dicePlayerFromDomain(dm, dm.Player1ID(), 1),
+		//nolint:mnd // Player number is constant.
+		dicePlayerFromDomain(dm, dm.Player2ID(), 2),
+	})
+	if err != nil {
+		return gM.Game{}, fmt.Errorf("failed to marshal players in %s: %w", operationName, err)
+	}
+
+	throws := make([]diceThrow, 0, 2) //nolint:mnd // Two players throw.
+	if !dm.Throw1().IsZero() {
+		throws = append(throws, diceThrowFromDomain(1, dm.Throw1()))
+	}
+	if !dm.Throw2().IsZero() {
+		//nolint:mnd // Player number is constant.
+		throws = append(throws, diceThrowFromDomain(2, dm.Throw2()))
+	}
+	data, err := json.Marshal(diceData{
+		Kind:     dm.Kind(),
+		Throws:   throws,
+		WinnerID: dm.WinnerID().UUID(),
+	})
+	if err != nil {
+		return gM.Game{}, fmt.Errorf("failed to marshal data in %s: %w", operationName, err)
+	}
+	gm = gm.SetCommonFields(dm)
+	gm.Players = players
+	gm.Data = data
+
+	return gm, nil
+}
+
+func (Repository) ToDomain(gm gM.Game) (diceD.Dice, error) {
+	const operationName = "repo::game::dice::model::ToDomain"
+	var players dicePlayers
+	var data diceData
+	err := gm.DecodeBinaryFields(gm.Players, &players, gm.Data, &data)
+	if err != nil {
+		return diceD.Dice{}, fmt.Errorf("failed to decode binary fields in %s: %w", operationName, err)
+	}
+	player1 := dicePlayerByNumber(players, 1)
+	//nolint:mnd // Player number is constant.
+	player2 := dicePlayerByNumber(players, 2)
+
+	model, err := diceD.New(
+		// common fields
+		diceD.WithIDFromUUID(gm.ID),
+		diceD.WithCreatorID(gm.CreatorID),
+		diceD.WithStatus(gm.Status),
+		diceD.WithSessionID(gm.SessionID),
+		diceD.WithCreatedAt(gm.CreatedAt),
+		diceD.WithUpdatedAt(gm.UpdatedAt),
+		// game-specific fields
+		diceD.WithKind(data.Kind),
+		diceD.WithWinnerIDFromUUID(data.WinnerID),
+		diceD.WithPlayer1IDFromUUID(player1.ID),
+		diceD.WithPlayer2IDFromUUID(player2.ID),
+		diceD.WithThrow1(diceThrowByNumber(data.Throws, 1)),
+		//nolint:mnd // Player number is constant.
+		diceD.WithThrow2(diceThrowByNumber(data.Throws, 2)),
+	)
+	if err != nil {
+		return diceD.Dice{}, fmt.Errorf("failed to create Dice in %s: %w", operationName, err)
+	}
+	return model, nil
+}
+
+func dicePlayerByNumber(players dicePlayers, number int) dicePlayer {
+	for _, player := range players {
+		if player.Number == number {
+			return player
+		}
+	}
+	return dicePlayer{}
+}
+
+func dicePlayerFromDomain(dm diceD.Dice, id user.ID, number int) dicePlayer {
+	return dicePlayer{
+		ID:       id.UUID(),
+		Number:   number,
+		IsWinner: dm.WinnerID() == id,
+	}
+}
+
+func diceThrowByNumber(throws []diceThrow, number int) diceD.Throw {
+	for _, throw := range throws {
+		if throw.Number == number {
+			return diceD.Throw{Value: throw.Value, ChatID: throw.ChatID, MessageID: throw.MessageID}
+		}
+	}
+	return diceD.Throw{}
+}
+
+func diceThrowFromDomain(number int, throw diceD.Throw) diceThrow {
+	return diceThrow{
+		Number:    number,
+		Value:     throw.Value,
+		ChatID:    throw.ChatID,
+		MessageID: throw.MessageID,
+	}
+}
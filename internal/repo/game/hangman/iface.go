@@ -0,0 +1,30 @@
+package hangman
+
+import (
+	"context"
+	"microgame-bot/internal/domain/hangman"
+	"microgame-bot/internal/domain/session"
+	"microgame-bot/internal/domain/user"
+)
+
+type IHangmanGetter interface {
+	GameByID(ctx context.Context, id hangman.ID) (hangman.Hangman, error)
+	GameByIDLocked(ctx context.Context, id hangman.ID) (hangman.Hangman, error)
+	GamesByCreatorID(ctx context.Context, id user.ID) ([]hangman.Hangman, error)
+	GamesBySessionID(ctx context.Context, id session.ID) ([]hangman.Hangman, error)
+	GamesBySessionIDLocked(ctx context.Context, id session.ID) ([]hangman.Hangman, error)
+}
+
+type IHangmanCreator interface {
+	CreateGame(ctx context.Context, game hangman.Hangman) (hangman.Hangman, error)
+}
+
+type IHangmanUpdater interface {
+	UpdateGame(ctx context.Context, game hangman.Hangman) (hangman.Hangman, error)
+}
+
+type IHangmanRepository interface {
+	IHangmanCreator
+	IHangmanUpdater
+	IHangmanGetter
+}
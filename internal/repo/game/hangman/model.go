@@ -0,0 +1,112 @@
+package hangman
+
+import (
+	"encoding/json"
+	"fmt"
+	hangmanD "microgame-bot/internal/domain/hangman"
+	"microgame-bot/internal/domain/user"
+	gM "microgame-bot/internal/repo/game"
+
+	"github.com/google/uuid"
+)
+
+type hangmanPlayers []hangmanPlayer
+
+type hangmanPlayer struct {
+	Number   int       `json:"number"`
+	ID       uuid.UUID `json:"id"`
+	IsWinner bool      `json:"is_winner"`
+	Score    int       `json:"score"`
+}
+
+// hangmanData keeps the word with the letters named so far, in the order they were named.
+type hangmanData struct {
+	Language hangmanD.Language `json:"language"`
+	Word     string            `json:"word"`
+	Guessed  string            `json:"guessed"`
+	Turn     uuid.UUID         `json:"turn"`
+	WinnerID uuid.UUID         `json:"winner"`
+}
+
+func (Repository) FromDomain(gm gM.Game, dm hangmanD.Hangman) (gM.Game, error) {
+	const operationName = "repo::game::hangman::model::FromDomain"
+	players, err := json.Marshal(hangmanPlayers{
+		hangmanPlayerFromDomain(dm, dm.Player1ID(), 1),
+		//nolint:mnd // Player number is constant.
+		hangmanPlayerFromDomain(dm, dm.Player2ID(), 2),
+	})
+	if err != nil {
+		return gM.Game{}, fmt.Errorf("failed to marshal players in %s: %w", operationName, err)
+	}
+
+	data, err := json.Marshal(hangmanData{
+		Language: dm.Language(),
+		Word:     dm.Word(),
+		Guessed:  string(dm.Guessed()),
+		Turn:     dm.Turn().UUID(),
+		WinnerID: dm.WinnerID().UUID(),
+	})
+	if err != nil {
+		return gM.Game{}, fmt.Errorf("failed to marshal data in %s: %w", operationName, err)
+	}
+	gm = gm.SetCommonFields(dm)
+	gm.Players = players
+	gm.Data = data
+
+	return gm, nil
+}
+
+func (Repository) ToDomain(gm gM.Game) (hangmanD.Hangman, error) {
+	const operationName = "repo::game::hangman::model::ToDomain"
+	var players hangmanPlayers
+	var data hangmanData
+	err := gm.DecodeBinaryFields(gm.Players, &players, gm.Data, &data)
+	if err != nil {
+		return hangmanD.Hangman{}, fmt.Errorf("failed to decode binary fields in %s: %w", operationName, err)
+	}
+	player1 := hangmanPlayerByNumber(players, 1)
+	//nolint:mnd // Player number is constant.
+	player2 := hangmanPlayerByNumber(players, 2)
+
+	model, err := hangmanD.New(
+		// common fields
+		hangmanD.WithIDFromUUID(gm.ID),
+		hangmanD.WithCreatorID(gm.CreatorID),
+		hangmanD.WithStatus(gm.Status),
+		hangmanD.WithSessionID(gm.SessionID),
+		hangmanD.WithCreatedAt(gm.CreatedAt),
+		hangmanD.WithUpdatedAt(gm.UpdatedAt),
+		// game-specific fields
+		hangmanD.WithLanguage(data.Language),
+		hangmanD.WithWord(data.Word),
+		hangmanD.WithGuessed([]rune(data.Guessed)),
+		hangmanD.WithTurnFromUUID(data.Turn),
+		hangmanD.WithWinnerIDFromUUID(data.WinnerID),
+		hangmanD.WithPlayer1IDFromUUID(player1.ID),
+		hangmanD.WithPlayer2IDFromUUID(player2.ID),
+		hangmanD.WithScore1(player1.Score),
+		hangmanD.WithScore2(player2.Score),
+	)
+	if err != nil {
+		return hangmanD.Hangman{}, fmt.Errorf("failed to create Hangman in %s: %w", operationName, err)
+	}
+	return model, nil
+}
+
+func hangmanPlayerByNumber(players hangmanPlayers, number int) hangmanPlayer {
+	for _, player := range players {
+		if player.Number == number {
+			return player
+		}
+	}
+	return hangmanPlayer{}
+}
+
+func hangmanPlayerFromDomain(dm hangmanD.Hangman, id user.ID, number int) hangmanPlayer {
+	return hangmanPlayer{
+		ID:       id.UUID(),
+		Number:   number,
+		IsWinner: dm.WinnerID() == id,
+		Score:    dm.ScoreOf(id),
+	}
+}
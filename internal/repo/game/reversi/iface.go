@@ -0,0 +1,30 @@
+package reversi
+
+import (
+	"context"
+	"microgame-bot/internal/domain/reversi"
+	"microgame-bot/internal/domain/session"
+	"microgame-bot/internal/domain/user"
+)
+
+type IReversiGetter interface {
+	GameByID(ctx context.Context, id reversi.ID) (reversi.Reversi, error)
+	GameByIDLocked(ctx context.Context, id reversi.ID) (reversi.Reversi, error)
+	GamesByCreatorID(ctx context.Context, id user.ID) ([]reversi.Reversi, error)
+	GamesBySessionID(ctx context.Context, id session.ID) ([]reversi.Reversi, error)
+	GamesBySessionIDLocked(ctx context.Context, id session.ID) ([]reversi.Reversi, error)
+}
+
+type IReversiCreator interface {
+	CreateGame(ctx context.Context, game reversi.Reversi) (reversi.Reversi, error)
+}
+
+type IReversiUpdater interface {
+	UpdateGame(ctx context.Context, game reversi.Reversi) (reversi.Reversi, error)
+}
+
+type IReversiRepository interface {
+	IReversiCreator
+	IReversiUpdater
+	IReversiGetter
+}
@@ -0,0 +1,147 @@
+package reversi
+
+import (
+	"encoding/json"
+	"fmt"
+	reversiD "microgame-bot/internal/domain/reversi"
+	"microgame-bot/internal/domain/user"
+	gM "microgame-bot/internal/repo/game"
+
+	"github.com/google/uuid"
+)
+
+type reversiPlayers []reversiPlayer
+
+type reversiPlayer struct {
+	Disc     reversiD.Disc `json:"disc"`
+	ID       uuid.UUID     `json:"id"`
+	IsWinner bool          `json:"is_winner"`
+	Score    int           `json:"score"`
+}
+
+// reversiMove keeps a placed disc or a forced pass, so the game can be replayed move by move.
+type reversiMove struct {
+	PlayerID uuid.UUID     `json:"player_id"`
+	Disc     reversiD.Disc `json:"disc"`
+	Row      int           `json:"row"`
+	Col      int           `json:"col"`
+	Flipped  int           `json:"flipped,omitempty"`
+	Pass     bool          `json:"pass,omitempty"`
+}
+
+type reversiData struct {
+	Board    reversiD.Board `json:"board"`
+	WinnerID uuid.UUID      `json:"winner"`
+	Turn     uuid.UUID      `json:"turn"`
+	Seed     string         `json:"seed,omitempty"`
+	Moves    []reversiMove  `json:"moves,omitempty"`
+}
+
+func (Repository) FromDomain(gm gM.Game, dm reversiD.Reversi) (gM.Game, error) {
+	const operationName = "repo::game::reversi::model::FromDomain"
+	players, err := json.Marshal(reversiPlayers{
+		reversiPlayerFromDomain(dm, dm.PlayerBlackID(), reversiD.DiscBlack),
+		reversiPlayerFromDomain(dm, dm.PlayerWhiteID(), reversiD.DiscWhite),
+	})
+	if err != nil {
+		return gM.Game{}, fmt.Errorf("failed to marshal players in %s: %w", operationName, err)
+	}
+	data, err := json.Marshal(reversiData{
+		Board:    dm.Board(),
+		WinnerID: dm.WinnerID().UUID(),
+		Turn:     dm.Turn().UUID(),
+		Seed:     dm.Seed(),
+		Moves:    reversiMovesFromDomain(dm.Moves()),
+	})
+	if err != nil {
+		return gM.Game{}, fmt.Errorf("failed to marshal data in %s: %w", operationName, err)
+	}
+
+	gm = gm.SetCommonFields(dm)
+	gm.Players = players
+	gm.Data = data
+
+	return gm, nil
+}
+
+func (Repository) ToDomain(gm gM.Game) (reversiD.Reversi, error) {
+	const operationName = "repo::game::reversi::model::ToDomain"
+	var players reversiPlayers
+	var data reversiData
+	err := gm.DecodeBinaryFields(gm.Players, &players, gm.Data, &data)
+	if err != nil {
+		return reversiD.Reversi{}, fmt.Errorf("failed to decode binary fields in %s: %w", operationName, err)
+	}
+	playerBlack := reversiPlayerByDisc(players, reversiD.DiscBlack)
+	playerWhite := reversiPlayerByDisc(players, reversiD.DiscWhite)
+
+	model, err := reversiD.New(
+		// common fields
+		reversiD.WithIDFromUUID(gm.ID),
+		reversiD.WithCreatorID(gm.CreatorID),
+		reversiD.WithStatus(gm.Status),
+		reversiD.WithSessionID(gm.SessionID),
+		reversiD.WithCreatedAt(gm.CreatedAt),
+		reversiD.WithUpdatedAt(gm.UpdatedAt),
+		// game-specific fields
+		reversiD.WithPlayerBlackIDFromUUID(playerBlack.ID),
+		reversiD.WithPlayerWhiteIDFromUUID(playerWhite.ID),
+		reversiD.WithBoard(data.Board),
+		reversiD.WithTurnFromUUID(data.Turn),
+		reversiD.WithWinnerIDFromUUID(data.WinnerID),
+		reversiD.WithSeed(data.Seed),
+		reversiD.WithMoves(reversiMovesToDomain(data.Moves)),
+	)
+	if err != nil {
+		return reversiD.Reversi{}, fmt.Errorf("failed to create Reversi in %s: %w", operationName, err)
+	}
+	return model, nil
+}
+
+func reversiPlayerByDisc(players reversiPlayers, disc reversiD.Disc) reversiPlayer {
+	for _, player := range players {
+		if player.Disc == disc {
+			return player
+		}
+	}
+	return reversiPlayer{}
+}
+
+func reversiPlayerFromDomain(dm reversiD.Reversi, id user.ID, disc reversiD.Disc) reversiPlayer {
+	return reversiPlayer{
+		Disc:     disc,
+		ID:       id.UUID(),
+		IsWinner: !id.IsZero() && dm.WinnerID() == id,
+		Score:    dm.Board().Count(disc),
+	}
+}
+
+func reversiMovesFromDomain(moves []reversiD.Move) []reversiMove {
+	result := make([]reversiMove, len(moves))
+	for i, move := range moves {
+		result[i] = reversiMove{
+			PlayerID: move.PlayerID.UUID(),
+			Disc:     move.Disc,
+			Row:      move.Row,
+			Col:      move.Col,
+			Flipped:  move.Flipped,
+			Pass:     move.Pass,
+		}
+	}
+	return result
+}
+
+func reversiMovesToDomain(moves []reversiMove) []reversiD.Move {
+	result := make([]reversiD.Move, len(moves))
+	for i, move := range moves {
+		result[i] = reversiD.Move{
+			PlayerID: user.ID(move.PlayerID),
+			Disc:     move.Disc,
+			Row:      move.Row,
+			Col:      move.Col,
+			Flipped:  move.Flipped,
+			Pass:     move.Pass,
+		}
+	}
+	return result
+}
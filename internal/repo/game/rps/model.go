@@ -4,17 +4,35 @@ import (
 	"encoding/json"
 	"fmt"
 	rpsD "microgame-bot/internal/domain/rps"
+	"microgame-bot/internal/domain/user"
 	gM "microgame-bot/internal/repo/game"
 
 	"github.com/google/uuid"
 )
 
 type rpsPlayers []rpsPlayer
+
+// Salt is the salt of a choice that is still hidden. It is kept out of the game row until the reveal,
+// so the row alone doesn't give the choice away: with the salt at hand the hash of three choices is easy to match.
+type Salt struct {
+	GameID uuid.UUID `gorm:"primaryKey;type:uuid"`
+	Number int       `gorm:"primaryKey"`
+	Salt   string    `gorm:"not null;size:64"`
+}
+
+func (Salt) TableName() string {
+	return "rps_salts"
+}
+
+// rpsPlayer keeps the choice as a salted hash until both players have chosen,
+// the plain choice and the salt are written only after the reveal.
 type rpsPlayer struct {
-	Choice   rpsD.Choice `json:"choice"`
-	Number   int         `json:"number"`
-	ID       uuid.UUID   `json:"id"`
-	IsWinner bool        `json:"is_winner"`
+	Choice     rpsD.Choice `json:"choice"`
+	Commitment string      `json:"commitment,omitempty"`
+	Salt       string      `json:"salt,omitempty"`
+	Number     int         `json:"number"`
+	ID         uuid.UUID   `json:"id"`
+	IsWinner   bool        `json:"is_winner"`
 }
 
 type rpsData struct {
@@ -24,19 +42,9 @@ type rpsData struct {
 func (Repository) FromDomain(gm gM.Game, dm rpsD.RPS) (gM.Game, error) {
 	const operationName = "repo::game::rps::model::FromDomain"
 	players, err := json.Marshal(rpsPlayers{
-		{
-			ID:       dm.Player1ID().UUID(),
-			Number:   1,
-			IsWinner: dm.WinnerID() == dm.Player1ID(),
-			Choice:   dm.Choice1(),
-		},
-		{
-			ID: dm.Player2ID().UUID(),
-			//nolint:mnd // Player number is constant.
-			Number:   2,
-			IsWinner: dm.WinnerID() == dm.Player2ID(),
-			Choice:   dm.Choice2(),
-		},
+		rpsPlayerFromDomain(dm, dm.Player1ID(), 1, dm.Choice1(), dm.Commitment1()),
+		//nolint:mnd // Player number is constant.
+		rpsPlayerFromDomain(dm, dm.Player2ID(), 2, dm.Choice2(), dm.Commitment2()),
 	})
 	if err != nil {
 		return gM.Game{}, fmt.Errorf("failed to marshal players in %s: %w", operationName, err)
@@ -54,7 +62,8 @@ func (Repository) FromDomain(gm gM.Game, dm rpsD.RPS) (gM.Game, error) {
 	return gm, nil
 }
 
-func (Repository) ToDomain(gm gM.Game) (rpsD.RPS, error) {
+// ToDomain restores the game, the salts of the choices that are still hidden are taken from hidden by player number.
+func (Repository) ToDomain(gm gM.Game, hidden map[int]string) (rpsD.RPS, error) {
 	const operationName = "repo::game::rps::model::ToDomain"
 	var players rpsPlayers
 	var data rpsData
@@ -62,9 +71,19 @@ func (Repository) ToDomain(gm gM.Game) (rpsD.RPS, error) {
 	if err != nil {
 		return rpsD.RPS{}, fmt.Errorf("failed to decode binary fields in %s: %w", operationName, err)
 	}
-	player1 := rpsPlayerByNumber(players, 1)
+	player1 := rpsPlayerByNumber(players, 1).withSalt(hidden)
 	//nolint:mnd // Player number is constant.
-	player2 := rpsPlayerByNumber(players, 2)
+	player2 := rpsPlayerByNumber(players, 2).withSalt(hidden)
+
+	gameID := rpsD.ID(gm.ID)
+	choice1, err := player1.revealChoice(gameID)
+	if err != nil {
+		return rpsD.RPS{}, fmt.Errorf("failed to reveal player 1 choice in %s: %w", operationName, err)
+	}
+	choice2, err := player2.revealChoice(gameID)
+	if err != nil {
+		return rpsD.RPS{}, fmt.Errorf("failed to reveal player 2 choice in %s: %w", operationName, err)
+	}
 
 	model, err := rpsD.New(
 		// common fields
@@ -78,8 +97,10 @@ func (Repository) ToDomain(gm gM.Game) (rpsD.RPS, error) {
 		rpsD.WithWinnerIDFromUUID(data.WinnerID),
 		rpsD.WithPlayer1IDFromUUID(player1.ID),
 		rpsD.WithPlayer2IDFromUUID(player2.ID),
-		rpsD.WithChoice1(player1.Choice),
-		rpsD.WithChoice2(player2.Choice),
+		rpsD.WithChoice1(choice1),
+		rpsD.WithChoice2(choice2),
+		rpsD.WithCommitment1(rpsD.CommitmentFrom(player1.Commitment, player1.Salt)),
+		rpsD.WithCommitment2(rpsD.CommitmentFrom(player2.Commitment, player2.Salt)),
 	)
 	if err != nil {
 		return rpsD.RPS{}, fmt.Errorf("failed to create RPS in %s: %w", operationName, err)
@@ -95,3 +116,55 @@ func rpsPlayerByNumber(players rpsPlayers, number int) rpsPlayer {
 	}
 	return rpsPlayer{}
 }
+
+func rpsPlayerFromDomain(
+	dm rpsD.RPS,
+	id user.ID,
+	number int,
+	choice rpsD.Choice,
+	commitment rpsD.Commitment,
+) rpsPlayer {
+	player := rpsPlayer{
+		ID:         id.UUID(),
+		Number:     number,
+		IsWinner:   dm.WinnerID() == id,
+		Commitment: commitment.Hash(),
+	}
+	// Games played before commitments were introduced have no hash to hide behind.
+	if dm.IsRevealed() || commitment.IsZero() {
+		player.Choice = choice
+		player.Salt = commitment.Salt()
+	}
+	return player
+}
+
+// hiddenSalts returns the salts of the choices that are still hidden, by player number.
+func hiddenSalts(dm rpsD.RPS) []Salt {
+	if dm.IsRevealed() {
+		return nil
+	}
+	var salts []Salt
+	//nolint:mnd // Player number is constant.
+	for number, commitment := range map[int]rpsD.Commitment{1: dm.Commitment1(), 2: dm.Commitment2()} {
+		if !commitment.IsZero() {
+			salts = append(salts, Salt{GameID: dm.IDtoUUID(), Number: number, Salt: commitment.Salt()})
+		}
+	}
+	return salts
+}
+
+// withSalt takes the salt of the hidden choice, the games stored before the salts were split off keep it in the row.
+func (p rpsPlayer) withSalt(hidden map[int]string) rpsPlayer {
+	if p.Salt == "" {
+		p.Salt = hidden[p.Number]
+	}
+	return p
+}
+
+// revealChoice returns the stored choice, recovering it from the commitment while it is still hidden.
+func (p rpsPlayer) revealChoice(gameID rpsD.ID) (rpsD.Choice, error) {
+	if p.Choice != rpsD.ChoiceEmpty || p.Commitment == "" {
+		return p.Choice, nil
+	}
+	return rpsD.CommitmentFrom(p.Commitment, p.Salt).Reveal(gameID)
+}
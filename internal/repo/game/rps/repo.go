@@ -13,6 +13,7 @@ import (
 
 	gM "microgame-bot/internal/repo/game"
 
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
@@ -33,7 +34,10 @@ func (r *Repository) CreateGame(ctx context.Context, game rps.RPS) (rps.RPS, err
 	if err := gorm.G[gM.Game](r.db).Create(ctx, &model); err != nil {
 		return rps.RPS{}, err
 	}
-	return r.ToDomain(model)
+	if err := r.saveSalts(ctx, game); err != nil {
+		return rps.RPS{}, err
+	}
+	return r.oneToDomain(ctx, model)
 }
 
 func (r *Repository) GameByID(ctx context.Context, id rps.ID) (rps.RPS, error) {
@@ -54,14 +58,7 @@ func (r *Repository) GamesByCreatorID(ctx context.Context, id user.ID) ([]rps.RP
 	if err != nil {
 		return nil, err
 	}
-	results := make([]rps.RPS, len(models))
-	for i, model := range models {
-		results[i], err = r.ToDomain(model)
-		if err != nil {
-			return nil, err
-		}
-	}
-	return results, nil
+	return r.toDomain(ctx, models)
 }
 
 func (r *Repository) GamesBySessionID(ctx context.Context, id session.ID) ([]rps.RPS, error) {
@@ -81,6 +78,9 @@ func (r *Repository) UpdateGame(ctx context.Context, game rps.RPS) (rps.RPS, err
 	if err != nil {
 		return rps.RPS{}, fmt.Errorf("failed to update game in gorm database: %w", err)
 	}
+	if err := r.saveSalts(ctx, game); err != nil {
+		return rps.RPS{}, err
+	}
 	model, err = gorm.G[gM.Game](r.db).Where("id = ?", model.ID.String()).First(ctx)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -88,7 +88,7 @@ func (r *Repository) UpdateGame(ctx context.Context, game rps.RPS) (rps.RPS, err
 		}
 		return rps.RPS{}, fmt.Errorf("failed to get game by ID from gorm database: %w", err)
 	}
-	return r.ToDomain(model)
+	return r.oneToDomain(ctx, model)
 }
 
 func (r *Repository) gamesBySessionID(
@@ -103,12 +103,9 @@ func (r *Repository) gamesBySessionID(
 	if err != nil {
 		return nil, fmt.Errorf("failed to get games by session ID from gorm database in %s: %w", operationName, err)
 	}
-	results := make([]rps.RPS, len(models))
-	for i, model := range models {
-		results[i], err = r.ToDomain(model)
-		if err != nil {
-			return nil, fmt.Errorf("failed to convert model to domain in %s: %w", operationName, err)
-		}
+	results, err := r.toDomain(ctx, models)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert model to domain in %s: %w", operationName, err)
 	}
 	return results, nil
 }
@@ -124,5 +121,66 @@ func (r *Repository) gameByID(ctx context.Context, id rps.ID, opts ...clause.Exp
 		}
 		return rps.RPS{}, fmt.Errorf("failed to get game by ID from gorm database in %s: %w", operationName, err)
 	}
-	return r.ToDomain(model)
+	return r.oneToDomain(ctx, model)
+}
+
+// saveSalts keeps the salts of the hidden choices of the game, they are dropped once the game row has them.
+func (r *Repository) saveSalts(ctx context.Context, game rps.RPS) error {
+	if game.IsRevealed() {
+		_, err := gorm.G[Salt](r.db).Where("game_id = ?", game.IDtoUUID()).Delete(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to delete revealed salts from gorm database: %w", err)
+		}
+		return nil
+	}
+	salts := hiddenSalts(game)
+	if len(salts) == 0 {
+		return nil
+	}
+	err := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{UpdateAll: true}).
+		Create(&salts).Error
+	if err != nil {
+		return fmt.Errorf("failed to save hidden salts to gorm database: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) oneToDomain(ctx context.Context, model gM.Game) (rps.RPS, error) {
+	results, err := r.toDomain(ctx, []gM.Game{model})
+	if err != nil {
+		return rps.RPS{}, err
+	}
+	return results[0], nil
+}
+
+// toDomain restores the games with the salts of their hidden choices.
+func (r *Repository) toDomain(ctx context.Context, models []gM.Game) ([]rps.RPS, error) {
+	if len(models) == 0 {
+		return []rps.RPS{}, nil
+	}
+	ids := make([]uuid.UUID, len(models))
+	for i, model := range models {
+		ids[i] = model.ID
+	}
+	salts, err := gorm.G[Salt](r.db).Where("game_id IN ?", ids).Find(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get hidden salts from gorm database: %w", err)
+	}
+	hidden := make(map[uuid.UUID]map[int]string, len(salts))
+	for _, salt := range salts {
+		if hidden[salt.GameID] == nil {
+			hidden[salt.GameID] = make(map[int]string)
+		}
+		hidden[salt.GameID][salt.Number] = salt.Salt
+	}
+
+	results := make([]rps.RPS, len(models))
+	for i, model := range models {
+		results[i], err = r.ToDomain(model, hidden[model.ID])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return results, nil
 }
@@ -0,0 +1,30 @@
+package trivia
+
+import (
+	"context"
+	"microgame-bot/internal/domain/session"
+	"microgame-bot/internal/domain/trivia"
+	"microgame-bot/internal/domain/user"
+)
+
+type ITriviaGetter interface {
+	GameByID(ctx context.Context, id trivia.ID) (trivia.Trivia, error)
+	GameByIDLocked(ctx context.Context, id trivia.ID) (trivia.Trivia, error)
+	GamesByCreatorID(ctx context.Context, id user.ID) ([]trivia.Trivia, error)
+	GamesBySessionID(ctx context.Context, id session.ID) ([]trivia.Trivia, error)
+	GamesBySessionIDLocked(ctx context.Context, id session.ID) ([]trivia.Trivia, error)
+}
+
+type ITriviaCreator interface {
+	CreateGame(ctx context.Context, game trivia.Trivia) (trivia.Trivia, error)
+}
+
+type ITriviaUpdater interface {
+	UpdateGame(ctx context.Context, game trivia.Trivia) (trivia.Trivia, error)
+}
+
+type ITriviaRepository interface {
+	ITriviaCreator
+	ITriviaUpdater
+	ITriviaGetter
+}
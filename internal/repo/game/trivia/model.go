@@ -0,0 +1,117 @@
+package trivia
+
+import (
+	"encoding/json"
+	"fmt"
+	triviaD "microgame-bot/internal/domain/trivia"
+	"microgame-bot/internal/domain/user"
+	gM "microgame-bot/internal/repo/game"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type triviaPlayers []triviaPlayer
+
+type triviaPlayer struct {
+	Number   int       `json:"number"`
+	ID       uuid.UUID `json:"id"`
+	IsWinner bool      `json:"is_winner"`
+	Score    int       `json:"score"`
+}
+
+// triviaData keeps the questions drawn for the game, so a changed pack doesn't affect the games in progress.
+type triviaData struct {
+	AskedAt         time.Time          `json:"asked_at"`
+	Filter          triviaD.Filter     `json:"filter"`
+	Questions       []triviaD.Question `json:"questions"`
+	Answers         []triviaD.Answer   `json:"answers"`
+	Current         int                `json:"current"`
+	QuestionTimeout time.Duration      `json:"question_timeout"`
+	WinnerID        uuid.UUID          `json:"winner"`
+}
+
+func (Repository) FromDomain(gm gM.Game, dm triviaD.Trivia) (gM.Game, error) {
+	const operationName = "repo::game::trivia::model::FromDomain"
+	players, err := json.Marshal(triviaPlayers{
+		triviaPlayerFromDomain(dm, dm.Player1ID(), 1),
+		//nolint:mnd // Player number is constant.
+		triviaPlayerFromDomain(dm, dm.Player2ID(), 2),
+	})
+	if err != nil {
+		return gM.Game{}, fmt.Errorf("failed to marshal players in %s: %w", operationName, err)
+	}
+
+	data, err := json.Marshal(triviaData{
+		AskedAt:         dm.AskedAt(),
+		Filter:          dm.Filter(),
+		Questions:       dm.Questions(),
+		Answers:         dm.Answers(),
+		Current:         dm.Current(),
+		QuestionTimeout: dm.QuestionTimeout(),
+		WinnerID:        dm.WinnerID().UUID(),
+	})
+	if err != nil {
+		return gM.Game{}, fmt.Errorf("failed to marshal data in %s: %w", operationName, err)
+	}
+	gm = gm.SetCommonFields(dm)
+	gm.Players = players
+	gm.Data = data
+
+	return gm, nil
+}
+
+func (Repository) ToDomain(gm gM.Game) (triviaD.Trivia, error) {
+	const operationName = "repo::game::trivia::model::ToDomain"
+	var players triviaPlayers
+	var data triviaData
+	err := gm.DecodeBinaryFields(gm.Players, &players, gm.Data, &data)
+	if err != nil {
+		return triviaD.Trivia{}, fmt.Errorf("failed to decode binary fields in %s: %w", operationName, err)
+	}
+	player1 := triviaPlayerByNumber(players, 1)
+	//nolint:mnd // Player number is constant.
+	player2 := triviaPlayerByNumber(players, 2)
+
+	model, err := triviaD.New(
+		// common fields
+		triviaD.WithIDFromUUID(gm.ID),
+		triviaD.WithCreatorID(gm.CreatorID),
+		triviaD.WithStatus(gm.Status),
+		triviaD.WithSessionID(gm.SessionID),
+		triviaD.WithCreatedAt(gm.CreatedAt),
+		triviaD.WithUpdatedAt(gm.UpdatedAt),
+		// game-specific fields
+		triviaD.WithFilter(data.Filter),
+		triviaD.WithQuestions(data.Questions),
+		triviaD.WithAnswers(data.Answers),
+		triviaD.WithCurrent(data.Current),
+		triviaD.WithAskedAt(data.AskedAt),
+		triviaD.WithQuestionTimeout(data.QuestionTimeout),
+		triviaD.WithWinnerIDFromUUID(data.WinnerID),
+		triviaD.WithPlayer1IDFromUUID(player1.ID),
+		triviaD.WithPlayer2IDFromUUID(player2.ID),
+	)
+	if err != nil {
+		return triviaD.Trivia{}, fmt.Errorf("failed to create Trivia in %s: %w", operationName, err)
+	}
+	return model, nil
+}
+
+func triviaPlayerByNumber(players triviaPlayers, number int) triviaPlayer {
+	for _, player := range players {
+		if player.Number == number {
+			return player
+		}
+	}
+	return triviaPlayer{}
+}
+
+func triviaPlayerFromDomain(dm triviaD.Trivia, id user.ID, number int) triviaPlayer {
+	return triviaPlayer{
+		ID:       id.UUID(),
+		Number:   number,
+		IsWinner: dm.WinnerID() == id,
+		Score:    dm.ScoreOf(id),
+	}
+}
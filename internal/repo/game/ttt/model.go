@@ -4,7 +4,9 @@ import (
 	"encoding/json"
 	"fmt"
 	tttD "microgame-bot/internal/domain/ttt"
+	"microgame-bot/internal/domain/user"
 	gM "microgame-bot/internal/repo/game"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -16,10 +18,20 @@ type tttPlayer struct {
 	IsWinner bool      `json:"is_winner"`
 }
 
+type tttMove struct {
+	At       time.Time `json:"at"`
+	PlayerID uuid.UUID `json:"player_id"`
+	Cell     tttD.Cell `json:"cell"`
+	Row      int       `json:"row"`
+	Col      int       `json:"col"`
+}
+
 type tttData struct {
 	Board    tttD.Board `json:"board"`
 	WinnerID uuid.UUID  `json:"winner"`
 	Turn     uuid.UUID  `json:"turn"`
+	Seed     string     `json:"seed,omitempty"`
+	Moves    []tttMove  `json:"moves,omitempty"`
 }
 
 func (Repository) FromDomain(gm gM.Game, dm tttD.TTT) (gM.Game, error) {
@@ -43,6 +55,8 @@ func (Repository) FromDomain(gm gM.Game, dm tttD.TTT) (gM.Game, error) {
 		WinnerID: dm.WinnerID().UUID(),
 		Board:    dm.Board(),
 		Turn:     dm.Turn().UUID(),
+		Seed:     dm.Seed(),
+		Moves:    tttMovesFromDomain(dm.Moves()),
 	})
 	if err != nil {
 		return gM.Game{}, fmt.Errorf("failed to marshal data in %s: %w", operationName, err)
@@ -80,6 +94,8 @@ func (Repository) ToDomain(gm gM.Game) (tttD.TTT, error) {
 		tttD.WithBoard(data.Board),
 		tttD.WithTurnFromUUID(data.Turn),
 		tttD.WithWinnerIDFromUUID(data.WinnerID),
+		tttD.WithSeed(data.Seed),
+		tttD.WithMoves(tttMovesToDomain(data.Moves)),
 	)
 	if err != nil {
 		return tttD.TTT{}, fmt.Errorf("failed to create TTT in %s: %w", operationName, err)
@@ -95,3 +111,31 @@ func tttPlayerBySymbol(players tttPlayers, symbol tttD.Cell) tttPlayer {
 	}
 	return tttPlayer{}
 }
+
+func tttMovesFromDomain(moves []tttD.Move) []tttMove {
+	result := make([]tttMove, len(moves))
+	for i, move := range moves {
+		result[i] = tttMove{
+			At:       move.At,
+			PlayerID: move.PlayerID.UUID(),
+			Cell:     move.Cell,
+			Row:      move.Row,
+			Col:      move.Col,
+		}
+	}
+	return result
+}
+
+func tttMovesToDomain(moves []tttMove) []tttD.Move {
+	result := make([]tttD.Move, len(moves))
+	for i, move := range moves {
+		result[i] = tttD.Move{
+			At:       move.At,
+			PlayerID: user.ID(move.PlayerID),
+			Cell:     move.Cell,
+			Row:      move.Row,
+			Col:      move.Col,
+		}
+	}
+	return result
+}
@@ -111,6 +111,7 @@ func (r *Repository) gamesBySessionID(
 	const operationName = "repo::ttt::gorm::gamesBySessionID"
 	models, err := gorm.G[gM.Game](r.db, opts...).
 		Where("session_id = ?", id.String()).
+		Order("created_at ASC").
 		Find(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get games by session ID from gorm database in %s: %w", operationName, err)
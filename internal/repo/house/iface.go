@@ -0,0 +1,21 @@
+package house
+
+import (
+	"context"
+	"microgame-bot/internal/domain/house"
+)
+
+type IAccountGetter interface {
+	// AccountLocked returns the account with row lock (SELECT FOR UPDATE), opening it with zero balance
+	// on first use. Must be called within transaction
+	AccountLocked(ctx context.Context, id house.AccountID) (house.Account, error)
+}
+
+type IAccountUpdater interface {
+	UpdateAccount(ctx context.Context, a house.Account) (house.Account, error)
+}
+
+type IAccountRepository interface {
+	IAccountGetter
+	IAccountUpdater
+}
@@ -0,0 +1,37 @@
+package house
+
+import (
+	domainHouse "microgame-bot/internal/domain/house"
+	"time"
+)
+
+type Account struct {
+	CreatedAt time.Time `gorm:"not null"`
+	UpdatedAt time.Time `gorm:"not null"`
+	ID        string    `gorm:"primaryKey"`
+	Balance   int64     `gorm:"not null"`
+}
+
+func (Account) TableName() string {
+	return "house_accounts"
+}
+
+// ToDomain TODO: add tests
+func (m Account) ToDomain() (domainHouse.Account, error) {
+	return domainHouse.New(
+		domainHouse.WithID(domainHouse.AccountID(m.ID)),
+		domainHouse.WithBalance(m.Balance),
+		domainHouse.WithCreatedAt(m.CreatedAt),
+		domainHouse.WithUpdatedAt(m.UpdatedAt),
+	)
+}
+
+// FromDomain TODO: add tests
+func (Account) FromDomain(a domainHouse.Account) Account {
+	return Account{
+		ID:        a.ID().String(),
+		Balance:   a.Balance(),
+		CreatedAt: a.CreatedAt(),
+		UpdatedAt: a.UpdatedAt(),
+	}
+}
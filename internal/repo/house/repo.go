@@ -0,0 +1,62 @@
+package house
+
+import (
+	"context"
+	"fmt"
+	"microgame-bot/internal/domain/house"
+	"microgame-bot/internal/repo"
+	"microgame-bot/internal/utils"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Repository struct {
+	db *gorm.DB
+}
+
+func New(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+func (r *Repository) AccountLocked(ctx context.Context, id house.AccountID) (house.Account, error) {
+	const operationName = "repo::house::gorm::AccountLocked"
+	if !utils.IsInGormTransaction(r.db) {
+		return house.Account{}, repo.ErrNotInTransaction
+	}
+
+	now := time.Now()
+	opened := Account{ID: id.String(), CreatedAt: now, UpdatedAt: now}
+	err := gorm.G[Account](r.db, clause.OnConflict{DoNothing: true}).Create(ctx, &opened)
+	if err != nil {
+		return house.Account{}, fmt.Errorf("failed to open house account in %s: %w", operationName, err)
+	}
+
+	model, err := gorm.G[Account](r.db, clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", id.String()).
+		First(ctx)
+	if err != nil {
+		return house.Account{}, fmt.Errorf("failed to get house account in %s: %w", operationName, err)
+	}
+	return model.ToDomain()
+}
+
+func (r *Repository) UpdateAccount(ctx context.Context, a house.Account) (house.Account, error) {
+	const operationName = "repo::house::gorm::UpdateAccount"
+	model := Account{}.FromDomain(a)
+	// Balance is selected explicitly, Updates skips zero values and the house may break even.
+	_, err := gorm.G[Account](r.db).
+		Where("id = ?", model.ID).
+		Select("balance", "updated_at").
+		Updates(ctx, Account{Balance: model.Balance, UpdatedAt: time.Now()})
+	if err != nil {
+		return house.Account{}, fmt.Errorf("failed to update house account in %s: %w", operationName, err)
+	}
+
+	model, err = gorm.G[Account](r.db).Where("id = ?", model.ID).First(ctx)
+	if err != nil {
+		return house.Account{}, fmt.Errorf("failed to get house account in %s: %w", operationName, err)
+	}
+	return model.ToDomain()
+}
@@ -0,0 +1,30 @@
+package league
+
+import (
+	"context"
+	"microgame-bot/internal/domain"
+	"microgame-bot/internal/domain/league"
+)
+
+type ILeagueGetter interface {
+	LeagueByID(ctx context.Context, id league.ID) (league.League, error)
+	// LeagueByIDLocked returns league with row lock (SELECT FOR UPDATE)
+	// Must be called within transaction
+	LeagueByIDLocked(ctx context.Context, id league.ID) (league.League, error)
+	// LeaguesByStatus returns leagues in any of the given statuses, oldest first
+	LeaguesByStatus(ctx context.Context, statuses ...domain.GameStatus) ([]league.League, error)
+}
+
+type ILeagueCreator interface {
+	CreateLeague(ctx context.Context, l league.League) (league.League, error)
+}
+
+type ILeagueUpdater interface {
+	UpdateLeague(ctx context.Context, l league.League) (league.League, error)
+}
+
+type ILeagueRepository interface {
+	ILeagueCreator
+	ILeagueUpdater
+	ILeagueGetter
+}
@@ -0,0 +1,138 @@
+package league
+
+import (
+	"encoding/json"
+	"fmt"
+	"microgame-bot/internal/domain"
+	domainLeague "microgame-bot/internal/domain/league"
+	se "microgame-bot/internal/domain/session"
+	"microgame-bot/internal/domain/user"
+	uM "microgame-bot/internal/repo/user"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type League struct {
+	CreatedAt       time.Time              `gorm:"not null"`
+	UpdatedAt       time.Time              `gorm:"not null"`
+	Deadline        time.Time              `gorm:"not null"`
+	Organizer       uM.User                `gorm:"foreignKey:OrganizerID;references:ID;constraint:OnDelete:RESTRICT"`
+	GameType        domain.GameType        `gorm:"not null"`
+	InlineMessageID domain.InlineMessageID `gorm:"not null;uniqueIndex"`
+	Status          domain.GameStatus      `gorm:"not null;index"`
+	Players         []byte                 `gorm:"type:jsonb"`
+	Fixtures        []byte                 `gorm:"type:jsonb"`
+	GameCount       int                    `gorm:"not null"`
+	Duration        time.Duration          `gorm:"not null;type:bigint"`
+	ID              uuid.UUID              `gorm:"primaryKey;type:uuid"`
+	OrganizerID     user.ID                `gorm:"type:uuid;not null"`
+}
+
+type leagueFixture struct {
+	Home      uuid.UUID                  `json:"home"`
+	Away      uuid.UUID                  `json:"away"`
+	Winner    uuid.UUID                  `json:"winner"`
+	SessionID uuid.UUID                  `json:"session_id"`
+	Result    domainLeague.FixtureResult `json:"result"`
+	Round     int                        `json:"round"`
+}
+
+func (League) TableName() string {
+	return "leagues"
+}
+
+// ToDomain TODO: add tests
+func (m League) ToDomain() (domainLeague.League, error) {
+	const operationName = "repo::league::model::ToDomain"
+
+	var players []uuid.UUID
+	if len(m.Players) > 0 {
+		if err := json.Unmarshal(m.Players, &players); err != nil {
+			return domainLeague.League{}, fmt.Errorf("failed to unmarshal players in %s: %w", operationName, err)
+		}
+	}
+	var fixtures []leagueFixture
+	if len(m.Fixtures) > 0 {
+		if err := json.Unmarshal(m.Fixtures, &fixtures); err != nil {
+			return domainLeague.League{}, fmt.Errorf("failed to unmarshal fixtures in %s: %w", operationName, err)
+		}
+	}
+
+	playerIDs := make([]user.ID, len(players))
+	for i, player := range players {
+		playerIDs[i] = user.ID(player)
+	}
+	domainFixtures := make([]domainLeague.Fixture, len(fixtures))
+	for i, fixture := range fixtures {
+		domainFixtures[i] = domainLeague.Fixture{
+			Home:      user.ID(fixture.Home),
+			Away:      user.ID(fixture.Away),
+			Winner:    user.ID(fixture.Winner),
+			SessionID: se.ID(fixture.SessionID),
+			Result:    fixture.Result,
+			Round:     fixture.Round,
+		}
+	}
+
+	return domainLeague.New(
+		domainLeague.WithIDFromUUID(m.ID),
+		domainLeague.WithOrganizerID(m.OrganizerID),
+		domainLeague.WithGameType(m.GameType),
+		domainLeague.WithInlineMessageID(m.InlineMessageID),
+		domainLeague.WithStatus(m.Status),
+		domainLeague.WithGameCount(m.GameCount),
+		domainLeague.WithDuration(m.Duration),
+		domainLeague.WithDeadline(m.Deadline),
+		domainLeague.WithPlayers(playerIDs),
+		domainLeague.WithFixtures(domainFixtures),
+		domainLeague.WithCreatedAt(m.CreatedAt),
+		domainLeague.WithUpdatedAt(m.UpdatedAt),
+	)
+}
+
+// FromDomain TODO: add tests
+func (League) FromDomain(l domainLeague.League) (League, error) {
+	const operationName = "repo::league::model::FromDomain"
+
+	playerIDs := make([]uuid.UUID, 0, len(l.Players()))
+	for _, player := range l.Players() {
+		playerIDs = append(playerIDs, player.UUID())
+	}
+	players, err := json.Marshal(playerIDs)
+	if err != nil {
+		return League{}, fmt.Errorf("failed to marshal players in %s: %w", operationName, err)
+	}
+
+	domainFixtures := l.Fixtures()
+	fixtures := make([]leagueFixture, len(domainFixtures))
+	for i, fixture := range domainFixtures {
+		fixtures[i] = leagueFixture{
+			Home:      fixture.Home.UUID(),
+			Away:      fixture.Away.UUID(),
+			Winner:    fixture.Winner.UUID(),
+			SessionID: uuid.UUID(fixture.SessionID),
+			Result:    fixture.Result,
+			Round:     fixture.Round,
+		}
+	}
+	fixturesData, err := json.Marshal(fixtures)
+	if err != nil {
+		return League{}, fmt.Errorf("failed to marshal fixtures in %s: %w", operationName, err)
+	}
+
+	return League{
+		ID:              l.ID().UUID(),
+		OrganizerID:     l.OrganizerID(),
+		GameType:        l.GameType(),
+		InlineMessageID: l.InlineMessageID(),
+		Status:          l.Status(),
+		GameCount:       l.GameCount(),
+		Duration:        l.Duration(),
+		Deadline:        l.Deadline(),
+		Players:         players,
+		Fixtures:        fixturesData,
+		CreatedAt:       l.CreatedAt(),
+		UpdatedAt:       l.UpdatedAt(),
+	}, nil
+}
@@ -0,0 +1,93 @@
+package league
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"microgame-bot/internal/domain"
+	"microgame-bot/internal/domain/league"
+	"microgame-bot/internal/repo"
+	"microgame-bot/internal/utils"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Repository struct {
+	db *gorm.DB
+}
+
+func New(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+func (r *Repository) CreateLeague(ctx context.Context, l league.League) (league.League, error) {
+	model, err := League{}.FromDomain(l)
+	if err != nil {
+		return league.League{}, fmt.Errorf("failed to convert league domain model to gorm model: %w", err)
+	}
+	if err := gorm.G[League](r.db).Create(ctx, &model); err != nil {
+		return league.League{}, err
+	}
+	return model.ToDomain()
+}
+
+func (r *Repository) UpdateLeague(ctx context.Context, l league.League) (league.League, error) {
+	model, err := League{}.FromDomain(l)
+	if err != nil {
+		return league.League{}, fmt.Errorf("failed to convert league domain model to gorm model: %w", err)
+	}
+	_, err = gorm.G[League](r.db).Where("id = ?", model.ID.String()).Updates(ctx, model)
+	if err != nil {
+		return league.League{}, fmt.Errorf("failed to update league in gorm database: %w", err)
+	}
+	return r.leagueByID(ctx, model.ID.String())
+}
+
+func (r *Repository) LeagueByID(ctx context.Context, id league.ID) (league.League, error) {
+	return r.leagueByID(ctx, id.String())
+}
+
+func (r *Repository) LeagueByIDLocked(ctx context.Context, id league.ID) (league.League, error) {
+	if !utils.IsInGormTransaction(r.db) {
+		return league.League{}, repo.ErrNotInTransaction
+	}
+	return r.leagueByID(ctx, id.String(), clause.Locking{Strength: "UPDATE"})
+}
+
+func (r *Repository) LeaguesByStatus(ctx context.Context, statuses ...domain.GameStatus) ([]league.League, error) {
+	const operationName = "repo::league::gorm::LeaguesByStatus"
+	models, err := gorm.G[League](r.db).
+		Where("status IN (?)", statuses).
+		Order("created_at ASC").
+		Find(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get leagues by status from gorm database in %s: %w", operationName, err)
+	}
+	results := make([]league.League, len(models))
+	for i, model := range models {
+		results[i], err = model.ToDomain()
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert model to domain in %s: %w", operationName, err)
+		}
+	}
+	return results, nil
+}
+
+func (r *Repository) leagueByID(ctx context.Context, id string, opts ...clause.Expression) (league.League, error) {
+	const operationName = "repo::league::gorm::leagueByID"
+	model, err := gorm.G[League](r.db, opts...).
+		Where("id = ?", id).
+		First(ctx)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return league.League{}, fmt.Errorf(
+				"league not found in %s: %w",
+				operationName,
+				domain.ErrLeagueNotFound,
+			)
+		}
+		return league.League{}, fmt.Errorf("failed to get league from gorm database in %s: %w", operationName, err)
+	}
+	return model.ToDomain()
+}
@@ -0,0 +1,40 @@
+package matchmaking
+
+import (
+	"context"
+	"microgame-bot/internal/domain/matchmaking"
+	"microgame-bot/internal/domain/session"
+	"microgame-bot/internal/domain/user"
+)
+
+type ITicketGetter interface {
+	// TicketByIDLocked returns ticket with row lock (SELECT FOR UPDATE)
+	// Must be called within transaction
+	TicketByIDLocked(ctx context.Context, id matchmaking.ID) (matchmaking.Ticket, error)
+	// TicketByMessage returns the ticket bound to the private chat message
+	TicketByMessage(ctx context.Context, message matchmaking.Message) (matchmaking.Ticket, error)
+	// TicketsByStatus returns tickets in any of the given statuses, oldest first
+	TicketsByStatus(ctx context.Context, statuses ...matchmaking.TicketStatus) ([]matchmaking.Ticket, error)
+	// TicketsByUserID returns tickets of the player in any of the given statuses
+	TicketsByUserID(
+		ctx context.Context,
+		userID user.ID,
+		statuses ...matchmaking.TicketStatus,
+	) ([]matchmaking.Ticket, error)
+	// TicketsBySessionID returns both tickets matched into the session
+	TicketsBySessionID(ctx context.Context, sessionID session.ID) ([]matchmaking.Ticket, error)
+}
+
+type ITicketCreator interface {
+	CreateTicket(ctx context.Context, t matchmaking.Ticket) (matchmaking.Ticket, error)
+}
+
+type ITicketUpdater interface {
+	UpdateTicket(ctx context.Context, t matchmaking.Ticket) (matchmaking.Ticket, error)
+}
+
+type ITicketRepository interface {
+	ITicketCreator
+	ITicketUpdater
+	ITicketGetter
+}
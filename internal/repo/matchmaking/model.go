@@ -0,0 +1,64 @@
+package matchmaking
+
+import (
+	"microgame-bot/internal/domain"
+	domainMatchmaking "microgame-bot/internal/domain/matchmaking"
+	se "microgame-bot/internal/domain/session"
+	"microgame-bot/internal/domain/user"
+	uM "microgame-bot/internal/repo/user"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Ticket struct {
+	CreatedAt  time.Time                      `gorm:"not null"`
+	UpdatedAt  time.Time                      `gorm:"not null"`
+	User       uM.User                        `gorm:"foreignKey:UserID;references:ID;constraint:OnDelete:RESTRICT"`
+	GameType   domain.GameType                `gorm:"not null"`
+	Status     domainMatchmaking.TicketStatus `gorm:"not null;index"`
+	Stake      uint64                         `gorm:"not null"`
+	ChatID     int64                          `gorm:"not null;index:idx_matchmaking_tickets_message"`
+	MessageID  int                            `gorm:"not null;index:idx_matchmaking_tickets_message"`
+	OpponentID uuid.NullUUID                  `gorm:"type:uuid"`
+	SessionID  uuid.NullUUID                  `gorm:"type:uuid;index"`
+	ID         uuid.UUID                      `gorm:"primaryKey;type:uuid"`
+	UserID     user.ID                        `gorm:"type:uuid;not null;index"`
+}
+
+func (Ticket) TableName() string {
+	return "matchmaking_tickets"
+}
+
+// ToDomain TODO: add tests
+func (m Ticket) ToDomain() (domainMatchmaking.Ticket, error) {
+	return domainMatchmaking.New(
+		domainMatchmaking.WithIDFromUUID(m.ID),
+		domainMatchmaking.WithUserID(m.UserID),
+		domainMatchmaking.WithOpponentID(user.ID(m.OpponentID.UUID)),
+		domainMatchmaking.WithSessionID(se.ID(m.SessionID.UUID)),
+		domainMatchmaking.WithGameType(m.GameType),
+		domainMatchmaking.WithStatus(m.Status),
+		domainMatchmaking.WithStake(domain.Token(m.Stake)),
+		domainMatchmaking.WithMessage(domainMatchmaking.Message{ChatID: m.ChatID, MessageID: m.MessageID}),
+		domainMatchmaking.WithCreatedAt(m.CreatedAt),
+		domainMatchmaking.WithUpdatedAt(m.UpdatedAt),
+	)
+}
+
+// FromDomain TODO: add tests
+func (Ticket) FromDomain(t domainMatchmaking.Ticket) Ticket {
+	return Ticket{
+		ID:         t.ID().UUID(),
+		UserID:     t.UserID(),
+		OpponentID: uuid.NullUUID{UUID: t.OpponentID().UUID(), Valid: !t.OpponentID().IsZero()},
+		SessionID:  uuid.NullUUID{UUID: uuid.UUID(t.SessionID()), Valid: !t.SessionID().IsZero()},
+		GameType:   t.GameType(),
+		Status:     t.Status(),
+		Stake:      uint64(t.Stake()),
+		ChatID:     t.Message().ChatID,
+		MessageID:  t.Message().MessageID,
+		CreatedAt:  t.CreatedAt(),
+		UpdatedAt:  t.UpdatedAt(),
+	}
+}
@@ -0,0 +1,130 @@
+package matchmaking
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"microgame-bot/internal/domain"
+	"microgame-bot/internal/domain/matchmaking"
+	"microgame-bot/internal/domain/session"
+	"microgame-bot/internal/domain/user"
+	"microgame-bot/internal/repo"
+	"microgame-bot/internal/utils"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Repository struct {
+	db *gorm.DB
+}
+
+func New(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+func (r *Repository) CreateTicket(ctx context.Context, t matchmaking.Ticket) (matchmaking.Ticket, error) {
+	model := Ticket{}.FromDomain(t)
+	if err := gorm.G[Ticket](r.db).Create(ctx, &model); err != nil {
+		return matchmaking.Ticket{}, err
+	}
+	return model.ToDomain()
+}
+
+func (r *Repository) UpdateTicket(ctx context.Context, t matchmaking.Ticket) (matchmaking.Ticket, error) {
+	model := Ticket{}.FromDomain(t)
+	_, err := gorm.G[Ticket](r.db).Where("id = ?", model.ID.String()).Updates(ctx, model)
+	if err != nil {
+		return matchmaking.Ticket{}, fmt.Errorf("failed to update ticket in gorm database: %w", err)
+	}
+	return r.ticket(ctx, "id = ?", []any{model.ID.String()})
+}
+
+func (r *Repository) TicketByIDLocked(ctx context.Context, id matchmaking.ID) (matchmaking.Ticket, error) {
+	if !utils.IsInGormTransaction(r.db) {
+		return matchmaking.Ticket{}, repo.ErrNotInTransaction
+	}
+	return r.ticket(ctx, "id = ?", []any{id.String()}, clause.Locking{Strength: "UPDATE"})
+}
+
+func (r *Repository) TicketByMessage(ctx context.Context, message matchmaking.Message) (matchmaking.Ticket, error) {
+	return r.ticket(ctx, "chat_id = ? AND message_id = ?", []any{message.ChatID, message.MessageID})
+}
+
+func (r *Repository) TicketsByStatus(
+	ctx context.Context,
+	statuses ...matchmaking.TicketStatus,
+) ([]matchmaking.Ticket, error) {
+	const operationName = "repo::matchmaking::gorm::TicketsByStatus"
+	models, err := gorm.G[Ticket](r.db).
+		Where("status IN (?)", statuses).
+		Order("created_at ASC").
+		Find(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tickets by status from gorm database in %s: %w", operationName, err)
+	}
+	return toDomainTickets(models, operationName)
+}
+
+func (r *Repository) TicketsByUserID(
+	ctx context.Context,
+	userID user.ID,
+	statuses ...matchmaking.TicketStatus,
+) ([]matchmaking.Ticket, error) {
+	const operationName = "repo::matchmaking::gorm::TicketsByUserID"
+	models, err := gorm.G[Ticket](r.db).
+		Where("user_id = ? AND status IN (?)", userID.String(), statuses).
+		Order("created_at ASC").
+		Find(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tickets by user ID from gorm database in %s: %w", operationName, err)
+	}
+	return toDomainTickets(models, operationName)
+}
+
+func (r *Repository) TicketsBySessionID(ctx context.Context, sessionID session.ID) ([]matchmaking.Ticket, error) {
+	const operationName = "repo::matchmaking::gorm::TicketsBySessionID"
+	models, err := gorm.G[Ticket](r.db).
+		Where("session_id = ?", sessionID.String()).
+		Order("created_at ASC").
+		Find(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tickets by session ID from gorm database in %s: %w", operationName, err)
+	}
+	return toDomainTickets(models, operationName)
+}
+
+func (r *Repository) ticket(
+	ctx context.Context,
+	query string,
+	args []any,
+	opts ...clause.Expression,
+) (matchmaking.Ticket, error) {
+	const operationName = "repo::matchmaking::gorm::ticket"
+	model, err := gorm.G[Ticket](r.db, opts...).
+		Where(query, args...).
+		First(ctx)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return matchmaking.Ticket{}, fmt.Errorf(
+				"ticket not found in %s: %w",
+				operationName,
+				domain.ErrTicketNotFound,
+			)
+		}
+		return matchmaking.Ticket{}, fmt.Errorf("failed to get ticket from gorm database in %s: %w", operationName, err)
+	}
+	return model.ToDomain()
+}
+
+func toDomainTickets(models []Ticket, operationName string) ([]matchmaking.Ticket, error) {
+	results := make([]matchmaking.Ticket, len(models))
+	for i, model := range models {
+		var err error
+		results[i], err = model.ToDomain()
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert model to domain in %s: %w", operationName, err)
+		}
+	}
+	return results, nil
+}
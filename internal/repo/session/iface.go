@@ -5,6 +5,8 @@ import (
 	"microgame-bot/internal/domain"
 	se "microgame-bot/internal/domain/session"
 	"time"
+
+	"github.com/google/uuid"
 )
 
 type ISessionGetter interface {
@@ -13,6 +15,8 @@ type ISessionGetter interface {
 	SessionByIDLocked(ctx context.Context, id se.ID) (se.Session, error)
 	// FindOldInProgressSessions finds sessions in progress that haven't been updated within the given duration
 	FindOldInProgressSession(ctx context.Context, timeout time.Duration) (se.Session, error)
+	// SessionsByLeagueID returns the fixture sessions of the league
+	SessionsByLeagueID(ctx context.Context, leagueID uuid.UUID) ([]se.Session, error)
 }
 
 type ISessionCreator interface {
@@ -4,6 +4,8 @@ import (
 	"microgame-bot/internal/domain"
 	se "microgame-bot/internal/domain/session"
 	"time"
+
+	"github.com/google/uuid"
 )
 
 type Session struct {
@@ -15,6 +17,10 @@ type Session struct {
 	WinCondition    se.WinCondition        `gorm:"not null"`
 	GameCount       int                    `gorm:"not null"`
 	Bet             uint64                 `gorm:"not null"`
+	MoveTimeout     time.Duration          `gorm:"not null;default:0;type:bigint"`
+	JoinTimeout     time.Duration          `gorm:"not null;default:0;type:bigint"`
+	Seed            string                 `gorm:"not null;default:''"`
+	LeagueID        uuid.NullUUID          `gorm:"type:uuid;index"`
 	ID              se.ID                  `gorm:"primaryKey;type:uuid"`
 }
 
@@ -30,6 +36,10 @@ func (m Session) ToDomain() (se.Session, error) {
 		se.WithUpdatedAt(m.UpdatedAt),
 		se.WithInlineMessageID(m.InlineMessageID),
 		se.WithWinCondition(m.WinCondition),
+		se.WithMoveTimeout(m.MoveTimeout),
+		se.WithJoinTimeout(m.JoinTimeout),
+		se.WithSeed(m.Seed),
+		se.WithLeagueID(m.LeagueID.UUID),
 	)
 }
 
@@ -45,5 +55,9 @@ func (Session) FromDomain(u se.Session) Session {
 		UpdatedAt:       u.UpdatedAt(),
 		InlineMessageID: u.InlineMessageID(),
 		WinCondition:    u.WinCondition(),
+		MoveTimeout:     u.MoveTimeout(),
+		JoinTimeout:     u.JoinTimeout(),
+		Seed:            u.Seed(),
+		LeagueID:        uuid.NullUUID{UUID: u.LeagueID(), Valid: u.IsLeagueFixture()},
 	}
 }
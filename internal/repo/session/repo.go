@@ -10,6 +10,7 @@ import (
 	"microgame-bot/internal/utils"
 	"time"
 
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
@@ -92,6 +93,27 @@ func (r *Repository) SessionByMessageID(ctx context.Context, id domain.InlineMes
 	return model.ToDomain()
 }
 
+func (r *Repository) SessionsByLeagueID(ctx context.Context, leagueID uuid.UUID) ([]se.Session, error) {
+	const operationName = "repo::session::gorm::SessionsByLeagueID"
+	models, err := gorm.G[Session](r.db).
+		Where("league_id = ?", leagueID).
+		Order("created_at ASC").
+		Find(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get league sessions in %s: %w", operationName, err)
+	}
+
+	sessions := make([]se.Session, 0, len(models))
+	for _, model := range models {
+		session, err := model.ToDomain()
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert session in %s: %w", operationName, err)
+		}
+		sessions = append(sessions, session)
+	}
+	return sessions, nil
+}
+
 func (r *Repository) FindOldInProgressSession(ctx context.Context, timeout time.Duration) (se.Session, error) {
 	const operationName = "repo::session::FindOldInProgressSessions"
 
@@ -110,6 +132,8 @@ func (r *Repository) FindOldInProgressSession(ctx context.Context, timeout time.
 	model, err := gorm.G[Session](r.db, clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
 		Where("status IN (?)", statuses).
 		Where("updated_at < ?", cutoffTime).
+		// Prize pool sessions have no games, tournaments expire them on their own.
+		Where("game_type <> ?", domain.GameTypeTournament).
 		First(ctx)
 
 	if err != nil {
@@ -0,0 +1,33 @@
+package tournament
+
+import (
+	"context"
+	"microgame-bot/internal/domain"
+	se "microgame-bot/internal/domain/session"
+	"microgame-bot/internal/domain/tournament"
+)
+
+type ITournamentGetter interface {
+	TournamentByID(ctx context.Context, id tournament.ID) (tournament.Tournament, error)
+	// TournamentByIDLocked returns tournament with row lock (SELECT FOR UPDATE)
+	// Must be called within transaction
+	TournamentByIDLocked(ctx context.Context, id tournament.ID) (tournament.Tournament, error)
+	// TournamentBySessionID returns the tournament whose prize pool is held by the session
+	TournamentBySessionID(ctx context.Context, id se.ID) (tournament.Tournament, error)
+	// TournamentsByStatus returns tournaments in any of the given statuses, oldest first
+	TournamentsByStatus(ctx context.Context, statuses ...domain.GameStatus) ([]tournament.Tournament, error)
+}
+
+type ITournamentCreator interface {
+	CreateTournament(ctx context.Context, t tournament.Tournament) (tournament.Tournament, error)
+}
+
+type ITournamentUpdater interface {
+	UpdateTournament(ctx context.Context, t tournament.Tournament) (tournament.Tournament, error)
+}
+
+type ITournamentRepository interface {
+	ITournamentCreator
+	ITournamentUpdater
+	ITournamentGetter
+}
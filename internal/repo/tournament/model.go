@@ -0,0 +1,146 @@
+package tournament
+
+import (
+	"encoding/json"
+	"fmt"
+	"microgame-bot/internal/domain"
+	se "microgame-bot/internal/domain/session"
+	domainTournament "microgame-bot/internal/domain/tournament"
+	"microgame-bot/internal/domain/user"
+	seM "microgame-bot/internal/repo/session"
+	uM "microgame-bot/internal/repo/user"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Tournament struct {
+	CreatedAt       time.Time              `gorm:"not null"`
+	UpdatedAt       time.Time              `gorm:"not null"`
+	Session         seM.Session            `gorm:"not null;foreignKey:SessionID;references:ID;constraint:OnDelete:RESTRICT"`
+	Organizer       uM.User                `gorm:"foreignKey:OrganizerID;references:ID;constraint:OnDelete:RESTRICT"`
+	GameType        domain.GameType        `gorm:"not null"`
+	InlineMessageID domain.InlineMessageID `gorm:"not null;uniqueIndex"`
+	Status          domain.GameStatus      `gorm:"not null;index"`
+	Seed            string                 `gorm:"not null;default:''"`
+	Players         []byte                 `gorm:"type:jsonb"`
+	Matches         []byte                 `gorm:"type:jsonb"`
+	Size            int                    `gorm:"not null"`
+	GameCount       int                    `gorm:"not null"`
+	EntryFee        uint64                 `gorm:"not null"`
+	ID              uuid.UUID              `gorm:"primaryKey;type:uuid"`
+	SessionID       se.ID                  `gorm:"type:uuid;not null;uniqueIndex"`
+	OrganizerID     user.ID                `gorm:"type:uuid;not null"`
+}
+
+type tournamentMatch struct {
+	Player1   uuid.UUID `json:"player1"`
+	Player2   uuid.UUID `json:"player2"`
+	Winner    uuid.UUID `json:"winner"`
+	SessionID uuid.UUID `json:"session_id"`
+	Round     int       `json:"round"`
+	Slot      int       `json:"slot"`
+}
+
+func (Tournament) TableName() string {
+	return "tournaments"
+}
+
+// ToDomain TODO: add tests
+func (m Tournament) ToDomain() (domainTournament.Tournament, error) {
+	const operationName = "repo::tournament::model::ToDomain"
+
+	var players []uuid.UUID
+	if len(m.Players) > 0 {
+		if err := json.Unmarshal(m.Players, &players); err != nil {
+			return domainTournament.Tournament{}, fmt.Errorf("failed to unmarshal players in %s: %w", operationName, err)
+		}
+	}
+	var matches []tournamentMatch
+	if len(m.Matches) > 0 {
+		if err := json.Unmarshal(m.Matches, &matches); err != nil {
+			return domainTournament.Tournament{}, fmt.Errorf("failed to unmarshal matches in %s: %w", operationName, err)
+		}
+	}
+
+	playerIDs := make([]user.ID, len(players))
+	for i, player := range players {
+		playerIDs[i] = user.ID(player)
+	}
+	domainMatches := make([]domainTournament.Match, len(matches))
+	for i, match := range matches {
+		domainMatches[i] = domainTournament.Match{
+			Player1:   user.ID(match.Player1),
+			Player2:   user.ID(match.Player2),
+			Winner:    user.ID(match.Winner),
+			SessionID: se.ID(match.SessionID),
+			Round:     match.Round,
+			Slot:      match.Slot,
+		}
+	}
+
+	return domainTournament.New(
+		domainTournament.WithIDFromUUID(m.ID),
+		domainTournament.WithOrganizerID(m.OrganizerID),
+		domainTournament.WithSessionID(m.SessionID),
+		domainTournament.WithGameType(m.GameType),
+		domainTournament.WithInlineMessageID(m.InlineMessageID),
+		domainTournament.WithStatus(m.Status),
+		domainTournament.WithSeed(m.Seed),
+		domainTournament.WithSize(m.Size),
+		domainTournament.WithGameCount(m.GameCount),
+		domainTournament.WithEntryFeeFromUint64(m.EntryFee),
+		domainTournament.WithPlayers(playerIDs),
+		domainTournament.WithMatches(domainMatches),
+		domainTournament.WithCreatedAt(m.CreatedAt),
+		domainTournament.WithUpdatedAt(m.UpdatedAt),
+	)
+}
+
+// FromDomain TODO: add tests
+func (Tournament) FromDomain(t domainTournament.Tournament) (Tournament, error) {
+	const operationName = "repo::tournament::model::FromDomain"
+
+	playerIDs := make([]uuid.UUID, 0, len(t.Players()))
+	for _, player := range t.Players() {
+		playerIDs = append(playerIDs, player.UUID())
+	}
+	players, err := json.Marshal(playerIDs)
+	if err != nil {
+		return Tournament{}, fmt.Errorf("failed to marshal players in %s: %w", operationName, err)
+	}
+
+	domainMatches := t.Matches()
+	matches := make([]tournamentMatch, len(domainMatches))
+	for i, match := range domainMatches {
+		matches[i] = tournamentMatch{
+			Player1:   match.Player1.UUID(),
+			Player2:   match.Player2.UUID(),
+			Winner:    match.Winner.UUID(),
+			SessionID: uuid.UUID(match.SessionID),
+			Round:     match.Round,
+			Slot:      match.Slot,
+		}
+	}
+	matchesData, err := json.Marshal(matches)
+	if err != nil {
+		return Tournament{}, fmt.Errorf("failed to marshal matches in %s: %w", operationName, err)
+	}
+
+	return Tournament{
+		ID:              t.ID().UUID(),
+		OrganizerID:     t.OrganizerID(),
+		SessionID:       t.SessionID(),
+		GameType:        t.GameType(),
+		InlineMessageID: t.InlineMessageID(),
+		Status:          t.Status(),
+		Seed:            t.Seed(),
+		Size:            t.Size(),
+		GameCount:       t.GameCount(),
+		EntryFee:        uint64(t.EntryFee()),
+		Players:         players,
+		Matches:         matchesData,
+		CreatedAt:       t.CreatedAt(),
+		UpdatedAt:       t.UpdatedAt(),
+	}, nil
+}
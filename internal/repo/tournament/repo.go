@@ -0,0 +1,106 @@
+package tournament
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"microgame-bot/internal/domain"
+	se "microgame-bot/internal/domain/session"
+	"microgame-bot/internal/domain/tournament"
+	"microgame-bot/internal/repo"
+	"microgame-bot/internal/utils"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Repository struct {
+	db *gorm.DB
+}
+
+func New(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+func (r *Repository) CreateTournament(ctx context.Context, t tournament.Tournament) (tournament.Tournament, error) {
+	model, err := Tournament{}.FromDomain(t)
+	if err != nil {
+		return tournament.Tournament{}, fmt.Errorf("failed to convert tournament domain model to gorm model: %w", err)
+	}
+	if err := gorm.G[Tournament](r.db).Create(ctx, &model); err != nil {
+		return tournament.Tournament{}, err
+	}
+	return model.ToDomain()
+}
+
+func (r *Repository) UpdateTournament(ctx context.Context, t tournament.Tournament) (tournament.Tournament, error) {
+	model, err := Tournament{}.FromDomain(t)
+	if err != nil {
+		return tournament.Tournament{}, fmt.Errorf("failed to convert tournament domain model to gorm model: %w", err)
+	}
+	_, err = gorm.G[Tournament](r.db).Where("id = ?", model.ID.String()).Updates(ctx, model)
+	if err != nil {
+		return tournament.Tournament{}, fmt.Errorf("failed to update tournament in gorm database: %w", err)
+	}
+	return r.tournamentBy(ctx, "id = ?", model.ID.String())
+}
+
+func (r *Repository) TournamentByID(ctx context.Context, id tournament.ID) (tournament.Tournament, error) {
+	return r.tournamentBy(ctx, "id = ?", id.String())
+}
+
+func (r *Repository) TournamentByIDLocked(ctx context.Context, id tournament.ID) (tournament.Tournament, error) {
+	if !utils.IsInGormTransaction(r.db) {
+		return tournament.Tournament{}, repo.ErrNotInTransaction
+	}
+	return r.tournamentBy(ctx, "id = ?", id.String(), clause.Locking{Strength: "UPDATE"})
+}
+
+func (r *Repository) TournamentBySessionID(ctx context.Context, id se.ID) (tournament.Tournament, error) {
+	return r.tournamentBy(ctx, "session_id = ?", id.String())
+}
+
+func (r *Repository) TournamentsByStatus(
+	ctx context.Context,
+	statuses ...domain.GameStatus,
+) ([]tournament.Tournament, error) {
+	const operationName = "repo::tournament::gorm::TournamentsByStatus"
+	models, err := gorm.G[Tournament](r.db).
+		Where("status IN (?)", statuses).
+		Order("created_at ASC").
+		Find(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tournaments by status from gorm database in %s: %w", operationName, err)
+	}
+	results := make([]tournament.Tournament, len(models))
+	for i, model := range models {
+		results[i], err = model.ToDomain()
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert model to domain in %s: %w", operationName, err)
+		}
+	}
+	return results, nil
+}
+
+func (r *Repository) tournamentBy(
+	ctx context.Context,
+	query string,
+	arg string,
+	opts ...clause.Expression,
+) (tournament.Tournament, error) {
+	const operationName = "repo::tournament::gorm::tournamentBy"
+	model, err := gorm.G[Tournament](r.db, opts...).
+		Where(query, arg).
+		First(ctx)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return tournament.Tournament{}, fmt.Errorf(
+				"tournament not found in %s: %w",
+				operationName,
+				domain.ErrTournamentNotFound,
+			)
+		}
+		return tournament.Tournament{}, fmt.Errorf("failed to get tournament from gorm database in %s: %w", operationName, err)
+	}
+	return model.ToDomain()
+}
@@ -3,8 +3,10 @@ package scheduler
 import (
 	"context"
 	"fmt"
+	"microgame-bot/internal/queue"
 	"microgame-bot/internal/utils"
 	"reflect"
+	"strconv"
 	"time"
 
 	"github.com/robfig/cron/v3"
@@ -13,6 +15,7 @@ import (
 
 var cronParserPattern = cron.Second | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow
 
+// CronExpression is the schedule of a recurring job, it is empty for a one-shot job.
 type CronExpression string
 
 func (e CronExpression) String() string {
@@ -30,6 +33,10 @@ func (e *CronExpression) Scan(_ context.Context, _ *schema.Field, _ reflect.Valu
 	switch value := dbValue.(type) {
 	case string:
 		expr := CronExpression(value)
+		if expr == "" {
+			*e = expr
+			return nil
+		}
 		if err := expr.Validate(); err != nil {
 			return fmt.Errorf("failed to validate cron expression: %w", err)
 		}
@@ -42,6 +49,9 @@ func (e *CronExpression) Scan(_ context.Context, _ *schema.Field, _ reflect.Valu
 
 // Value implements gorm.Serializer interface for writing to database.
 func (e CronExpression) Value(_ context.Context, _ *schema.Field, _ reflect.Value, _ any) (any, error) {
+	if e == "" {
+		return "", nil
+	}
 	if err := e.Validate(); err != nil {
 		return nil, fmt.Errorf("failed to validate CronExpression: %w", err)
 	}
@@ -53,18 +63,61 @@ type CronJobStatus string
 const (
 	CronJobStatusActive   CronJobStatus = "active"
 	CronJobStatusDisabled CronJobStatus = "disabled"
+	// CronJobStatusPaused is set at runtime, unlike disabled it survives CreateOrUpdateCronJobs.
+	CronJobStatusPaused CronJobStatus = "paused"
+	// CronJobStatusDone is a one-shot job that has run.
+	CronJobStatusDone CronJobStatus = "done"
 )
 
 type CronJob struct {
-	NextRunAt   time.Time      `gorm:"not null;index:idx_cron_active_run,where:status = 'active'"`
-	LastRunAt   time.Time      `gorm:"not null;index:idx_cron_last_run,where:status = 'active'"`
-	CreatedAt   time.Time      `gorm:"not null"`
-	UpdatedAt   time.Time      `gorm:"not null"`
-	Name        string         `gorm:"uniqueIndex:idx_cron_name;not null;size:255"`
-	Expression  CronExpression `gorm:"not null;size:255"`
-	Status      CronJobStatus  `gorm:"not null;default:active"`
-	Subject     string         `gorm:"not null;size:255"`
-	Payload     []byte         `gorm:"not null;type:jsonb"`
-	TaskTimeout time.Duration  `gorm:"not null;type:bigint"`
-	ID          utils.UniqueID `gorm:"primaryKey;type:uuid"`
+	NextRunAt  time.Time      `gorm:"not null;index:idx_cron_active_run,where:status = 'active'"`
+	LastRunAt  time.Time      `gorm:"not null;index:idx_cron_last_run,where:status = 'active'"`
+	CreatedAt  time.Time      `gorm:"not null"`
+	UpdatedAt  time.Time      `gorm:"not null"`
+	Name       string         `gorm:"uniqueIndex:idx_cron_name;not null;size:255"`
+	Expression CronExpression `gorm:"not null;size:255"`
+	Status     CronJobStatus  `gorm:"not null;default:active"`
+	Subject    string         `gorm:"not null;size:255"`
+	Payload    []byte         `gorm:"not null;type:jsonb"`
+	// Timezone is the IANA name of the timezone of the expression, the server one if it is empty.
+	Timezone string        `gorm:"not null;default:'';size:64"`
+	Misfire  MisfirePolicy `gorm:"not null;default:once;size:16"`
+	// MisfireLimit caps the missed runs fired by MisfireAll, defaultMisfireLimit if it is zero.
+	MisfireLimit int            `gorm:"not null;default:0"`
+	TaskTimeout  time.Duration  `gorm:"not null;type:bigint"`
+	ID           utils.UniqueID `gorm:"primaryKey;type:uuid"`
+}
+
+// OneShotJob is a job that publishes its task once at runAt.
+func OneShotJob(name, subject string, payload []byte, runAt time.Time) CronJob {
+	return CronJob{
+		Name:      name,
+		Subject:   subject,
+		Payload:   payload,
+		NextRunAt: runAt,
+		Status:    CronJobStatusActive,
+	}
+}
+
+// IsOneShot reports whether the job runs once at its NextRunAt instead of by an expression.
+func (j CronJob) IsOneShot() bool {
+	return j.Expression == ""
+}
+
+// task is the task the job publishes on every run, a run is skipped while the previous one is still queued.
+// The runs fired by MisfireAll are told apart by their scheduled time and the manual runs by their ID,
+// so one doesn't skip another.
+// The outcome of the task is written to the run.
+func (j CronJob) task(run CronJobRun) queue.Task {
+	key := queue.UniqueKey(j.Subject, j.Payload)
+	switch {
+	case run.Manual:
+		key += "@" + run.ID.String()
+	case j.Misfire == MisfireAll:
+		key += "@" + strconv.FormatInt(run.ScheduledAt.Unix(), 10)
+	}
+	task := queue.NewTask(j.Subject, j.Payload, time.Time{}, 0, j.TaskTimeout).WithDedupKey(key)
+	return task.Then(run.outcomeTask(RunOutcomeCompleted)).
+		OrElse(run.outcomeTask(RunOutcomeFailed)).
+		IfSkipped(run.outcomeTask(RunOutcomeDeduplicated))
 }
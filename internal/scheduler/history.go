@@ -0,0 +1,136 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"microgame-bot/internal/queue"
+	"microgame-bot/internal/utils"
+	"time"
+)
+
+// RunOutcomeSubject is the subject of the tasks that write the outcome of a run to the history,
+// RunOutcomeHandler must be registered for it.
+const RunOutcomeSubject = "scheduler.run"
+
+const (
+	// runHistoryTTL is how long the runs are kept in the history.
+	runHistoryTTL = 7 * 24 * time.Hour
+	// runHistoryPruneInterval is how often the runs older than runHistoryTTL are deleted.
+	runHistoryPruneInterval = time.Hour
+	// DefaultRunsLimit is the number of the latest runs Runs returns without a limit.
+	DefaultRunsLimit = 20
+	// maxRunsLimit caps the limit of Runs.
+	maxRunsLimit = 1000
+)
+
+var errRunNotFound = errors.New("cron job run not found")
+
+type RunOutcome string
+
+const (
+	// RunOutcomePublished is a run whose task hasn't finished yet.
+	RunOutcomePublished RunOutcome = "published"
+	RunOutcomeCompleted RunOutcome = "completed"
+	RunOutcomeFailed    RunOutcome = "failed"
+	// RunOutcomeSkipped records the runs dropped by the misfire policy when nothing fired.
+	RunOutcomeSkipped RunOutcome = "skipped"
+	// RunOutcomeDeduplicated is a run whose task was skipped by its dedup key, the task of an earlier run
+	// being still queued. The run has no task.
+	RunOutcomeDeduplicated RunOutcome = "deduplicated"
+)
+
+// CronJobRun is a firing of a job with the task it produced and its outcome.
+type CronJobRun struct {
+	ScheduledAt time.Time  `gorm:"not null"`
+	FiredAt     time.Time  `gorm:"not null;index:idx_cron_run_job,priority:2;index:idx_cron_run_fired"`
+	UpdatedAt   time.Time  `gorm:"not null"`
+	JobName     string     `gorm:"not null;size:255;index:idx_cron_run_job,priority:1"`
+	Outcome     RunOutcome `gorm:"not null;size:16"`
+	// Missed is the number of the runs dropped by the misfire policy before this one.
+	Missed int            `gorm:"not null;default:0"`
+	TaskID utils.UniqueID `gorm:"type:uuid;index"`
+	ID     utils.UniqueID `gorm:"primaryKey;type:uuid"`
+	// Manual is a run triggered by TriggerJob.
+	Manual bool `gorm:"not null;default:false"`
+}
+
+func newCronJobRun(jobName string, scheduledAt, firedAt time.Time) CronJobRun {
+	return CronJobRun{
+		ID:          utils.NewUniqueID(),
+		JobName:     jobName,
+		ScheduledAt: scheduledAt,
+		FiredAt:     firedAt,
+		Outcome:     RunOutcomePublished,
+	}
+}
+
+type runOutcomePayload struct {
+	Outcome RunOutcome     `json:"outcome"`
+	RunID   utils.UniqueID `json:"run_id"`
+}
+
+// outcomeTask is the continuation of the task of the run that writes the outcome.
+func (r CronJobRun) outcomeTask(outcome RunOutcome) queue.Task {
+	payload, _ := json.Marshal(runOutcomePayload{RunID: r.ID, Outcome: outcome})
+	return queue.NewTask(RunOutcomeSubject, payload, time.Time{}, 0, 0)
+}
+
+// RunOutcomeHandler writes the outcome of the finished or skipped task to its run. A run that isn't found
+// is retried, the task may finish before the run is committed.
+func (s *Scheduler) RunOutcomeHandler() queue.Handler {
+	return func(ctx context.Context, data []byte) error {
+		var payload runOutcomePayload
+		if err := json.Unmarshal(data, &payload); err != nil {
+			return fmt.Errorf("failed to unmarshal run outcome: %w", err)
+		}
+		run := CronJobRun{Outcome: payload.Outcome, UpdatedAt: time.Now()}
+		columns := []string{"outcome", "updated_at"}
+		// The skipped task was never queued, the run keeps no ID of it
+		if payload.Outcome == RunOutcomeDeduplicated {
+			columns = append(columns, "task_id")
+		}
+		result := s.db.WithContext(ctx).Model(&CronJobRun{}).
+			Where("id = ?", payload.RunID).
+			Select(columns).
+			Updates(&run)
+		if result.Error != nil {
+			return fmt.Errorf("failed to update run outcome: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return errRunNotFound
+		}
+		return nil
+	}
+}
+
+// Runs returns the latest runs of the job, the newest first. The history is kept for a week.
+func (s *Scheduler) Runs(ctx context.Context, name string, limit int) ([]CronJobRun, error) {
+	if limit <= 0 {
+		limit = DefaultRunsLimit
+	}
+	limit = min(limit, maxRunsLimit)
+	runs := make([]CronJobRun, 0, limit)
+	err := s.db.WithContext(ctx).
+		Where("job_name = ?", name).
+		Order("fired_at DESC").
+		Order("scheduled_at DESC").
+		Limit(limit).
+		Find(&runs).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find runs of cron job %s: %w", name, err)
+	}
+	return runs, nil
+}
+
+// pruneRuns deletes the runs older than runHistoryTTL.
+func (s *Scheduler) pruneRuns(ctx context.Context) error {
+	err := s.db.WithContext(ctx).
+		Where("fired_at < ?", time.Now().Add(-runHistoryTTL)).
+		Delete(&CronJobRun{}).Error
+	if err != nil {
+		return fmt.Errorf("failed to prune cron job runs: %w", err)
+	}
+	return nil
+}
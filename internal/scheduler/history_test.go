@@ -0,0 +1,108 @@
+package scheduler
+
+import (
+	"context"
+	"microgame-bot/internal/queue"
+	"microgame-bot/internal/utils"
+	"net/url"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// postgresEnv is the database of the queue conformance suite, the test is skipped without it.
+const postgresEnv = "QUEUE_TEST_POSTGRES_URL"
+
+// testSchema keeps the tables of the test apart from the ones the queue tests truncate in parallel.
+const testSchema = "scheduler_test"
+
+func TestProcessCronJobs_DeduplicatedRun(t *testing.T) {
+	db := openPostgres(t)
+	q := queue.New(db, 2)
+	s := New(db, 10, q, time.Second)
+
+	slot := time.Now().Truncate(time.Hour)
+	job := CronJob{
+		ID:         utils.NewUniqueID(),
+		Name:       "dedup",
+		Expression: "0 0 * * * *",
+		Timezone:   "UTC",
+		Subject:    "scheduler.test",
+		Payload:    []byte(`{}`),
+		Status:     CronJobStatusActive,
+		Misfire:    MisfireOnce,
+		NextRunAt:  slot,
+	}
+	require.NoError(t, db.Create(&job).Error)
+
+	// The slot fires again while the task of the first run is still pending
+	require.NoError(t, s.processCronJobs(t.Context()))
+	require.NoError(t, db.Model(&CronJob{}).Where("id = ?", job.ID).Update("next_run_at", slot).Error)
+	require.NoError(t, s.processCronJobs(t.Context()))
+
+	q.Register("scheduler.test", func(context.Context, []byte) error { return nil })
+	q.Register(RunOutcomeSubject, s.RunOutcomeHandler())
+	ctx, cancel := context.WithCancel(t.Context())
+	q.Start(ctx)
+	t.Cleanup(func() {
+		cancel()
+		_ = q.Stop(context.Background())
+	})
+
+	var runs []CronJobRun
+	require.Eventually(t, func() bool {
+		var err error
+		runs, err = s.Runs(t.Context(), job.Name, 0)
+		return err == nil && len(runs) == 2 &&
+			runs[0].Outcome != RunOutcomePublished && runs[1].Outcome != RunOutcomePublished
+	}, 10*time.Second, 50*time.Millisecond)
+
+	// The newest run first
+	assert.Equal(t, RunOutcomeDeduplicated, runs[0].Outcome)
+	assert.True(t, runs[0].TaskID.IsZero())
+	assert.Equal(t, RunOutcomeCompleted, runs[1].Outcome)
+	require.False(t, runs[1].TaskID.IsZero())
+
+	// Both runs are of the slot, only the first one queued a task
+	var tasks int64
+	require.NoError(t, db.Model(&queue.Task{}).Where("subject = ?", job.Subject).Count(&tasks).Error)
+	assert.Equal(t, int64(1), tasks)
+	assert.WithinDuration(t, slot, runs[0].ScheduledAt, 0)
+	assert.WithinDuration(t, slot, runs[1].ScheduledAt, 0)
+}
+
+func openPostgres(t *testing.T) *gorm.DB {
+	t.Helper()
+	dsn := os.Getenv(postgresEnv)
+	if dsn == "" {
+		t.Skip(postgresEnv + " is not set, see the task queue section of README.md")
+	}
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Discard})
+	require.NoError(t, err)
+	require.NoError(t, db.Exec("CREATE SCHEMA IF NOT EXISTS "+testSchema).Error)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	require.NoError(t, sqlDB.Close())
+
+	u, err := url.Parse(dsn)
+	require.NoError(t, err)
+	params := u.Query()
+	params.Set("search_path", testSchema)
+	u.RawQuery = params.Encode()
+
+	db, err = gorm.Open(postgres.Open(u.String()), &gorm.Config{Logger: logger.Discard})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&CronJob{}, &CronJobRun{}, &queue.Task{}, &queue.TaskGroup{}))
+	require.NoError(t, queue.DropStaleIndexes(db))
+	require.NoError(t, db.Exec("TRUNCATE cron_jobs, cron_job_runs, tasks, task_groups").Error)
+	sqlDB, err = db.DB()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+	return db
+}
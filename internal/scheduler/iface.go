@@ -8,3 +8,15 @@ type IScheduler interface {
 	Stop(ctx context.Context) error
 	IsHealthy() bool
 }
+
+// IJobs manages the jobs of the scheduler at runtime, the jobs are addressed by name.
+type IJobs interface {
+	AddJob(ctx context.Context, job CronJob) (CronJob, error)
+	Jobs(ctx context.Context) ([]CronJob, error)
+	Job(ctx context.Context, name string) (CronJob, error)
+	PauseJob(ctx context.Context, name string) (CronJob, error)
+	ResumeJob(ctx context.Context, name string) (CronJob, error)
+	TriggerJob(ctx context.Context, name string) (CronJob, error)
+	DeleteJob(ctx context.Context, name string) error
+	Runs(ctx context.Context, name string, limit int) ([]CronJobRun, error)
+}
@@ -0,0 +1,162 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"microgame-bot/internal/queue"
+	"microgame-bot/internal/utils"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrJobNotFound = errors.New("cron job not found")
+	ErrJobExists   = errors.New("cron job already exists")
+	ErrInvalidJob  = errors.New("invalid cron job")
+)
+
+// AddJob creates the job at runtime. A recurring job runs by its expression from now on,
+// a one-shot job runs once at its NextRunAt.
+func (s *Scheduler) AddJob(ctx context.Context, job CronJob) (CronJob, error) {
+	const operationName = "scheduler::AddJob"
+	if job.Name == "" || job.Subject == "" {
+		return CronJob{}, fmt.Errorf("%w: name and subject are required", ErrInvalidJob)
+	}
+	if job.IsOneShot() && job.NextRunAt.IsZero() {
+		return CronJob{}, fmt.Errorf("%w: one-shot job needs the run time", ErrInvalidJob)
+	}
+	if err := job.validateSchedule(); err != nil {
+		return CronJob{}, fmt.Errorf("%w: %w", ErrInvalidJob, err)
+	}
+	if job.Misfire == "" {
+		job.Misfire = MisfireOnce
+	}
+	if !job.IsOneShot() {
+		nextRunAt, err := job.nextRun(time.Now())
+		if err != nil {
+			return CronJob{}, fmt.Errorf("%w: %w", ErrInvalidJob, err)
+		}
+		job.NextRunAt = nextRunAt
+	}
+	if job.ID.IsZero() {
+		job.ID = utils.NewUniqueID()
+	}
+	if job.Status == "" {
+		job.Status = CronJobStatusActive
+	}
+	if job.Payload == nil {
+		job.Payload = queue.EmptyPayload
+	}
+
+	result := s.db.WithContext(ctx).
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "name"}}, DoNothing: true}).
+		Create(&job)
+	if result.Error != nil {
+		return CronJob{}, fmt.Errorf("failed to create cron job in %s: %w", operationName, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return CronJob{}, ErrJobExists
+	}
+	return job, nil
+}
+
+// Jobs lists the jobs by name with their last and next runs.
+func (s *Scheduler) Jobs(ctx context.Context) ([]CronJob, error) {
+	return gorm.G[CronJob](s.db).Order("name").Find(ctx)
+}
+
+// Job returns the job by name.
+func (s *Scheduler) Job(ctx context.Context, name string) (CronJob, error) {
+	job, err := gorm.G[CronJob](s.db).Where("name = ?", name).First(ctx)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return CronJob{}, ErrJobNotFound
+	}
+	return job, err
+}
+
+// PauseJob stops the active job from running until it is resumed, other jobs are returned as they are.
+func (s *Scheduler) PauseJob(ctx context.Context, name string) (CronJob, error) {
+	return s.updateJob(ctx, name, func(_ *gorm.DB, job *CronJob) error {
+		if job.Status == CronJobStatusActive {
+			job.Status = CronJobStatusPaused
+		}
+		return nil
+	})
+}
+
+// ResumeJob activates the paused job. The runs missed while it was paused are skipped,
+// a one-shot job that is overdue runs right away.
+func (s *Scheduler) ResumeJob(ctx context.Context, name string) (CronJob, error) {
+	return s.updateJob(ctx, name, func(_ *gorm.DB, job *CronJob) error {
+		if job.Status != CronJobStatusPaused {
+			return nil
+		}
+		job.Status = CronJobStatusActive
+		if job.IsOneShot() {
+			return nil
+		}
+		nextRunAt, err := job.nextRun(time.Now())
+		job.NextRunAt = nextRunAt
+		return err
+	})
+}
+
+// TriggerJob publishes the task of the job right away, whatever its status. The schedule of a recurring job
+// is kept, a one-shot job is done. The run is recorded as manual, it runs even while a scheduled run is pending.
+func (s *Scheduler) TriggerJob(ctx context.Context, name string) (CronJob, error) {
+	return s.updateJob(ctx, name, func(tx *gorm.DB, job *CronJob) error {
+		now := time.Now()
+		job.LastRunAt = now
+		if job.IsOneShot() {
+			job.Status = CronJobStatusDone
+		}
+		run := newCronJobRun(job.Name, now, now)
+		run.Manual = true
+		task := job.task(run)
+		run.TaskID = task.ID
+		if err := tx.Create(&run).Error; err != nil {
+			return fmt.Errorf("failed to record cron job run: %w", err)
+		}
+		return s.qp.Outbox(tx).Publish(ctx, []queue.Task{task})
+	})
+}
+
+// DeleteJob deletes the job. The jobs declared on start are created again by CreateOrUpdateCronJobs.
+func (s *Scheduler) DeleteJob(ctx context.Context, name string) error {
+	const operationName = "scheduler::DeleteJob"
+	result := s.db.WithContext(ctx).Where("name = ?", name).Delete(&CronJob{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete cron job in %s: %w", operationName, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrJobNotFound
+	}
+	return nil
+}
+
+// updateJob locks the job by name and saves the changes of update.
+func (s *Scheduler) updateJob(
+	ctx context.Context,
+	name string,
+	update func(tx *gorm.DB, job *CronJob) error,
+) (CronJob, error) {
+	var job CronJob
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		job, err = gorm.G[CronJob](tx, clause.Locking{Strength: "UPDATE"}).Where("name = ?", name).First(ctx)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrJobNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if err := update(tx, &job); err != nil {
+			return err
+		}
+		return tx.Save(&job).Error
+	})
+	return job, err
+}
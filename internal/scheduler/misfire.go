@@ -0,0 +1,160 @@
+package scheduler
+
+import (
+	"fmt"
+	"time"
+	// The timezones of the jobs don't depend on the zoneinfo of the host.
+	_ "time/tzdata"
+
+	"github.com/robfig/cron/v3"
+)
+
+// MisfirePolicy tells what a job does with the runs missed while the scheduler was down.
+type MisfirePolicy string
+
+const (
+	// MisfireOnce collapses the missed runs into one run, it is the default.
+	MisfireOnce MisfirePolicy = "once"
+	// MisfireAll fires every missed run, up to MisfireLimit latest ones.
+	MisfireAll MisfirePolicy = "all"
+	// MisfireSkip drops the missed runs, the job waits for its next run.
+	MisfireSkip MisfirePolicy = "skip"
+)
+
+const (
+	// misfireThreshold is the delay after which a run counts as missed rather than late.
+	misfireThreshold = time.Minute
+	// defaultMisfireLimit is the number of the missed runs MisfireAll fires without MisfireLimit.
+	defaultMisfireLimit = 10
+	// maxMisfireScan bounds the runs counted after a long downtime of a frequent job,
+	// the scan then jumps to the recent runs and the missed count is approximate.
+	maxMisfireScan = 100000
+)
+
+func (p MisfirePolicy) Validate() error {
+	switch p {
+	case "", MisfireOnce, MisfireAll, MisfireSkip:
+		return nil
+	default:
+		return fmt.Errorf("unknown misfire policy %q", p)
+	}
+}
+
+// validateSchedule checks the timezone and the misfire policy of the job.
+func (j CronJob) validateSchedule() error {
+	if err := j.Misfire.Validate(); err != nil {
+		return err
+	}
+	if j.MisfireLimit < 0 {
+		return fmt.Errorf("negative misfire limit %d", j.MisfireLimit)
+	}
+	if _, err := j.location(); err != nil {
+		return fmt.Errorf("failed to load timezone: %w", err)
+	}
+	return nil
+}
+
+// location is the timezone of the cron expression of the job, the server one if the job has none.
+func (j CronJob) location() (*time.Location, error) {
+	if j.Timezone == "" {
+		return time.Local, nil
+	}
+	return time.LoadLocation(j.Timezone)
+}
+
+// schedule parses the cron expression of the job along with its timezone.
+func (j CronJob) schedule() (cron.Schedule, *time.Location, error) {
+	loc, err := j.location()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load timezone: %w", err)
+	}
+	parser := cron.NewParser(cronParserPattern)
+	schedule, err := parser.Parse(j.Expression.String())
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse cron expression: %w", err)
+	}
+	return schedule, loc, nil
+}
+
+// nextRun is the run of the job after from in the timezone of the job.
+func (j CronJob) nextRun(from time.Time) (time.Time, error) {
+	schedule, loc, err := j.schedule()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return schedule.Next(from.In(loc)), nil
+}
+
+// runPlan is what a due job does at a poll.
+type runPlan struct {
+	// next is the next run of a recurring job, zero for a one-shot job
+	next time.Time
+	// fire holds the scheduled times of the runs to fire, the oldest first
+	fire []time.Time
+	// missed is the number of the due runs that aren't fired
+	missed int
+}
+
+// plan applies the misfire policy of the due job to the runs scheduled from its NextRunAt up to now.
+// A job that has never been scheduled is due once at now.
+func (j CronJob) plan(now time.Time) (runPlan, error) {
+	var plan runPlan
+	var due []time.Time
+	var count int
+	limit := 1
+	if j.Misfire == MisfireAll {
+		limit = j.MisfireLimit
+		if limit <= 0 {
+			limit = defaultMisfireLimit
+		}
+	}
+	keep := func(at time.Time) {
+		count++
+		due = append(due, at)
+		if len(due) > limit {
+			due = due[1:]
+		}
+	}
+
+	var schedule cron.Schedule
+	loc := time.Local
+	if !j.IsOneShot() {
+		var err error
+		if schedule, loc, err = j.schedule(); err != nil {
+			return runPlan{}, err
+		}
+		plan.next = schedule.Next(now.In(loc))
+	}
+
+	switch {
+	case j.NextRunAt.IsZero():
+		keep(now)
+	case j.IsOneShot():
+		keep(j.NextRunAt)
+	default:
+		for at := j.NextRunAt.In(loc); !at.After(now); {
+			keep(at)
+			next := schedule.Next(at)
+			if count%maxMisfireScan == 0 {
+				if recent := schedule.Next(now.Add(-misfireThreshold).In(loc)); recent.After(next) {
+					next = recent
+				}
+			}
+			at = next
+		}
+	}
+	if count == 0 {
+		return plan, nil
+	}
+
+	plan.fire = due
+	if j.Misfire == MisfireSkip {
+		latest := due[len(due)-1]
+		plan.fire = nil
+		if now.Sub(latest) <= misfireThreshold {
+			plan.fire = due[len(due)-1:]
+		}
+	}
+	plan.missed = count - len(plan.fire)
+	return plan, nil
+}
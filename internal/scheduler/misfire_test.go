@@ -0,0 +1,173 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCronJob_Plan(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 30, 0, time.UTC)
+	hourly := CronJob{Expression: "0 0 * * * *", Timezone: "UTC"}
+	fiveHoursAgo := time.Date(2026, 3, 10, 7, 0, 0, 0, time.UTC)
+	nextHour := time.Date(2026, 3, 10, 13, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		job    func(CronJob) CronJob
+		fire   []time.Time
+		missed int
+	}{
+		{
+			name: "once collapses the missed runs into the latest",
+			job: func(j CronJob) CronJob {
+				j.NextRunAt = fiveHoursAgo
+				return j
+			},
+			fire:   []time.Time{time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)},
+			missed: 5,
+		},
+		{
+			name: "all fires the latest missed runs up to the limit",
+			job: func(j CronJob) CronJob {
+				j.NextRunAt = fiveHoursAgo
+				j.Misfire = MisfireAll
+				j.MisfireLimit = 3
+				return j
+			},
+			fire: []time.Time{
+				time.Date(2026, 3, 10, 10, 0, 0, 0, time.UTC),
+				time.Date(2026, 3, 10, 11, 0, 0, 0, time.UTC),
+				time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC),
+			},
+			missed: 3,
+		},
+		{
+			name: "skip fires the run that is only late",
+			job: func(j CronJob) CronJob {
+				j.NextRunAt = fiveHoursAgo
+				j.Misfire = MisfireSkip
+				return j
+			},
+			fire:   []time.Time{time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)},
+			missed: 5,
+		},
+		{
+			name: "skip drops the missed runs",
+			job: func(j CronJob) CronJob {
+				j.Expression = "0 30 * * * *"
+				j.NextRunAt = time.Date(2026, 3, 10, 9, 30, 0, 0, time.UTC)
+				j.Misfire = MisfireSkip
+				return j
+			},
+			missed: 3,
+		},
+		{
+			name: "on time run fires once",
+			job: func(j CronJob) CronJob {
+				j.NextRunAt = time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+				j.Misfire = MisfireAll
+				return j
+			},
+			fire: []time.Time{time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)},
+		},
+		{
+			name: "new job runs now",
+			job: func(j CronJob) CronJob {
+				return j
+			},
+			fire: []time.Time{now},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := tt.job(hourly)
+			plan, err := job.plan(now)
+			require.NoError(t, err)
+			assert.Equal(t, tt.fire, plan.fire)
+			assert.Equal(t, tt.missed, plan.missed)
+			if job.Expression == hourly.Expression {
+				assert.True(t, nextHour.Equal(plan.next), plan.next)
+			}
+		})
+	}
+}
+
+func TestCronJob_PlanOneShot(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	job := OneShotJob("promo", "promo.start", nil, now.Add(-time.Hour))
+
+	plan, err := job.plan(now)
+	require.NoError(t, err)
+	assert.Equal(t, []time.Time{now.Add(-time.Hour)}, plan.fire)
+	assert.True(t, plan.next.IsZero())
+
+	job.Misfire = MisfireSkip
+	plan, err = job.plan(now)
+	require.NoError(t, err)
+	assert.Empty(t, plan.fire)
+	assert.Equal(t, 1, plan.missed)
+}
+
+func TestCronJob_PlanLongDowntime(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	job := CronJob{
+		Expression:   "* * * * * *",
+		Timezone:     "UTC",
+		NextRunAt:    now.Add(-72 * time.Hour),
+		Misfire:      MisfireAll,
+		MisfireLimit: 2,
+	}
+
+	plan, err := job.plan(now)
+	require.NoError(t, err)
+	assert.Equal(t, []time.Time{now.Add(-time.Second), now}, plan.fire)
+	assert.Positive(t, plan.missed)
+	assert.True(t, now.Add(time.Second).Equal(plan.next))
+}
+
+func TestCronJob_NextRunTimezone(t *testing.T) {
+	from := time.Date(2026, 3, 10, 10, 0, 0, 0, time.UTC)
+	job := CronJob{Expression: "0 0 12 * * *", Timezone: "Asia/Tokyo"}
+
+	next, err := job.nextRun(from)
+	require.NoError(t, err)
+	// Noon in Tokyo is 03:00 UTC
+	assert.True(t, time.Date(2026, 3, 11, 3, 0, 0, 0, time.UTC).Equal(next), next)
+
+	job.Timezone = "Mars/Olympus"
+	_, err = job.nextRun(from)
+	require.Error(t, err)
+	require.Error(t, job.validateSchedule())
+}
+
+func TestCronJob_ValidateSchedule(t *testing.T) {
+	require.NoError(t, CronJob{}.validateSchedule())
+	require.NoError(t, CronJob{Misfire: MisfireAll, MisfireLimit: 5, Timezone: "Europe/Moscow"}.validateSchedule())
+	require.Error(t, CronJob{Misfire: "sometimes"}.validateSchedule())
+	require.Error(t, CronJob{Misfire: MisfireAll, MisfireLimit: -1}.validateSchedule())
+}
+
+func TestCronJob_TaskDedupKey(t *testing.T) {
+	job := CronJob{Subject: "bets.payout", Payload: []byte(`{}`), Misfire: MisfireAll}
+	first := newCronJobRun("bets-payout", time.Date(2026, 3, 10, 11, 0, 0, 0, time.UTC), time.Now())
+	second := newCronJobRun("bets-payout", time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC), time.Now())
+	assert.NotEqual(t, job.task(first).DedupKey, job.task(second).DedupKey)
+
+	job.Misfire = MisfireOnce
+	assert.Equal(t, job.task(first).DedupKey, job.task(second).DedupKey)
+
+	manual := newCronJobRun("bets-payout", time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC), time.Now())
+	manual.Manual = true
+	assert.NotEqual(t, job.task(second).DedupKey, job.task(manual).DedupKey)
+
+	task := job.task(first)
+	require.Len(t, task.OnSuccess, 1)
+	require.Len(t, task.OnFailure, 1)
+	require.Len(t, task.OnSkip, 1)
+	assert.Equal(t, RunOutcomeSubject, task.OnSuccess[0].Subject)
+	assert.Contains(t, string(task.OnFailure[0].Payload), `"outcome":"failed"`)
+	assert.Contains(t, string(task.OnSkip[0].Payload), `"outcome":"deduplicated"`)
+}
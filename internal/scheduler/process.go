@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log/slog"
+	"microgame-bot/internal/core/logger"
 	"microgame-bot/internal/queue"
 	"time"
 
@@ -13,6 +15,7 @@ import (
 
 func (s *Scheduler) processCronJobs(ctx context.Context) error {
 	const operationName = "scheduler::processCronJobs"
+	l := slog.With(slog.String(logger.OperationField, operationName))
 
 	err := s.db.Transaction(func(tx *gorm.DB) error {
 		now := time.Now()
@@ -38,27 +41,55 @@ func (s *Scheduler) processCronJobs(ctx context.Context) error {
 			return nil
 		}
 
-		// First: update NextRunAt and LastRunAt
+		// First: apply the misfire policies, update NextRunAt and LastRunAt, one-shot jobs are done
+		runs := make([]CronJobRun, 0, len(cronJobs))
+		tasks := make([]queue.Task, 0, len(cronJobs))
 		for i := range cronJobs {
-			cronJobs[i].LastRunAt = now
-			nextRunAt, err := s.calculateNextRun(cronJobs[i].Expression, now)
+			job := &cronJobs[i]
+			plan, err := job.plan(now)
 			if err != nil {
-				return fmt.Errorf("failed to calculate next run for job %s: %w", cronJobs[i].Name, err)
+				return fmt.Errorf("failed to plan runs for job %s: %w", job.Name, err)
+			}
+			if plan.missed > 0 {
+				l.WarnContext(ctx, "Cron job missed runs",
+					"job", job.Name, "missed", plan.missed, "fired", len(plan.fire), "misfire", job.Misfire)
+			}
+			if len(plan.fire) == 0 {
+				run := newCronJobRun(job.Name, job.NextRunAt, now)
+				run.Outcome = RunOutcomeSkipped
+				run.Missed = plan.missed
+				runs = append(runs, run)
+			}
+			for k, scheduledAt := range plan.fire {
+				run := newCronJobRun(job.Name, scheduledAt, now)
+				if k == len(plan.fire)-1 {
+					run.Missed = plan.missed
+				}
+				task := job.task(run)
+				run.TaskID = task.ID
+				runs = append(runs, run)
+				tasks = append(tasks, task)
+				job.LastRunAt = now
+			}
+			if job.IsOneShot() {
+				job.Status = CronJobStatusDone
+				continue
 			}
-			cronJobs[i].NextRunAt = nextRunAt
+			job.NextRunAt = plan.next
 		}
 
 		if err := tx.Save(&cronJobs).Error; err != nil {
 			return fmt.Errorf("failed to update cron jobs: %w", err)
 		}
-
-		// Second: publish tasks after successful update
-		tasks := make([]queue.Task, 0, len(cronJobs))
-		for _, cronJob := range cronJobs {
-			tasks = append(tasks, queue.NewTask(cronJob.Subject, cronJob.Payload, time.Time{}, 0, cronJob.TaskTimeout))
+		if err := tx.Create(&runs).Error; err != nil {
+			return fmt.Errorf("failed to record cron job runs: %w", err)
+		}
+		if len(tasks) == 0 {
+			return nil
 		}
 
-		if err := s.qp.Publish(ctx, tasks); err != nil {
+		// Second: publish tasks through the outbox, they are queued only if the update commits
+		if err := s.qp.Outbox(tx).Publish(ctx, tasks); err != nil {
 			return fmt.Errorf("failed to publish cron jobs: %w", err)
 		}
 
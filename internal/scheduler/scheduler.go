@@ -11,7 +11,6 @@ import (
 	"sync"
 	"time"
 
-	"github.com/robfig/cron/v3"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
@@ -19,7 +18,7 @@ import (
 // Scheduler is a cron scheduler that can be used to schedule jobs.
 // It uses GORM as a storage for cron jobs.
 type Scheduler struct {
-	qp           queue.IQueuePublisher
+	qp           queue.IOutbox
 	db           *gorm.DB
 	stopCh       chan struct{}
 	wg           sync.WaitGroup
@@ -29,7 +28,7 @@ type Scheduler struct {
 	running      bool
 }
 
-func New(db *gorm.DB, batchSize int, qp queue.IQueuePublisher, pollInterval time.Duration) *Scheduler {
+func New(db *gorm.DB, batchSize int, qp queue.IOutbox, pollInterval time.Duration) *Scheduler {
 	return &Scheduler{
 		db:           db,
 		batchSize:    batchSize,
@@ -47,14 +46,29 @@ func (s *Scheduler) CreateOrUpdateCronJobs(ctx context.Context, jobs []CronJob)
 		if err != nil {
 			return fmt.Errorf("failed to validate cron expression in %s: %w", operationName, err)
 		}
+		if err := j.validateSchedule(); err != nil {
+			return fmt.Errorf("failed to validate schedule of cron job %s in %s: %w", j.Name, operationName, err)
+		}
 		if j.ID.IsZero() {
 			j.ID = utils.NewUniqueID()
-			jobs[i] = j
 		}
+		if j.Misfire == "" {
+			j.Misfire = MisfireOnce
+		}
+		jobs[i] = j
+	}
+	// A job paused at runtime stays paused
+	keepPaused := clause.Assignment{
+		Column: clause.Column{Name: "status"},
+		Value: gorm.Expr("CASE WHEN cron_jobs.status = ? THEN cron_jobs.status ELSE excluded.status END",
+			CronJobStatusPaused),
 	}
+	updates := clause.AssignmentColumns([]string{
+		"expression", "subject", "payload", "timezone", "misfire", "misfire_limit",
+	})
 	err := s.db.Clauses(clause.OnConflict{
 		Columns:   []clause.Column{{Name: "name"}},
-		DoUpdates: clause.AssignmentColumns([]string{"expression", "status", "subject", "payload"}),
+		DoUpdates: append(updates, keepPaused),
 	}).Create(&jobs).Error
 	if err != nil {
 		return fmt.Errorf("failed to create or update cron job in %s: %w", operationName, err)
@@ -104,6 +118,8 @@ func (s *Scheduler) Start(ctx context.Context) error {
 
 		ticker := time.NewTicker(s.pollInterval)
 		defer ticker.Stop()
+		pruneTicker := time.NewTicker(runHistoryPruneInterval)
+		defer pruneTicker.Stop()
 
 		for {
 			select {
@@ -117,6 +133,10 @@ func (s *Scheduler) Start(ctx context.Context) error {
 				if err := s.processCronJobs(ctx); err != nil {
 					l.ErrorContext(ctx, "Failed to process cron jobs", logger.ErrorField, err.Error())
 				}
+			case <-pruneTicker.C:
+				if err := s.pruneRuns(ctx); err != nil {
+					l.ErrorContext(ctx, "Failed to prune cron job runs", logger.ErrorField, err.Error())
+				}
 			}
 		}
 	})
@@ -124,15 +144,6 @@ func (s *Scheduler) Start(ctx context.Context) error {
 	return nil
 }
 
-func (s *Scheduler) calculateNextRun(expression CronExpression, from time.Time) (time.Time, error) {
-	parser := cron.NewParser(cronParserPattern)
-	schedule, err := parser.Parse(expression.String())
-	if err != nil {
-		return time.Time{}, fmt.Errorf("failed to parse cron expression: %w", err)
-	}
-	return schedule.Next(from), nil
-}
-
 // IsHealthy returns true if scheduler is running.
 func (s *Scheduler) IsHealthy() bool {
 	s.mu.Lock()
@@ -3,11 +3,24 @@ package uow
 import (
 	"context"
 	"fmt"
+	"microgame-bot/internal/queue"
 	"microgame-bot/internal/repo/bet"
 	"microgame-bot/internal/repo/claim"
+	"microgame-bot/internal/repo/game/battleship"
+	"microgame-bot/internal/repo/game/blackjack"
+	"microgame-bot/internal/repo/game/bullscows"
+	"microgame-bot/internal/repo/game/checkers"
+	"microgame-bot/internal/repo/game/dice"
+	"microgame-bot/internal/repo/game/hangman"
+	"microgame-bot/internal/repo/game/reversi"
 	"microgame-bot/internal/repo/game/rps"
+	"microgame-bot/internal/repo/game/trivia"
 	"microgame-bot/internal/repo/game/ttt"
+	"microgame-bot/internal/repo/house"
+	"microgame-bot/internal/repo/league"
+	"microgame-bot/internal/repo/matchmaking"
 	"microgame-bot/internal/repo/session"
+	"microgame-bot/internal/repo/tournament"
 	"microgame-bot/internal/repo/user"
 )
 
@@ -25,6 +38,20 @@ type IUnitOfWork interface {
 	SessionRepo() (session.ISessionRepository, error)
 	TTTRepo() (ttt.ITTTRepository, error)
 	RPSRepo() (rps.IRPSRepository, error)
+	DiceRepo() (dice.IDiceRepository, error)
+	HangmanRepo() (hangman.IHangmanRepository, error)
+	ReversiRepo() (reversi.IReversiRepository, error)
+	CheckersRepo() (checkers.ICheckersRepository, error)
+	TriviaRepo() (trivia.ITriviaRepository, error)
+	BattleshipRepo() (battleship.IBattleshipRepository, error)
+	BullsCowsRepo() (bullscows.IBullsCowsRepository, error)
+	BlackjackRepo() (blackjack.IBlackjackRepository, error)
+	HouseRepo() (house.IAccountRepository, error)
 	ClaimRepo() (claim.IClaimRepository, error)
 	BetRepo() (bet.IBetRepository, error)
+	TournamentRepo() (tournament.ITournamentRepository, error)
+	LeagueRepo() (league.ILeagueRepository, error)
+	MatchmakingRepo() (matchmaking.ITicketRepository, error)
+	// Outbox publishes tasks through the transaction, they become visible on its commit.
+	Outbox() (queue.IQueuePublisher, error)
 }
@@ -3,11 +3,24 @@ package uow
 import (
 	"context"
 	"errors"
+	"microgame-bot/internal/queue"
 	"microgame-bot/internal/repo/bet"
 	"microgame-bot/internal/repo/claim"
+	"microgame-bot/internal/repo/game/battleship"
+	"microgame-bot/internal/repo/game/blackjack"
+	"microgame-bot/internal/repo/game/bullscows"
+	"microgame-bot/internal/repo/game/checkers"
+	"microgame-bot/internal/repo/game/dice"
+	"microgame-bot/internal/repo/game/hangman"
+	"microgame-bot/internal/repo/game/reversi"
 	"microgame-bot/internal/repo/game/rps"
+	"microgame-bot/internal/repo/game/trivia"
 	"microgame-bot/internal/repo/game/ttt"
+	"microgame-bot/internal/repo/house"
+	"microgame-bot/internal/repo/league"
+	"microgame-bot/internal/repo/matchmaking"
 	"microgame-bot/internal/repo/session"
+	"microgame-bot/internal/repo/tournament"
 	"microgame-bot/internal/repo/user"
 
 	"gorm.io/gorm"
@@ -21,8 +34,21 @@ type UnitOfWork struct {
 	tttRepo     ttt.ITTTRepository
 	sessionRepo session.ISessionRepository
 	rpsRepo     rps.IRPSRepository
+	diceRepo    dice.IDiceRepository
+	hmRepo      hangman.IHangmanRepository
+	rvRepo      reversi.IReversiRepository
+	ckRepo      checkers.ICheckersRepository
+	tvRepo      trivia.ITriviaRepository
+	bsRepo      battleship.IBattleshipRepository
+	bcRepo      bullscows.IBullsCowsRepository
+	bjRepo      blackjack.IBlackjackRepository
+	houseRepo   house.IAccountRepository
 	claimRepo   claim.IClaimRepository
 	betRepo     bet.IBetRepository
+	tourRepo    tournament.ITournamentRepository
+	leagueRepo  league.ILeagueRepository
+	ticketRepo  matchmaking.ITicketRepository
+	outbox      queue.IOutbox
 }
 
 // New creates a new unit of work instance.
@@ -40,7 +66,7 @@ func New(db *gorm.DB, opts ...UnitOfWorkOpt) *UnitOfWork {
 func (u *UnitOfWork) Do(_ context.Context, fn func(unit IUnitOfWork) error) error {
 	return u.db.Transaction(func(tx *gorm.DB) error {
 		//nolint:mnd // Repo count is constant.
-		opts := make([]UnitOfWorkOpt, 0, 6)
+		opts := make([]UnitOfWorkOpt, 0, 19)
 
 		if u.sessionRepo != nil {
 			opts = append(opts, WithSessionRepo(session.New(tx)))
@@ -51,6 +77,33 @@ func (u *UnitOfWork) Do(_ context.Context, fn func(unit IUnitOfWork) error) erro
 		if u.rpsRepo != nil {
 			opts = append(opts, WithRPSRepo(rps.New(tx)))
 		}
+		if u.diceRepo != nil {
+			opts = append(opts, WithDiceRepo(dice.New(tx)))
+		}
+		if u.hmRepo != nil {
+			opts = append(opts, WithHangmanRepo(hangman.New(tx)))
+		}
+		if u.rvRepo != nil {
+			opts = append(opts, WithReversiRepo(reversi.New(tx)))
+		}
+		if u.ckRepo != nil {
+			opts = append(opts, WithCheckersRepo(checkers.New(tx)))
+		}
+		if u.tvRepo != nil {
+			opts = append(opts, WithTriviaRepo(trivia.New(tx)))
+		}
+		if u.bsRepo != nil {
+			opts = append(opts, WithBattleshipRepo(battleship.New(tx)))
+		}
+		if u.bcRepo != nil {
+			opts = append(opts, WithBullsCowsRepo(bullscows.New(tx)))
+		}
+		if u.bjRepo != nil {
+			opts = append(opts, WithBlackjackRepo(blackjack.New(tx)))
+		}
+		if u.houseRepo != nil {
+			opts = append(opts, WithHouseRepo(house.New(tx)))
+		}
 		if u.userRepo != nil {
 			opts = append(opts, WithUserRepo(user.New(tx)))
 		}
@@ -60,6 +113,18 @@ func (u *UnitOfWork) Do(_ context.Context, fn func(unit IUnitOfWork) error) erro
 		if u.betRepo != nil {
 			opts = append(opts, WithBetRepo(bet.New(tx)))
 		}
+		if u.tourRepo != nil {
+			opts = append(opts, WithTournamentRepo(tournament.New(tx)))
+		}
+		if u.leagueRepo != nil {
+			opts = append(opts, WithLeagueRepo(league.New(tx)))
+		}
+		if u.ticketRepo != nil {
+			opts = append(opts, WithMatchmakingRepo(matchmaking.New(tx)))
+		}
+		if u.outbox != nil {
+			opts = append(opts, WithOutbox(u.outbox))
+		}
 
 		txUow := New(tx, opts...)
 		return fn(txUow)
@@ -94,6 +159,69 @@ func (u *UnitOfWork) RPSRepo() (rps.IRPSRepository, error) {
 	return u.rpsRepo, nil
 }
 
+func (u *UnitOfWork) DiceRepo() (dice.IDiceRepository, error) {
+	if u.diceRepo == nil {
+		return nil, errors.New("dice repository is not set")
+	}
+	return u.diceRepo, nil
+}
+
+func (u *UnitOfWork) HangmanRepo() (hangman.IHangmanRepository, error) {
+	if u.hmRepo == nil {
+		return nil, errors.New("hangman repository is not set")
+	}
+	return u.hmRepo, nil
+}
+
+func (u *UnitOfWork) ReversiRepo() (reversi.IReversiRepository, error) {
+	if u.rvRepo == nil {
+		return nil, errors.New("reversi repository is not set")
+	}
+	return u.rvRepo, nil
+}
+
+func (u *UnitOfWork) CheckersRepo() (checkers.ICheckersRepository, error) {
+	if u.ckRepo == nil {
+		return nil, errors.New("checkers repository is not set")
+	}
+	return u.ckRepo, nil
+}
+
+func (u *UnitOfWork) TriviaRepo() (trivia.ITriviaRepository, error) {
+	if u.tvRepo == nil {
+		return nil, errors.New("trivia repository is not set")
+	}
+	return u.tvRepo, nil
+}
+
+func (u *UnitOfWork) BattleshipRepo() (battleship.IBattleshipRepository, error) {
+	if u.bsRepo == nil {
+		return nil, errors.New("battleship repository is not set")
+	}
+	return u.bsRepo, nil
+}
+
+func (u *UnitOfWork) BullsCowsRepo() (bullscows.IBullsCowsRepository, error) {
+	if u.bcRepo == nil {
+		return nil, errors.New("bulls and cows repository is not set")
+	}
+	return u.bcRepo, nil
+}
+
+func (u *UnitOfWork) BlackjackRepo() (blackjack.IBlackjackRepository, error) {
+	if u.bjRepo == nil {
+		return nil, errors.New("blackjack repository is not set")
+	}
+	return u.bjRepo, nil
+}
+
+func (u *UnitOfWork) HouseRepo() (house.IAccountRepository, error) {
+	if u.houseRepo == nil {
+		return nil, errors.New("house repository is not set")
+	}
+	return u.houseRepo, nil
+}
+
 func (u *UnitOfWork) ClaimRepo() (claim.IClaimRepository, error) {
 	if u.claimRepo == nil {
 		return nil, errors.New("claim repository is not set")
@@ -108,6 +236,35 @@ func (u *UnitOfWork) BetRepo() (bet.IBetRepository, error) {
 	return u.betRepo, nil
 }
 
+func (u *UnitOfWork) TournamentRepo() (tournament.ITournamentRepository, error) {
+	if u.tourRepo == nil {
+		return nil, errors.New("tournament repository is not set")
+	}
+	return u.tourRepo, nil
+}
+
+func (u *UnitOfWork) LeagueRepo() (league.ILeagueRepository, error) {
+	if u.leagueRepo == nil {
+		return nil, errors.New("league repository is not set")
+	}
+	return u.leagueRepo, nil
+}
+
+func (u *UnitOfWork) MatchmakingRepo() (matchmaking.ITicketRepository, error) {
+	if u.ticketRepo == nil {
+		return nil, errors.New("matchmaking repository is not set")
+	}
+	return u.ticketRepo, nil
+}
+
+// Outbox publishes through the transaction of the unit, outside of Do every task is published on its own.
+func (u *UnitOfWork) Outbox() (queue.IQueuePublisher, error) {
+	if u.outbox == nil {
+		return nil, errors.New("task outbox is not set")
+	}
+	return u.outbox.Outbox(u.db), nil
+}
+
 type UnitOfWorkOpt func(*UnitOfWork)
 
 func WithUserRepo(userR user.IUserRepository) UnitOfWorkOpt {
@@ -134,6 +291,60 @@ func WithRPSRepo(rpsR rps.IRPSRepository) UnitOfWorkOpt {
 	}
 }
 
+func WithDiceRepo(diceR dice.IDiceRepository) UnitOfWorkOpt {
+	return func(u *UnitOfWork) {
+		u.diceRepo = diceR
+	}
+}
+
+func WithHangmanRepo(hmR hangman.IHangmanRepository) UnitOfWorkOpt {
+	return func(u *UnitOfWork) {
+		u.hmRepo = hmR
+	}
+}
+
+func WithReversiRepo(rvR reversi.IReversiRepository) UnitOfWorkOpt {
+	return func(u *UnitOfWork) {
+		u.rvRepo = rvR
+	}
+}
+
+func WithCheckersRepo(ckR checkers.ICheckersRepository) UnitOfWorkOpt {
+	return func(u *UnitOfWork) {
+		u.ckRepo = ckR
+	}
+}
+
+func WithTriviaRepo(tvR trivia.ITriviaRepository) UnitOfWorkOpt {
+	return func(u *UnitOfWork) {
+		u.tvRepo = tvR
+	}
+}
+
+func WithBattleshipRepo(bsR battleship.IBattleshipRepository) UnitOfWorkOpt {
+	return func(u *UnitOfWork) {
+		u.bsRepo = bsR
+	}
+}
+
+func WithBullsCowsRepo(bcR bullscows.IBullsCowsRepository) UnitOfWorkOpt {
+	return func(u *UnitOfWork) {
+		u.bcRepo = bcR
+	}
+}
+
+func WithBlackjackRepo(bjR blackjack.IBlackjackRepository) UnitOfWorkOpt {
+	return func(u *UnitOfWork) {
+		u.bjRepo = bjR
+	}
+}
+
+func WithHouseRepo(houseR house.IAccountRepository) UnitOfWorkOpt {
+	return func(u *UnitOfWork) {
+		u.houseRepo = houseR
+	}
+}
+
 func WithClaimRepo(claimR claim.IClaimRepository) UnitOfWorkOpt {
 	return func(u *UnitOfWork) {
 		u.claimRepo = claimR
@@ -145,3 +356,27 @@ func WithBetRepo(betR bet.IBetRepository) UnitOfWorkOpt {
 		u.betRepo = betR
 	}
 }
+
+func WithTournamentRepo(tourR tournament.ITournamentRepository) UnitOfWorkOpt {
+	return func(u *UnitOfWork) {
+		u.tourRepo = tourR
+	}
+}
+
+func WithLeagueRepo(leagueR league.ILeagueRepository) UnitOfWorkOpt {
+	return func(u *UnitOfWork) {
+		u.leagueRepo = leagueR
+	}
+}
+
+func WithMatchmakingRepo(ticketR matchmaking.ITicketRepository) UnitOfWorkOpt {
+	return func(u *UnitOfWork) {
+		u.ticketRepo = ticketR
+	}
+}
+
+func WithOutbox(outbox queue.IOutbox) UnitOfWorkOpt {
+	return func(u *UnitOfWork) {
+		u.outbox = outbox
+	}
+}
@@ -1,6 +1,10 @@
 package utils
 
 import (
+	cryptoRand "crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
 	"math/rand/v2"
 )
 
@@ -11,3 +15,34 @@ func RandInt(maxValue int) int {
 	}
 	return rand.IntN(maxValue)
 }
+
+// RandHex generates a cryptographically secure random hex string of n bytes.
+func RandHex(n int) string {
+	buf := make([]byte, n)
+	// crypto/rand.Read never returns an error.
+	_, _ = cryptoRand.Read(buf)
+	return hex.EncodeToString(buf)
+}
+
+// SHA256Hex returns hex encoded SHA-256 of the given parts joined with ":".
+func SHA256Hex(parts ...string) string {
+	h := sha256.New()
+	for i, part := range parts {
+		if i > 0 {
+			h.Write([]byte(":"))
+		}
+		h.Write([]byte(part))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// SeededRandInt deterministically derives an integer in range [0, max) from the seed and the key.
+// Anyone who knows the seed can recompute the result as first 8 bytes of SHA256(seed:key) modulo max.
+func SeededRandInt(seed string, key string, maxValue int) int {
+	if maxValue <= 0 {
+		return 0
+	}
+	sum := sha256.Sum256([]byte(seed + ":" + key))
+	//nolint:gosec // Result is always less than maxValue.
+	return int(binary.BigEndian.Uint64(sum[:8]) % uint64(maxValue))
+}